@@ -102,3 +102,21 @@ securityContext:
 {{- printf "%s/%s@%s" .Values.osm.image.registry .Values.osm.image.name.osmHealthcheck .Values.osm.image.digest.osmHealthcheck -}}
 {{- end -}}
 {{- end -}}
+
+{{/* osm-cert-agent image */}}
+{{- define "osmCertAgent.image" -}}
+{{- if .Values.osm.image.tag -}}
+{{- printf "%s/%s:%s" .Values.osm.image.registry .Values.osm.image.name.osmCertAgent .Values.osm.image.tag -}}
+{{- else -}}
+{{- printf "%s/%s@%s" .Values.osm.image.registry .Values.osm.image.name.osmCertAgent .Values.osm.image.digest.osmCertAgent -}}
+{{- end -}}
+{{- end -}}
+
+{{/* osm-cni image */}}
+{{- define "osmCNI.image" -}}
+{{- if .Values.osm.image.tag -}}
+{{- printf "%s/%s:%s" .Values.osm.image.registry .Values.osm.image.name.osmCNI .Values.osm.image.tag -}}
+{{- else -}}
+{{- printf "%s/%s@%s" .Values.osm.image.registry .Values.osm.image.name.osmCNI .Values.osm.image.digest.osmCNI -}}
+{{- end -}}
+{{- end -}}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+)
+
+const certificateCmdDescription = `
+This command consists of subcommands related to the certificates issued
+by the control plane to the proxies, ingress gateways and control plane
+components.
+
+The debug server must be enabled in the MeshConfig for these commands to work.
+`
+
+const (
+	issuedCertsPath  = "/debug/certs/issued"
+	inspectCertPath  = "/debug/certs/inspect"
+	rotateCertPath   = "/debug/certs/rotate"
+	certNameQueryKey = "name"
+)
+
+func newCertificateCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "certificate",
+		Aliases: []string{"cert"},
+		Short:   "certificate operations",
+		Long:    certificateCmdDescription,
+		Args:    cobra.NoArgs,
+	}
+	cmd.AddCommand(newCertificateListCmd(config, out))
+	cmd.AddCommand(newCertificateInspectCmd(config, out))
+	cmd.AddCommand(newCertificateRotateCmd(config, out))
+
+	return cmd
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const certificateInspectCmdDescription = `
+This command shows the details of a certificate issued by the control plane.
+The certificate is named by its key, such as the service identity of the form
+<service-account>.<namespace> for the certificates issued to the proxies, its
+common name or its serial number.
+`
+
+const certificateInspectCmdExample = `
+# Show the certificate issued to the proxies of the 'bookbuyer' service account in the 'bookbuyer' namespace
+osm certificate inspect bookbuyer.bookbuyer
+
+# Show the certificate with the given serial number, along with its PEM encoded certificate chain
+osm certificate inspect 286185934375217356283742380419219128474 --pem
+`
+
+type certificateInspectCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	name      string
+	pem       bool
+	localPort uint16
+}
+
+func newCertificateInspectCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	inspectCmd := &certificateInspectCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "inspect NAME",
+		Short: "show the details of a certificate issued by the control plane",
+		Long:  certificateInspectCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			inspectCmd.name = args[0]
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			inspectCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			inspectCmd.clientSet = clientset
+			return inspectCmd.run()
+		},
+		Example: certificateInspectCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.BoolVar(&inspectCmd.pem, "pem", false, "Print the PEM encoded certificate chain")
+	f.Uint16VarP(&inspectCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *certificateInspectCmd) run() error {
+	query := url.Values{}
+	query.Set(certNameQueryKey, cmd.name)
+	resp, err := cli.ExecuteControllerDebugReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, inspectCertPath+"?"+query.Encode())
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error inspecting certificate: %s", err)
+	}
+
+	var cert certificate.CertificateInfo
+	if err := json.Unmarshal(resp, &cert); err != nil {
+		return fmt.Errorf("Error decoding certificate: %w", err)
+	}
+
+	return cmd.printCertificate(cert)
+}
+
+// printCertificate prints the details of the given certificate, along with the fields of its leaf x509 certificate
+func (cmd *certificateInspectCmd) printCertificate(cert certificate.CertificateInfo) error {
+	w := newTabWriter(cmd.out)
+	fmt.Fprintf(w, "Key:\t%s\n", cert.Key)
+	fmt.Fprintf(w, "Common Name:\t%s\n", cert.CommonName)
+	fmt.Fprintf(w, "Serial Number:\t%s\n", cert.SerialNumber)
+	fmt.Fprintf(w, "Type:\t%s\n", cert.CertType)
+	fmt.Fprintf(w, "Signing Issuer:\t%s\n", cert.SigningIssuerID)
+	fmt.Fprintf(w, "Validating Issuer:\t%s\n", cert.ValidatingIssuerID)
+	if cert.KeyAlgorithm != "" {
+		fmt.Fprintf(w, "Key Algorithm:\t%s\n", cert.KeyAlgorithm)
+	}
+	if !cert.IssuedAt.IsZero() {
+		fmt.Fprintf(w, "Issued At:\t%s\n", cert.IssuedAt.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "Expires:\t%s\n", formatExpiration(cert.Expiration))
+
+	if cert.CertChain != "" {
+		x509Cert, err := certificate.DecodePEMCertificate(pem.Certificate(cert.CertChain))
+		if err != nil {
+			return fmt.Errorf("Error decoding the certificate chain: %w", err)
+		}
+
+		var uris []string
+		for _, uri := range x509Cert.URIs {
+			uris = append(uris, uri.String())
+		}
+
+		fmt.Fprintf(w, "Subject:\t%s\n", x509Cert.Subject)
+		fmt.Fprintf(w, "Issuer:\t%s\n", x509Cert.Issuer)
+		fmt.Fprintf(w, "Not Before:\t%s\n", x509Cert.NotBefore.UTC().Format(time.RFC3339))
+		fmt.Fprintf(w, "Not After:\t%s\n", x509Cert.NotAfter.UTC().Format(time.RFC3339))
+		fmt.Fprintf(w, "DNS Names:\t%s\n", strings.Join(x509Cert.DNSNames, ", "))
+		fmt.Fprintf(w, "URIs:\t%s\n", strings.Join(uris, ", "))
+		fmt.Fprintf(w, "Signature Algorithm:\t%s\n", x509Cert.SignatureAlgorithm)
+		fmt.Fprintf(w, "Public Key Algorithm:\t%s\n", x509Cert.PublicKeyAlgorithm)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if cmd.pem && cert.CertChain != "" {
+		fmt.Fprintf(cmd.out, "\n%s", cert.CertChain)
+	}
+	return nil
+}
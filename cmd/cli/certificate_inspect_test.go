@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+)
+
+func TestCertificateInspectPrintCertificate(t *testing.T) {
+	require := trequire.New(t)
+
+	cert, err := tresorFake.NewFake(time.Hour).IssueCertificate(certificate.ForServiceIdentity("bookbuyer.bookbuyer"))
+	require.NoError(err)
+	info := cert.GetInfo()
+	info.CertChain = string(cert.GetCertificateChain())
+
+	testCases := []struct {
+		name        string
+		info        certificate.CertificateInfo
+		pem         bool
+		contains    []string
+		notContains []string
+		expectedErr bool
+	}{
+		{
+			name: "certificate",
+			info: info,
+			contains: []string{
+				"Key:                    bookbuyer.bookbuyer\n",
+				"Common Name:            " + cert.GetCommonName().String() + "\n",
+				"Serial Number:          " + cert.GetSerialNumber().String() + "\n",
+				"Type:                   service\n",
+				"Subject:                CN=" + cert.GetCommonName().String() + ",",
+				"Public Key Algorithm:   ",
+			},
+			notContains: []string{"BEGIN CERTIFICATE"},
+		},
+		{
+			name:     "certificate with PEM",
+			info:     info,
+			pem:      true,
+			contains: []string{"Key:                    bookbuyer.bookbuyer\n", "\n-----BEGIN CERTIFICATE-----\n"},
+		},
+		{
+			name: "invalid certificate chain",
+			info: certificate.CertificateInfo{
+				Key:       "bookbuyer.bookbuyer",
+				CertChain: "invalid",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+			cmd := &certificateInspectCmd{
+				out: out,
+				pem: tc.pem,
+			}
+
+			err := cmd.printCertificate(tc.info)
+			assert.Equal(tc.expectedErr, err != nil)
+			for _, s := range tc.contains {
+				assert.Contains(out.String(), s)
+			}
+			for _, s := range tc.notContains {
+				assert.NotContains(out.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const certificateListCmdDescription = `
+This command lists the certificates issued by the control plane, along with
+their serial number, type, issuer and expiration. The issuer is the name of
+the MeshRootCertificate that signed the certificate.
+`
+
+const certificateListCmdExample = `
+# List the certificates issued by the control plane
+osm certificate list
+
+# List the certificates issued to the proxies
+osm certificate list --type service
+`
+
+type certificateListCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	certType  string
+	localPort uint16
+}
+
+func newCertificateListCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	listCmd := &certificateListCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "list the certificates issued by the control plane",
+		Long:    certificateListCmdDescription,
+		Args:    cobra.NoArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			listCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			listCmd.clientSet = clientset
+			return listCmd.run()
+		},
+		Example: certificateListCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&listCmd.certType, "type", "", "Type of the certificates to list: service, ingressGateway or internal, defaults to all types")
+	f.Uint16VarP(&listCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *certificateListCmd) run() error {
+	resp, err := cli.ExecuteControllerDebugReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, issuedCertsPath)
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error listing certificates: %s", err)
+	}
+
+	var certs []certificate.CertificateInfo
+	if err := json.Unmarshal(resp, &certs); err != nil {
+		return fmt.Errorf("Error decoding certificates: %w", err)
+	}
+
+	return cmd.printCertificates(certs)
+}
+
+// printCertificates prints the given certificates of the requested type
+func (cmd *certificateListCmd) printCertificates(certs []certificate.CertificateInfo) error {
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "COMMON NAME\tSERIAL NUMBER\tTYPE\tISSUER\tEXPIRES")
+	for _, cert := range certs {
+		if cmd.certType != "" && cert.CertType != cmd.certType {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", cert.CommonName, cert.SerialNumber, cert.CertType, formatIssuers(cert), formatExpiration(cert.Expiration))
+	}
+	return w.Flush()
+}
+
+// formatIssuers returns the ID of the issuer that signed the given certificate, along with the ID of the issuer
+// validating peers during a root certificate rotation
+func formatIssuers(cert certificate.CertificateInfo) string {
+	if cert.ValidatingIssuerID == "" || cert.ValidatingIssuerID == cert.SigningIssuerID {
+		return cert.SigningIssuerID
+	}
+	return fmt.Sprintf("%s (validating: %s)", cert.SigningIssuerID, cert.ValidatingIssuerID)
+}
+
+// formatExpiration returns the given expiration along with the time remaining until it
+func formatExpiration(expiration time.Time) string {
+	remaining := time.Until(expiration)
+	if remaining <= 0 {
+		return fmt.Sprintf("%s (expired)", expiration.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s (in %s)", expiration.UTC().Format(time.RFC3339), duration.HumanDuration(remaining))
+}
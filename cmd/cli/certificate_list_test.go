@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+)
+
+func TestCertificateListPrintCertificates(t *testing.T) {
+	expiration := time.Now().Add(10*time.Hour + time.Minute)
+	expires := expiration.UTC().Format(time.RFC3339) + " (in 10h)"
+
+	certs := []certificate.CertificateInfo{
+		{
+			CommonName:         "bookbuyer.bookbuyer.cluster.local",
+			SerialNumber:       "1234",
+			CertType:           "service",
+			SigningIssuerID:    "osm-mesh-root-certificate",
+			ValidatingIssuerID: "osm-mesh-root-certificate",
+			Expiration:         expiration,
+		},
+		{
+			CommonName:         "osm-validator.osm-system.svc",
+			SerialNumber:       "5678",
+			CertType:           "internal",
+			SigningIssuerID:    "new-root",
+			ValidatingIssuerID: "osm-mesh-root-certificate",
+			Expiration:         expiration,
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		certType string
+		expected string
+	}{
+		{
+			name: "all types",
+			expected: "COMMON NAME                         SERIAL NUMBER   TYPE       ISSUER                                             EXPIRES\n" +
+				"bookbuyer.bookbuyer.cluster.local   1234            service    osm-mesh-root-certificate                          " + expires + "\n" +
+				"osm-validator.osm-system.svc        5678            internal   new-root (validating: osm-mesh-root-certificate)   " + expires + "\n",
+		},
+		{
+			name:     "single type",
+			certType: "service",
+			expected: "COMMON NAME                         SERIAL NUMBER   TYPE      ISSUER                      EXPIRES\n" +
+				"bookbuyer.bookbuyer.cluster.local   1234            service   osm-mesh-root-certificate   " + expires + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+			cmd := &certificateListCmd{
+				out:      out,
+				certType: tc.certType,
+			}
+
+			assert.NoError(cmd.printCertificates(certs))
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
+
+func TestFormatExpiration(t *testing.T) {
+	assert := tassert.New(t)
+
+	expired := time.Now().Add(-time.Hour)
+	assert.Equal(expired.UTC().Format(time.RFC3339)+" (expired)", formatExpiration(expired))
+
+	expiration := time.Now().Add(90 * time.Second)
+	assert.Equal(expiration.UTC().Format(time.RFC3339)+" (in 89s)", formatExpiration(expiration))
+}
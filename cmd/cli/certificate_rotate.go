@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const certificateRotateCmdDescription = `
+This command forces the rotation of a certificate issued by the control plane,
+such as a certificate whose private key is compromised. The certificate is
+named by its key, such as the service identity of the form
+<service-account>.<namespace> for the certificates issued to the proxies, its
+common name or its serial number. The proxies using the certificate are sent
+the reissued certificate.
+
+To also have the proxies reject the rotated certificate, revoke it with a
+CertificateRevocation resource instead.
+`
+
+const certificateRotateCmdExample = `
+# Rotate the certificate issued to the proxies of the 'bookbuyer' service account in the 'bookbuyer' namespace
+osm certificate rotate bookbuyer.bookbuyer
+`
+
+type certificateRotateCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	name      string
+	localPort uint16
+}
+
+func newCertificateRotateCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	rotateCmd := &certificateRotateCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rotate NAME",
+		Short: "force the rotation of a certificate issued by the control plane",
+		Long:  certificateRotateCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			rotateCmd.name = args[0]
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			rotateCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			rotateCmd.clientSet = clientset
+			return rotateCmd.run()
+		},
+		Example: certificateRotateCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.Uint16VarP(&rotateCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *certificateRotateCmd) run() error {
+	query := url.Values{}
+	query.Set(certNameQueryKey, cmd.name)
+	resp, err := cli.ExecuteControllerDebugPostReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, rotateCertPath+"?"+query.Encode())
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error rotating certificate: %s", err)
+	}
+
+	var cert certificate.CertificateInfo
+	if err := json.Unmarshal(resp, &cert); err != nil {
+		return fmt.Errorf("Error decoding rotated certificate: %w", err)
+	}
+
+	fmt.Fprintf(cmd.out, "Certificate %s rotated, new serial number %s expires %s\n", cert.CommonName, cert.SerialNumber, formatExpiration(cert.Expiration))
+	return nil
+}
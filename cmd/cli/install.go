@@ -5,8 +5,10 @@ import (
 	"context"
 
 	_ "embed" // required to embed resources
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -14,9 +16,14 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/strvals"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 const installDesc = `
@@ -48,11 +55,23 @@ Example:
 The mesh name is used in various ways like for naming Kubernetes resources as
 well as for adding a Kubernetes Namespace to the list of Namespaces a control
 plane should watch for sidecar injection of Envoy proxies.
+
+An intermediate CA signed by an offline root can be imported for the Tresor
+certificate provider to issue certificates with, instead of a root it
+generates. The certificate chain of the intermediate CA, its private key and
+the root certificate are validated and stored in a Kubernetes Secret in the
+control plane namespace.
+
+Example:
+  $ osm install --ca-intermediate-cert-chain intermediate.crt --ca-intermediate-key intermediate.key --ca-root-cert root.crt
 `
 const (
 	defaultChartPath         = ""
 	defaultMeshName          = "osm"
 	defaultEnforceSingleMesh = true
+
+	// caIntermediateSecretName is the name of the Secret the imported intermediate CA is stored in
+	caIntermediateSecretName = "osm-ca-intermediate"
 )
 
 // chartTGZSource is the `helm package`d representation of the default Helm chart.
@@ -72,6 +91,10 @@ type installCmd struct {
 	atomic         bool
 	// Toggle this to enforce only one mesh in this cluster
 	enforceSingleMesh bool
+	// Paths to the PEM files of the intermediate CA to import
+	caIntermediateCertChainFile string
+	caIntermediateKeyFile       string
+	caRootCertFile              string
 }
 
 func newInstallCmd(config *helm.Configuration, out io.Writer) *cobra.Command {
@@ -105,6 +128,9 @@ func newInstallCmd(config *helm.Configuration, out io.Writer) *cobra.Command {
 	f.DurationVar(&inst.timeout, "timeout", 5*time.Minute, "Time to wait for installation and resources in a ready state, zero means no timeout")
 	f.StringArrayVar(&inst.setOptions, "set", nil, "Set arbitrary chart values (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.BoolVar(&inst.atomic, "atomic", false, "Automatically clean up resources if installation fails")
+	f.StringVar(&inst.caIntermediateCertChainFile, "ca-intermediate-cert-chain", "", "Path to the PEM encoded certificate chain of an intermediate CA signed by an offline root, used by Tresor to issue certificates")
+	f.StringVar(&inst.caIntermediateKeyFile, "ca-intermediate-key", "", "Path to the PEM encoded PKCS#8 private key of the intermediate CA")
+	f.StringVar(&inst.caRootCertFile, "ca-root-cert", "", "Path to the PEM encoded root certificate the intermediate CA is signed by")
 
 	return cmd
 }
@@ -120,6 +146,15 @@ func (i *installCmd) run(config *helm.Configuration) error {
 		return err
 	}
 
+	// The intermediate CA is imported before the chart is installed, so that the control plane can start with it.
+	// It is removed again if the installation fails, as Helm doesn't know about it.
+	cleanupIntermediateCA := func() {}
+	if i.caIntermediateCertChainFile != "" {
+		if cleanupIntermediateCA, err = i.importIntermediateCA(); err != nil {
+			return err
+		}
+	}
+
 	installClient := helm.NewInstall(config)
 	installClient.ReleaseName = i.meshName
 	installClient.Namespace = settings.Namespace()
@@ -130,6 +165,7 @@ func (i *installCmd) run(config *helm.Configuration) error {
 
 	debug("Beginning OSM installation")
 	if _, err = installClient.Run(i.chartRequested, values); err != nil {
+		cleanupIntermediateCA()
 		if !settings.Verbose() {
 			return err
 		}
@@ -174,6 +210,13 @@ func (i *installCmd) resolveValues() (map[string]interface{}, error) {
 		fmt.Sprintf("osm.enforceSingleMesh=%t", i.enforceSingleMesh),
 	}
 
+	if i.caIntermediateCertChainFile != "" || i.caIntermediateKeyFile != "" || i.caRootCertFile != "" {
+		if i.caIntermediateCertChainFile == "" || i.caIntermediateKeyFile == "" || i.caRootCertFile == "" {
+			return nil, errors.New("--ca-intermediate-cert-chain, --ca-intermediate-key and --ca-root-cert must be set together")
+		}
+		valuesConfig = append(valuesConfig, fmt.Sprintf("osm.caIntermediateSecretName=%s", caIntermediateSecretName))
+	}
+
 	if err := parseVal(valuesConfig, finalValues); err != nil {
 		return nil, err
 	}
@@ -181,6 +224,84 @@ func (i *installCmd) resolveValues() (map[string]interface{}, error) {
 	return finalValues, nil
 }
 
+// importIntermediateCA validates the intermediate CA read from the given files and stores it in a Secret in the
+// control plane namespace, creating the namespace if needed. The returned function reverts the changes made to the
+// cluster: it deletes the namespace or the Secret if they were created, or restores the previous Secret.
+func (i *installCmd) importIntermediateCA() (func(), error) {
+	chainPEM, err := os.ReadFile(i.caIntermediateCertChainFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading intermediate CA certificate chain: %w", err)
+	}
+	keyPEM, err := os.ReadFile(i.caIntermediateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading intermediate CA private key: %w", err)
+	}
+	rootPEM, err := os.ReadFile(i.caRootCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading root certificate: %w", err)
+	}
+
+	ca, err := certificate.NewIntermediateCAFromPEM(chainPEM, keyPEM, rootPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := settings.Namespace()
+	_, err = i.clientSet.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: ns},
+	}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("error creating namespace %s: %w", ns, err)
+	}
+	createdNamespace := err == nil
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caIntermediateSecretName,
+			Namespace: ns,
+			Labels: map[string]string{
+				constants.OSMAppNameLabelKey: constants.OSMAppNameLabelValue,
+			},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			constants.KubernetesOpaqueSecretCAKey: ca.GetIssuingCA(),
+			corev1.TLSCertKey:                     ca.GetCertificateChain(),
+			corev1.TLSPrivateKeyKey:               ca.GetPrivateKey(),
+		},
+	}
+	previous, err := i.clientSet.CoreV1().Secrets(ns).Get(context.Background(), caIntermediateSecretName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		previous = nil
+		_, err = i.clientSet.CoreV1().Secrets(ns).Create(context.Background(), secret, metav1.CreateOptions{})
+	case err == nil:
+		_, err = i.clientSet.CoreV1().Secrets(ns).Update(context.Background(), secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error storing intermediate CA in secret %s/%s: %w", ns, caIntermediateSecretName, err)
+	}
+
+	fmt.Fprintf(i.out, "Imported intermediate CA %s in secret %s/%s\n", ca.GetCommonName(), ns, caIntermediateSecretName)
+
+	cleanup := func() {
+		var err error
+		switch {
+		case createdNamespace:
+			err = i.clientSet.CoreV1().Namespaces().Delete(context.Background(), ns, metav1.DeleteOptions{})
+		case previous == nil:
+			err = i.clientSet.CoreV1().Secrets(ns).Delete(context.Background(), caIntermediateSecretName, metav1.DeleteOptions{})
+		default:
+			previous.ResourceVersion = ""
+			_, err = i.clientSet.CoreV1().Secrets(ns).Update(context.Background(), previous, metav1.UpdateOptions{})
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Fprintf(i.out, "Error removing intermediate CA from secret %s/%s: %s\n", ns, caIntermediateSecretName, err)
+		}
+	}
+	return cleanup, nil
+}
+
 // parses Helm strvals line and merges into a map
 func parseVal(vals []string, parsedVals map[string]interface{}) error {
 	for _, v := range vals {
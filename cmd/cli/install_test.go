@@ -2,13 +2,21 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/big"
+	"os"
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,9 +29,12 @@ import (
 	"helm.sh/helm/v3/pkg/storage/driver"
 	"helm.sh/helm/v3/pkg/strvals"
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -583,6 +594,31 @@ func TestResolveValues(t *testing.T) {
 			}(),
 			expectedErr: errors.New("invalid format for --set: key \"can't set this\" has no value"),
 		},
+		{
+			name: "intermediate CA sets the secret name",
+			installCmd: func() installCmd {
+				installCmd := getDefaultInstallCmd(ioutil.Discard)
+				installCmd.caIntermediateCertChainFile = "chain.pem"
+				installCmd.caIntermediateKeyFile = "key.pem"
+				installCmd.caRootCertFile = "root.pem"
+				return installCmd
+			}(),
+			expected: func() map[string]interface{} {
+				vals := getDefaultValues()
+				vals["osm"].(map[string]interface{})["caIntermediateSecretName"] = caIntermediateSecretName
+				return vals
+			}(),
+		},
+		{
+			name: "intermediate CA without its key",
+			installCmd: func() installCmd {
+				installCmd := getDefaultInstallCmd(ioutil.Discard)
+				installCmd.caIntermediateCertChainFile = "chain.pem"
+				installCmd.caRootCertFile = "root.pem"
+				return installCmd
+			}(),
+			expectedErr: errors.New("--ca-intermediate-cert-chain, --ca-intermediate-key and --ca-root-cert must be set together"),
+		},
 	}
 
 	for idx, test := range tests {
@@ -599,6 +635,107 @@ func TestResolveValues(t *testing.T) {
 	}
 }
 
+func TestImportIntermediateCA(t *testing.T) {
+	assert := tassert.New(t)
+
+	newCA := func(cn string, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, string) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		assert.NoError(err)
+		cert, err := x509.ParseCertificate(der)
+		assert.NoError(err)
+		certPEM, err := certificate.EncodeCertDERtoPEM(der)
+		assert.NoError(err)
+		return cert, key, string(certPEM)
+	}
+
+	root, rootKey, rootPEM := newCA("root", nil, nil)
+	_, intermediateKey, intermediatePEM := newCA("intermediate", root, rootKey)
+	_, _, otherRootPEM := newCA("other-root", nil, nil)
+	keyPEM, err := certificate.EncodeKeyDERtoPEM(intermediateKey)
+	assert.NoError(err)
+
+	dir := t.TempDir()
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(os.WriteFile(path, []byte(contents), 0600))
+		return path
+	}
+	chainFile := writeFile("chain.pem", intermediatePEM)
+	keyFile := writeFile("key.pem", string(keyPEM))
+	rootFile := writeFile("root.pem", rootPEM)
+	otherRootFile := writeFile("other-root.pem", otherRootPEM)
+
+	// A chain that does not verify against the given root is rejected
+	fakeClientSet := fake.NewSimpleClientset()
+	install := getDefaultInstallCmd(ioutil.Discard)
+	install.clientSet = fakeClientSet
+	install.caIntermediateCertChainFile = chainFile
+	install.caIntermediateKeyFile = keyFile
+	install.caRootCertFile = otherRootFile
+	_, err = install.importIntermediateCA()
+	assert.Error(err)
+
+	// A valid intermediate CA is stored, and importing it again updates the secret
+	install.caRootCertFile = rootFile
+	cleanupNamespace, err := install.importIntermediateCA()
+	assert.NoError(err)
+	_, err = fakeClientSet.CoreV1().Secrets(settings.Namespace()).Update(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: caIntermediateSecretName, Namespace: settings.Namespace()},
+		Data:       map[string][]byte{"previous": []byte("previous")},
+	}, metav1.UpdateOptions{})
+	assert.NoError(err)
+	cleanupUpdate, err := install.importIntermediateCA()
+	assert.NoError(err)
+
+	secret, err := fakeClientSet.CoreV1().Secrets(settings.Namespace()).Get(context.Background(), caIntermediateSecretName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal(rootPEM, string(secret.Data[constants.KubernetesOpaqueSecretCAKey]))
+	assert.Equal(intermediatePEM, string(secret.Data[corev1.TLSCertKey]))
+	assert.NotEmpty(secret.Data[corev1.TLSPrivateKeyKey])
+
+	_, err = fakeClientSet.CoreV1().Namespaces().Get(context.Background(), settings.Namespace(), metav1.GetOptions{})
+	assert.NoError(err)
+
+	// Cleaning up restores the secret that existed before the import
+	cleanupUpdate()
+	secret, err = fakeClientSet.CoreV1().Secrets(settings.Namespace()).Get(context.Background(), caIntermediateSecretName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal(map[string][]byte{"previous": []byte("previous")}, secret.Data)
+
+	// Cleaning up deletes the namespace created by the import
+	cleanupNamespace()
+	_, err = fakeClientSet.CoreV1().Namespaces().Get(context.Background(), settings.Namespace(), metav1.GetOptions{})
+	assert.True(apierrors.IsNotFound(err))
+
+	// Cleaning up deletes the secret created in an existing namespace
+	_, err = fakeClientSet.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: settings.Namespace()},
+	}, metav1.CreateOptions{})
+	assert.NoError(err)
+	assert.NoError(fakeClientSet.CoreV1().Secrets(settings.Namespace()).Delete(context.Background(), caIntermediateSecretName, metav1.DeleteOptions{}))
+	cleanupSecret, err := install.importIntermediateCA()
+	assert.NoError(err)
+	cleanupSecret()
+	_, err = fakeClientSet.CoreV1().Secrets(settings.Namespace()).Get(context.Background(), caIntermediateSecretName, metav1.GetOptions{})
+	assert.True(apierrors.IsNotFound(err))
+	_, err = fakeClientSet.CoreV1().Namespaces().Get(context.Background(), settings.Namespace(), metav1.GetOptions{})
+	assert.NoError(err)
+}
+
 func createDeploymentSpec(namespace, meshName string) *v1.Deployment {
 	labelMap := make(map[string]string)
 	if meshName != "" {
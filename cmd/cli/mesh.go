@@ -25,6 +25,7 @@ func newMeshCmd(config *action.Configuration, in io.Reader, out io.Writer) *cobr
 
 	if !settings.IsManaged() {
 		cmd.AddCommand(newMeshUpgradeCmd(config, out))
+		cmd.AddCommand(newMeshRotateRootCmd(config, out))
 	}
 
 	return cmd
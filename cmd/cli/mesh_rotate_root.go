@@ -0,0 +1,643 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+)
+
+const meshRotateRootDescription = `
+This command rotates the root certificate of the mesh by stepping through the
+transitions of the MeshRootCertificate (MRC) resources:
+  1. create the new MRC with the passive intent, so that the proxies trust
+     the new root certificate alongside the old one,
+  2. set the intent of the new MRC to active, so that certificates are
+     signed by the new root certificate,
+  3. set the intent of the old MRC to passive,
+  4. delete the old MRC, so that the old root certificate is no longer
+     trusted.
+
+Before each step, the command waits for the certificates issued by the control
+plane to use the expected root certificates, for the proxies to ACK the
+certificates sent to them over SDS, and for no component status of the MRCs to
+be False. Before the new root certificate signs certificates, the bootstrap
+configuration of every proxy must trust it; before the old MRC is deleted, the
+bootstrap certificate of every proxy must be signed by the new root
+certificate. Pods that must be restarted for that are listed while waiting.
+
+The step to run is inferred from the MRCs in the cluster, so the command
+resumes an interrupted rotation when run again with the same arguments.
+
+The new MRC is created from the old one for the Tresor provider, with its root
+certificate stored in the secret given by --ca-secret. For other providers,
+create the new MRC with the passive intent before running this command.
+
+The debug server must be enabled in the MeshConfig for this command to work.
+`
+
+const meshRotateRootExample = `
+# Rotate the root certificate of the mesh to a new MRC named 'osm-mesh-root-certificate-2'
+osm mesh rotate-root --new-mrc osm-mesh-root-certificate-2
+
+# Show the steps left to rotate the root certificate without changing the MRCs
+osm mesh rotate-root --new-mrc osm-mesh-root-certificate-2 --dry-run
+`
+
+const rootsPath = "/debug/certs/roots"
+
+// rotationStep is a step of the rotation of the root certificate
+type rotationStep string
+
+const (
+	rotationStepCreate   rotationStep = "create the new MRC with the passive intent"
+	rotationStepActivate rotationStep = "set the intent of the new MRC to active"
+	rotationStepRetire   rotationStep = "set the intent of the old MRC to passive"
+	rotationStepDelete   rotationStep = "delete the old MRC"
+	rotationStepDone     rotationStep = "complete the rotation"
+)
+
+type meshRotateRootCmd struct {
+	out          io.Writer
+	config       *rest.Config
+	kubeClient   kubernetes.Interface
+	configClient configClientset.Interface
+	namespace    string
+	newMRCName   string
+	oldMRCName   string
+	caSecret     string
+	dryRun       bool
+	timeout      time.Duration
+	pollInterval time.Duration
+	localPort    uint16
+
+	// getDebugJSON decodes the response of the given path of the osm-controller's debug server into v
+	getDebugJSON func(path string, v interface{}) error
+
+	// lastReasons are the reasons printed while waiting for the current step
+	lastReasons []string
+}
+
+func newMeshRotateRootCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	rotateCmd := &meshRotateRootCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "rotate-root",
+		Short: "rotate the root certificate of the mesh",
+		Long:  meshRotateRootDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			rotateCmd.config = conf
+
+			kubeClient, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			rotateCmd.kubeClient = kubeClient
+
+			configClient, err := configClientset.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access OSM, check configuration: %w", err)
+			}
+			rotateCmd.configClient = configClient
+
+			rotateCmd.namespace = settings.Namespace()
+			rotateCmd.getDebugJSON = rotateCmd.getControllerDebugJSON
+			return rotateCmd.run()
+		},
+		Example: meshRotateRootExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&rotateCmd.newMRCName, "new-mrc", "", "Name of the MRC of the new root certificate")
+	f.StringVar(&rotateCmd.oldMRCName, "old-mrc", "", "Name of the MRC of the old root certificate, defaults to the only other MRC")
+	f.StringVar(&rotateCmd.caSecret, "ca-secret", "", "Name of the secret storing the new root certificate of the Tresor provider, defaults to <new-mrc>-ca-bundle")
+	f.BoolVar(&rotateCmd.dryRun, "dry-run", false, "Print the steps left without changing the MRCs")
+	f.DurationVar(&rotateCmd.timeout, "timeout", 10*time.Minute, "Time to wait for each step")
+	f.DurationVar(&rotateCmd.pollInterval, "poll-interval", 5*time.Second, "Interval between checks while waiting for a step")
+	f.Uint16VarP(&rotateCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+	//nolint: errcheck
+	//#nosec G104: Errors unhandled
+	cmd.MarkFlagRequired("new-mrc")
+
+	return cmd
+}
+
+func (cmd *meshRotateRootCmd) run() error {
+	newMRC, oldMRC, err := cmd.getMRCs()
+	if err != nil {
+		return err
+	}
+
+	for {
+		step := nextRotationStep(newMRC, oldMRC)
+		fmt.Fprintf(cmd.out, "Next step: %s\n", step)
+
+		if err := cmd.waitFor(step, newMRC, oldMRC); err != nil {
+			return err
+		}
+		if step == rotationStepDone {
+			if cmd.dryRun {
+				fmt.Fprintf(cmd.out, "[dry-run] Root certificate rotation to MRC %s would be complete\n", cmd.newMRCName)
+			} else {
+				fmt.Fprintf(cmd.out, "Root certificate rotation to MRC %s complete\n", cmd.newMRCName)
+			}
+			return nil
+		}
+
+		newMRC, oldMRC, err = cmd.apply(step, newMRC, oldMRC)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// nextRotationStep returns the step of the rotation to run given the new and old MRCs found in the cluster
+func nextRotationStep(newMRC, oldMRC *configv1alpha2.MeshRootCertificate) rotationStep {
+	switch {
+	case newMRC == nil:
+		return rotationStepCreate
+	case newMRC.Intent != constants.MRCIntentActive:
+		return rotationStepActivate
+	case oldMRC == nil:
+		return rotationStepDone
+	case oldMRC.Intent != constants.MRCIntentPassive:
+		return rotationStepRetire
+	default:
+		return rotationStepDelete
+	}
+}
+
+// getMRCs returns the new and old MRCs, which are nil if they don't exist
+func (cmd *meshRotateRootCmd) getMRCs() (newMRC, oldMRC *configv1alpha2.MeshRootCertificate, err error) {
+	mrcList, err := cmd.configClient.ConfigV1alpha2().MeshRootCertificates(cmd.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, annotateErrorMessageWithOsmNamespace("Error listing MRCs: %s", err)
+	}
+
+	var others []*configv1alpha2.MeshRootCertificate
+	for i := range mrcList.Items {
+		mrc := &mrcList.Items[i]
+		switch mrc.Name {
+		case cmd.newMRCName:
+			newMRC = mrc
+		case cmd.oldMRCName:
+			oldMRC = mrc
+		default:
+			others = append(others, mrc)
+		}
+	}
+
+	if cmd.oldMRCName == "" {
+		switch len(others) {
+		case 0:
+		case 1:
+			oldMRC = others[0]
+			cmd.oldMRCName = oldMRC.Name
+		default:
+			var names []string
+			for _, mrc := range others {
+				names = append(names, mrc.Name)
+			}
+			return nil, nil, fmt.Errorf("Found MRCs %s, specify the MRC of the old root certificate with --old-mrc", strings.Join(names, ", "))
+		}
+	}
+
+	// The old MRC is only missing once it was deleted at the end of the rotation
+	if oldMRC == nil && newMRC == nil {
+		return nil, nil, annotateErrorMessageWithOsmNamespace("Error finding the MRC of the root certificate to rotate")
+	}
+
+	return newMRC, oldMRC, nil
+}
+
+// apply runs the given step, and returns the resulting new and old MRCs. In dry-run mode, the MRCs are only
+// changed locally.
+func (cmd *meshRotateRootCmd) apply(step rotationStep, newMRC, oldMRC *configv1alpha2.MeshRootCertificate) (*configv1alpha2.MeshRootCertificate, *configv1alpha2.MeshRootCertificate, error) {
+	if step == rotationStepCreate && oldMRC.Spec.Provider.Tresor == nil {
+		return nil, nil, fmt.Errorf("MRC %s does not use the Tresor provider, create MRC %s with the %s intent and run this command again to resume the rotation",
+			oldMRC.Name, cmd.newMRCName, constants.MRCIntentPassive)
+	}
+
+	switch step {
+	case rotationStepCreate:
+		newMRC = cmd.newTresorMRC(oldMRC)
+	case rotationStepActivate:
+		newMRC = newMRC.DeepCopy()
+		newMRC.Intent = constants.MRCIntentActive
+	case rotationStepRetire:
+		oldMRC = oldMRC.DeepCopy()
+		oldMRC.Intent = constants.MRCIntentPassive
+	}
+
+	if cmd.dryRun {
+		fmt.Fprintf(cmd.out, "[dry-run] Would %s\n", step)
+		if step == rotationStepDelete {
+			oldMRC = nil
+		}
+		return newMRC, oldMRC, nil
+	}
+
+	mrcClient := cmd.configClient.ConfigV1alpha2().MeshRootCertificates(cmd.namespace)
+	var err error
+	switch step {
+	case rotationStepCreate:
+		newMRC, err = mrcClient.Create(context.TODO(), newMRC, metav1.CreateOptions{})
+	case rotationStepActivate:
+		newMRC, err = mrcClient.Update(context.TODO(), newMRC, metav1.UpdateOptions{})
+	case rotationStepRetire:
+		oldMRC, err = mrcClient.Update(context.TODO(), oldMRC, metav1.UpdateOptions{})
+	case rotationStepDelete:
+		if err = mrcClient.Delete(context.TODO(), oldMRC.Name, metav1.DeleteOptions{}); k8sErrors.IsNotFound(err) {
+			err = nil
+		}
+		oldMRC = nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error applying step to %s: %w", step, err)
+	}
+
+	fmt.Fprintf(cmd.out, "Done: %s\n", step)
+	return newMRC, oldMRC, nil
+}
+
+// newTresorMRC returns the new MRC of the Tresor provider, created from the given old MRC
+func (cmd *meshRotateRootCmd) newTresorMRC(oldMRC *configv1alpha2.MeshRootCertificate) *configv1alpha2.MeshRootCertificate {
+	caSecret := cmd.caSecret
+	if caSecret == "" {
+		caSecret = cmd.newMRCName + "-ca-bundle"
+	}
+
+	spec := *oldMRC.Spec.DeepCopy()
+	spec.Provider.Tresor.CA.SecretRef.Name = caSecret
+	spec.Provider.Tresor.CA.SecretRef.Namespace = cmd.namespace
+	spec.Provider.Tresor.CA.IntermediateSecretRef = nil
+
+	return &configv1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmd.newMRCName,
+			Namespace: cmd.namespace,
+		},
+		Spec:   spec,
+		Intent: constants.MRCIntentPassive,
+	}
+}
+
+// waitFor waits until the given step can run. In dry-run mode, it prints what the step would wait for instead.
+func (cmd *meshRotateRootCmd) waitFor(step rotationStep, newMRC, oldMRC *configv1alpha2.MeshRootCertificate) error {
+	cmd.lastReasons = nil
+	if cmd.dryRun {
+		if step == rotationStepCreate {
+			return nil
+		}
+		reasons, err := cmd.check(step, newMRC, oldMRC)
+		if err != nil {
+			return err
+		}
+		for _, reason := range reasons {
+			fmt.Fprintf(cmd.out, "[dry-run] Would wait: %s\n", reason)
+		}
+		return nil
+	}
+
+	err := wait.PollImmediate(cmd.pollInterval, cmd.timeout, func() (bool, error) {
+		if step != rotationStepCreate {
+			var err error
+			newMRC, oldMRC, err = cmd.refreshMRCs(newMRC, oldMRC)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		reasons, err := cmd.check(step, newMRC, oldMRC)
+		if err != nil {
+			return false, err
+		}
+		cmd.printReasons(reasons)
+		return len(reasons) == 0, nil
+	})
+	if errors.Is(err, wait.ErrWaitTimeout) {
+		return fmt.Errorf("Timed out waiting to %s, run this command again to resume the rotation", step)
+	}
+	return err
+}
+
+// refreshMRCs gets the latest version of the given MRCs
+func (cmd *meshRotateRootCmd) refreshMRCs(mrcs ...*configv1alpha2.MeshRootCertificate) (*configv1alpha2.MeshRootCertificate, *configv1alpha2.MeshRootCertificate, error) {
+	var refreshed []*configv1alpha2.MeshRootCertificate
+	for _, mrc := range mrcs {
+		if mrc == nil {
+			refreshed = append(refreshed, nil)
+			continue
+		}
+		latest, err := cmd.configClient.ConfigV1alpha2().MeshRootCertificates(cmd.namespace).Get(context.TODO(), mrc.Name, metav1.GetOptions{})
+		if k8sErrors.IsNotFound(err) {
+			refreshed = append(refreshed, nil)
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error getting MRC %s: %w", mrc.Name, err)
+		}
+		refreshed = append(refreshed, latest)
+	}
+	return refreshed[0], refreshed[1], nil
+}
+
+// printReasons prints the reasons the current step is waiting for, when they change
+func (cmd *meshRotateRootCmd) printReasons(reasons []string) {
+	if strings.Join(reasons, "\n") == strings.Join(cmd.lastReasons, "\n") {
+		return
+	}
+	cmd.lastReasons = reasons
+	for _, reason := range reasons {
+		fmt.Fprintf(cmd.out, "Waiting: %s\n", reason)
+	}
+}
+
+// check returns the reasons the given step can't run yet
+func (cmd *meshRotateRootCmd) check(step rotationStep, newMRC, oldMRC *configv1alpha2.MeshRootCertificate) ([]string, error) {
+	if step == rotationStepCreate {
+		return checkMRCStatus(oldMRC), nil
+	}
+	if newMRC == nil {
+		return nil, fmt.Errorf("MRC %s was deleted during the rotation", cmd.newMRCName)
+	}
+
+	reasons := append(checkMRCStatus(newMRC), checkMRCStatus(oldMRC)...)
+
+	var roots []certificate.RootInfo
+	if err := cmd.getDebugJSON(rootsPath, &roots); err != nil {
+		return nil, annotateErrorMessageWithOsmNamespace("Error fetching root certificates: %s", err)
+	}
+	var certs []certificate.CertificateInfo
+	if err := cmd.getDebugJSON(issuedCertsPath, &certs); err != nil {
+		return nil, annotateErrorMessageWithOsmNamespace("Error fetching issued certificates: %s", err)
+	}
+	var statuses []envoy.ProxySyncStatus
+	if err := cmd.getDebugJSON(xdsSyncStatusPath, &statuses); err != nil {
+		return nil, annotateErrorMessageWithOsmNamespace("Error fetching proxy status: %s", err)
+	}
+
+	var newRoot *certificate.RootInfo
+	for i := range roots {
+		if roots[i].MRC == cmd.newMRCName {
+			newRoot = &roots[i]
+		}
+	}
+
+	switch step {
+	case rotationStepActivate:
+		if newRoot == nil || !newRoot.Validating {
+			reasons = append(reasons, fmt.Sprintf("the control plane does not trust the root certificate of MRC %s yet", cmd.newMRCName))
+			break
+		}
+		reasons = append(reasons, checkCertificates(certs, func(c certificate.CertificateInfo) bool {
+			return c.SigningIssuerID == cmd.newMRCName || c.ValidatingIssuerID == cmd.newMRCName
+		}, "trusting")...)
+		bootstrapReasons, err := cmd.checkBootstrapSecrets(newRoot, false)
+		if err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, bootstrapReasons...)
+	case rotationStepRetire, rotationStepDelete, rotationStepDone:
+		if newRoot == nil || !newRoot.Signing {
+			reasons = append(reasons, fmt.Sprintf("the control plane does not sign certificates with MRC %s yet", cmd.newMRCName))
+			break
+		}
+		if step == rotationStepDone {
+			reasons = append(reasons, checkCertificates(certs, func(c certificate.CertificateInfo) bool {
+				return c.SigningIssuerID == cmd.newMRCName && c.ValidatingIssuerID == cmd.newMRCName
+			}, "only trusting")...)
+			break
+		}
+		reasons = append(reasons, checkCertificates(certs, func(c certificate.CertificateInfo) bool {
+			return c.SigningIssuerID == cmd.newMRCName
+		}, "signed by")...)
+		if step == rotationStepDelete {
+			bootstrapReasons, err := cmd.checkBootstrapSecrets(newRoot, true)
+			if err != nil {
+				return nil, err
+			}
+			reasons = append(reasons, bootstrapReasons...)
+		}
+	}
+
+	return append(reasons, checkProxies(certs, statuses)...), nil
+}
+
+// checkMRCStatus returns the reasons the given MRC is not ready for the rotation to proceed
+func checkMRCStatus(mrc *configv1alpha2.MeshRootCertificate) []string {
+	if mrc == nil {
+		return nil
+	}
+
+	var reasons []string
+	if mrc.Status.State == constants.MRCStateError {
+		reasons = append(reasons, fmt.Sprintf("MRC %s is in the %s state", mrc.Name, constants.MRCStateError))
+	}
+
+	statuses := mrc.Status.ComponentStatuses
+	for _, component := range []struct {
+		name   string
+		status configv1alpha2.MeshRootCertificateComponentStatus
+	}{
+		{"webhooks", statuses.Webhooks},
+		{"xdsControlPlane", statuses.XDSControlPlane},
+		{"sidecar", statuses.Sidecar},
+		{"bootstrap", statuses.Bootstrap},
+		{"gateway", statuses.Gateway},
+	} {
+		if component.status == "False" {
+			reasons = append(reasons, fmt.Sprintf("MRC %s has the %s component status False", mrc.Name, component.name))
+		}
+	}
+	return reasons
+}
+
+// checkCertificates returns the reasons for the certificates that don't use the expected root certificates
+func checkCertificates(certs []certificate.CertificateInfo, ok func(certificate.CertificateInfo) bool, expected string) []string {
+	var pending []string
+	for _, c := range certs {
+		if !ok(c) {
+			pending = append(pending, c.Key)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+	return []string{fmt.Sprintf("%d certificate(s) not yet %s the new root certificate: %s", len(pending), expected, strings.Join(pending, ", "))}
+}
+
+// checkProxies returns the reasons for the proxies that have not ACKed the latest certificate issued to them over SDS
+func checkProxies(certs []certificate.CertificateInfo, statuses []envoy.ProxySyncStatus) []string {
+	issuedAt := make(map[string]time.Time, len(certs))
+	for _, c := range certs {
+		issuedAt[c.Key] = c.IssuedAt
+	}
+
+	var pending []string
+	for _, status := range statuses {
+		sds, ok := status.Types[envoy.TypeSDS]
+		if !ok || sds.State() != envoy.SyncStateSynced || sds.LastSentAt.Before(issuedAt[status.Identity]) {
+			pending = append(pending, fmt.Sprintf("%s (%s)", status.UUID, status.Identity))
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+	return []string{fmt.Sprintf("%d proxies have not ACKed their latest certificates over SDS: %s", len(pending), strings.Join(pending, ", "))}
+}
+
+// checkBootstrapSecrets returns the reasons for the pods whose bootstrap secret doesn't trust the given root
+// certificate, or whose bootstrap certificate isn't signed by it when signed is set
+func (cmd *meshRotateRootCmd) checkBootstrapSecrets(root *certificate.RootInfo, signed bool) ([]string, error) {
+	roots := x509.NewCertPool()
+	rootCerts := parseCertificates([]byte(root.RootCertificate))
+	for _, rootCert := range rootCerts {
+		roots.AddCert(rootCert)
+	}
+
+	pods, err := cmd.kubeClient.CoreV1().Pods("").List(context.TODO(), metav1.ListOptions{LabelSelector: constants.EnvoyUniqueIDLabelName})
+	if err != nil {
+		return nil, fmt.Errorf("Error listing pods: %w", err)
+	}
+
+	var pending []string
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		secretName := getBootstrapSecretName(pod)
+		if secretName == "" {
+			continue
+		}
+
+		secret, err := cmd.kubeClient.CoreV1().Secrets(pod.Namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("Error getting bootstrap secret %s/%s: %w", pod.Namespace, secretName, err)
+		}
+
+		var ok bool
+		if signed {
+			ok = isSignedBy(secret.Data[bootstrap.EnvoyXDSCertFile], roots)
+		} else {
+			ok = containsCertificates(secret.Data[bootstrap.EnvoyXDSCACertFile], rootCerts)
+		}
+		if !ok {
+			pending = append(pending, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	sort.Strings(pending)
+	expected := "trust"
+	if signed {
+		expected = "have a bootstrap certificate signed by"
+	}
+	return []string{fmt.Sprintf("%d pod(s) must be restarted to %s the new root certificate: %s", len(pending), expected, strings.Join(pending, ", "))}, nil
+}
+
+// getBootstrapSecretName returns the name of the bootstrap secret of the given pod
+func getBootstrapSecretName(pod corev1.Pod) string {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == constants.EnvoyBootstrapConfigVolume && volume.Secret != nil {
+			return volume.Secret.SecretName
+		}
+	}
+	return ""
+}
+
+// parseCertificates returns the certificates of the given PEM encoded data, skipping the blocks that fail to parse
+func parseCertificates(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != certificate.TypeCertificate {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// containsCertificates returns whether the given PEM encoded data contains all the given certificates
+func containsCertificates(data []byte, certs []*x509.Certificate) bool {
+	found := parseCertificates(data)
+	for _, cert := range certs {
+		contained := false
+		for _, f := range found {
+			contained = contained || f.Equal(cert)
+		}
+		if !contained {
+			return false
+		}
+	}
+	return len(certs) > 0
+}
+
+// isSignedBy returns whether the leaf of the given PEM encoded certificate chain verifies against the given roots
+func isSignedBy(chain []byte, roots *x509.CertPool) bool {
+	certs := parseCertificates(chain)
+	if len(certs) == 0 {
+		return false
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+// getControllerDebugJSON decodes the response of the given path of the osm-controller's debug server into v
+func (cmd *meshRotateRootCmd) getControllerDebugJSON(path string, v interface{}) error {
+	resp, err := cli.ExecuteControllerDebugReq(cmd.kubeClient, cmd.config, cmd.namespace, cmd.localPort, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(resp, v)
+}
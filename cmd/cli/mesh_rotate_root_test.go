@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+)
+
+const (
+	testOldMRC = "osm-mesh-root-certificate"
+	testNewMRC = "osm-mesh-root-certificate-2"
+)
+
+func TestNextRotationStep(t *testing.T) {
+	newMRC := func(intent configv1alpha2.MeshRootCertificateIntent) *configv1alpha2.MeshRootCertificate {
+		return &configv1alpha2.MeshRootCertificate{Intent: intent}
+	}
+
+	testCases := []struct {
+		name     string
+		newMRC   *configv1alpha2.MeshRootCertificate
+		oldMRC   *configv1alpha2.MeshRootCertificate
+		expected rotationStep
+	}{
+		{
+			name:     "new MRC missing",
+			oldMRC:   newMRC(constants.MRCIntentActive),
+			expected: rotationStepCreate,
+		},
+		{
+			name:     "new MRC passive",
+			newMRC:   newMRC(constants.MRCIntentPassive),
+			oldMRC:   newMRC(constants.MRCIntentActive),
+			expected: rotationStepActivate,
+		},
+		{
+			name:     "new MRC without intent",
+			newMRC:   newMRC(""),
+			oldMRC:   newMRC(""),
+			expected: rotationStepActivate,
+		},
+		{
+			name:     "old MRC active",
+			newMRC:   newMRC(constants.MRCIntentActive),
+			oldMRC:   newMRC(constants.MRCIntentActive),
+			expected: rotationStepRetire,
+		},
+		{
+			name:     "old MRC passive",
+			newMRC:   newMRC(constants.MRCIntentActive),
+			oldMRC:   newMRC(constants.MRCIntentPassive),
+			expected: rotationStepDelete,
+		},
+		{
+			name:     "old MRC deleted",
+			newMRC:   newMRC(constants.MRCIntentActive),
+			expected: rotationStepDone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tassert.Equal(t, tc.expected, nextRotationStep(tc.newMRC, tc.oldMRC))
+		})
+	}
+}
+
+// rotationTestEnv simulates the control plane during a root certificate rotation
+type rotationTestEnv struct {
+	oldCA, newCA *certificate.Certificate
+	configClient *configFake.Clientset
+	kubeClient   *fake.Clientset
+	cmd          *meshRotateRootCmd
+	out          *bytes.Buffer
+}
+
+func newRotationTestEnv(t *testing.T, oldIntent configv1alpha2.MeshRootCertificateIntent, mrcs ...*configv1alpha2.MeshRootCertificate) *rotationTestEnv {
+	require := trequire.New(t)
+
+	oldCA, err := tresor.NewCA("old-root", time.Hour, "US", "CA", "osm", configv1alpha2.KeyAlgorithmECDSAP256)
+	require.NoError(err)
+	newCA, err := tresor.NewCA("new-root", time.Hour, "US", "CA", "osm", configv1alpha2.KeyAlgorithmECDSAP256)
+	require.NoError(err)
+
+	oldMRC := &configv1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: testOldMRC, Namespace: "osm-system"},
+		Spec: configv1alpha2.MeshRootCertificateSpec{
+			TrustDomain: "cluster.local",
+			Provider: configv1alpha2.ProviderSpec{
+				Tresor: &configv1alpha2.TresorProviderSpec{
+					CA: configv1alpha2.TresorCASpec{SecretRef: corev1.SecretReference{Name: "osm-ca-bundle", Namespace: "osm-system"}},
+				},
+			},
+		},
+		Intent: oldIntent,
+	}
+
+	objects := []runtime.Object{oldMRC}
+	for _, mrc := range mrcs {
+		objects = append(objects, mrc)
+	}
+
+	env := &rotationTestEnv{
+		oldCA:        oldCA,
+		newCA:        newCA,
+		configClient: configFake.NewSimpleClientset(objects...),
+		kubeClient:   fake.NewSimpleClientset(),
+		out:          new(bytes.Buffer),
+	}
+	env.cmd = &meshRotateRootCmd{
+		out:          env.out,
+		kubeClient:   env.kubeClient,
+		configClient: env.configClient,
+		namespace:    "osm-system",
+		newMRCName:   testNewMRC,
+		timeout:      time.Second,
+		pollInterval: time.Millisecond,
+		getDebugJSON: env.getDebugJSON,
+	}
+	return env
+}
+
+// addPod adds a pod whose bootstrap secret trusts the given roots and holds a certificate signed by the given CA
+func (env *rotationTestEnv) addPod(t *testing.T, name string, signer *certificate.Certificate, trusted ...*certificate.Certificate) {
+	require := trequire.New(t)
+
+	issuer, err := tresor.New(signer, "osm", 2048)
+	require.NoError(err)
+	cert, err := issuer.IssueCertificate(certificate.NewCertOptionsWithFullName(name, time.Hour))
+	require.NoError(err)
+
+	var cacert []byte
+	for _, ca := range trusted {
+		cacert = append(cacert, ca.GetCertificateChain()...)
+	}
+
+	secretName := "envoy-bootstrap-config-" + name
+	_, err = env.kubeClient.CoreV1().Secrets("app").Create(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: "app"},
+		Data: map[string][]byte{
+			bootstrap.EnvoyXDSCACertFile: cacert,
+			bootstrap.EnvoyXDSCertFile:   cert.GetCertificateChain(),
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(err)
+
+	_, err = env.kubeClient.CoreV1().Pods("app").Create(context.TODO(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "app",
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: name},
+		},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name:         constants.EnvoyBootstrapConfigVolume,
+				VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}},
+			}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(err)
+}
+
+// getDebugJSON returns the roots, certificates and proxy statuses the controller would report given the MRCs
+func (env *rotationTestEnv) getDebugJSON(path string, v interface{}) error {
+	mrcs, err := env.configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var oldMRC, newMRC *configv1alpha2.MeshRootCertificate
+	for i := range mrcs.Items {
+		switch mrcs.Items[i].Name {
+		case testOldMRC:
+			oldMRC = &mrcs.Items[i]
+		case testNewMRC:
+			newMRC = &mrcs.Items[i]
+		}
+	}
+
+	signing, validating := testOldMRC, testOldMRC
+	switch {
+	case newMRC == nil:
+	case oldMRC == nil:
+		signing, validating = testNewMRC, testNewMRC
+	case newMRC.Intent == constants.MRCIntentActive:
+		signing = testNewMRC
+	default:
+		validating = testNewMRC
+	}
+
+	var resp interface{}
+	switch path {
+	case rootsPath:
+		var roots []certificate.RootInfo
+		if oldMRC != nil {
+			roots = append(roots, certificate.RootInfo{MRC: testOldMRC, Signing: signing == testOldMRC, Validating: true, RootCertificate: string(env.oldCA.GetCertificateChain())})
+		}
+		if newMRC != nil {
+			roots = append(roots, certificate.RootInfo{MRC: testNewMRC, Signing: signing == testNewMRC, Validating: true, RootCertificate: string(env.newCA.GetCertificateChain())})
+		}
+		resp = roots
+	case issuedCertsPath:
+		resp = []certificate.CertificateInfo{
+			{Key: "bookbuyer.bookbuyer", SigningIssuerID: signing, ValidatingIssuerID: validating, IssuedAt: time.Now().Add(-time.Minute)},
+		}
+	case xdsSyncStatusPath:
+		resp = []envoy.ProxySyncStatus{
+			{
+				UUID:     "proxy-1",
+				Identity: "bookbuyer.bookbuyer",
+				Types: map[envoy.TypeURI]*envoy.XDSSyncStatus{
+					envoy.TypeSDS: {LastSentVersion: "2", LastAckedVersion: "2", LastSentAt: time.Now()},
+				},
+			},
+		}
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func TestMeshRotateRoot(t *testing.T) {
+	assert := tassert.New(t)
+
+	env := newRotationTestEnv(t, constants.MRCIntentActive)
+	env.addPod(t, "pod-1", env.newCA, env.oldCA, env.newCA)
+
+	err := env.cmd.run()
+	assert.NoError(err, env.out.String())
+
+	out := env.out.String()
+	for _, step := range []rotationStep{rotationStepCreate, rotationStepActivate, rotationStepRetire, rotationStepDelete} {
+		assert.Contains(out, "Done: "+string(step))
+	}
+	assert.Contains(out, "Root certificate rotation to MRC osm-mesh-root-certificate-2 complete")
+
+	mrcs, err := env.configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(err)
+	assert.Len(mrcs.Items, 1)
+	newMRC := mrcs.Items[0]
+	assert.Equal(testNewMRC, newMRC.Name)
+	assert.Equal(configv1alpha2.MeshRootCertificateIntent(constants.MRCIntentActive), newMRC.Intent)
+	assert.Equal("osm-mesh-root-certificate-2-ca-bundle", newMRC.Spec.Provider.Tresor.CA.SecretRef.Name)
+	assert.Equal("cluster.local", newMRC.Spec.TrustDomain)
+}
+
+func TestMeshRotateRootWaitsForBootstrapSecrets(t *testing.T) {
+	testCases := []struct {
+		name            string
+		signer          func(env *rotationTestEnv) *certificate.Certificate
+		trusted         func(env *rotationTestEnv) []*certificate.Certificate
+		expectedStep    rotationStep
+		expectedWaiting string
+	}{
+		{
+			name:            "bootstrap secret does not trust the new root",
+			signer:          func(env *rotationTestEnv) *certificate.Certificate { return env.oldCA },
+			trusted:         func(env *rotationTestEnv) []*certificate.Certificate { return []*certificate.Certificate{env.oldCA} },
+			expectedStep:    rotationStepActivate,
+			expectedWaiting: "Waiting: 1 pod(s) must be restarted to trust the new root certificate: app/pod-1",
+		},
+		{
+			name:   "bootstrap certificate signed by the old root",
+			signer: func(env *rotationTestEnv) *certificate.Certificate { return env.oldCA },
+			trusted: func(env *rotationTestEnv) []*certificate.Certificate {
+				return []*certificate.Certificate{env.oldCA, env.newCA}
+			},
+			expectedStep:    rotationStepDelete,
+			expectedWaiting: "Waiting: 1 pod(s) must be restarted to have a bootstrap certificate signed by the new root certificate: app/pod-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			env := newRotationTestEnv(t, constants.MRCIntentActive)
+			env.addPod(t, "pod-1", tc.signer(env), tc.trusted(env)...)
+			env.cmd.timeout = 50 * time.Millisecond
+
+			err := env.cmd.run()
+			assert.EqualError(err, "Timed out waiting to "+string(tc.expectedStep)+", run this command again to resume the rotation")
+			assert.Contains(env.out.String(), "Next step: "+string(tc.expectedStep))
+			assert.Contains(env.out.String(), tc.expectedWaiting)
+			assert.NotContains(env.out.String(), "Done: "+string(tc.expectedStep))
+		})
+	}
+}
+
+func TestMeshRotateRootResume(t *testing.T) {
+	assert := tassert.New(t)
+
+	newMRC := &configv1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: testNewMRC, Namespace: "osm-system"},
+		Intent:     constants.MRCIntentActive,
+	}
+	env := newRotationTestEnv(t, constants.MRCIntentPassive, newMRC)
+	env.addPod(t, "pod-1", env.newCA, env.newCA)
+
+	err := env.cmd.run()
+	assert.NoError(err, env.out.String())
+
+	out := env.out.String()
+	assert.NotContains(out, "Done: "+string(rotationStepCreate))
+	assert.NotContains(out, "Done: "+string(rotationStepActivate))
+	assert.NotContains(out, "Done: "+string(rotationStepRetire))
+	assert.Contains(out, "Done: "+string(rotationStepDelete))
+
+	_, err = env.configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").Get(context.TODO(), testOldMRC, metav1.GetOptions{})
+	assert.Error(err)
+}
+
+func TestMeshRotateRootDryRun(t *testing.T) {
+	testCases := []struct {
+		name            string
+		mrcs            []*configv1alpha2.MeshRootCertificate
+		expectedSteps   []rotationStep
+		expectedWaiting string
+	}{
+		{
+			name:            "new MRC missing",
+			expectedSteps:   []rotationStep{rotationStepCreate, rotationStepActivate, rotationStepRetire, rotationStepDelete},
+			expectedWaiting: "[dry-run] Would wait: the control plane does not trust the root certificate of MRC osm-mesh-root-certificate-2 yet",
+		},
+		{
+			name: "new MRC passive",
+			mrcs: []*configv1alpha2.MeshRootCertificate{{
+				ObjectMeta: metav1.ObjectMeta{Name: testNewMRC, Namespace: "osm-system"},
+				Intent:     constants.MRCIntentPassive,
+			}},
+			expectedSteps:   []rotationStep{rotationStepActivate, rotationStepRetire, rotationStepDelete},
+			expectedWaiting: "[dry-run] Would wait: 1 pod(s) must be restarted to trust the new root certificate: app/pod-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			env := newRotationTestEnv(t, "", tc.mrcs...)
+			env.addPod(t, "pod-1", env.oldCA, env.oldCA)
+			env.cmd.dryRun = true
+
+			err := env.cmd.run()
+			assert.NoError(err, env.out.String())
+
+			out := env.out.String()
+			for _, step := range tc.expectedSteps {
+				assert.Contains(out, "[dry-run] Would "+string(step))
+			}
+			assert.NotContains(out, "Done: ")
+			assert.Contains(out, tc.expectedWaiting)
+			assert.Contains(out, "[dry-run] Root certificate rotation to MRC osm-mesh-root-certificate-2 would be complete")
+
+			mrcs, err := env.configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").List(context.TODO(), metav1.ListOptions{})
+			assert.NoError(err)
+			assert.Len(mrcs.Items, len(tc.mrcs)+1)
+			for _, mrc := range mrcs.Items {
+				if mrc.Name == testOldMRC {
+					assert.Empty(mrc.Intent)
+				} else {
+					assert.Equal(configv1alpha2.MeshRootCertificateIntent(constants.MRCIntentPassive), mrc.Intent)
+				}
+			}
+		})
+	}
+}
+
+func TestMeshRotateRootWaitsForMRCStatus(t *testing.T) {
+	assert := tassert.New(t)
+
+	newMRC := &configv1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: testNewMRC, Namespace: "osm-system"},
+		Intent:     constants.MRCIntentPassive,
+		Status: configv1alpha2.MeshRootCertificateStatus{
+			ComponentStatuses: configv1alpha2.MeshRootCertificateComponentStatuses{Sidecar: "False"},
+		},
+	}
+	env := newRotationTestEnv(t, constants.MRCIntentActive, newMRC)
+	env.addPod(t, "pod-1", env.oldCA, env.oldCA, env.newCA)
+	env.cmd.timeout = 50 * time.Millisecond
+
+	err := env.cmd.run()
+	assert.Error(err)
+	assert.Contains(env.out.String(), "Waiting: MRC osm-mesh-root-certificate-2 has the sidecar component status False")
+	assert.NotContains(env.out.String(), "Done: ")
+}
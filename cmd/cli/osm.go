@@ -38,6 +38,7 @@ func newRootCmd(config *action.Configuration, stdin io.Reader, stdout io.Writer,
 	// Add subcommands here
 	cmd.AddCommand(
 		newMeshCmd(config, stdin, stdout),
+		newCertificateCmd(config, stdout),
 		newEnvCmd(stdout, stderr),
 		newNamespaceCmd(stdout),
 		newMetricsCmd(stdout),
@@ -47,6 +48,7 @@ func newRootCmd(config *action.Configuration, stdin io.Reader, stdout io.Writer,
 		newSupportCmd(config, stdout, stderr),
 		newUninstallCmd(config, stdin, stdout),
 		newVerifyCmd(stdout, stderr),
+		newVMCmd(stdout),
 	)
 
 	// Add subcommands related to unmanaged environments
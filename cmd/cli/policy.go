@@ -11,7 +11,7 @@ This command consists of subcommands related to traffic policies
 associated with osm.
 `
 
-func newPolicyCmd(stdout io.Writer, _ io.Writer) *cobra.Command {
+func newPolicyCmd(stdout io.Writer, stderr io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "policy",
 		Short: "manage and check traffic policies",
@@ -20,6 +20,8 @@ func newPolicyCmd(stdout io.Writer, _ io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(newPolicyCheckPods(stdout))
 	cmd.AddCommand(newPolicyCheckConflicts(stdout))
+	cmd.AddCommand(newPolicyCheckPorts(stdout))
+	cmd.AddCommand(newPolicySimulateCmd(stdout, stderr))
 
 	return cmd
 }
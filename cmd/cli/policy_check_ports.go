@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/compute/kube"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const policyCheckPortsDescription = `
+This command lists the service ports whose protocol is ambiguous. The protocol
+of a service port is ambiguous when it is neither specified by the port's
+appProtocol field nor prefixed to the port's name (e.g. tcp-db), in which case
+the protocol defaults to http. Traffic on such ports that is not HTTP based
+will not be proxied correctly.
+
+To resolve the ambiguity, set the port's appProtocol field or prefix its name
+with the protocol. The protocol can be set to '%s' to detect whether traffic
+is HTTP based per connection. Ports serving server-first protocols, such as
+MySQL and SMTP, can be marked opaque by setting the protocol to '%s' or by
+listing them in the service's '%s' annotation.
+
+By default, the services in namespaces monitored by any mesh are checked.
+`
+
+const policyCheckPortsExample = `
+# To list the service ports whose protocol is ambiguous in namespaces monitored by any mesh
+osm policy check-ports
+
+# To list the service ports whose protocol is ambiguous in the 'bookstore' namespace
+osm policy check-ports -n bookstore
+`
+
+type policyCheckPortsCmd struct {
+	out        io.Writer
+	namespaces []string
+	clientSet  kubernetes.Interface
+}
+
+func newPolicyCheckPorts(out io.Writer) *cobra.Command {
+	policyCheckPortsCmd := &policyCheckPortsCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "check-ports",
+		Short: "list service ports whose protocol is ambiguous",
+		Long:  fmt.Sprintf(policyCheckPortsDescription, constants.ProtocolAuto, constants.ProtocolOpaque, constants.OpaquePortsAnnotation),
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			policyCheckPortsCmd.clientSet = clientset
+
+			return policyCheckPortsCmd.run()
+		},
+		Example: policyCheckPortsExample,
+	}
+
+	f := cmd.Flags()
+	f.StringSliceVarP(&policyCheckPortsCmd.namespaces, "namespaces", "n", []string{}, "One or more namespaces to limit the check to")
+
+	return cmd
+}
+
+func (cmd *policyCheckPortsCmd) run() error {
+	namespaces := cmd.namespaces
+	if len(namespaces) == 0 {
+		monitoredNamespaces, err := selectNamespacesMonitoredByMesh("", cmd.clientSet)
+		if err != nil {
+			return fmt.Errorf("Could not list namespaces monitored by any mesh: %w", err)
+		}
+		for _, ns := range monitoredNamespaces.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	var ambiguousPorts [][]string
+	for _, ns := range namespaces {
+		services, err := cmd.clientSet.CoreV1().Services(ns).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing services in namespace %s: %w", ns, err)
+		}
+
+		for _, svc := range services.Items {
+			for _, portSpec := range svc.Spec.Ports {
+				// Only TCP based ports are proxied
+				if portSpec.Protocol != "" && portSpec.Protocol != corev1.ProtocolTCP {
+					continue
+				}
+				if !kube.IsServicePortProtocolAmbiguous(svc, portSpec) {
+					continue
+				}
+
+				portName := portSpec.Name
+				if portName == "" {
+					portName = "-" // not set
+				}
+				ambiguousPorts = append(ambiguousPorts, []string{svc.Namespace, svc.Name, fmt.Sprintf("%d", portSpec.Port), portName, kube.GetServicePortProtocol(svc, portSpec)})
+			}
+		}
+	}
+
+	if len(ambiguousPorts) == 0 {
+		fmt.Fprintf(cmd.out, "[+] No service ports with an ambiguous protocol found\n")
+		return nil
+	}
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "NAMESPACE\tSERVICE\tPORT\tPORT-NAME\tDEFAULT-PROTOCOL")
+	for _, port := range ambiguousPorts {
+		fmt.Fprintln(w, strings.Join(port, "\t"))
+	}
+	_ = w.Flush()
+
+	fmt.Fprintf(cmd.out, "\n[+] Set the appProtocol field of the ports above, or prefix their names with the protocol. "+
+		"Use '%s' to detect whether traffic is HTTP based per connection.\n", constants.ProtocolAuto)
+
+	return nil
+}
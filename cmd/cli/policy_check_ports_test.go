@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestPolicyCheckPortsRun(t *testing.T) {
+	monitoredNs := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "bookstore",
+			Labels: map[string]string{constants.OSMKubeResourceMonitorAnnotation: "osm"},
+		},
+	}
+	unmonitoredNs := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other",
+		},
+	}
+	bookstoreSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore",
+			Namespace: monitoredNs.Name,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "web", Port: 80},
+				{Name: "tcp-db", Port: 5432},
+				{Name: "admin", Port: 9000, AppProtocol: pointer.StringPtr(constants.ProtocolAuto)},
+				{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+			},
+		},
+	}
+	otherSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: unmonitoredNs.Name,
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Port: 8080},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		namespaces        []string
+		existingResources []runtime.Object
+		expectedOut       []string
+		unexpectedOut     []string
+	}{
+		{
+			name:              "ambiguous ports in monitored namespaces",
+			existingResources: []runtime.Object{monitoredNs, unmonitoredNs, bookstoreSvc, otherSvc},
+			expectedOut:       []string{"bookstore   bookstore   80     web         http"},
+			unexpectedOut:     []string{"5432", "9000", "53", "8080"},
+		},
+		{
+			name:              "ambiguous ports in the given namespaces",
+			namespaces:        []string{unmonitoredNs.Name},
+			existingResources: []runtime.Object{monitoredNs, unmonitoredNs, bookstoreSvc, otherSvc},
+			expectedOut:       []string{"other       other     8080   -           http"},
+			unexpectedOut:     []string{"bookstore"},
+		},
+		{
+			name:              "no ambiguous ports",
+			existingResources: []runtime.Object{monitoredNs},
+			expectedOut:       []string{"[+] No service ports with an ambiguous protocol found"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+			out := new(bytes.Buffer)
+
+			cmd := &policyCheckPortsCmd{
+				out:        out,
+				namespaces: tc.namespaces,
+				clientSet:  fake.NewSimpleClientset(tc.existingResources...),
+			}
+
+			a.NoError(cmd.run())
+			for _, s := range tc.expectedOut {
+				a.Contains(out.String(), s)
+			}
+			for _, s := range tc.unexpectedOut {
+				a.NotContains(out.String(), s)
+			}
+		})
+	}
+}
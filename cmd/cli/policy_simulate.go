@@ -0,0 +1,332 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/cli/simulator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const policySimulateDescription = `
+This command computes the listeners, routes, clusters and endpoints the OSM
+controller would program on the sidecar of a pod, using the Kubernetes
+resources defined in the YAML and JSON files of the given directory instead
+of a live cluster.
+
+The directory may contain Namespaces, Services, Endpoints, ServiceAccounts,
+Pods and workloads (Deployments, StatefulSets, DaemonSets, ReplicaSets), SMI
+and OSM policies, and a MeshConfig. Workloads are expanded into pods named
+<workload>-<index>, services are given endpoints for the pods they select,
+and every namespace is treated as part of the mesh. Resources of other kinds
+are ignored. When no MeshConfig is given, the MeshConfig OSM is installed
+with by default is used, as set by the default values of the Helm chart.
+`
+
+const policySimulateExample = `
+# Summarize the configuration of the sidecar on the 'bookbuyer-0' pod in the 'bookbuyer' namespace
+osm policy simulate ./manifests --pod bookbuyer/bookbuyer-0
+
+# Print the configuration of the sidecar on a pod running as the 'bookstore' service account, as YAML
+osm policy simulate ./manifests --service-account bookstore/bookstore -o yaml
+`
+
+const (
+	// presetMeshConfigTemplate is the template of the Helm chart rendering the ConfigMap the default MeshConfig is
+	// created from by osm-bootstrap
+	presetMeshConfigTemplate = "templates/preset-mesh-config.yaml"
+
+	// presetMeshConfigJSONKey is the key of the ConfigMap of presetMeshConfigTemplate holding the MeshConfig spec
+	presetMeshConfigJSONKey = "preset-mesh-config.json"
+)
+
+const (
+	simulateOutputSummary = "summary"
+	simulateOutputJSON    = "json"
+	simulateOutputYAML    = "yaml"
+)
+
+type policySimulateCmd struct {
+	out            io.Writer
+	errOut         io.Writer
+	dir            string
+	pod            string
+	serviceAccount string
+	output         string
+	verbose        bool
+}
+
+// simulatedConfig is the xDS configuration printed by 'osm policy simulate' in the json and yaml output formats
+type simulatedConfig struct {
+	Listeners []json.RawMessage `json:"listeners"`
+	Routes    []json.RawMessage `json:"routes"`
+	Clusters  []json.RawMessage `json:"clusters"`
+	Endpoints []json.RawMessage `json:"endpoints"`
+}
+
+func newPolicySimulateCmd(out io.Writer, errOut io.Writer) *cobra.Command {
+	simulateCmd := &policySimulateCmd{
+		out:    out,
+		errOut: errOut,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "simulate DIR",
+		Short: "compute the proxy config for a workload from local manifests",
+		Long:  policySimulateDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			simulateCmd.dir = args[0]
+			if (simulateCmd.pod == "") == (simulateCmd.serviceAccount == "") {
+				return fmt.Errorf("Exactly one of --pod or --service-account must be specified")
+			}
+			switch simulateCmd.output {
+			case simulateOutputSummary, simulateOutputJSON, simulateOutputYAML:
+			default:
+				return fmt.Errorf("Invalid output format %q, must be one of: %s, %s, %s", simulateCmd.output,
+					simulateOutputSummary, simulateOutputJSON, simulateOutputYAML)
+			}
+			return simulateCmd.run()
+		},
+		Example: policySimulateExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&simulateCmd.pod, "pod", "", "Pod to simulate the sidecar configuration of, in the form <namespace/pod>, or <pod> for default namespace")
+	f.StringVar(&simulateCmd.serviceAccount, "service-account", "", "Service account of a pod to simulate the sidecar configuration of, in the form <namespace/name>, or <name> for default namespace")
+	f.StringVarP(&simulateCmd.output, "output", "o", simulateOutputSummary, "Output format, one of: summary, json, yaml")
+	f.BoolVar(&simulateCmd.verbose, "verbose", false, "Print the control plane logs emitted during the simulation")
+
+	return cmd
+}
+
+func (cmd *policySimulateCmd) run() error {
+	if !cmd.verbose {
+		if err := logger.SetLogLevel("disabled"); err != nil {
+			return err
+		}
+	}
+
+	objects, skipped, err := simulator.LoadDir(cmd.dir)
+	if err != nil {
+		return fmt.Errorf("Error loading resources from %s: %w", cmd.dir, err)
+	}
+	for _, s := range skipped {
+		fmt.Fprintf(cmd.errOut, "Ignoring resource of unsupported kind in %s\n", s)
+	}
+	if !hasMeshConfig(objects) {
+		meshConfig, err := getPresetMeshConfig()
+		if err != nil {
+			return fmt.Errorf("Error rendering the default MeshConfig: %w", err)
+		}
+		objects = append(objects, meshConfig)
+	}
+
+	sim, err := simulator.New(objects)
+	if err != nil {
+		return fmt.Errorf("Error initializing simulation: %w", err)
+	}
+	defer sim.Close()
+
+	var pod *corev1.Pod
+	if cmd.pod != "" {
+		namespace, name, err := unmarshalNamespacedPod(cmd.pod)
+		if err != nil {
+			return err
+		}
+		pod, err = sim.GetPod(namespace, name)
+		if err != nil {
+			return err
+		}
+	} else {
+		namespace, name, err := unmarshalNamespacedPod(cmd.serviceAccount)
+		if err != nil {
+			return err
+		}
+		pod, err = sim.GetPodForServiceAccount(identity.K8sServiceAccount{Name: name, Namespace: namespace})
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := sim.Simulate(pod)
+	if err != nil {
+		return fmt.Errorf("Error simulating configuration for pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	switch cmd.output {
+	case simulateOutputJSON, simulateOutputYAML:
+		return cmd.printConfig(result)
+	default:
+		cmd.printSummary(result)
+		return nil
+	}
+}
+
+// hasMeshConfig returns whether the given objects include a MeshConfig
+func hasMeshConfig(objects []runtime.Object) bool {
+	for _, obj := range objects {
+		if _, ok := obj.(*configv1alpha2.MeshConfig); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getPresetMeshConfig returns the MeshConfig osm-bootstrap creates when OSM is installed with the default values of
+// the Helm chart
+func getPresetMeshConfig() (*configv1alpha2.MeshConfig, error) {
+	chartRequested, err := loader.LoadArchive(bytes.NewReader(chartTGZSource))
+	if err != nil {
+		return nil, err
+	}
+	values, err := chartutil.ToRenderValues(chartRequested, nil, chartutil.ReleaseOptions{Name: defaultMeshName, Namespace: simulator.DefaultOSMNamespace}, chartutil.DefaultCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := engine.Render(chartRequested, values)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, ok := rendered[chartRequested.Name()+"/"+presetMeshConfigTemplate]
+	if !ok {
+		return nil, fmt.Errorf("chart %s has no template %s", chartRequested.Name(), presetMeshConfigTemplate)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := yaml.Unmarshal([]byte(manifest), configMap); err != nil {
+		return nil, err
+	}
+	meshConfig := &configv1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OSMMeshConfig,
+			Namespace: simulator.DefaultOSMNamespace,
+		},
+	}
+	if err := json.Unmarshal([]byte(configMap.Data[presetMeshConfigJSONKey]), &meshConfig.Spec); err != nil {
+		return nil, err
+	}
+	return meshConfig, nil
+}
+
+// printConfig prints the simulated xDS resources as JSON or YAML
+func (cmd *policySimulateCmd) printConfig(result *simulator.Result) error {
+	config := simulatedConfig{}
+	for typeURI, dst := range map[envoy.TypeURI]*[]json.RawMessage{
+		envoy.TypeLDS: &config.Listeners,
+		envoy.TypeRDS: &config.Routes,
+		envoy.TypeCDS: &config.Clusters,
+		envoy.TypeEDS: &config.Endpoints,
+	} {
+		*dst = []json.RawMessage{}
+		for _, res := range result.Resources[typeURI] {
+			resJSON, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(res)
+			if err != nil {
+				return fmt.Errorf("Error marshaling %s resource: %w", typeURI.Short(), err)
+			}
+			*dst = append(*dst, resJSON)
+		}
+	}
+
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	if cmd.output == simulateOutputYAML {
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(cmd.out, string(out))
+	return nil
+}
+
+// printSummary prints a human readable summary of the simulated xDS resources
+func (cmd *policySimulateCmd) printSummary(result *simulator.Result) {
+	fmt.Fprintf(cmd.out, "Pod: %s/%s\n", result.Pod.Namespace, result.Pod.Name)
+	fmt.Fprintf(cmd.out, "Identity: %s\n", result.Proxy.Identity)
+	fmt.Fprintf(cmd.out, "Proxy UUID: %s\n", result.Proxy.UUID)
+
+	fmt.Fprintf(cmd.out, "\nListeners:\n")
+	printSummaryItems(cmd.out, result.Resources[envoy.TypeLDS], func(res types.Resource) []string {
+		listener := res.(*xds_listener.Listener)
+		lines := []string{fmt.Sprintf("%s (%s)", listener.Name, formatSocketAddress(listener.Address))}
+		for _, filterChain := range listener.FilterChains {
+			lines = append(lines, "  filter chain "+filterChain.Name)
+		}
+		return lines
+	})
+
+	fmt.Fprintf(cmd.out, "\nRoutes:\n")
+	printSummaryItems(cmd.out, result.Resources[envoy.TypeRDS], func(res types.Resource) []string {
+		routeConfig := res.(*xds_route.RouteConfiguration)
+		lines := []string{routeConfig.Name}
+		for _, vh := range routeConfig.VirtualHosts {
+			lines = append(lines, fmt.Sprintf("  virtual host %s: %d domain(s), %d route(s)", vh.Name, len(vh.Domains), len(vh.Routes)))
+		}
+		return lines
+	})
+
+	fmt.Fprintf(cmd.out, "\nClusters:\n")
+	printSummaryItems(cmd.out, result.Resources[envoy.TypeCDS], func(res types.Resource) []string {
+		return []string{res.(*xds_cluster.Cluster).Name}
+	})
+
+	fmt.Fprintf(cmd.out, "\nEndpoints:\n")
+	printSummaryItems(cmd.out, result.Resources[envoy.TypeEDS], func(res types.Resource) []string {
+		cla := res.(*xds_endpoint.ClusterLoadAssignment)
+		lines := []string{cla.ClusterName}
+		for _, localityEndpoints := range cla.Endpoints {
+			for _, lbEndpoint := range localityEndpoints.LbEndpoints {
+				lines = append(lines, "  "+formatSocketAddress(lbEndpoint.GetEndpoint().GetAddress()))
+			}
+		}
+		return lines
+	})
+}
+
+// printSummaryItems prints the lines describing each of the given resources, indented under a section heading
+func printSummaryItems(out io.Writer, resources []types.Resource, describe func(types.Resource) []string) {
+	if len(resources) == 0 {
+		fmt.Fprintln(out, "  none")
+		return
+	}
+	for _, res := range resources {
+		for _, line := range describe(res) {
+			fmt.Fprintf(out, "  %s\n", line)
+		}
+	}
+}
+
+// formatSocketAddress returns the given Envoy address in the form <ip>:<port>
+func formatSocketAddress(addr *xds_core.Address) string {
+	socketAddr := addr.GetSocketAddress()
+	if socketAddr == nil {
+		return "-"
+	}
+	return net.JoinHostPort(socketAddr.Address, strconv.FormatUint(uint64(socketAddr.GetPortValue()), 10))
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+)
+
+const simulateTestDir = "../../pkg/cli/simulator/testdata/bookstore"
+
+func TestPolicySimulate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		args             []string
+		expectedErr      string
+		expectedContains []string
+	}{
+		{
+			name: "summary for pod",
+			args: []string{simulateTestDir, "--pod", "bookbuyer/bookbuyer-0"},
+			expectedContains: []string{
+				"Pod: bookbuyer/bookbuyer-0\n",
+				"Identity: bookbuyer.bookbuyer\n",
+				"  outbound-listener (0.0.0.0:15001)\n    filter chain outbound_bookstore/bookstore_14001_http\n",
+				"Clusters:\n  bookstore/bookstore|14001\n",
+				"  bookstore/bookstore|14001\n    10.244.0.2:14001\n    10.244.0.3:14001\n",
+			},
+		},
+		{
+			name: "summary for service account",
+			args: []string{simulateTestDir, "--service-account", "bookstore/bookstore"},
+			expectedContains: []string{
+				"Pod: bookstore/bookstore-0\n",
+				"Endpoints:\n  none\n",
+			},
+		},
+		{
+			name:        "pod and service account are mutually exclusive",
+			args:        []string{simulateTestDir, "--pod", "bookbuyer/bookbuyer-0", "--service-account", "bookbuyer/bookbuyer"},
+			expectedErr: "Exactly one of --pod or --service-account must be specified",
+		},
+		{
+			name:        "invalid output format",
+			args:        []string{simulateTestDir, "--pod", "bookbuyer/bookbuyer-0", "-o", "xml"},
+			expectedErr: `Invalid output format "xml", must be one of: summary, json, yaml`,
+		},
+		{
+			name:        "unknown pod",
+			args:        []string{simulateTestDir, "--pod", "bookbuyer/bookbuyer-1"},
+			expectedErr: "pod not found: bookbuyer/bookbuyer-1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			out := new(bytes.Buffer)
+			errOut := new(bytes.Buffer)
+			cmd := newPolicySimulateCmd(out, errOut)
+			cmd.SetArgs(tc.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			err := cmd.Execute()
+			if tc.expectedErr != "" {
+				assert.EqualError(err, tc.expectedErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Contains(errOut.String(), "Ignoring resource of unsupported kind")
+			for _, expected := range tc.expectedContains {
+				assert.Contains(out.String(), expected)
+			}
+		})
+	}
+}
+
+func TestPolicySimulateJSON(t *testing.T) {
+	assert := tassert.New(t)
+
+	out := new(bytes.Buffer)
+	cmd := newPolicySimulateCmd(out, new(bytes.Buffer))
+	cmd.SetArgs([]string{simulateTestDir, "--pod", "bookbuyer/bookbuyer-0", "-o", "json"})
+	assert.NoError(cmd.Execute())
+
+	var config simulatedConfig
+	assert.NoError(json.Unmarshal(out.Bytes(), &config))
+	assert.Len(config.Listeners, 1)
+	assert.Len(config.Routes, 1)
+	assert.Len(config.Clusters, 1)
+	assert.Len(config.Endpoints, 1)
+	assert.Contains(string(config.Clusters[0]), `"name": "bookstore/bookstore|14001"`)
+}
+
+func TestPolicySimulateDefaultMeshConfig(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	defaultChartSource := chartTGZSource
+	defer func() { chartTGZSource = defaultChartSource }()
+	var err error
+	chartTGZSource, err = cli.GetChartSource(filepath.Join("..", "..", "charts", "osm"))
+	require.NoError(err)
+
+	meshConfig, err := getPresetMeshConfig()
+	require.NoError(err)
+	assert.Equal("osm-mesh-config", meshConfig.Name)
+	assert.Equal("osm-system", meshConfig.Namespace)
+	assert.True(meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode)
+	assert.True(meshConfig.Spec.Traffic.EnableEgress)
+	assert.Equal("error", meshConfig.Spec.Sidecar.LogLevel)
+
+	// Simulate the manifests without their MeshConfig
+	dir := t.TempDir()
+	for _, file := range []string{"policies.yaml", "workloads.yaml"} {
+		content, err := os.ReadFile(filepath.Join(simulateTestDir, file))
+		require.NoError(err)
+		require.NoError(os.WriteFile(filepath.Join(dir, file), content, 0600))
+	}
+
+	out := new(bytes.Buffer)
+	cmd := newPolicySimulateCmd(out, new(bytes.Buffer))
+	cmd.SetArgs([]string{dir, "--pod", "bookbuyer/bookbuyer-0"})
+	require.NoError(cmd.Execute())
+	// Egress is enabled by default
+	assert.Contains(out.String(), "Clusters:\n  bookstore/bookstore|14001\n  passthrough-outbound\n")
+}
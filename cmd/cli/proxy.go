@@ -41,6 +41,8 @@ func newProxyCmd(config *action.Configuration, out io.Writer) *cobra.Command {
 	}
 	cmd.AddCommand(newProxyGetCmd(config, out))
 	cmd.AddCommand(newProxySetCmd(config, out))
+	cmd.AddCommand(newProxyStatusCmd(config, out))
+	cmd.AddCommand(newProxyDiffCmd(config, out))
 
 	return cmd
 }
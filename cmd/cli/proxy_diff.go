@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const proxyDiffCmdDescription = `
+This command shows the difference in the listeners, routes, clusters and
+endpoints sent to the proxy on the given pod between two configuration
+versions. The versions are the ones listed with the --list flag. By default,
+the latest version is compared with the version preceding it.
+
+The debug server must be enabled in the MeshConfig for this command to work,
+and only the most recent versions sent while it was enabled are available.
+`
+
+const proxyDiffCmdExample = `
+# List the configuration versions available for the pod 'bookbuyer-5ccf77f46d-rc5mg' in the 'bookbuyer' namespace
+osm proxy diff bookbuyer-5ccf77f46d-rc5mg -n bookbuyer --list
+
+# Show what changed in the latest configuration version sent to the pod
+osm proxy diff bookbuyer-5ccf77f46d-rc5mg -n bookbuyer
+
+# Show what changed between versions 3 and 5
+osm proxy diff bookbuyer-5ccf77f46d-rc5mg -n bookbuyer --from 3 --to 5
+`
+
+const (
+	snapshotHistoryPath = "/debug/xds/history"
+	xdsDiffPath         = "/debug/xds/diff"
+)
+
+type proxyDiffCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	namespace string
+	pod       string
+	from      uint64
+	to        uint64
+	list      bool
+	localPort uint16
+}
+
+func newProxyDiffCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	diffCmd := &proxyDiffCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff POD",
+		Short: "show the config changes between versions sent to a proxy",
+		Long:  proxyDiffCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			diffCmd.pod = args[0]
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			diffCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			diffCmd.clientSet = clientset
+			return diffCmd.run()
+		},
+		Example: proxyDiffCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&diffCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of pod")
+	f.Uint64Var(&diffCmd.from, "from", 0, "Version to compare from, defaults to the version preceding --to")
+	f.Uint64Var(&diffCmd.to, "to", 0, "Version to compare to, defaults to the latest version")
+	f.BoolVar(&diffCmd.list, "list", false, "List the available versions instead of showing a diff")
+	f.Uint16VarP(&diffCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *proxyDiffCmd) run() error {
+	pod, err := cmd.clientSet.CoreV1().Pods(cmd.namespace).Get(context.TODO(), cmd.pod, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Error getting pod %s/%s: %w", cmd.namespace, cmd.pod, err)
+	}
+	if !isMeshedPod(*pod) {
+		return fmt.Errorf("Pod %s/%s is not a part of a mesh", cmd.namespace, cmd.pod)
+	}
+	proxyUUID := pod.Labels[constants.EnvoyUniqueIDLabelName]
+
+	query := url.Values{}
+	query.Set("proxy", proxyUUID)
+
+	if cmd.list {
+		resp, err := cli.ExecuteControllerDebugReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, snapshotHistoryPath+"?"+query.Encode())
+		if err != nil {
+			return annotateErrorMessageWithOsmNamespace("Error fetching configuration versions: %s", err)
+		}
+		var history []envoy.SnapshotRecord
+		if err := json.Unmarshal(resp, &history); err != nil {
+			return fmt.Errorf("Error decoding configuration versions: %w", err)
+		}
+		return cmd.printHistory(history)
+	}
+
+	if cmd.from != 0 {
+		query.Set("from", strconv.FormatUint(cmd.from, 10))
+	}
+	if cmd.to != 0 {
+		query.Set("to", strconv.FormatUint(cmd.to, 10))
+	}
+	resp, err := cli.ExecuteControllerDebugReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, xdsDiffPath+"?"+query.Encode())
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error fetching configuration diff: %s", err)
+	}
+	var diff envoy.XDSDiff
+	if err := json.Unmarshal(resp, &diff); err != nil {
+		return fmt.Errorf("Error decoding configuration diff: %w", err)
+	}
+	cmd.printDiff(diff)
+	return nil
+}
+
+func (cmd *proxyDiffCmd) printHistory(history []envoy.SnapshotRecord) error {
+	if len(history) == 0 {
+		fmt.Fprintf(cmd.out, "No configuration versions recorded for pod %s/%s\n", cmd.namespace, cmd.pod)
+		return nil
+	}
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "VERSION\tCREATED\tTRIGGERS")
+	for _, record := range history {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", record.Version, record.CreatedAt.Format("2006-01-02T15:04:05Z07:00"), formatTriggers(record.Triggers))
+	}
+	return w.Flush()
+}
+
+func (cmd *proxyDiffCmd) printDiff(diff envoy.XDSDiff) {
+	if diff.From.Version == 0 {
+		fmt.Fprintf(cmd.out, "Version %d (%s) is the oldest version available, showing its full configuration\n\n",
+			diff.To.Version, formatTriggers(diff.To.Triggers))
+	} else {
+		fmt.Fprintf(cmd.out, "Version %d (%s) -> version %d (%s)\n\n",
+			diff.From.Version, formatTriggers(diff.From.Triggers), diff.To.Version, formatTriggers(diff.To.Triggers))
+	}
+
+	for _, typeURI := range []envoy.TypeURI{envoy.TypeLDS, envoy.TypeRDS, envoy.TypeCDS, envoy.TypeEDS} {
+		typeDiff := diff.Types[typeURI.Short()]
+		if typeDiff.IsEmpty() {
+			fmt.Fprintf(cmd.out, "%s: no changes\n", typeURI.Short())
+			continue
+		}
+
+		fmt.Fprintf(cmd.out, "%s:\n", typeURI.Short())
+		for _, name := range typeDiff.Added {
+			fmt.Fprintf(cmd.out, "  + %s\n", name)
+		}
+		for _, name := range typeDiff.Removed {
+			fmt.Fprintf(cmd.out, "  - %s\n", name)
+		}
+		for _, resourceDiff := range typeDiff.Modified {
+			fmt.Fprintf(cmd.out, "  ~ %s\n", resourceDiff.Name)
+			for _, line := range strings.Split(strings.TrimRight(resourceDiff.Diff, "\n"), "\n") {
+				fmt.Fprintf(cmd.out, "      %s\n", line)
+			}
+		}
+	}
+}
+
+func formatTriggers(triggers []string) string {
+	if len(triggers) == 0 {
+		return "-"
+	}
+	return strings.Join(triggers, ", ")
+}
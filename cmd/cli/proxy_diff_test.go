@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestProxyDiffPrintDiff(t *testing.T) {
+	assert := tassert.New(t)
+	out := new(bytes.Buffer)
+	cmd := &proxyDiffCmd{out: out}
+
+	cmd.printDiff(envoy.XDSDiff{
+		From: envoy.SnapshotRecord{Version: 2, Triggers: []string{"service-added"}},
+		To:   envoy.SnapshotRecord{Version: 3, Triggers: []string{"pod-updated", "service-updated"}},
+		Types: map[string]envoy.XDSTypeDiff{
+			"LDS": {Added: []string{"outbound"}},
+			"CDS": {
+				Removed: []string{"b"},
+				Modified: []envoy.XDSResourceDiff{
+					{Name: "a", Diff: "- 1s\n+ 2s\n"},
+				},
+			},
+		},
+	})
+
+	assert.Equal(`Version 2 (service-added) -> version 3 (pod-updated, service-updated)
+
+LDS:
+  + outbound
+RDS: no changes
+CDS:
+  - b
+  ~ a
+      - 1s
+      + 2s
+EDS: no changes
+`, out.String())
+}
+
+func TestProxyDiffPrintHistory(t *testing.T) {
+	assert := tassert.New(t)
+	out := new(bytes.Buffer)
+	cmd := &proxyDiffCmd{out: out, namespace: "ns", pod: "pod"}
+
+	assert.NoError(cmd.printHistory(nil))
+	assert.Equal("No configuration versions recorded for pod ns/pod\n", out.String())
+
+	out.Reset()
+	assert.NoError(cmd.printHistory([]envoy.SnapshotRecord{
+		{Version: 1, Triggers: []string{"proxy-connected"}},
+		{Version: 2},
+	}))
+	assert.Equal(`VERSION   CREATED                TRIGGERS
+1         0001-01-01T00:00:00Z   proxy-connected
+2         0001-01-01T00:00:00Z   -
+`, out.String())
+}
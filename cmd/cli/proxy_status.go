@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cli"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const proxyStatusCmdDescription = `
+This command lists the proxies connected to the control plane along with the
+sync status of their configuration. A proxy is:
+  SYNCED if it ACKed the latest configuration sent to it,
+  STALE  if it has not yet ACKed the latest configuration sent to it,
+  NACKED if it rejected the latest configuration sent to it.
+
+The debug server must be enabled in the MeshConfig for this command to work.
+`
+
+const proxyStatusCmdExample = `
+# List the sync status of all the proxies connected to the control plane
+osm proxy status
+
+# List the sync status of the proxies on pods in the 'bookbuyer' namespace
+osm proxy status -n bookbuyer
+`
+
+const xdsSyncStatusPath = "/debug/xds/status"
+
+type proxyStatusCmd struct {
+	out       io.Writer
+	config    *rest.Config
+	clientSet kubernetes.Interface
+	namespace string
+	localPort uint16
+}
+
+func newProxyStatusCmd(config *action.Configuration, out io.Writer) *cobra.Command {
+	statusCmd := &proxyStatusCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "show the config sync status of proxies",
+		Long:  proxyStatusCmdDescription,
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf, err := config.RESTClientGetter.ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+			statusCmd.config = conf
+
+			clientset, err := kubernetes.NewForConfig(conf)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			statusCmd.clientSet = clientset
+			return statusCmd.run()
+		},
+		Example: proxyStatusCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&statusCmd.namespace, "namespace", "n", "", "Namespace of pods to show the status for, defaults to all namespaces")
+	f.Uint16VarP(&statusCmd.localPort, "local-port", "p", constants.DebugPort, "Local port to use for port forwarding")
+
+	return cmd
+}
+
+func (cmd *proxyStatusCmd) run() error {
+	resp, err := cli.ExecuteControllerDebugReq(cmd.clientSet, cmd.config, settings.Namespace(), cmd.localPort, xdsSyncStatusPath)
+	if err != nil {
+		return annotateErrorMessageWithOsmNamespace("Error fetching proxy status: %s", err)
+	}
+
+	var statuses []envoy.ProxySyncStatus
+	if err := json.Unmarshal(resp, &statuses); err != nil {
+		return fmt.Errorf("Error decoding proxy status: %w", err)
+	}
+
+	return cmd.printStatus(statuses)
+}
+
+// printStatus prints the given sync statuses, along with the pod each proxy belongs to
+func (cmd *proxyStatusCmd) printStatus(statuses []envoy.ProxySyncStatus) error {
+	pods, err := cmd.clientSet.CoreV1().Pods(cmd.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: constants.EnvoyUniqueIDLabelName})
+	if err != nil {
+		return fmt.Errorf("Error listing pods: %w", err)
+	}
+
+	podsByUUID := make(map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		podsByUUID[pod.Labels[constants.EnvoyUniqueIDLabelName]] = fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+	}
+
+	w := newTabWriter(cmd.out)
+	fmt.Fprintln(w, "POD\tIDENTITY\tSTATUS\tLAST PUSH\tREASON")
+	for _, status := range statuses {
+		pod, ok := podsByUUID[status.UUID]
+		if !ok {
+			if cmd.namespace != "" {
+				continue
+			}
+			pod = fmt.Sprintf("- (%s)", status.UUID)
+		}
+
+		lastPush := "-"
+		if lastSentAt := status.LastSentAt(); !lastSentAt.IsZero() {
+			lastPush = duration.HumanDuration(time.Since(lastSentAt)) + " ago"
+		}
+
+		reason := status.Reason()
+		if reason == "" {
+			reason = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pod, status.Identity, status.State(), lastPush, reason)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestProxyStatusPrintStatus(t *testing.T) {
+	newPod := func(namespace, name, proxyUUID string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: proxyUUID},
+			},
+		}
+	}
+	newStatus := func(proxyUUID, identity string, nackError string) envoy.ProxySyncStatus {
+		types := make(map[envoy.TypeURI]*envoy.XDSSyncStatus)
+		for _, typeURI := range envoy.XDSResponseOrder {
+			types[typeURI] = &envoy.XDSSyncStatus{LastSentVersion: "1", LastAckedVersion: "1", LastSentAt: time.Now().Add(-time.Minute)}
+		}
+		if nackError != "" {
+			types[envoy.TypeCDS].Nacked = true
+			types[envoy.TypeCDS].LastNackError = nackError
+		}
+		return envoy.ProxySyncStatus{UUID: proxyUUID, Identity: identity, Types: types}
+	}
+
+	statuses := []envoy.ProxySyncStatus{
+		newStatus("uuid-1", "bookbuyer.bookbuyer", ""),
+		newStatus("uuid-2", "bookstore.bookstore", "bad cluster"),
+		{UUID: "uuid-3", Identity: "bookwarehouse.bookwarehouse"},
+	}
+
+	testCases := []struct {
+		name      string
+		namespace string
+		expected  string
+	}{
+		{
+			name: "all namespaces",
+			expected: "POD                     IDENTITY                      STATUS   LAST PUSH   REASON\n" +
+				"bookbuyer/bookbuyer-1   bookbuyer.bookbuyer           SYNCED   60s ago     -\n" +
+				"bookstore/bookstore-1   bookstore.bookstore           NACKED   60s ago     CDS rejected: bad cluster\n" +
+				"- (uuid-3)              bookwarehouse.bookwarehouse   STALE    -           awaiting ACK for CDS, EDS, LDS, RDS, SDS\n",
+		},
+		{
+			name:      "single namespace",
+			namespace: "bookstore",
+			expected: "POD                     IDENTITY              STATUS   LAST PUSH   REASON\n" +
+				"bookstore/bookstore-1   bookstore.bookstore   NACKED   60s ago     CDS rejected: bad cluster\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			out := new(bytes.Buffer)
+			cmd := &proxyStatusCmd{
+				out: out,
+				clientSet: fake.NewSimpleClientset(
+					newPod("bookbuyer", "bookbuyer-1", "uuid-1"),
+					newPod("bookstore", "bookstore-1", "uuid-2"),
+				),
+				namespace: tc.namespace,
+			}
+
+			assert.NoError(cmd.printStatus(statuses))
+			assert.Equal(tc.expected, out.String())
+		})
+	}
+}
@@ -290,10 +290,15 @@ func (d *uninstallMeshCmd) uninstallCustomResourceDefinitions() error {
 	crds := []string{
 		"egresses.policy.openservicemesh.io",
 		"ingressbackends.policy.openservicemesh.io",
+		"certificaterevocations.config.openservicemesh.io",
 		"meshconfigs.config.openservicemesh.io",
 		"meshrootcertificates.config.openservicemesh.io",
+		"remoteclusters.config.openservicemesh.io",
+		"trustdomainfederations.config.openservicemesh.io",
 		"upstreamtrafficsettings.policy.openservicemesh.io",
 		"retries.policy.openservicemesh.io",
+		"workloadentries.policy.openservicemesh.io",
+		"httpfilterextensions.policy.openservicemesh.io",
 		"httproutegroups.specs.smi-spec.io",
 		"tcproutes.specs.smi-spec.io",
 		"trafficsplits.split.smi-spec.io",
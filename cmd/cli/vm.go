@@ -0,0 +1,25 @@
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const vmCmdDescription = `
+This command consists of subcommands related to workloads running outside of
+Kubernetes, such as on VMs or bare-metal hosts, that are registered in the mesh
+with WorkloadEntry resources.
+`
+
+func newVMCmd(stdout io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vm",
+		Short: "manage workloads running outside of Kubernetes",
+		Long:  vmCmdDescription,
+		Args:  cobra.NoArgs,
+	}
+	cmd.AddCommand(newVMBootstrapCmd(stdout))
+
+	return cmd
+}
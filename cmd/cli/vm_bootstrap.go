@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	xds_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	policyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+const vmBootstrapCmdDescription = `
+This command writes the files needed to run the Envoy sidecar of a workload
+registered with a WorkloadEntry resource on the workload's host:
+  - the Envoy bootstrap config and the SDS configs it references,
+  - the certificate and key used by Envoy to connect to the control plane,
+  - iptables.sh, the script redirecting the traffic of the host to Envoy.
+
+The files are generated by osm-injector when the WorkloadEntry is created.
+They must be copied to /etc/envoy on the host, where Envoy is started with
+'envoy -c /etc/envoy/bootstrap.yaml' as the user with UID 1500. iptables.sh
+must then be run as root.
+
+As the host is outside of the cluster, the address of osm-controller's xDS
+server reachable from the host must be given with --xds-address.
+
+osm-injector renews the certificate before it expires, or after the root
+certificate of the mesh is rotated. The command prints the expiration of the
+certificate, and must be run again before then to fetch the renewed one, e.g.
+from a periodic job on the host. Files are replaced atomically, so the command
+can be run while Envoy is running; Envoy picks up the renewed certificate
+when the SDS configs, written last, are replaced, without a restart.
+`
+
+const vmBootstrapCmdExample = `
+# Write the sidecar files of the WorkloadEntry 'billing-vm' in the 'legacy' namespace to ./billing-vm
+osm vm bootstrap billing-vm -n legacy --xds-address osm-xds.example.com:15128 --output-dir ./billing-vm
+`
+
+type vmBootstrapCmd struct {
+	out          io.Writer
+	name         string
+	namespace    string
+	outputDir    string
+	xdsAddress   string
+	kubeClient   kubernetes.Interface
+	policyClient policyClientset.Interface
+}
+
+func newVMBootstrapCmd(out io.Writer) *cobra.Command {
+	bootstrapCmd := &vmBootstrapCmd{
+		out: out,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "bootstrap WORKLOAD_ENTRY_NAME",
+		Short: "write the sidecar files of a workload running outside of Kubernetes",
+		Long:  vmBootstrapCmdDescription,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			bootstrapCmd.name = args[0]
+
+			config, err := settings.RESTClientGetter().ToRESTConfig()
+			if err != nil {
+				return fmt.Errorf("Error fetching kubeconfig: %w", err)
+			}
+
+			kubeClient, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Could not access Kubernetes cluster, check kubeconfig: %w", err)
+			}
+			bootstrapCmd.kubeClient = kubeClient
+
+			policyClient, err := policyClientset.NewForConfig(config)
+			if err != nil {
+				return fmt.Errorf("Error initializing %s client: %w", policyv1alpha1.SchemeGroupVersion, err)
+			}
+			bootstrapCmd.policyClient = policyClient
+
+			return bootstrapCmd.run()
+		},
+		Example: vmBootstrapCmdExample,
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&bootstrapCmd.namespace, "namespace", "n", metav1.NamespaceDefault, "Namespace of the WorkloadEntry")
+	f.StringVarP(&bootstrapCmd.outputDir, "output-dir", "o", ".", "Directory to write the files to")
+	f.StringVar(&bootstrapCmd.xdsAddress, "xds-address", "", "Address (host:port) of osm-controller's xDS server reachable from the host")
+
+	return cmd
+}
+
+func (cmd *vmBootstrapCmd) run() error {
+	entry, err := cmd.policyClient.PolicyV1alpha1().WorkloadEntries(cmd.namespace).Get(context.Background(), cmd.name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Error fetching WorkloadEntry %s/%s: %w", cmd.namespace, cmd.name, err)
+	}
+
+	secretName := constants.EnvoyBootstrapConfigSecretPrefix + string(entry.UID)
+	secret, err := cmd.kubeClient.CoreV1().Secrets(cmd.namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Error fetching bootstrap config Secret %s/%s of WorkloadEntry %s/%s, check that osm-injector is running: %w",
+			cmd.namespace, secretName, cmd.namespace, cmd.name, err)
+	}
+
+	if cmd.xdsAddress != "" {
+		configYAML, err := setXDSAddress(secret.Data[bootstrap.EnvoyBootstrapConfigFile], cmd.xdsAddress)
+		if err != nil {
+			return err
+		}
+		secret.Data[bootstrap.EnvoyBootstrapConfigFile] = configYAML
+	}
+
+	if err := os.MkdirAll(cmd.outputDir, 0700); err != nil {
+		return fmt.Errorf("Error creating directory %s: %w", cmd.outputDir, err)
+	}
+
+	files := make([]string, 0, len(secret.Data))
+	for file := range secret.Data {
+		files = append(files, file)
+	}
+	// Envoy reloads the certificate and key when the SDS config files referencing them are replaced, so these are
+	// written last
+	sort.Slice(files, func(i, j int) bool {
+		iSDS, jSDS := isSDSConfigFile(files[i]), isSDSConfigFile(files[j])
+		if iSDS != jSDS {
+			return jSDS
+		}
+		return files[i] < files[j]
+	})
+
+	for _, file := range files {
+		path := filepath.Join(cmd.outputDir, file)
+		if err := writeFileAtomic(path, secret.Data[file]); err != nil {
+			return fmt.Errorf("Error writing file %s: %w", path, err)
+		}
+		fmt.Fprintf(cmd.out, "Wrote %s\n", path)
+	}
+
+	if certs, err := certificate.DecodePEMCertificates(secret.Data[bootstrap.EnvoyXDSCertFile]); err == nil {
+		fmt.Fprintf(cmd.out, "The certificate expires on %s, run this command again before then to fetch the renewed certificate\n",
+			certs[0].NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// isSDSConfigFile returns whether the given file is one of the SDS config files referenced by the Envoy bootstrap config
+func isSDSConfigFile(file string) bool {
+	return file == bootstrap.EnvoyTLSCertificateSDSSecretFile || file == bootstrap.EnvoyValidationContextSDSSecretFile
+}
+
+// writeFileAtomic writes the given data to a temporary file renamed to the given path, so that Envoy never
+// reads a partially written certificate or key when the files are replaced while it is running
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) //nolint: errcheck
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// CreateTemp creates the file with mode 0600
+	return os.Rename(f.Name(), path)
+}
+
+// setXDSAddress returns the given Envoy bootstrap config with the address of the osm-controller cluster set to
+// the given host:port address
+func setXDSAddress(configYAML []byte, xdsAddress string) ([]byte, error) {
+	host, portStr, err := net.SplitHostPort(xdsAddress)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid xDS address %s: %w", xdsAddress, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid port in xDS address %s: %w", xdsAddress, err)
+	}
+
+	config := &xds_bootstrap.Bootstrap{}
+	if err := utils.YAMLToProto(configYAML, config); err != nil {
+		return nil, fmt.Errorf("Error unmarshalling Envoy bootstrap config: %w", err)
+	}
+
+	for _, cluster := range config.GetStaticResources().GetClusters() {
+		if cluster.Name != constants.OSMControllerName {
+			continue
+		}
+		for _, localityEndpoints := range cluster.GetLoadAssignment().GetEndpoints() {
+			for _, lbEndpoint := range localityEndpoints.GetLbEndpoints() {
+				socketAddress := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress()
+				if socketAddress == nil {
+					continue
+				}
+				socketAddress.Address = host
+				socketAddress.PortSpecifier = &xds_core.SocketAddress_PortValue{PortValue: uint32(port)}
+			}
+		}
+	}
+
+	return utils.ProtoToYAML(config)
+}
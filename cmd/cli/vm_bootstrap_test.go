@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	fakePolicyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+)
+
+const testVMBootstrapConfig = `node:
+  id: 8a3c5f30-4b9f-4b7a-8d4c-8f2c0a8a0b1e
+staticResources:
+  clusters:
+  - loadAssignment:
+      clusterName: osm-controller
+      endpoints:
+      - lbEndpoints:
+        - endpoint:
+            address:
+              socketAddress:
+                address: osm-controller.osm-system.svc.cluster.local
+                portValue: 15128
+    name: osm-controller
+    type: LOGICAL_DNS
+`
+
+func TestVMBootstrapRun(t *testing.T) {
+	const entryUID = "8a3c5f30-4b9f-4b7a-8d4c-8f2c0a8a0b1e"
+	entry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing-vm",
+			Namespace: "legacy",
+			UID:       entryUID,
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "envoy-bootstrap-config-" + entryUID,
+			Namespace: "legacy",
+		},
+		Data: map[string][]byte{
+			bootstrap.EnvoyBootstrapConfigFile:         []byte(testVMBootstrapConfig),
+			bootstrap.EnvoyXDSCertFile:                 []byte("cert"),
+			bootstrap.EnvoyXDSKeyFile:                  []byte("key"),
+			"iptables.sh":                              []byte("#!/bin/sh"),
+			bootstrap.EnvoyTLSCertificateSDSSecretFile: []byte("sds"),
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		entryName          string
+		xdsAddress         string
+		expectErr          bool
+		expectedXDSAddress string
+	}{
+		{
+			name:               "bootstrap files are written",
+			entryName:          "billing-vm",
+			expectedXDSAddress: "osm-controller.osm-system.svc.cluster.local",
+		},
+		{
+			name:               "xDS address is rewritten",
+			entryName:          "billing-vm",
+			xdsAddress:         "10.0.0.1:30128",
+			expectedXDSAddress: "10.0.0.1",
+		},
+		{
+			name:       "invalid xDS address",
+			entryName:  "billing-vm",
+			xdsAddress: "10.0.0.1",
+			expectErr:  true,
+		},
+		{
+			name:      "unknown WorkloadEntry",
+			entryName: "unknown",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			out := new(bytes.Buffer)
+			cmd := &vmBootstrapCmd{
+				out:          out,
+				name:         tc.entryName,
+				namespace:    "legacy",
+				outputDir:    filepath.Join(t.TempDir(), "out"),
+				xdsAddress:   tc.xdsAddress,
+				kubeClient:   fake.NewSimpleClientset(secret.DeepCopy()),
+				policyClient: fakePolicyClientset.NewSimpleClientset(entry),
+			}
+
+			err := cmd.run()
+			assert.Equal(tc.expectErr, err != nil, err)
+			if tc.expectErr {
+				return
+			}
+
+			for file, content := range secret.Data {
+				written, err := os.ReadFile(filepath.Join(cmd.outputDir, file))
+				assert.NoError(err)
+				if file != bootstrap.EnvoyBootstrapConfigFile {
+					assert.Equal(content, written)
+				} else {
+					assert.Contains(string(written), tc.expectedXDSAddress)
+				}
+			}
+			assert.Contains(out.String(), filepath.Join(cmd.outputDir, "iptables.sh"))
+			// The SDS config is replaced after the certificate and key it references
+			assert.Greater(strings.Index(out.String(), bootstrap.EnvoyTLSCertificateSDSSecretFile), strings.Index(out.String(), bootstrap.EnvoyXDSKeyFile))
+
+			info, err := os.Stat(filepath.Join(cmd.outputDir, bootstrap.EnvoyXDSCertFile))
+			assert.NoError(err)
+			assert.Equal(os.FileMode(0600), info.Mode().Perm())
+			entries, err := os.ReadDir(cmd.outputDir)
+			assert.NoError(err)
+			assert.Len(entries, len(secret.Data))
+		})
+	}
+}
+
+func TestVMBootstrapRunPrintsCertificateExpiration(t *testing.T) {
+	assert := tassert.New(t)
+
+	const entryUID = "8a3c5f30-4b9f-4b7a-8d4c-8f2c0a8a0b1e"
+	cert, err := tresorFake.NewFake(time.Hour).IssueCertificate(certificate.ForCommonNamePrefix("billing.legacy"))
+	assert.NoError(err)
+
+	out := new(bytes.Buffer)
+	cmd := &vmBootstrapCmd{
+		out:       out,
+		name:      "billing-vm",
+		namespace: "legacy",
+		outputDir: t.TempDir(),
+		kubeClient: fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "envoy-bootstrap-config-" + entryUID,
+				Namespace: "legacy",
+			},
+			Data: map[string][]byte{
+				bootstrap.EnvoyXDSCertFile: cert.GetCertificateChain(),
+			},
+		}),
+		policyClient: fakePolicyClientset.NewSimpleClientset(&policyv1alpha1.WorkloadEntry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "billing-vm",
+				Namespace: "legacy",
+				UID:       entryUID,
+			},
+		}),
+	}
+
+	assert.NoError(cmd.run())
+	assert.Contains(out.String(), "The certificate expires on "+cert.GetExpiration().UTC().Format(time.RFC3339))
+}
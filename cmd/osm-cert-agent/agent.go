@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// dataDir is the symlink to the directory holding the current certificate and private key, swapped atomically
+	// once they are renewed, like the volumes of Kubernetes secrets. Envoy reloads them once the symlink is moved.
+	dataDir = "..data"
+
+	// renewFraction is the fraction of the validity of a certificate after which a new certificate is requested
+	renewFraction = 2.0 / 3.0
+)
+
+// certificateRequester is implemented by csr.Client
+type certificateRequester interface {
+	GetStatus(ctx context.Context, chain pem.Certificate) (*csr.StatusResponse, error)
+	SignCertificateRequest(ctx context.Context, csrPEM pem.CertificateRequest) (*csr.CertificateResponse, error)
+}
+
+// agent generates the private key of the Envoy sidecar, and requests its certificate from osm-controller.
+type agent struct {
+	client         certificateRequester
+	serviceAccount identity.K8sServiceAccount
+	dir            string
+	rsaKeyBitSize  int
+
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// run renews the certificate at the given interval until the context is done.
+func (a *agent) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := a.renew(ctx); err != nil {
+			log.Error().Err(err).Msg("Error renewing the certificate of the Envoy sidecar")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForCertificate requests a certificate until one is obtained, retrying at the given interval.
+func (a *agent) waitForCertificate(ctx context.Context, interval time.Duration) error {
+	for {
+		_, err := a.renew(ctx)
+		if err == nil {
+			return nil
+		}
+		log.Warn().Err(err).Msg("Error requesting the certificate of the Envoy sidecar, retrying")
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ctx.Err(), err.Error())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// renew requests a new certificate if there is none, the current one has reached the end of its renewal period, or
+// osm-controller reports it must be renewed. It returns whether the certificate was renewed.
+func (a *agent) renew(ctx context.Context) (bool, error) {
+	chain, err := os.ReadFile(filepath.Join(a.dir, constants.CertAgentCertFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	status, err := a.client.GetStatus(ctx, chain)
+	if err != nil {
+		return false, fmt.Errorf("error checking the certificate: %w", err)
+	}
+	if !status.Renew && !a.dueForRenewal(chain) {
+		return false, nil
+	}
+
+	key, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithm(status.KeyAlgorithm), a.rsaKeyBitSize)
+	if err != nil {
+		return false, err
+	}
+	csrPEM, err := a.newCertificateRequest(key, status.TrustDomain)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := a.client.SignCertificateRequest(ctx, csrPEM)
+	if err != nil {
+		return false, fmt.Errorf("error requesting a certificate: %w", err)
+	}
+
+	keyPEM, err := certificate.EncodeKeyDERtoPEM(key)
+	if err != nil {
+		return false, err
+	}
+	if err := a.write([]byte(resp.CertificateChain), keyPEM); err != nil {
+		return false, fmt.Errorf("error writing the certificate: %w", err)
+	}
+
+	log.Info().Msgf("Renewed the certificate of the Envoy sidecar, expiring at %s", resp.Expiration)
+	return true, nil
+}
+
+// dueForRenewal returns whether the given certificate chain has reached the end of its renewal period
+func (a *agent) dueForRenewal(chain []byte) bool {
+	cert, err := certificate.DecodePEMCertificate(chain)
+	if err != nil {
+		return true
+	}
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	return !a.now().Before(cert.NotBefore.Add(time.Duration(float64(validity) * renewFraction)))
+}
+
+// newCertificateRequest returns a certificate signing request of the names of the pod's service identity, signed by
+// the given private key
+func (a *agent) newCertificateRequest(key crypto.Signer, trustDomain string) (pem.CertificateRequest, error) {
+	opts := certificate.NewCertOptions(certificate.ForServiceIdentity(a.serviceAccount.ToServiceIdentity()), certificate.WithTrustDomain(trustDomain))
+
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName: opts.CommonName().String(),
+		},
+		DNSNames: []string{opts.CommonName().String()},
+	}
+	if spiffeID := opts.SPIFFEID(); spiffeID != nil {
+		template.URIs = []*url.URL{spiffeID}
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating certificate request: %w", err)
+	}
+	return certificate.EncodeCertReqDERtoPEM(der)
+}
+
+// write writes the certificate chain and private key to a new directory, only readable by the user the agent and the
+// Envoy sidecar run as, and atomically swaps the data symlink to it. The certificate and key files are symlinks to
+// the files of the data symlink.
+func (a *agent) write(chain, key []byte) error {
+	newDir, err := os.MkdirTemp(a.dir, "..")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(newDir, constants.CertAgentCertFile), chain, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(newDir, constants.CertAgentKeyFile), key, 0600); err != nil {
+		return err
+	}
+
+	dataPath := filepath.Join(a.dir, dataDir)
+	oldDir, _ := os.Readlink(dataPath)
+
+	tmpLink := filepath.Join(a.dir, dataDir+"_tmp")
+	_ = os.Remove(tmpLink)
+	if err := os.Symlink(filepath.Base(newDir), tmpLink); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpLink, dataPath); err != nil {
+		return err
+	}
+
+	for _, file := range []string{constants.CertAgentCertFile, constants.CertAgentKeyFile} {
+		if _, err := os.Lstat(filepath.Join(a.dir, file)); err == nil {
+			continue
+		}
+		if err := os.Symlink(filepath.Join(dataDir, file), filepath.Join(a.dir, file)); err != nil {
+			return err
+		}
+	}
+
+	if oldDir != "" && strings.HasPrefix(oldDir, "..") {
+		if err := os.RemoveAll(filepath.Join(a.dir, oldDir)); err != nil {
+			log.Warn().Err(err).Msgf("Error removing the previous certificate directory %s", oldDir)
+		}
+	}
+	return nil
+}
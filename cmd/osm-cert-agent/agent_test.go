@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// fakeRequester signs the certificate requests with a certificate manager, like osm-controller
+type fakeRequester struct {
+	certManager *certificate.Manager
+	renew       bool
+	failures    int
+	requests    int
+}
+
+func (r *fakeRequester) GetStatus(_ context.Context, chain pem.Certificate) (*csr.StatusResponse, error) {
+	if r.failures > 0 {
+		r.failures--
+		return nil, errors.New("connection refused")
+	}
+	renew := true
+	if len(chain) > 0 {
+		var err error
+		if renew, err = r.certManager.ShouldRenewCertificate(chain); err != nil {
+			return nil, err
+		}
+	}
+	return &csr.StatusResponse{
+		Renew:        renew || r.renew,
+		TrustDomain:  r.certManager.GetTrustDomain(),
+		KeyAlgorithm: string(r.certManager.GetKeyAlgorithm()),
+	}, nil
+}
+
+func (r *fakeRequester) SignCertificateRequest(_ context.Context, csrPEM pem.CertificateRequest) (*csr.CertificateResponse, error) {
+	r.requests++
+	req, err := certificate.DecodePEMCertificateRequest(csrPEM)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := r.certManager.SignCertificateRequest(req, certificate.ForServiceIdentity("sa.ns"))
+	if err != nil {
+		return nil, err
+	}
+	return &csr.CertificateResponse{
+		CertificateChain: string(cert.GetCertificateChain()),
+		IssuingCA:        string(cert.GetIssuingCA()),
+		Expiration:       cert.GetExpiration(),
+	}, nil
+}
+
+func newTestAgent(t *testing.T) (*agent, *fakeRequester, *time.Time) {
+	t.Helper()
+	certManager := tresorFake.NewFake(time.Hour)
+	trequire.NotNil(t, certManager)
+
+	current := time.Now()
+	requester := &fakeRequester{certManager: certManager}
+	return &agent{
+		client:         requester,
+		serviceAccount: identity.K8sServiceAccount{Namespace: "ns", Name: "sa"},
+		dir:            t.TempDir(),
+		rsaKeyBitSize:  2048,
+		now:            func() time.Time { return current },
+	}, requester, &current
+}
+
+// readCertificate returns the certificate and private key written by the agent, checking they match
+func readCertificate(t *testing.T, a *agent) *certificate.Certificate {
+	t.Helper()
+	chain, err := os.ReadFile(filepath.Join(a.dir, constants.CertAgentCertFile))
+	trequire.NoError(t, err)
+	keyPEM, err := os.ReadFile(filepath.Join(a.dir, constants.CertAgentKeyFile))
+	trequire.NoError(t, err)
+
+	cert, err := certificate.DecodePEMCertificate(chain)
+	trequire.NoError(t, err)
+	key, err := certificate.DecodePEMPrivateKey(keyPEM)
+	trequire.NoError(t, err)
+	tassert.Equal(t, cert.PublicKey, key.Public())
+
+	return &certificate.Certificate{
+		CommonName:   certificate.CommonName(cert.Subject.CommonName),
+		SerialNumber: certificate.SerialNumber(cert.SerialNumber.String()),
+		Expiration:   cert.NotAfter,
+		CertChain:    chain,
+	}
+}
+
+// dataDirs returns the directories holding certificates in the agent's directory
+func dataDirs(t *testing.T, a *agent) []string {
+	t.Helper()
+	entries, err := os.ReadDir(a.dir)
+	trequire.NoError(t, err)
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "..") {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	return dirs
+}
+
+func TestRenew(t *testing.T) {
+	assert := tassert.New(t)
+	a, requester, current := newTestAgent(t)
+
+	// A certificate is requested when there is none
+	renewed, err := a.renew(context.Background())
+	trequire.NoError(t, err)
+	assert.True(renewed)
+	cert := readCertificate(t, a)
+	assert.Equal(certificate.CommonName("sa.ns."+requester.certManager.GetTrustDomain()), cert.GetCommonName())
+	assert.Len(dataDirs(t, a), 1)
+	link, err := os.Readlink(filepath.Join(a.dir, constants.CertAgentKeyFile))
+	assert.NoError(err)
+	assert.Equal(filepath.Join(dataDir, constants.CertAgentKeyFile), link)
+
+	// The certificate is not renewed until two thirds of its validity elapsed
+	renewed, err = a.renew(context.Background())
+	trequire.NoError(t, err)
+	assert.False(renewed)
+	assert.Equal(1, requester.requests)
+
+	*current = current.Add(41 * time.Minute)
+	renewed, err = a.renew(context.Background())
+	trequire.NoError(t, err)
+	assert.True(renewed)
+	renewedCert := readCertificate(t, a)
+	assert.NotEqual(cert.GetSerialNumber(), renewedCert.GetSerialNumber())
+	assert.Len(dataDirs(t, a), 1)
+
+	// The certificate is renewed when osm-controller requires it
+	requester.renew = true
+	renewed, err = a.renew(context.Background())
+	trequire.NoError(t, err)
+	assert.True(renewed)
+	assert.NotEqual(renewedCert.GetSerialNumber(), readCertificate(t, a).GetSerialNumber())
+	assert.Equal(3, requester.requests)
+}
+
+func TestWaitForCertificate(t *testing.T) {
+	assert := tassert.New(t)
+	a, requester, _ := newTestAgent(t)
+
+	// The request is retried until a certificate is obtained
+	requester.failures = 2
+	err := a.waitForCertificate(context.Background(), time.Millisecond)
+	assert.NoError(err)
+	assert.Equal(1, requester.requests)
+	readCertificate(t, a)
+
+	// It fails once the context is done
+	a, requester, _ = newTestAgent(t)
+	requester.failures = 1000
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = a.waitForCertificate(ctx, time.Millisecond)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+	assert.Equal(0, requester.requests)
+}
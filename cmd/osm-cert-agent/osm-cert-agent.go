@@ -0,0 +1,97 @@
+// Package main implements the main entrypoint for osm-cert-agent.
+// osm-cert-agent runs in the pods generating the private keys of their Envoy sidecars. It generates the private key
+// of the Envoy sidecar, and requests its certificate from osm-controller with a certificate signing request,
+// authenticated with the service account token projected in the pod, so that the private key never leaves the pod.
+// The certificate and private key are written to a volume shared with the Envoy sidecar, and renewed before the
+// certificate expires.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/signals"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+var log = logger.New("osm-cert-agent/main")
+
+func main() {
+	log.Info().Msgf("Starting osm-cert-agent %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
+
+	var verbosity string
+	var controllerAddress string
+	var caFile string
+	var tokenFile string
+	var certDir string
+	var serviceAccount identity.K8sServiceAccount
+	var rsaKeyBitSize int
+	var once bool
+	var checkInterval time.Duration
+	var waitTimeout time.Duration
+
+	flags := pflag.NewFlagSet("osm-cert-agent", pflag.ExitOnError)
+	flags.StringVarP(&verbosity, "verbosity", "v", "info", "Set log verbosity level")
+	flags.StringVar(&controllerAddress, "controller-address", fmt.Sprintf("https://%s.osm-system.svc:%d", constants.OSMControllerName, constants.CertificateRequestPort), "Address of the osm-controller server signing the certificate requests")
+	flags.StringVar(&caFile, "ca-file", filepath.Join(bootstrap.EnvoyProxyConfigPath, bootstrap.EnvoyXDSCACertFile), "File holding the root certificates validating the osm-controller server")
+	flags.StringVar(&tokenFile, "token-file", filepath.Join(constants.PodTokenDir, constants.PodTokenFile), "File holding the service account token authenticating the pod")
+	flags.StringVar(&certDir, "cert-dir", constants.CertAgentCertDir, "Directory the certificate and private key are written to")
+	flags.StringVar(&serviceAccount.Name, "service-account", "", "Service account of the pod")
+	flags.StringVar(&serviceAccount.Namespace, "namespace", "", "Namespace of the pod")
+	flags.IntVar(&rsaKeyBitSize, "key-bit-size", 2048, "Bit size of the RSA private keys generated")
+	flags.BoolVar(&once, "once", false, "Request a certificate and exit once it is obtained")
+	flags.DurationVar(&checkInterval, "check-interval", time.Minute, "Interval at which the certificate is checked for renewal")
+	flags.DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum duration to wait for a certificate with --once")
+
+	err := flags.Parse(os.Args)
+	if err != nil {
+		log.Fatal().Err(err).Msg("parsing flags")
+	}
+
+	if err := logger.SetLogLevel(verbosity); err != nil {
+		log.Fatal().Err(err).Msg("Error setting log level")
+	}
+
+	if serviceAccount.Name == "" || serviceAccount.Namespace == "" {
+		log.Fatal().Msg("Both --service-account and --namespace are required")
+	}
+
+	a := &agent{
+		client:         csr.NewClient(controllerAddress, caFile, tokenFile),
+		serviceAccount: serviceAccount,
+		dir:            certDir,
+		rsaKeyBitSize:  rsaKeyBitSize,
+		now:            time.Now,
+	}
+
+	if once {
+		// Used as an init container, holding the start of the Envoy sidecar until its certificate is obtained
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		err := a.waitForCertificate(ctx, 2*time.Second)
+		cancel()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error requesting the certificate of the Envoy sidecar")
+		}
+		return
+	}
+
+	stop := signals.RegisterExitHandlers()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	a.run(ctx, checkInterval)
+	log.Info().Msg("Stopped renewing the certificate of the Envoy sidecar")
+}
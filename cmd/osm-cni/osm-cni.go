@@ -0,0 +1,127 @@
+// Package main implements the main entrypoint for osm-cni.
+// osm-cni is a CNI plugin chained to the primary CNI plugin of the nodes, programming the
+// traffic interception and redirection of meshed pods in their network namespace when their
+// sandbox is created, in place of the init container requiring the NET_ADMIN capability.
+// Run with the install command by a DaemonSet, it installs itself on the node it runs on.
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/cni"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/signals"
+	"github.com/openservicemesh/osm/pkg/version"
+)
+
+const (
+	// installCommand is the command installing the osm-cni plugin on the node
+	installCommand = "install"
+
+	// installRetryInterval is the interval at which installing the osm-cni plugin is retried
+	installRetryInterval = 5 * time.Second
+
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var log = logger.New("osm-cni/main")
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != installCommand {
+		// Run as a CNI plugin by the container runtime, the result or error being written to stdout
+		if err := cni.Run(os.Getenv, os.Stdin, os.Stdout); err != nil {
+			os.Exit(1)
+		}
+		return
+	}
+
+	log.Info().Msgf("Starting osm-cni installer %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
+
+	var verbosity string
+	var pluginVerbosity string
+	var refreshInterval time.Duration
+	installer := &cni.Installer{
+		CAFile:    serviceAccountCAFile,
+		TokenFile: serviceAccountTokenFile,
+	}
+
+	flags := pflag.NewFlagSet("osm-cni", pflag.ExitOnError)
+	flags.StringVarP(&verbosity, "verbosity", "v", "info", "Set log verbosity level")
+	flags.StringVar(&pluginVerbosity, "plugin-verbosity", "info", "Set the log verbosity level of the installed plugin")
+	flags.StringVar(&installer.BinDir, "cni-bin-dir", "/host/opt/cni/bin", "Path of the mounted CNI plugin binary directory of the node")
+	flags.StringVar(&installer.ConfDir, "cni-conf-dir", "/host/etc/cni/net.d", "Path of the mounted CNI network configuration directory of the node")
+	flags.StringVar(&installer.HostConfDir, "host-cni-conf-dir", "/etc/cni/net.d", "Path of the CNI network configuration directory on the node")
+	flags.StringVar(&installer.OSMNamespace, "osm-namespace", "", "Namespace of the MeshConfig")
+	flags.StringVar(&installer.MeshConfigName, "osm-config-name", "osm-mesh-config", "Name of the OSM MeshConfig")
+	flags.StringVar(&installer.MeshName, "mesh-name", "", "OSM mesh name, whose monitored namespaces are watched")
+	flags.StringVar(&installer.NodeName, "node-name", "", "Name of the node the plugin is installed on, labeled once installed")
+	flags.StringSliceVar(&installer.ExcludeNamespaces, "exclude-namespaces", nil, "Namespaces whose pods are skipped by the plugin")
+	flags.DurationVar(&refreshInterval, "refresh-interval", time.Minute, "Interval at which the plugin's kubeconfig, monitored namespaces and network configuration are refreshed")
+
+	err := flags.Parse(os.Args[2:])
+	if err != nil {
+		log.Fatal().Err(err).Msg("parsing flags")
+	}
+
+	if err := logger.SetLogLevel(verbosity); err != nil {
+		log.Fatal().Err(err).Msg("Error setting log level")
+	}
+	if installer.OSMNamespace == "" || installer.MeshName == "" || installer.NodeName == "" {
+		log.Fatal().Msg("--osm-namespace, --mesh-name and --node-name must be set")
+	}
+	installer.LogLevel = pluginVerbosity
+
+	installer.KubernetesHost, err = cni.KubernetesHostFromEnv()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error getting the Kubernetes API server address")
+	}
+	installer.BinarySource, err = os.Executable()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error getting the path of the osm-cni binary")
+	}
+
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating the in-cluster Kubernetes config")
+	}
+	installer.KubeClient, err = kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating the Kubernetes client")
+	}
+
+	stop := signals.RegisterExitHandlers()
+	go installer.WatchMonitoredNamespaces(stop)
+
+	// The primary CNI plugin may not have written its network configuration yet, installing is retried until it did
+	installed := false
+	for {
+		wait := refreshInterval
+		if !installed {
+			if err := installer.Install(); err != nil {
+				log.Error().Err(err).Msg("Error installing the osm-cni plugin, retrying")
+				wait = installRetryInterval
+			} else {
+				installed = true
+				log.Info().Msg("Installed the osm-cni plugin")
+			}
+		} else if err := installer.Refresh(); err != nil {
+			log.Error().Err(err).Msg("Error refreshing the osm-cni plugin")
+		}
+
+		select {
+		case <-stop:
+			if err := installer.Uninstall(); err != nil {
+				log.Error().Err(err).Msg("Error uninstalling the osm-cni plugin")
+			}
+			log.Info().Msg("Exiting osm-cni installer")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
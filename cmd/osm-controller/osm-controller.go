@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -31,13 +33,18 @@ import (
 	policyClientset "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/compute/file"
 	"github.com/openservicemesh/osm/pkg/compute/kube"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/debugger"
 	"github.com/openservicemesh/osm/pkg/envoy/ads"
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/federation"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/httpserver"
 	"github.com/openservicemesh/osm/pkg/ingress"
@@ -47,7 +54,10 @@ import (
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
+	"github.com/openservicemesh/osm/pkg/multicluster"
+	"github.com/openservicemesh/osm/pkg/podidentity"
 	"github.com/openservicemesh/osm/pkg/reconciler"
+	"github.com/openservicemesh/osm/pkg/revocation"
 	"github.com/openservicemesh/osm/pkg/signals"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/validator"
@@ -75,6 +85,15 @@ var (
 	enableReconciler      bool
 	validateTrafficTarget bool
 
+	computeProvider string
+	computeFileDir  string
+	caCertFile      string
+	caKeyFile       string
+
+	enableMultiCluster bool
+
+	podTokenVerifier string
+
 	scheme = runtime.NewScheme()
 )
 
@@ -96,6 +115,7 @@ func init() {
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.BoolVar(&enableMeshRootCertificate, "enable-mesh-root-certificate", false, "Enable unsupported MeshRootCertificate to create the OSM Certificate Manager")
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
+	flags.StringVar(&tresorOptions.IntermediateSecretName, "ca-intermediate-secret-name", "", "Name of the Kubernetes Secret for the intermediate CA used by Tresor to issue certificates")
 
 	// TODO (#4502): Remove when we add full MRC support
 	flags.StringVar(&trustDomain, "trust-domain", "cluster.local", "The trust domain to use as part of the common name when requesting new certificates")
@@ -108,6 +128,13 @@ func init() {
 	flags.IntVar(&vaultOptions.VaultPort, "vault-port", 8200, "Port of the Hashi Vault")
 	flags.StringVar(&vaultOptions.VaultTokenSecretName, "vault-token-secret-name", "", "Name of the secret storing the Vault token used in OSM")
 	flags.StringVar(&vaultOptions.VaultTokenSecretKey, "vault-token-secret-key", "", "Key for the vault token used in OSM")
+	flags.StringVar(&vaultOptions.VaultAuthMethod, "vault-auth-method", providers.VaultAuthMethodToken, fmt.Sprintf("Method used to authenticate to the Hashi Vault, one of [%s %s %s]", providers.VaultAuthMethodToken, providers.VaultAuthMethodKubernetes, providers.VaultAuthMethodAppRole))
+	flags.StringVar(&vaultOptions.VaultAuthMountPath, "vault-auth-mount-path", "", "Path the kubernetes or approle auth method is mounted at in the Hashi Vault, defaults to the name of the auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthRole, "vault-kubernetes-auth-role", "", "Vault role bound to the service account of OSM, for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthTokenPath, "vault-kubernetes-auth-token-path", vault.DefaultKubernetesAuthTokenPath, "Path of the projected service account token, for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleID, "vault-approle-role-id", "", "Role ID of the AppRole, for the approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretName, "vault-approle-secret-id-secret-name", "", "Name of the secret storing the secret ID of the AppRole, for the approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretKey, "vault-approle-secret-id-secret-key", "", "Key for the secret ID of the AppRole, for the approle auth method")
 
 	// Cert-manager certificate manager/provider options
 	flags.StringVar(&certManagerOptions.IssuerName, "cert-manager-issuer-name", "osm-ca", "cert-manager issuer name")
@@ -118,6 +145,18 @@ func init() {
 	flags.BoolVar(&enableReconciler, "enable-reconciler", false, "Enable reconciler for CDRs, mutating webhook and validating webhook")
 	flags.BoolVar(&validateTrafficTarget, "validate-traffic-target", true, "Enable traffic target validation")
 
+	// Compute provider options
+	flags.StringVar(&computeProvider, "compute-provider", computeProviderKubernetes, fmt.Sprintf("Provider used to discover services and workloads, one of [%s %s]", computeProviderKubernetes, computeProviderFile))
+	flags.StringVar(&computeFileDir, "compute-file-dir", "", "Directory of service, workload and policy files watched by the file compute provider")
+	flags.StringVar(&caCertFile, "ca-cert-file", "", "File of the PEM encoded certificate of the CA used by Tresor to issue certificates with the file compute provider")
+	flags.StringVar(&caKeyFile, "ca-key-file", "", "File of the PEM encoded private key of the CA used by Tresor to issue certificates with the file compute provider")
+
+	// Multi-cluster
+	flags.BoolVar(&enableMultiCluster, "enable-multicluster", false, "Enable discovery of the services exported by the peer clusters registered with RemoteCluster resources")
+
+	// Pod authentication
+	flags.StringVar(&podTokenVerifier, "pod-token-verifier", podTokenVerifierTokenReview, fmt.Sprintf("Verifier of the service account tokens authenticating the pods, one of [%s %s]", podTokenVerifierTokenReview, podTokenVerifierJWKS))
+
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = admissionv1.AddToScheme(scheme)
 }
@@ -147,28 +186,38 @@ func main() {
 		log.Fatal().Err(err).Msg("Error setting log level")
 	}
 
-	// Initialize kube config and client
-	kubeConfig, err := clientcmd.BuildConfigFromFlags("", "")
-	if err != nil {
-		log.Fatal().Err(err).Msg("Error creating kube configs using in-cluster config")
-	}
-	kubeClient := kubernetes.NewForConfigOrDie(kubeConfig)
-	policyClient := policyClientset.NewForConfigOrDie(kubeConfig)
-	configClient := configClientset.NewForConfigOrDie(kubeConfig)
+	// The control plane runs without Kubernetes with the file compute provider. The pods authenticating with their
+	// service account tokens, the webhooks, the debug server and the watchers of the Kubernetes resources are then
+	// unavailable.
+	var kubeConfig *rest.Config
+	var kubeClient kubernetes.Interface
+	var policyClient policyClientset.Interface
+	var configClient configClientset.Interface
+	if computeProvider != computeProviderFile {
+		// Initialize kube config and client
+		var err error
+		kubeConfig, err = clientcmd.BuildConfigFromFlags("", "")
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error creating kube configs using in-cluster config")
+		}
+		kubeClient = kubernetes.NewForConfigOrDie(kubeConfig)
+		policyClient = policyClientset.NewForConfigOrDie(kubeConfig)
+		configClient = configClientset.NewForConfigOrDie(kubeConfig)
 
-	// Initialize the generic Kubernetes event recorder and associate it with the osm-controller pod resource
-	controllerPod, err := getOSMControllerPod(kubeClient)
-	if err != nil {
-		log.Fatal().Msg("Error fetching osm-controller pod")
-	}
-	eventRecorder := events.GenericEventRecorder()
-	if err := eventRecorder.Initialize(controllerPod, kubeClient, osmNamespace); err != nil {
-		log.Fatal().Msg("Error initializing generic event recorder")
+		// Initialize the generic Kubernetes event recorder and associate it with the osm-controller pod resource
+		controllerPod, err := getOSMControllerPod(kubeClient)
+		if err != nil {
+			log.Fatal().Msg("Error fetching osm-controller pod")
+		}
+		eventRecorder := events.GenericEventRecorder()
+		if err := eventRecorder.Initialize(controllerPod, kubeClient, osmNamespace); err != nil {
+			log.Fatal().Msg("Error initializing generic event recorder")
+		}
 	}
 
 	// This ensures CLI parameters (and dependent values) are correct.
 	if err := validateCLIParams(); err != nil {
-		events.GenericEventRecorder().FatalEvent(err, events.InvalidCLIParameters, "Error validating CLI parameters")
+		fatalEvent(err, events.InvalidCLIParameters, "Error validating CLI parameters")
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -179,50 +228,92 @@ func main() {
 
 	msgBroker := messaging.NewBroker(stop)
 
-	smiTrafficSplitClientSet := smiTrafficSplitClient.NewForConfigOrDie(kubeConfig)
-	smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(kubeConfig)
-	smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(kubeConfig)
+	var meshSpec smi.MeshSpec
+	var computeClient compute.Interface
+	var certManager *certificate.Manager
+	var k8sClient k8s.Controller
+	var podAuthenticator podidentity.Authenticator
 
-	informerCollection, err := informers.NewInformerCollection(meshName, stop,
-		informers.WithKubeClient(kubeClient),
-		informers.WithSMIClients(smiTrafficSplitClientSet, smiTrafficSpecClientSet, smiTrafficTargetClientSet),
-		informers.WithConfigClient(configClient, osmMeshConfigName, osmNamespace),
-		informers.WithPolicyClient(policyClient),
-	)
-	if err != nil {
-		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating informer collection")
-	}
+	if computeProvider == computeProviderFile {
+		fileClient, err := file.NewClient(computeFileDir, osmNamespace, msgBroker, stop)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Error loading compute resources from %s", computeFileDir)
+		}
+		meshSpec = fileClient
+		computeClient = fileClient
 
-	k8sClient := k8s.NewClient(osmNamespace, osmMeshConfigName, informerCollection, policyClient, msgBroker)
+		ca, err := getCAFromFiles()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error reading the CA from --ca-cert-file and --ca-key-file")
+		}
+		certManager, err = providers.NewCertificateManagerFromCA(ctx, ca, osmNamespace, fileClient.GetMeshConfig, 5*time.Second, trustDomain)
+		if err != nil {
+			log.Fatal().Err(err).Msgf("Error fetching certificate manager of kind %s", certProviderKind)
+		}
+	} else {
+		smiTrafficSplitClientSet := smiTrafficSplitClient.NewForConfigOrDie(kubeConfig)
+		smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(kubeConfig)
+		smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(kubeConfig)
+
+		informerCollection, err := informers.NewInformerCollection(meshName, stop,
+			informers.WithKubeClient(kubeClient),
+			informers.WithSMIClients(smiTrafficSplitClientSet, smiTrafficSpecClientSet, smiTrafficTargetClientSet),
+			informers.WithConfigClient(configClient, osmMeshConfigName, osmNamespace),
+			informers.WithPolicyClient(policyClient),
+		)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating informer collection")
+		}
 
-	meshSpec := smi.NewSMIClient(informerCollection, osmNamespace, k8sClient, msgBroker)
+		k8sClient = k8s.NewClient(osmNamespace, osmMeshConfigName, informerCollection, policyClient, msgBroker)
 
-	certOpts, err := getCertOptions()
-	if err != nil {
-		log.Fatal().Err(err).Msg("Error getting certificate options")
-	}
+		meshSpec = smi.NewSMIClient(informerCollection, osmNamespace, k8sClient, msgBroker)
 
-	// Intitialize certificate manager/provider
-	var certManager *certificate.Manager
-	if enableMeshRootCertificate {
-		certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, osmNamespace,
-			certOpts, k8sClient, informerCollection, 5*time.Second)
+		certOpts, err := getCertOptions()
 		if err != nil {
-			events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
-				"Error fetching certificate manager of kind %s from MRC", certProviderKind)
+			log.Fatal().Err(err).Msg("Error getting certificate options")
 		}
-	} else {
-		certManager, err = providers.NewCertificateManager(ctx, kubeClient, kubeConfig, osmNamespace,
-			certOpts, k8sClient, 5*time.Second, trustDomain)
-		if err != nil {
-			events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
-				"Error fetching certificate manager of kind %s", certProviderKind)
+
+		// Intitialize certificate manager/provider
+		if enableMeshRootCertificate {
+			certManager, err = providers.NewCertificateManagerFromMRC(ctx, kubeClient, kubeConfig, osmNamespace,
+				certOpts, k8sClient, informerCollection, 5*time.Second)
+			if err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
+					"Error fetching certificate manager of kind %s from MRC", certProviderKind)
+			}
+		} else {
+			certManager, err = providers.NewCertificateManager(ctx, kubeClient, kubeConfig, osmNamespace,
+				certOpts, k8sClient, 5*time.Second, trustDomain)
+			if err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InvalidCertificateManager,
+					"Error fetching certificate manager of kind %s", certProviderKind)
+			}
 		}
-	}
 
-	computeClient := kube.NewClient(k8sClient)
+		computeClient = kube.NewClient(k8sClient)
 
-	ingress.Initialize(kubeClient, k8sClient, stop, certManager, msgBroker)
+		if enableMultiCluster {
+			computeClient = multicluster.NewClient(computeClient, kubeClient, k8sClient, certManager.GetTrustDomain(),
+				multicluster.NewClientFromKubeconfig, msgBroker, stop)
+			// Start the watcher exposing the services exported by the local cluster on the east-west gateway
+			go multicluster.WatchAndUpdateGateway(kubeClient, k8sClient, msgBroker, osmNamespace, stop)
+		}
+
+		// Start the watcher trusting the roots of the foreign trust domains federated with TrustDomainFederation resources
+		go federation.WatchTrustDomainFederations(kubeClient, k8sClient, certManager, certManager.GetTrustDomain(), osmNamespace, msgBroker, stop)
+
+		// Start the watcher revoking the certificates listed by CertificateRevocation resources
+		go revocation.WatchCertificateRevocations(k8sClient, configClient, certManager, msgBroker, stop)
+
+		ingress.Initialize(kubeClient, k8sClient, stop, certManager, msgBroker)
+
+		// The pods authenticate with their service account tokens to request certificates, and to connect to the ADS server
+		podAuthenticator, err = getPodAuthenticator(ctx, kubeClient)
+		if err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating the pod authenticator")
+		}
+	}
 
 	meshCatalog := catalog.NewMeshCatalog(
 		meshSpec,
@@ -235,35 +326,43 @@ func main() {
 	proxyRegistry := registry.NewProxyRegistry()
 
 	// Create and start the ADS gRPC service
-	xdsServer := ads.NewADSServer(meshCatalog, proxyRegistry, k8sClient.GetMeshConfig().Spec.Observability.EnableDebugServer, osmNamespace, certManager, k8sClient, msgBroker)
+	xdsServer := ads.NewADSServer(meshCatalog, proxyRegistry, computeClient.GetMeshConfig().Spec.Observability.EnableDebugServer, osmNamespace, certManager, k8sClient, msgBroker, podAuthenticator)
 	if err := xdsServer.Start(ctx, cancel, constants.ADSServerPort); err != nil {
-		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error initializing ADS server")
+		fatalEvent(err, events.InitializationError, "Error initializing ADS server")
 	}
 
-	if err := validator.NewValidatingWebhook(ctx, validatorWebhookConfigName, osmNamespace, osmVersion, meshName, enableReconciler, validateTrafficTarget, certManager, kubeClient, computeClient); err != nil {
-		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, fmt.Sprintf("Error starting the validating webhook server: %s", err))
-	}
+	if computeProvider == computeProviderKubernetes {
+		if err := validator.NewValidatingWebhook(ctx, validatorWebhookConfigName, osmNamespace, osmVersion, meshName, enableReconciler, validateTrafficTarget, certManager, kubeClient, computeClient); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, fmt.Sprintf("Error starting the validating webhook server: %s", err))
+		}
 
-	version.SetMetric()
+		// Start the server signing the certificate requests of the pods generating the private keys of their Envoy sidecars
+		if err := csr.NewServer(ctx, osmNamespace, certManager, podAuthenticator, k8sClient); err != nil {
+			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, fmt.Sprintf("Error starting the certificate request server: %s", err))
+		}
 
-	// Create DebugServer and start its config event listener.
-	// Listener takes care to start and stop the debug server as appropriate
-	debugConfig := debugger.NewDebugConfig(certManager, xdsServer, meshCatalog, proxyRegistry, kubeConfig, kubeClient, k8sClient, msgBroker)
-	go debugConfig.StartDebugServerConfigListener(stop)
+		// Create DebugServer and start its config event listener.
+		// Listener takes care to start and stop the debug server as appropriate
+		debugConfig := debugger.NewDebugConfig(certManager, xdsServer, meshCatalog, proxyRegistry, kubeConfig, kubeClient, k8sClient, msgBroker)
+		go debugConfig.StartDebugServerConfigListener(stop)
 
-	// Start the k8s pod watcher that updates corresponding k8s secrets
-	go k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, stop)
-	// Start the global log level watcher that updates the log level dynamically
-	go k8s.WatchAndUpdateLogLevel(msgBroker, stop)
+		// Start the k8s pod watcher that updates corresponding k8s secrets
+		go k8s.WatchAndUpdateProxyBootstrapSecret(kubeClient, msgBroker, stop)
 
-	if enableReconciler {
-		log.Info().Msgf("OSM reconciler enabled for validating webhook")
-		err = reconciler.NewReconcilerClient(kubeClient, nil, meshName, osmVersion, stop, reconciler.ValidatingWebhookInformerKey)
-		if err != nil {
-			events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating reconciler client to reconcile validating webhook")
+		if enableReconciler {
+			log.Info().Msgf("OSM reconciler enabled for validating webhook")
+			err := reconciler.NewReconcilerClient(kubeClient, nil, meshName, osmVersion, stop, reconciler.ValidatingWebhookInformerKey)
+			if err != nil {
+				events.GenericEventRecorder().FatalEvent(err, events.InitializationError, "Error creating reconciler client to reconcile validating webhook")
+			}
 		}
 	}
 
+	version.SetMetric()
+
+	// Start the global log level watcher that updates the log level dynamically
+	go k8s.WatchAndUpdateLogLevel(msgBroker, stop)
+
 	// Initialize OSM's http service server
 	httpServer := httpserver.NewHTTPServer(constants.OSMHTTPServerPort)
 	// Health/Liveness probes
@@ -277,10 +376,11 @@ func main() {
 	httpServer.AddHandler(constants.VersionPath, version.GetVersionHandler())
 	// Supported SMI Versions
 	httpServer.AddHandler(constants.OSMControllerSMIVersionPath, smi.GetSmiClientVersionHTTPHandler())
+	// SPIFFE trust bundle
+	httpServer.AddHandler(constants.SPIFFETrustBundlePath, certManager.GetSPIFFETrustBundleHandler())
 
 	// Start HTTP server
-	err = httpServer.Start()
-	if err != nil {
+	if err := httpServer.Start(); err != nil {
 		log.Fatal().Err(err).Msgf("Failed to start OSM metrics/probes HTTP server")
 	}
 
@@ -307,12 +407,27 @@ func startMetricsStore() {
 		metricsstore.DefaultMetricsStore.VersionInfo,
 		metricsstore.DefaultMetricsStore.ProxyXDSRequestCount,
 		metricsstore.DefaultMetricsStore.ProxyMaxConnectionsRejected,
+		metricsstore.DefaultMetricsStore.ProxyXDSAckCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSNackCount,
+		metricsstore.DefaultMetricsStore.ProxyXDSNackedCount,
 		metricsstore.DefaultMetricsStore.AdmissionWebhookResponseTotal,
 		metricsstore.DefaultMetricsStore.EventsQueued,
 		metricsstore.DefaultMetricsStore.ReconciliationTotal,
+		metricsstore.DefaultMetricsStore.CertRotationErrorCount,
+		metricsstore.DefaultMetricsStore.CertEarliestExpiration,
+		metricsstore.DefaultMetricsStore.CertMRCExpiration,
 	)
 }
 
+// getPodAuthenticator returns the authenticator verifying the service account tokens of the pods with the verifier
+// given by --pod-token-verifier
+func getPodAuthenticator(ctx context.Context, kubeClient kubernetes.Interface) (podidentity.Authenticator, error) {
+	if podTokenVerifier == podTokenVerifierJWKS {
+		return podidentity.NewAPIServerJWKSAuthenticator(ctx, kubeClient, constants.PodTokenAudience)
+	}
+	return podidentity.NewTokenReviewAuthenticator(kubeClient, constants.PodTokenAudience), nil
+}
+
 func parseFlags() error {
 	if err := flags.Parse(os.Args); err != nil {
 		return err
@@ -326,6 +441,27 @@ func joinURL(baseURL string, paths ...string) string {
 	return fmt.Sprintf("%s/%s", strings.TrimRight(baseURL, "/"), strings.TrimLeft(p, "/"))
 }
 
+// fatalEvent records a fatal event on the osm-controller pod when running on Kubernetes, logs the error, and exits
+func fatalEvent(err error, reason string, messageFmt string, args ...interface{}) {
+	if computeProvider == computeProviderFile {
+		log.Fatal().Err(err).Msgf(messageFmt, args...)
+	}
+	events.GenericEventRecorder().FatalEvent(err, reason, messageFmt, args...)
+}
+
+// getCAFromFiles returns the CA read from the --ca-cert-file and --ca-key-file files
+func getCAFromFiles() (*certificate.Certificate, error) {
+	pemCert, err := os.ReadFile(filepath.Clean(caCertFile))
+	if err != nil {
+		return nil, err
+	}
+	pemKey, err := os.ReadFile(filepath.Clean(caKeyFile))
+	if err != nil {
+		return nil, err
+	}
+	return certificate.NewFromPEM(pemCert, pemKey)
+}
+
 // getOSMControllerPod returns the osm-controller pod.
 // The pod name is inferred from the 'CONTROLLER_POD_NAME' env variable which is set during deployment.
 func getOSMControllerPod(kubeClient kubernetes.Interface) (*corev1.Pod, error) {
@@ -2,6 +2,24 @@ package main
 
 import (
 	"fmt"
+
+	"github.com/openservicemesh/osm/pkg/certificate/providers"
+)
+
+const (
+	// computeProviderKubernetes discovers services and workloads from the Kubernetes API server
+	computeProviderKubernetes = "kubernetes"
+
+	// computeProviderFile discovers services and workloads from the files in the --compute-file-dir directory, and
+	// runs the control plane without Kubernetes
+	computeProviderFile = "file"
+
+	// podTokenVerifierTokenReview verifies the service account tokens of the pods with the TokenReview API
+	podTokenVerifierTokenReview = "tokenreview"
+
+	// podTokenVerifierJWKS verifies the service account tokens of the pods locally, with the keys of the service
+	// account issuer discovered from the Kubernetes API server
+	podTokenVerifierJWKS = "jwks"
 )
 
 // validateCLIParams contains all checks necessary that various permutations of the CLI flags are consistent
@@ -14,8 +32,31 @@ func validateCLIParams() error {
 		return fmt.Errorf("Please specify the OSM namespace using --osm-namespace")
 	}
 
-	if validatorWebhookConfigName == "" {
-		return fmt.Errorf("Please specify the webhook configuration name using --validator-webhook-config")
+	switch computeProvider {
+	case computeProviderKubernetes:
+		if validatorWebhookConfigName == "" {
+			return fmt.Errorf("Please specify the webhook configuration name using --validator-webhook-config")
+		}
+	case computeProviderFile:
+		if computeFileDir == "" {
+			return fmt.Errorf("Please specify the directory to read compute resources from using --compute-file-dir")
+		}
+		// Without Kubernetes, Tresor issues the certificates with a CA read from files
+		if providers.Kind(certProviderKind) != providers.TresorKind {
+			return fmt.Errorf("The %s compute provider only supports the %s certificate manager", computeProviderFile, providers.TresorKind)
+		}
+		if caCertFile == "" || caKeyFile == "" {
+			return fmt.Errorf("Please specify the CA to issue certificates with using --ca-cert-file and --ca-key-file")
+		}
+		if enableMeshRootCertificate || enableMultiCluster {
+			return fmt.Errorf("The %s compute provider does not support --enable-mesh-root-certificate and --enable-multicluster", computeProviderFile)
+		}
+	default:
+		return fmt.Errorf("Invalid compute provider %q, must be one of [%s %s]", computeProvider, computeProviderKubernetes, computeProviderFile)
+	}
+
+	if podTokenVerifier != podTokenVerifierTokenReview && podTokenVerifier != podTokenVerifierJWKS {
+		return fmt.Errorf("Invalid pod token verifier %q, must be one of [%s %s]", podTokenVerifier, podTokenVerifierTokenReview, podTokenVerifierJWKS)
 	}
 
 	return nil
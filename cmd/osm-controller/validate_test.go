@@ -12,6 +12,13 @@ func TestValidateCLIParams(t *testing.T) {
 		meshName                   string
 		osmNamespace               string
 		validatorWebhookConfigName string
+		computeProvider            string
+		computeFileDir             string
+		certProviderKind           string
+		caCertFile                 string
+		caKeyFile                  string
+		enableMultiCluster         bool
+		podTokenVerifier           string
 		expectError                bool
 	}{
 		{
@@ -42,6 +49,80 @@ func TestValidateCLIParams(t *testing.T) {
 			validatorWebhookConfigName: "",
 			expectError:                true,
 		},
+		{
+			name:            "file compute provider with directory and CA, without webhook",
+			meshName:        "test-mesh",
+			osmNamespace:    "test-ns",
+			computeProvider: computeProviderFile,
+			computeFileDir:  "/etc/osm/compute",
+			caCertFile:      "/etc/osm/ca/tls.crt",
+			caKeyFile:       "/etc/osm/ca/tls.key",
+			expectError:     false,
+		},
+		{
+			name:            "file compute provider without directory",
+			meshName:        "test-mesh",
+			osmNamespace:    "test-ns",
+			computeProvider: computeProviderFile,
+			caCertFile:      "/etc/osm/ca/tls.crt",
+			caKeyFile:       "/etc/osm/ca/tls.key",
+			expectError:     true,
+		},
+		{
+			name:            "file compute provider without CA",
+			meshName:        "test-mesh",
+			osmNamespace:    "test-ns",
+			computeProvider: computeProviderFile,
+			computeFileDir:  "/etc/osm/compute",
+			caCertFile:      "/etc/osm/ca/tls.crt",
+			expectError:     true,
+		},
+		{
+			name:             "file compute provider with vault",
+			meshName:         "test-mesh",
+			osmNamespace:     "test-ns",
+			computeProvider:  computeProviderFile,
+			computeFileDir:   "/etc/osm/compute",
+			certProviderKind: "vault",
+			caCertFile:       "/etc/osm/ca/tls.crt",
+			caKeyFile:        "/etc/osm/ca/tls.key",
+			expectError:      true,
+		},
+		{
+			name:               "file compute provider with multicluster",
+			meshName:           "test-mesh",
+			osmNamespace:       "test-ns",
+			computeProvider:    computeProviderFile,
+			computeFileDir:     "/etc/osm/compute",
+			caCertFile:         "/etc/osm/ca/tls.crt",
+			caKeyFile:          "/etc/osm/ca/tls.key",
+			enableMultiCluster: true,
+			expectError:        true,
+		},
+		{
+			name:                       "invalid compute provider",
+			meshName:                   "test-mesh",
+			osmNamespace:               "test-ns",
+			validatorWebhookConfigName: "test-webhook",
+			computeProvider:            "consul",
+			expectError:                true,
+		},
+		{
+			name:                       "jwks pod token verifier",
+			meshName:                   "test-mesh",
+			osmNamespace:               "test-ns",
+			validatorWebhookConfigName: "test-webhook",
+			podTokenVerifier:           podTokenVerifierJWKS,
+			expectError:                false,
+		},
+		{
+			name:                       "invalid pod token verifier",
+			meshName:                   "test-mesh",
+			osmNamespace:               "test-ns",
+			validatorWebhookConfigName: "test-webhook",
+			podTokenVerifier:           "oidc",
+			expectError:                true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -50,6 +131,22 @@ func TestValidateCLIParams(t *testing.T) {
 			meshName = tc.meshName
 			osmNamespace = tc.osmNamespace
 			validatorWebhookConfigName = tc.validatorWebhookConfigName
+			computeProvider = tc.computeProvider
+			if computeProvider == "" {
+				computeProvider = computeProviderKubernetes
+			}
+			computeFileDir = tc.computeFileDir
+			certProviderKind = tc.certProviderKind
+			if certProviderKind == "" {
+				certProviderKind = "tresor"
+			}
+			caCertFile = tc.caCertFile
+			caKeyFile = tc.caKeyFile
+			enableMultiCluster = tc.enableMultiCluster
+			podTokenVerifier = tc.podTokenVerifier
+			if podTokenVerifier == "" {
+				podTokenVerifier = podTokenVerifierTokenReview
+			}
 			err := validateCLIParams()
 			assert.Equal(err != nil, tc.expectError)
 		})
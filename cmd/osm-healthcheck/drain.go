@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// drainPollInterval is the interval at which the active connections of a draining Envoy are polled
+	drainPollInterval = time.Second
+
+	// binaryName is the name of the osm-healthcheck binary installed for the Envoy sidecar's preStop hook
+	binaryName = "osm-healthcheck"
+)
+
+var (
+	envoyAdminURL = fmt.Sprintf("http://%s", net.JoinHostPort(constants.LocalhostIPAddress, strconv.Itoa(constants.EnvoyAdminPort)))
+
+	// inboundConnectionsStatRegex matches the Envoy stats holding the number of active downstream connections of the
+	// inbound listener, one per address of the listener
+	inboundConnectionsStatRegex = regexp.MustCompile(fmt.Sprintf(`^listener\..*_%d\.downstream_cx_active$`, constants.EnvoyInboundListenerPort))
+)
+
+// installBinary copies the running osm-healthcheck binary to the given directory, shared with the Envoy sidecar
+// whose preStop hook runs it to drain Envoy. The Envoy image has no shell or HTTP client to do so.
+func installBinary(dir string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	src, err := os.Open(executable) //#nosec G304
+	if err != nil {
+		return err
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer src.Close()
+
+	// Write to a temporary file renamed once complete, so that the preStop hook never runs a partial binary
+	dst, err := os.CreateTemp(dir, "."+binaryName+".*")
+	if err != nil {
+		return err
+	}
+	//nolint: errcheck
+	defer os.Remove(dst.Name())
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	// The binary is run by the Envoy sidecar's user
+	if err := os.Chmod(dst.Name(), 0755); err != nil { //#nosec G302
+		return err
+	}
+	return os.Rename(dst.Name(), filepath.Join(dir, binaryName))
+}
+
+// drainEnvoy starts draining the inbound listeners of the Envoy instance exposing its admin interface at the given
+// URL, then waits until its active connections are closed or the given context is done. Reaching the deadline of
+// the context is not an error: the remaining connections are closed when Envoy exits.
+func drainEnvoy(ctx context.Context, adminURL string, pollInterval time.Duration) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, adminURL+"/drain_listeners?graceful&inboundonly", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error requesting Envoy to drain its listeners: %w", err)
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error requesting Envoy to drain its listeners: status %d", resp.StatusCode)
+	}
+	log.Info().Msg("Draining the Envoy sidecar")
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		connections, err := getActiveConnections(ctx, adminURL)
+		if err != nil {
+			log.Error().Err(err).Msg("Error getting the active connections of the Envoy sidecar")
+		} else if connections == 0 {
+			log.Info().Msg("Done draining the Envoy sidecar, no active connections left")
+			return nil
+		} else {
+			log.Debug().Msgf("Waiting for %d active connections of the Envoy sidecar to close", connections)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Drain duration elapsed, stopped waiting for the active connections of the Envoy sidecar")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// getActiveConnections returns the number of active downstream connections of the inbound listener of the Envoy
+// instance exposing its admin interface at the given URL. The connections of the outbound listener, which is not
+// drained, and of the admin interface are not counted.
+func getActiveConnections(ctx context.Context, adminURL string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, adminURL+"/stats?filter="+url.QueryEscape(inboundConnectionsStatRegex.String()), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %d getting Envoy stats", resp.StatusCode)
+	}
+	return sumStats(resp.Body, inboundConnectionsStatRegex)
+}
+
+// sumStats returns the sum of the values of the stats matching the given regex from Envoy's plain text stats output.
+// The sum is 0 when no stat matches, such as when the listener has been removed.
+func sumStats(r io.Reader, statRegex *regexp.Regexp) (int, error) {
+	sum := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if !found || !statRegex.MatchString(strings.TrimSpace(name)) {
+			continue
+		}
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return 0, fmt.Errorf("invalid value for stat %s: %w", name, err)
+		}
+		sum += v
+	}
+	return sum, scanner.Err()
+}
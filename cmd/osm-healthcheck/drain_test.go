@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestDrainEnvoy(t *testing.T) {
+	testCases := []struct {
+		name                string
+		drainStatusCode     int
+		connections         []int
+		timeout             time.Duration
+		expectErr           bool
+		expectedStatsPolled int32
+	}{
+		{
+			name:                "connections closed",
+			drainStatusCode:     http.StatusOK,
+			connections:         []int{3, 1, 0},
+			timeout:             time.Minute,
+			expectedStatsPolled: 3,
+		},
+		{
+			name:            "drain duration elapsed",
+			drainStatusCode: http.StatusOK,
+			connections:     []int{5},
+			timeout:         50 * time.Millisecond,
+		},
+		{
+			name:            "drain request failed",
+			drainStatusCode: http.StatusInternalServerError,
+			timeout:         time.Minute,
+			expectErr:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var drained bool
+			var polled int32
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/drain_listeners":
+					assert.Equal(http.MethodPost, req.Method)
+					assert.True(req.URL.Query().Has("graceful"))
+					assert.True(req.URL.Query().Has("inboundonly"))
+					drained = true
+					w.WriteHeader(tc.drainStatusCode)
+				case "/stats":
+					assert.Equal(inboundConnectionsStatRegex.String(), req.URL.Query().Get("filter"))
+					i := int(atomic.AddInt32(&polled, 1)) - 1
+					if i >= len(tc.connections) {
+						i = len(tc.connections) - 1
+					}
+					fmt.Fprintf(w, "listener.0.0.0.0_15003.downstream_cx_active: %d\n", tc.connections[i])
+				}
+			}))
+			defer admin.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			err := drainEnvoy(ctx, admin.URL, time.Millisecond)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.True(drained)
+			if tc.expectedStatsPolled > 0 {
+				assert.Equal(tc.expectedStatsPolled, atomic.LoadInt32(&polled))
+			}
+		})
+	}
+}
+
+func TestInstallBinary(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(installBinary(dir))
+
+	executable, err := os.Executable()
+	assert.NoError(err)
+	expected, err := os.ReadFile(executable) //#nosec G304
+	assert.NoError(err)
+	installed, err := os.ReadFile(filepath.Join(dir, binaryName)) //#nosec G304
+	assert.NoError(err)
+	assert.Equal(expected, installed)
+
+	info, err := os.Stat(filepath.Join(dir, binaryName))
+	assert.NoError(err)
+	assert.Equal(os.FileMode(0755), info.Mode().Perm())
+	entries, err := os.ReadDir(dir)
+	assert.NoError(err)
+	assert.Len(entries, 1)
+
+	assert.Error(installBinary(filepath.Join(dir, "missing")))
+}
+
+func TestSumStats(t *testing.T) {
+	assert := tassert.New(t)
+
+	stats := "listener.0.0.0.0_15003.downstream_cx_active: 2\n" +
+		"listener.[__]_15003.downstream_cx_active: 3\n" +
+		"listener.0.0.0.0_15001.downstream_cx_active: 7\n" +
+		"listener.admin.downstream_cx_active: 1\n"
+	value, err := sumStats(strings.NewReader(stats), inboundConnectionsStatRegex)
+	assert.Nil(err)
+	assert.Equal(5, value)
+
+	value, err = sumStats(strings.NewReader("server.live: 1\n"), inboundConnectionsStatRegex)
+	assert.Nil(err)
+	assert.Equal(0, value)
+
+	_, err = sumStats(strings.NewReader("listener.0.0.0.0_15003.downstream_cx_active: abc\n"), inboundConnectionsStatRegex)
+	assert.NotNil(err)
+}
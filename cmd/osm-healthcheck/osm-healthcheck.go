@@ -1,5 +1,7 @@
 // Package main implements the main entrypoint for osm-healthcheck.
-// osm-healthcheck provides TCPSocket probe support for pods in the mesh.
+// osm-healthcheck provides TCPSocket probe support for pods in the mesh, holds the
+// start of a pod's application until its Envoy sidecar is ready, and drains the
+// Envoy sidecar of a pod when it terminates.
 package main
 
 import (
@@ -24,9 +26,19 @@ func main() {
 	log.Info().Msgf("Starting osm-healthcheck %s; %s; %s", version.Version, version.GitCommit, version.BuildDate)
 
 	var verbosity string
+	var drain bool
+	var drainDuration time.Duration
+	var installDir string
+	var waitForEnvoyReadiness bool
+	var waitTimeout time.Duration
 
 	flags := pflag.NewFlagSet("osm-healthcheck", pflag.ExitOnError)
 	flags.StringVarP(&verbosity, "verbosity", "v", "info", "Set log verbosity level")
+	flags.BoolVar(&drain, "drain", false, "Drain the Envoy sidecar and exit once its active connections are closed or the drain duration elapses")
+	flags.DurationVar(&drainDuration, "drain-duration", 30*time.Second, "Maximum duration to wait for the active connections of the Envoy sidecar to close when draining")
+	flags.StringVar(&installDir, "install-dir", "", "Directory to install the osm-healthcheck binary to, for the Envoy sidecar's preStop hook to drain it")
+	flags.BoolVar(&waitForEnvoyReadiness, "wait-for-envoy-ready", false, "Wait for the Envoy sidecar to be ready and exit")
+	flags.DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum duration to wait for the Envoy sidecar to be ready")
 
 	err := flags.Parse(os.Args)
 	if err != nil {
@@ -37,12 +49,40 @@ func main() {
 		log.Fatal().Err(err).Msg("Error setting log level")
 	}
 
+	if waitForEnvoyReadiness {
+		// Used as the postStart hook of the osm-healthcheck container, holding the start of the
+		// application containers until the Envoy sidecar is ready
+		ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+		err := waitForEnvoyReady(ctx, envoyAdminURL, readyPollInterval)
+		cancel()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error waiting for the Envoy sidecar to be ready")
+		}
+		return
+	}
+
+	if drain {
+		// Used as the preStop hook of the Envoy sidecar, run from the binary installed in the volume shared with the
+		// osm-healthcheck container. Envoy's admin interface is only reachable from the pod's loopback interface.
+		ctx, cancel := context.WithTimeout(context.Background(), drainDuration)
+		err := drainEnvoy(ctx, envoyAdminURL, drainPollInterval)
+		cancel()
+		if err != nil {
+			log.Fatal().Err(err).Msg("Error draining the Envoy sidecar")
+		}
+		return
+	}
+
+	if installDir != "" {
+		if err := installBinary(installDir); err != nil {
+			log.Error().Err(err).Msgf("Error installing osm-healthcheck to %s, the Envoy sidecar will not be drained", installDir)
+		}
+	}
+
 	stop := signals.RegisterExitHandlers()
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
 	serverMux := http.NewServeMux()
-	serverMux.HandleFunc("/osm-healthcheck", healthcheckHandler)
+	serverMux.HandleFunc(constants.HealthcheckPath, healthcheckHandler)
 
 	// Initialize osm-healthcheck HTTP server
 	server := &http.Server{
@@ -60,6 +100,8 @@ func main() {
 
 	<-stop
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
 		log.Error().Err(err).Msg("Error shutting down OSM healthcheck HTTP server")
 	} else {
@@ -80,7 +122,7 @@ func healthcheckHandler(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	address := fmt.Sprintf("%s:%s", constants.LocalhostIPAddress, port)
+	address := net.JoinHostPort(constants.LocalhostIPAddress, port)
 	conn, err := net.Dial("tcp", address)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to establish connection to %s", address)
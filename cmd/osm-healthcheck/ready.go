@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// readyPollInterval is the interval at which the readiness of a starting Envoy is polled
+	readyPollInterval = 500 * time.Millisecond
+)
+
+// waitForEnvoyReady waits until the Envoy instance exposing its admin interface at the given URL reports being
+// ready, i.e. it received and initialized its listeners and clusters, or until the given context is done.
+func waitForEnvoyReady(ctx context.Context, adminURL string, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		ready, err := isEnvoyReady(ctx, adminURL)
+		if err != nil {
+			log.Debug().Err(err).Msg("Error getting the readiness of the Envoy sidecar")
+		} else if ready {
+			log.Info().Msg("The Envoy sidecar is ready")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the Envoy sidecar to be ready: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isEnvoyReady returns whether the Envoy instance exposing its admin interface at the given URL is ready
+func isEnvoyReady(ctx context.Context, adminURL string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, adminURL+"/ready", nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestWaitForEnvoyReady(t *testing.T) {
+	testCases := []struct {
+		name           string
+		readyAfter     int32
+		timeout        time.Duration
+		expectErr      bool
+		expectedPolled int32
+	}{
+		{
+			name:           "ready immediately",
+			readyAfter:     1,
+			timeout:        time.Minute,
+			expectedPolled: 1,
+		},
+		{
+			name:           "ready after initializing",
+			readyAfter:     3,
+			timeout:        time.Minute,
+			expectedPolled: 3,
+		},
+		{
+			name:       "never ready",
+			readyAfter: 1000000,
+			timeout:    50 * time.Millisecond,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			var polled int32
+			admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal("/ready", req.URL.Path)
+				if atomic.AddInt32(&polled, 1) < tc.readyAfter {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer admin.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			err := waitForEnvoyReady(ctx, admin.URL, time.Millisecond)
+			assert.Equal(tc.expectErr, err != nil)
+			if tc.expectedPolled > 0 {
+				assert.Equal(tc.expectedPolled, atomic.LoadInt32(&polled))
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/health"
 
 	"github.com/openservicemesh/osm/pkg/certificate/providers"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/httpserver"
@@ -90,6 +91,7 @@ func init() {
 	flags.StringVar(&certProviderKind, "certificate-manager", providers.TresorKind.String(), fmt.Sprintf("Certificate manager, one of [%v]", providers.ValidCertificateProviders))
 	flags.BoolVar(&enableMeshRootCertificate, "enable-mesh-root-certificate", false, "Enable unsupported MeshRootCertificate to create the OSM Certificate Manager")
 	flags.StringVar(&caBundleSecretName, "ca-bundle-secret-name", "", "Name of the Kubernetes Secret for the OSM CA bundle")
+	flags.StringVar(&tresorOptions.IntermediateSecretName, "ca-intermediate-secret-name", "", "Name of the Kubernetes Secret for the intermediate CA used by Tresor to issue certificates")
 
 	// TODO (#4502): Remove when we add full MRC support
 	flags.StringVar(&trustDomain, "trust-domain", "cluster.local", "The trust domain to use as part of the common name when requesting new certificates")
@@ -102,6 +104,13 @@ func init() {
 	flags.IntVar(&vaultOptions.VaultPort, "vault-port", 8200, "Port of the Hashi Vault")
 	flags.StringVar(&vaultOptions.VaultTokenSecretName, "vault-token-secret-name", "", "Name of the secret storing the Vault token used in OSM")
 	flags.StringVar(&vaultOptions.VaultTokenSecretKey, "vault-token-secret-key", "", "Key for the vault token used in OSM")
+	flags.StringVar(&vaultOptions.VaultAuthMethod, "vault-auth-method", providers.VaultAuthMethodToken, fmt.Sprintf("Method used to authenticate to the Hashi Vault, one of [%s %s %s]", providers.VaultAuthMethodToken, providers.VaultAuthMethodKubernetes, providers.VaultAuthMethodAppRole))
+	flags.StringVar(&vaultOptions.VaultAuthMountPath, "vault-auth-mount-path", "", "Path the kubernetes or approle auth method is mounted at in the Hashi Vault, defaults to the name of the auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthRole, "vault-kubernetes-auth-role", "", "Vault role bound to the service account of OSM, for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultKubernetesAuthTokenPath, "vault-kubernetes-auth-token-path", vault.DefaultKubernetesAuthTokenPath, "Path of the projected service account token, for the kubernetes auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleID, "vault-approle-role-id", "", "Role ID of the AppRole, for the approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretName, "vault-approle-secret-id-secret-name", "", "Name of the secret storing the secret ID of the AppRole, for the approle auth method")
+	flags.StringVar(&vaultOptions.VaultAppRoleSecretIDSecretKey, "vault-approle-secret-id-secret-key", "", "Key for the secret ID of the AppRole, for the approle auth method")
 
 	// Cert-manager certificate manager/provider options
 	flags.StringVar(&certManagerOptions.IssuerName, "cert-manager-issuer-name", "osm-ca", "cert-manager issuer name")
@@ -198,7 +207,7 @@ func main() {
 	}
 
 	// Initialize kubernetes.Controller to watch kubernetes resources
-	kubeController := k8s.NewClient(osmNamespace, osmMeshConfigName, informerCollection, policyClient, msgBroker, k8s.Namespaces)
+	kubeController := k8s.NewClient(osmNamespace, osmMeshConfigName, informerCollection, policyClient, msgBroker, k8s.Namespaces, k8s.WorkloadEntry)
 
 	certOpts, err := getCertOptions()
 	if err != nil {
@@ -227,6 +236,9 @@ func main() {
 		events.GenericEventRecorder().FatalEvent(err, events.InitializationError, fmt.Sprintf("Error creating sidecar injector webhook: %s", err))
 	}
 
+	// Start the routine that creates the bootstrap config of the sidecars running on WorkloadEntry hosts
+	go injector.WatchAndCreateWorkloadEntryBootstrap(kubeClient, certManager, kubeController, msgBroker, meshName, osmNamespace, stop)
+
 	version.SetMetric()
 	/*
 	 * Initialize osm-injector's HTTP server
@@ -73,7 +73,7 @@ spec:
             vault write pki/config/urls issuing_certificates='http://127.0.0.1:8200/v1/pki/ca' crl_distribution_points='http://127.0.0.1:8200/v1/pki/crl';
 
             # Configure a role for OSM (See: https://www.vaultproject.io/docs/secrets/pki#configure-a-role)
-            vault write pki/roles/${VAULT_ROLE} allow_any_name=true allow_subdomains=true max_ttl=87700h;
+            vault write pki/roles/${VAULT_ROLE} allow_any_name=true allow_subdomains=true allowed_uri_sans='spiffe://*' max_ttl=87700h;
 
             # Create the root certificate (See: https://www.vaultproject.io/docs/secrets/pki#setup)
             vault write pki/root/generate/internal common_name='osm.root' ttl='87700h';
@@ -0,0 +1,71 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateRevocation revokes certificates issued to the workloads of the
+// mesh, such as certificates whose private keys are compromised. Revoked
+// certificates are reissued, and rejected by the proxies of the mesh. The
+// revocation is not accepted when the proxies can't reject the revoked
+// certificates, because the certificate provider can't sign certificate
+// revocation lists or trust domains are federated.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CertificateRevocation struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the CertificateRevocation specification
+	// +optional
+	Spec CertificateRevocationSpec `json:"spec,omitempty"`
+
+	// Status of the CertificateRevocation
+	// +optional
+	Status CertificateRevocationStatus `json:"status,omitempty"`
+}
+
+// CertificateRevocationSpec defines the CertificateRevocation specification
+type CertificateRevocationSpec struct {
+	// SerialNumbers specifies the serial numbers of the revoked certificates,
+	// as reported by OSM.
+	// +optional
+	SerialNumbers []string `json:"serialNumbers,omitempty"`
+
+	// Identities specifies the service identities, of the form
+	// <service-account>.<namespace>, whose certificates issued before the
+	// creation of the CertificateRevocation are revoked.
+	// +optional
+	Identities []string `json:"identities,omitempty"`
+}
+
+// CertificateRevocationStatus defines the status of a CertificateRevocation
+type CertificateRevocationStatus struct {
+	// RevokedSerialNumbers lists the serial numbers of the certificates of the
+	// revoked identities, as found by the control plane.
+	// +optional
+	RevokedSerialNumbers []string `json:"revokedSerialNumbers,omitempty"`
+
+	// Conditions of the CertificateRevocation. The known condition type is
+	// `Accepted`.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CertificateRevocationConditionAccepted is the type of the condition of a
+// CertificateRevocation reporting whether the proxies reject the revoked
+// certificates.
+const CertificateRevocationConditionAccepted = "Accepted"
+
+// CertificateRevocationList defines the list of CertificateRevocation objects
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CertificateRevocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []CertificateRevocation `json:"items"`
+}
@@ -50,11 +50,85 @@ const (
 	LocalProxyModePodIP LocalProxyMode = "PodIP"
 )
 
+// NativeSidecarMode is a type alias representing whether the envoy sidecar is injected as a native sidecar container
+type NativeSidecarMode string
+
+const (
+	// NativeSidecarModeAuto indicates the sidecar is injected as a native sidecar container when the Kubernetes API server supports it
+	NativeSidecarModeAuto NativeSidecarMode = "Auto"
+	// NativeSidecarModeEnabled indicates the sidecar is always injected as a native sidecar container
+	NativeSidecarModeEnabled NativeSidecarMode = "Enabled"
+	// NativeSidecarModeDisabled indicates the sidecar is always injected as a regular container
+	NativeSidecarModeDisabled NativeSidecarMode = "Disabled"
+)
+
+// RedirectionBackend is a type alias representing the packet filtering framework programming the traffic interception and redirection rules of meshed pods
+type RedirectionBackend string
+
+const (
+	// RedirectionBackendIptables indicates the rules are programmed with iptables
+	RedirectionBackendIptables RedirectionBackend = "iptables"
+	// RedirectionBackendNftables indicates the rules are programmed with nftables, for nodes whose kernel lacks the iptables modules
+	RedirectionBackendNftables RedirectionBackend = "nftables"
+)
+
+// KeyAlgorithm is a type alias representing the algorithm used to generate the private keys of certificates
+type KeyAlgorithm string
+
+const (
+	// KeyAlgorithmRSA indicates RSA keys, whose size is defined by the certificate key bit size
+	KeyAlgorithmRSA KeyAlgorithm = "rsa"
+	// KeyAlgorithmECDSAP256 indicates ECDSA keys on the NIST P-256 curve
+	KeyAlgorithmECDSAP256 KeyAlgorithm = "ecdsa-p256"
+	// KeyAlgorithmECDSAP384 indicates ECDSA keys on the NIST P-384 curve
+	KeyAlgorithmECDSAP384 KeyAlgorithm = "ecdsa-p384"
+)
+
+// IdentityMatchMode is a type alias representing how the identities of workloads are matched in their certificates
+type IdentityMatchMode string
+
+const (
+	// IdentityMatchModeCompat indicates a workload is identified by either the SPIFFE ID in the URI SAN of its certificate,
+	// or the <service-account>.<namespace>.<trust-domain> name in the DNS SAN of certificates issued without a SPIFFE ID
+	IdentityMatchModeCompat IdentityMatchMode = "Compat"
+	// IdentityMatchModeSPIFFE indicates a workload is only identified by the SPIFFE ID in the URI SAN of its certificate
+	IdentityMatchModeSPIFFE IdentityMatchMode = "SPIFFE"
+)
+
+// KeyGenerationMode is a type alias representing where the private keys of the Envoy sidecars are generated
+type KeyGenerationMode string
+
+const (
+	// KeyGenerationModeController indicates the private keys of the Envoy sidecars are generated by osm-controller, and
+	// sent to the sidecars with their certificates
+	KeyGenerationModeController KeyGenerationMode = "Controller"
+	// KeyGenerationModeWorkload indicates the private keys of the Envoy sidecars are generated in their pods, which
+	// obtain their certificates from osm-controller with certificate signing requests
+	KeyGenerationModeWorkload KeyGenerationMode = "Workload"
+)
+
+// XDSAuthenticationMode is a type alias representing how the Envoy sidecars authenticate to the xDS server
+type XDSAuthenticationMode string
+
+const (
+	// XDSAuthenticationModeCertificate indicates the Envoy sidecars authenticate with the client certificates of
+	// their bootstrap Secrets
+	XDSAuthenticationModeCertificate XDSAuthenticationMode = "Certificate"
+	// XDSAuthenticationModeServiceAccountToken indicates the Envoy sidecars authenticate with the service account
+	// tokens projected in their pods, bound to the audience of osm-controller and to the pods
+	XDSAuthenticationModeServiceAccountToken XDSAuthenticationMode = "ServiceAccountToken"
+)
+
 // SidecarSpec is the type used to represent the specifications for the proxy sidecar.
 type SidecarSpec struct {
 	// EnablePrivilegedInitContainer defines a boolean indicating whether the init container for a meshed pod should run as privileged.
 	EnablePrivilegedInitContainer bool `json:"enablePrivilegedInitContainer"`
 
+	// EnableCNI defines a boolean indicating whether the traffic interception and redirection of meshed pods is
+	// programmed by the osm-cni plugin when their sandbox is created, instead of by an init container requiring the
+	// NET_ADMIN capability. Meshed pods are then only scheduled on the nodes the osm-cni plugin is installed on.
+	EnableCNI bool `json:"enableCNI,omitempty"`
+
 	// LogLevel defines the logging level for the sidecar's logs. Non developers should generally never set this value. In production environments the LogLevel should be set to error.
 	LogLevel string `json:"logLevel,omitempty"`
 
@@ -88,8 +162,35 @@ type SidecarSpec struct {
 	// ECDHCurves defines a list of ECDH curves that TLS connection supports. If not specified, the curves are [X25519, P-256] for non-FIPS build and P-256 for builds using BoringSSL FIPS.
 	ECDHCurves []string `json:"ecdhCurves,omitempty"`
 
+	// DrainDuration defines the maximum duration the sidecar drains its inbound listeners and waits for its active
+	// connections to close when its pod terminates, e.g. "30s". Draining is disabled when empty or 0s.
+	// It can be overridden for a pod with the openservicemesh.io/sidecar-drain-duration annotation.
+	DrainDuration string `json:"drainDuration,omitempty"`
+
+	// HoldApplicationUntilProxyStarts defines a boolean indicating whether the application containers of meshed pods
+	// only start once their sidecar is ready. It can be overridden for a pod with the
+	// openservicemesh.io/hold-application-until-proxy-starts annotation.
+	HoldApplicationUntilProxyStarts bool `json:"holdApplicationUntilProxyStarts,omitempty"`
+
+	// NativeSidecarMode defines whether the sidecar is injected as a native sidecar container, i.e. an init container
+	// with an Always restart policy, started before and terminated after the application containers. Acceptable values
+	// are [`Auto`, `Enabled`, `Disabled`]. The default is `Auto`, selecting native sidecar containers when the
+	// Kubernetes API server supports them. `Enabled` and `Disabled` override the detection, and pods are rejected when
+	// `Enabled` but not supported.
+	NativeSidecarMode NativeSidecarMode `json:"nativeSidecarMode,omitempty"`
+
 	// LocalProxyMode defines the network interface the envoy proxy will use to send traffic to the backend service application. Acceptable values are [`Localhost`, `PodIP`]. The default is `Localhost`
 	LocalProxyMode LocalProxyMode `json:"localProxyMode,omitempty"`
+
+	// RedirectionBackend defines the packet filtering framework programming the traffic interception and redirection rules of meshed pods. Acceptable values are [`iptables`, `nftables`]. The default is `iptables`
+	RedirectionBackend RedirectionBackend `json:"redirectionBackend,omitempty"`
+
+	// XDSAuthentication defines how the Envoy sidecars injected authenticate to the xDS server. Acceptable values are
+	// [`Certificate`, `ServiceAccountToken`]. The default is `Certificate`. With `ServiceAccountToken`, the bootstrap
+	// Secrets of the sidecars carry no credentials, and the sidecars are identified by the service account tokens
+	// projected in their pods. It only applies to the Linux pods injected once it is set.
+	// +optional
+	XDSAuthentication XDSAuthenticationMode `json:"xdsAuthentication,omitempty"`
 }
 
 // TrafficSpec is the type used to represent OSM's traffic management configuration.
@@ -181,6 +282,23 @@ type CertificateSpec struct {
 	// CertKeyBitSize defines the certicate key bit size.
 	CertKeyBitSize int `json:"certKeyBitSize,omitempty"`
 
+	// KeyAlgorithm defines the algorithm used to generate the private keys of certificates.
+	// Acceptable values are [`rsa`, `ecdsa-p256`, `ecdsa-p384`]. The default is `rsa`.
+	// +optional
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+
+	// IdentityMatchMode defines how the identities of workloads are matched in their certificates when enforcing
+	// access control and verifying upstream peers. Acceptable values are [`Compat`, `SPIFFE`]. The default is `Compat`.
+	// +optional
+	IdentityMatchMode IdentityMatchMode `json:"identityMatchMode,omitempty"`
+
+	// KeyGeneration defines where the private keys of the Envoy sidecars injected are generated.
+	// Acceptable values are [`Controller`, `Workload`]. The default is `Controller`.
+	// With `Workload`, the private keys never leave the pods, and are generated by the osm-cert-agent container
+	// injected alongside the Envoy sidecars. It only applies to the Linux pods injected once it is set.
+	// +optional
+	KeyGeneration KeyGenerationMode `json:"keyGeneration,omitempty"`
+
 	// IngressGateway defines the certificate specification for an ingress gateway.
 	// +optional
 	IngressGateway *IngressGatewayCertSpec `json:"ingressGateway,omitempty"`
@@ -81,8 +81,59 @@ type VaultProviderSpec struct {
 	Protocol string `json:"protocol"`
 
 	// Token specifies the configuration of the token to be used by mesh control plane
-	// to connect to Vault
-	Token VaultTokenSpec `json:"token"`
+	// to connect to Vault. It is not used when Auth is specified.
+	// +optional
+	Token VaultTokenSpec `json:"token,omitempty"`
+
+	// Auth specifies the auth method used by mesh control plane to log in to Vault,
+	// in place of a static token. The tokens obtained are renewed, and obtained again
+	// once they can no longer be renewed.
+	// +optional
+	Auth *VaultAuthSpec `json:"auth,omitempty"`
+}
+
+// VaultAuthSpec defines the auth method used to log in to Vault.
+// Exactly one auth method must be specified.
+type VaultAuthSpec struct {
+	// Kubernetes specifies the Kubernetes auth method, logging in with a projected
+	// service account token of the mesh control plane
+	// +optional
+	Kubernetes *VaultKubernetesAuthSpec `json:"kubernetes,omitempty"`
+
+	// AppRole specifies the AppRole auth method
+	// +optional
+	AppRole *VaultAppRoleAuthSpec `json:"appRole,omitempty"`
+}
+
+// VaultKubernetesAuthSpec defines the configuration of the Vault Kubernetes auth method
+type VaultKubernetesAuthSpec struct {
+	// Role specifies the name of the Vault role bound to the service account of the
+	// mesh control plane
+	Role string `json:"role"`
+
+	// MountPath specifies the path the Kubernetes auth method is mounted at,
+	// defaults to `kubernetes`
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// TokenPath specifies the path of the projected service account token,
+	// defaults to `/var/run/secrets/tokens/vault-token`
+	// +optional
+	TokenPath string `json:"tokenPath,omitempty"`
+}
+
+// VaultAppRoleAuthSpec defines the configuration of the Vault AppRole auth method
+type VaultAppRoleAuthSpec struct {
+	// RoleID specifies the role ID of the AppRole
+	RoleID string `json:"roleID"`
+
+	// SecretIDRef specifies the secret in which the secret ID of the AppRole is stored
+	SecretIDRef SecretKeyReferenceSpec `json:"secretIDRef"`
+
+	// MountPath specifies the path the AppRole auth method is mounted at,
+	// defaults to `approle`
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
 }
 
 // VaultTokenSpec defines the configuration of the Vault token
@@ -113,6 +164,13 @@ type TresorProviderSpec struct {
 type TresorCASpec struct {
 	// SecretRef specifies the secret in which the root certificate is stored
 	SecretRef corev1.SecretReference `json:"secretRef"`
+
+	// IntermediateSecretRef specifies the secret in which an intermediate CA signed by an offline root is stored.
+	// When set, certificates are issued by the intermediate CA instead of a root generated by Tresor, and SecretRef
+	// is not used. The secret stores the root certificate in `ca.crt`, the certificate chain of the intermediate CA
+	// in `tls.crt`, and the private key of the intermediate CA in `tls.key`.
+	// +optional
+	IntermediateSecretRef *corev1.SecretReference `json:"intermediateSecretRef,omitempty"`
 }
 
 // MeshRootCertificateIntent specifies the intent of the MeshRootCertificate
@@ -37,10 +37,16 @@ func Resource(resource string) schema.GroupResource {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&CertificateRevocation{},
+		&CertificateRevocationList{},
 		&MeshConfig{},
 		&MeshConfigList{},
 		&MeshRootCertificate{},
 		&MeshRootCertificateList{},
+		&RemoteCluster{},
+		&RemoteClusterList{},
+		&TrustDomainFederation{},
+		&TrustDomainFederationList{},
 	)
 
 	metav1.AddToGroupVersion(
@@ -0,0 +1,59 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteCluster registers a peer cluster with the mesh. The services exported
+// by the peer cluster become part of the mesh, and are reached through the
+// east-west gateway of the peer cluster.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RemoteCluster struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the RemoteCluster specification
+	// +optional
+	Spec RemoteClusterSpec `json:"spec,omitempty"`
+}
+
+// RemoteClusterSpec defines the RemoteCluster specification
+type RemoteClusterSpec struct {
+	// KubeconfigSecretRef specifies the secret whose 'kubeconfig' key holds the
+	// kubeconfig used to watch the services exported by the peer cluster.
+	KubeconfigSecretRef corev1.SecretReference `json:"kubeconfigSecretRef"`
+
+	// GatewayAddress specifies the IP address of the east-west gateway of the
+	// peer cluster.
+	GatewayAddress string `json:"gatewayAddress"`
+
+	// TrustDomain specifies the trust domain of the certificates issued in the
+	// peer cluster. Defaults to the trust domain of the local cluster.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+
+	// Priority specifies the priority of the peer cluster's endpoints when
+	// failing over from local endpoints, which have priority 0. Defaults to 1.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+
+	// Weight specifies the share of the traffic sent to the peer cluster among
+	// the peer clusters with the same priority. Defaults to 1.
+	// +optional
+	Weight int `json:"weight,omitempty"`
+}
+
+// RemoteClusterList defines the list of RemoteCluster objects
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RemoteClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []RemoteCluster `json:"items"`
+}
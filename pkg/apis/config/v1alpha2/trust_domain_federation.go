@@ -0,0 +1,57 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TrustDomainFederation imports the root certificates of a foreign trust
+// domain, so that workloads of the mesh trust the certificates issued to the
+// workloads of the foreign trust domain.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TrustDomainFederation struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the TrustDomainFederation specification
+	// +optional
+	Spec TrustDomainFederationSpec `json:"spec,omitempty"`
+}
+
+// TrustDomainFederationSpec defines the TrustDomainFederation specification
+type TrustDomainFederationSpec struct {
+	// TrustDomain specifies the foreign trust domain. It must differ from the
+	// trust domain of the mesh.
+	TrustDomain string `json:"trustDomain"`
+
+	// TrustBundle specifies the source of the root certificates of the foreign
+	// trust domain.
+	TrustBundle TrustBundleSourceSpec `json:"trustBundle"`
+}
+
+// TrustBundleSourceSpec defines the source of the root certificates of a
+// foreign trust domain. Exactly one source must be specified.
+type TrustBundleSourceSpec struct {
+	// SecretKeyRef specifies the secret key holding the PEM encoded root
+	// certificates of the foreign trust domain.
+	// +optional
+	SecretKeyRef *SecretKeyReferenceSpec `json:"secretKeyRef,omitempty"`
+
+	// SPIFFEBundle specifies the contents of the SPIFFE bundle file of the
+	// foreign trust domain, as served by its SPIFFE bundle endpoint.
+	// +optional
+	SPIFFEBundle string `json:"spiffeBundle,omitempty"`
+}
+
+// TrustDomainFederationList defines the list of TrustDomainFederation objects
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TrustDomainFederationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []TrustDomainFederation `json:"items"`
+}
@@ -0,0 +1,140 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HTTPFilterExtension is the type used to represent user-defined Envoy HTTP
+// filters, such as WASM modules or Lua scripts, attached to the HTTP filter
+// chains of the workloads it selects.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HTTPFilterExtension struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the HTTPFilterExtension specification
+	// +optional
+	Spec HTTPFilterExtensionSpec `json:"spec,omitempty"`
+}
+
+// HTTPFilterExtensionSpec is the type used to represent the HTTPFilterExtension specification.
+type HTTPFilterExtensionSpec struct {
+	// Selector defines the labels of the pods and WorkloadEntry resources, in the
+	// namespace of the HTTPFilterExtension, the filters are attached to.
+	// All the workloads in the namespace are selected when empty.
+	// +optional
+	Selector map[string]string `json:"selector,omitempty"`
+
+	// Direction defines the traffic direction whose HTTP filter chains the filters
+	// are added to, one of inbound, outbound or both. Defaults to inbound.
+	// +optional
+	Direction HTTPFilterDirection `json:"direction,omitempty"`
+
+	// Position defines where the filters are added relative to the HTTP filters
+	// built by OSM, one of first or last. Filters in the last position are added
+	// right before the router filter. Defaults to last.
+	// +optional
+	Position HTTPFilterPosition `json:"position,omitempty"`
+
+	// Filters defines the HTTP filters, in the order they are added to the chain.
+	Filters []HTTPFilterSpec `json:"filters"`
+}
+
+// HTTPFilterDirection is the type used to represent the traffic direction of an HTTPFilterExtension.
+type HTTPFilterDirection string
+
+const (
+	// HTTPFilterDirectionInbound adds the filters to the inbound HTTP filter chains
+	HTTPFilterDirectionInbound HTTPFilterDirection = "inbound"
+
+	// HTTPFilterDirectionOutbound adds the filters to the outbound HTTP filter chains
+	HTTPFilterDirectionOutbound HTTPFilterDirection = "outbound"
+
+	// HTTPFilterDirectionBoth adds the filters to the inbound and outbound HTTP filter chains
+	HTTPFilterDirectionBoth HTTPFilterDirection = "both"
+)
+
+// HTTPFilterPosition is the type used to represent the position of the filters of an HTTPFilterExtension
+// relative to the HTTP filters built by OSM.
+type HTTPFilterPosition string
+
+const (
+	// HTTPFilterPositionFirst adds the filters before the HTTP filters built by OSM
+	HTTPFilterPositionFirst HTTPFilterPosition = "first"
+
+	// HTTPFilterPositionLast adds the filters after the HTTP filters built by OSM, right before the router filter
+	HTTPFilterPositionLast HTTPFilterPosition = "last"
+)
+
+// HTTPFilterType is the type used to represent the type of a user-defined HTTP filter.
+type HTTPFilterType string
+
+const (
+	// HTTPFilterTypeWASM is the type of a WASM HTTP filter
+	HTTPFilterTypeWASM HTTPFilterType = "wasm"
+
+	// HTTPFilterTypeLua is the type of a Lua HTTP filter
+	HTTPFilterTypeLua HTTPFilterType = "lua"
+)
+
+// HTTPFilterSpec is the type used to represent a user-defined HTTP filter.
+type HTTPFilterSpec struct {
+	// Name defines the name of the filter, unique within the HTTPFilterExtension.
+	Name string `json:"name"`
+
+	// Type defines the type of the filter, one of wasm or lua.
+	Type HTTPFilterType `json:"type"`
+
+	// WASM defines the configuration of a WASM filter.
+	// +optional
+	WASM *WASMFilterSpec `json:"wasm,omitempty"`
+
+	// Lua defines the configuration of a Lua filter.
+	// +optional
+	Lua *LuaFilterSpec `json:"lua,omitempty"`
+}
+
+// WASMFilterSpec is the type used to represent the configuration of a WASM HTTP filter.
+type WASMFilterSpec struct {
+	// ConfigMapRef defines the ConfigMap, in the namespace of the HTTPFilterExtension,
+	// holding the WASM module. The ConfigMap must be labeled with
+	// openservicemesh.io/http-filter-module=true to be read by OSM.
+	ConfigMapRef ConfigMapKeyRef `json:"configMapRef"`
+
+	// RootID defines the root ID of the WASM plugin.
+	// +optional
+	RootID string `json:"rootID,omitempty"`
+
+	// Configuration defines the configuration passed to the WASM plugin as a string.
+	// +optional
+	Configuration string `json:"configuration,omitempty"`
+}
+
+// ConfigMapKeyRef is the type used to reference a key of a ConfigMap.
+type ConfigMapKeyRef struct {
+	// Name defines the name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key defines the key of the ConfigMap's binary data holding the module.
+	Key string `json:"key"`
+}
+
+// LuaFilterSpec is the type used to represent the configuration of a Lua HTTP filter.
+type LuaFilterSpec struct {
+	// InlineCode defines the Lua script.
+	InlineCode string `json:"inlineCode"`
+}
+
+// HTTPFilterExtensionList defines the list of HTTPFilterExtension objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type HTTPFilterExtensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HTTPFilterExtension `json:"items"`
+}
@@ -45,6 +45,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RetryList{},
 		&UpstreamTrafficSetting{},
 		&UpstreamTrafficSettingList{},
+		&WorkloadEntry{},
+		&WorkloadEntryList{},
+		&HTTPFilterExtension{},
+		&HTTPFilterExtensionList{},
 	)
 
 	metav1.AddToGroupVersion(
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadEntry is the type used to represent a workload running outside of
+// Kubernetes, such as on a VM or bare-metal host, with an Envoy sidecar that
+// is part of the mesh.
+// Like pods, WorkloadEntry resources are selected by the Services whose
+// selector matches their labels.
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkloadEntry struct {
+	// Object's type metadata
+	metav1.TypeMeta `json:",inline"`
+
+	// Object's metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the WorkloadEntry specification
+	// +optional
+	Spec WorkloadEntrySpec `json:"spec,omitempty"`
+}
+
+// WorkloadEntrySpec is the type used to represent the WorkloadEntry specification.
+type WorkloadEntrySpec struct {
+	// Address is the IP address of the workload.
+	Address string `json:"address"`
+
+	// ServiceAccount is the name of the ServiceAccount, in the namespace of the
+	// WorkloadEntry, whose identity the workload runs with.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// Ports defines the named ports the workload listens on. Services selecting
+	// the WorkloadEntry with a named target port resolve it using these ports.
+	// +optional
+	Ports []WorkloadEntryPortSpec `json:"ports,omitempty"`
+}
+
+// WorkloadEntryPortSpec is the type used to represent a named port of a WorkloadEntry.
+type WorkloadEntryPortSpec struct {
+	// Name defines the name of the port.
+	Name string `json:"name"`
+
+	// Number defines the port number.
+	Number int `json:"number"`
+}
+
+// WorkloadEntryList defines the list of WorkloadEntry objects.
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkloadEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []WorkloadEntry `json:"items"`
+}
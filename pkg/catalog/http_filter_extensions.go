@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"fmt"
+	"sort"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// GetHTTPFilterExtensions returns the user-defined HTTP filters of the given proxy, resolved from the
+// HTTPFilterExtension resources selecting its workload. Extensions are applied in the order of their names, and the
+// filters of an extension in the order they are defined.
+func (mc *MeshCatalog) GetHTTPFilterExtensions(proxy *envoy.Proxy) (*trafficpolicy.HTTPFilterExtensions, error) {
+	extensions, err := mc.ListHTTPFilterExtensionsForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Name < extensions[j].Name
+	})
+
+	httpFilters := &trafficpolicy.HTTPFilterExtensions{}
+	for _, extension := range extensions {
+		var filters []trafficpolicy.HTTPFilter
+		for _, filterSpec := range extension.Spec.Filters {
+			filter, err := mc.getHTTPFilter(extension, filterSpec)
+			if err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrResolvingHTTPFilterExtension)).
+					Str("proxy", proxy.String()).Msgf("Error resolving filter %s of HTTPFilterExtension %s/%s, skipping it",
+					filterSpec.Name, extension.Namespace, extension.Name)
+				continue
+			}
+			filters = append(filters, filter)
+		}
+
+		var directions []*trafficpolicy.HTTPFilters
+		switch extension.Spec.Direction {
+		case policyv1alpha1.HTTPFilterDirectionOutbound:
+			directions = append(directions, &httpFilters.Outbound)
+		case policyv1alpha1.HTTPFilterDirectionBoth:
+			directions = append(directions, &httpFilters.Inbound, &httpFilters.Outbound)
+		default:
+			directions = append(directions, &httpFilters.Inbound)
+		}
+
+		for _, direction := range directions {
+			if extension.Spec.Position == policyv1alpha1.HTTPFilterPositionFirst {
+				direction.First = append(direction.First, filters...)
+			} else {
+				direction.Last = append(direction.Last, filters...)
+			}
+		}
+	}
+
+	return httpFilters, nil
+}
+
+// getHTTPFilter returns the given filter of the given HTTPFilterExtension, with its WASM module if any. An error is
+// returned when the filter is invalid, so that it is skipped rather than sent to the proxies.
+func (mc *MeshCatalog) getHTTPFilter(extension *policyv1alpha1.HTTPFilterExtension, filterSpec policyv1alpha1.HTTPFilterSpec) (trafficpolicy.HTTPFilter, error) {
+	filter := trafficpolicy.HTTPFilter{
+		Name: fmt.Sprintf("%s/%s/%s", extension.Namespace, extension.Name, filterSpec.Name),
+		Type: filterSpec.Type,
+	}
+
+	switch filterSpec.Type {
+	case policyv1alpha1.HTTPFilterTypeWASM:
+		if filterSpec.WASM == nil {
+			return filter, fmt.Errorf("missing WASM configuration")
+		}
+		module, err := mc.GetHTTPFilterModule(extension.Namespace, filterSpec.WASM.ConfigMapRef)
+		if err != nil {
+			return filter, err
+		}
+		if len(module) == 0 {
+			return filter, fmt.Errorf("empty WASM module in key %s of ConfigMap %s/%s", filterSpec.WASM.ConfigMapRef.Key,
+				extension.Namespace, filterSpec.WASM.ConfigMapRef.Name)
+		}
+		filter.WASMModule = module
+		filter.WASMRootID = filterSpec.WASM.RootID
+		filter.WASMConfiguration = filterSpec.WASM.Configuration
+
+	case policyv1alpha1.HTTPFilterTypeLua:
+		if filterSpec.Lua == nil || filterSpec.Lua.InlineCode == "" {
+			return filter, fmt.Errorf("missing Lua configuration")
+		}
+		filter.LuaInlineCode = filterSpec.Lua.InlineCode
+
+	default:
+		return filter, fmt.Errorf("unsupported filter type %q", filterSpec.Type)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestGetHTTPFilterExtensions(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	mockCompute := compute.NewMockInterface(mockCtrl)
+	mc := &MeshCatalog{
+		Interface: mockCompute,
+	}
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("bookstore", "bookstore"), nil, 1)
+
+	moduleRef := policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "auth.wasm"}
+	missingModuleRef := policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "missing.wasm"}
+	emptyModuleRef := policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "empty.wasm"}
+	extensions := []*policyv1alpha1.HTTPFilterExtension{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-headers", Namespace: "bookstore"},
+			Spec: policyv1alpha1.HTTPFilterExtensionSpec{
+				Direction: policyv1alpha1.HTTPFilterDirectionBoth,
+				Filters: []policyv1alpha1.HTTPFilterSpec{
+					{Name: "headers", Type: policyv1alpha1.HTTPFilterTypeLua, Lua: &policyv1alpha1.LuaFilterSpec{InlineCode: "-- headers"}},
+					{Name: "no-code", Type: policyv1alpha1.HTTPFilterTypeLua, Lua: &policyv1alpha1.LuaFilterSpec{}},
+					{Name: "golang", Type: "golang"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-auth", Namespace: "bookstore"},
+			Spec: policyv1alpha1.HTTPFilterExtensionSpec{
+				Position: policyv1alpha1.HTTPFilterPositionFirst,
+				Filters: []policyv1alpha1.HTTPFilterSpec{
+					{Name: "auth", Type: policyv1alpha1.HTTPFilterTypeWASM, WASM: &policyv1alpha1.WASMFilterSpec{ConfigMapRef: moduleRef, RootID: "auth"}},
+					{Name: "missing", Type: policyv1alpha1.HTTPFilterTypeWASM, WASM: &policyv1alpha1.WASMFilterSpec{ConfigMapRef: missingModuleRef}},
+					{Name: "empty", Type: policyv1alpha1.HTTPFilterTypeWASM, WASM: &policyv1alpha1.WASMFilterSpec{ConfigMapRef: emptyModuleRef}},
+				},
+			},
+		},
+	}
+
+	mockCompute.EXPECT().ListHTTPFilterExtensionsForProxy(proxy).Return(extensions, nil)
+	mockCompute.EXPECT().GetHTTPFilterModule("bookstore", moduleRef).Return([]byte("module"), nil)
+	mockCompute.EXPECT().GetHTTPFilterModule("bookstore", missingModuleRef).Return(nil, errors.New("not found"))
+	mockCompute.EXPECT().GetHTTPFilterModule("bookstore", emptyModuleRef).Return([]byte{}, nil)
+
+	// Invalid filters are skipped
+	httpFilters, err := mc.GetHTTPFilterExtensions(proxy)
+	assert.NoError(err)
+
+	headers := trafficpolicy.HTTPFilter{Name: "bookstore/b-headers/headers", Type: policyv1alpha1.HTTPFilterTypeLua, LuaInlineCode: "-- headers"}
+	auth := trafficpolicy.HTTPFilter{Name: "bookstore/a-auth/auth", Type: policyv1alpha1.HTTPFilterTypeWASM, WASMModule: []byte("module"), WASMRootID: "auth"}
+	assert.Equal(&trafficpolicy.HTTPFilterExtensions{
+		Inbound: trafficpolicy.HTTPFilters{
+			First: []trafficpolicy.HTTPFilter{auth},
+			Last:  []trafficpolicy.HTTPFilter{headers},
+		},
+		Outbound: trafficpolicy.HTTPFilters{
+			Last: []trafficpolicy.HTTPFilter{headers},
+		},
+	}, httpFilters)
+
+	mockCompute.EXPECT().ListHTTPFilterExtensionsForProxy(proxy).Return(nil, errors.New("proxy not found"))
+	_, err = mc.GetHTTPFilterExtensions(proxy)
+	assert.Error(err)
+}
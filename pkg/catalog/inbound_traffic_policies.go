@@ -6,6 +6,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	access "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -14,6 +15,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 const (
@@ -181,9 +183,18 @@ func (mc *MeshCatalog) getRoutingRulesFromTrafficTarget(trafficTarget access.Tra
 
 	// Compute the allowed downstream service identities for the given TrafficTarget object
 	trustDomain := mc.GetTrustDomain()
+	spiffePrincipalsOnly := utils.GetIdentityMatchMode(mc.GetMeshConfig()) == configv1alpha2.IdentityMatchModeSPIFFE
 	allowedDownstreamPrincipals := mapset.NewSet()
 	for _, source := range trafficTarget.Spec.Sources {
-		allowedDownstreamPrincipals.Add(trafficTargetIdentityToSvcAccount(source).AsPrincipal(trustDomain))
+		srcIdentity, err := trafficTargetSourceToServiceIdentity(source)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidSourceKind)).
+				Msgf("Applied TrafficTarget policy %s/%s has an invalid source", trafficTarget.Namespace, trafficTarget.Name)
+			continue
+		}
+		for _, principal := range srcIdentity.AsPrincipals(trustDomain, spiffePrincipalsOnly) {
+			allowedDownstreamPrincipals.Add(principal)
+		}
 	}
 
 	var routingRules []*trafficpolicy.Rule
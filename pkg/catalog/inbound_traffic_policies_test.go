@@ -192,7 +192,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -231,7 +231,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -497,7 +497,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 								{
 									Route: trafficpolicy.RouteWeightedClusters{
@@ -517,7 +517,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -556,7 +556,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 								{
 									Route: trafficpolicy.RouteWeightedClusters{
@@ -576,7 +576,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -745,7 +745,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -782,7 +782,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -821,7 +821,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -1137,7 +1137,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 											Weight:      100,
 										}),
 									},
-									AllowedPrincipals: mapset.NewSet("sa2.ns2.cluster.local"),
+									AllowedPrincipals: mapset.NewSet("spiffe://cluster.local/ns/ns2/sa/sa2", "sa2.ns2.cluster.local"),
 								},
 							},
 						},
@@ -1309,11 +1309,11 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 										identity.K8sServiceAccount{
 											Name:      "sa2",
 											Namespace: "ns2",
-										}.AsPrincipal("cluster.local"),
+										}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2",
 										identity.K8sServiceAccount{
 											Name:      "sa3",
 											Namespace: "ns3",
-										}.AsPrincipal("cluster.local")),
+										}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns3/sa/sa3"),
 								},
 							},
 						},
@@ -1353,11 +1353,11 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 										identity.K8sServiceAccount{
 											Name:      "sa2",
 											Namespace: "ns2",
-										}.AsPrincipal("cluster.local"),
+										}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2",
 										identity.K8sServiceAccount{
 											Name:      "sa3",
 											Namespace: "ns3",
-										}.AsPrincipal("cluster.local")),
+										}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns3/sa/sa3"),
 								},
 							},
 						},
@@ -1524,7 +1524,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -1563,7 +1563,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -1867,7 +1867,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
@@ -1908,7 +1908,7 @@ func TestGetInboundMeshTrafficPolicy(t *testing.T) {
 									AllowedPrincipals: mapset.NewSet(identity.K8sServiceAccount{
 										Name:      "sa2",
 										Namespace: "ns2",
-									}.AsPrincipal("cluster.local")),
+									}.AsPrincipal("cluster.local"), "spiffe://cluster.local/ns/ns2/sa/sa2"),
 								},
 							},
 						},
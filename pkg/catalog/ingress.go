@@ -13,11 +13,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
-)
-
-const (
-	// singeIPPrefixLen is the IP prefix length for a single IP address
-	singeIPPrefixLen = "/32"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // GetIngressTrafficPolicies returns a list of IngressTrafficPolicy objects for the given MeshService list
@@ -92,7 +88,7 @@ func (mc *MeshCatalog) GetIngressTrafficPolicy(svc service.MeshService) (*traffi
 				}
 
 				for _, ep := range endpoints {
-					sourceCIDR := ep.IP.String() + singeIPPrefixLen
+					sourceCIDR := utils.GetHostCIDR(ep.IP)
 					if sourceIPSet.Add(sourceCIDR) {
 						sourceIPRanges = append(sourceIPRanges, sourceCIDR)
 					}
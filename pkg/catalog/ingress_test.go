@@ -24,6 +24,7 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 	ingressBackendSvcEndpoints := []endpoint.Endpoint{
 		{IP: net.ParseIP("10.0.0.10"), Port: 80},
 		{IP: net.ParseIP("10.0.0.10"), Port: 90},
+		{IP: net.ParseIP("fd00:10::a"), Port: 80},
 	}
 	sourceSvcWithoutEndpoints := service.MeshService{Name: "unknown", Namespace: "IngressGatewayNs"}
 
@@ -99,7 +100,7 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 						Name:           "ingress_testns/foo_80_http",
 						Protocol:       "http",
 						Port:           80,
-						SourceIPRanges: []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+						SourceIPRanges: []string{"10.0.0.10/32", "fd00:10::a/128"}, // Endpoints of 'ingressSourceSvc' referenced as a source
 					},
 				},
 			},
@@ -167,7 +168,7 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 						Name:                     "ingress_testns/foo_80_https",
 						Protocol:                 "https",
 						Port:                     80,
-						SourceIPRanges:           []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+						SourceIPRanges:           []string{"10.0.0.10/32", "fd00:10::a/128"}, // Endpoints of 'ingressSourceSvc' referenced as a source
 						SkipClientCertValidation: false,
 						ServerNames:              []string{"foo.org"},
 					},
@@ -236,7 +237,7 @@ func TestGetIngressTrafficPolicy(t *testing.T) {
 						Name:                     "ingress_testns/foo_80_https",
 						Protocol:                 "https",
 						Port:                     80,
-						SourceIPRanges:           []string{"10.0.0.10/32"}, // Endpoint of 'ingressSourceSvc' referenced as a source
+						SourceIPRanges:           []string{"10.0.0.10/32", "fd00:10::a/128"}, // Endpoints of 'ingressSourceSvc' referenced as a source
 						SkipClientCertValidation: true,
 					},
 				},
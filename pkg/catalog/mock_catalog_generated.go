@@ -5,6 +5,7 @@
 package catalog
 
 import (
+	net "net"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -59,6 +60,36 @@ func (mr *MockMeshCatalogerMockRecorder) GetEgressTrafficPolicy(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressTrafficPolicy", reflect.TypeOf((*MockMeshCataloger)(nil).GetEgressTrafficPolicy), arg0)
 }
 
+// GetHTTPFilterExtensions mocks base method.
+func (m *MockMeshCataloger) GetHTTPFilterExtensions(arg0 *envoy.Proxy) (*trafficpolicy.HTTPFilterExtensions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPFilterExtensions", arg0)
+	ret0, _ := ret[0].(*trafficpolicy.HTTPFilterExtensions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHTTPFilterExtensions indicates an expected call of GetHTTPFilterExtensions.
+func (mr *MockMeshCatalogerMockRecorder) GetHTTPFilterExtensions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPFilterExtensions", reflect.TypeOf((*MockMeshCataloger)(nil).GetHTTPFilterExtensions), arg0)
+}
+
+// GetHTTPFilterModule mocks base method.
+func (m *MockMeshCataloger) GetHTTPFilterModule(arg0 string, arg1 v1alpha1.ConfigMapKeyRef) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPFilterModule", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHTTPFilterModule indicates an expected call of GetHTTPFilterModule.
+func (mr *MockMeshCatalogerMockRecorder) GetHTTPFilterModule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPFilterModule", reflect.TypeOf((*MockMeshCataloger)(nil).GetHTTPFilterModule), arg0, arg1)
+}
+
 // GetHostnamesForService mocks base method.
 func (m *MockMeshCataloger) GetHostnamesForService(arg0 service.MeshService, arg1 bool) []string {
 	m.ctrl.T.Helper()
@@ -301,6 +332,21 @@ func (mr *MockMeshCatalogerMockRecorder) IsMetricsEnabled(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMetricsEnabled", reflect.TypeOf((*MockMeshCataloger)(nil).IsMetricsEnabled), arg0)
 }
 
+// IsWorkloadKeyGenerationEnabled mocks base method.
+func (m *MockMeshCataloger) IsWorkloadKeyGenerationEnabled(arg0 *envoy.Proxy) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWorkloadKeyGenerationEnabled", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsWorkloadKeyGenerationEnabled indicates an expected call of IsWorkloadKeyGenerationEnabled.
+func (mr *MockMeshCatalogerMockRecorder) IsWorkloadKeyGenerationEnabled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWorkloadKeyGenerationEnabled", reflect.TypeOf((*MockMeshCataloger)(nil).IsWorkloadKeyGenerationEnabled), arg0)
+}
+
 // ListAllowedUpstreamEndpointsForService mocks base method.
 func (m *MockMeshCataloger) ListAllowedUpstreamEndpointsForService(arg0 identity.ServiceIdentity, arg1 service.MeshService) []endpoint.Endpoint {
 	m.ctrl.T.Helper()
@@ -371,6 +417,50 @@ func (mr *MockMeshCatalogerMockRecorder) ListEndpointsForService(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpointsForService", reflect.TypeOf((*MockMeshCataloger)(nil).ListEndpointsForService), arg0)
 }
 
+// ListHTTPFilterExtensions mocks base method.
+func (m *MockMeshCataloger) ListHTTPFilterExtensions() []*v1alpha1.HTTPFilterExtension {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTTPFilterExtensions")
+	ret0, _ := ret[0].([]*v1alpha1.HTTPFilterExtension)
+	return ret0
+}
+
+// ListHTTPFilterExtensions indicates an expected call of ListHTTPFilterExtensions.
+func (mr *MockMeshCatalogerMockRecorder) ListHTTPFilterExtensions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTTPFilterExtensions", reflect.TypeOf((*MockMeshCataloger)(nil).ListHTTPFilterExtensions))
+}
+
+// ListHTTPFilterExtensionsForProxy mocks base method.
+func (m *MockMeshCataloger) ListHTTPFilterExtensionsForProxy(arg0 *envoy.Proxy) ([]*v1alpha1.HTTPFilterExtension, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTTPFilterExtensionsForProxy", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.HTTPFilterExtension)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHTTPFilterExtensionsForProxy indicates an expected call of ListHTTPFilterExtensionsForProxy.
+func (mr *MockMeshCatalogerMockRecorder) ListHTTPFilterExtensionsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTTPFilterExtensionsForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).ListHTTPFilterExtensionsForProxy), arg0)
+}
+
+// ListIPsForProxy mocks base method.
+func (m *MockMeshCataloger) ListIPsForProxy(arg0 *envoy.Proxy) ([]net.IP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIPsForProxy", arg0)
+	ret0, _ := ret[0].([]net.IP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIPsForProxy indicates an expected call of ListIPsForProxy.
+func (mr *MockMeshCatalogerMockRecorder) ListIPsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPsForProxy", reflect.TypeOf((*MockMeshCataloger)(nil).ListIPsForProxy), arg0)
+}
+
 // ListInboundServiceIdentities mocks base method.
 func (m *MockMeshCataloger) ListInboundServiceIdentities(arg0 identity.ServiceIdentity) []identity.ServiceIdentity {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // GetOutboundMeshTrafficPolicy returns the outbound mesh traffic policy for the given downstream identity
@@ -40,7 +41,7 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 		var destinationIPRanges []string
 		destinationIPSet := mapset.NewSet()
 		for _, endp := range mc.GetResolvableEndpointsForService(meshSvc) {
-			ipCIDR := endp.IP.String() + "/32"
+			ipCIDR := utils.GetHostCIDR(endp.IP)
 			if added := destinationIPSet.Add(ipCIDR); added {
 				destinationIPRanges = append(destinationIPRanges, ipCIDR)
 			}
@@ -57,6 +58,7 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 		clusterConfigs = append(clusterConfigs, clusterConfigForServicePort)
 
 		var upstreamClusters []service.WeightedCluster
+		var httpUpstreamClusters []service.WeightedCluster // upstream clusters HTTP routes direct traffic to
 		// Check if there is a traffic split corresponding to this service.
 		// The upstream clusters are to be derived from the traffic split backends
 		// in that case.
@@ -82,6 +84,10 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 					Weight:      backend.Weight,
 				}
 				upstreamClusters = append(upstreamClusters, wc)
+				httpUpstreamClusters = append(httpUpstreamClusters, service.WeightedCluster{
+					ClusterName: service.ClusterName(getHTTPClusterName(backendMeshSvc)),
+					Weight:      backend.Weight,
+				})
 			}
 		} else {
 			wc := service.WeightedCluster{
@@ -90,6 +96,10 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 			}
 			// No TrafficSplit for this upstream service, so use a default weighted cluster
 			upstreamClusters = append(upstreamClusters, wc)
+			httpUpstreamClusters = append(httpUpstreamClusters, service.WeightedCluster{
+				ClusterName: service.ClusterName(getHTTPClusterName(meshSvc)),
+				Weight:      constants.ClusterWeightAcceptAll,
+			})
 		}
 
 		retryPolicy := mc.getRetryPolicy(downstreamIdentity, meshSvc)
@@ -117,7 +127,7 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 		// Create a route to access the upstream service via it's hostnames and upstream weighted clusters
 		httpHostNamesForServicePort := mc.GetHostnamesForService(meshSvc, downstreamSvcAccount.Namespace == meshSvc.Namespace)
 		outboundTrafficPolicy := trafficpolicy.NewOutboundTrafficPolicy(meshSvc.FQDN(), httpHostNamesForServicePort)
-		if err := outboundTrafficPolicy.AddRoute(trafficpolicy.WildCardRouteMatch, retryPolicy, upstreamClusters...); err != nil {
+		if err := outboundTrafficPolicy.AddRoute(trafficpolicy.WildCardRouteMatch, retryPolicy, httpUpstreamClusters...); err != nil {
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrAddingRouteToOutboundTrafficPolicy)).
 				Msgf("Error adding route to outbound mesh HTTP traffic policy for destination %s", meshSvc)
 			continue
@@ -132,6 +142,16 @@ func (mc *MeshCatalog) GetOutboundMeshTrafficPolicy(downstreamIdentity identity.
 	}
 }
 
+// getHTTPClusterName returns the name of the cluster HTTP routes direct traffic for the given upstream service to.
+// HTTP traffic detected on a port whose protocol is detected automatically is directed to a dedicated cluster,
+// so that the upstream can distinguish it from TCP traffic on the same port.
+func getHTTPClusterName(upstreamSvc service.MeshService) string {
+	if upstreamSvc.Protocol == constants.ProtocolAuto {
+		return upstreamSvc.EnvoyHTTPClusterName()
+	}
+	return upstreamSvc.EnvoyClusterName()
+}
+
 // ListOutboundServicesForIdentity list the services the given service account is allowed to initiate outbound connections to
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
 func (mc *MeshCatalog) ListOutboundServicesForIdentity(serviceIdentity identity.ServiceIdentity) []service.MeshService {
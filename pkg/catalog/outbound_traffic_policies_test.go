@@ -650,6 +650,73 @@ func TestGetOutboundMeshTrafficPolicy(t *testing.T) {
 	}
 }
 
+func TestGetOutboundMeshTrafficPolicyWithAutoProtocol(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	mockProvider := compute.NewMockInterface(mockCtrl)
+	mockMeshSpec := smi.NewMockMeshSpec(mockCtrl)
+	mc := MeshCatalog{
+		Interface: mockProvider,
+		meshSpec:  mockMeshSpec,
+	}
+
+	// ns1/s1 is split between a backend whose protocol is detected automatically and an HTTP backend
+	meshSvc1 := service.MeshService{Name: "s1", Namespace: "ns1", Port: 8080, TargetPort: 80, Protocol: "auto"}
+	meshSvc1V1 := service.MeshService{Name: "s1-v1", Namespace: "ns1", Port: 8080, TargetPort: 80, Protocol: "auto"}
+	meshSvc1V2 := service.MeshService{Name: "s1-v2", Namespace: "ns1", Port: 8080, TargetPort: 80, Protocol: "http"}
+	downstreamIdentity := identity.ServiceIdentity("sa-x.ns1")
+
+	mockProvider.EXPECT().GetMeshConfig().Return(v1alpha2.MeshConfig{
+		Spec: v1alpha2.MeshConfigSpec{
+			Traffic: v1alpha2.TrafficSpec{
+				EnablePermissiveTrafficPolicyMode: true,
+			},
+		},
+	}).AnyTimes()
+	mockProvider.EXPECT().ListServices().Return([]service.MeshService{meshSvc1}).AnyTimes()
+	mockMeshSpec.EXPECT().ListTrafficSplits(gomock.Any()).Return([]*split.TrafficSplit{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "s1-split",
+				Namespace: "ns1",
+			},
+			Spec: split.TrafficSplitSpec{
+				Service: "s1.ns1.svc.cluster.local",
+				Backends: []split.TrafficSplitBackend{
+					{Service: meshSvc1V1.Name, Weight: 10},
+					{Service: meshSvc1V2.Name, Weight: 90},
+				},
+			},
+		},
+	}).AnyTimes()
+	mockProvider.EXPECT().GetMeshService(meshSvc1V1.Name, meshSvc1V1.Namespace, meshSvc1.Port).Return(meshSvc1V1, nil).AnyTimes()
+	mockProvider.EXPECT().GetMeshService(meshSvc1V2.Name, meshSvc1V2.Namespace, meshSvc1.Port).Return(meshSvc1V2, nil).AnyTimes()
+	mockProvider.EXPECT().GetResolvableEndpointsForService(meshSvc1).Return([]endpoint.Endpoint{{IP: net.ParseIP("10.0.1.1")}}).AnyTimes()
+	mockProvider.EXPECT().GetUpstreamTrafficSettingByService(gomock.Any()).Return(nil).AnyTimes()
+	mockProvider.EXPECT().GetHostnamesForService(meshSvc1, true).Return([]string{"s1", "s1.ns1"}).AnyTimes()
+
+	actual := mc.GetOutboundMeshTrafficPolicy(downstreamIdentity)
+	assert.NotNil(actual)
+
+	// TCP traffic is proxied to the clusters of the backends
+	assert.Len(actual.TrafficMatches, 1)
+	assert.Equal("auto", actual.TrafficMatches[0].DestinationProtocol)
+	assert.ElementsMatch([]service.WeightedCluster{
+		{ClusterName: "ns1/s1-v1|80", Weight: 10},
+		{ClusterName: "ns1/s1-v2|80", Weight: 90},
+	}, actual.TrafficMatches[0].WeightedClusters)
+
+	// HTTP traffic is routed to the HTTP cluster of backends whose protocol is detected automatically
+	assert.Len(actual.HTTPRouteConfigsPerPort[8080], 1)
+	routes := actual.HTTPRouteConfigsPerPort[8080][0].Routes
+	assert.Len(routes, 1)
+	assert.True(routes[0].WeightedClusters.Equal(mapset.NewSet(
+		service.WeightedCluster{ClusterName: "ns1/s1-v1|80|http", Weight: 10},
+		service.WeightedCluster{ClusterName: "ns1/s1-v2|80", Weight: 90},
+	)))
+}
+
 func TestListOutboundServicesForIdentity(t *testing.T) {
 	assert := tassert.New(t)
 
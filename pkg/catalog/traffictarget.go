@@ -50,7 +50,12 @@ func (mc *MeshCatalog) ListInboundTrafficTargetsWithRoutes(upstream identity.Ser
 		// Source identifies for this traffic target
 		var sourceIdentities []identity.ServiceIdentity
 		for _, source := range t.Spec.Sources {
-			srcIdentity := trafficTargetIdentityToServiceIdentity(source)
+			srcIdentity, err := trafficTargetSourceToServiceIdentity(source)
+			if err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidSourceKind)).
+					Msgf("Applied TrafficTarget policy %s/%s has an invalid source", t.Namespace, t.Name)
+				continue
+			}
 			sourceIdentities = append(sourceIdentities, srcIdentity)
 		}
 		trafficTarget.Sources = sourceIdentities
@@ -91,20 +96,30 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 				continue
 			}
 			for _, source := range spec.Sources {
-				if source.Kind != smi.ServiceAccountKind {
+				if source.Kind != smi.ServiceAccountKind && source.Kind != smi.SPIFFEIDKind {
 					// Destination kind is not valid
 					log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidSourceKind)).
 						Msgf("Applied TrafficTarget policy %s has invalid Source kind: %s", trafficTarget.Name, spec.Destination.Kind)
 					continue
 				}
 
-				allowed.Add(trafficTargetIdentityToSvcAccount(source))
+				srcIdentity, err := trafficTargetSourceToServiceIdentity(source)
+				if err != nil {
+					log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidSourceKind)).
+						Msgf("Applied TrafficTarget policy %s has an invalid source", trafficTarget.Name)
+					continue
+				}
+				allowed.Add(srcIdentity)
 			}
 		}
 
 		// For outbound direction, match TrafficTargets with source corresponding to the given service account
 		if direction == outbound {
 			for _, source := range spec.Sources {
+				if source.Kind == smi.SPIFFEIDKind {
+					// Service accounts of federated trust domains have no outbound traffic in the mesh
+					continue
+				}
 				if source.Kind != smi.ServiceAccountKind {
 					// Destination kind is not valid
 					log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrInvalidSourceKind)).
@@ -117,14 +132,14 @@ func (mc *MeshCatalog) getAllowedDirectionalServiceAccounts(svcIdentity identity
 					continue
 				}
 
-				allowed.Add(trafficTargetIdentityToSvcAccount(spec.Destination))
+				allowed.Add(trafficTargetIdentityToServiceIdentity(spec.Destination))
 			}
 		}
 	}
 
 	var allowedSvcIdentities []identity.ServiceIdentity
-	for svcAccount := range allowed.Iter() {
-		allowedSvcIdentities = append(allowedSvcIdentities, svcAccount.(identity.K8sServiceAccount).ToServiceIdentity())
+	for svcIdentity := range allowed.Iter() {
+		allowedSvcIdentities = append(allowedSvcIdentities, svcIdentity.(identity.ServiceIdentity))
 	}
 
 	return allowedSvcIdentities
@@ -142,6 +157,15 @@ func trafficTargetIdentityToServiceIdentity(identitySubject smiAccess.IdentityBi
 	return trafficTargetIdentityToSvcAccount(identitySubject).ToServiceIdentity()
 }
 
+// trafficTargetSourceToServiceIdentity returns the identity of the given TrafficTarget source. Sources of kind SPIFFEID
+// identify a service account of a federated trust domain by its SPIFFE ID.
+func trafficTargetSourceToServiceIdentity(source smiAccess.IdentityBindingSubject) (identity.ServiceIdentity, error) {
+	if source.Kind == smi.SPIFFEIDKind {
+		return identity.NewFromSPIFFEID(source.Name)
+	}
+	return trafficTargetIdentityToServiceIdentity(source), nil
+}
+
 // trafficTargetIdentitiesToSvcAccounts returns a list of Service Accounts from the given list of identities from a Traffic Target
 func trafficTargetIdentitiesToSvcAccounts(identities []smiAccess.IdentityBindingSubject) []identity.K8sServiceAccount {
 	serviceAccountsMap := map[identity.K8sServiceAccount]bool{}
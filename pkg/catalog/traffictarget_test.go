@@ -174,6 +174,61 @@ func TestListInboundServiceIdentities(t *testing.T) {
 			nil,
 		},
 		// Test case 3 end ------------------------------------
+
+		// Test case 4 begin ------------------------------------
+		// Inbound service accounts of a federated trust domain, identified by their SPIFFE ID
+		{
+			[]*smiAccess.TrafficTarget{
+				{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: "access.smi-spec.io/v1alpha3",
+						Kind:       "TrafficTarget",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-1",
+						Namespace: "ns-2",
+					},
+					Spec: smiAccess.TrafficTargetSpec{
+						Destination: smiAccess.IdentityBindingSubject{
+							Kind:      "ServiceAccount",
+							Name:      "sa-2",
+							Namespace: "ns-2",
+						},
+						Sources: []smiAccess.IdentityBindingSubject{
+							{
+								Kind:      "ServiceAccount",
+								Name:      "sa-1",
+								Namespace: "ns-1",
+							},
+							{
+								Kind: "SPIFFEID",
+								Name: "spiffe://west.mesh/ns/ns-1/sa/sa-1",
+							},
+							{
+								Kind: "SPIFFEID",
+								Name: "spiffe://west.mesh/sa-1", // Invalid SPIFFE ID
+							},
+						},
+					},
+				},
+			},
+
+			// given service account to test
+			identity.K8sServiceAccount{
+				Name:      "sa-2",
+				Namespace: "ns-2",
+			}.ToServiceIdentity(),
+
+			// allowed inbound service accounts: the local and the federated service accounts
+			[]identity.ServiceIdentity{
+				identity.K8sServiceAccount{
+					Name:      "sa-1",
+					Namespace: "ns-1",
+				}.ToServiceIdentity(),
+				identity.ServiceIdentity("spiffe://west.mesh/ns/ns-1/sa/sa-1"),
+			},
+		},
+		// Test case 4 end ------------------------------------
 	}
 
 	for i, tc := range testCases {
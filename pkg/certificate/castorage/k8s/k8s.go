@@ -52,6 +52,39 @@ func GetCertFromKubernetes(ns string, secretName string, kubeClient kubernetes.I
 	return cert, nil
 }
 
+// GetIntermediateCAFromKubernetes is a helper function that loads an intermediate CA from a Kubernetes secret holding
+// the root certificate, and the certificate chain and private key of the intermediate CA. The certificate chain is
+// validated against the root.
+func GetIntermediateCAFromKubernetes(ns string, secretName string, kubeClient kubernetes.Interface) (*certificate.Certificate, error) {
+	caSecret, err := kubeClient.CoreV1().Secrets(ns).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		// TODO(#3962): metric might not be scraped before process restart resulting from this error
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingCertSecret)).
+			Msgf("Could not retrieve intermediate CA secret %q from namespace %q", secretName, ns)
+		return nil, certificate.ErrSecretNotFound
+	}
+
+	pemData := make(map[string][]byte)
+	for _, key := range []string{constants.KubernetesOpaqueSecretCAKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		data, ok := caSecret.Data[key]
+		if !ok {
+			// TODO(#3962): metric might not be scraped before process restart resulting from this error
+			log.Error().Err(certificate.ErrInvalidCertSecret).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrObtainingCertFromSecret)).
+				Msgf("k8s secret %s/%s does not have required field %q", ns, secretName, key)
+			return nil, certificate.ErrInvalidCertSecret
+		}
+		pemData[key] = data
+	}
+
+	ca, err := certificate.NewIntermediateCAFromPEM(pemData[corev1.TLSCertKey], pemData[corev1.TLSPrivateKeyKey], pemData[constants.KubernetesOpaqueSecretCAKey])
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to load intermediate CA from k8s secret %s/%s", ns, secretName)
+		return nil, err
+	}
+
+	return ca, nil
+}
+
 // GetCertificateFromSecret is a helper function that ensures creation and synchronization of a certificate
 // using Kubernetes Secrets backend and API atomicity.
 func GetCertificateFromSecret(ns string, secretName string, cert *certificate.Certificate, kubeClient kubernetes.Interface) (*certificate.Certificate, error) {
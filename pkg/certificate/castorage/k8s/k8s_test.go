@@ -2,6 +2,10 @@ package k8s
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -105,7 +110,7 @@ func TestSynchronizeCertificate(t *testing.T) {
 	kubeClient := fake.NewSimpleClientset()
 
 	// Create some cert, using tresor's api for simplicity
-	cert, err := tresor.NewCA("common-name", time.Hour, "test-country", "test-locality", "test-org")
+	cert, err := tresor.NewCA("common-name", time.Hour, "test-country", "test-locality", "test-org", v1alpha2.KeyAlgorithmRSA)
 	assert.NoError(err)
 
 	wg := sync.WaitGroup{}
@@ -130,3 +135,85 @@ func TestSynchronizeCertificate(t *testing.T) {
 		assert.Equal(certResults[i], certResults[i+1])
 	}
 }
+
+func TestGetIntermediateCAFromKubernetes(t *testing.T) {
+	assert := tassert.New(t)
+
+	root, err := tresor.NewCA("root", time.Hour, "test-country", "test-locality", "test-org", v1alpha2.KeyAlgorithmECDSAP256)
+	assert.NoError(err)
+	x509Root, err := certificate.DecodePEMCertificate(root.GetCertificateChain())
+	assert.NoError(err)
+	rootKey, err := certificate.DecodePEMPrivateKey(root.GetPrivateKey())
+	assert.NoError(err)
+
+	intermediateKey, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+	assert.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, x509Root, intermediateKey.Public(), rootKey)
+	assert.NoError(err)
+	chainPEM, err := certificate.EncodeCertDERtoPEM(der)
+	assert.NoError(err)
+	keyPEM, err := certificate.EncodeKeyDERtoPEM(intermediateKey)
+	assert.NoError(err)
+
+	testCases := []struct {
+		name        string
+		data        map[string][]byte
+		expectError bool
+	}{
+		{
+			name: "valid intermediate CA",
+			data: map[string][]byte{
+				constants.KubernetesOpaqueSecretCAKey: root.GetIssuingCA(),
+				corev1.TLSCertKey:                     chainPEM,
+				corev1.TLSPrivateKeyKey:               keyPEM,
+			},
+		},
+		{
+			name: "missing root",
+			data: map[string][]byte{
+				corev1.TLSCertKey:       chainPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+			expectError: true,
+		},
+		{
+			name: "chain not signed by the root",
+			data: map[string][]byte{
+				constants.KubernetesOpaqueSecretCAKey: chainPEM,
+				corev1.TLSCertKey:                     root.GetCertificateChain(),
+				corev1.TLSPrivateKeyKey:               keyPEM,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "intermediate-ca", Namespace: "osm-system"},
+				Data:       tc.data,
+			})
+
+			ca, err := GetIntermediateCAFromKubernetes("osm-system", "intermediate-ca", kubeClient)
+			assert.Equal(tc.expectError, err != nil)
+			if tc.expectError {
+				return
+			}
+			assert.Equal(certificate.CommonName("intermediate"), ca.GetCommonName())
+			assert.Equal(root.GetIssuingCA(), ca.GetTrustedCAs())
+		})
+	}
+
+	_, err = GetIntermediateCAFromKubernetes("osm-system", "missing", fake.NewSimpleClientset())
+	assert.ErrorIs(err, certificate.ErrSecretNotFound)
+}
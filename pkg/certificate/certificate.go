@@ -1,6 +1,8 @@
 package certificate
 
 import (
+	"crypto"
+	"crypto/x509"
 	"fmt"
 	time "time"
 
@@ -18,15 +20,21 @@ const (
 	noiseSeconds = 5
 )
 
-// mergeRoot will merge in the provided root CA for future calls to GetTrustedCAs. It guarantees to not mutate
+// mergeRoot will merge in the provided root CAs for future calls to GetTrustedCAs. It guarantees to not mutate
 // the underlying IssuingCA or trustedCAs fields. By doing so, we ensure that we don't need locks.
 // NOTE: this does not return a full copy, mutations to the other byte slices could cause data races.
-func (c *Certificate) newMergedWithRoot(root pem.RootCertificate) *Certificate {
+func (c *Certificate) newMergedWithRoot(roots ...pem.RootCertificate) *Certificate {
 	cert := *c
 
-	buf := make([]byte, 0, len(root)+len(c.IssuingCA))
+	size := len(c.IssuingCA)
+	for _, root := range roots {
+		size += len(root)
+	}
+	buf := make([]byte, 0, size)
 	buf = append(buf, c.IssuingCA...)
-	buf = append(buf, root...)
+	for _, root := range roots {
+		buf = append(buf, root...)
+	}
 	cert.TrustedCAs = buf
 	return &cert
 }
@@ -71,6 +79,34 @@ func (c *Certificate) GetTrustedCAs() pem.RootCertificate {
 	return c.TrustedCAs
 }
 
+// GetTrustDomainBundles returns the PEM-encoded trust context for this certificate's holder keyed by trust domain,
+// each root only validating the peer certificates of its trust domain. It is only set when trust domains are
+// federated, GetTrustedCAs returning the roots of the mesh otherwise.
+func (c *Certificate) GetTrustDomainBundles() map[string]pem.RootCertificate {
+	return c.TrustDomainBundles
+}
+
+// GetRevocationLists returns the PEM-encoded revocation lists
+// the certificate's holder must check peer certificates against
+func (c *Certificate) GetRevocationLists() pem.RevocationList {
+	return c.RevocationLists
+}
+
+// GetInfo returns the description of the certificate
+func (c *Certificate) GetInfo() CertificateInfo {
+	return CertificateInfo{
+		Key:                c.cacheKey,
+		CommonName:         c.CommonName,
+		SerialNumber:       c.SerialNumber,
+		CertType:           string(c.certType),
+		SigningIssuerID:    c.signingIssuerID,
+		ValidatingIssuerID: c.validatingIssuerID,
+		KeyAlgorithm:       string(c.keyAlgorithm),
+		IssuedAt:           c.issuedAt,
+		Expiration:         c.Expiration,
+	}
+}
+
 // NewFromPEM is a helper returning a *certificate.Certificate from the PEM components given.
 func NewFromPEM(pemCert pem.Certificate, pemKey pem.PrivateKey) (*Certificate, error) {
 	x509Cert, err := DecodePEMCertificate(pemCert)
@@ -91,3 +127,80 @@ func NewFromPEM(pemCert pem.Certificate, pemKey pem.PrivateKey) (*Certificate, e
 		Expiration:   x509Cert.NotAfter,
 	}, nil
 }
+
+// NewIntermediateCAFromPEM is a helper returning a *certificate.Certificate for an intermediate CA from the PEM
+// components given. The certificate chain starts with the certificate of the intermediate CA, followed by the
+// certificates of any other intermediate CA up to the root. The chain is validated against the root, and the private
+// key must match the certificate of the intermediate CA. The root is the only trusted CA of the certificate, and is
+// removed from the chain if present.
+func NewIntermediateCAFromPEM(pemChain pem.Certificate, pemKey pem.PrivateKey, pemRoot pem.RootCertificate) (*Certificate, error) {
+	chain, err := DecodePEMCertificates(pemChain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error decoding certificate chain: %s", ErrInvalidIntermediateCA, err)
+	}
+	roots, err := DecodePEMCertificates(pemRoot)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error decoding root certificate: %s", ErrInvalidIntermediateCA, err)
+	}
+	key, err := DecodePEMPrivateKey(pemKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error decoding private key: %s", ErrInvalidIntermediateCA, err)
+	}
+
+	intermediate := chain[0]
+	if !intermediate.IsCA || intermediate.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, fmt.Errorf("%w: certificate %s is not a CA certificate", ErrInvalidIntermediateCA, intermediate.Subject.CommonName)
+	}
+	if publicKey, ok := intermediate.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(key.Public()) {
+		return nil, fmt.Errorf("%w: private key does not match certificate %s", ErrInvalidIntermediateCA, intermediate.Subject.CommonName)
+	}
+
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+	intermediatePool := x509.NewCertPool()
+	var certChain pem.Certificate
+	expiration := intermediate.NotAfter
+	for _, cert := range chain {
+		if isRoot(cert, roots) {
+			continue
+		}
+		intermediatePool.AddCert(cert)
+		certPEM, err := EncodeCertDERtoPEM(cert.Raw)
+		if err != nil {
+			return nil, err
+		}
+		certChain = append(certChain, certPEM...)
+		if cert.NotAfter.Before(expiration) {
+			expiration = cert.NotAfter
+		}
+	}
+	if _, err := intermediate.Verify(x509.VerifyOptions{
+		Roots:         rootPool,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("%w: error verifying certificate chain: %s", ErrInvalidIntermediateCA, err)
+	}
+
+	return &Certificate{
+		CommonName:   CommonName(intermediate.Subject.CommonName),
+		SerialNumber: SerialNumber(intermediate.SerialNumber.String()),
+		CertChain:    certChain,
+		IssuingCA:    pemRoot,
+		TrustedCAs:   pemRoot,
+		PrivateKey:   pemKey,
+		Expiration:   expiration,
+	}, nil
+}
+
+// isRoot returns whether the given certificate is one of the given roots
+func isRoot(cert *x509.Certificate, roots []*x509.Certificate) bool {
+	for _, root := range roots {
+		if cert.Equal(root) {
+			return true
+		}
+	}
+	return false
+}
@@ -113,3 +113,130 @@ func TestNewFromPEM(t *testing.T) {
 		})
 	}
 }
+
+// newTestCA returns a CA certificate and its key, signed by the given parent or self-signed if parent is nil
+func newTestCA(t *testing.T, cn string, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, pem.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	tassert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	tassert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	tassert.NoError(t, err)
+	certPEM, err := EncodeCertDERtoPEM(der)
+	tassert.NoError(t, err)
+	return cert, key, certPEM
+}
+
+func TestNewIntermediateCAFromPEM(t *testing.T) {
+	root, rootKey, rootPEM := newTestCA(t, "root", nil, nil)
+	intermediate, intermediateKey, intermediatePEM := newTestCA(t, "intermediate", root, rootKey)
+	_, issuingKey, issuingPEM := newTestCA(t, "issuing", intermediate, intermediateKey)
+	_, _, otherRootPEM := newTestCA(t, "other-root", nil, nil)
+
+	intermediateKeyPEM, err := EncodeKeyDERtoPEM(intermediateKey)
+	tassert.NoError(t, err)
+	issuingKeyPEM, err := EncodeKeyDERtoPEM(issuingKey)
+	tassert.NoError(t, err)
+
+	concat := func(pems ...pem.Certificate) pem.Certificate {
+		var chain pem.Certificate
+		for _, p := range pems {
+			chain = append(chain, p...)
+		}
+		return chain
+	}
+
+	testCases := []struct {
+		name          string
+		chain         pem.Certificate
+		key           pem.PrivateKey
+		root          pem.RootCertificate
+		expectedCN    CommonName
+		expectedChain pem.Certificate
+		expectErr     bool
+	}{
+		{
+			name:          "intermediate signed by the root",
+			chain:         intermediatePEM,
+			key:           intermediateKeyPEM,
+			root:          pem.RootCertificate(rootPEM),
+			expectedCN:    "intermediate",
+			expectedChain: intermediatePEM,
+		},
+		{
+			name:          "chain of intermediates",
+			chain:         concat(issuingPEM, intermediatePEM),
+			key:           issuingKeyPEM,
+			root:          pem.RootCertificate(rootPEM),
+			expectedCN:    "issuing",
+			expectedChain: concat(issuingPEM, intermediatePEM),
+		},
+		{
+			name:          "root is removed from the chain",
+			chain:         concat(intermediatePEM, rootPEM),
+			key:           intermediateKeyPEM,
+			root:          pem.RootCertificate(rootPEM),
+			expectedCN:    "intermediate",
+			expectedChain: intermediatePEM,
+		},
+		{
+			name:      "missing intermediate in the chain",
+			chain:     issuingPEM,
+			key:       issuingKeyPEM,
+			root:      pem.RootCertificate(rootPEM),
+			expectErr: true,
+		},
+		{
+			name:      "chain not signed by the root",
+			chain:     intermediatePEM,
+			key:       intermediateKeyPEM,
+			root:      pem.RootCertificate(otherRootPEM),
+			expectErr: true,
+		},
+		{
+			name:      "private key does not match",
+			chain:     intermediatePEM,
+			key:       issuingKeyPEM,
+			root:      pem.RootCertificate(rootPEM),
+			expectErr: true,
+		},
+		{
+			name:      "invalid chain",
+			chain:     pem.Certificate("chain"),
+			key:       intermediateKeyPEM,
+			root:      pem.RootCertificate(rootPEM),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ca, err := NewIntermediateCAFromPEM(tc.chain, tc.key, tc.root)
+			if tc.expectErr {
+				assert.ErrorIs(err, ErrInvalidIntermediateCA)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedCN, ca.GetCommonName())
+			assert.Equal(tc.expectedChain, ca.GetCertificateChain())
+			assert.Equal(tc.root, ca.GetIssuingCA())
+			assert.Equal(tc.root, ca.GetTrustedCAs())
+			assert.Equal(tc.key, ca.GetPrivateKey())
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package csr
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/webhook"
+)
+
+const (
+	// clientTimeout is the timeout of the requests sent by the Client
+	clientTimeout = 30 * time.Second
+)
+
+// Client sends the certificate signing requests of a pod to osm-controller.
+type Client struct {
+	address   string
+	caFile    string
+	tokenFile string
+}
+
+// NewClient returns a Client sending requests to the server at the given address, whose certificate is validated with
+// the roots in caFile. The requests are authenticated with the service account token in tokenFile. Both files are
+// read for every request, since they are rotated.
+func NewClient(address, caFile, tokenFile string) *Client {
+	return &Client{
+		address:   strings.TrimSuffix(address, "/"),
+		caFile:    caFile,
+		tokenFile: tokenFile,
+	}
+}
+
+// GetStatus returns whether the given certificate chain must be renewed, and how the next certificate must be
+// requested. An empty chain must be renewed.
+func (c *Client) GetStatus(ctx context.Context, chain pem.Certificate) (*StatusResponse, error) {
+	var resp StatusResponse
+	if err := c.post(ctx, CertificateStatusPath, false, StatusRequest{CertificateChain: string(chain)}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SignCertificateRequest returns the certificate signed for the given PEM encoded certificate signing request.
+func (c *Client) SignCertificateRequest(ctx context.Context, csrPEM pem.CertificateRequest) (*CertificateResponse, error) {
+	var resp CertificateResponse
+	if err := c.post(ctx, CertificateRequestsPath, true, CertificateRequest{CSR: string(csrPEM)}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, authenticate bool, body, respBody interface{}) error {
+	httpClient, err := c.newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	reqBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.address+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(webhook.HTTPHeaderContentType, webhook.ContentTypeJSON)
+	if authenticate {
+		token, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return fmt.Errorf("error reading service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxRequestBytes))
+		return fmt.Errorf("request to %s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(respBody)
+}
+
+func (c *Client) newHTTPClient() (*http.Client, error) {
+	ca, err := os.ReadFile(c.caFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no CA certificate in %s", c.caFile)
+	}
+
+	return &http.Client{
+		Timeout: clientTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    roots,
+				MinVersion: constants.MinTLSVersion,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,236 @@
+package csr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/podidentity"
+	"github.com/openservicemesh/osm/pkg/webhook"
+)
+
+// server signs the certificate signing requests of the pods running the osm-cert-agent container.
+type server struct {
+	certManager    *certificate.Manager
+	authenticator  podidentity.Authenticator
+	kubeController k8s.Controller
+}
+
+// NewServer starts the HTTPS server signing the certificate signing requests of the pods generating the private keys
+// of their Envoy sidecars. It serves the requests of the monitored pods running the osm-cert-agent container,
+// authenticated with the service account tokens projected in them. It also exchanges the tokens of the Envoy sidecars
+// authenticating to the xDS server with them.
+func NewServer(ctx context.Context, osmNamespace string, certManager *certificate.Manager, authenticator podidentity.Authenticator, kubeController k8s.Controller) error {
+	s := &server{
+		certManager:    certManager,
+		authenticator:  authenticator,
+		kubeController: kubeController,
+	}
+
+	srv := webhook.NewServer(constants.OSMControllerName, osmNamespace, constants.CertificateRequestPort, certManager, s.handlers(),
+		func(cert *certificate.Certificate) error { return nil })
+
+	return srv.Run(ctx)
+}
+
+func (s *server) handlers() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		CertificateRequestsPath: s.signCertificateRequest,
+		CertificateStatusPath:   s.getCertificateStatus,
+		TokenExchangePath:       s.exchangeToken,
+	}
+}
+
+// signCertificateRequest signs the certificate of the service identity of the authenticated pod
+func (s *server) signCertificateRequest(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	pod, status, err := s.authenticate(req.Context(), getBearerToken(req))
+	if err == nil && !HasCertAgent(pod.pod) {
+		status, err = http.StatusForbidden, fmt.Errorf("pod %s/%s does not generate the private key of its Envoy sidecar", pod.pod.Namespace, pod.pod.Name)
+	}
+	if err != nil {
+		log.Warn().Err(err).Msgf("Rejected certificate request from %s", req.RemoteAddr)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	var certReq CertificateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&certReq); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err), http.StatusBadRequest)
+		return
+	}
+	csr, err := certificate.DecodePEMCertificateRequest(pem.CertificateRequest(certReq.CSR))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error decoding certificate request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	si := pod.ServiceAccount.ToServiceIdentity()
+	cert, err := s.certManager.SignCertificateRequest(csr, certificate.ForServiceIdentity(si))
+	if errors.Is(err, certificate.ErrInvalidCertificateRequest) || errors.Is(err, certificate.ErrUnsupportedKeyAlgorithm) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrSigningCertificateRequest)).
+			Msgf("Error signing certificate request of pod %s/%s", pod.ServiceAccount.Namespace, pod.PodName)
+		http.Error(w, "error signing certificate request", http.StatusInternalServerError)
+		return
+	}
+
+	log.Debug().Msgf("Signed certificate with SerialNumber=%s for pod %s/%s with identity %s",
+		cert.GetSerialNumber(), pod.ServiceAccount.Namespace, pod.PodName, si)
+	writeJSON(w, CertificateResponse{
+		CertificateChain: string(cert.GetCertificateChain()),
+		IssuingCA:        string(cert.GetIssuingCA()),
+		Expiration:       cert.GetExpiration(),
+	})
+}
+
+// exchangeToken implements the token exchange of RFC 8693 for the gRPC clients of the Envoy sidecars authenticating
+// to the xDS server with the service account tokens projected in their pods. The token of a monitored pod with an
+// Envoy sidecar is exchanged for itself, gRPC sending the access token returned with the xDS requests.
+func (s *server) exchangeToken(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	req.Body = http.MaxBytesReader(w, req.Body, maxRequestBytes)
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err), http.StatusBadRequest)
+		return
+	}
+	if grantType := req.PostForm.Get("grant_type"); grantType != TokenExchangeGrantType {
+		http.Error(w, fmt.Sprintf("unsupported grant type %q", grantType), http.StatusBadRequest)
+		return
+	}
+	if tokenType := req.PostForm.Get("subject_token_type"); tokenType != JWTTokenType {
+		http.Error(w, fmt.Sprintf("unsupported subject token type %q", tokenType), http.StatusBadRequest)
+		return
+	}
+
+	token := req.PostForm.Get("subject_token")
+	pod, status, err := s.authenticate(req.Context(), token)
+	if err == nil && pod.pod.Labels[constants.EnvoyUniqueIDLabelName] == "" {
+		status, err = http.StatusForbidden, fmt.Errorf("pod %s/%s has no Envoy sidecar", pod.pod.Namespace, pod.pod.Name)
+	}
+	if err != nil {
+		log.Warn().Err(err).Msgf("Rejected token exchange request from %s", req.RemoteAddr)
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	expiresIn := defaultTokenLifetime
+	if !pod.Expiration.IsZero() {
+		expiresIn = time.Until(pod.Expiration)
+	}
+	writeJSON(w, TokenExchangeResponse{
+		AccessToken:     token,
+		IssuedTokenType: JWTTokenType,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(expiresIn.Seconds()),
+	})
+}
+
+// authenticatedPod is a pod authenticated with the service account token projected in it
+type authenticatedPod struct {
+	*podidentity.Identity
+	pod *corev1.Pod
+}
+
+// authenticate returns the monitored pod authenticated by the given token, or the HTTP status to reject the request
+// with.
+func (s *server) authenticate(ctx context.Context, token string) (*authenticatedPod, int, error) {
+	if token == "" {
+		return nil, http.StatusUnauthorized, errors.New("bearer token required")
+	}
+
+	id, err := s.authenticator.Authenticate(ctx, token)
+	if errors.Is(err, podidentity.ErrUnauthenticated) {
+		return nil, http.StatusUnauthorized, err
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	pod := s.kubeController.GetPod(id.PodName, id.ServiceAccount.Namespace)
+	if err := id.VerifyPod(pod); err != nil {
+		return nil, http.StatusForbidden, err
+	}
+	return &authenticatedPod{Identity: id, pod: pod}, http.StatusOK, nil
+}
+
+// getBearerToken returns the bearer token of the given request, or an empty string if there's none
+func getBearerToken(req *http.Request) string {
+	header := req.Header.Get("Authorization")
+	if token := strings.TrimPrefix(header, "Bearer "); token != header {
+		return token
+	}
+	return ""
+}
+
+// getCertificateStatus returns whether the given certificate must be renewed. The requests are not authenticated,
+// since only public information is returned.
+func (s *server) getCertificateStatus(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var statusReq StatusRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxRequestBytes)).Decode(&statusReq); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	renew := true
+	if statusReq.CertificateChain != "" {
+		var err error
+		if renew, err = s.certManager.ShouldRenewCertificate(pem.Certificate(statusReq.CertificateChain)); err != nil {
+			http.Error(w, fmt.Sprintf("error checking certificate: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	writeJSON(w, StatusResponse{
+		Renew:        renew,
+		TrustDomain:  s.certManager.GetTrustDomain(),
+		KeyAlgorithm: string(s.certManager.GetKeyAlgorithm()),
+	})
+}
+
+// HasCertAgent returns whether the given pod runs the osm-cert-agent container, generating the private key of its
+// Envoy sidecar.
+func HasCertAgent(pod *corev1.Pod) bool {
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			if container.Name == constants.CertAgentContainerName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set(webhook.HTTPHeaderContentType, webhook.ContentTypeJSON)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Error().Err(err).Msg("Error writing response")
+	}
+}
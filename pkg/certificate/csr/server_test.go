@@ -0,0 +1,371 @@
+package csr
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/podidentity"
+)
+
+// fakeAuthenticator authenticates the pods of the tokens it was given
+type fakeAuthenticator map[string]*podidentity.Identity
+
+func (a fakeAuthenticator) Authenticate(_ context.Context, token string) (*podidentity.Identity, error) {
+	if token == "error" {
+		return nil, errors.New("token review failed")
+	}
+	id, ok := a[token]
+	if !ok {
+		return nil, podidentity.ErrUnauthenticated
+	}
+	return id, nil
+}
+
+func newTestPod(name, uid, serviceAccount string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			UID:       types.UID(uid),
+		},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: serviceAccount,
+		},
+	}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+		if c == "envoy" {
+			pod.Labels = map[string]string{constants.EnvoyUniqueIDLabelName: "proxy-uuid"}
+		}
+	}
+	return pod
+}
+
+// newTestServer returns a client of a test server signing the requests of the pods authenticated with the tokens
+// pod, other-uid, other-sa, no-agent, no-sidecar and unknown-pod, and the certificate manager signing them
+func newTestServer(t *testing.T, token string) (*Client, *certificate.Manager) {
+	t.Helper()
+	s := newTestPodServer(t)
+	mux := http.NewServeMux()
+	for path, h := range s.handlers() {
+		mux.HandleFunc(path, h)
+	}
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	caPEM, err := certificate.EncodeCertDERtoPEM(srv.Certificate().Raw)
+	trequire.NoError(t, err)
+	trequire.NoError(t, os.WriteFile(filepath.Join(dir, "ca.pem"), caPEM, 0600))
+	trequire.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte(token+"\n"), 0600))
+
+	return NewClient(srv.URL, filepath.Join(dir, "ca.pem"), filepath.Join(dir, "token")), s.certManager
+}
+
+// newTestPodServer returns a server authenticating the pods of newTestServer
+func newTestPodServer(t *testing.T) *server {
+	t.Helper()
+	certManager := tresorFake.NewFake(time.Hour)
+	trequire.NotNil(t, certManager)
+
+	mockCtrl := gomock.NewController(t)
+	kubeController := k8s.NewMockController(mockCtrl)
+	kubeController.EXPECT().GetPod("pod", "ns").Return(newTestPod("pod", "uid", "sa", "envoy", constants.CertAgentContainerName)).AnyTimes()
+	kubeController.EXPECT().GetPod("other-uid", "ns").Return(newTestPod("other-uid", "other", "sa", constants.CertAgentContainerName)).AnyTimes()
+	kubeController.EXPECT().GetPod("other-sa", "ns").Return(newTestPod("other-sa", "uid", "other", constants.CertAgentContainerName)).AnyTimes()
+	kubeController.EXPECT().GetPod("no-agent", "ns").Return(newTestPod("no-agent", "uid", "sa", "envoy")).AnyTimes()
+	kubeController.EXPECT().GetPod("no-sidecar", "ns").Return(newTestPod("no-sidecar", "uid", "sa")).AnyTimes()
+	kubeController.EXPECT().GetPod("unknown-pod", "ns").Return(nil).AnyTimes()
+
+	sa := identity.K8sServiceAccount{Namespace: "ns", Name: "sa"}
+	authenticator := fakeAuthenticator{}
+	for _, pod := range []string{"pod", "other-uid", "other-sa", "no-agent", "no-sidecar", "unknown-pod"} {
+		authenticator[pod] = &podidentity.Identity{ServiceAccount: sa, PodName: pod, PodUID: "uid"}
+	}
+	authenticator["expiring"] = &podidentity.Identity{ServiceAccount: sa, PodName: "pod", PodUID: "uid", Expiration: time.Now().Add(time.Hour)}
+
+	return &server{
+		certManager:    certManager,
+		authenticator:  authenticator,
+		kubeController: kubeController,
+	}
+}
+
+func newTestCSR(t *testing.T, cn string, uris ...string) (pem.CertificateRequest, *x509.CertificateRequest) {
+	t.Helper()
+	key, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+	trequire.NoError(t, err)
+	template := &x509.CertificateRequest{Subject: pkix.Name{CommonName: cn}}
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		trequire.NoError(t, err)
+		template.URIs = append(template.URIs, u)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	trequire.NoError(t, err)
+	csrPEM, err := certificate.EncodeCertReqDERtoPEM(der)
+	trequire.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	trequire.NoError(t, err)
+	return csrPEM, csr
+}
+
+func TestSignCertificateRequest(t *testing.T) {
+	testCases := []struct {
+		name           string
+		token          string
+		cn             string
+		uri            string
+		expectedStatus int
+	}{
+		{
+			name:  "pod running the cert agent",
+			token: "pod",
+		},
+		{
+			name:  "request with the names of the pod's identity",
+			token: "pod",
+			cn:    "sa.ns.%s",
+			uri:   "spiffe://%s/ns/ns/sa/sa",
+		},
+		{
+			name:           "request with the names of another identity",
+			token:          "pod",
+			uri:            "spiffe://%s/ns/ns/sa/other",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			token:          "invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "token review error",
+			token:          "error",
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "pod not found",
+			token:          "unknown-pod",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "token of a deleted pod with the same name",
+			token:          "other-uid",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "pod running as another service account",
+			token:          "other-sa",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "pod not running the cert agent",
+			token:          "no-agent",
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			client, certManager := newTestServer(t, tc.token)
+			trustDomain := certManager.GetTrustDomain()
+
+			var names []string
+			if tc.uri != "" {
+				names = append(names, fmt.Sprintf(tc.uri, trustDomain))
+			}
+			cn := tc.cn
+			if cn != "" {
+				cn = fmt.Sprintf(cn, trustDomain)
+			}
+			csrPEM, csr := newTestCSR(t, cn, names...)
+
+			resp, err := client.SignCertificateRequest(context.Background(), csrPEM)
+			if tc.expectedStatus != 0 {
+				assert.ErrorContains(err, fmt.Sprintf("status %d", tc.expectedStatus))
+				return
+			}
+			trequire.NoError(t, err)
+
+			cert, err := certificate.DecodePEMCertificate([]byte(resp.CertificateChain))
+			trequire.NoError(t, err)
+			assert.Equal(csr.PublicKey, cert.PublicKey)
+			assert.Equal(fmt.Sprintf("sa.ns.%s", trustDomain), cert.Subject.CommonName)
+			trequire.Len(t, cert.URIs, 1)
+			assert.Equal(fmt.Sprintf("spiffe://%s/ns/ns/sa/sa", trustDomain), cert.URIs[0].String())
+			assert.WithinDuration(cert.NotAfter, resp.Expiration, time.Second)
+			assert.NotEmpty(resp.IssuingCA)
+
+			// The certificate is not cached, nor rotated by the manager
+			assert.Nil(certManager.GetIssuedCertificate(cert.SerialNumber.String()))
+		})
+	}
+}
+
+func TestGetCertificateStatus(t *testing.T) {
+	assert := tassert.New(t)
+	client, certManager := newTestServer(t, "pod")
+
+	// A certificate must be requested when there is none
+	status, err := client.GetStatus(context.Background(), nil)
+	trequire.NoError(t, err)
+	assert.True(status.Renew)
+	assert.Equal(certManager.GetTrustDomain(), status.TrustDomain)
+	assert.Equal(string(certManager.GetKeyAlgorithm()), status.KeyAlgorithm)
+
+	// A certificate signed with a key of the configured algorithm doesn't need to be renewed
+	key, err := certificate.GeneratePrivateKey(certManager.GetKeyAlgorithm(), 2048)
+	trequire.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	trequire.NoError(t, err)
+	csrPEM, err := certificate.EncodeCertReqDERtoPEM(der)
+	trequire.NoError(t, err)
+	resp, err := client.SignCertificateRequest(context.Background(), csrPEM)
+	trequire.NoError(t, err)
+	status, err = client.GetStatus(context.Background(), pem.Certificate(resp.CertificateChain))
+	trequire.NoError(t, err)
+	assert.False(status.Renew)
+
+	// Certificates that are not signed by the signing issuer must be renewed
+	other, err := tresorFake.NewFake(time.Hour).IssueCertificate(certificate.ForServiceIdentity("sa.ns"))
+	trequire.NoError(t, err)
+	status, err = client.GetStatus(context.Background(), other.GetCertificateChain())
+	trequire.NoError(t, err)
+	assert.True(status.Renew)
+
+	// Invalid certificates are rejected
+	_, err = client.GetStatus(context.Background(), pem.Certificate("invalid"))
+	assert.ErrorContains(err, "status 400")
+}
+
+func TestExchangeToken(t *testing.T) {
+	testCases := []struct {
+		name              string
+		method            string
+		grantType         string
+		tokenType         string
+		token             string
+		expectedStatus    int
+		expectedExpiresIn int64
+	}{
+		{
+			name:              "token of a pod with an Envoy sidecar",
+			token:             "pod",
+			expectedStatus:    http.StatusOK,
+			expectedExpiresIn: int64(defaultTokenLifetime.Seconds()),
+		},
+		{
+			name:              "token with an expiration",
+			token:             "expiring",
+			expectedStatus:    http.StatusOK,
+			expectedExpiresIn: int64(time.Hour.Seconds()),
+		},
+		{
+			name:           "pod without Envoy sidecar",
+			token:          "no-sidecar",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "token of a deleted pod with the same name",
+			token:          "other-uid",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "invalid token",
+			token:          "invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "no token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unsupported grant type",
+			grantType:      "client_credentials",
+			token:          "pod",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported token type",
+			tokenType:      "urn:ietf:params:oauth:token-type:access_token",
+			token:          "pod",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "GET request",
+			method:         http.MethodGet,
+			token:          "pod",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			form := url.Values{
+				"grant_type":         {TokenExchangeGrantType},
+				"subject_token_type": {JWTTokenType},
+				"subject_token":      {tc.token},
+			}
+			if tc.grantType != "" {
+				form.Set("grant_type", tc.grantType)
+			}
+			if tc.tokenType != "" {
+				form.Set("subject_token_type", tc.tokenType)
+			}
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, TokenExchangePath, strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+
+			newTestPodServer(t).exchangeToken(w, req)
+
+			assert.Equal(tc.expectedStatus, w.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var resp TokenExchangeResponse
+			trequire.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(tc.token, resp.AccessToken)
+			assert.Equal(JWTTokenType, resp.IssuedTokenType)
+			assert.Equal("Bearer", resp.TokenType)
+			assert.InDelta(tc.expectedExpiresIn, resp.ExpiresIn, 2)
+		})
+	}
+}
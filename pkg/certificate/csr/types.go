@@ -0,0 +1,94 @@
+// Package csr implements the signing of the certificates of the Envoy sidecars whose private keys are generated in
+// their pods, so that the private keys never leave the pods.
+//
+// The osm-cert-agent container injected alongside the Envoy sidecar generates the private key, and sends a
+// certificate signing request to osm-controller, authenticated with the service account token projected in the pod.
+// osm-controller signs the certificate of the pod's service identity with the signing issuer of the certificate
+// manager. The agent requests a new certificate before the certificate expires, or once osm-controller reports that
+// it must be renewed, such as during root certificate rotations.
+//
+// The server also implements the token exchange used by the gRPC clients of the Envoy sidecars authenticating to the
+// xDS server with the service account tokens projected in their pods, rather than with client certificates.
+package csr
+
+import (
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// CertificateRequestsPath is the path the certificate signing requests are sent to
+	CertificateRequestsPath = "/v1/certificaterequests"
+
+	// CertificateStatusPath is the path the certificates signed are checked at
+	CertificateStatusPath = "/v1/certificaterequests/status"
+
+	// TokenExchangePath is the path the token exchange requests of the Envoy sidecars are sent to
+	TokenExchangePath = "/v1/token"
+
+	// TokenExchangeGrantType is the grant type of the token exchange requests, as defined in RFC 8693
+	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// JWTTokenType is the type of the tokens exchanged, as defined in RFC 8693
+	JWTTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+	// maxRequestBytes is the maximum size of the requests' bodies
+	maxRequestBytes = 64 * 1024
+
+	// defaultTokenLifetime is the lifetime of the tokens exchanged whose expiration is unknown
+	defaultTokenLifetime = 10 * time.Minute
+)
+
+var log = logger.New("csr")
+
+// CertificateRequest is the body of the requests sent to CertificateRequestsPath.
+type CertificateRequest struct {
+	// CSR is the PEM encoded certificate signing request, signed with the private key of the certificate
+	CSR string `json:"csr"`
+}
+
+// CertificateResponse is the body of the responses to the requests sent to CertificateRequestsPath.
+type CertificateResponse struct {
+	// CertificateChain is the PEM encoded certificate chain signed
+	CertificateChain string `json:"certificateChain"`
+
+	// IssuingCA is the PEM encoded root certificate of the issuer that signed the certificate
+	IssuingCA string `json:"issuingCA"`
+
+	// Expiration is when the certificate expires
+	Expiration time.Time `json:"expiration"`
+}
+
+// StatusRequest is the body of the requests sent to CertificateStatusPath.
+type StatusRequest struct {
+	// CertificateChain is the PEM encoded certificate chain to check. An empty chain must be renewed.
+	CertificateChain string `json:"certificateChain"`
+}
+
+// StatusResponse is the body of the responses to the requests sent to CertificateStatusPath.
+type StatusResponse struct {
+	// Renew is whether a new certificate must be requested
+	Renew bool `json:"renew"`
+
+	// TrustDomain is the trust domain of the certificates signed, which the requests must request the names of
+	TrustDomain string `json:"trustDomain"`
+
+	// KeyAlgorithm is the algorithm the private keys must be generated with. Empty for RSA.
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+}
+
+// TokenExchangeResponse is the body of the responses to the requests sent to TokenExchangePath, as defined in RFC 8693.
+type TokenExchangeResponse struct {
+	// AccessToken is the token sent with the xDS requests
+	AccessToken string `json:"access_token"`
+
+	// IssuedTokenType is the type of the access token
+	IssuedTokenType string `json:"issued_token_type"`
+
+	// TokenType is how the access token is sent
+	TokenType string `json:"token_type"`
+
+	// ExpiresIn is the lifetime of the access token in seconds, after which the token is exchanged again
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -2,6 +2,9 @@ package certificate
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	pemEnc "encoding/pem"
@@ -24,8 +27,22 @@ func EncodeCertDERtoPEM(derBytes []byte) (pem.Certificate, error) {
 	return certOut.Bytes(), nil
 }
 
-// EncodeKeyDERtoPEM converts a DER encoded private key into a PEM encoded key
-func EncodeKeyDERtoPEM(priv *rsa.PrivateKey) (pem.PrivateKey, error) {
+// EncodeRevocationListDERtoPEM encodes the certificate revocation list provided in DER format into PEM format
+func EncodeRevocationListDERtoPEM(derBytes []byte) (pem.RevocationList, error) {
+	crlOut := &bytes.Buffer{}
+	block := pemEnc.Block{
+		Type:  TypeRevocationList,
+		Bytes: derBytes,
+	}
+	if err := pemEnc.Encode(crlOut, &block); err != nil {
+		return nil, fmt.Errorf("%s: %w", errEncodeRevocationList.Error(), err)
+	}
+	return crlOut.Bytes(), nil
+}
+
+// EncodeKeyDERtoPEM converts a DER encoded private key into a PEM encoded key.
+// RSA, ECDSA and Ed25519 private keys are supported.
+func EncodeKeyDERtoPEM(priv crypto.PrivateKey) (pem.PrivateKey, error) {
 	keyOut := &bytes.Buffer{}
 	privBytes, err := x509.MarshalPKCS8PrivateKey(priv)
 	if err != nil {
@@ -64,8 +81,44 @@ func DecodePEMCertificate(certPEM []byte) (*x509.Certificate, error) {
 	return nil, ErrNoCertificateInPEM
 }
 
-// DecodePEMPrivateKey converts a certificate from PEM to x509 encoding
-func DecodePEMPrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+// DecodePEMCertificates converts all the certificates in a PEM bundle to x509 encoding
+func DecodePEMCertificates(certPEM []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for len(certPEM) > 0 {
+		var block *pemEnc.Block
+		block, certPEM = pemEnc.Decode(certPEM)
+		if block == nil {
+			break
+		}
+		if block.Type != TypeCertificate || len(block.Headers) != 0 {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, ErrNoCertificateInPEM
+	}
+	return certs, nil
+}
+
+// DecodePEMCertificateRequest converts a certificate request from PEM to x509 encoding
+func DecodePEMCertificateRequest(csrPEM []byte) (*x509.CertificateRequest, error) {
+	block, _ := pemEnc.Decode(csrPEM)
+	if block == nil || block.Type != TypeCertificateRequest {
+		return nil, errNoCertificateRequestInPEM
+	}
+	return x509.ParseCertificateRequest(block.Bytes)
+}
+
+// DecodePEMPrivateKey converts a private key from PEM to x509 encoding.
+// RSA, ECDSA and Ed25519 private keys are supported.
+func DecodePEMPrivateKey(keyPEM []byte) (crypto.Signer, error) {
 	for len(keyPEM) > 0 {
 		var block *pemEnc.Block
 		block, keyPEM = pemEnc.Decode(keyPEM)
@@ -80,7 +133,12 @@ func DecodePEMPrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
 		if err != nil {
 			return nil, err
 		}
-		return caKeyInterface.(*rsa.PrivateKey), nil
+		switch key := caKeyInterface.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return key.(crypto.Signer), nil
+		default:
+			return nil, fmt.Errorf("%w: %T", errUnsupportedPrivateKey, key)
+		}
 	}
 
 	return nil, ErrNoCertificateInPEM
@@ -6,12 +6,34 @@ import (
 
 var errEncodeKey = errors.New("encode key")
 var errEncodeCert = errors.New("encode cert")
+var errEncodeRevocationList = errors.New("encode revocation list")
 var errMarshalPrivateKey = errors.New("marshal private key")
 var errNoPrivateKeyInPEM = errors.New("no private Key in PEM")
+var errNoCertificateRequestInPEM = errors.New("no certificate request in PEM")
+var errUnsupportedPrivateKey = errors.New("unsupported private key type")
+
+// ErrUnsupportedKeyAlgorithm is the error for a key algorithm that private keys can't be generated with
+var ErrUnsupportedKeyAlgorithm = errors.New("unsupported key algorithm")
 
 // ErrNoCertificateInPEM is the errror for no certificate in PEM
 var ErrNoCertificateInPEM = errors.New("no certificate in PEM")
 
+// ErrCertificateNotFound is the error for a certificate that wasn't issued by the certificate manager
+var ErrCertificateNotFound = errors.New("certificate not found")
+
+// ErrInvalidCertificateRequest is the error for a certificate signing request that can't be signed
+var ErrInvalidCertificateRequest = errors.New("invalid certificate request")
+
+// ErrCertificateRequestNotSupported is the error for a signing issuer that can't sign certificate signing requests
+var ErrCertificateRequestNotSupported = errors.New("certificate requests not supported by the signing issuer")
+
+// ErrRevocationListsNotSupported is the error for a revocation that proxies can't enforce, because the revocation
+// lists of the issuers can't be signed, or trust domains are federated
+var ErrRevocationListsNotSupported = errors.New("revocation lists not supported by the issuers or federated trust domains")
+
+// ErrInvalidIntermediateCA is the error for an intermediate CA whose certificate chain, private key or root is invalid
+var ErrInvalidIntermediateCA = errors.New("invalid intermediate CA")
+
 // All of the below errors should be returned by the StorageEngine for each described scenario. The errors may be
 // wrapped
 
@@ -129,6 +129,7 @@ func FakeCertManager() (*Manager, error) {
 		&fakeMRCClient{},
 		getCertValidityDuration,
 		getCertValidityDuration,
+		func() v1alpha2.KeyAlgorithm { return v1alpha2.KeyAlgorithmRSA },
 		1*time.Hour,
 	)
 	if err != nil {
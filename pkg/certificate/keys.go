@@ -0,0 +1,66 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+// GeneratePrivateKey generates a new private key with the given algorithm.
+// The key size only applies to RSA keys. RSA keys are generated if no algorithm is given.
+func GeneratePrivateKey(algorithm v1alpha2.KeyAlgorithm, rsaKeySize int) (crypto.Signer, error) {
+	switch algorithm {
+	case v1alpha2.KeyAlgorithmRSA, "":
+		return rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case v1alpha2.KeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case v1alpha2.KeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyAlgorithm, algorithm)
+	}
+}
+
+// GetKeyAlgorithm returns the algorithm of the given private key, or an empty
+// algorithm if the key was not generated with a supported algorithm.
+func GetKeyAlgorithm(key crypto.Signer) v1alpha2.KeyAlgorithm {
+	return GetPublicKeyAlgorithm(key.Public())
+}
+
+// GetPublicKeyAlgorithm returns the algorithm of the given public key, or an empty
+// algorithm if its private key was not generated with a supported algorithm.
+func GetPublicKeyAlgorithm(key crypto.PublicKey) v1alpha2.KeyAlgorithm {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return v1alpha2.KeyAlgorithmRSA
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return v1alpha2.KeyAlgorithmECDSAP256
+		case elliptic.P384():
+			return v1alpha2.KeyAlgorithmECDSAP384
+		}
+	}
+	return ""
+}
+
+// GetCSRSignatureAlgorithm returns the signature algorithm used to sign a certificate request with the given key
+func GetCSRSignatureAlgorithm(key crypto.Signer) x509.SignatureAlgorithm {
+	switch GetKeyAlgorithm(key) {
+	case v1alpha2.KeyAlgorithmRSA:
+		return x509.SHA512WithRSA
+	case v1alpha2.KeyAlgorithmECDSAP256:
+		return x509.ECDSAWithSHA256
+	case v1alpha2.KeyAlgorithmECDSAP384:
+		return x509.ECDSAWithSHA384
+	default:
+		// Let the x509 package pick the signature algorithm based on the key
+		return x509.UnknownSignatureAlgorithm
+	}
+}
@@ -0,0 +1,72 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+func TestGeneratePrivateKey(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		algorithm                  v1alpha2.KeyAlgorithm
+		expectedAlgorithm          v1alpha2.KeyAlgorithm
+		expectedSignatureAlgorithm x509.SignatureAlgorithm
+		expectErr                  bool
+	}{
+		{
+			name:                       "default algorithm",
+			algorithm:                  "",
+			expectedAlgorithm:          v1alpha2.KeyAlgorithmRSA,
+			expectedSignatureAlgorithm: x509.SHA512WithRSA,
+		},
+		{
+			name:                       "RSA",
+			algorithm:                  v1alpha2.KeyAlgorithmRSA,
+			expectedAlgorithm:          v1alpha2.KeyAlgorithmRSA,
+			expectedSignatureAlgorithm: x509.SHA512WithRSA,
+		},
+		{
+			name:                       "ECDSA P-256",
+			algorithm:                  v1alpha2.KeyAlgorithmECDSAP256,
+			expectedAlgorithm:          v1alpha2.KeyAlgorithmECDSAP256,
+			expectedSignatureAlgorithm: x509.ECDSAWithSHA256,
+		},
+		{
+			name:                       "ECDSA P-384",
+			algorithm:                  v1alpha2.KeyAlgorithmECDSAP384,
+			expectedAlgorithm:          v1alpha2.KeyAlgorithmECDSAP384,
+			expectedSignatureAlgorithm: x509.ECDSAWithSHA384,
+		},
+		{
+			name:      "unsupported algorithm",
+			algorithm: "dsa",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			key, err := GeneratePrivateKey(tc.algorithm, 2048)
+			if tc.expectErr {
+				assert.ErrorIs(err, ErrUnsupportedKeyAlgorithm)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedAlgorithm, GetKeyAlgorithm(key))
+			assert.Equal(tc.expectedSignatureAlgorithm, GetCSRSignatureAlgorithm(key))
+
+			// The key round trips through its PEM encoding
+			keyPEM, err := EncodeKeyDERtoPEM(key)
+			assert.NoError(err)
+			decodedKey, err := DecodePEMPrivateKey(keyPEM)
+			assert.NoError(err)
+			assert.Equal(key, decodedKey)
+		})
+	}
+}
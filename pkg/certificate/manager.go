@@ -1,19 +1,25 @@
 package certificate
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math/rand"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/cskr/pubsub"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 var (
@@ -21,10 +27,12 @@ var (
 )
 
 // NewManager creates a new CertificateManager with the passed MRCClient and options
-func NewManager(ctx context.Context, mrcClient MRCClient, getServiceCertValidityPeriod func() time.Duration, getIngressCertValidityDuration func() time.Duration, checkInterval time.Duration) (*Manager, error) {
+func NewManager(ctx context.Context, mrcClient MRCClient, getServiceCertValidityPeriod func() time.Duration, getIngressCertValidityDuration func() time.Duration,
+	getKeyAlgorithm func() v1alpha2.KeyAlgorithm, checkInterval time.Duration) (*Manager, error) {
 	m := &Manager{
 		serviceCertValidityDuration: getServiceCertValidityPeriod,
 		ingressCertValidityDuration: getIngressCertValidityDuration,
+		keyAlgorithm:                getKeyAlgorithm,
 		pubsub:                      pubsub.New(1),
 	}
 
@@ -117,11 +125,22 @@ func (m *Manager) start(ctx context.Context, mrcClient MRCClient) error {
 }
 
 func (m *Manager) handleMRCEvent(mrcClient MRCClient, event MRCEvent) error {
+	mrc := event.MRC
 	switch event.Type {
-	case MRCEventAdded:
-		mrc := event.MRC
-		if mrc.Status.State == constants.MRCStateError {
-			log.Debug().Msgf("skipping MRC with error state %s", mrc.GetName())
+	case MRCEventAdded, MRCEventUpdated:
+		if mrc.Status.State == constants.MRCStateError || mrc.Status.State == constants.MRCStateInactive {
+			log.Debug().Msgf("skipping MRC with %s state %s", mrc.Status.State, mrc.GetName())
+			m.removeMRC(mrc.GetName())
+			return nil
+		}
+
+		// the issuer is only regenerated when the spec of the MRC changes, since its intent and status change
+		// during a root certificate rotation
+		m.mu.Lock()
+		existing, ok := m.mrcs[mrc.GetName()]
+		m.mu.Unlock()
+		if ok && reflect.DeepEqual(existing.mrc.Spec, mrc.Spec) {
+			m.setMRC(&mrcIssuer{mrc: mrc, issuer: existing.issuer})
 			return nil
 		}
 
@@ -131,33 +150,125 @@ func (m *Manager) handleMRCEvent(mrcClient MRCClient, event MRCEvent) error {
 		}
 
 		c := &issuer{Issuer: client, ID: mrc.Name, CertificateAuthority: ca, TrustDomain: mrc.Spec.TrustDomain}
-		switch {
-		case mrc.Status.State == constants.MRCStateActive:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.validatingIssuer = c
-			m.mu.Unlock()
-		case mrc.Status.State == constants.MRCStateIssuingRollback || mrc.Status.State == constants.MRCStateIssuingRollout:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.mu.Unlock()
-		case mrc.Status.State == constants.MRCStateValidatingRollback || mrc.Status.State == constants.MRCStateValidatingRollout:
-			m.mu.Lock()
-			m.validatingIssuer = c
-			m.mu.Unlock()
-		default:
-			m.mu.Lock()
-			m.signingIssuer = c
-			m.validatingIssuer = c
-			m.mu.Unlock()
-		}
-	case MRCEventUpdated:
-		// TODO
+		m.setMRC(&mrcIssuer{mrc: mrc, issuer: c})
+	case MRCEventDeleted:
+		m.removeMRC(mrc.GetName())
 	}
 
 	return nil
 }
 
+// setMRC adds or replaces the given MRC, and updates the signing and validating issuers.
+// Certificates are rotated to the new issuers by the next rotation check.
+func (m *Manager) setMRC(mi *mrcIssuer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mrcs == nil {
+		m.mrcs = make(map[string]*mrcIssuer)
+	}
+	m.mrcSeq++
+	mi.seq = m.mrcSeq
+	m.mrcs[mi.mrc.GetName()] = mi
+	m.updateIssuers()
+}
+
+// removeMRC removes the MRC with the given name, and updates the signing and validating issuers.
+func (m *Manager) removeMRC(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.mrcs[name]; !ok {
+		return
+	}
+	delete(m.mrcs, name)
+	m.updateIssuers()
+}
+
+// updateIssuers selects the signing and validating issuers from the MRCs. The issuers are kept if there is no MRC,
+// since certificates can't be issued without them. The caller must hold mu.
+func (m *Manager) updateIssuers() {
+	if len(m.mrcs) == 0 {
+		return
+	}
+
+	signing, validating := selectIssuers(m.mrcs)
+	if m.signingIssuer == nil || m.signingIssuer.ID != signing.issuer.ID || m.validatingIssuer.ID != validating.issuer.ID {
+		log.Info().Msgf("Using MRC %s to sign certificates and MRC %s to validate certificates", signing.issuer.ID, validating.issuer.ID)
+	}
+	m.signingIssuer = signing.issuer
+	m.validatingIssuer = validating.issuer
+}
+
+// selectIssuers returns the MRC whose issuer signs certificates, and the MRC whose issuer validates certificates
+// along with the signing one. During a root certificate rotation, where the intent of the new MRC transitions from
+// passive to active before the old MRC is retired:
+//   - a single MRC signs and validates certificates, whatever its intent.
+//   - the most recently created active MRC signs certificates, or the oldest MRC if none is active.
+//   - the other active MRC, or else the most recently created other MRC, validates certificates.
+//
+// MRCs without an intent are selected by their state, in the order they were added or updated.
+func selectIssuers(mrcs map[string]*mrcIssuer) (signing *mrcIssuer, validating *mrcIssuer) {
+	sorted := make([]*mrcIssuer, 0, len(mrcs))
+	hasIntent := false
+	for _, mi := range mrcs {
+		sorted = append(sorted, mi)
+		hasIntent = hasIntent || mi.mrc.Intent != ""
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		ti, tj := sorted[i].mrc.CreationTimestamp, sorted[j].mrc.CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return sorted[i].mrc.Name < sorted[j].mrc.Name
+	})
+
+	if len(sorted) > 2 {
+		log.Warn().Msgf("Found %d MRCs, only 2 MRCs are used to sign and validate certificates", len(sorted))
+	}
+
+	if len(sorted) == 1 {
+		return sorted[0], sorted[0]
+	}
+
+	if !hasIntent {
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].seq < sorted[j].seq })
+		for _, mi := range sorted {
+			switch mi.mrc.Status.State {
+			case constants.MRCStateIssuingRollback, constants.MRCStateIssuingRollout:
+				signing = mi
+			case constants.MRCStateValidatingRollback, constants.MRCStateValidatingRollout:
+				validating = mi
+			default:
+				signing, validating = mi, mi
+			}
+		}
+		if signing == nil {
+			signing = validating
+		}
+		if validating == nil {
+			validating = signing
+		}
+		return signing, validating
+	}
+
+	signing = sorted[0]
+	for _, mi := range sorted {
+		if mi.mrc.Intent == constants.MRCIntentActive {
+			signing = mi
+		}
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i] != signing && sorted[i].mrc.Intent == constants.MRCIntentActive {
+			return signing, sorted[i]
+		}
+	}
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i] != signing {
+			return signing, sorted[i]
+		}
+	}
+	return signing, signing
+}
+
 // GetTrustDomain returns the trust domain from the configured signingkey issuer.
 // Note that the CRD uses a default, so this value will always be set.
 func (m *Manager) GetTrustDomain() string {
@@ -166,6 +277,252 @@ func (m *Manager) GetTrustDomain() string {
 	return m.signingIssuer.TrustDomain
 }
 
+// AddFederatedRoot adds the roots of a federated trust domain to the trust context of the service certificates,
+// so that proxies accept the certificates issued to the workloads of the federated trust domain. The roots only
+// validate the certificates whose SPIFFE ID is in the given trust domain. Adding roots with an existing ID replaces
+// them. Certificates are reissued when the roots change.
+func (m *Manager) AddFederatedRoot(id string, trustDomain string, roots pem.RootCertificate) {
+	m.mu.Lock()
+	if existing, ok := m.federatedRoots[id]; ok && existing.trustDomain == trustDomain && bytes.Equal(existing.roots, roots) {
+		m.mu.Unlock()
+		return
+	}
+	if m.federatedRoots == nil {
+		m.federatedRoots = make(map[string]federatedRoot)
+	}
+	m.federatedRoots[id] = federatedRoot{trustDomain: trustDomain, roots: roots}
+	m.federatedRootsGeneration++
+	m.mu.Unlock()
+
+	log.Info().Msgf("Added federated roots %s", id)
+	go m.checkAndRotate()
+}
+
+// RemoveFederatedRoot removes the roots of a federated trust domain added by AddFederatedRoot.
+// Certificates are reissued when the roots change.
+func (m *Manager) RemoveFederatedRoot(id string) {
+	m.mu.Lock()
+	if _, ok := m.federatedRoots[id]; !ok {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.federatedRoots, id)
+	m.federatedRootsGeneration++
+	m.mu.Unlock()
+
+	log.Info().Msgf("Removed federated roots %s", id)
+	go m.checkAndRotate()
+}
+
+// getFederatedRoots returns the roots of the federated trust domains sorted by ID, and their generation.
+// The caller must hold mu.
+func (m *Manager) getFederatedRoots() ([]federatedRoot, uint64) {
+	ids := make([]string, 0, len(m.federatedRoots))
+	for id := range m.federatedRoots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	roots := make([]federatedRoot, 0, len(ids))
+	for _, id := range ids {
+		roots = append(roots, m.federatedRoots[id])
+	}
+	return roots, m.federatedRootsGeneration
+}
+
+// newTrustDomainBundles returns the roots of the given issuers and federated trust domains keyed by trust domain.
+// The roots of a federated trust domain are ignored if it is the trust domain of an issuer, so that they can't
+// validate the certificates of the workloads of the mesh.
+func newTrustDomainBundles(federatedRoots []federatedRoot, issuers ...*issuer) map[string]pem.RootCertificate {
+	bundles := make(map[string]pem.RootCertificate)
+	seen := make(map[string]struct{})
+	for _, iss := range issuers {
+		if _, ok := seen[iss.ID]; ok {
+			continue
+		}
+		seen[iss.ID] = struct{}{}
+		bundles[iss.TrustDomain] = append(append(pem.RootCertificate{}, bundles[iss.TrustDomain]...), iss.CertificateAuthority...)
+	}
+
+	local := make(map[string]struct{}, len(bundles))
+	for trustDomain := range bundles {
+		local[trustDomain] = struct{}{}
+	}
+	for _, root := range federatedRoots {
+		if _, ok := local[root.trustDomain]; ok {
+			log.Error().Msgf("Ignoring federated roots of trust domain %s, which is the trust domain of the mesh", root.trustDomain)
+			continue
+		}
+		bundles[root.trustDomain] = append(append(pem.RootCertificate{}, bundles[root.trustDomain]...), root.roots...)
+	}
+	return bundles
+}
+
+// AddRevocation revokes the certificates listed by the given revocation. Revocations are keyed by ID, and adding a
+// revocation with an existing ID replaces it. The certificates of the revoked identities are looked up in the cache.
+// Revoked certificates are reissued, and the revocation lists of the service certificates are updated to list the
+// revoked certificates. It returns the serial numbers of the certificates of the revoked identities found.
+// ErrRevocationListsNotSupported is returned, and the revocation with the given ID removed, when proxies can't reject
+// the revoked certificates.
+func (m *Manager) AddRevocation(id string, r Revocation) ([]SerialNumber, error) {
+	m.mu.Lock()
+	supported := m.revocationListsSupported()
+	m.mu.Unlock()
+	if !supported {
+		m.RemoveRevocation(id)
+		return nil, ErrRevocationListsNotSupported
+	}
+
+	identities := make(map[string]struct{}, len(r.Identities))
+	for _, si := range r.Identities {
+		identities[si.String()] = struct{}{}
+	}
+
+	found := make(map[SerialNumber]struct{})
+	if len(identities) > 0 {
+		m.cache.Range(func(_ interface{}, certInterface interface{}) bool {
+			cert := certInterface.(*Certificate)
+			if _, ok := identities[cert.cacheKey]; ok && cert.certType == service && cert.issuedAt.Before(r.RevokedAt) {
+				found[cert.SerialNumber] = struct{}{}
+			}
+			return true // continue the iteration
+		})
+	}
+
+	m.mu.Lock()
+	if existing, ok := m.revocations[id]; ok {
+		for serialNumber := range existing.identitySerialNumbers {
+			found[serialNumber] = struct{}{}
+		}
+	}
+	rev := &revocation{
+		serialNumbers:         make(map[SerialNumber]struct{}, len(r.SerialNumbers)+len(found)),
+		identitySerialNumbers: found,
+		identities:            make(map[string]time.Time, len(identities)),
+	}
+	for si := range identities {
+		rev.identities[si] = r.RevokedAt
+	}
+	for _, serialNumber := range r.SerialNumbers {
+		rev.serialNumbers[serialNumber] = struct{}{}
+	}
+	for serialNumber := range found {
+		rev.serialNumbers[serialNumber] = struct{}{}
+	}
+	identitySerialNumbers := sortedSerialNumbers(found)
+
+	if existing, ok := m.revocations[id]; ok && reflect.DeepEqual(existing.serialNumbers, rev.serialNumbers) {
+		existing.identities = rev.identities
+		m.mu.Unlock()
+		return identitySerialNumbers, nil
+	}
+	if m.revocations == nil {
+		m.revocations = make(map[string]*revocation)
+	}
+	m.revocations[id] = rev
+	m.revocationsGeneration++
+	m.mu.Unlock()
+
+	log.Info().Msgf("Added certificate revocation %s revoking %d certificates", id, len(rev.serialNumbers))
+	go m.checkAndRotate()
+	return identitySerialNumbers, nil
+}
+
+// RemoveRevocation removes the revocation added by AddRevocation.
+// The revocation lists of the service certificates are updated when the revoked certificates change.
+func (m *Manager) RemoveRevocation(id string) {
+	m.mu.Lock()
+	if _, ok := m.revocations[id]; !ok {
+		m.mu.Unlock()
+		return
+	}
+	delete(m.revocations, id)
+	m.revocationsGeneration++
+	m.mu.Unlock()
+
+	log.Info().Msgf("Removed certificate revocation %s", id)
+	go m.checkAndRotate()
+}
+
+// getRevokedSerialNumbers returns the sorted serial numbers of the revoked certificates, and their generation.
+// The caller must hold mu.
+func (m *Manager) getRevokedSerialNumbers() ([]SerialNumber, uint64) {
+	revoked := make(map[SerialNumber]struct{})
+	for _, rev := range m.revocations {
+		for serialNumber := range rev.serialNumbers {
+			revoked[serialNumber] = struct{}{}
+		}
+	}
+	return sortedSerialNumbers(revoked), m.revocationsGeneration
+}
+
+// isRevoked returns whether the certificate with the given serial number is revoked.
+// The caller must hold mu.
+func (m *Manager) isRevoked(serialNumber SerialNumber) bool {
+	for _, rev := range m.revocations {
+		if _, ok := rev.serialNumbers[serialNumber]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// revocationListsSupported returns whether the revocation lists of all the issuers trusted by the service
+// certificates can be signed. Proxies checking revocation lists reject the certificates of the issuers without one.
+// The caller must hold mu.
+func (m *Manager) revocationListsSupported() bool {
+	if len(m.federatedRoots) > 0 {
+		return false
+	}
+	for _, iss := range []*issuer{m.signingIssuer, m.validatingIssuer} {
+		if iss == nil {
+			continue
+		}
+		if _, ok := iss.Issuer.(RevocationListIssuer); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// issueRevocationLists returns the revocation lists of the given issuers, listing the given revoked serial numbers,
+// valid for the given duration. Nil is returned if no serial number is revoked, or if any of the revocation lists
+// can't be signed, since proxies checking revocation lists reject the certificates of the issuers without one.
+func issueRevocationLists(revoked []SerialNumber, validityDuration time.Duration, signingIssuer, validatingIssuer *issuer) pem.RevocationList {
+	if len(revoked) == 0 {
+		return nil
+	}
+	issuers := []*issuer{signingIssuer}
+	if validatingIssuer.ID != signingIssuer.ID {
+		issuers = append(issuers, validatingIssuer)
+	}
+
+	var revocationLists pem.RevocationList
+	for _, iss := range issuers {
+		revocationListIssuer, ok := iss.Issuer.(RevocationListIssuer)
+		if !ok {
+			return nil
+		}
+		revocationList, err := revocationListIssuer.IssueRevocationList(revoked, validityDuration)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrIssuingRevocationList)).
+				Msgf("Error issuing the revocation list of issuer %s", iss.ID)
+			return nil
+		}
+		revocationLists = append(revocationLists, revocationList...)
+	}
+	return revocationLists
+}
+
+func sortedSerialNumbers(serialNumbers map[SerialNumber]struct{}) []SerialNumber {
+	sorted := make([]SerialNumber, 0, len(serialNumbers))
+	for serialNumber := range serialNumbers {
+		sorted = append(sorted, serialNumber)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
 // shouldRotate determines whether a certificate should be rotated.
 func (m *Manager) shouldRotate(c *Certificate) bool {
 	// The certificate is going to expire at a timestamp T
@@ -190,8 +547,16 @@ func (m *Manager) shouldRotate(c *Certificate) bool {
 	m.mu.Lock()
 	validatingIssuer := m.validatingIssuer
 	signingIssuer := m.signingIssuer
+	federatedRootsGeneration := m.federatedRootsGeneration
+	revoked := m.isRevoked(c.GetSerialNumber())
 	m.mu.Unlock()
 
+	// Revoked certificates must be reissued, so that they are no longer used once proxies reject them.
+	if revoked {
+		log.Info().Msgf("Cert %s should be rotated; serial number %s is revoked", c.GetCommonName(), c.GetSerialNumber())
+		return true
+	}
+
 	// During root certificate rotation the Issuers will change. If the Manager's Issuers are
 	// different than the validating Issuer and signing Issuer IDs in the certificate, the
 	// certificate must be reissued with the correct Issuers for the current rotation stage and
@@ -202,6 +567,21 @@ func (m *Manager) shouldRotate(c *Certificate) bool {
 			c.GetCommonName())
 		return true
 	}
+
+	// Service certificates must be reissued when the roots of the federated trust domains change, so that
+	// their trust context includes the current roots.
+	if c.certType == service && c.federatedRootsGeneration != federatedRootsGeneration {
+		log.Info().Msgf("Cert %s should be rotated; federated roots changed", c.GetCommonName())
+		return true
+	}
+
+	// The certificate must be reissued when the key algorithm changes, so that the new algorithm
+	// takes effect without waiting for the certificate to expire.
+	if keyAlgorithm := m.getKeyAlgorithm(); c.keyAlgorithm != keyAlgorithm {
+		log.Info().Msgf("Cert %s should be rotated; key algorithm changed from %s to %s",
+			c.GetCommonName(), c.keyAlgorithm, keyAlgorithm)
+		return true
+	}
 	log.Trace().Msgf("Cert %s should not be rotated with serial number %s and expiration %s", c.GetCommonName(), c.GetSerialNumber(), c.GetExpiration())
 	return false
 }
@@ -222,22 +602,71 @@ func (m *Manager) checkAndRotate() {
 		if err := m.CheckCacheMatch(cert); err != nil {
 			log.Warn().Msg(err.Error()) // don't log as a full error message
 		}
-		opts := []IssueOption{}
-		opts = append(opts, withCommonNamePrefix(key))
-		opts = append(opts, withCertType(cert.certType))
-
-		// There are a few certificates (webhook and Ingress)  that have entire CN passed
-		// In that case the key will be the common name on the cert
-		if key == cert.GetCommonName().String() {
-			opts = append(opts, withFullCommonName())
-		}
 
-		_, err := m.IssueCertificate(opts...)
+		_, err := m.IssueCertificate(rotationOptions(key, cert)...)
 		if err != nil {
+			metricsstore.DefaultMetricsStore.CertRotationErrorCount.WithLabelValues(string(cert.certType)).Inc()
 			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrRotatingCert)).
 				Msgf("Error rotating cert SerialNumber=%s", cert.GetSerialNumber())
 		}
 	}
+
+	m.updateExpirationMetrics()
+}
+
+// rotationOptions returns the options to reissue the given certificate cached with the given key
+func rotationOptions(key string, cert *Certificate) []IssueOption {
+	opts := []IssueOption{}
+	if cert.trustOnly {
+		return append(opts, withCommonNamePrefix(strings.TrimPrefix(key, trustOnlyKeyPrefix)), withCertType(cert.certType), withTrustOnly())
+	}
+	opts = append(opts, withCommonNamePrefix(key))
+	opts = append(opts, withCertType(cert.certType))
+
+	// There are a few certificates (webhook and Ingress)  that have entire CN passed
+	// In that case the key will be the common name on the cert
+	if key == cert.GetCommonName().String() {
+		opts = append(opts, withFullCommonName())
+	}
+	return opts
+}
+
+// updateExpirationMetrics records the earliest expiration of the issued certificates of each type, and the
+// expiration of the roots of the MeshRootCertificates in use.
+func (m *Manager) updateExpirationMetrics() {
+	earliest := make(map[certType]time.Time)
+	m.cache.Range(func(_ interface{}, certInterface interface{}) bool {
+		cert := certInterface.(*Certificate)
+		if cert.trustOnly {
+			return true // continue the iteration
+		}
+		if expiration, ok := earliest[cert.certType]; !ok || cert.GetExpiration().Before(expiration) {
+			earliest[cert.certType] = cert.GetExpiration()
+		}
+		return true // continue the iteration
+	})
+
+	metricsstore.DefaultMetricsStore.CertEarliestExpiration.Reset()
+	for ct, expiration := range earliest {
+		metricsstore.DefaultMetricsStore.CertEarliestExpiration.WithLabelValues(string(ct)).Set(float64(expiration.Unix()))
+	}
+
+	m.mu.Lock()
+	issuers := []*issuer{m.signingIssuer, m.validatingIssuer}
+	m.mu.Unlock()
+
+	metricsstore.DefaultMetricsStore.CertMRCExpiration.Reset()
+	for _, iss := range issuers {
+		if iss == nil || len(iss.CertificateAuthority) == 0 {
+			continue
+		}
+		root, err := DecodePEMCertificate(iss.CertificateAuthority)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error decoding the root certificate of MRC %s", iss.ID)
+			continue
+		}
+		metricsstore.DefaultMetricsStore.CertMRCExpiration.WithLabelValues(iss.ID).Set(float64(root.NotAfter.Unix()))
+	}
 }
 
 func (m *Manager) getValidityDurationForCertType(ct certType) time.Duration {
@@ -254,6 +683,15 @@ func (m *Manager) getValidityDurationForCertType(ct certType) time.Duration {
 	}
 }
 
+// getKeyAlgorithm returns the algorithm used to generate the private keys of the certificates issued.
+// An empty algorithm implies the providers' default, RSA.
+func (m *Manager) getKeyAlgorithm() v1alpha2.KeyAlgorithm {
+	if m.keyAlgorithm == nil {
+		return ""
+	}
+	return m.keyAlgorithm()
+}
+
 // getFromCache returns the certificate with the specified cn from cache if it exists.
 // Note: getFromCache might return an expired or invalid certificate.
 func (m *Manager) getFromCache(key string) *Certificate {
@@ -288,36 +726,71 @@ func (m *Manager) issueCertificate(options IssueOptions) (*Certificate, error) {
 	cert := m.getFromCache(options.cacheKey()) // Don't call this while holding the lock
 	if cert != nil {
 		// check if cert needs to be rotated
-		rotate = m.shouldRotate(cert)
+		rotate = options.forceRotation || m.shouldRotate(cert)
 		if !rotate {
-			return cert, nil
+			return m.updateRevocationLists(cert), nil
 		}
 	}
 
 	m.mu.Lock()
 	validatingIssuer := m.validatingIssuer
 	signingIssuer := m.signingIssuer
+	federatedRoots, federatedRootsGeneration := m.getFederatedRoots()
+	revoked, revocationsGeneration := m.getRevokedSerialNumbers()
 	m.mu.Unlock()
 
 	start := time.Now()
 
 	options.ValidityDuration = m.getValidityDurationForCertType(options.certType)
+	options.KeyAlgorithm = m.getKeyAlgorithm()
 	options.trustDomain = signingIssuer.TrustDomain
-	newCert, err := signingIssuer.IssueCertificate(options)
-	if err != nil {
-		return nil, err
+	var newCert *Certificate
+	if options.trustOnly {
+		// The trust context of the service certificates signed by the signing issuer
+		newCert = &Certificate{
+			CommonName: options.CommonName(),
+			Expiration: start.Add(options.ValidityDuration),
+			IssuingCA:  signingIssuer.CertificateAuthority,
+			TrustedCAs: signingIssuer.CertificateAuthority,
+			trustOnly:  true,
+		}
+	} else {
+		var err error
+		if newCert, err = signingIssuer.IssueCertificate(options); err != nil {
+			return nil, err
+		}
 	}
 
-	// if we have different signing and validating issuers,
-	// create the cert's trust context
+	// if we have different signing and validating issuers, create the cert's trust context.
 	if validatingIssuer.ID != signingIssuer.ID {
 		newCert = newCert.newMergedWithRoot(validatingIssuer.CertificateAuthority)
 	}
 
+	// Only service certificates trust the federated trust domains. Their roots are kept apart from the roots of the
+	// mesh, so that a federated CA is only trusted for the SPIFFE IDs of its trust domain and can't impersonate the
+	// workloads of the mesh.
+	if options.certType == service {
+		newCert.federatedRootsGeneration = federatedRootsGeneration
+		if len(federatedRoots) > 0 {
+			newCert.TrustDomainBundles = newTrustDomainBundles(federatedRoots, signingIssuer, validatingIssuer)
+		}
+	}
+
+	// Service certificates carry the revocation lists of their trusted issuers, unless the certificates of federated
+	// trust domains, whose revocation lists can't be signed, must be accepted.
+	if options.certType == service {
+		newCert.revocationsGeneration = revocationsGeneration
+		if len(federatedRoots) == 0 {
+			newCert.RevocationLists = issueRevocationLists(revoked, options.ValidityDuration, signingIssuer, validatingIssuer)
+		}
+	}
+
 	// Add some additional meta data for internal usage
+	newCert.issuedAt = start
 	newCert.signingIssuerID = signingIssuer.ID
 	newCert.validatingIssuerID = validatingIssuer.ID
 	newCert.certType = options.certType
+	newCert.keyAlgorithm = options.KeyAlgorithm
 	newCert.cacheKey = options.cacheKey()
 
 	m.cache.Store(newCert.cacheKey, newCert)
@@ -325,8 +798,13 @@ func (m *Manager) issueCertificate(options IssueOptions) (*Certificate, error) {
 	log.Trace().Msgf("It took %s to issue certificate with SerialNumber=%s", time.Since(start), newCert.GetSerialNumber())
 
 	if rotate {
-		// Certificate was rotated
-		m.pubsub.Pub(newCert, cert.cacheKey)
+		// Certificate was rotated. The subscribers to the certificates of an identity are notified of the rotations
+		// of its trust context.
+		if options.trustOnly {
+			m.pubsub.Pub(newCert, options.commonNamePrefix)
+		} else {
+			m.pubsub.Pub(newCert, cert.cacheKey)
+		}
 
 		log.Debug().Msgf("Rotated certificate (old SerialNumber=%s) with new SerialNumber=%s", cert.SerialNumber, newCert.SerialNumber)
 	}
@@ -334,6 +812,44 @@ func (m *Manager) issueCertificate(options IssueOptions) (*Certificate, error) {
 	return newCert, nil
 }
 
+// updateRevocationLists returns the given certificate, updated with the current revocation lists when it is a service
+// certificate and the revoked certificates changed since its revocation lists were issued. Only the trust context of
+// the certificate is updated, and its subscribers notified: its key pair is kept, as only the revoked certificates
+// must be reissued.
+func (m *Manager) updateRevocationLists(cert *Certificate) *Certificate {
+	if cert.certType != service {
+		return cert
+	}
+
+	m.mu.Lock()
+	validatingIssuer := m.validatingIssuer
+	signingIssuer := m.signingIssuer
+	federated := len(m.federatedRoots) > 0
+	revoked, revocationsGeneration := m.getRevokedSerialNumbers()
+	m.mu.Unlock()
+
+	if cert.revocationsGeneration == revocationsGeneration {
+		return cert
+	}
+
+	updated := *cert
+	updated.revocationsGeneration = revocationsGeneration
+	updated.RevocationLists = nil
+	if !federated {
+		updated.RevocationLists = issueRevocationLists(revoked, m.getValidityDurationForCertType(service), signingIssuer, validatingIssuer)
+	}
+	m.cache.Store(updated.cacheKey, &updated)
+
+	if updated.trustOnly {
+		m.pubsub.Pub(&updated, strings.TrimPrefix(updated.cacheKey, trustOnlyKeyPrefix))
+	} else {
+		m.pubsub.Pub(&updated, updated.cacheKey)
+	}
+	log.Debug().Msgf("Updated the revocation lists of certificate SerialNumber=%s", updated.SerialNumber)
+
+	return &updated
+}
+
 // ReleaseCertificate is called when a cert will no longer be needed and should be removed from the system.
 func (m *Manager) ReleaseCertificate(key string) {
 	log.Trace().Msgf("Releasing certificate %s", key)
@@ -344,12 +860,78 @@ func (m *Manager) ReleaseCertificate(key string) {
 func (m *Manager) ListIssuedCertificates() []*Certificate {
 	var certs []*Certificate
 	m.cache.Range(func(cnInterface interface{}, certInterface interface{}) bool {
-		certs = append(certs, certInterface.(*Certificate))
+		if cert := certInterface.(*Certificate); !cert.trustOnly {
+			certs = append(certs, cert)
+		}
 		return true // continue the iteration
 	})
 	return certs
 }
 
+// ListRoots returns the root certificates of the MeshRootCertificates observed, sorted by name.
+func (m *Manager) ListRoots() []RootInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	issuers := make(map[string]*issuer, len(m.mrcs)+2)
+	for name, mi := range m.mrcs {
+		issuers[name] = mi.issuer
+	}
+	for _, iss := range []*issuer{m.signingIssuer, m.validatingIssuer} {
+		if iss != nil {
+			issuers[iss.ID] = iss
+		}
+	}
+
+	roots := make([]RootInfo, 0, len(issuers))
+	for id, iss := range issuers {
+		roots = append(roots, RootInfo{
+			MRC:             id,
+			Signing:         m.signingIssuer != nil && m.signingIssuer.ID == id,
+			Validating:      m.signingIssuer != nil && (m.signingIssuer.ID == id || m.validatingIssuer.ID == id),
+			RootCertificate: string(iss.CertificateAuthority),
+		})
+	}
+	sort.Slice(roots, func(i, j int) bool { return roots[i].MRC < roots[j].MRC })
+	return roots
+}
+
+// GetIssuedCertificate returns the issued certificate with the given key, such as the service identity of a service
+// certificate, common name or serial number. Nil is returned if there is no such certificate.
+func (m *Manager) GetIssuedCertificate(name string) *Certificate {
+	if cert := m.getFromCache(name); cert != nil {
+		return cert
+	}
+
+	var found *Certificate
+	m.cache.Range(func(_ interface{}, certInterface interface{}) bool {
+		cert := certInterface.(*Certificate)
+		if !cert.trustOnly && (cert.GetCommonName().String() == name || cert.GetSerialNumber().String() == name) {
+			found = cert
+			return false // stop the iteration
+		}
+		return true // continue the iteration
+	})
+	return found
+}
+
+// RotateCertificate forces the rotation of the issued certificate with the given key, common name or serial number,
+// and returns the reissued certificate. The subscribers to the certificate's rotations are notified.
+func (m *Manager) RotateCertificate(name string) (*Certificate, error) {
+	cert := m.GetIssuedCertificate(name)
+	if cert == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCertificateNotFound, name)
+	}
+
+	log.Info().Msgf("Forcing the rotation of cert %s with SerialNumber=%s", cert.GetCommonName(), cert.GetSerialNumber())
+	newCert, err := m.IssueCertificate(append(rotationOptions(cert.cacheKey, cert), withForcedRotation())...)
+	if err != nil {
+		metricsstore.DefaultMetricsStore.CertRotationErrorCount.WithLabelValues(string(cert.certType)).Inc()
+		return nil, err
+	}
+	return newCert, nil
+}
+
 // SubscribeRotations returns a channel that outputs every certificate that is rotated by the manager.
 // The caller must call the returned method to close the channel.
 // WARNING: you cannot call wait on the returned channel on the same go routine you are issuing a certificate on.
@@ -373,7 +955,7 @@ func (m *Manager) CheckCacheMatch(cert *Certificate) error {
 	}
 
 	// Currently, these don't get rotated, so we assume the cache is correct.
-	if cert.certType != service {
+	if cert.certType != service || cert.trustOnly {
 		return nil
 	}
 	key := strings.TrimSuffix(cert.CommonName.String(), "."+m.GetTrustDomain())
@@ -2,11 +2,15 @@ package certificate
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	time "time"
 
 	"github.com/cskr/pubsub"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	tassert "github.com/stretchr/testify/assert"
 	trequire "github.com/stretchr/testify/require"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,17 +19,19 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
 )
 
 func TestShouldRotate(t *testing.T) {
 	manager := &Manager{}
 
 	testCases := []struct {
-		name             string
-		cert             *Certificate
-		managerKeyIssuer *issuer
-		managerPubIssuer *issuer
-		expectedRotation bool
+		name                string
+		cert                *Certificate
+		managerKeyIssuer    *issuer
+		managerPubIssuer    *issuer
+		managerKeyAlgorithm v1alpha2.KeyAlgorithm
+		expectedRotation    bool
 	}{
 		{
 			name: "Expired certificate",
@@ -60,6 +66,32 @@ func TestShouldRotate(t *testing.T) {
 			managerPubIssuer: &issuer{ID: "1"},
 			expectedRotation: false,
 		},
+		{
+			name: "Changed key algorithm",
+			cert: &Certificate{
+				Expiration:         time.Now().Add(1 * time.Hour),
+				signingIssuerID:    "1",
+				validatingIssuerID: "1",
+				keyAlgorithm:       v1alpha2.KeyAlgorithmRSA,
+			},
+			managerKeyIssuer:    &issuer{ID: "1"},
+			managerPubIssuer:    &issuer{ID: "1"},
+			managerKeyAlgorithm: v1alpha2.KeyAlgorithmECDSAP256,
+			expectedRotation:    true,
+		},
+		{
+			name: "Unchanged key algorithm",
+			cert: &Certificate{
+				Expiration:         time.Now().Add(1 * time.Hour),
+				signingIssuerID:    "1",
+				validatingIssuerID: "1",
+				keyAlgorithm:       v1alpha2.KeyAlgorithmECDSAP256,
+			},
+			managerKeyIssuer:    &issuer{ID: "1"},
+			managerPubIssuer:    &issuer{ID: "1"},
+			managerKeyAlgorithm: v1alpha2.KeyAlgorithmECDSAP256,
+			expectedRotation:    false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -68,6 +100,7 @@ func TestShouldRotate(t *testing.T) {
 
 			manager.signingIssuer = tc.managerKeyIssuer
 			manager.validatingIssuer = tc.managerPubIssuer
+			manager.keyAlgorithm = func() v1alpha2.KeyAlgorithm { return tc.managerKeyAlgorithm }
 
 			rotate := manager.shouldRotate(tc.cert)
 			assert.Equal(tc.expectedRotation, rotate)
@@ -86,7 +119,7 @@ func TestRotor(t *testing.T) {
 
 	stop := make(chan struct{})
 	defer close(stop)
-	certManager, err := NewManager(context.Background(), &fakeMRCClient{}, getServiceCertValidityPeriod, getIngressGatewayCertValidityPeriod, 5*time.Second)
+	certManager, err := NewManager(context.Background(), &fakeMRCClient{}, getServiceCertValidityPeriod, getIngressGatewayCertValidityPeriod, nil, 5*time.Second)
 	require.NoError(err)
 
 	certA, err := certManager.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
@@ -280,6 +313,165 @@ func TestIssueCertificate(t *testing.T) {
 		assert.Equal(CommonName("fake-cert-cn.fake2.domain.com"), cert5.GetCommonName())
 	})
 
+	t.Run("federated roots", func(t *testing.T) {
+		cm := &Manager{
+			serviceCertValidityDuration: getServiceValidityDuration,
+			signingIssuer:               &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+			validatingIssuer:            &issuer{ID: "id2", Issuer: &fakeIssuer{id: "id2"}, CertificateAuthority: pem.RootCertificate("id2"), TrustDomain: "fake1.domain.com"},
+			pubsub:                      pubsub.New(0),
+		}
+
+		cert1, err := cm.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
+		assert.NoError(err)
+		assert.Equal(pem.RootCertificate("id1id2"), cert1.GetTrustedCAs())
+
+		assert.Empty(cert1.GetTrustDomainBundles())
+
+		// federated roots are only trusted for their own trust domain, and never for the local one
+		cm.AddFederatedRoot("west", "west.mesh", pem.RootCertificate("west"))
+		cm.AddFederatedRoot("east", "east.mesh", pem.RootCertificate("east"))
+		cm.AddFederatedRoot("spoof", "fake1.domain.com", pem.RootCertificate("spoof"))
+		cert2, err := cm.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
+		assert.NoError(err)
+		assert.NotEqual(cert1, cert2)
+		assert.Equal(pem.RootCertificate("id1id2"), cert2.GetTrustedCAs())
+		assert.Equal(map[string]pem.RootCertificate{
+			"fake1.domain.com": pem.RootCertificate("id1id2"),
+			"west.mesh":        pem.RootCertificate("west"),
+			"east.mesh":        pem.RootCertificate("east"),
+		}, cert2.GetTrustDomainBundles())
+
+		// adding the same roots again does not rotate the certificate
+		cm.AddFederatedRoot("west", "west.mesh", pem.RootCertificate("west"))
+		cert3, err := cm.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
+		assert.NoError(err)
+		assert.Equal(cert2, cert3)
+
+		// internal certificates don't trust federated roots
+		internalCert, err := cm.IssueCertificate(ForCommonName("internal.fake1.domain.com"))
+		assert.NoError(err)
+		assert.Equal(pem.RootCertificate("id1id2"), internalCert.GetTrustedCAs())
+
+		cm.RemoveFederatedRoot("east")
+		cm.RemoveFederatedRoot("unknown")
+		cert4, err := cm.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
+		assert.NoError(err)
+		assert.NotEqual(cert3, cert4)
+		assert.Equal(pem.RootCertificate("id1id2"), cert4.GetTrustedCAs())
+		assert.Equal(map[string]pem.RootCertificate{
+			"fake1.domain.com": pem.RootCertificate("id1id2"),
+			"west.mesh":        pem.RootCertificate("west"),
+		}, cert4.GetTrustDomainBundles())
+	})
+
+	t.Run("revocations", func(t *testing.T) {
+		iss := &issuer{ID: "id1", Issuer: &fakeRevocationListIssuer{fakeIssuer: fakeIssuer{id: "id1"}}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"}
+		cm := &Manager{
+			serviceCertValidityDuration: getServiceValidityDuration,
+			signingIssuer:               iss,
+			validatingIssuer:            iss,
+			pubsub:                      pubsub.New(0),
+		}
+
+		compromised, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+		assert.NoError(err)
+		assert.Empty(compromised.GetRevocationLists())
+		other, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+
+		// the certificates of revoked identities are found in the cache and reissued
+		found, err := cm.AddRevocation("revoked-identity", Revocation{Identities: []identity.ServiceIdentity{"sa.ns"}, RevokedAt: time.Now()})
+		assert.NoError(err)
+		assert.Equal([]SerialNumber{compromised.GetSerialNumber()}, found)
+		reissued, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+		assert.NoError(err)
+		assert.NotEqual(compromised.GetSerialNumber(), reissued.GetSerialNumber())
+		assert.Equal(pem.RevocationList(fmt.Sprintf("id1:[%s]", compromised.GetSerialNumber())), reissued.GetRevocationLists())
+
+		// the other service certificates keep their key pair, and are updated with the revocation lists
+		other2, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+		assert.Equal(other.GetSerialNumber(), other2.GetSerialNumber())
+		assert.Equal(other.GetPrivateKey(), other2.GetPrivateKey())
+		assert.Empty(other.GetRevocationLists())
+		assert.Equal(reissued.GetRevocationLists(), other2.GetRevocationLists())
+		other2Again, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+		assert.Same(other2, other2Again)
+
+		// the reissued certificates of revoked identities are not revoked again
+		found, err = cm.AddRevocation("revoked-identity", Revocation{Identities: []identity.ServiceIdentity{"sa.ns"}, RevokedAt: time.Now().Add(-time.Hour)})
+		assert.NoError(err)
+		assert.Equal([]SerialNumber{compromised.GetSerialNumber()}, found)
+		reissued2, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+		assert.NoError(err)
+		assert.Equal(reissued, reissued2)
+
+		// certificates are revoked by serial number
+		_, err = cm.AddRevocation("revoked-serial", Revocation{SerialNumbers: []SerialNumber{other2.GetSerialNumber()}})
+		assert.NoError(err)
+		other3, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+		assert.NotEqual(other2.GetSerialNumber(), other3.GetSerialNumber())
+		assert.Contains(string(other3.GetRevocationLists()), string(other2.GetSerialNumber()))
+		assert.Contains(string(other3.GetRevocationLists()), string(compromised.GetSerialNumber()))
+
+		// internal certificates don't carry revocation lists
+		internalCert, err := cm.IssueCertificate(ForCommonName("internal.fake1.domain.com"))
+		assert.NoError(err)
+		assert.Empty(internalCert.GetRevocationLists())
+
+		// the revocation lists of federated trust domains can't be signed, so revocations are rejected
+		cm.AddFederatedRoot("west", "west.mesh", pem.RootCertificate("west"))
+		federated, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+		assert.Empty(federated.GetRevocationLists())
+		_, err = cm.AddRevocation("revoked-federated", Revocation{SerialNumbers: []SerialNumber{"1"}})
+		assert.ErrorIs(err, ErrRevocationListsNotSupported)
+		cm.RemoveFederatedRoot("west")
+
+		cm.RemoveRevocation("revoked-identity")
+		cm.RemoveRevocation("revoked-serial")
+		cm.RemoveRevocation("unknown")
+		unrevoked, err := cm.IssueCertificate(ForServiceIdentity("other.ns"))
+		assert.NoError(err)
+		assert.Empty(unrevoked.GetRevocationLists())
+	})
+
+	t.Run("revocations without revocation lists", func(t *testing.T) {
+		cm := &Manager{
+			serviceCertValidityDuration: getServiceValidityDuration,
+			signingIssuer:               &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+			validatingIssuer:            &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+			pubsub:                      pubsub.New(0),
+		}
+
+		cert, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+		assert.NoError(err)
+
+		// revocations proxies can't enforce are rejected, and the certificates are not reissued
+		found, err := cm.AddRevocation("revoked-identity", Revocation{Identities: []identity.ServiceIdentity{"sa.ns"}, RevokedAt: time.Now()})
+		assert.ErrorIs(err, ErrRevocationListsNotSupported)
+		assert.Empty(found)
+		cert2, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+		assert.NoError(err)
+		assert.Same(cert, cert2)
+	})
+
+	t.Run("revocations without revocation lists", func(t *testing.T) {
+		cm := &Manager{
+			serviceCertValidityDuration: getServiceValidityDuration,
+			signingIssuer:               &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+			validatingIssuer:            &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+			pubsub:                      pubsub.New(0),
+		}
+
+		cm.AddRevocation("revoked-serial", Revocation{SerialNumbers: []SerialNumber{"1"}})
+		cert, err := cm.IssueCertificate(ForServiceIdentity(identity.ServiceIdentity(cnPrefix)))
+		assert.NoError(err)
+		assert.Empty(cert.GetRevocationLists())
+	})
+
 	t.Run("bad issuers", func(t *testing.T) {
 		cm := &Manager{
 			serviceCertValidityDuration: getServiceValidityDuration,
@@ -399,6 +591,174 @@ func TestHandleMRCEvent(t *testing.T) {
 	}
 }
 
+func TestHandleMRCEventRotation(t *testing.T) {
+	newMRC := func(name string, created time.Time, intent v1alpha2.MeshRootCertificateIntent, state string) *v1alpha2.MeshRootCertificate {
+		return &v1alpha2.MeshRootCertificate{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: v1.NewTime(created),
+			},
+			Spec: v1alpha2.MeshRootCertificateSpec{
+				TrustDomain: "foo.bar.com",
+			},
+			Intent: intent,
+			Status: v1alpha2.MeshRootCertificateStatus{
+				State: state,
+			},
+		}
+	}
+	now := time.Now()
+
+	type step struct {
+		name               string
+		event              MRCEvent
+		expectedSigning    string
+		expectedValidating string
+	}
+
+	testCases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "rotation with intents",
+			steps: []step{
+				{
+					name:               "old MRC without intent",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("old", now, "", constants.MRCStateActive)},
+					expectedSigning:    "old",
+					expectedValidating: "old",
+				},
+				{
+					name:               "new passive MRC",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("new", now.Add(time.Hour), constants.MRCIntentPassive, constants.MRCStatePending)},
+					expectedSigning:    "old",
+					expectedValidating: "new",
+				},
+				{
+					name:               "new MRC activated",
+					event:              MRCEvent{Type: MRCEventUpdated, MRC: newMRC("new", now.Add(time.Hour), constants.MRCIntentActive, constants.MRCStatePending)},
+					expectedSigning:    "new",
+					expectedValidating: "old",
+				},
+				{
+					name:               "old MRC passive",
+					event:              MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old", now, constants.MRCIntentPassive, constants.MRCStateActive)},
+					expectedSigning:    "new",
+					expectedValidating: "old",
+				},
+				{
+					name:               "old MRC deleted",
+					event:              MRCEvent{Type: MRCEventDeleted, MRC: newMRC("old", now, constants.MRCIntentPassive, constants.MRCStateActive)},
+					expectedSigning:    "new",
+					expectedValidating: "new",
+				},
+				{
+					name:               "unknown MRC deleted",
+					event:              MRCEvent{Type: MRCEventDeleted, MRC: newMRC("unknown", now, constants.MRCIntentPassive, constants.MRCStateActive)},
+					expectedSigning:    "new",
+					expectedValidating: "new",
+				},
+			},
+		},
+		{
+			name: "rotation with states",
+			steps: []step{
+				{
+					name:               "old MRC",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("old", now, "", constants.MRCStateActive)},
+					expectedSigning:    "old",
+					expectedValidating: "old",
+				},
+				{
+					name:               "new MRC issuing",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("new", now.Add(time.Hour), "", constants.MRCStateIssuingRollout)},
+					expectedSigning:    "new",
+					expectedValidating: "old",
+				},
+				{
+					name:               "new MRC in error",
+					event:              MRCEvent{Type: MRCEventUpdated, MRC: newMRC("new", now.Add(time.Hour), "", constants.MRCStateError)},
+					expectedSigning:    "old",
+					expectedValidating: "old",
+				},
+			},
+		},
+		{
+			name: "inactive MRC",
+			steps: []step{
+				{
+					name:               "old MRC",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("old", now, constants.MRCIntentActive, constants.MRCStateActive)},
+					expectedSigning:    "old",
+					expectedValidating: "old",
+				},
+				{
+					name:               "new MRC",
+					event:              MRCEvent{Type: MRCEventAdded, MRC: newMRC("new", now.Add(time.Hour), constants.MRCIntentActive, constants.MRCStateActive)},
+					expectedSigning:    "new",
+					expectedValidating: "old",
+				},
+				{
+					name:               "old MRC inactive",
+					event:              MRCEvent{Type: MRCEventUpdated, MRC: newMRC("old", now, constants.MRCIntentPassive, constants.MRCStateInactive)},
+					expectedSigning:    "new",
+					expectedValidating: "new",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Manager{}
+			for _, s := range tc.steps {
+				assert := tassert.New(t)
+
+				assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, s.event), s.name)
+
+				m.mu.Lock()
+				assert.Equal(s.expectedSigning, m.signingIssuer.ID, s.name)
+				assert.Equal(s.expectedValidating, m.validatingIssuer.ID, s.name)
+				m.mu.Unlock()
+			}
+		})
+	}
+
+	t.Run("issuer regenerated when the spec changes", func(t *testing.T) {
+		assert := tassert.New(t)
+		m := &Manager{}
+		mrc := newMRC("mrc", now, constants.MRCIntentPassive, constants.MRCStateActive)
+		assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, MRCEvent{Type: MRCEventAdded, MRC: mrc}))
+		iss := m.signingIssuer
+
+		// the intent and status of the MRC change during a rotation, without regenerating its issuer
+		updated := mrc.DeepCopy()
+		updated.Intent = constants.MRCIntentActive
+		updated.Status.State = constants.MRCStatePending
+		assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, MRCEvent{Type: MRCEventUpdated, MRC: updated}))
+		assert.Same(iss, m.signingIssuer)
+		assert.Equal(updated, m.mrcs["mrc"].mrc)
+
+		updated = updated.DeepCopy()
+		updated.Spec.TrustDomain = "bar.foo.com"
+		assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, MRCEvent{Type: MRCEventUpdated, MRC: updated}))
+		assert.NotSame(iss, m.signingIssuer)
+		assert.Equal("bar.foo.com", m.signingIssuer.TrustDomain)
+	})
+
+	t.Run("roots", func(t *testing.T) {
+		assert := tassert.New(t)
+		m := &Manager{}
+		assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, MRCEvent{Type: MRCEventAdded, MRC: newMRC("old", now, constants.MRCIntentActive, constants.MRCStateActive)}))
+		assert.NoError(m.handleMRCEvent(&fakeMRCClient{}, MRCEvent{Type: MRCEventAdded, MRC: newMRC("new", now.Add(time.Hour), constants.MRCIntentPassive, constants.MRCStatePending)}))
+		assert.Equal([]RootInfo{
+			{MRC: "new", Signing: false, Validating: true, RootCertificate: "rootCA"},
+			{MRC: "old", Signing: true, Validating: true, RootCertificate: "rootCA"},
+		}, m.ListRoots())
+	})
+}
+
 func TestSubscribeRotations(t *testing.T) {
 	assert := tassert.New(t)
 	cnPrefix1 := "fake-cert-cn1"
@@ -445,3 +805,93 @@ func TestSubscribeRotations(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRotateCertificate(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	cm := &Manager{
+		serviceCertValidityDuration: func() time.Duration { return time.Hour },
+		signingIssuer:               &issuer{ID: "id1", Issuer: &fakeRevocationListIssuer{fakeIssuer: fakeIssuer{id: "id1"}}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+		pubsub:                      pubsub.New(1),
+	}
+	cm.validatingIssuer = cm.signingIssuer
+
+	cert, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+	require.NoError(err)
+	assert.Equal(cert, cm.GetIssuedCertificate("sa.ns"))
+	assert.Equal(cert, cm.GetIssuedCertificate("sa.ns.fake1.domain.com"))
+	assert.Equal(cert, cm.GetIssuedCertificate(cert.GetSerialNumber().String()))
+	assert.Nil(cm.GetIssuedCertificate("other.ns"))
+
+	info := cert.GetInfo()
+	assert.Equal("sa.ns", info.Key)
+	assert.Equal("service", info.CertType)
+	assert.Equal("id1", info.SigningIssuerID)
+	assert.Equal("id1", info.ValidatingIssuerID)
+
+	rotations, unsub := cm.SubscribeRotations("sa.ns")
+	defer unsub()
+
+	rotated, err := cm.RotateCertificate(cert.GetSerialNumber().String())
+	require.NoError(err)
+	assert.NotEqual(cert.GetSerialNumber(), rotated.GetSerialNumber())
+	assert.Equal(rotated, cm.GetIssuedCertificate("sa.ns"))
+	assert.Equal(rotated, <-rotations)
+
+	_, err = cm.RotateCertificate("other.ns")
+	assert.ErrorIs(err, ErrCertificateNotFound)
+}
+
+func TestCheckAndRotateMetrics(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	metricsstore.DefaultMetricsStore.Start(
+		metricsstore.DefaultMetricsStore.CertRotationErrorCount,
+		metricsstore.DefaultMetricsStore.CertEarliestExpiration,
+	)
+	defer metricsstore.DefaultMetricsStore.Stop(
+		metricsstore.DefaultMetricsStore.CertRotationErrorCount,
+		metricsstore.DefaultMetricsStore.CertEarliestExpiration,
+	)
+
+	cm := &Manager{
+		serviceCertValidityDuration: func() time.Duration { return time.Hour },
+		signingIssuer:               &issuer{ID: "id1", Issuer: &fakeIssuer{id: "id1"}, CertificateAuthority: pem.RootCertificate("id1"), TrustDomain: "fake1.domain.com"},
+		pubsub:                      pubsub.New(1),
+	}
+	cm.validatingIssuer = cm.signingIssuer
+
+	cert, err := cm.IssueCertificate(ForServiceIdentity("sa.ns"))
+	require.NoError(err)
+
+	cm.checkAndRotate()
+	assert.Equal(float64(cert.GetExpiration().Unix()), testutil.ToFloat64(metricsstore.DefaultMetricsStore.CertEarliestExpiration.WithLabelValues("service")))
+	assert.False(metricsstore.DefaultMetricsStore.Contains("osm_cert_rotation_error_count"))
+
+	// a new failing signing issuer fails the rotation of the certificate
+	cm.signingIssuer = &issuer{ID: "id2", Issuer: &fakeIssuer{id: "id2", err: true}, CertificateAuthority: pem.RootCertificate("id2"), TrustDomain: "fake1.domain.com"}
+	cm.checkAndRotate()
+	assert.True(metricsstore.DefaultMetricsStore.Contains(`osm_cert_rotation_error_count{cert_type="service"} 1` + "\n"))
+}
+
+// fakeRevocationListIssuer issues certificates with sequential serial numbers, and revocation lists listing the
+// revoked serial numbers
+type fakeRevocationListIssuer struct {
+	fakeIssuer
+	serialNumber int64
+}
+
+func (i *fakeRevocationListIssuer) IssueCertificate(options IssueOptions) (*Certificate, error) {
+	cert, err := i.fakeIssuer.IssueCertificate(options)
+	if err != nil {
+		return nil, err
+	}
+	cert.SerialNumber = SerialNumber(strconv.FormatInt(atomic.AddInt64(&i.serialNumber, 1), 10))
+	return cert, nil
+}
+
+func (i *fakeRevocationListIssuer) IssueRevocationList(serialNumbers []SerialNumber, _ time.Duration) (pem.RevocationList, error) {
+	return pem.RevocationList(fmt.Sprintf("%s:%v", i.id, serialNumbers)), nil
+}
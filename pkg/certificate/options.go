@@ -2,8 +2,11 @@ package certificate
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/identity"
 )
 
@@ -16,10 +19,20 @@ type IssueOptions struct {
 	trustDomain      string
 	commonNamePrefix string
 	certType         certType
+	// forceRotation reissues the cached certificate, even if it doesn't need to be rotated
+	forceRotation bool
+	// trustOnly returns the trust context of a service certificate, without issuing it
+	trustOnly        bool
 	ValidityDuration time.Duration
+	// KeyAlgorithm is the algorithm used to generate the certificate's private key.
+	// Providers generate RSA keys if it is not set.
+	KeyAlgorithm v1alpha2.KeyAlgorithm
 }
 
 func (o IssueOptions) cacheKey() string {
+	if o.trustOnly {
+		return trustOnlyKeyPrefix + o.commonNamePrefix
+	}
 	return o.commonNamePrefix
 }
 
@@ -38,6 +51,23 @@ func (o IssueOptions) CommonName() CommonName {
 	return CommonName(fmt.Sprintf("%s.%s", o.commonNamePrefix, o.trustDomain))
 }
 
+// SPIFFEID returns the SPIFFE ID to include as a URI SAN in the certificate, of the form
+// spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>.
+// Only service certificates carry a SPIFFE ID, nil is returned otherwise.
+func (o IssueOptions) SPIFFEID() *url.URL {
+	// Service identities are of the form <service-account>.<namespace>
+	if o.certType != service || o.trustDomain == "" || !strings.Contains(o.commonNamePrefix, ".") {
+		return nil
+	}
+
+	spiffeID, err := url.Parse(identity.ServiceIdentity(o.commonNamePrefix).AsSPIFFEID(o.trustDomain))
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building SPIFFE ID for %s", o.commonNamePrefix)
+		return nil
+	}
+	return spiffeID
+}
+
 func withCommonNamePrefix(prefix string) IssueOption {
 	return func(opts *IssueOptions) {
 		opts.commonNamePrefix = prefix
@@ -56,6 +86,26 @@ func withCertType(certType certType) IssueOption {
 	}
 }
 
+func withForcedRotation() IssueOption {
+	return func(opts *IssueOptions) {
+		opts.forceRotation = true
+	}
+}
+
+func withTrustOnly() IssueOption {
+	return func(opts *IssueOptions) {
+		opts.trustOnly = true
+	}
+}
+
+// WithTrustDomain sets the trust domain of the certificate's names. The certificate manager issues certificates in
+// the trust domain of its signing issuer, regardless of this option.
+func WithTrustDomain(trustDomain string) IssueOption {
+	return func(opts *IssueOptions) {
+		opts.trustDomain = trustDomain
+	}
+}
+
 // ForServiceIdentity creates a service certificate with the given prefix for the common name
 // The trust domain will be appended to the Common Name
 func ForServiceIdentity(identity identity.ServiceIdentity) IssueOption {
@@ -65,6 +115,17 @@ func ForServiceIdentity(identity identity.ServiceIdentity) IssueOption {
 	}
 }
 
+// ForServiceIdentityTrust returns the trust context of the service certificates of the given identity: the roots and
+// revocation lists its certificates are validated with. No certificate is issued, the certificates of the identity
+// being signed for the certificate signing requests of the workloads, which hold their private keys.
+func ForServiceIdentityTrust(identity identity.ServiceIdentity) IssueOption {
+	return func(opts *IssueOptions) {
+		opts.commonNamePrefix = identity.String()
+		opts.certType = service
+		opts.trustOnly = true
+	}
+}
+
 // ForIngressGateway creates a certificate which is given a full common name
 func ForIngressGateway(fullCommonName string) IssueOption {
 	return func(opts *IssueOptions) {
@@ -87,3 +87,56 @@ func TestIssueOptions_CommonName(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueOptions_SPIFFEID(t *testing.T) {
+	tests := []struct {
+		name        string
+		trustDomain string
+		issueOption []IssueOption
+		want        string
+	}{
+		{
+			name:        "ForServiceIdentity has a SPIFFE ID",
+			trustDomain: "cluster.local",
+			issueOption: []IssueOption{ForServiceIdentity("sa.ns")},
+			want:        "spiffe://cluster.local/ns/ns/sa/sa",
+		},
+		{
+			name:        "rotated service certificate has a SPIFFE ID",
+			trustDomain: "cluster.local",
+			issueOption: []IssueOption{withCommonNamePrefix("sa.ns"), withCertType(service)},
+			want:        "spiffe://cluster.local/ns/ns/sa/sa",
+		},
+		{
+			name:        "ForServiceIdentity without a namespace has no SPIFFE ID",
+			trustDomain: "cluster.local",
+			issueOption: []IssueOption{ForServiceIdentity("sa")},
+		},
+		{
+			name:        "ForIngressGateway has no SPIFFE ID",
+			trustDomain: "cluster.local",
+			issueOption: []IssueOption{ForIngressGateway("sa.ns.cluster.local")},
+		},
+		{
+			name:        "ForCommonNamePrefix has no SPIFFE ID",
+			trustDomain: "cluster.local",
+			issueOption: []IssueOption{ForCommonNamePrefix("osm-controller.osm-system")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewCertOptions(tt.issueOption...)
+			o.trustDomain = tt.trustDomain
+			got := o.SPIFFEID()
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("IssueOptions.SPIFFEID() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.String() != tt.want {
+				t.Errorf("IssueOptions.SPIFFEID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -13,5 +13,8 @@ type RootCertificate []byte
 // RootPrivateKey is the private key for a root SSL certificate.
 type RootPrivateKey []byte
 
+// RevocationList is a certificate revocation list.
+type RevocationList []byte
+
 // CertificateRequest is an SSL certificate request.
 type CertificateRequest []byte
@@ -8,6 +8,7 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	cmapi "github.com/jetstack/cert-manager/pkg/apis/certmanager/v1"
@@ -16,6 +17,7 @@ import (
 	cminformers "github.com/jetstack/cert-manager/pkg/client/informers/externalversions"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/errcode"
@@ -84,7 +86,7 @@ func (cm *CertManager) IssueCertificate(options certificate.IssueOptions) (*cert
 		Duration: options.ValidityDuration,
 	}
 
-	certPrivKey, err := rsa.GenerateKey(rand.Reader, cm.keySize)
+	certPrivKey, err := certificate.GeneratePrivateKey(options.KeyAlgorithm, cm.keySize)
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGeneratingPrivateKey)).
@@ -102,13 +104,15 @@ func (cm *CertManager) IssueCertificate(options certificate.IssueOptions) (*cert
 
 	csr := &x509.CertificateRequest{
 		Version:            3,
-		SignatureAlgorithm: x509.SHA512WithRSA,
-		PublicKeyAlgorithm: x509.RSA,
+		SignatureAlgorithm: certificate.GetCSRSignatureAlgorithm(certPrivKey),
 		Subject: pkix.Name{
 			CommonName: options.CommonName().String(),
 		},
 		DNSNames: []string{options.CommonName().String()},
 	}
+	if spiffeID := options.SPIFFEID(); spiffeID != nil {
+		csr.URIs = []*url.URL{spiffeID}
+	}
 
 	csrDER, err := x509.CreateCertificateRequest(rand.Reader, csr, certPrivKey)
 	if err != nil {
@@ -126,28 +130,74 @@ func (cm *CertManager) IssueCertificate(options certificate.IssueOptions) (*cert
 		return nil, fmt.Errorf("failed to encode certificate request DER to PEM CN=%s: %w", options.CommonName(), err)
 	}
 
+	usages := []cmapi.KeyUsage{cmapi.UsageDigitalSignature}
+	// Key encipherment only applies to RSA keys
+	if certificate.GetKeyAlgorithm(certPrivKey) == v1alpha2.KeyAlgorithmRSA {
+		usages = []cmapi.KeyUsage{cmapi.UsageKeyEncipherment, cmapi.UsageDigitalSignature}
+	}
+
+	return cm.requestCertificate(options.CommonName(), csrPEM, usages, duration, privKeyPEM)
+}
+
+// SignCertificateRequest requests a certificate from the configured cert-manager issuer for the public key of the
+// given certificate signing request, whose private key is held by the requester. cert-manager issuers sign the names
+// of the request, which must be the names of the given options.
+func (cm *CertManager) SignCertificateRequest(csr *x509.CertificateRequest, options certificate.IssueOptions) (*certificate.Certificate, error) {
+	if !hasNames(csr, options) {
+		return nil, fmt.Errorf("%w: the names of the certificate request for CN=%s must be requested", certificate.ErrInvalidCertificateRequest, options.CommonName())
+	}
+
+	csrPEM, err := certificate.EncodeCertReqDERtoPEM(csr.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificate request DER to PEM CN=%s: %w", options.CommonName(), err)
+	}
+
+	usages := []cmapi.KeyUsage{cmapi.UsageDigitalSignature}
+	// Key encipherment only applies to RSA keys
+	if _, ok := csr.PublicKey.(*rsa.PublicKey); ok {
+		usages = []cmapi.KeyUsage{cmapi.UsageKeyEncipherment, cmapi.UsageDigitalSignature}
+	}
+
+	return cm.requestCertificate(options.CommonName(), csrPEM, usages, &metav1.Duration{Duration: options.ValidityDuration}, nil)
+}
+
+// hasNames returns whether the certificate request requests the names of the given options, and only them.
+func hasNames(csr *x509.CertificateRequest, options certificate.IssueOptions) bool {
+	if csr.Subject.CommonName != options.CommonName().String() ||
+		len(csr.DNSNames) != 1 || csr.DNSNames[0] != options.CommonName().String() {
+		return false
+	}
+	spiffeID := options.SPIFFEID()
+	if spiffeID == nil {
+		return len(csr.URIs) == 0
+	}
+	return len(csr.URIs) == 1 && csr.URIs[0].String() == spiffeID.String()
+}
+
+// requestCertificate creates a CertificateRequest for the given certificate request, and returns the certificate
+// issued once the CertificateRequest is ready, with the given private key.
+func (cm *CertManager) requestCertificate(cn certificate.CommonName, csrPEM pem.CertificateRequest, usages []cmapi.KeyUsage,
+	duration *metav1.Duration, privKeyPEM pem.PrivateKey) (*certificate.Certificate, error) {
 	cr := &cmapi.CertificateRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "osm-",
 			Namespace:    cm.namespace,
 		},
 		Spec: cmapi.CertificateRequestSpec{
-			Duration: duration,
-			IsCA:     false,
-			Usages: []cmapi.KeyUsage{
-				cmapi.UsageKeyEncipherment, cmapi.UsageDigitalSignature,
-			},
+			Duration:  duration,
+			IsCA:      false,
+			Usages:    usages,
 			Request:   csrPEM,
 			IssuerRef: cm.issuerRef,
 		},
 	}
 
-	cr, err = cm.client.Create(context.TODO(), cr, metav1.CreateOptions{})
+	cr, err := cm.client.Create(context.TODO(), cr, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	log.Debug().Msgf("Created CertificateRequest %s/%s for CN=%s", cm.namespace, cr.Name, options.CommonName())
+	log.Debug().Msgf("Created CertificateRequest %s/%s for CN=%s", cm.namespace, cr.Name, cn)
 
 	// TODO: add timeout option instead of 60s hard coded.
 	cr, err = cm.waitForCertificateReady(cr.Name, time.Second*60)
@@ -3,6 +3,8 @@ package certmanager
 import (
 	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
 	"testing"
 
 	tassert "github.com/stretchr/testify/assert"
@@ -125,3 +127,53 @@ func TestNew(t *testing.T) {
 	)
 	assert.NoError(err, "expected no error from key size of zero, got: %s", err)
 }
+
+func TestHasNames(t *testing.T) {
+	options := certificate.NewCertOptions(certificate.ForServiceIdentity("sa.ns"), certificate.WithTrustDomain("cluster.local"))
+	spiffeID := options.SPIFFEID()
+	other, _ := url.Parse("spiffe://cluster.local/ns/ns/sa/other")
+
+	testCases := []struct {
+		name     string
+		csr      *x509.CertificateRequest
+		expected bool
+	}{
+		{
+			name: "request with the names of the options",
+			csr: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "sa.ns.cluster.local"},
+				DNSNames: []string{"sa.ns.cluster.local"},
+				URIs:     []*url.URL{spiffeID},
+			},
+			expected: true,
+		},
+		{
+			name:     "request without names",
+			csr:      &x509.CertificateRequest{},
+			expected: false,
+		},
+		{
+			name: "request without the SPIFFE ID",
+			csr: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "sa.ns.cluster.local"},
+				DNSNames: []string{"sa.ns.cluster.local"},
+			},
+			expected: false,
+		},
+		{
+			name: "request with another SPIFFE ID",
+			csr: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "sa.ns.cluster.local"},
+				DNSNames: []string{"sa.ns.cluster.local"},
+				URIs:     []*url.URL{other},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tassert.Equal(t, tc.expected, hasNames(tc.csr, options))
+		})
+	}
+}
@@ -55,55 +55,10 @@ func NewCertificateManager(ctx context.Context, kubeClient kubernetes.Interface,
 			kubeClient:      kubeClient,
 			kubeConfig:      kubeConfig,
 			KeyBitSize:      utils.GetCertKeyBitSize(kubeController.GetMeshConfig()),
+			KeyAlgorithm:    utils.GetCertKeyAlgorithm(kubeController.GetMeshConfig()),
 			caExtractorFunc: getCA,
 		},
-		mrc: &v1alpha2.MeshRootCertificate{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      "legacy-compat",
-				Namespace: providerNamespace,
-			},
-			Spec: v1alpha2.MeshRootCertificateSpec{
-				Provider:    option.AsProviderSpec(),
-				TrustDomain: trustDomain,
-			},
-			Intent: constants.MRCIntentPassive,
-			Status: v1alpha2.MeshRootCertificateStatus{
-				State: constants.MRCStateActive,
-				ComponentStatuses: v1alpha2.MeshRootCertificateComponentStatuses{
-					Webhooks:        constants.MRCComponentStatusUnknown,
-					XDSControlPlane: constants.MRCComponentStatusUnknown,
-					Sidecar:         constants.MRCComponentStatusUnknown,
-					Bootstrap:       constants.MRCComponentStatusUnknown,
-					Gateway:         constants.MRCComponentStatusUnknown,
-				},
-				Conditions: []v1alpha2.MeshRootCertificateCondition{
-					{
-						Type:   constants.MRCConditionTypeReady,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-					{
-						Type:   constants.MRCConditionTypeAccepted,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-					{
-						Type:   constants.MRCConditionTypeIssuingRollout,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-					{
-						Type:   constants.MRCConditionTypeValidatingRollout,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-					{
-						Type:   constants.MRCConditionTypeIssuingRollback,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-					{
-						Type:   constants.MRCConditionTypeValidatingRollback,
-						Status: constants.MRCConditionStatusUnknown,
-					},
-				},
-			},
-		},
+		mrc: newCompatMRC(providerNamespace, option.AsProviderSpec(), trustDomain),
 	}
 	// TODO(#4745): Remove after deprecating the osm.vault.token option.
 	if vaultOption, ok := option.(VaultOptions); ok {
@@ -115,6 +70,7 @@ func NewCertificateManager(ctx context.Context, kubeClient kubernetes.Interface,
 		mrcClient,
 		func() time.Duration { return utils.GetServiceCertValidityPeriod(kubeController.GetMeshConfig()) },
 		func() time.Duration { return utils.GetIngressGatewayCertValidityPeriod(kubeController.GetMeshConfig()) },
+		func() v1alpha2.KeyAlgorithm { return utils.GetCertKeyAlgorithm(kubeController.GetMeshConfig()) },
 		checkInterval,
 	)
 }
@@ -131,6 +87,7 @@ func NewCertificateManagerFromMRC(ctx context.Context, kubeClient kubernetes.Int
 			kubeClient:      kubeClient,
 			kubeConfig:      kubeConfig,
 			KeyBitSize:      utils.GetCertKeyBitSize(kubeController.GetMeshConfig()),
+			KeyAlgorithm:    utils.GetCertKeyAlgorithm(kubeController.GetMeshConfig()),
 			caExtractorFunc: getCA,
 		},
 		informerCollection: ic,
@@ -145,10 +102,91 @@ func NewCertificateManagerFromMRC(ctx context.Context, kubeClient kubernetes.Int
 		mrcClient,
 		func() time.Duration { return utils.GetServiceCertValidityPeriod(kubeController.GetMeshConfig()) },
 		func() time.Duration { return utils.GetIngressGatewayCertValidityPeriod(kubeController.GetMeshConfig()) },
+		func() v1alpha2.KeyAlgorithm { return utils.GetCertKeyAlgorithm(kubeController.GetMeshConfig()) },
 		checkInterval,
 	)
 }
 
+// NewCertificateManagerFromCA returns a new certificate manager with a MRC compat client, issuing certificates with
+// Tresor from the given CA instead of a CA stored in a Kubernetes Secret. It is used when the control plane runs
+// without Kubernetes, and reads the MeshConfig with the given function.
+func NewCertificateManagerFromCA(ctx context.Context, ca *certificate.Certificate, providerNamespace string,
+	getMeshConfig func() v1alpha2.MeshConfig, checkInterval time.Duration, trustDomain string) (*certificate.Manager, error) {
+	if ca.GetPrivateKey() == nil {
+		return nil, errors.New("CA does not have a private key")
+	}
+
+	mrcClient := &MRCCompatClient{
+		MRCProviderGenerator: MRCProviderGenerator{
+			KeyBitSize:      utils.GetCertKeyBitSize(getMeshConfig()),
+			KeyAlgorithm:    utils.GetCertKeyAlgorithm(getMeshConfig()),
+			caExtractorFunc: getCA,
+			tresorCA:        ca,
+		},
+		mrc: newCompatMRC(providerNamespace, TresorOptions{}.AsProviderSpec(), trustDomain),
+	}
+
+	return certificate.NewManager(
+		ctx,
+		mrcClient,
+		func() time.Duration { return utils.GetServiceCertValidityPeriod(getMeshConfig()) },
+		func() time.Duration { return utils.GetIngressGatewayCertValidityPeriod(getMeshConfig()) },
+		func() v1alpha2.KeyAlgorithm { return utils.GetCertKeyAlgorithm(getMeshConfig()) },
+		checkInterval,
+	)
+}
+
+// newCompatMRC returns the MRC generated from the certificate provider options by the MRC compat client
+func newCompatMRC(namespace string, provider v1alpha2.ProviderSpec, trustDomain string) *v1alpha2.MeshRootCertificate {
+	return &v1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy-compat",
+			Namespace: namespace,
+		},
+		Spec: v1alpha2.MeshRootCertificateSpec{
+			Provider:    provider,
+			TrustDomain: trustDomain,
+		},
+		Intent: constants.MRCIntentPassive,
+		Status: v1alpha2.MeshRootCertificateStatus{
+			State: constants.MRCStateActive,
+			ComponentStatuses: v1alpha2.MeshRootCertificateComponentStatuses{
+				Webhooks:        constants.MRCComponentStatusUnknown,
+				XDSControlPlane: constants.MRCComponentStatusUnknown,
+				Sidecar:         constants.MRCComponentStatusUnknown,
+				Bootstrap:       constants.MRCComponentStatusUnknown,
+				Gateway:         constants.MRCComponentStatusUnknown,
+			},
+			Conditions: []v1alpha2.MeshRootCertificateCondition{
+				{
+					Type:   constants.MRCConditionTypeReady,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+				{
+					Type:   constants.MRCConditionTypeAccepted,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+				{
+					Type:   constants.MRCConditionTypeIssuingRollout,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+				{
+					Type:   constants.MRCConditionTypeValidatingRollout,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+				{
+					Type:   constants.MRCConditionTypeIssuingRollback,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+				{
+					Type:   constants.MRCConditionTypeValidatingRollback,
+					Status: constants.MRCConditionStatusUnknown,
+				},
+			},
+		},
+	}
+}
+
 // GetCertIssuerForMRC returns a certificate.Issuer generated from the provided MRC.
 func (c *MRCProviderGenerator) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (certificate.Issuer, pem.RootCertificate, error) {
 	p := mrc.Spec.Provider
@@ -182,11 +220,28 @@ func (c *MRCProviderGenerator) getTresorOSMCertificateManager(mrc *v1alpha2.Mesh
 	var err error
 	var rootCert *certificate.Certificate
 
+	if c.tresorCA != nil {
+		return tresor.New(c.tresorCA, rootCertOrganization, c.KeyBitSize)
+	}
+
+	// An intermediate CA signed by an offline root is imported rather than generated
+	if ref := mrc.Spec.Provider.Tresor.CA.IntermediateSecretRef; ref != nil {
+		ns := ref.Namespace
+		if ns == "" {
+			ns = mrc.Namespace
+		}
+		intermediateCA, err := k8storage.GetIntermediateCAFromKubernetes(ns, ref.Name, c.kubeClient)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load intermediate CA from secret %s/%s: %w", ns, ref.Name, err)
+		}
+		return tresor.New(intermediateCA, rootCertOrganization, c.KeyBitSize)
+	}
+
 	// This part synchronizes CA creation using the inherent atomicity of kubernetes API backend
 	// Assuming multiple instances of Tresor are instantiated at the same time, only one of them will
 	// succeed to issue a "Create" of the secret. All other Creates will fail with "AlreadyExists".
 	// Regardless of success or failure, all instances can proceed to load the same CA.
-	rootCert, err = tresor.NewCA(constants.CertificationAuthorityCommonName, constants.CertificationAuthorityRootValidityPeriod, rootCertCountry, rootCertLocality, rootCertOrganization)
+	rootCert, err = tresor.NewCA(constants.CertificationAuthorityCommonName, constants.CertificationAuthorityRootValidityPeriod, rootCertCountry, rootCertLocality, rootCertOrganization, c.KeyAlgorithm)
 	if err != nil {
 		return nil, errors.New("Failed to create new Certificate Authority with cert issuer tresor")
 	}
@@ -223,6 +278,18 @@ func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.
 	// A Vault address would have the following shape: "http://vault.default.svc.cluster.local:8200"
 	vaultAddr := fmt.Sprintf("%s://%s:%d", provider.Protocol, provider.Host, provider.Port)
 
+	if provider.Auth != nil {
+		auth, err := c.getHashiVaultAuth(provider.Auth)
+		if err != nil {
+			return nil, err
+		}
+		vaultClient, err := vault.NewWithAuth(vaultAddr, auth, provider.Role, c.KeyBitSize)
+		if err != nil {
+			return nil, fmt.Errorf("error instantiating Hashicorp Vault as a Certificate Manager: %w", err)
+		}
+		return vaultClient, nil
+	}
+
 	// If the DefaultVaultToken is empty, query Vault token secret
 	var err error
 	vaultToken := c.DefaultVaultToken
@@ -238,6 +305,7 @@ func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.
 		vaultAddr,
 		vaultToken,
 		provider.Role,
+		c.KeyBitSize,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error instantiating Hashicorp Vault as a Certificate Manager: %w", err)
@@ -246,19 +314,47 @@ func (c *MRCProviderGenerator) getHashiVaultOSMCertificateManager(mrc *v1alpha2.
 	return vaultClient, nil
 }
 
+// getHashiVaultAuth returns the method used to log in to Hashi Vault given the auth spec
+func (c *MRCProviderGenerator) getHashiVaultAuth(spec *v1alpha2.VaultAuthSpec) (vault.Auth, error) {
+	switch {
+	case spec.Kubernetes != nil && spec.AppRole == nil:
+		return vault.KubernetesAuth{
+			Role:      spec.Kubernetes.Role,
+			MountPath: spec.Kubernetes.MountPath,
+			TokenPath: spec.Kubernetes.TokenPath,
+		}, nil
+	case spec.AppRole != nil && spec.Kubernetes == nil:
+		secretIDRef := spec.AppRole.SecretIDRef
+		return vault.AppRoleAuth{
+			RoleID:    spec.AppRole.RoleID,
+			MountPath: spec.AppRole.MountPath,
+			GetSecretID: func() (string, error) {
+				return getHashiVaultSecretValue(&secretIDRef, "AppRole secret ID", c.kubeClient)
+			},
+		}, nil
+	default:
+		return nil, errors.New("exactly one of the kubernetes and appRole Hashi Vault auth methods must be specified")
+	}
+}
+
 // getHashiVaultOSMToken returns the Hashi Vault token from the secret specified in the provided secret key reference
 func getHashiVaultOSMToken(secretKeyRef *v1alpha2.SecretKeyReferenceSpec, kubeClient kubernetes.Interface) (string, error) {
-	tokenSecret, err := kubeClient.CoreV1().Secrets(secretKeyRef.Namespace).Get(context.TODO(), secretKeyRef.Name, metav1.GetOptions{})
+	return getHashiVaultSecretValue(secretKeyRef, "token", kubeClient)
+}
+
+// getHashiVaultSecretValue returns the value of the secret specified in the provided secret key reference
+func getHashiVaultSecretValue(secretKeyRef *v1alpha2.SecretKeyReferenceSpec, description string, kubeClient kubernetes.Interface) (string, error) {
+	secret, err := kubeClient.CoreV1().Secrets(secretKeyRef.Namespace).Get(context.TODO(), secretKeyRef.Name, metav1.GetOptions{})
 	if err != nil {
-		return "", fmt.Errorf("error retrieving Hashi Vault token secret %s/%s: %w", secretKeyRef.Namespace, secretKeyRef.Name, err)
+		return "", fmt.Errorf("error retrieving Hashi Vault %s secret %s/%s: %w", description, secretKeyRef.Namespace, secretKeyRef.Name, err)
 	}
 
-	token, ok := tokenSecret.Data[secretKeyRef.Key]
+	value, ok := secret.Data[secretKeyRef.Key]
 	if !ok {
-		return "", fmt.Errorf("key %s not found in Hashi Vault token secret %s/%s", secretKeyRef.Key, secretKeyRef.Namespace, secretKeyRef.Name)
+		return "", fmt.Errorf("key %s not found in Hashi Vault %s secret %s/%s", secretKeyRef.Key, description, secretKeyRef.Namespace, secretKeyRef.Name)
 	}
 
-	return string(token), nil
+	return string(value), nil
 }
 
 // getCertManagerOSMCertificateManager returns a certificate manager instance with cert-manager as the certificate provider
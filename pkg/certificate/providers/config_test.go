@@ -23,6 +23,8 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
 	"github.com/openservicemesh/osm/pkg/k8s/informers"
 )
 
@@ -149,6 +151,28 @@ func TestGetCertificateManager(t *testing.T) {
 	}
 }
 
+func TestNewCertificateManagerFromCA(t *testing.T) {
+	assert := tassert.New(t)
+	getMeshConfig := func() v1alpha2.MeshConfig { return v1alpha2.MeshConfig{} }
+
+	ca, err := tresor.NewCA("Fake Tresor CN", 1*time.Hour, "US", "CA", "Open Service Mesh", v1alpha2.KeyAlgorithmRSA)
+	assert.NoError(err)
+
+	manager, err := NewCertificateManagerFromCA(context.Background(), ca, "osm-system", getMeshConfig, 1*time.Hour, "cluster.local")
+	assert.NoError(err)
+	assert.Equal("cluster.local", manager.GetTrustDomain())
+
+	cert, err := manager.IssueCertificate(certificate.ForServiceIdentity("foo.bar.cluster.local"))
+	assert.NoError(err)
+	assert.Equal(pem.RootCertificate(ca.GetCertificateChain()), cert.GetIssuingCA())
+
+	// The CA must be able to sign certificates
+	ca.PrivateKey = nil
+	manager, err = NewCertificateManagerFromCA(context.Background(), ca, "osm-system", getMeshConfig, 1*time.Hour, "cluster.local")
+	assert.Error(err)
+	assert.Nil(manager)
+}
+
 func TestGetCertificateManagerFromMRC(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	k8sMock := k8s.NewMockController(mockCtrl)
@@ -781,3 +805,91 @@ func TestGetHashiVaultOSMToken(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHashiVaultAuth(t *testing.T) {
+	secretIDSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "osm-system",
+			Name:      "osm-vault-approle",
+		},
+		Data: map[string][]byte{
+			"secret-id": []byte("secret-id"),
+		},
+	}
+	secretIDRef := v1alpha2.SecretKeyReferenceSpec{
+		Name:      "osm-vault-approle",
+		Namespace: "osm-system",
+		Key:       "secret-id",
+	}
+
+	testCases := []struct {
+		name             string
+		spec             *v1alpha2.VaultAuthSpec
+		expectedAuth     vault.Auth
+		expectedSecretID string
+		expectError      bool
+	}{
+		{
+			name: "kubernetes auth",
+			spec: &v1alpha2.VaultAuthSpec{
+				Kubernetes: &v1alpha2.VaultKubernetesAuthSpec{
+					Role:      "osm",
+					MountPath: "k8s",
+					TokenPath: "/var/run/secrets/tokens/vault-token",
+				},
+			},
+			expectedAuth: vault.KubernetesAuth{
+				Role:      "osm",
+				MountPath: "k8s",
+				TokenPath: "/var/run/secrets/tokens/vault-token",
+			},
+		},
+		{
+			name: "approle auth",
+			spec: &v1alpha2.VaultAuthSpec{
+				AppRole: &v1alpha2.VaultAppRoleAuthSpec{
+					RoleID:      "role-id",
+					SecretIDRef: secretIDRef,
+				},
+			},
+			expectedSecretID: "secret-id",
+		},
+		{
+			name:        "no auth method",
+			spec:        &v1alpha2.VaultAuthSpec{},
+			expectError: true,
+		},
+		{
+			name: "both auth methods",
+			spec: &v1alpha2.VaultAuthSpec{
+				Kubernetes: &v1alpha2.VaultKubernetesAuthSpec{Role: "osm"},
+				AppRole:    &v1alpha2.VaultAppRoleAuthSpec{RoleID: "role-id", SecretIDRef: secretIDRef},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			c := &MRCProviderGenerator{kubeClient: fake.NewSimpleClientset(secretIDSecret)}
+
+			auth, err := c.getHashiVaultAuth(tc.spec)
+			if tc.expectError {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			appRole, ok := auth.(vault.AppRoleAuth)
+			if !ok {
+				assert.Equal(tc.expectedAuth, auth)
+				return
+			}
+			assert.Equal(tc.spec.AppRole.RoleID, appRole.RoleID)
+			secretID, err := appRole.GetSecretID()
+			assert.NoError(err)
+			assert.Equal(tc.expectedSecretID, secretID)
+		})
+	}
+}
@@ -62,10 +62,21 @@ func (m *MRCComposer) Watch(ctx context.Context) (<-chan certificate.MRCEvent, e
 				MRC:  mrc,
 			}
 		},
-		// We don't care about deletes because the only deletes that should
-		// happen come from the control plane cleaning up an old MRC. Our
-		// ValdatingWebhookConfiguration should prevent deletes from users
-		DeleteFunc: func(obj interface{}) {},
+		// MRCs are deleted to retire them at the end of a root certificate rotation
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			mrc, ok := obj.(*v1alpha2.MeshRootCertificate)
+			if !ok {
+				return
+			}
+			log.Debug().Msgf("received MRC delete event for MRC %s/%s", mrc.GetNamespace(), mrc.GetName())
+			eventChan <- certificate.MRCEvent{
+				Type: certificate.MRCEventDeleted,
+				MRC:  mrc,
+			}
+		},
 	})
 
 	return eventChan, nil
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	fakeConfigClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+)
+
+func TestMRCComposerWatch(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	configClient := fakeConfigClientset.NewSimpleClientset()
+	ic, err := informers.NewInformerCollection("osm", stop, informers.WithConfigClient(configClient, "", "osm-system"))
+	require.NoError(err)
+
+	m := &MRCComposer{informerCollection: ic}
+	events, err := m.Watch(context.Background())
+	require.NoError(err)
+
+	nextEvent := func() certificate.MRCEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for an MRC event")
+			return certificate.MRCEvent{}
+		}
+	}
+
+	mrc := &v1alpha2.MeshRootCertificate{
+		ObjectMeta: metav1.ObjectMeta{Name: "osm-mesh-root-certificate", Namespace: "osm-system"},
+		Intent:     constants.MRCIntentPassive,
+	}
+	_, err = configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").Create(context.Background(), mrc, metav1.CreateOptions{})
+	require.NoError(err)
+	event := nextEvent()
+	assert.Equal(certificate.MRCEventAdded, event.Type)
+	assert.Equal(mrc.Name, event.MRC.Name)
+
+	mrc.Intent = constants.MRCIntentActive
+	_, err = configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").Update(context.Background(), mrc, metav1.UpdateOptions{})
+	require.NoError(err)
+	event = nextEvent()
+	assert.Equal(certificate.MRCEventUpdated, event.Type)
+	assert.Equal(v1alpha2.MeshRootCertificateIntent(constants.MRCIntentActive), event.MRC.Intent)
+
+	// MRCs are deleted to retire them at the end of a root certificate rotation
+	err = configClient.ConfigV1alpha2().MeshRootCertificates("osm-system").Delete(context.Background(), mrc.Name, metav1.DeleteOptions{})
+	require.NoError(err)
+	event = nextEvent()
+	assert.Equal(certificate.MRCEventDeleted, event.Type)
+	assert.Equal(mrc.Name, event.MRC.Name)
+}
@@ -19,7 +19,7 @@ func (options TresorOptions) Validate() error {
 
 // AsProviderSpec returns the provider spec generated from the tresor options
 func (options TresorOptions) AsProviderSpec() v1alpha2.ProviderSpec {
-	return v1alpha2.ProviderSpec{
+	spec := v1alpha2.ProviderSpec{
 		Tresor: &v1alpha2.TresorProviderSpec{
 			CA: v1alpha2.TresorCASpec{
 				SecretRef: corev1.SecretReference{
@@ -28,6 +28,12 @@ func (options TresorOptions) AsProviderSpec() v1alpha2.ProviderSpec {
 			},
 		},
 	}
+	if options.IntermediateSecretName != "" {
+		spec.Tresor.CA.IntermediateSecretRef = &corev1.SecretReference{
+			Name: options.IntermediateSecretName,
+		}
+	}
+	return spec
 }
 
 // Validate validates the options for Hashi Vault certificate provider
@@ -36,8 +42,22 @@ func (options VaultOptions) Validate() error {
 		return errors.New("VaultHost not specified in Hashi Vault options")
 	}
 
-	if options.VaultToken == "" && (options.VaultTokenSecretKey == "" || options.VaultTokenSecretName == "") {
-		return errors.New("VaultTokenSecretKey and VaultTokenSecretName must both specified if VaultToken is not specified in Hashi Vault options")
+	switch options.VaultAuthMethod {
+	case "", VaultAuthMethodToken:
+		if options.VaultToken == "" && (options.VaultTokenSecretKey == "" || options.VaultTokenSecretName == "") {
+			return errors.New("VaultTokenSecretKey and VaultTokenSecretName must both specified if VaultToken is not specified in Hashi Vault options")
+		}
+	case VaultAuthMethodKubernetes:
+		if options.VaultKubernetesAuthRole == "" {
+			return errors.New("VaultKubernetesAuthRole not specified for the kubernetes auth method in Hashi Vault options")
+		}
+	case VaultAuthMethodAppRole:
+		if options.VaultAppRoleID == "" || options.VaultAppRoleSecretIDSecretName == "" || options.VaultAppRoleSecretIDSecretKey == "" {
+			return errors.New("VaultAppRoleID, VaultAppRoleSecretIDSecretName and VaultAppRoleSecretIDSecretKey must be specified for the approle auth method in Hashi Vault options")
+		}
+	default:
+		return fmt.Errorf("VaultAuthMethod in Hashi Vault options must be one of [%s, %s, %s], got %s",
+			VaultAuthMethodToken, VaultAuthMethodKubernetes, VaultAuthMethodAppRole, options.VaultAuthMethod)
 	}
 
 	if options.VaultRole == "" {
@@ -66,10 +86,39 @@ func (options VaultOptions) AsProviderSpec() v1alpha2.ProviderSpec {
 			},
 			Role: options.VaultRole,
 			Port: options.VaultPort,
+			Auth: options.getAuthSpec(),
 		},
 	}
 }
 
+// getAuthSpec returns the spec of the auth method used to log in to Vault, nil if a static token is used
+func (options VaultOptions) getAuthSpec() *v1alpha2.VaultAuthSpec {
+	switch options.VaultAuthMethod {
+	case VaultAuthMethodKubernetes:
+		return &v1alpha2.VaultAuthSpec{
+			Kubernetes: &v1alpha2.VaultKubernetesAuthSpec{
+				Role:      options.VaultKubernetesAuthRole,
+				MountPath: options.VaultAuthMountPath,
+				TokenPath: options.VaultKubernetesAuthTokenPath,
+			},
+		}
+	case VaultAuthMethodAppRole:
+		return &v1alpha2.VaultAuthSpec{
+			AppRole: &v1alpha2.VaultAppRoleAuthSpec{
+				RoleID: options.VaultAppRoleID,
+				SecretIDRef: v1alpha2.SecretKeyReferenceSpec{
+					Name:      options.VaultAppRoleSecretIDSecretName,
+					Namespace: options.VaultTokenSecretNamespace,
+					Key:       options.VaultAppRoleSecretIDSecretKey,
+				},
+				MountPath: options.VaultAuthMountPath,
+			},
+		}
+	default:
+		return nil
+	}
+}
+
 // Validate validates the options for cert-manager.io certificate provider
 func (options CertManagerOptions) Validate() error {
 	if options.IssuerName == "" {
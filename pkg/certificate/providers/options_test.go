@@ -144,6 +144,62 @@ func TestValidateVaultOptions(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			testName: "Valid kubernetes auth",
+			options: VaultOptions{
+				VaultProtocol:           "https",
+				VaultHost:               "vault-host",
+				VaultRole:               "role",
+				VaultAuthMethod:         VaultAuthMethodKubernetes,
+				VaultKubernetesAuthRole: "osm",
+			},
+			expectErr: false,
+		},
+		{
+			testName: "Kubernetes auth without role",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultRole:       "role",
+				VaultAuthMethod: VaultAuthMethodKubernetes,
+			},
+			expectErr: true,
+		},
+		{
+			testName: "Valid approle auth",
+			options: VaultOptions{
+				VaultProtocol:                  "https",
+				VaultHost:                      "vault-host",
+				VaultRole:                      "role",
+				VaultAuthMethod:                VaultAuthMethodAppRole,
+				VaultAppRoleID:                 "role-id",
+				VaultAppRoleSecretIDSecretName: "secret",
+				VaultAppRoleSecretIDSecretKey:  "key",
+			},
+			expectErr: false,
+		},
+		{
+			testName: "Approle auth without secret ID secret",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultRole:       "role",
+				VaultAuthMethod: VaultAuthMethodAppRole,
+				VaultAppRoleID:  "role-id",
+			},
+			expectErr: true,
+		},
+		{
+			testName: "Unknown auth method",
+			options: VaultOptions{
+				VaultProtocol:   "https",
+				VaultHost:       "vault-host",
+				VaultToken:      "vault-token",
+				VaultRole:       "role",
+				VaultAuthMethod: "userpass",
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, t := range testCases {
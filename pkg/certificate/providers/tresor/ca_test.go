@@ -7,6 +7,7 @@ import (
 
 	tassert "github.com/stretchr/testify/assert"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
@@ -16,7 +17,7 @@ func TestNewCA(t *testing.T) {
 	rootCertLocality := "CA"
 	rootCertOrganization := testCertOrgName
 
-	cert, err := NewCA("Tresor CA for Testing", 2*time.Second, rootCertCountry, rootCertLocality, rootCertOrganization)
+	cert, err := NewCA("Tresor CA for Testing", 2*time.Second, rootCertCountry, rootCertLocality, rootCertOrganization, v1alpha2.KeyAlgorithmRSA)
 	assert.Nil(err)
 
 	x509Cert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
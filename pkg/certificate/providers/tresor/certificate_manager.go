@@ -1,11 +1,15 @@
 package tresor
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -32,6 +36,10 @@ func New(
 		certificatesOrganization: certificatesOrganization,
 		keySize:                  keySize,
 	}
+	// An intermediate CA's chain differs from its root
+	if !bytes.Equal(ca.GetCertificateChain(), ca.GetIssuingCA()) {
+		certManager.caChain = ca.GetCertificateChain()
+	}
 	return &certManager, nil
 }
 
@@ -44,7 +52,7 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		return nil, errNoIssuingCA
 	}
 
-	certPrivKey, err := rsa.GenerateKey(rand.Reader, cm.keySize)
+	certPrivKey, err := certificate.GeneratePrivateKey(opts.KeyAlgorithm, cm.keySize)
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGeneratingPrivateKey)).
@@ -52,6 +60,34 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		return nil, fmt.Errorf("%s: %w", errGeneratingPrivateKey.Error(), err)
 	}
 
+	cert, err := cm.signCertificate(opts, certPrivKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	privKeyPEM, err := certificate.EncodeKeyDERtoPEM(certPrivKey)
+	if err != nil {
+		// TODO(#3962): metric might not be scraped before process restart resulting from this error
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrEncodingKeyDERtoPEM)).
+			Msgf("Error encoding private key for certificate with SerialNumber=%s", cert.GetSerialNumber())
+		return nil, err
+	}
+	cert.PrivateKey = privKeyPEM
+
+	return cert, nil
+}
+
+// SignCertificateRequest signs a certificate for the public key of the given certificate signing request, whose
+// private key is held by the requester. The certificate's names are set from the given options, not the request.
+func (cm *CertManager) SignCertificateRequest(csr *x509.CertificateRequest, opts certificate.IssueOptions) (*certificate.Certificate, error) {
+	if cm.ca == nil {
+		return nil, errNoIssuingCA
+	}
+	return cm.signCertificate(opts, csr.PublicKey)
+}
+
+// signCertificate signs a certificate for the given public key, without private key.
+func (cm *CertManager) signCertificate(opts certificate.IssueOptions, publicKey crypto.PublicKey) (*certificate.Certificate, error) {
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errGeneratingSerialNumber.Error(), err)
@@ -70,10 +106,17 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		NotBefore: now,
 		NotAfter:  now.Add(opts.ValidityDuration),
 
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 	}
+	if spiffeID := opts.SPIFFEID(); spiffeID != nil {
+		template.URIs = []*url.URL{spiffeID}
+	}
+	// Key encipherment only applies to RSA keys
+	if _, ok := publicKey.(*rsa.PublicKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
 
 	x509Root, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
 	if err != nil {
@@ -83,7 +126,12 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		return nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
 	}
 
-	rsaKeyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
+	// A certificate can't outlive the CA issuing it, which matters for an intermediate CA expiring before its root
+	if template.NotAfter.After(x509Root.NotAfter) {
+		template.NotAfter = x509Root.NotAfter
+	}
+
+	keyRoot, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMPrivateKey)).
@@ -91,7 +139,7 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		return nil, fmt.Errorf("%s: %w", errCreateCert.Error(), err)
 	}
 
-	derBytes, err := x509.CreateCertificate(rand.Reader, &template, x509Root, &certPrivKey.PublicKey, rsaKeyRoot)
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template, x509Root, publicKey, keyRoot)
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrCreatingCert)).
@@ -107,21 +155,18 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 		return nil, err
 	}
 
-	privKeyPEM, err := certificate.EncodeKeyDERtoPEM(certPrivKey)
-	if err != nil {
-		// TODO(#3962): metric might not be scraped before process restart resulting from this error
-		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrEncodingKeyDERtoPEM)).
-			Msgf("Error encoding private key for certificate with SerialNumber=%s", serialNumber)
-		return nil, err
-	}
+	// Certificates issued by an intermediate CA are sent with the chain of the intermediate CA, so that
+	// peers trusting the root alone can validate them
+	certChain := make(pem.Certificate, 0, len(certPEM)+len(cm.caChain))
+	certChain = append(certChain, certPEM...)
+	certChain = append(certChain, cm.caChain...)
 
 	cert := &certificate.Certificate{
 		CommonName:   opts.CommonName(),
 		SerialNumber: certificate.SerialNumber(serialNumber.String()),
-		CertChain:    certPEM,
-		PrivateKey:   privKeyPEM,
-		IssuingCA:    pem.RootCertificate(cm.ca.GetCertificateChain()),
-		TrustedCAs:   pem.RootCertificate(cm.ca.GetCertificateChain()),
+		CertChain:    certChain,
+		IssuingCA:    cm.ca.GetIssuingCA(),
+		TrustedCAs:   cm.ca.GetTrustedCAs(),
 		Expiration:   template.NotAfter,
 	}
 
@@ -129,3 +174,46 @@ func (cm *CertManager) IssueCertificate(opts certificate.IssueOptions) (*certifi
 
 	return cert, nil
 }
+
+// IssueRevocationList returns a certificate revocation list, signed by the CA, listing the certificates with the
+// given serial numbers as revoked. Serial numbers that were not issued by Tresor are ignored.
+func (cm *CertManager) IssueRevocationList(serialNumbers []certificate.SerialNumber, validityDuration time.Duration) (pem.RevocationList, error) {
+	if cm.ca == nil {
+		return nil, errNoIssuingCA
+	}
+
+	x509CA, err := certificate.DecodePEMCertificate(cm.ca.GetCertificateChain())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCreateRevocationList.Error(), err)
+	}
+
+	keyCA, err := certificate.DecodePEMPrivateKey(cm.ca.GetPrivateKey())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCreateRevocationList.Error(), err)
+	}
+
+	now := time.Now()
+	template := x509.RevocationList{
+		Number:     big.NewInt(now.UnixNano()),
+		ThisUpdate: now,
+		NextUpdate: now.Add(validityDuration),
+	}
+	for _, serialNumber := range serialNumbers {
+		// Tresor serial numbers are decimal
+		serial, ok := new(big.Int).SetString(serialNumber.String(), 10)
+		if !ok {
+			continue
+		}
+		template.RevokedCertificates = append(template.RevokedCertificates, pkix.RevokedCertificate{
+			SerialNumber:   serial,
+			RevocationTime: now,
+		})
+	}
+
+	derBytes, err := x509.CreateRevocationList(rand.Reader, &template, x509CA, keyCA)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errCreateRevocationList.Error(), err)
+	}
+
+	return certificate.EncodeRevocationListDERtoPEM(derBytes)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
@@ -22,7 +23,7 @@ func BenchmarkIssueCertificate(b *testing.B) {
 	rootCertLocality := "CA"
 	rootCertOrganization := testCertOrgName
 
-	rootCert, err := NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization)
+	rootCert, err := NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization, v1alpha2.KeyAlgorithmRSA)
 	if err != nil {
 		b.Fatalf("Error loading CA from files %s and %s: %s", rootCertPem, rootKeyPem, err.Error())
 	}
@@ -1,11 +1,17 @@
 package tresor
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
@@ -26,7 +32,7 @@ var _ = Describe("Test Certificate Manager", func() {
 		rootCertLocality := "CA"
 		rootCertOrganization := testCertOrgName
 
-		rootCert, err := NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization)
+		rootCert, err := NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization, v1alpha2.KeyAlgorithmRSA)
 		if err != nil {
 			GinkgoT().Fatalf("Error loading CA from files %s and %s: %s", rootCertPem, rootKeyPem, err.Error())
 		}
@@ -60,6 +66,197 @@ var _ = Describe("Test Certificate Manager", func() {
 		})
 	})
 
+	Context("Test issuing an ECDSA certificate from an ECDSA CA", func() {
+		rootCert, err := NewCA("Test CA", 1*time.Hour, "US", "CA", testCertOrgName, v1alpha2.KeyAlgorithmECDSAP384)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating CA: %s", err.Error())
+		}
+		m, newCertError := New(
+			rootCert,
+			"org",
+			2048,
+		)
+		It("should issue a certificate with an ECDSA key", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			opts := certificate.NewCertOptionsWithFullName(serviceFQDN, 1*time.Hour)
+			opts.KeyAlgorithm = v1alpha2.KeyAlgorithmECDSAP256
+			cert, issueCertificateError := m.IssueCertificate(opts)
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+
+			key, err := certificate.DecodePEMPrivateKey(cert.GetPrivateKey())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificate.GetKeyAlgorithm(key)).To(Equal(v1alpha2.KeyAlgorithmECDSAP256))
+
+			xCert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(xCert.PublicKeyAlgorithm).To(Equal(x509.ECDSA))
+			Expect(xCert.SignatureAlgorithm).To(Equal(x509.ECDSAWithSHA384))
+			Expect(xCert.KeyUsage).To(Equal(x509.KeyUsageDigitalSignature))
+
+			xRootCert, err := certificate.DecodePEMCertificate(cert.GetIssuingCA())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(xCert.CheckSignatureFrom(xRootCert)).To(Succeed())
+		})
+	})
+
+	Context("Test signing a certificate request", func() {
+		rootCert, err := NewCA("Test CA", 1*time.Hour, "US", "CA", testCertOrgName, v1alpha2.KeyAlgorithmRSA)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating CA: %s", err.Error())
+		}
+		m, newCertError := New(
+			rootCert,
+			"org",
+			2048,
+		)
+		It("should sign a certificate for the public key of the request", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			key, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+			Expect(err).ToNot(HaveOccurred())
+			// The names of the request are ignored, the certificate's names being set from the options
+			der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "other"}}, key)
+			Expect(err).ToNot(HaveOccurred())
+			csr, err := x509.ParseCertificateRequest(der)
+			Expect(err).ToNot(HaveOccurred())
+
+			cert, err := m.SignCertificateRequest(csr, certificate.NewCertOptionsWithFullName(serviceFQDN, time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cert.GetPrivateKey()).To(BeEmpty())
+			Expect(cert.GetCommonName()).To(Equal(certificate.CommonName(serviceFQDN)))
+
+			xCert, err := certificate.DecodePEMCertificate(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(xCert.Subject.CommonName).To(Equal(serviceFQDN))
+			Expect(xCert.PublicKey).To(Equal(key.Public()))
+			Expect(xCert.KeyUsage).To(Equal(x509.KeyUsageDigitalSignature))
+
+			xRootCert, err := certificate.DecodePEMCertificate(cert.GetIssuingCA())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(xCert.CheckSignatureFrom(xRootCert)).To(Succeed())
+		})
+	})
+
+	Context("Test issuing a certificate from an intermediate CA", func() {
+		rootCA, err := NewCA("Test Root CA", 1*time.Hour, "US", "CA", testCertOrgName, v1alpha2.KeyAlgorithmECDSAP256)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating CA: %s", err.Error())
+		}
+		x509Root, err := certificate.DecodePEMCertificate(rootCA.GetCertificateChain())
+		if err != nil {
+			GinkgoT().Fatalf("Error decoding CA: %s", err.Error())
+		}
+		rootKey, err := certificate.DecodePEMPrivateKey(rootCA.GetPrivateKey())
+		if err != nil {
+			GinkgoT().Fatalf("Error decoding CA key: %s", err.Error())
+		}
+
+		intermediateKey, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+		if err != nil {
+			GinkgoT().Fatalf("Error generating intermediate CA key: %s", err.Error())
+		}
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(2),
+			Subject:               pkix.Name{CommonName: "Test Intermediate CA"},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, x509Root, intermediateKey.Public(), rootKey)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating intermediate CA: %s", err.Error())
+		}
+		intermediatePEM, err := certificate.EncodeCertDERtoPEM(der)
+		if err != nil {
+			GinkgoT().Fatalf("Error encoding intermediate CA: %s", err.Error())
+		}
+		intermediateKeyPEM, err := certificate.EncodeKeyDERtoPEM(intermediateKey)
+		if err != nil {
+			GinkgoT().Fatalf("Error encoding intermediate CA key: %s", err.Error())
+		}
+		intermediateCA, err := certificate.NewIntermediateCAFromPEM(intermediatePEM, intermediateKeyPEM, rootCA.GetIssuingCA())
+		if err != nil {
+			GinkgoT().Fatalf("Error loading intermediate CA: %s", err.Error())
+		}
+
+		m, newCertError := New(
+			intermediateCA,
+			"org",
+			2048,
+		)
+		It("should issue a certificate with the chain of the intermediate CA", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			cert, issueCertificateError := m.IssueCertificate(certificate.NewCertOptionsWithFullName(serviceFQDN, time.Hour))
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+
+			chain, err := certificate.DecodePEMCertificates(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chain).To(HaveLen(2))
+			Expect(chain[0].Subject.CommonName).To(Equal(serviceFQDN))
+			Expect(chain[1].Subject.CommonName).To(Equal("Test Intermediate CA"))
+
+			// Only the root is trusted
+			Expect(cert.GetTrustedCAs()).To(Equal(rootCA.GetIssuingCA()))
+			Expect(cert.GetIssuingCA()).To(Equal(rootCA.GetIssuingCA()))
+
+			roots := x509.NewCertPool()
+			roots.AddCert(x509Root)
+			intermediates := x509.NewCertPool()
+			intermediates.AddCert(chain[1])
+			_, err = chain[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not issue a certificate outliving the intermediate CA", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			cert, issueCertificateError := m.IssueCertificate(certificate.NewCertOptionsWithFullName(serviceFQDN, 2*time.Hour))
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+
+			chain, err := certificate.DecodePEMCertificates(cert.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chain[0].NotAfter).To(Equal(chain[1].NotAfter))
+			Expect(cert.GetExpiration()).To(Equal(chain[1].NotAfter))
+		})
+	})
+
+	Context("Test issuing a revocation list", func() {
+		rootCertCountry := "US"
+		rootCertLocality := "CA"
+		ca, err := NewCA("Test CA", 1*time.Hour, rootCertCountry, rootCertLocality, testCertOrgName, v1alpha2.KeyAlgorithmRSA)
+		if err != nil {
+			GinkgoT().Fatalf("Error creating CA: %s", err.Error())
+		}
+		m, newCertError := New(
+			ca,
+			"org",
+			2048,
+		)
+		It("should issue a revocation list signed by the CA", func() {
+			Expect(newCertError).ToNot(HaveOccurred())
+			cert, issueCertificateError := m.IssueCertificate(certificate.NewCertOptionsWithFullName(serviceFQDN, time.Hour))
+			Expect(issueCertificateError).ToNot(HaveOccurred())
+
+			revocationListPEM, err := m.IssueRevocationList([]certificate.SerialNumber{cert.GetSerialNumber(), "aa:bb:cc"}, time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+
+			block, _ := pem.Decode(revocationListPEM)
+			Expect(block).ToNot(BeNil())
+			Expect(block.Type).To(Equal(certificate.TypeRevocationList))
+			revocationList, err := x509.ParseRevocationList(block.Bytes)
+			Expect(err).ToNot(HaveOccurred())
+
+			x509CA, err := certificate.DecodePEMCertificate(ca.GetCertificateChain())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(revocationList.CheckSignatureFrom(x509CA)).To(Succeed())
+			Expect(revocationList.NextUpdate).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+
+			// Only the serial numbers issued by Tresor are listed
+			Expect(revocationList.RevokedCertificates).To(HaveLen(1))
+			Expect(revocationList.RevokedCertificates[0].SerialNumber.String()).To(Equal(cert.GetSerialNumber().String()))
+		})
+	})
+
 	Context("Test nil certificate issue", func() {
 		m, newCertError := New(
 			nil,
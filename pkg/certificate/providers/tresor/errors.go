@@ -5,6 +5,7 @@ import (
 )
 
 var errCreateCert = errors.New("create cert")
+var errCreateRevocationList = errors.New("create revocation list")
 var errGeneratingSerialNumber = errors.New("generate serial number")
 var errGeneratingPrivateKey = errors.New("generate private")
 var errNoIssuingCA = errors.New("no issuing CA")
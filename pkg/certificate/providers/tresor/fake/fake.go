@@ -21,6 +21,8 @@ const (
 	initialRootName      = "osm-mesh-root-certificate"
 )
 
+var getKeyAlgorithm = func() v1alpha2.KeyAlgorithm { return v1alpha2.KeyAlgorithmRSA }
+
 type fakeMRCClient struct {
 	mrcChannel chan certificate.MRCEvent
 }
@@ -102,7 +104,7 @@ func (c *fakeMRCClient) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (
 	rootCertLocality := "CA"
 	cn := certificate.CommonName(mrc.Name)
 
-	ca, err := tresor.NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization)
+	ca, err := tresor.NewCA(cn, 1*time.Hour, rootCertCountry, rootCertLocality, rootCertOrganization, v1alpha2.KeyAlgorithmRSA)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -111,7 +113,7 @@ func (c *fakeMRCClient) GetCertIssuerForMRC(mrc *v1alpha2.MeshRootCertificate) (
 		return nil, nil, err
 	}
 
-	cert, err := issuer.IssueCertificate("rootCA", 24*time.Hour)
+	cert, err := issuer.IssueCertificate(certificate.NewCertOptionsWithFullName("rootCA", 24*time.Hour))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -142,7 +144,7 @@ func NewFake(checkInterval time.Duration) *certificate.Manager {
 
 // NewFakeWithValidityDuration constructs a fake certificate manager with specified cert validity duration
 func NewFakeWithValidityDuration(getCertValidityDuration func() time.Duration, checkInterval time.Duration) *certificate.Manager {
-	tresorCertManager, err := certificate.NewManager(context.Background(), NewFakeMRC(), getCertValidityDuration, getCertValidityDuration, checkInterval)
+	tresorCertManager, err := certificate.NewManager(context.Background(), NewFakeMRC(), getCertValidityDuration, getCertValidityDuration, getKeyAlgorithm, checkInterval)
 	if err != nil {
 		log.Error().Err(err).Msg("error encountered creating fake cert manager")
 		return nil
@@ -153,7 +155,7 @@ func NewFakeWithValidityDuration(getCertValidityDuration func() time.Duration, c
 // NewFakeWithMRC constructs a fake certificate manager with specified cert validity duration and fake MRC client
 func NewFakeWithMRC(fakeMRCClient *fakeMRCClient, checkInterval time.Duration) *certificate.Manager {
 	getValidityDuration := func() time.Duration { return 1 * time.Hour }
-	tresorCertManager, err := certificate.NewManager(context.Background(), fakeMRCClient, getValidityDuration, getValidityDuration, checkInterval)
+	tresorCertManager, err := certificate.NewManager(context.Background(), fakeMRCClient, getValidityDuration, getValidityDuration, getKeyAlgorithm, checkInterval)
 	if err != nil {
 		log.Error().Err(err).Msg("error encountered creating fake cert manager")
 		return nil
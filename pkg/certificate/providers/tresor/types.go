@@ -5,11 +5,12 @@ import (
 	"math/big"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
 const (
-	// How many bits to use for the RSA key
+	// How many bits to use for the RSA key of the CA
 	rsaBits = 2048
 
 	// How many bits in the certificate serial number
@@ -26,7 +27,10 @@ var (
 // CertManager implements certificate.Manager
 type CertManager struct {
 	// The Certificate Authority root certificate to be used by this certificate manager
-	ca                       *certificate.Certificate
+	ca *certificate.Certificate
+	// The certificate chain of an intermediate CA up to the root, appended to the certificates issued.
+	// Empty when the CA is a root.
+	caChain                  pem.Certificate
 	certificatesOrganization string
 	keySize                  int
 }
@@ -37,6 +37,18 @@ var (
 	ValidCertificateProviders = []Kind{TresorKind, VaultKind, CertManagerKind}
 )
 
+const (
+	// VaultAuthMethodToken authenticates to Hashi Vault with a static token
+	VaultAuthMethodToken = "token"
+
+	// VaultAuthMethodKubernetes authenticates to Hashi Vault with the Kubernetes auth method, using a projected
+	// service account token
+	VaultAuthMethodKubernetes = "kubernetes"
+
+	// VaultAuthMethodAppRole authenticates to Hashi Vault with the AppRole auth method
+	VaultAuthMethodAppRole = "approle"
+)
+
 // Options is an interface that contains required fields to convert the old style options to the new style MRC for
 // each provider type.
 // TODO(#4502): Remove this interface, and all of the options below.
@@ -48,8 +60,12 @@ type Options interface {
 
 // TresorOptions is a type that specifies 'Tresor' certificate provider options
 type TresorOptions struct {
-	// No options at the moment
+	// SecretName is the name of the secret storing the root certificate generated by Tresor
 	SecretName string
+
+	// IntermediateSecretName is the name of the secret storing an intermediate CA signed by an offline root.
+	// When set, certificates are issued by the intermediate CA.
+	IntermediateSecretName string
 }
 
 // VaultOptions is a type that specifies 'Hashicorp Vault' certificate provider options
@@ -62,6 +78,21 @@ type VaultOptions struct {
 	VaultTokenSecretNamespace string
 	VaultTokenSecretName      string
 	VaultTokenSecretKey       string
+
+	// VaultAuthMethod is the method used to authenticate to Vault, one of token, kubernetes or approle
+	VaultAuthMethod string
+	// VaultAuthMountPath is the path the kubernetes or approle auth method is mounted at
+	VaultAuthMountPath string
+	// VaultKubernetesAuthRole is the Vault role bound to the service account, for the kubernetes auth method
+	VaultKubernetesAuthRole string
+	// VaultKubernetesAuthTokenPath is the path of the projected service account token, for the kubernetes auth method
+	VaultKubernetesAuthTokenPath string
+	// VaultAppRoleID is the role ID, for the approle auth method
+	VaultAppRoleID string
+	// VaultAppRoleSecretIDSecretName is the name of the secret storing the secret ID, for the approle auth method
+	VaultAppRoleSecretIDSecretName string
+	// VaultAppRoleSecretIDSecretKey is the key of the secret ID in its secret, for the approle auth method
+	VaultAppRoleSecretIDSecretKey string
 }
 
 // CertManagerOptions is a type that specifies 'cert-manager.io' certificate provider options
@@ -86,8 +117,13 @@ type MRCProviderGenerator struct {
 
 	// TODO(#4711): move these to the compat client once we have added these fields to the MRC.
 	KeyBitSize int
+	// KeyAlgorithm is the algorithm used to generate the private key of a new Tresor root certificate
+	KeyAlgorithm v1alpha2.KeyAlgorithm
 
 	// TODO(#4745): Remove after deprecating the osm.vault.token option.
 	DefaultVaultToken string
 	caExtractorFunc   func(certificate.Issuer) (pem.RootCertificate, error)
+
+	// tresorCA is the CA Tresor issues certificates with when it is not stored in a Kubernetes Secret
+	tresorCA *certificate.Certificate
 }
@@ -0,0 +1,185 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	// DefaultKubernetesAuthMountPath is the default path the Kubernetes auth method is mounted at
+	DefaultKubernetesAuthMountPath = "kubernetes"
+
+	// DefaultKubernetesAuthTokenPath is the default path of the projected service account token used to log in
+	// with the Kubernetes auth method
+	DefaultKubernetesAuthTokenPath = "/var/run/secrets/tokens/vault-token"
+
+	// DefaultAppRoleAuthMountPath is the default path the AppRole auth method is mounted at
+	DefaultAppRoleAuthMountPath = "approle"
+)
+
+var (
+	errNoClientToken = errors.New("vault login response does not contain a client token")
+
+	// now returns the current time, replaced in tests
+	now = time.Now
+)
+
+// Auth logs in to Vault to obtain the client tokens used by the CertManager.
+type Auth interface {
+	// Login logs in to Vault with the given client, and returns the secret holding the client token
+	Login(client *api.Client) (*api.Secret, error)
+}
+
+// KubernetesAuth logs in to Vault with the Kubernetes auth method, using a projected service account token.
+// The token is read at every login, since the kubelet rotates projected tokens.
+type KubernetesAuth struct {
+	// Role is the name of the Vault role bound to the service account
+	Role string
+
+	// MountPath is the path the Kubernetes auth method is mounted at
+	MountPath string
+
+	// TokenPath is the path of the projected service account token
+	TokenPath string
+}
+
+// Login logs in to Vault with the Kubernetes auth method
+func (a KubernetesAuth) Login(client *api.Client) (*api.Secret, error) {
+	tokenPath := a.TokenPath
+	if tokenPath == "" {
+		tokenPath = DefaultKubernetesAuthTokenPath
+	}
+	jwt, err := os.ReadFile(tokenPath) // #nosec G304: the token path is configured by the mesh operator
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account token %s: %w", tokenPath, err)
+	}
+
+	return client.Logical().Write(getLoginURL(a.MountPath, DefaultKubernetesAuthMountPath), map[string]interface{}{
+		"role": a.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+}
+
+// AppRoleAuth logs in to Vault with the AppRole auth method.
+type AppRoleAuth struct {
+	// RoleID is the role ID of the AppRole
+	RoleID string
+
+	// GetSecretID returns the secret ID of the AppRole. It is called at every login, so that a rotated secret ID
+	// is used once the previous one is no longer valid.
+	GetSecretID func() (string, error)
+
+	// MountPath is the path the AppRole auth method is mounted at
+	MountPath string
+}
+
+// Login logs in to Vault with the AppRole auth method
+func (a AppRoleAuth) Login(client *api.Client) (*api.Secret, error) {
+	secretID, err := a.GetSecretID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting AppRole secret ID: %w", err)
+	}
+
+	return client.Logical().Write(getLoginURL(a.MountPath, DefaultAppRoleAuthMountPath), map[string]interface{}{
+		"role_id":   a.RoleID,
+		"secret_id": secretID,
+	})
+}
+
+func getLoginURL(mountPath, defaultMountPath string) string {
+	if mountPath == "" {
+		mountPath = defaultMountPath
+	}
+	return fmt.Sprintf("auth/%s/login", strings.Trim(mountPath, "/"))
+}
+
+// ensureToken renews the client token once two thirds of its TTL elapsed, and logs in again once it can't be
+// renewed anymore. It is a no-op when the CertManager uses a static token.
+func (cm *CertManager) ensureToken() error {
+	if cm.auth == nil {
+		return nil
+	}
+
+	cm.tokenMu.Lock()
+	defer cm.tokenMu.Unlock()
+
+	t := now()
+	if cm.tokenRenewAt.IsZero() || t.Before(cm.tokenRenewAt) {
+		return nil
+	}
+
+	if cm.tokenRenewable && t.Before(cm.tokenExpiration) {
+		secret, err := cm.client.Auth().Token().RenewSelf(0)
+		if err == nil {
+			err = cm.setToken(secret)
+		}
+		if err == nil {
+			log.Debug().Msgf("Renewed Vault token, expires on %s", cm.tokenExpiration)
+			return nil
+		}
+		log.Warn().Err(err).Msg("Error renewing Vault token, logging in again")
+	}
+
+	return cm.login()
+}
+
+// relogin logs in again, such as when the client token was revoked
+func (cm *CertManager) relogin() error {
+	cm.tokenMu.Lock()
+	defer cm.tokenMu.Unlock()
+	return cm.login()
+}
+
+// login logs in to obtain a new client token. The caller must hold tokenMu.
+func (cm *CertManager) login() error {
+	// The previous token may have expired, and is not needed to log in
+	cm.client.ClearToken()
+
+	secret, err := cm.auth.Login(cm.client)
+	if err != nil {
+		return fmt.Errorf("error logging in to Vault: %w", err)
+	}
+	if err := cm.setToken(secret); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Logged in to Vault, token expires on %s", cm.tokenExpiration)
+	return nil
+}
+
+// setToken uses the client token of the given secret, and schedules its renewal. The caller must hold tokenMu.
+func (cm *CertManager) setToken(secret *api.Secret) error {
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return errNoClientToken
+	}
+
+	cm.client.SetToken(secret.Auth.ClientToken)
+
+	// A token without TTL doesn't expire, and is never renewed
+	ttl := time.Duration(secret.Auth.LeaseDuration) * time.Second
+	if ttl == 0 {
+		cm.tokenExpiration = time.Time{}
+		cm.tokenRenewAt = time.Time{}
+		cm.tokenRenewable = false
+		return nil
+	}
+
+	t := now()
+	cm.tokenExpiration = t.Add(ttl)
+	cm.tokenRenewAt = t.Add(ttl * 2 / 3)
+	cm.tokenRenewable = secret.Auth.Renewable
+	return nil
+}
+
+// isPermissionDenied returns whether the given error is Vault denying a request, such as when the client token
+// was revoked
+func isPermissionDenied(err error) bool {
+	var respErr *api.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,420 @@
+package vault
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+)
+
+// fakeVault is an httptest stand-in for the Vault API, implementing the Kubernetes and AppRole auth methods,
+// token renewal, certificate issuance and the signing of certificate requests.
+type fakeVault struct {
+	mu sync.Mutex
+
+	// the TTL and renewability of the client tokens issued
+	ttl       int
+	renewable bool
+
+	// valid client tokens
+	tokens map[string]bool
+
+	logins    int
+	renewals  int
+	issuances int
+
+	// the certificate request signed last
+	csr string
+}
+
+func newFakeVault(t *testing.T, ttl int, renewable bool) (*fakeVault, *httptest.Server) {
+	t.Helper()
+	v := &fakeVault{
+		ttl:       ttl,
+		renewable: renewable,
+		tokens:    map[string]bool{},
+	}
+	srv := httptest.NewServer(v)
+	t.Cleanup(srv.Close)
+	return v, srv
+}
+
+func (v *fakeVault) revokeAll() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens = map[string]bool{}
+}
+
+func (v *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	body := map[string]string{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	switch {
+	case r.URL.Path == "/v1/auth/kubernetes/login" || r.URL.Path == "/v1/auth/k8s/login":
+		if body["role"] != "osm" || body["jwt"] != "sa-token" {
+			writeVaultError(w, http.StatusForbidden)
+			return
+		}
+		v.login(w)
+	case r.URL.Path == "/v1/auth/approle/login":
+		if body["role_id"] != "role-id" || body["secret_id"] != "secret-id" {
+			writeVaultError(w, http.StatusBadRequest)
+			return
+		}
+		v.login(w)
+	case r.URL.Path == "/v1/auth/token/renew-self":
+		token := r.Header.Get("X-Vault-Token")
+		if !v.tokens[token] {
+			writeVaultError(w, http.StatusForbidden)
+			return
+		}
+		v.renewals++
+		writeVaultAuth(w, token, v.ttl, v.renewable)
+	case strings.HasPrefix(r.URL.Path, "/v1/pki/issue/"):
+		if !v.tokens[r.Header.Get("X-Vault-Token")] {
+			writeVaultError(w, http.StatusForbidden)
+			return
+		}
+		v.issuances++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				certificateField:  "cert",
+				privateKeyField:   "key",
+				issuingCAField:    "ca",
+				serialNumberField: fmt.Sprintf("%d", v.issuances),
+			},
+		})
+	case strings.HasPrefix(r.URL.Path, "/v1/pki/sign/"):
+		if !v.tokens[r.Header.Get("X-Vault-Token")] {
+			writeVaultError(w, http.StatusForbidden)
+			return
+		}
+		v.issuances++
+		v.csr = body[csrField]
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				certificateField:  "cert",
+				issuingCAField:    "ca",
+				serialNumberField: fmt.Sprintf("%d", v.issuances),
+			},
+		})
+	default:
+		writeVaultError(w, http.StatusNotFound)
+	}
+}
+
+func (v *fakeVault) login(w http.ResponseWriter) {
+	v.logins++
+	token := fmt.Sprintf("token-%d", v.logins)
+	v.tokens[token] = true
+	writeVaultAuth(w, token, v.ttl, v.renewable)
+}
+
+func writeVaultAuth(w http.ResponseWriter, token string, ttl int, renewable bool) {
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"auth": map[string]interface{}{
+			"client_token":   token,
+			"lease_duration": ttl,
+			"renewable":      renewable,
+		},
+	})
+}
+
+func writeVaultError(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": []string{http.StatusText(status)}})
+}
+
+func writeTokenFile(t *testing.T, token string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vault-token")
+	trequire.NoError(t, os.WriteFile(path, []byte(token+"\n"), 0600))
+	return path
+}
+
+// setNow replaces the clock used to schedule token renewals for the duration of the test
+func setNow(t *testing.T, start time.Time) *time.Time {
+	t.Helper()
+	current := start
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+	return &current
+}
+
+func TestNewWithAuth(t *testing.T) {
+	testCases := []struct {
+		name        string
+		auth        func(t *testing.T) Auth
+		expectedErr bool
+	}{
+		{
+			name: "kubernetes auth",
+			auth: func(t *testing.T) Auth {
+				return KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "sa-token")}
+			},
+		},
+		{
+			name: "kubernetes auth at a custom mount path",
+			auth: func(t *testing.T) Auth {
+				return KubernetesAuth{Role: "osm", MountPath: "/k8s/", TokenPath: writeTokenFile(t, "sa-token")}
+			},
+		},
+		{
+			name: "kubernetes auth with a service account token rejected by Vault",
+			auth: func(t *testing.T) Auth {
+				return KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "other-token")}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "kubernetes auth without service account token",
+			auth: func(t *testing.T) Auth {
+				return KubernetesAuth{Role: "osm", TokenPath: filepath.Join(t.TempDir(), "missing")}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "approle auth",
+			auth: func(t *testing.T) Auth {
+				return AppRoleAuth{RoleID: "role-id", GetSecretID: func() (string, error) { return "secret-id", nil }}
+			},
+		},
+		{
+			name: "approle auth with an invalid secret ID",
+			auth: func(t *testing.T) Auth {
+				return AppRoleAuth{RoleID: "role-id", GetSecretID: func() (string, error) { return "other", nil }}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "approle auth failing to get the secret ID",
+			auth: func(t *testing.T) Auth {
+				return AppRoleAuth{RoleID: "role-id", GetSecretID: func() (string, error) { return "", errors.New("not found") }}
+			},
+			expectedErr: true,
+		},
+		{
+			name: "no auth method",
+			auth: func(t *testing.T) Auth {
+				return nil
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			v, srv := newFakeVault(t, 3600, true)
+
+			cm, err := NewWithAuth(srv.URL, tc.auth(t), "osm-role", 2048)
+			if tc.expectedErr {
+				assert.Error(err)
+				return
+			}
+			trequire.NoError(t, err)
+			assert.Equal(1, v.logins)
+			assert.Equal("token-1", cm.client.Token())
+
+			cert, err := cm.IssueCertificate(certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour))
+			assert.NoError(err)
+			assert.Equal(certificate.CommonName("foo.bar.cluster.local"), cert.GetCommonName())
+		})
+	}
+}
+
+func TestEnsureToken(t *testing.T) {
+	assert := tassert.New(t)
+	current := setNow(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	v, srv := newFakeVault(t, 30, true)
+	opts := certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour)
+
+	cm, err := NewWithAuth(srv.URL, KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "sa-token")}, "osm-role", 2048)
+	trequire.NoError(t, err)
+	assert.Equal(current.Add(30*time.Second), cm.tokenExpiration)
+	assert.Equal(current.Add(20*time.Second), cm.tokenRenewAt)
+
+	// The token is not renewed before two thirds of its TTL elapsed
+	*current = current.Add(19 * time.Second)
+	_, err = cm.IssueCertificate(opts)
+	assert.NoError(err)
+	assert.Equal(0, v.renewals)
+	assert.Equal(1, v.logins)
+
+	// The token is renewed once two thirds of its TTL elapsed
+	*current = current.Add(2 * time.Second)
+	_, err = cm.IssueCertificate(opts)
+	assert.NoError(err)
+	assert.Equal(1, v.renewals)
+	assert.Equal(1, v.logins)
+	assert.Equal("token-1", cm.client.Token())
+	assert.Equal(current.Add(30*time.Second), cm.tokenExpiration)
+
+	// The token is not renewed once it expired, a new token is obtained by logging in again
+	*current = current.Add(time.Minute)
+	_, err = cm.IssueCertificate(opts)
+	assert.NoError(err)
+	assert.Equal(1, v.renewals)
+	assert.Equal(2, v.logins)
+	assert.Equal("token-2", cm.client.Token())
+
+	// The token can't be renewed once revoked, a new token is obtained by logging in again
+	v.revokeAll()
+	*current = current.Add(25 * time.Second)
+	_, err = cm.IssueCertificate(opts)
+	assert.NoError(err)
+	assert.Equal(1, v.renewals)
+	assert.Equal(3, v.logins)
+	assert.Equal("token-3", cm.client.Token())
+}
+
+func TestEnsureTokenNotRenewable(t *testing.T) {
+	assert := tassert.New(t)
+	current := setNow(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	v, srv := newFakeVault(t, 30, false)
+
+	cm, err := NewWithAuth(srv.URL, AppRoleAuth{RoleID: "role-id", GetSecretID: func() (string, error) { return "secret-id", nil }}, "osm-role", 2048)
+	trequire.NoError(t, err)
+
+	*current = current.Add(20 * time.Second)
+	_, err = cm.IssueCertificate(certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour))
+	assert.NoError(err)
+	assert.Equal(0, v.renewals)
+	assert.Equal(2, v.logins)
+	assert.Equal("token-2", cm.client.Token())
+}
+
+func TestEnsureTokenWithoutTTL(t *testing.T) {
+	assert := tassert.New(t)
+	current := setNow(t, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	v, srv := newFakeVault(t, 0, true)
+
+	cm, err := NewWithAuth(srv.URL, AppRoleAuth{RoleID: "role-id", GetSecretID: func() (string, error) { return "secret-id", nil }}, "osm-role", 2048)
+	trequire.NoError(t, err)
+	assert.True(cm.tokenRenewAt.IsZero())
+
+	*current = current.Add(365 * 24 * time.Hour)
+	_, err = cm.IssueCertificate(certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour))
+	assert.NoError(err)
+	assert.Equal(0, v.renewals)
+	assert.Equal(1, v.logins)
+}
+
+func TestIssueCertificateRevokedToken(t *testing.T) {
+	assert := tassert.New(t)
+	v, srv := newFakeVault(t, 3600, true)
+	opts := certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour)
+
+	cm, err := NewWithAuth(srv.URL, KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "sa-token")}, "osm-role", 2048)
+	trequire.NoError(t, err)
+
+	// Vault denies the request with the revoked token, and the certificate is issued after logging in again
+	v.revokeAll()
+	cert, err := cm.IssueCertificate(opts)
+	assert.NoError(err)
+	assert.NotNil(cert)
+	assert.Equal(2, v.logins)
+	assert.Equal(1, v.issuances)
+
+	// A static token is not replaced
+	static, err := New(srv.URL, "static", "osm-role", 2048)
+	trequire.NoError(t, err)
+	_, err = static.IssueCertificate(opts)
+	assert.Error(err)
+	assert.Equal(2, v.logins)
+}
+
+func TestSignCertificateRequest(t *testing.T) {
+	assert := tassert.New(t)
+	v, srv := newFakeVault(t, 3600, true)
+	opts := certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour)
+
+	key, err := certificate.GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+	trequire.NoError(t, err)
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "foo.bar.cluster.local"}}, key)
+	trequire.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	trequire.NoError(t, err)
+	csrPEM, err := certificate.EncodeCertReqDERtoPEM(der)
+	trequire.NoError(t, err)
+
+	cm, err := NewWithAuth(srv.URL, KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "sa-token")}, "osm-role", 2048)
+	trequire.NoError(t, err)
+
+	// The request is signed after logging in again with a revoked token, and the certificate has no private key
+	v.revokeAll()
+	cert, err := cm.SignCertificateRequest(csr, opts)
+	trequire.NoError(t, err)
+	assert.Equal(string(csrPEM), v.csr)
+	assert.Equal(certificate.CommonName("foo.bar.cluster.local"), cert.GetCommonName())
+	assert.Equal(pem.Certificate("cert"), cert.GetCertificateChain())
+	assert.Empty(cert.GetPrivateKey())
+	assert.Equal(2, v.logins)
+	assert.Equal(1, v.issuances)
+}
+
+func TestIssueCertificateKeyAlgorithm(t *testing.T) {
+	testCases := []struct {
+		keyAlgorithm v1alpha2.KeyAlgorithm
+		expectErr    bool
+	}{
+		{keyAlgorithm: v1alpha2.KeyAlgorithmRSA},
+		{keyAlgorithm: v1alpha2.KeyAlgorithmECDSAP256},
+		{keyAlgorithm: v1alpha2.KeyAlgorithmECDSAP384},
+		{keyAlgorithm: "ed25519", expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.keyAlgorithm), func(t *testing.T) {
+			assert := tassert.New(t)
+			v, srv := newFakeVault(t, 3600, true)
+			opts := certificate.NewCertOptionsWithFullName("foo.bar.cluster.local", time.Hour)
+			opts.KeyAlgorithm = tc.keyAlgorithm
+
+			cm, err := NewWithAuth(srv.URL, KubernetesAuth{Role: "osm", TokenPath: writeTokenFile(t, "sa-token")}, "osm-role", 2048)
+			trequire.NoError(t, err)
+
+			cert, err := cm.IssueCertificate(opts)
+			if tc.expectErr {
+				assert.Error(err)
+				assert.Equal(0, v.issuances)
+				return
+			}
+			trequire.NoError(t, err)
+
+			// The private key is generated locally, and Vault signs a certificate request for it
+			assert.Equal(1, v.issuances)
+			csr, err := certificate.DecodePEMCertificateRequest([]byte(v.csr))
+			trequire.NoError(t, err)
+			assert.Equal("foo.bar.cluster.local", csr.Subject.CommonName)
+			key, err := certificate.DecodePEMPrivateKey(cert.GetPrivateKey())
+			trequire.NoError(t, err)
+			assert.Equal(tc.keyAlgorithm, certificate.GetKeyAlgorithm(key))
+			assert.Equal(key.Public(), csr.PublicKey)
+			assert.Equal(pem.Certificate("cert"), cert.GetCertificateChain())
+		})
+	}
+}
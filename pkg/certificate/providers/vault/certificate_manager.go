@@ -1,6 +1,9 @@
 package vault
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"time"
 
@@ -23,21 +26,56 @@ const (
 	issuingCAField    = "issuing_ca"
 	commonNameField   = "common_name"
 	ttlField          = "ttl"
+	uriSANsField      = "uri_sans"
+	csrField          = "csr"
 )
 
-// New constructs a new certificate client using Vault's cert-manager
-func New(vaultAddr, token, role string) (*CertManager, error) {
-	if vaultAddr == "" {
-		return nil, fmt.Errorf("vault address must not be empty")
-	}
+// New constructs a new certificate client using Vault's cert-manager. The key size applies to the RSA private keys
+// generated for the certificates issued with a key algorithm.
+func New(vaultAddr, token, role string, keySize int) (*CertManager, error) {
 	if token == "" {
 		return nil, fmt.Errorf("vault token must not be empty")
 	}
+	c, err := newCertManager(vaultAddr, role, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	c.client.SetToken(token)
+
+	return c, nil
+}
+
+// NewWithAuth constructs a new certificate client using Vault's cert-manager, which logs in to Vault with the
+// given auth method. The client token is renewed as it is used, and obtained again by logging in once it can't
+// be renewed anymore or is revoked.
+func NewWithAuth(vaultAddr string, auth Auth, role string, keySize int) (*CertManager, error) {
+	if auth == nil {
+		return nil, fmt.Errorf("vault auth method must not be empty")
+	}
+	c, err := newCertManager(vaultAddr, role, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	c.auth = auth
+	if err := c.relogin(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func newCertManager(vaultAddr, role string, keySize int) (*CertManager, error) {
+	if vaultAddr == "" {
+		return nil, fmt.Errorf("vault address must not be empty")
+	}
 	if role == "" {
 		return nil, fmt.Errorf("vault role must not be empty")
 	}
 	c := &CertManager{
-		role: role,
+		role:    role,
+		keySize: keySize,
 	}
 	config := api.DefaultConfig()
 	config.Address = vaultAddr
@@ -48,14 +86,25 @@ func New(vaultAddr, token, role string) (*CertManager, error) {
 	}
 	log.Info().Msgf("Created Vault CertManager, with role=%q at %v", role, vaultAddr)
 
-	c.client.SetToken(token)
-
 	return c, nil
 }
 
-// IssueCertificate requests a new signed certificate from the configured Vault issuer.
+// IssueCertificate requests a new signed certificate from the configured Vault issuer. When a key algorithm is
+// requested, the private key is generated locally and Vault signs a certificate request for it, so the key type of the
+// Vault role must allow the algorithm. Otherwise Vault generates the private key with the key type of the role.
 func (cm *CertManager) IssueCertificate(options certificate.IssueOptions) (*certificate.Certificate, error) {
-	secret, err := cm.client.Logical().Write(getIssueURL(cm.role), getIssuanceData(options.CommonName(), options.ValidityDuration))
+	if options.KeyAlgorithm != "" {
+		return cm.issueCertificateForLocalKey(options)
+	}
+
+	if err := cm.ensureToken(); err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrIssuingCert)).
+			Msgf("Error obtaining a Vault token to issue a new certificate for CN=%s", options.CommonName())
+		return nil, err
+	}
+
+	data := getIssuanceData(options.CommonName(), options.SPIFFEID(), options.ValidityDuration)
+	secret, err := cm.write(getIssueURL(cm.role), data)
 	if err != nil {
 		// TODO(#3962): metric might not be scraped before process restart resulting from this error
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrIssuingCert)).
@@ -65,14 +114,91 @@ func (cm *CertManager) IssueCertificate(options certificate.IssueOptions) (*cert
 	return newCert(options.CommonName(), secret, time.Now().Add(options.ValidityDuration)), nil
 }
 
+// issueCertificateForLocalKey generates a private key with the requested key algorithm, and requests Vault to sign a
+// certificate for it.
+func (cm *CertManager) issueCertificateForLocalKey(options certificate.IssueOptions) (*certificate.Certificate, error) {
+	key, err := certificate.GeneratePrivateKey(options.KeyAlgorithm, cm.keySize)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrGeneratingPrivateKey)).
+			Msgf("Error generating private key for certificate with CN=%s", options.CommonName())
+		return nil, err
+	}
+
+	template := &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: options.CommonName().String()},
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating certificate request for CN=%s: %w", options.CommonName(), err)
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate request for CN=%s: %w", options.CommonName(), err)
+	}
+
+	cert, err := cm.SignCertificateRequest(csr, options)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.PrivateKey, err = certificate.EncodeKeyDERtoPEM(key)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrEncodingKeyDERtoPEM)).
+			Msgf("Error encoding private key for certificate with SerialNumber=%s", cert.GetSerialNumber())
+		return nil, err
+	}
+	return cert, nil
+}
+
+// SignCertificateRequest requests Vault to sign a certificate for the public key of the given certificate signing
+// request, whose private key is held by the requester. The Vault role must not use the names of the request,
+// see use_csr_common_name and use_csr_sans, so that the certificate's names are set from the given options.
+func (cm *CertManager) SignCertificateRequest(csr *x509.CertificateRequest, options certificate.IssueOptions) (*certificate.Certificate, error) {
+	if err := cm.ensureToken(); err != nil {
+		return nil, err
+	}
+
+	csrPEM, err := certificate.EncodeCertReqDERtoPEM(csr.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	data := getIssuanceData(options.CommonName(), options.SPIFFEID(), options.ValidityDuration)
+	data[csrField] = string(csrPEM)
+	secret, err := cm.write(getSignURL(cm.role), data)
+	if err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrIssuingCert)).
+			Msgf("Error signing certificate request for CN=%s", options.CommonName())
+		return nil, err
+	}
+	return newCert(options.CommonName(), secret, time.Now().Add(options.ValidityDuration)), nil
+}
+
+// write writes the given data to the given path, logging in again if Vault denies the request.
+func (cm *CertManager) write(path string, data map[string]interface{}) (*api.Secret, error) {
+	secret, err := cm.client.Logical().Write(path, data)
+	if err != nil && cm.auth != nil && isPermissionDenied(err) {
+		// The client token may have been revoked before it expired
+		log.Warn().Err(err).Msg("Vault denied the certificate request, logging in again")
+		if err = cm.relogin(); err == nil {
+			secret, err = cm.client.Logical().Write(path, data)
+		}
+	}
+	return secret, err
+}
+
 func newCert(cn certificate.CommonName, secret *api.Secret, expiration time.Time) *certificate.Certificate {
-	return &certificate.Certificate{
+	cert := &certificate.Certificate{
 		CommonName:   cn,
 		SerialNumber: certificate.SerialNumber(secret.Data[serialNumberField].(string)),
 		Expiration:   expiration,
 		CertChain:    pem.Certificate(secret.Data[certificateField].(string)),
-		PrivateKey:   []byte(secret.Data[privateKeyField].(string)),
 		IssuingCA:    pem.RootCertificate(secret.Data[issuingCAField].(string)),
 		TrustedCAs:   pem.RootCertificate(secret.Data[issuingCAField].(string)),
 	}
+	// Certificates signed for a certificate signing request are returned without private key
+	if privateKey, ok := secret.Data[privateKeyField].(string); ok {
+		cert.PrivateKey = []byte(privateKey)
+	}
+	return cert
 }
@@ -2,6 +2,7 @@ package vault
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
@@ -15,9 +16,18 @@ func getIssueURL(role string) string {
 	return fmt.Sprintf("pki/issue/%+v", role)
 }
 
-func getIssuanceData(cn certificate.CommonName, validityPeriod time.Duration) map[string]interface{} {
-	return map[string]interface{}{
+func getSignURL(role string) string {
+	return fmt.Sprintf("pki/sign/%+v", role)
+}
+
+func getIssuanceData(cn certificate.CommonName, spiffeID *url.URL, validityPeriod time.Duration) map[string]interface{} {
+	data := map[string]interface{}{
 		commonNameField: cn.String(),
 		ttlField:        getDurationInMinutes(validityPeriod),
 	}
+	// The Vault role must allow the spiffe URI SAN, see allowed_uri_sans
+	if spiffeID != nil {
+		data[uriSANsField] = spiffeID.String()
+	}
+	return data
 }
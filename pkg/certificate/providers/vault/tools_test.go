@@ -2,6 +2,7 @@ package vault
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -29,17 +30,35 @@ var _ = Describe("Test tools", func() {
 			expected := fmt.Sprintf("pki/issue/%s", role)
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("creates the URL for signing a certificate request", func() {
+			actual := getSignURL(role)
+			expected := fmt.Sprintf("pki/sign/%s", role)
+			Expect(actual).To(Equal(expected))
+		})
 	})
 
 	Context("Test cert issuance data for request", func() {
 		It("creates a map w/ correct fields", func() {
 			cn := certificate.CommonName("blah.foo.com")
-			actual := getIssuanceData(cn, 8123*time.Minute)
+			actual := getIssuanceData(cn, nil, 8123*time.Minute)
 			expected := map[string]interface{}{
 				"common_name": "blah.foo.com",
 				"ttl":         "135h",
 			}
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("includes the SPIFFE ID as a URI SAN", func() {
+			cn := certificate.CommonName("sa.ns.cluster.local")
+			spiffeID := &url.URL{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/ns/sa/sa"}
+			actual := getIssuanceData(cn, spiffeID, 8123*time.Minute)
+			expected := map[string]interface{}{
+				"common_name": "sa.ns.cluster.local",
+				"ttl":         "135h",
+				"uri_sans":    "spiffe://cluster.local/ns/ns/sa/sa",
+			}
+			Expect(actual).To(Equal(expected))
+		})
 	})
 })
@@ -2,6 +2,9 @@
 package vault
 
 import (
+	"sync"
+	"time"
+
 	"github.com/hashicorp/vault/api"
 )
 
@@ -12,4 +15,19 @@ type CertManager struct {
 
 	// The Vault role configured for OSM and passed as a CLI.
 	role string
+
+	// The size of the RSA private keys generated for the certificates issued with a key algorithm
+	keySize int
+
+	// auth logs in to Vault to obtain the client token, nil when a static token is used
+	auth Auth
+
+	// tokenMu synchronizes the renewal of the client token
+	tokenMu sync.Mutex
+	// the time the client token expires, zero if it doesn't expire
+	tokenExpiration time.Time
+	// the time the client token is renewed after, zero if it is never renewed
+	tokenRenewAt time.Time
+	// whether the client token can be renewed
+	tokenRenewable bool
 }
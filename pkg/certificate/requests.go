@@ -0,0 +1,175 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// SignCertificateRequest signs a certificate for the given certificate signing request with the signing issuer. The
+// private key of the certificate is held by the requester, and the request must be signed with it. The names of the
+// certificate are set from the given options, and the request must not request other names.
+// Certificates signed for requests are not cached nor rotated by the manager: the requesters request them again
+// before they expire, or once ShouldRenewCertificate returns true.
+func (m *Manager) SignCertificateRequest(csr *x509.CertificateRequest, opts ...IssueOption) (*Certificate, error) {
+	options := NewCertOptions(opts...)
+
+	m.mu.Lock()
+	validatingIssuer := m.validatingIssuer
+	signingIssuer := m.signingIssuer
+	m.mu.Unlock()
+
+	options.ValidityDuration = m.getValidityDurationForCertType(options.certType)
+	options.trustDomain = signingIssuer.TrustDomain
+
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCertificateRequest, err.Error())
+	}
+	if err := checkCertificateRequestNames(csr, options); err != nil {
+		return nil, err
+	}
+	keyAlgorithm := GetPublicKeyAlgorithm(csr.PublicKey)
+	if keyAlgorithm == "" {
+		return nil, fmt.Errorf("%w: %T public key", ErrUnsupportedKeyAlgorithm, csr.PublicKey)
+	}
+
+	signer, ok := signingIssuer.Issuer.(CertificateRequestSigner)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCertificateRequestNotSupported, signingIssuer.ID)
+	}
+
+	start := time.Now()
+	cert, err := signer.SignCertificateRequest(csr, options)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.issuedAt = start
+	cert.signingIssuerID = signingIssuer.ID
+	cert.validatingIssuerID = validatingIssuer.ID
+	cert.certType = options.certType
+	cert.keyAlgorithm = keyAlgorithm
+
+	log.Debug().Msgf("Signed certificate request for CN=%s with SerialNumber=%s", cert.GetCommonName(), cert.GetSerialNumber())
+	return cert, nil
+}
+
+// checkCertificateRequestNames returns an error if the certificate request requests other names than the ones of
+// the given options. Requests without names are signed with the names of the options.
+func checkCertificateRequestNames(csr *x509.CertificateRequest, options IssueOptions) error {
+	cn := options.CommonName().String()
+	if csr.Subject.CommonName != "" && csr.Subject.CommonName != cn {
+		return fmt.Errorf("%w: common name %s is not %s", ErrInvalidCertificateRequest, csr.Subject.CommonName, cn)
+	}
+	for _, name := range csr.DNSNames {
+		if name != cn {
+			return fmt.Errorf("%w: DNS name %s is not %s", ErrInvalidCertificateRequest, name, cn)
+		}
+	}
+	spiffeID := options.SPIFFEID()
+	for _, uri := range csr.URIs {
+		if spiffeID == nil || uri.String() != spiffeID.String() {
+			return fmt.Errorf("%w: URI %s is not the SPIFFE ID of %s", ErrInvalidCertificateRequest, uri, cn)
+		}
+	}
+	if len(csr.EmailAddresses) > 0 || len(csr.IPAddresses) > 0 {
+		return fmt.Errorf("%w: only the common name and SPIFFE ID of %s can be requested", ErrInvalidCertificateRequest, cn)
+	}
+	return nil
+}
+
+// ShouldRenewCertificate returns whether the holder of the given certificate chain, signed for a certificate
+// signing request, should request a new certificate: when the certificate is about to expire, is revoked, is not
+// signed by the signing issuer anymore, or its key algorithm differs from the configured one.
+func (m *Manager) ShouldRenewCertificate(chain pem.Certificate) (bool, error) {
+	certs, err := DecodePEMCertificates(chain)
+	if err != nil {
+		return false, err
+	}
+	leaf := certs[0]
+
+	if time.Until(leaf.NotAfter.Round(0)) <= RenewBeforeCertExpires {
+		log.Debug().Msgf("Cert %s should be renewed; expires at %s", leaf.Subject.CommonName, leaf.NotAfter)
+		return true, nil
+	}
+
+	m.mu.Lock()
+	signingIssuer := m.signingIssuer
+	revoked := m.isRevoked(SerialNumber(leaf.SerialNumber.String())) || m.isRevoked(SerialNumber(colonHex(leaf.SerialNumber.Bytes()))) ||
+		m.isIdentityRevoked(leaf)
+	m.mu.Unlock()
+
+	if revoked {
+		log.Debug().Msgf("Cert %s should be renewed; serial number %s is revoked", leaf.Subject.CommonName, leaf.SerialNumber)
+		return true, nil
+	}
+
+	roots, err := DecodePEMCertificates(signingIssuer.CertificateAuthority)
+	if err != nil {
+		return false, fmt.Errorf("error decoding root certificates of issuer %s: %w", signingIssuer.ID, err)
+	}
+	verifyOpts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, root := range roots {
+		verifyOpts.Roots.AddCert(root)
+	}
+	for _, intermediate := range certs[1:] {
+		verifyOpts.Intermediates.AddCert(intermediate)
+	}
+	if _, err := leaf.Verify(verifyOpts); err != nil {
+		log.Debug().Msgf("Cert %s should be renewed; not signed by issuer %s", leaf.Subject.CommonName, signingIssuer.ID)
+		return true, nil
+	}
+
+	keyAlgorithm := m.getKeyAlgorithm()
+	if keyAlgorithm == "" {
+		keyAlgorithm = v1alpha2.KeyAlgorithmRSA
+	}
+	if GetPublicKeyAlgorithm(leaf.PublicKey) != keyAlgorithm {
+		log.Debug().Msgf("Cert %s should be renewed; key algorithm changed to %s", leaf.Subject.CommonName, keyAlgorithm)
+		return true, nil
+	}
+	return false, nil
+}
+
+// GetKeyAlgorithm returns the algorithm the private keys of the certificates issued are generated with. An empty
+// algorithm implies RSA.
+func (m *Manager) GetKeyAlgorithm() v1alpha2.KeyAlgorithm {
+	return m.getKeyAlgorithm()
+}
+
+// isIdentityRevoked returns whether the identity of the given service certificate was revoked after the certificate
+// was issued. The caller must hold mu.
+func (m *Manager) isIdentityRevoked(cert *x509.Certificate) bool {
+	if len(cert.URIs) == 0 {
+		return false
+	}
+	si, err := identity.NewFromSPIFFEID(cert.URIs[0].String())
+	if err != nil {
+		return false
+	}
+	key := si.ToK8sServiceAccount().ToServiceIdentity().String()
+	for _, rev := range m.revocations {
+		if revokedAt, ok := rev.identities[key]; ok && cert.NotBefore.Before(revokedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// colonHex returns the colon separated hexadecimal form of the given serial number, used by Vault.
+func colonHex(serialNumber []byte) string {
+	parts := make([]string, 0, len(serialNumber))
+	for _, b := range serialNumber {
+		parts = append(parts, fmt.Sprintf("%02x", b))
+	}
+	return strings.Join(parts, ":")
+}
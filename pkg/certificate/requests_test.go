@@ -0,0 +1,286 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cskr/pubsub"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// fakeRequestSigner signs the certificate requests with a test CA, and fake revocation lists
+type fakeRequestSigner struct {
+	fakeIssuer
+	ca  *x509.Certificate
+	key *rsa.PrivateKey
+}
+
+func (s *fakeRequestSigner) SignCertificateRequest(csr *x509.CertificateRequest, options IssueOptions) (*Certificate, error) {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: options.CommonName().String()},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(options.ValidityDuration),
+	}
+	if spiffeID := options.SPIFFEID(); spiffeID != nil {
+		template.URIs = []*url.URL{spiffeID}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.ca, csr.PublicKey, s.key)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := EncodeCertDERtoPEM(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Certificate{
+		CommonName:   options.CommonName(),
+		SerialNumber: SerialNumber(template.SerialNumber.String()),
+		CertChain:    chain,
+		Expiration:   template.NotAfter,
+	}, nil
+}
+
+func (s *fakeRequestSigner) IssueRevocationList(serialNumbers []SerialNumber, _ time.Duration) (pem.RevocationList, error) {
+	return pem.RevocationList(fmt.Sprintf("%s:%v", s.id, serialNumbers)), nil
+}
+
+// newTestRequestManager returns a manager whose signing issuer signs certificate requests, and the CA of the issuer
+func newTestRequestManager(t *testing.T) *Manager {
+	t.Helper()
+	ca, caKey, caPEM := newTestCA(t, "root", nil, nil)
+	m := &Manager{
+		serviceCertValidityDuration: func() time.Duration { return time.Hour },
+		signingIssuer: &issuer{
+			ID:                   "id1",
+			Issuer:               &fakeRequestSigner{fakeIssuer: fakeIssuer{id: "id1"}, ca: ca, key: caKey},
+			CertificateAuthority: pem.RootCertificate(caPEM),
+			TrustDomain:          "cluster.local",
+		},
+		pubsub: pubsub.New(1),
+	}
+	m.validatingIssuer = m.signingIssuer
+	return m
+}
+
+func newTestCertificateRequest(t *testing.T, key crypto.Signer, template *x509.CertificateRequest) *x509.CertificateRequest {
+	t.Helper()
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	trequire.NoError(t, err)
+	csr, err := x509.ParseCertificateRequest(der)
+	trequire.NoError(t, err)
+	return csr
+}
+
+func TestManagerSignCertificateRequest(t *testing.T) {
+	key, err := GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+	trequire.NoError(t, err)
+	spiffeID, _ := url.Parse("spiffe://cluster.local/ns/ns/sa/sa")
+	otherSPIFFEID, _ := url.Parse("spiffe://cluster.local/ns/ns/sa/other")
+
+	testCases := []struct {
+		name        string
+		template    *x509.CertificateRequest
+		unsupported bool
+		expectedErr error
+	}{
+		{
+			name:     "request without names",
+			template: &x509.CertificateRequest{},
+		},
+		{
+			name: "request with the names of the identity",
+			template: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "sa.ns.cluster.local"},
+				DNSNames: []string{"sa.ns.cluster.local"},
+				URIs:     []*url.URL{spiffeID},
+			},
+		},
+		{
+			name:        "request with another common name",
+			template:    &x509.CertificateRequest{Subject: pkix.Name{CommonName: "other.ns.cluster.local"}},
+			expectedErr: ErrInvalidCertificateRequest,
+		},
+		{
+			name:        "request with another DNS name",
+			template:    &x509.CertificateRequest{DNSNames: []string{"other.ns.cluster.local"}},
+			expectedErr: ErrInvalidCertificateRequest,
+		},
+		{
+			name:        "request with the SPIFFE ID of another identity",
+			template:    &x509.CertificateRequest{URIs: []*url.URL{otherSPIFFEID}},
+			expectedErr: ErrInvalidCertificateRequest,
+		},
+		{
+			name:        "request with an IP address",
+			template:    &x509.CertificateRequest{IPAddresses: []net.IP{net.ParseIP("10.0.0.1")}},
+			expectedErr: ErrInvalidCertificateRequest,
+		},
+		{
+			name:        "issuer not signing certificate requests",
+			template:    &x509.CertificateRequest{},
+			unsupported: true,
+			expectedErr: ErrCertificateRequestNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			m := newTestRequestManager(t)
+			if tc.unsupported {
+				m.signingIssuer.Issuer = &fakeIssuer{id: "id1"}
+			}
+
+			cert, err := m.SignCertificateRequest(newTestCertificateRequest(t, key, tc.template), ForServiceIdentity("sa.ns"))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			trequire.NoError(t, err)
+
+			x509Cert, err := DecodePEMCertificate(cert.GetCertificateChain())
+			trequire.NoError(t, err)
+			assert.Equal(key.Public(), x509Cert.PublicKey)
+			assert.Equal("sa.ns.cluster.local", x509Cert.Subject.CommonName)
+			assert.Empty(cert.GetPrivateKey())
+			assert.Equal(v1alpha2.KeyAlgorithmECDSAP256, cert.keyAlgorithm)
+			assert.Equal("id1", cert.signingIssuerID)
+
+			// The certificate is not cached
+			assert.Nil(m.GetIssuedCertificate("sa.ns"))
+			assert.Empty(m.ListIssuedCertificates())
+		})
+	}
+
+	t.Run("request with an invalid signature", func(t *testing.T) {
+		m := newTestRequestManager(t)
+		csr := newTestCertificateRequest(t, key, &x509.CertificateRequest{})
+		csr.Signature[0] ^= 0xff
+		_, err := m.SignCertificateRequest(csr, ForServiceIdentity("sa.ns"))
+		tassert.ErrorIs(t, err, ErrInvalidCertificateRequest)
+	})
+}
+
+func TestShouldRenewCertificate(t *testing.T) {
+	rsaKey, err := GeneratePrivateKey(v1alpha2.KeyAlgorithmRSA, 2048)
+	trequire.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		validity      time.Duration
+		keyAlgorithm  v1alpha2.KeyAlgorithm
+		revokeSerial  bool
+		revokeID      bool
+		otherIssuer   bool
+		expectedRenew bool
+	}{
+		{
+			name:          "valid certificate",
+			validity:      time.Hour,
+			expectedRenew: false,
+		},
+		{
+			name:          "certificate about to expire",
+			validity:      RenewBeforeCertExpires / 2,
+			expectedRenew: true,
+		},
+		{
+			name:          "revoked certificate",
+			validity:      time.Hour,
+			revokeSerial:  true,
+			expectedRenew: true,
+		},
+		{
+			name:          "certificate of a revoked identity",
+			validity:      time.Hour,
+			revokeID:      true,
+			expectedRenew: true,
+		},
+		{
+			name:          "certificate not signed by the signing issuer",
+			validity:      time.Hour,
+			otherIssuer:   true,
+			expectedRenew: true,
+		},
+		{
+			name:          "key algorithm changed",
+			validity:      time.Hour,
+			keyAlgorithm:  v1alpha2.KeyAlgorithmECDSAP256,
+			expectedRenew: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			m := newTestRequestManager(t)
+			m.serviceCertValidityDuration = func() time.Duration { return tc.validity }
+			m.keyAlgorithm = func() v1alpha2.KeyAlgorithm { return tc.keyAlgorithm }
+
+			cert, err := m.SignCertificateRequest(newTestCertificateRequest(t, rsaKey, &x509.CertificateRequest{}), ForServiceIdentity("sa.ns"))
+			trequire.NoError(t, err)
+
+			if tc.revokeSerial {
+				_, err := m.AddRevocation("serial", Revocation{SerialNumbers: []SerialNumber{cert.GetSerialNumber()}, RevokedAt: time.Now()})
+				assert.NoError(err)
+			}
+			if tc.revokeID {
+				_, err := m.AddRevocation("identity", Revocation{Identities: []identity.ServiceIdentity{"sa.ns"}, RevokedAt: time.Now()})
+				assert.NoError(err)
+			}
+			if tc.otherIssuer {
+				_, _, otherCA := newTestCA(t, "other", nil, nil)
+				m.signingIssuer = &issuer{ID: "id2", Issuer: &fakeIssuer{id: "id2"}, CertificateAuthority: pem.RootCertificate(otherCA), TrustDomain: "cluster.local"}
+			}
+
+			renew, err := m.ShouldRenewCertificate(cert.GetCertificateChain())
+			assert.NoError(err)
+			assert.Equal(tc.expectedRenew, renew)
+		})
+	}
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		_, err := newTestRequestManager(t).ShouldRenewCertificate(pem.Certificate("invalid"))
+		tassert.Error(t, err)
+	})
+}
+
+func TestIssueTrustOnlyCertificate(t *testing.T) {
+	assert := tassert.New(t)
+	require := trequire.New(t)
+	m := newTestRequestManager(t)
+
+	trust, err := m.IssueCertificate(ForServiceIdentityTrust("sa.ns"))
+	require.NoError(err)
+	assert.Empty(trust.GetPrivateKey())
+	assert.Empty(trust.GetCertificateChain())
+	assert.Equal(m.signingIssuer.CertificateAuthority, trust.GetTrustedCAs())
+	assert.Equal(CommonName("sa.ns.cluster.local"), trust.GetCommonName())
+
+	// Trust-only entries are cached apart from the certificates of the identity, and not listed
+	again, err := m.IssueCertificate(ForServiceIdentityTrust("sa.ns"))
+	require.NoError(err)
+	assert.Same(trust, again)
+	assert.Empty(m.ListIssuedCertificates())
+	assert.Nil(m.GetIssuedCertificate("sa.ns"))
+
+	cert, err := m.IssueCertificate(ForServiceIdentity("sa.ns"))
+	require.NoError(err)
+	assert.NotSame(trust, cert)
+	assert.Len(m.ListIssuedCertificates(), 1)
+}
@@ -0,0 +1,102 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/errcode"
+)
+
+const (
+	// spiffeX509SVIDKeyUse is the key use of X.509-SVID roots in a SPIFFE trust bundle.
+	// See: https://github.com/spiffe/spiffe/blob/main/standards/SPIFFE_Trust_Domain_and_Bundle.md#4-spiffe-bundle-format
+	spiffeX509SVIDKeyUse = "x509-svid"
+
+	// spiffeBundleRefreshHintSeconds is the interval at which SPIFFE clients are advised to refresh the trust bundle
+	spiffeBundleRefreshHintSeconds = 300
+)
+
+// spiffeTrustBundle is a SPIFFE trust bundle, a JWK Set with SPIFFE specific parameters.
+type spiffeTrustBundle struct {
+	Keys        []jose.JSONWebKey `json:"keys"`
+	RefreshHint int               `json:"spiffe_refresh_hint,omitempty"`
+}
+
+// GetSPIFFETrustBundle returns the SPIFFE trust bundle of the mesh trust domain. The bundle includes the roots of
+// both the signing and validating issuers, so that clients keep validating X.509-SVIDs during root rotations.
+func (m *Manager) GetSPIFFETrustBundle() ([]byte, error) {
+	m.mu.Lock()
+	issuers := []*issuer{m.signingIssuer}
+	if m.validatingIssuer != nil && m.validatingIssuer.ID != m.signingIssuer.ID {
+		issuers = append(issuers, m.validatingIssuer)
+	}
+	m.mu.Unlock()
+
+	bundle := spiffeTrustBundle{
+		Keys:        []jose.JSONWebKey{},
+		RefreshHint: spiffeBundleRefreshHintSeconds,
+	}
+	for _, iss := range issuers {
+		roots, err := DecodePEMCertificates(iss.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding root certificates of issuer %s: %w", iss.ID, err)
+		}
+		for _, root := range roots {
+			bundle.Keys = append(bundle.Keys, jose.JSONWebKey{
+				Key:          root.PublicKey,
+				Use:          spiffeX509SVIDKeyUse,
+				Certificates: []*x509.Certificate{root},
+			})
+		}
+	}
+
+	return json.Marshal(bundle)
+}
+
+// GetSPIFFETrustBundleHandler returns an HTTP handler serving the SPIFFE trust bundle of the mesh trust domain,
+// so that SPIFFE aware clients outside the mesh can validate the X.509-SVIDs issued to workloads.
+func (m *Manager) GetSPIFFETrustBundleHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bundle, err := m.GetSPIFFETrustBundle()
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrDecodingPEMCert)).
+				Msg("Error building SPIFFE trust bundle")
+			http.Error(w, "error building SPIFFE trust bundle", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(bundle)
+	})
+}
+
+// DecodeSPIFFETrustBundle returns the PEM encoded X.509-SVID roots of the given SPIFFE trust bundle.
+func DecodeSPIFFETrustBundle(bundleJSON []byte) (pem.RootCertificate, error) {
+	var bundle spiffeTrustBundle
+	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+		return nil, fmt.Errorf("error decoding SPIFFE trust bundle: %w", err)
+	}
+
+	var roots pem.RootCertificate
+	for _, key := range bundle.Keys {
+		if key.Use != spiffeX509SVIDKeyUse {
+			continue
+		}
+		for _, cert := range key.Certificates {
+			certPEM, err := EncodeCertDERtoPEM(cert.Raw)
+			if err != nil {
+				return nil, err
+			}
+			roots = append(roots, certPEM...)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("SPIFFE trust bundle has no %s roots", spiffeX509SVIDKeyUse)
+	}
+	return roots, nil
+}
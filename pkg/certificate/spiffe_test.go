@@ -0,0 +1,144 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+)
+
+func newTestRootCertificate(t *testing.T, cn string) pem.RootCertificate {
+	key, err := GeneratePrivateKey(v1alpha2.KeyAlgorithmECDSAP256, 0)
+	tassert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	tassert.NoError(t, err)
+
+	certPEM, err := EncodeCertDERtoPEM(der)
+	tassert.NoError(t, err)
+	return pem.RootCertificate(certPEM)
+}
+
+func TestGetSPIFFETrustBundleHandler(t *testing.T) {
+	rootA := newTestRootCertificate(t, "root-a")
+	rootB := newTestRootCertificate(t, "root-b")
+
+	testCases := []struct {
+		name             string
+		signingIssuer    *issuer
+		validatingIssuer *issuer
+		expectedStatus   int
+		expectedRoots    []string
+	}{
+		{
+			name:             "single issuer",
+			signingIssuer:    &issuer{ID: "a", CertificateAuthority: rootA},
+			validatingIssuer: &issuer{ID: "a", CertificateAuthority: rootA},
+			expectedStatus:   http.StatusOK,
+			expectedRoots:    []string{"root-a"},
+		},
+		{
+			name:             "signing and validating issuers during rotation",
+			signingIssuer:    &issuer{ID: "a", CertificateAuthority: rootA},
+			validatingIssuer: &issuer{ID: "b", CertificateAuthority: rootB},
+			expectedStatus:   http.StatusOK,
+			expectedRoots:    []string{"root-a", "root-b"},
+		},
+		{
+			name:             "invalid root certificate",
+			signingIssuer:    &issuer{ID: "a", CertificateAuthority: pem.RootCertificate("rootCA")},
+			validatingIssuer: &issuer{ID: "a", CertificateAuthority: pem.RootCertificate("rootCA")},
+			expectedStatus:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			m := &Manager{signingIssuer: tc.signingIssuer, validatingIssuer: tc.validatingIssuer}
+			responseRecorder := httptest.NewRecorder()
+			m.GetSPIFFETrustBundleHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(tc.expectedStatus, responseRecorder.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			assert.Equal("application/json", responseRecorder.Header().Get("Content-Type"))
+
+			var bundle spiffeTrustBundle
+			assert.NoError(json.Unmarshal(responseRecorder.Body.Bytes(), &bundle))
+			assert.Equal(spiffeBundleRefreshHintSeconds, bundle.RefreshHint)
+
+			var roots []string
+			for _, key := range bundle.Keys {
+				assert.Equal(spiffeX509SVIDKeyUse, key.Use)
+				assert.Len(key.Certificates, 1)
+				roots = append(roots, key.Certificates[0].Subject.CommonName)
+			}
+			assert.ElementsMatch(tc.expectedRoots, roots)
+		})
+	}
+}
+
+func TestDecodeSPIFFETrustBundle(t *testing.T) {
+	rootA := newTestRootCertificate(t, "root-a")
+	rootB := newTestRootCertificate(t, "root-b")
+	bundle, err := (&Manager{
+		signingIssuer:    &issuer{ID: "a", CertificateAuthority: rootA},
+		validatingIssuer: &issuer{ID: "b", CertificateAuthority: rootB},
+	}).GetSPIFFETrustBundle()
+	tassert.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		bundle        []byte
+		expectedRoots pem.RootCertificate
+		expectErr     bool
+	}{
+		{
+			name:          "valid bundle",
+			bundle:        bundle,
+			expectedRoots: append(append(pem.RootCertificate{}, rootA...), rootB...),
+		},
+		{
+			name:      "no X.509-SVID roots",
+			bundle:    []byte(`{"keys":[]}`),
+			expectErr: true,
+		},
+		{
+			name:      "invalid JSON",
+			bundle:    []byte("not a bundle"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			roots, err := DecodeSPIFFETrustBundle(tc.bundle)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.Equal(tc.expectedRoots, roots)
+		})
+	}
+}
@@ -4,6 +4,7 @@ package certificate
 
 import (
 	"context"
+	"crypto/x509"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/identity"
 )
 
 const (
@@ -25,6 +27,13 @@ const (
 	// TypeCertificateRequest is a string constant to be used in the generation
 	// of a certificate requests.
 	TypeCertificateRequest = "CERTIFICATE REQUEST"
+
+	// TypeRevocationList is a string constant to be used in the generation of a certificate revocation list.
+	TypeRevocationList = "X509 CRL"
+
+	// trustOnlyKeyPrefix is the prefix of the cache keys of the trust contexts of service certificates, which can't
+	// collide with service identities
+	trustOnlyKeyPrefix = "trust/"
 )
 
 // SerialNumber is the Serial Number of the given certificate.
@@ -81,10 +90,82 @@ type Certificate struct {
 	// Includes both issuing CA and validating CA (if applicable)
 	TrustedCAs pem.RootCertificate
 
+	// The roots of the recipient's trust context keyed by trust domain, set when trust domains are federated. Each
+	// root only validates the certificates whose SPIFFE ID is in its trust domain.
+	TrustDomainBundles map[string]pem.RootCertificate
+
+	// PEM encoded revocation lists of the issuers in TrustedCAs, listing the certificates the recipient must reject.
+	// Empty if no certificate is revoked.
+	RevocationLists pem.RevocationList
+
 	signingIssuerID    string
 	validatingIssuerID string
 
+	// The generation of the federated roots included in TrustDomainBundles
+	federatedRootsGeneration uint64
+
+	// The generation of the revoked certificates listed in RevocationLists
+	revocationsGeneration uint64
+
+	// When the certificate was issued
+	issuedAt time.Time
+
 	certType certType
+
+	// The algorithm the certificate's private key was requested with
+	keyAlgorithm v1alpha2.KeyAlgorithm
+
+	// Whether the certificate only holds the trust context of service certificates, without certificate chain and
+	// private key
+	trustOnly bool
+}
+
+// CertificateInfo describes an issued certificate, without its private key.
+type CertificateInfo struct {
+	// Key is the key the certificate is cached by, such as the service identity of a service certificate
+	Key string `json:"key"`
+
+	// CommonName is the common name of the certificate
+	CommonName CommonName `json:"commonName"`
+
+	// SerialNumber is the serial number of the certificate
+	SerialNumber SerialNumber `json:"serialNumber"`
+
+	// CertType is the type of the certificate: internal, ingressGateway or service
+	CertType string `json:"certType"`
+
+	// SigningIssuerID is the ID of the issuer, the name of the MeshRootCertificate, that signed the certificate
+	SigningIssuerID string `json:"signingIssuerID"`
+
+	// ValidatingIssuerID is the ID of the issuer whose root the certificate's holder validates peers with
+	ValidatingIssuerID string `json:"validatingIssuerID"`
+
+	// KeyAlgorithm is the algorithm the certificate's private key was requested with. Empty for the providers' default.
+	KeyAlgorithm string `json:"keyAlgorithm,omitempty"`
+
+	// IssuedAt is when the certificate was issued
+	IssuedAt time.Time `json:"issuedAt"`
+
+	// Expiration is when the certificate expires
+	Expiration time.Time `json:"expiration"`
+
+	// CertChain is the PEM encoded certificate chain, only set when inspecting a certificate
+	CertChain string `json:"certChain,omitempty"`
+}
+
+// RootInfo describes the root certificate of a MeshRootCertificate observed by the certificate manager.
+type RootInfo struct {
+	// MRC is the name of the MeshRootCertificate
+	MRC string `json:"mrc"`
+
+	// Signing is whether the MeshRootCertificate signs the certificates issued
+	Signing bool `json:"signing"`
+
+	// Validating is whether the root certificate is trusted by the holders of the certificates issued
+	Validating bool `json:"validating"`
+
+	// RootCertificate is the PEM encoded root certificate
+	RootCertificate string `json:"rootCertificate"`
 }
 
 // Issuer is the interface for a certificate authority that can issue certificates from a given root certificate.
@@ -93,6 +174,52 @@ type Issuer interface {
 	IssueCertificate(IssueOptions) (*Certificate, error)
 }
 
+// RevocationListIssuer is implemented by the Issuers that can sign certificate revocation lists.
+type RevocationListIssuer interface {
+	// IssueRevocationList returns a certificate revocation list, signed by the issuer, listing the certificates with
+	// the given serial numbers as revoked, and valid for the given duration.
+	IssueRevocationList(serialNumbers []SerialNumber, validityDuration time.Duration) (pem.RevocationList, error)
+}
+
+// CertificateRequestSigner is implemented by the Issuers that can sign certificates for certificate signing requests,
+// whose private keys are held by the requesters.
+type CertificateRequestSigner interface {
+	// SignCertificateRequest signs a certificate for the public key of the given certificate signing request, whose
+	// signature was verified. The certificate is returned without private key.
+	SignCertificateRequest(*x509.CertificateRequest, IssueOptions) (*Certificate, error)
+}
+
+// Revocation lists the certificates revoked by a revocation resource.
+type Revocation struct {
+	// SerialNumbers are the serial numbers of the revoked certificates
+	SerialNumbers []SerialNumber
+
+	// Identities are the service identities whose certificates issued before RevokedAt are revoked
+	Identities []identity.ServiceIdentity
+
+	// RevokedAt is the time the revocation was made
+	RevokedAt time.Time
+}
+
+// revocation holds the serial numbers revoked by a Revocation
+type revocation struct {
+	// all the revoked serial numbers
+	serialNumbers map[SerialNumber]struct{}
+
+	// the serial numbers of the certificates of the revoked identities found in the cache. They are accumulated,
+	// since the certificates leave the cache once reissued.
+	identitySerialNumbers map[SerialNumber]struct{}
+
+	// the revoked identities, whose certificates issued before the time they were revoked at are revoked
+	identities map[string]time.Time
+}
+
+// federatedRoot is the type used to represent the roots of a federated trust domain
+type federatedRoot struct {
+	trustDomain string
+	roots       pem.RootCertificate
+}
+
 type issuer struct {
 	Issuer
 	ID          string
@@ -101,6 +228,14 @@ type issuer struct {
 	CertificateAuthority pem.RootCertificate
 }
 
+// mrcIssuer is a MeshRootCertificate observed by the Manager, along with the issuer generated from it
+type mrcIssuer struct {
+	mrc    *v1alpha2.MeshRootCertificate
+	issuer *issuer
+	// the order in which the MRC was last added or updated
+	seq uint64
+}
+
 // Manager represents all necessary information for the certificate managers.
 type Manager struct {
 	// Cache for all the certificates issued
@@ -110,11 +245,23 @@ type Manager struct {
 	ingressCertValidityDuration func() time.Duration
 	// TODO(#4711): define serviceCertValidityDuration in the MRC
 	serviceCertValidityDuration func() time.Duration
+	keyAlgorithm                func() v1alpha2.KeyAlgorithm
 
-	mu            sync.Mutex // mu syncrhonizes acces to the below resources.
+	mu sync.Mutex // mu syncrhonizes acces to the below resources.
+	// the MeshRootCertificates the signing and validating issuers are selected from, keyed by name.
+	mrcs          map[string]*mrcIssuer
+	mrcSeq        uint64
 	signingIssuer *issuer
 	// equal to signingIssuer if there is no additional public cert issuer.
 	validatingIssuer *issuer
+	// the roots of federated trust domains, keyed by ID, validated in addition to validatingIssuer.
+	federatedRoots map[string]federatedRoot
+	// incremented every time federatedRoots changes.
+	federatedRootsGeneration uint64
+	// the revoked certificates, keyed by the ID of the revocation.
+	revocations map[string]*revocation
+	// incremented every time revocations changes.
+	revocationsGeneration uint64
 
 	group singleflight.Group
 
@@ -146,6 +293,9 @@ var (
 
 	// MRCEventUpdated is the type of announcement emitted when we observe an update to a Kubernetes MeshRootCertificate
 	MRCEventUpdated MRCEventType = "meshrootcertificate-updated"
+
+	// MRCEventDeleted is the type of announcement emitted when we observe the deletion of a Kubernetes MeshRootCertificate
+	MRCEventDeleted MRCEventType = "meshrootcertificate-deleted"
 )
 
 // MRCEventBroker describes any type that allows the caller to Watch() MRCEvents
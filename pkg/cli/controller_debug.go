@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+// ExecuteControllerDebugReq makes an HTTP GET request to the given path on the osm-controller's debug server by
+// port-forwarding to a running osm-controller pod in the given namespace. The debug server must be enabled
+// in the MeshConfig.
+func ExecuteControllerDebugReq(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, localPort uint16, path string) ([]byte, error) {
+	return executeControllerDebugReq(clientSet, config, osmNamespace, localPort, http.MethodGet, path)
+}
+
+// ExecuteControllerDebugPostReq makes an HTTP POST request to the given path on the osm-controller's debug server,
+// like ExecuteControllerDebugReq.
+func ExecuteControllerDebugPostReq(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, localPort uint16, path string) ([]byte, error) {
+	return executeControllerDebugReq(clientSet, config, osmNamespace, localPort, http.MethodPost, path)
+}
+
+func executeControllerDebugReq(clientSet kubernetes.Interface, config *rest.Config, osmNamespace string, localPort uint16, method string, path string) ([]byte, error) {
+	pod, err := getRunningControllerPod(clientSet, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer, err := k8s.DialerToPod(config, clientSet, pod.Name, osmNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	portForwarder, err := k8s.NewPortForwarder(dialer, fmt.Sprintf("%d:%d", localPort, constants.DebugPort))
+	if err != nil {
+		return nil, fmt.Errorf("error setting up port forwarding: %w", err)
+	}
+
+	var body []byte
+	err = portForwarder.Start(func(pf *k8s.PortForwarder) error {
+		defer pf.Stop()
+		url := fmt.Sprintf("http://localhost:%d%s", localPort, path)
+
+		req, err := http.NewRequest(method, url, nil)
+		if err != nil {
+			return fmt.Errorf("error creating %s request to url %s: %w", method, url, err)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("error making %s request to url %s: %w", method, url, err)
+		}
+
+		//nolint: errcheck
+		//#nosec G307
+		defer resp.Body.Close()
+
+		body, err = ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("error rendering HTTP response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("%s request to url %s returned status %d: %s", method, url, resp.StatusCode, body)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying debug server on pod %s in namespace %s, ensure the debug server is enabled in the MeshConfig: %w",
+			pod.Name, osmNamespace, err)
+	}
+
+	return body, nil
+}
+
+// getRunningControllerPod returns a running osm-controller pod in the given namespace
+func getRunningControllerPod(clientSet kubernetes.Interface, osmNamespace string) (*corev1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{constants.AppLabel: constants.OSMControllerName})
+	podList, err := clientSet.CoreV1().Pods(osmNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s pods in namespace %s: %w", constants.OSMControllerName, osmNamespace, err)
+	}
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == corev1.PodRunning {
+			return &podList.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running %s pod found in namespace %s", constants.OSMControllerName, osmNamespace)
+}
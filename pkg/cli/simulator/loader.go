@@ -0,0 +1,115 @@
+package simulator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	codecs = serializer.NewCodecFactory(scheme)
+)
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = smiAccess.AddToScheme(scheme)
+	_ = smiSpecs.AddToScheme(scheme)
+	_ = smiSplit.AddToScheme(scheme)
+	_ = configv1alpha2.AddToScheme(scheme)
+	_ = policyv1alpha1.AddToScheme(scheme)
+}
+
+// LoadDir decodes the Kubernetes resources in the YAML and JSON files found in the given directory and its
+// subdirectories. Documents of a kind unknown to the mesh are skipped, and their file names and kinds are returned
+// so the caller can report them.
+func LoadDir(dir string) ([]runtime.Object, []string, error) {
+	var objects []runtime.Object
+	var skipped []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", path, err)
+		}
+		fileObjects, fileSkipped, err := decode(content)
+		if err != nil {
+			return fmt.Errorf("error decoding file %s: %w", path, err)
+		}
+		objects = append(objects, fileObjects...)
+		for _, kind := range fileSkipped {
+			skipped = append(skipped, fmt.Sprintf("%s: %s", path, kind))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return objects, skipped, nil
+}
+
+// decode decodes the documents in the given multi-document YAML or JSON content
+func decode(content []byte) ([]runtime.Object, []string, error) {
+	var objects []runtime.Object
+	var skipped []string
+
+	deserializer := codecs.UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := deserializer.Decode(doc, nil, nil)
+		if runtime.IsNotRegisteredError(err) {
+			var typeMeta metav1.TypeMeta
+			_ = yaml.Unmarshal(doc, &typeMeta)
+			skipped = append(skipped, typeMeta.GroupVersionKind().String())
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, skipped, nil
+}
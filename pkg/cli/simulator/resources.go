@@ -0,0 +1,275 @@
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// podCIDRPrefix and serviceCIDRPrefix are the prefixes of the addresses given to pods and services that
+	// do not specify one
+	podCIDRPrefix     = "10.244"
+	serviceCIDRPrefix = "10.96"
+)
+
+// resourceSet is the set of objects to seed the fake clientsets of a simulation with
+type resourceSet struct {
+	kubeObjects      []runtime.Object
+	configObjects    []runtime.Object
+	policyObjects    []runtime.Object
+	smiAccessObjects []runtime.Object
+	smiSpecsObjects  []runtime.Object
+	smiSplitObjects  []runtime.Object
+
+	pods       []*corev1.Pod
+	meshConfig *configv1alpha2.MeshConfig
+}
+
+// newResourceSet sorts the given objects by the client serving them, and fills in what the Kubernetes control
+// plane would otherwise provide: pods for workloads, addresses, endpoints and mesh namespaces.
+func newResourceSet(objects []runtime.Object) *resourceSet {
+	r := &resourceSet{}
+
+	namespaces := map[string]*corev1.Namespace{}
+	var services []*corev1.Service
+	endpoints := map[string]bool{}
+
+	for _, obj := range objects {
+		if accessor, ok := obj.(metav1.Object); ok && accessor.GetNamespace() == "" {
+			if _, isNamespace := obj.(*corev1.Namespace); !isNamespace {
+				accessor.SetNamespace(metav1.NamespaceDefault)
+			}
+		}
+
+		switch o := obj.(type) {
+		case *corev1.Namespace:
+			namespaces[o.Name] = o
+		case *corev1.Pod:
+			r.pods = append(r.pods, o)
+		case *corev1.Service:
+			services = append(services, o)
+		case *corev1.Endpoints:
+			endpoints[o.Namespace+"/"+o.Name] = true
+			r.kubeObjects = append(r.kubeObjects, o)
+		case *corev1.ServiceAccount, *corev1.ConfigMap:
+			r.kubeObjects = append(r.kubeObjects, o)
+		case *appsv1.Deployment:
+			r.pods = append(r.pods, podsForWorkload(o.ObjectMeta, "Deployment", o.Spec.Template, o.Spec.Replicas)...)
+		case *appsv1.StatefulSet:
+			pods := podsForWorkload(o.ObjectMeta, "StatefulSet", o.Spec.Template, o.Spec.Replicas)
+			for _, pod := range pods {
+				pod.Spec.Hostname = pod.Name
+				pod.Spec.Subdomain = o.Spec.ServiceName
+			}
+			r.pods = append(r.pods, pods...)
+		case *appsv1.DaemonSet:
+			r.pods = append(r.pods, podsForWorkload(o.ObjectMeta, "DaemonSet", o.Spec.Template, nil)...)
+		case *appsv1.ReplicaSet:
+			r.pods = append(r.pods, podsForWorkload(o.ObjectMeta, "ReplicaSet", o.Spec.Template, o.Spec.Replicas)...)
+		case *configv1alpha2.MeshConfig:
+			if r.meshConfig == nil {
+				r.meshConfig = o
+				r.configObjects = append(r.configObjects, o)
+			}
+		case *smiAccess.TrafficTarget:
+			r.smiAccessObjects = append(r.smiAccessObjects, o)
+		case *smiSpecs.HTTPRouteGroup, *smiSpecs.TCPRoute:
+			r.smiSpecsObjects = append(r.smiSpecsObjects, o)
+		case *smiSplit.TrafficSplit:
+			r.smiSplitObjects = append(r.smiSplitObjects, o)
+		case *policyv1alpha1.Egress, *policyv1alpha1.IngressBackend, *policyv1alpha1.Retry, *policyv1alpha1.UpstreamTrafficSetting,
+			*policyv1alpha1.HTTPFilterExtension:
+			r.policyObjects = append(r.policyObjects, o)
+		}
+	}
+
+	if r.meshConfig == nil {
+		r.meshConfig = &configv1alpha2.MeshConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      constants.OSMMeshConfig,
+				Namespace: DefaultOSMNamespace,
+			},
+		}
+		r.configObjects = append(r.configObjects, r.meshConfig)
+	}
+
+	sort.Slice(r.pods, func(i, j int) bool {
+		if r.pods[i].Namespace != r.pods[j].Namespace {
+			return r.pods[i].Namespace < r.pods[j].Namespace
+		}
+		return r.pods[i].Name < r.pods[j].Name
+	})
+	for i, pod := range r.pods {
+		completePod(pod, i)
+		namespaces[pod.Namespace] = namespaceFor(namespaces[pod.Namespace], pod.Namespace)
+		r.kubeObjects = append(r.kubeObjects, pod)
+	}
+
+	for i, svc := range services {
+		if svc.Spec.ClusterIP == "" {
+			svc.Spec.ClusterIP = fmt.Sprintf("%s.%d.%d", serviceCIDRPrefix, (i+1)/256, (i+1)%256)
+		}
+		namespaces[svc.Namespace] = namespaceFor(namespaces[svc.Namespace], svc.Namespace)
+		r.kubeObjects = append(r.kubeObjects, svc)
+		if !endpoints[svc.Namespace+"/"+svc.Name] {
+			r.kubeObjects = append(r.kubeObjects, endpointsForService(svc, r.pods))
+		}
+	}
+
+	for _, list := range [][]runtime.Object{r.smiAccessObjects, r.smiSpecsObjects, r.smiSplitObjects, r.policyObjects} {
+		for _, obj := range list {
+			ns := obj.(metav1.Object).GetNamespace()
+			namespaces[ns] = namespaceFor(namespaces[ns], ns)
+		}
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.kubeObjects = append(r.kubeObjects, namespaces[name])
+	}
+
+	return r
+}
+
+// namespaceFor returns the given namespace, or a new one with the given name if nil, labeled to be monitored by
+// the simulated mesh
+func namespaceFor(ns *corev1.Namespace, name string) *corev1.Namespace {
+	if ns == nil {
+		ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[constants.OSMKubeResourceMonitorAnnotation] = DefaultMeshName
+	return ns
+}
+
+// podsForWorkload returns the pods a workload controller would create for the given pod template
+func podsForWorkload(meta metav1.ObjectMeta, kind string, template corev1.PodTemplateSpec, replicas *int32) []*corev1.Pod {
+	count := int(pointer.Int32Deref(replicas, 1))
+	pods := make([]*corev1.Pod, 0, count)
+	for i := 0; i < count; i++ {
+		pod := &corev1.Pod{
+			ObjectMeta: *template.ObjectMeta.DeepCopy(),
+			Spec:       *template.Spec.DeepCopy(),
+		}
+		pod.Name = fmt.Sprintf("%s-%d", meta.Name, i)
+		pod.Namespace = meta.Namespace
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       kind,
+			Name:       meta.Name,
+			Controller: pointer.Bool(true),
+		}}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// completePod fills in the service account, sidecar UUID label and IP address of the given pod if unset. The
+// UUID is derived from the pod's name so that simulations are reproducible.
+func completePod(pod *corev1.Pod, index int) {
+	if pod.Spec.ServiceAccountName == "" {
+		pod.Spec.ServiceAccountName = "default"
+	}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	if _, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]; !ok {
+		pod.Labels[constants.EnvoyUniqueIDLabelName] = uuid.NewSHA1(uuid.NameSpaceURL, []byte(pod.Namespace+"/"+pod.Name)).String()
+	}
+	if pod.Status.PodIP == "" {
+		pod.Status.PodIP = fmt.Sprintf("%s.%d.%d", podCIDRPrefix, (index+1)/256, (index+1)%256)
+	}
+	if len(pod.Status.PodIPs) == 0 {
+		pod.Status.PodIPs = []corev1.PodIP{{IP: pod.Status.PodIP}}
+	}
+	pod.Status.Phase = corev1.PodRunning
+}
+
+// endpointsForService returns the endpoints the endpoints controller would create for the given service
+func endpointsForService(svc *corev1.Service, pods []*corev1.Pod) *corev1.Endpoints {
+	eps := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      svc.Name,
+			Namespace: svc.Namespace,
+		},
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return eps
+	}
+
+	selector := labels.Set(svc.Spec.Selector).AsSelector()
+	var subset corev1.EndpointSubset
+	for _, pod := range pods {
+		if pod.Namespace != svc.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		address := corev1.EndpointAddress{
+			IP: pod.Status.PodIP,
+			TargetRef: &corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		}
+		if pod.Spec.Subdomain == svc.Name {
+			address.Hostname = pod.Spec.Hostname
+		}
+		subset.Addresses = append(subset.Addresses, address)
+
+		if subset.Ports == nil {
+			for _, port := range svc.Spec.Ports {
+				subset.Ports = append(subset.Ports, corev1.EndpointPort{
+					Name:        port.Name,
+					Port:        targetPort(port, pod),
+					Protocol:    port.Protocol,
+					AppProtocol: port.AppProtocol,
+				})
+			}
+		}
+	}
+	if len(subset.Addresses) > 0 {
+		eps.Subsets = []corev1.EndpointSubset{subset}
+	}
+	return eps
+}
+
+// targetPort resolves the target port of the given service port on the given pod
+func targetPort(port corev1.ServicePort, pod *corev1.Pod) int32 {
+	switch {
+	case port.TargetPort.Type == intstr.String:
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == port.TargetPort.StrVal {
+					return containerPort.ContainerPort
+				}
+			}
+		}
+		return port.Port
+	case port.TargetPort.IntVal != 0:
+		return port.TargetPort.IntVal
+	default:
+		return port.Port
+	}
+}
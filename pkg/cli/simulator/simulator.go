@@ -0,0 +1,216 @@
+// Package simulator computes the xDS configuration the OSM controller would program on a proxy, using Kubernetes
+// resources loaded from files instead of a live cluster.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/google/uuid"
+	smiAccessFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/access/clientset/versioned/fake"
+	smiSpecsFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/specs/clientset/versioned/fake"
+	smiSplitFake "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sClientFake "k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/compute/kube"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/cds"
+	"github.com/openservicemesh/osm/pkg/envoy/eds"
+	"github.com/openservicemesh/osm/pkg/envoy/lds"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+	policyFake "github.com/openservicemesh/osm/pkg/gen/client/policy/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+const (
+	// DefaultMeshName is the name of the simulated mesh
+	DefaultMeshName = "osm"
+
+	// DefaultOSMNamespace is the namespace of the simulated control plane, used when no MeshConfig is given. The
+	// MeshConfig of the simulation then has an empty spec.
+	DefaultOSMNamespace = "osm-system"
+)
+
+// SimulatedTypes are the xDS types computed by a simulation. SDS is left out as its resources are the
+// certificates issued to the proxy, which are not meaningful offline.
+var SimulatedTypes = []envoy.TypeURI{envoy.TypeLDS, envoy.TypeRDS, envoy.TypeCDS, envoy.TypeEDS}
+
+var xdsBuilders = map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
+	envoy.TypeLDS: lds.NewResponse,
+	envoy.TypeRDS: rds.NewResponse,
+	envoy.TypeCDS: cds.NewResponse,
+	envoy.TypeEDS: eds.NewResponse,
+}
+
+var (
+	errPodNotFound            = errors.New("pod not found")
+	errServiceAccountNotFound = errors.New("no pod found for service account")
+)
+
+// Simulator runs the mesh catalog and xDS builders over an in-memory copy of a set of Kubernetes resources.
+type Simulator struct {
+	stop        chan struct{}
+	pods        []*corev1.Pod
+	meshCatalog *catalog.MeshCatalog
+	certManager *certificate.Manager
+}
+
+// Result is the outcome of simulating the configuration of a single proxy.
+type Result struct {
+	// Pod is the pod the proxy belongs to.
+	Pod *corev1.Pod
+
+	// Proxy is the simulated proxy.
+	Proxy *envoy.Proxy
+
+	// Resources are the xDS resources computed for the proxy, keyed by type.
+	Resources map[envoy.TypeURI][]types.Resource
+}
+
+// New returns a Simulator for the given Kubernetes resources. Workloads are expanded into pods, services without
+// endpoints are given endpoints for the pods they select, and every namespace is treated as part of the mesh.
+// Close must be called once the Simulator is no longer needed.
+func New(objects []runtime.Object) (*Simulator, error) {
+	r := newResourceSet(objects)
+
+	stop := make(chan struct{})
+	kubeClient := k8sClientFake.NewSimpleClientset(r.kubeObjects...)
+	configClient := configFake.NewSimpleClientset(r.configObjects...)
+	policyClient := policyFake.NewSimpleClientset(r.policyObjects...)
+	smiAccessClient := smiAccessFake.NewSimpleClientset(r.smiAccessObjects...)
+	smiSpecsClient := smiSpecsFake.NewSimpleClientset(r.smiSpecsObjects...)
+	smiSplitClient := smiSplitFake.NewSimpleClientset(r.smiSplitObjects...)
+
+	informerCollection, err := informers.NewInformerCollection(DefaultMeshName, stop,
+		informers.WithKubeClient(kubeClient),
+		informers.WithSMIClients(smiSplitClient, smiSpecsClient, smiAccessClient),
+		informers.WithConfigClient(configClient, r.meshConfig.Name, r.meshConfig.Namespace),
+		informers.WithPolicyClient(policyClient),
+	)
+	if err != nil {
+		close(stop)
+		return nil, fmt.Errorf("error starting informers: %w", err)
+	}
+
+	certManager, err := certificate.FakeCertManager()
+	if err != nil {
+		close(stop)
+		return nil, err
+	}
+
+	msgBroker := messaging.NewBroker(stop)
+	kubeController := k8s.NewClient(r.meshConfig.Namespace, r.meshConfig.Name, informerCollection, policyClient, msgBroker)
+	meshSpec := smi.NewSMIClient(informerCollection, r.meshConfig.Namespace, kubeController, msgBroker)
+
+	return &Simulator{
+		stop:        stop,
+		pods:        r.pods,
+		meshCatalog: catalog.NewMeshCatalog(meshSpec, certManager, stop, kube.NewClient(kubeController), msgBroker),
+		certManager: certManager,
+	}, nil
+}
+
+// Close stops the informers backing the Simulator.
+func (s *Simulator) Close() {
+	close(s.stop)
+}
+
+// GetMeshConfig returns the MeshConfig used by the simulation.
+func (s *Simulator) GetMeshConfig() configv1alpha2.MeshConfig {
+	return s.meshCatalog.GetMeshConfig()
+}
+
+// ListPods returns the pods in the simulation, including those created for workloads, sorted by namespace and name.
+func (s *Simulator) ListPods() []*corev1.Pod {
+	return s.pods
+}
+
+// GetPod returns the pod with the given namespace and name.
+func (s *Simulator) GetPod(namespace, name string) (*corev1.Pod, error) {
+	for _, pod := range s.ListPods() {
+		if pod.Namespace == namespace && pod.Name == name {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s/%s", errPodNotFound, namespace, name)
+}
+
+// GetPodForServiceAccount returns the first pod, by name, running as the given service account.
+func (s *Simulator) GetPodForServiceAccount(sa identity.K8sServiceAccount) (*corev1.Pod, error) {
+	for _, pod := range s.ListPods() {
+		if pod.Namespace == sa.Namespace && pod.Spec.ServiceAccountName == sa.Name {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errServiceAccountNotFound, sa)
+}
+
+// Simulate computes the xDS resources the OSM controller would send to the sidecar of the given pod.
+func (s *Simulator) Simulate(pod *corev1.Pod) (*Result, error) {
+	proxyUUID, err := uuid.Parse(pod.Labels[constants.EnvoyUniqueIDLabelName])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s label on pod %s/%s: %w", constants.EnvoyUniqueIDLabelName, pod.Namespace, pod.Name, err)
+	}
+	svcIdentity := identity.K8sServiceAccount{Name: pod.Spec.ServiceAccountName, Namespace: pod.Namespace}.ToServiceIdentity()
+
+	var addr net.Addr
+	if ip := net.ParseIP(pod.Status.PodIP); ip != nil {
+		addr = &net.IPAddr{IP: ip}
+	}
+	proxy := envoy.NewProxy(envoy.KindSidecar, proxyUUID, svcIdentity, addr, 0)
+
+	result := &Result{
+		Pod:       pod,
+		Proxy:     proxy,
+		Resources: make(map[envoy.TypeURI][]types.Resource, len(SimulatedTypes)),
+	}
+	for _, typeURI := range SimulatedTypes {
+		resources, err := xdsBuilders[typeURI](s.meshCatalog, proxy, s.certManager, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error building %s resources: %w", typeURI.Short(), err)
+		}
+		sortResources(resources)
+		result.Resources[typeURI] = resources
+	}
+
+	return result, nil
+}
+
+// sortResources sorts the given resources by name, and the endpoints of cluster load assignments by address, so
+// that simulating the same resources always yields the same output. The xDS builders iterate over maps in places,
+// which Envoy is indifferent to but would make the output of a simulation hard to compare across runs.
+func sortResources(resources []types.Resource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		return cache.GetResourceName(resources[i]) < cache.GetResourceName(resources[j])
+	})
+	for _, res := range resources {
+		cla, ok := res.(*xds_endpoint.ClusterLoadAssignment)
+		if !ok {
+			continue
+		}
+		for _, localityEndpoints := range cla.Endpoints {
+			lbEndpoints := localityEndpoints.LbEndpoints
+			sort.SliceStable(lbEndpoints, func(i, j int) bool {
+				return lbEndpoints[i].GetEndpoint().GetAddress().GetSocketAddress().GetAddress() <
+					lbEndpoints[j].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()
+			})
+		}
+	}
+}
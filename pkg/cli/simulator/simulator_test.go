@@ -0,0 +1,91 @@
+package simulator
+
+import (
+	"testing"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestLoadDir(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, skipped, err := LoadDir("testdata/bookstore")
+	assert.NoError(err)
+	assert.Len(objects, 9)
+	assert.Equal([]string{"testdata/bookstore/policies.yaml: example.com/v1, Kind=Widget"}, skipped)
+
+	_, _, err = LoadDir("testdata/missing")
+	assert.Error(err)
+}
+
+func TestSimulate(t *testing.T) {
+	assert := tassert.New(t)
+
+	objects, _, err := LoadDir("testdata/bookstore")
+	assert.NoError(err)
+
+	s, err := New(objects)
+	assert.NoError(err)
+	defer s.Close()
+
+	assert.False(s.GetMeshConfig().Spec.Traffic.EnablePermissiveTrafficPolicyMode)
+
+	var podNames []string
+	for _, pod := range s.ListPods() {
+		podNames = append(podNames, pod.Namespace+"/"+pod.Name)
+		assert.NotEmpty(pod.Labels[constants.EnvoyUniqueIDLabelName])
+		assert.NotEmpty(pod.Status.PodIP)
+	}
+	assert.Equal([]string{"bookbuyer/bookbuyer-0", "bookstore/bookstore-0", "bookstore/bookstore-1"}, podNames)
+
+	_, err = s.GetPod("bookstore", "bookstore-2")
+	assert.ErrorIs(err, errPodNotFound)
+	_, err = s.GetPodForServiceAccount(identity.K8sServiceAccount{Name: "bookthief", Namespace: "bookthief"})
+	assert.ErrorIs(err, errServiceAccountNotFound)
+
+	pod, err := s.GetPodForServiceAccount(identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer"})
+	assert.NoError(err)
+	assert.Equal("bookbuyer-0", pod.Name)
+
+	result, err := s.Simulate(pod)
+	assert.NoError(err)
+	assert.Equal(identity.ServiceIdentity("bookbuyer.bookbuyer"), result.Proxy.Identity)
+
+	names := func(resources []types.Resource) []string {
+		var names []string
+		for _, res := range resources {
+			names = append(names, cache.GetResourceName(res))
+		}
+		return names
+	}
+	assert.Contains(names(result.Resources[envoy.TypeCDS]), "bookstore/bookstore|14001")
+	assert.Contains(names(result.Resources[envoy.TypeLDS]), "outbound-listener")
+
+	var bookstoreEndpoints *xds_endpoint.ClusterLoadAssignment
+	for _, res := range result.Resources[envoy.TypeEDS] {
+		if cla := res.(*xds_endpoint.ClusterLoadAssignment); cla.ClusterName == "bookstore/bookstore|14001" {
+			bookstoreEndpoints = cla
+		}
+	}
+	if assert.NotNil(bookstoreEndpoints) {
+		assert.Len(bookstoreEndpoints.Endpoints[0].LbEndpoints, 2)
+	}
+
+	// The upstream has no permission to connect to the downstream, so it only gets its local cluster
+	pod, err = s.GetPod("bookstore", "bookstore-1")
+	assert.NoError(err)
+	result, err = s.Simulate(pod)
+	assert.NoError(err)
+	for _, res := range result.Resources[envoy.TypeCDS] {
+		assert.NotEqual("bookbuyer/bookbuyer|80", res.(*xds_cluster.Cluster).Name)
+	}
+	assert.Contains(names(result.Resources[envoy.TypeCDS]), "bookstore/bookstore|14001|local")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
@@ -14,7 +15,6 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/utils/pointer"
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/compute/kube"
@@ -27,6 +27,7 @@ import (
 	envoySecrets "github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // configAttribute describes the attributes of the traffic
@@ -201,6 +202,12 @@ func (v *EnvoyConfigVerifier) verifySource() Result {
 			return result
 		}
 
+		if err := v.verifyListenerFiltersForService(svc, outboundListener.ListenerFilters, true); err != nil {
+			result.Status = Failure
+			result.Reason = fmt.Sprintf("Outbound listener filters are not configured correctly for service %q: %s", dst, err)
+			return result
+		}
+
 		if err := v.findHTTPRouteForService(svc, routeConfigs, true); err != nil {
 			result.Status = Failure
 			result.Reason = fmt.Sprintf("Did not find matching outbound route configuration for service %q: %s", dst, err)
@@ -336,6 +343,10 @@ func getFilterForProtocol(protocol string) string {
 	case constants.ProtocolTCP, constants.ProtocolHTTPS, constants.ProtocolTCPServerFirst:
 		return envoy.TCPProxyFilterName
 
+	case constants.ProtocolAuto:
+		// The filter chain named after the traffic match proxies traffic not detected as HTTP
+		return envoy.TCPProxyFilterName
+
 	default:
 		return ""
 	}
@@ -428,6 +439,11 @@ func (v *EnvoyConfigVerifier) verifyDestination() Result {
 			result.Reason = fmt.Sprintf("Did not find matching inbound filter chain for service %q: %s", dst, err)
 			return result
 		}
+		if err := v.verifyListenerFiltersForService(svc, inboundListener.ListenerFilters, false); err != nil {
+			result.Status = Failure
+			result.Reason = fmt.Sprintf("Inbound listener filters are not configured correctly for service %q: %s", dst, err)
+			return result
+		}
 		if err := v.findHTTPRouteForService(svc, routeConfigs, false); err != nil {
 			result.Status = Failure
 			result.Reason = fmt.Sprintf("Did not find matching inbound route configuration for service %q: %s", dst, err)
@@ -472,7 +488,7 @@ func (v *EnvoyConfigVerifier) getDstMeshServicesForK8sSvc(svc corev1.Service) ([
 			Namespace: svc.Namespace,
 			Name:      svc.Name,
 			Port:      uint16(portSpec.Port),
-			Protocol:  pointer.StringDeref(portSpec.AppProtocol, constants.ProtocolHTTP),
+			Protocol:  kube.GetServicePortProtocol(svc, portSpec),
 		}
 
 		// The endpoints for the kubernetes service carry information that allows
@@ -532,7 +548,7 @@ func (v *EnvoyConfigVerifier) findIngressFilterChainForService(svc *corev1.Servi
 	}
 
 	for _, ip := range chain.FilterChainMatch.SourcePrefixRanges {
-		if ip.PrefixLen.GetValue() == 32 {
+		if utils.IsHostPrefixLen(net.ParseIP(ip.AddressPrefix), ip.PrefixLen.GetValue()) {
 			sourceIPs[ip.AddressPrefix] = false
 		}
 	}
@@ -582,6 +598,18 @@ func findInboundFilterChainForServicePort(meshSvc service.MeshService, filterCha
 		}
 	}
 
+	isServerFirst := meshSvc.Protocol == constants.ProtocolTCPServerFirst
+	if filterChain == nil && isServerFirst {
+		// Server-first filter chains match on the destination port alone, so a single filter chain
+		// is shared by the services whose server-first ports have the same target port
+		for _, fc := range filterChains {
+			if isServerFirstFilterChainMatch(fc.FilterChainMatch) && fc.FilterChainMatch.DestinationPort.GetValue() == uint32(meshSvc.TargetPort) {
+				filterChain = fc
+				break
+			}
+		}
+	}
+
 	if filterChain == nil {
 		return fmt.Errorf("filter chain match %s not found", meshSvc.InboundTrafficMatchName())
 	}
@@ -590,6 +618,9 @@ func findInboundFilterChainForServicePort(meshSvc service.MeshService, filterCha
 	if filterChain.FilterChainMatch.DestinationPort.GetValue() != uint32(meshSvc.TargetPort) {
 		return fmt.Errorf("filter chain match not found for port %d", meshSvc.TargetPort)
 	}
+	if isServerFirst && !isServerFirstFilterChainMatch(filterChain.FilterChainMatch) {
+		return fmt.Errorf("filter chain match for server-first port %d must not match on TLS metadata", meshSvc.TargetPort)
+	}
 
 	// Verify the app protocol filter is present
 	filterName := getFilterForProtocol(meshSvc.Protocol)
@@ -610,6 +641,73 @@ func findInboundFilterChainForServicePort(meshSvc service.MeshService, filterCha
 	return nil
 }
 
+// isServerFirstFilterChainMatch returns true if the given filter chain match does not depend on
+// the TLS metadata inspected from the first bytes sent by the client
+func isServerFirstFilterChainMatch(match *xds_listener.FilterChainMatch) bool {
+	return match.GetTransportProtocol() == "" && len(match.GetServerNames()) == 0 && len(match.GetApplicationProtocols()) == 0
+}
+
+// verifyListenerFiltersForService verifies that the listener filters waiting on the first bytes sent by the
+// client are disabled for the server-first ports of the given service
+func (v *EnvoyConfigVerifier) verifyListenerFiltersForService(svc *corev1.Service, listenerFilters []*xds_listener.ListenerFilter, isOutbound bool) error {
+	if svc == nil {
+		return nil
+	}
+
+	meshServices, err := v.getDstMeshServicesForK8sSvc(*svc)
+	if len(meshServices) == 0 || err != nil {
+		return fmt.Errorf("endpoints not found for service %s/%s, err: %w", svc.Namespace, svc.Name, err)
+	}
+
+	for _, meshSvc := range meshServices {
+		if meshSvc.Protocol != constants.ProtocolTCPServerFirst {
+			continue
+		}
+		port := meshSvc.TargetPort
+		if isOutbound {
+			port = meshSvc.Port
+		}
+		if err := verifyListenerFiltersDisabledForPort(listenerFilters, port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyListenerFiltersDisabledForPort verifies that the TLS and HTTP inspector listener filters are
+// disabled for the given port
+func verifyListenerFiltersDisabledForPort(listenerFilters []*xds_listener.ListenerFilter, port uint16) error {
+	for _, filter := range listenerFilters {
+		if filter.Name != envoy.TLSInspectorFilterName && filter.Name != envoy.HTTPInspectorFilterName {
+			continue
+		}
+		if !matchPredicateContainsPort(filter.FilterDisabled, port) {
+			return fmt.Errorf("listener filter %s is not disabled for server-first port %d", filter.Name, port)
+		}
+	}
+
+	return nil
+}
+
+// matchPredicateContainsPort returns true if the given match predicate matches the given destination port
+func matchPredicateContainsPort(predicate *xds_listener.ListenerFilterChainMatchPredicate, port uint16) bool {
+	if predicate == nil {
+		return false
+	}
+
+	if portRange := predicate.GetDestinationPortRange(); portRange != nil {
+		return int32(port) >= portRange.Start && int32(port) < portRange.End
+	}
+	for _, rule := range predicate.GetOrMatch().GetRules() {
+		if matchPredicateContainsPort(rule, port) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (v *EnvoyConfigVerifier) findHTTPRouteForService(svc *corev1.Service, routeConfigs []*xds_route.RouteConfiguration, isOutbound bool) error {
 	if svc == nil {
 		return nil
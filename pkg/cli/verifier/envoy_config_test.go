@@ -0,0 +1,147 @@
+package verifier
+
+import (
+	"testing"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestFindInboundFilterChainForServicePort(t *testing.T) {
+	serverFirstSvc := service.MeshService{
+		Namespace:  "ns1",
+		Name:       "mysql",
+		Port:       3306,
+		TargetPort: 3306,
+		Protocol:   constants.ProtocolTCPServerFirst,
+	}
+	tcpProxyFilters := []*xds_listener.Filter{{Name: envoy.TCPProxyFilterName}}
+
+	testCases := []struct {
+		name         string
+		filterChains []*xds_listener.FilterChain
+		expectErr    bool
+	}{
+		{
+			name: "server-first filter chain matching on the port alone",
+			filterChains: []*xds_listener.FilterChain{
+				{
+					Name:             serverFirstSvc.InboundTrafficMatchName(),
+					FilterChainMatch: &xds_listener.FilterChainMatch{DestinationPort: &wrapperspb.UInt32Value{Value: 3306}},
+					Filters:          tcpProxyFilters,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "server-first filter chain shared with another service",
+			filterChains: []*xds_listener.FilterChain{
+				{
+					Name:             "inbound_ns1/mysql-replica_3306_tcp-server-first",
+					FilterChainMatch: &xds_listener.FilterChainMatch{DestinationPort: &wrapperspb.UInt32Value{Value: 3306}},
+					Filters:          tcpProxyFilters,
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "server-first filter chain matching on TLS metadata",
+			filterChains: []*xds_listener.FilterChain{
+				{
+					Name: serverFirstSvc.InboundTrafficMatchName(),
+					FilterChainMatch: &xds_listener.FilterChainMatch{
+						DestinationPort:   &wrapperspb.UInt32Value{Value: 3306},
+						TransportProtocol: envoy.TransportProtocolTLS,
+					},
+					Filters: tcpProxyFilters,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "no filter chain for the server-first port",
+			filterChains: []*xds_listener.FilterChain{
+				{
+					Name:             "inbound_ns1/smtp_25_tcp-server-first",
+					FilterChainMatch: &xds_listener.FilterChainMatch{DestinationPort: &wrapperspb.UInt32Value{Value: 25}},
+					Filters:          tcpProxyFilters,
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			err := findInboundFilterChainForServicePort(serverFirstSvc, tc.filterChains)
+			assert.Equal(tc.expectErr, err != nil, err)
+		})
+	}
+}
+
+func TestVerifyListenerFiltersDisabledForPort(t *testing.T) {
+	portRange := func(port int32) *xds_listener.ListenerFilterChainMatchPredicate {
+		return &xds_listener.ListenerFilterChainMatchPredicate{
+			Rule: &xds_listener.ListenerFilterChainMatchPredicate_DestinationPortRange{
+				DestinationPortRange: &xds_type.Int32Range{Start: port, End: port + 1},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		listenerFilters []*xds_listener.ListenerFilter
+		expectErr       bool
+	}{
+		{
+			name: "inspectors disabled for the port",
+			listenerFilters: []*xds_listener.ListenerFilter{
+				{Name: envoy.OriginalDstFilterName},
+				{Name: envoy.TLSInspectorFilterName, FilterDisabled: portRange(3306)},
+				{
+					Name: envoy.HTTPInspectorFilterName,
+					FilterDisabled: &xds_listener.ListenerFilterChainMatchPredicate{
+						Rule: &xds_listener.ListenerFilterChainMatchPredicate_OrMatch{
+							OrMatch: &xds_listener.ListenerFilterChainMatchPredicate_MatchSet{
+								Rules: []*xds_listener.ListenerFilterChainMatchPredicate{portRange(25), portRange(3306)},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "inspector not disabled",
+			listenerFilters: []*xds_listener.ListenerFilter{
+				{Name: envoy.OriginalDstFilterName},
+				{Name: envoy.TLSInspectorFilterName},
+			},
+			expectErr: true,
+		},
+		{
+			name: "inspector disabled for another port",
+			listenerFilters: []*xds_listener.ListenerFilter{
+				{Name: envoy.TLSInspectorFilterName, FilterDisabled: portRange(25)},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			err := verifyListenerFiltersDisabledForPort(tc.listenerFilters, 3306)
+			assert.Equal(tc.expectErr, err != nil, err)
+		})
+	}
+}
@@ -54,9 +54,10 @@ func (v *SidecarVerifier) Run() Result {
 		return result
 	}
 
-	// Check if the Envoy sidecar is present
+	// Check if the Envoy sidecar is present, either as a container or as a native sidecar container
+	// (an init container with an Always restart policy)
 	foundEnvoy := false
-	for _, container := range p.Spec.Containers {
+	for _, container := range append(p.Spec.Containers, p.Spec.InitContainers...) {
 		if container.Name == constants.EnvoyContainerName {
 			foundEnvoy = true
 			break
@@ -0,0 +1,84 @@
+package verifier
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestSidecarVerifier(t *testing.T) {
+	testPod := types.NamespacedName{Namespace: "test", Name: "pod"}
+
+	testCases := []struct {
+		name           string
+		pod            *corev1.Pod
+		verifyAbsence  bool
+		expectedStatus Status
+	}{
+		{
+			name: "sidecar container found",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testPod.Namespace, Name: testPod.Name},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}, {Name: constants.EnvoyContainerName}},
+				},
+			},
+			expectedStatus: Success,
+		},
+		{
+			name: "native sidecar container found",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testPod.Namespace, Name: testPod.Name},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: constants.InitContainerName}, {Name: constants.EnvoyContainerName}},
+					Containers:     []corev1.Container{{Name: "app"}},
+				},
+			},
+			expectedStatus: Success,
+		},
+		{
+			name: "sidecar not found",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testPod.Namespace, Name: testPod.Name},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app"}},
+				},
+			},
+			expectedStatus: Failure,
+		},
+		{
+			name: "native sidecar container found when absence expected",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testPod.Namespace, Name: testPod.Name},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: constants.EnvoyContainerName}},
+					Containers:     []corev1.Container{{Name: "app"}},
+				},
+			},
+			verifyAbsence:  true,
+			expectedStatus: Failure,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			var opts []SidecarVerifierOpt
+			if tc.verifyAbsence {
+				opts = append(opts, WithVerifyAbsence())
+			}
+			v := NewSidecarVerifier(new(bytes.Buffer), new(bytes.Buffer), fake.NewSimpleClientset(tc.pod), testPod, opts...)
+
+			result := v.Run()
+			a.Equal(tc.expectedStatus, result.Status)
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package cni
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// kubeconfigFileName is the name of the kubeconfig file written to the CNI network configuration directory of
+	// the node, used by the osm-cni plugin to get the pods and the MeshConfig
+	kubeconfigFileName = "osm-cni.kubeconfig"
+
+	// monitoredNamespacesFileName is the name of the file written to the CNI network configuration directory of the
+	// node with the namespaces monitored by the mesh, used by the osm-cni plugin to skip the pods of the other
+	// namespaces without requests to the Kubernetes API server
+	monitoredNamespacesFileName = "osm-cni.namespaces"
+
+	// tmpFileSuffix is the suffix of the temporary files written before being atomically renamed, which must not be
+	// loaded by the container runtime as network configurations
+	tmpFileSuffix = ".osm-cni-tmp"
+)
+
+// Installer is the type used to install the osm-cni plugin on a node, chaining it to the plugins of the node's
+// network configuration list
+type Installer struct {
+	// BinarySource is the path of the osm-cni binary to install
+	BinarySource string
+
+	// BinDir is the path of the node's CNI plugin binary directory, as mounted in the installer container
+	BinDir string
+
+	// ConfDir is the path of the node's CNI network configuration directory, as mounted in the installer container
+	ConfDir string
+
+	// HostConfDir is the path of the node's CNI network configuration directory on the node, referenced by the
+	// osm-cni plugin configuration
+	HostConfDir string
+
+	// KubernetesHost is the address of the Kubernetes API server as reachable from the node, i.e. host:port
+	KubernetesHost string
+
+	// CAFile is the path of the CA bundle of the Kubernetes API server
+	CAFile string
+
+	// TokenFile is the path of the service account token the osm-cni plugin authenticates with
+	TokenFile string
+
+	// OSMNamespace is the namespace of the MeshConfig
+	OSMNamespace string
+
+	// MeshConfigName is the name of the MeshConfig
+	MeshConfigName string
+
+	// LogLevel is the log verbosity of the osm-cni plugin
+	LogLevel string
+
+	// ExcludeNamespaces is the list of namespaces whose pods are skipped by the osm-cni plugin
+	ExcludeNamespaces []string
+
+	// MeshName is the name of the mesh monitoring the namespaces whose pods are processed by the osm-cni plugin
+	MeshName string
+
+	// NodeName is the name of the node the osm-cni plugin is installed on
+	NodeName string
+
+	// KubeClient is the client used to label the node and to watch the namespaces monitored by the mesh
+	KubeClient kubernetes.Interface
+
+	// namespaceStore holds the namespaces monitored by the mesh once they are watched
+	namespaceStore cache.Store
+
+	// namespacesMutex serializes the writes of the monitored namespaces file
+	namespacesMutex sync.Mutex
+}
+
+// Install installs the osm-cni binary and its kubeconfig, adds the osm-cni plugin to the node's network
+// configuration list, and labels the node so that the pods relying on the plugin can be scheduled on it
+func (i *Installer) Install() error {
+	if err := i.installBinary(); err != nil {
+		return err
+	}
+	return i.Refresh()
+}
+
+// Refresh refreshes the service account token of the osm-cni plugin's kubeconfig and the monitored namespaces file,
+// restores the plugin in the node's network configuration list if the primary CNI plugin overwrote it, and restores
+// the node's label. It is run periodically once installed.
+func (i *Installer) Refresh() error {
+	if err := i.writeKubeconfig(); err != nil {
+		return err
+	}
+	if err := i.writeMonitoredNamespaces(); err != nil {
+		return err
+	}
+	if err := i.addPluginConfig(); err != nil {
+		return err
+	}
+	return i.labelNode(true)
+}
+
+// Uninstall unlabels the node, and removes the osm-cni plugin from the node's network configuration list, as well as
+// its files and binary
+func (i *Installer) Uninstall() error {
+	// No new pod relying on the plugin must be scheduled on the node once the plugin is removed
+	if err := i.labelNode(false); err != nil {
+		return err
+	}
+	if err := i.removePluginConfig(); err != nil {
+		return err
+	}
+	for _, file := range []string{kubeconfigFileName, monitoredNamespacesFileName} {
+		if err := os.Remove(filepath.Join(i.ConfDir, file)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Remove(filepath.Join(i.BinDir, PluginName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	log.Info().Msg("Uninstalled the osm-cni plugin")
+	return nil
+}
+
+// installBinary copies the osm-cni binary to the node's CNI plugin binary directory
+func (i *Installer) installBinary() error {
+	src, err := os.Open(i.BinarySource)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", i.BinarySource, err)
+	}
+	//nolint: errcheck
+	//#nosec G307
+	defer src.Close()
+
+	var binary bytes.Buffer
+	if _, err := io.Copy(&binary, src); err != nil {
+		return fmt.Errorf("error reading %s: %w", i.BinarySource, err)
+	}
+	return writeFileAtomically(filepath.Join(i.BinDir, PluginName), binary.Bytes(), 0755)
+}
+
+// writeKubeconfig writes the kubeconfig file the osm-cni plugin authenticates with to the Kubernetes API server
+func (i *Installer) writeKubeconfig() error {
+	caData, err := os.ReadFile(i.CAFile)
+	if err != nil {
+		return fmt.Errorf("error reading the Kubernetes API server CA bundle: %w", err)
+	}
+	token, err := os.ReadFile(i.TokenFile)
+	if err != nil {
+		return fmt.Errorf("error reading the service account token: %w", err)
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[PluginName] = &clientcmdapi.Cluster{
+		Server:                   "https://" + i.KubernetesHost,
+		CertificateAuthorityData: caData,
+	}
+	config.AuthInfos[PluginName] = &clientcmdapi.AuthInfo{
+		Token: strings.TrimSpace(string(token)),
+	}
+	config.Contexts[PluginName] = &clientcmdapi.Context{
+		Cluster:  PluginName,
+		AuthInfo: PluginName,
+	}
+	config.CurrentContext = PluginName
+
+	kubeconfig, err := clientcmd.Write(*config)
+	if err != nil {
+		return fmt.Errorf("error encoding the kubeconfig: %w", err)
+	}
+	return writeFileAtomically(filepath.Join(i.ConfDir, kubeconfigFileName), kubeconfig, 0600)
+}
+
+// WatchMonitoredNamespaces watches the namespaces monitored by the mesh until the given channel is closed, writing
+// them to the monitored namespaces file read by the osm-cni plugin whenever they change
+func (i *Installer) WatchMonitoredNamespaces(stop <-chan struct{}) {
+	labelSelector := fields.SelectorFromSet(map[string]string{constants.OSMKubeResourceMonitorAnnotation: i.MeshName}).String()
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(i.KubeClient, 0, informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+		opts.LabelSelector = labelSelector
+	}))
+	informer := informerFactory.Core().V1().Namespaces().Informer()
+
+	write := func() {
+		if err := i.writeMonitoredNamespaces(); err != nil {
+			log.Error().Err(err).Msg("Error writing the namespaces monitored by the mesh")
+		}
+	}
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { write() },
+		UpdateFunc: func(interface{}, interface{}) {},
+		DeleteFunc: func(interface{}) { write() },
+	})
+
+	informerFactory.Start(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		return
+	}
+
+	// The plugin only skips the pods of unmonitored namespaces once all the monitored namespaces are known
+	i.namespacesMutex.Lock()
+	i.namespaceStore = informer.GetStore()
+	i.namespacesMutex.Unlock()
+	write()
+}
+
+// writeMonitoredNamespaces writes the namespaces monitored by the mesh to the monitored namespaces file, once they are
+// watched. The file is rewritten even if they did not change, its modification time telling the plugin it is current.
+func (i *Installer) writeMonitoredNamespaces() error {
+	i.namespacesMutex.Lock()
+	defer i.namespacesMutex.Unlock()
+
+	if i.namespaceStore == nil {
+		return nil
+	}
+	namespaces := i.namespaceStore.ListKeys()
+	sort.Strings(namespaces)
+	data, err := json.Marshal(namespaces)
+	if err != nil {
+		return fmt.Errorf("error encoding the monitored namespaces: %w", err)
+	}
+	return writeFileAtomically(filepath.Join(i.ConfDir, monitoredNamespacesFileName), data, 0644)
+}
+
+// labelNode sets or removes the label of the node telling the osm-cni plugin is installed on it
+func (i *Installer) labelNode(installed bool) error {
+	if i.KubeClient == nil {
+		return nil
+	}
+
+	value := "null"
+	if installed {
+		value = `"true"`
+	}
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%s}}}`, constants.CNIInstalledNodeLabel, value)
+	if _, err := i.KubeClient.CoreV1().Nodes().Patch(context.Background(), i.NodeName, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("error labeling node %s: %w", i.NodeName, err)
+	}
+	return nil
+}
+
+// pluginConfig returns the configuration of the osm-cni plugin in the node's network configuration list
+func (i *Installer) pluginConfig() map[string]interface{} {
+	conf := map[string]interface{}{
+		"type":                    PluginName,
+		"kubeconfig":              filepath.Join(i.HostConfDir, kubeconfigFileName),
+		"osmNamespace":            i.OSMNamespace,
+		"meshConfigName":          i.MeshConfigName,
+		"monitoredNamespacesFile": filepath.Join(i.HostConfDir, monitoredNamespacesFileName),
+	}
+	if len(i.ExcludeNamespaces) > 0 {
+		conf["excludeNamespaces"] = i.ExcludeNamespaces
+	}
+	if i.LogLevel != "" {
+		conf["logLevel"] = i.LogLevel
+	}
+	return conf
+}
+
+// addPluginConfig adds the osm-cni plugin to the end of the chain of the node's network configuration list, the one
+// used by the container runtime. A single network configuration is converted to a network configuration list.
+func (i *Installer) addPluginConfig() error {
+	confFile, err := getNetworkConfigFile(i.ConfDir)
+	if err != nil {
+		return err
+	}
+	confList, err := readNetworkConfigList(confFile)
+	if err != nil {
+		return err
+	}
+
+	plugins, _ := confList["plugins"].([]interface{})
+	var chained []interface{}
+	for _, p := range plugins {
+		if !isOSMPlugin(p) {
+			chained = append(chained, p)
+		}
+	}
+	confList["plugins"] = append(chained, i.pluginConfig())
+
+	confListFile := strings.TrimSuffix(confFile, filepath.Ext(confFile)) + ".conflist"
+	updated, err := writeNetworkConfigList(confListFile, confList)
+	if err != nil {
+		return err
+	}
+	if confListFile != confFile {
+		// The single network configuration converted to a network configuration list would take precedence
+		if err := os.Remove(confFile); err != nil {
+			return fmt.Errorf("error removing %s: %w", confFile, err)
+		}
+	}
+	if updated {
+		log.Info().Msgf("Added the osm-cni plugin to network configuration list %s", confListFile)
+	}
+	return nil
+}
+
+// removePluginConfig removes the osm-cni plugin from the node's network configuration lists
+func (i *Installer) removePluginConfig() error {
+	files, err := filepath.Glob(filepath.Join(i.ConfDir, "*.conflist"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		confList, err := readNetworkConfigList(file)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error reading network configuration list %s, skipping", file)
+			continue
+		}
+
+		plugins, _ := confList["plugins"].([]interface{})
+		var chained []interface{}
+		for _, p := range plugins {
+			if !isOSMPlugin(p) {
+				chained = append(chained, p)
+			}
+		}
+		if len(chained) == len(plugins) {
+			continue
+		}
+		confList["plugins"] = chained
+		if _, err := writeNetworkConfigList(file, confList); err != nil {
+			return err
+		}
+		log.Info().Msgf("Removed the osm-cni plugin from network configuration list %s", file)
+	}
+	return nil
+}
+
+// getNetworkConfigFile returns the path of the network configuration used by the container runtime, the first one
+// in lexicographic order as per libcni
+func getNetworkConfigFile(confDir string) (string, error) {
+	entries, err := os.ReadDir(confDir)
+	if err != nil {
+		return "", fmt.Errorf("error reading the CNI network configuration directory %s: %w", confDir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch filepath.Ext(entry.Name()) {
+		case ".conf", ".conflist", ".json":
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no CNI network configuration found in %s", confDir)
+	}
+	sort.Strings(files)
+	return filepath.Join(confDir, files[0]), nil
+}
+
+// readNetworkConfigList returns the network configuration list in the given file, converting a single network
+// configuration to a list
+func readNetworkConfigList(file string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(file) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("error reading network configuration %s: %w", file, err)
+	}
+	var conf map[string]interface{}
+	if err := json.Unmarshal(data, &conf); err != nil {
+		return nil, fmt.Errorf("error decoding network configuration %s: %w", file, err)
+	}
+
+	if _, ok := conf["plugins"]; ok {
+		return conf, nil
+	}
+	if _, ok := conf["type"]; !ok {
+		return nil, fmt.Errorf("network configuration %s has neither plugins nor type", file)
+	}
+	return map[string]interface{}{
+		"cniVersion": conf["cniVersion"],
+		"name":       conf["name"],
+		"plugins":    []interface{}{conf},
+	}, nil
+}
+
+// writeNetworkConfigList writes the given network configuration list to the given file, returning whether its
+// content changed
+func writeNetworkConfigList(file string, confList map[string]interface{}) (bool, error) {
+	data, err := json.MarshalIndent(confList, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("error encoding network configuration list %s: %w", file, err)
+	}
+	if current, err := os.ReadFile(file); err == nil && bytes.Equal(current, data) { // #nosec G304
+		return false, nil
+	}
+	return true, writeFileAtomically(file, data, 0644)
+}
+
+// isOSMPlugin returns whether the given plugin configuration is the osm-cni plugin's
+func isOSMPlugin(plugin interface{}) bool {
+	conf, ok := plugin.(map[string]interface{})
+	return ok && conf["type"] == PluginName
+}
+
+// writeFileAtomically writes the given data to the given file through a temporary file renamed once written, so that
+// the container runtime never reads a partially written file
+func writeFileAtomically(file string, data []byte, perm os.FileMode) error {
+	tmp := file + tmpFileSuffix
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return fmt.Errorf("error writing %s: %w", tmp, err)
+	}
+	if err := os.Chmod(tmp, perm); err != nil {
+		return fmt.Errorf("error setting the permissions of %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		return fmt.Errorf("error renaming %s to %s: %w", tmp, file, err)
+	}
+	return nil
+}
+
+// KubernetesHostFromEnv returns the address of the Kubernetes API server given by the environment of the pods
+func KubernetesHostFromEnv() (string, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return "", fmt.Errorf("KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set")
+	}
+	return net.JoinHostPort(host, port), nil
+}
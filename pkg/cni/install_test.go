@@ -0,0 +1,193 @@
+package cni
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const testConfList = `{
+  "cniVersion": "0.4.0",
+  "name": "k8s-pod-network",
+  "plugins": [
+    {"type": "calico", "ipam": {"type": "calico-ipam"}},
+    {"type": "portmap", "capabilities": {"portMappings": true}}
+  ]
+}`
+
+func newTestInstaller(t *testing.T) *Installer {
+	dir := t.TempDir()
+	installer := &Installer{
+		BinarySource:   filepath.Join(dir, "osm-cni-src"),
+		BinDir:         filepath.Join(dir, "bin"),
+		ConfDir:        filepath.Join(dir, "net.d"),
+		HostConfDir:    "/etc/cni/net.d",
+		KubernetesHost: "10.0.0.1:443",
+		CAFile:         filepath.Join(dir, "ca.crt"),
+		TokenFile:      filepath.Join(dir, "token"),
+		OSMNamespace:   "osm-system",
+		MeshConfigName: "osm-mesh-config",
+		LogLevel:       "debug",
+		MeshName:       "osm",
+		NodeName:       "node-1",
+		KubeClient: fakeKube.NewSimpleClientset(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		}),
+	}
+
+	for _, d := range []string{installer.BinDir, installer.ConfDir} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for file, content := range map[string]string{
+		installer.BinarySource: "binary",
+		installer.CAFile:       "ca",
+		installer.TokenFile:    "token\n",
+	} {
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return installer
+}
+
+func readPluginTypes(t *testing.T, file string) []string {
+	data, err := os.ReadFile(file) // #nosec G304
+	if err != nil {
+		t.Fatal(err)
+	}
+	var confList struct {
+		Plugins []struct {
+			Type string `json:"type"`
+		} `json:"plugins"`
+	}
+	if err := json.Unmarshal(data, &confList); err != nil {
+		t.Fatal(err)
+	}
+
+	var types []string
+	for _, p := range confList.Plugins {
+		types = append(types, p.Type)
+	}
+	return types
+}
+
+func TestInstall(t *testing.T) {
+	assert := tassert.New(t)
+	installer := newTestInstaller(t)
+
+	confListFile := filepath.Join(installer.ConfDir, "10-calico.conflist")
+	assert.NoError(os.WriteFile(confListFile, []byte(testConfList), 0600))
+	// Only the first network configuration in lexicographic order is used by the container runtime
+	otherConfListFile := filepath.Join(installer.ConfDir, "20-other.conflist")
+	assert.NoError(os.WriteFile(otherConfListFile, []byte(testConfList), 0600))
+
+	assert.NoError(installer.Install())
+
+	binary, err := os.ReadFile(filepath.Join(installer.BinDir, PluginName))
+	assert.NoError(err)
+	assert.Equal("binary", string(binary))
+
+	kubeconfig, err := clientcmd.LoadFromFile(filepath.Join(installer.ConfDir, kubeconfigFileName))
+	assert.NoError(err)
+	assert.Equal("https://10.0.0.1:443", kubeconfig.Clusters[PluginName].Server)
+	assert.Equal("token", kubeconfig.AuthInfos[PluginName].Token)
+
+	assert.Equal([]string{"calico", "portmap", PluginName}, readPluginTypes(t, confListFile))
+	assert.Equal([]string{"calico", "portmap"}, readPluginTypes(t, otherConfListFile))
+
+	// The node is labeled once the plugin is installed
+	node, err := installer.KubeClient.CoreV1().Nodes().Get(context.Background(), installer.NodeName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal("true", node.Labels[constants.CNIInstalledNodeLabel])
+
+	// Installing again does not chain the plugin twice
+	assert.NoError(installer.Refresh())
+	assert.Equal([]string{"calico", "portmap", PluginName}, readPluginTypes(t, confListFile))
+
+	// The plugin is restored when the primary CNI plugin overwrites its network configuration
+	assert.NoError(os.WriteFile(confListFile, []byte(testConfList), 0600))
+	assert.NoError(installer.Refresh())
+	assert.Equal([]string{"calico", "portmap", PluginName}, readPluginTypes(t, confListFile))
+
+	assert.NoError(installer.Uninstall())
+	assert.Equal([]string{"calico", "portmap"}, readPluginTypes(t, confListFile))
+	node, err = installer.KubeClient.CoreV1().Nodes().Get(context.Background(), installer.NodeName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.NotContains(node.Labels, constants.CNIInstalledNodeLabel)
+	assert.NoFileExists(filepath.Join(installer.BinDir, PluginName))
+	assert.NoFileExists(filepath.Join(installer.ConfDir, kubeconfigFileName))
+}
+
+func TestInstallConvertsNetworkConfig(t *testing.T) {
+	assert := tassert.New(t)
+	installer := newTestInstaller(t)
+
+	confFile := filepath.Join(installer.ConfDir, "10-bridge.conf")
+	assert.NoError(os.WriteFile(confFile, []byte(`{"cniVersion":"0.4.0","name":"bridge","type":"bridge"}`), 0600))
+
+	assert.NoError(installer.Install())
+
+	assert.NoFileExists(confFile)
+	assert.Equal([]string{"bridge", PluginName}, readPluginTypes(t, filepath.Join(installer.ConfDir, "10-bridge.conflist")))
+}
+
+func TestInstallWithoutNetworkConfig(t *testing.T) {
+	assert := tassert.New(t)
+	installer := newTestInstaller(t)
+
+	assert.Error(installer.Install())
+}
+
+func TestWatchMonitoredNamespaces(t *testing.T) {
+	assert := tassert.New(t)
+	installer := newTestInstaller(t)
+
+	for name, labels := range map[string]map[string]string{
+		"bookstore": {constants.OSMKubeResourceMonitorAnnotation: "osm"},
+		"bookbuyer": {constants.OSMKubeResourceMonitorAnnotation: "osm"},
+		"other":     {constants.OSMKubeResourceMonitorAnnotation: "other-mesh"},
+		"unmeshed":  nil,
+	} {
+		_, err := installer.KubeClient.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		}, metav1.CreateOptions{})
+		assert.NoError(err)
+	}
+
+	readMonitoredNamespaces := func() []string {
+		data, err := os.ReadFile(filepath.Join(installer.ConfDir, monitoredNamespacesFileName))
+		if err != nil {
+			return nil
+		}
+		var namespaces []string
+		assert.NoError(json.Unmarshal(data, &namespaces))
+		return namespaces
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go installer.WatchMonitoredNamespaces(stop)
+
+	assert.Eventually(func() bool {
+		return tassert.ObjectsAreEqual([]string{"bookbuyer", "bookstore"}, readMonitoredNamespaces())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Namespaces leaving the mesh are removed from the file
+	assert.NoError(installer.KubeClient.CoreV1().Namespaces().Delete(context.Background(), "bookbuyer", metav1.DeleteOptions{}))
+	assert.Eventually(func() bool {
+		return tassert.ObjectsAreEqual([]string{"bookstore"}, readMonitoredNamespaces())
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,72 @@
+//go:build linux
+
+package cni
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// programRulesInNetNS programs the given rules in the network namespace at the given path, running the given command
+// of the node with the rules as input
+func programRulesInNetNS(netns string, command []string, rules string) error {
+	return inNetNS(netns, func() error {
+		cmd := exec.Command(command[0], command[1:]...) // #nosec G204
+		cmd.Stdin = bytes.NewBufferString(rules)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("error running %s: %w, output: %s", command[0], err, output)
+		}
+		return nil
+	})
+}
+
+// inNetNS runs the given function on an OS thread switched to the network namespace at the given path. The processes
+// started by the function inherit the network namespace of the thread.
+func inNetNS(netns string, f func() error) error {
+	errc := make(chan error, 1)
+
+	// The function runs on a dedicated goroutine locked to its thread, which is terminated when the goroutine exits
+	// if it could not switch back to the original network namespace
+	go func() {
+		runtime.LockOSThread()
+
+		hostNS, err := os.Open(fmt.Sprintf("/proc/%d/task/%d/ns/net", os.Getpid(), unix.Gettid()))
+		if err != nil {
+			errc <- fmt.Errorf("error opening the current network namespace: %w", err)
+			return
+		}
+		//nolint: errcheck
+		//#nosec G307
+		defer hostNS.Close()
+
+		podNS, err := os.Open(netns) // #nosec G304
+		if err != nil {
+			errc <- fmt.Errorf("error opening network namespace %s: %w", netns, err)
+			return
+		}
+		//nolint: errcheck
+		//#nosec G307
+		defer podNS.Close()
+
+		if err := unix.Setns(int(podNS.Fd()), unix.CLONE_NEWNET); err != nil {
+			errc <- fmt.Errorf("error switching to network namespace %s: %w", netns, err)
+			return
+		}
+
+		err = f()
+
+		if restoreErr := unix.Setns(int(hostNS.Fd()), unix.CLONE_NEWNET); restoreErr != nil {
+			log.Error().Err(restoreErr).Msg("Error switching back to the original network namespace")
+		} else {
+			runtime.UnlockOSThread()
+		}
+		errc <- err
+	}()
+
+	return <-errc
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package cni
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// programRulesInNetNS programs the given rules in the network namespace at the given path, which is only supported on
+// Linux
+func programRulesInNetNS(netns string, _ []string, _ string) error {
+	return fmt.Errorf("programming the traffic redirection rules in network namespace %s is not supported on %s", netns, runtime.GOOS)
+}
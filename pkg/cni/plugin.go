@@ -0,0 +1,328 @@
+package cni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	"github.com/openservicemesh/osm/pkg/injector"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// apiTimeout is the timeout of the requests to the Kubernetes API server made when a pod sandbox is created
+	apiTimeout = 10 * time.Second
+
+	// monitoredNamespacesMaxAge is the age past which the monitored namespaces file is considered outdated, the
+	// installer no longer refreshing it. It must be greater than the refresh interval of the installer.
+	monitoredNamespacesMaxAge = 5 * time.Minute
+)
+
+// plugin is the type used to run the commands of the osm-cni plugin
+type plugin struct {
+	// newClients returns the Kubernetes and OSM config clients authenticating with the given kubeconfig file
+	newClients func(kubeconfig string) (kubernetes.Interface, configClientset.Interface, error)
+
+	// programRules programs the given rules in the network namespace at the given path, running the given command with
+	// the rules as input
+	programRules func(netns string, command []string, rules string) error
+}
+
+// Run runs the CNI command given by the environment on the network configuration read from stdin, and writes its
+// result to stdout. Errors are also written to stdout, as defined by the CNI specification.
+func Run(getenv func(string) string, stdin io.Reader, stdout io.Writer) error {
+	p := &plugin{
+		newClients:   newClients,
+		programRules: programRulesInNetNS,
+	}
+
+	result, err := p.run(getenv, stdin)
+	if err != nil {
+		e, ok := err.(*cniError)
+		if !ok {
+			e = &cniError{CNIVersion: defaultCNIVersion, Code: errCodeInternal, Msg: err.Error()}
+		}
+		//nolint: errcheck
+		json.NewEncoder(stdout).Encode(e)
+		return err
+	}
+
+	if len(result) > 0 {
+		if _, err := stdout.Write(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *plugin) run(getenv func(string) string, stdin io.Reader) ([]byte, error) {
+	args, err := parseCmdArgs(getenv)
+	if err != nil {
+		return nil, &cniError{CNIVersion: defaultCNIVersion, Code: errCodeInvalidEnvVars, Msg: "invalid CNI environment variables", Details: err.Error()}
+	}
+
+	if args.command == cmdVersion {
+		return json.Marshal(map[string]interface{}{
+			"cniVersion":        defaultCNIVersion,
+			"supportedVersions": supportedVersions,
+		})
+	}
+
+	conf, err := parseNetConf(stdin)
+	if err != nil {
+		return nil, err
+	}
+	if conf.LogLevel != "" {
+		if err := logger.SetLogLevel(conf.LogLevel); err != nil {
+			log.Error().Err(err).Msgf("Invalid log level %q", conf.LogLevel)
+		}
+	}
+
+	switch args.command {
+	case cmdAdd:
+		if err := p.add(args, conf); err != nil {
+			log.Error().Err(err).Msgf("Error programming the traffic redirection of pod %s/%s in sandbox %s", args.podNamespace, args.podName, args.containerID)
+			return nil, &cniError{CNIVersion: conf.CNIVersion, Code: errCodeInternal, Msg: "error programming the traffic redirection", Details: err.Error()}
+		}
+		return passThroughResult(conf)
+
+	case cmdDel, cmdCheck:
+		// The rules are removed along with the network namespace of the pod
+		return nil, nil
+
+	default:
+		return nil, &cniError{CNIVersion: conf.CNIVersion, Code: errCodeInvalidEnvVars, Msg: fmt.Sprintf("unknown CNI command %q", args.command)}
+	}
+}
+
+// add programs the traffic redirection of the pod whose sandbox is created, if it was injected without the init
+// container programming the rules. The pods of excluded and unmonitored namespaces are skipped without requests to the
+// Kubernetes API server, so that they are not affected by its unavailability. Otherwise errors fail the creation of
+// the sandbox, which is retried, since the traffic of a meshed pod must never bypass its sidecar.
+func (p *plugin) add(args cmdArgs, conf *NetConf) error {
+	if args.podName == "" || args.podNamespace == "" {
+		log.Debug().Msgf("Sandbox %s is not a Kubernetes pod sandbox, skipping", args.containerID)
+		return nil
+	}
+	if isNamespaceSkipped(args.podNamespace, conf) {
+		log.Debug().Msgf("Pod %s/%s is not in a namespace monitored by the mesh, skipping", args.podNamespace, args.podName)
+		return nil
+	}
+
+	kubeClient, configClient, err := p.newClients(conf.Kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error creating the Kubernetes clients from %s: %w", conf.Kubeconfig, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	defer cancel()
+
+	pod, err := kubeClient.CoreV1().Pods(args.podNamespace).Get(ctx, args.podName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		log.Debug().Msgf("Pod %s/%s was deleted, skipping", args.podNamespace, args.podName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting pod %s/%s: %w", args.podNamespace, args.podName, err)
+	}
+	if !injector.RequiresCNIRedirection(pod) {
+		log.Debug().Msgf("Pod %s/%s does not require its traffic redirection to be programmed, skipping", args.podNamespace, args.podName)
+		return nil
+	}
+
+	meshConfig, err := configClient.ConfigV1alpha2().MeshConfigs(conf.OSMNamespace).Get(ctx, conf.MeshConfigName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting MeshConfig %s/%s: %w", conf.OSMNamespace, conf.MeshConfigName, err)
+	}
+
+	// The rules are programmed for each IP family of the pod
+	podIPs, err := getPodIPs(conf.PrevResult)
+	if err != nil {
+		return err
+	}
+
+	redirectionRules, err := injector.GenerateRedirectionRules(pod, *meshConfig, podIPs)
+	if err != nil {
+		return fmt.Errorf("error generating the traffic redirection rules of pod %s/%s: %w", args.podNamespace, args.podName, err)
+	}
+	for _, r := range redirectionRules {
+		if err := p.programRules(args.netns, r.Command, r.Rules); err != nil {
+			return fmt.Errorf("error programming the %s traffic redirection rules in network namespace %s: %w", r.IPFamily, args.netns, err)
+		}
+	}
+
+	log.Info().Msgf("Programmed the traffic redirection of pod %s/%s in network namespace %s", args.podNamespace, args.podName, args.netns)
+	return nil
+}
+
+// isNamespaceSkipped returns whether the pods of the given namespace are skipped, the namespace being excluded or not
+// monitored by the mesh. The monitored namespaces file is ignored if it is missing or outdated.
+func isNamespaceSkipped(namespace string, conf *NetConf) bool {
+	for _, ns := range conf.ExcludeNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+
+	if conf.MonitoredNamespacesFile == "" {
+		return false
+	}
+	info, err := os.Stat(conf.MonitoredNamespacesFile)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Error reading the monitored namespaces file %s, ignoring it", conf.MonitoredNamespacesFile)
+		return false
+	}
+	if time.Since(info.ModTime()) > monitoredNamespacesMaxAge {
+		log.Warn().Msgf("Monitored namespaces file %s was last written on %s, ignoring it", conf.MonitoredNamespacesFile, info.ModTime())
+		return false
+	}
+	data, err := os.ReadFile(conf.MonitoredNamespacesFile) // #nosec G304
+	if err != nil {
+		log.Debug().Err(err).Msgf("Error reading the monitored namespaces file %s, ignoring it", conf.MonitoredNamespacesFile)
+		return false
+	}
+	var monitored []string
+	if err := json.Unmarshal(data, &monitored); err != nil {
+		log.Error().Err(err).Msgf("Error decoding the monitored namespaces file %s, ignoring it", conf.MonitoredNamespacesFile)
+		return false
+	}
+
+	for _, ns := range monitored {
+		if ns == namespace {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCmdArgs returns the arguments of the CNI command given by the environment
+func parseCmdArgs(getenv func(string) string) (cmdArgs, error) {
+	args := cmdArgs{
+		command:     getenv("CNI_COMMAND"),
+		containerID: getenv("CNI_CONTAINERID"),
+		netns:       getenv("CNI_NETNS"),
+	}
+
+	switch args.command {
+	case "":
+		return args, fmt.Errorf("CNI_COMMAND is not set")
+	case cmdVersion:
+		return args, nil
+	case cmdAdd:
+		if args.netns == "" {
+			return args, fmt.Errorf("CNI_NETNS is not set")
+		}
+	}
+
+	// CNI_ARGS is a list of semicolon separated KEY=VALUE pairs, set by the container runtime with the pod's name
+	// and namespace
+	for _, pair := range strings.Split(getenv("CNI_ARGS"), ";") {
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return args, fmt.Errorf("invalid CNI_ARGS pair %q, expected KEY=VALUE", pair)
+		}
+		switch kv[0] {
+		case "K8S_POD_NAMESPACE":
+			args.podNamespace = kv[1]
+		case "K8S_POD_NAME":
+			args.podName = kv[1]
+		}
+	}
+
+	return args, nil
+}
+
+// parseNetConf returns the network configuration of the osm-cni plugin read from the given reader
+func parseNetConf(stdin io.Reader) (*NetConf, error) {
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return nil, &cniError{CNIVersion: defaultCNIVersion, Code: errCodeDecodingFailure, Msg: "error reading the network configuration", Details: err.Error()}
+	}
+
+	conf := &NetConf{}
+	if err := json.Unmarshal(data, conf); err != nil {
+		return nil, &cniError{CNIVersion: defaultCNIVersion, Code: errCodeDecodingFailure, Msg: "error decoding the network configuration", Details: err.Error()}
+	}
+
+	supported := false
+	for _, v := range supportedVersions {
+		supported = supported || v == conf.CNIVersion
+	}
+	if !supported {
+		return nil, &cniError{CNIVersion: defaultCNIVersion, Code: errCodeIncompatibleVersion, Msg: fmt.Sprintf("unsupported CNI version %q", conf.CNIVersion)}
+	}
+	if conf.Kubeconfig == "" || conf.OSMNamespace == "" || conf.MeshConfigName == "" {
+		return nil, &cniError{CNIVersion: conf.CNIVersion, Code: errCodeInvalidNetworkConfig, Msg: "kubeconfig, osmNamespace and meshConfigName must be set"}
+	}
+
+	return conf, nil
+}
+
+// passThroughResult returns the result of the previous plugin of the chain, which the osm-cni plugin does not modify
+func passThroughResult(conf *NetConf) ([]byte, error) {
+	result := map[string]interface{}{}
+	if len(conf.PrevResult) > 0 {
+		if err := json.Unmarshal(conf.PrevResult, &result); err != nil {
+			return nil, &cniError{CNIVersion: conf.CNIVersion, Code: errCodeDecodingFailure, Msg: "error decoding the previous result", Details: err.Error()}
+		}
+	}
+	result["cniVersion"] = conf.CNIVersion
+	return json.Marshal(result)
+}
+
+// getPodIPs returns the IP addresses of the pod assigned by the previous plugins of the chain
+func getPodIPs(rawResult json.RawMessage) ([]net.IP, error) {
+	if len(rawResult) == 0 {
+		return nil, fmt.Errorf("no previous result to get the pod IPs from")
+	}
+	var result prevResult
+	if err := json.Unmarshal(rawResult, &result); err != nil {
+		return nil, fmt.Errorf("error decoding the previous result: %w", err)
+	}
+
+	var podIPs []net.IP
+	for _, ip := range result.IPs {
+		addr, _, err := net.ParseCIDR(ip.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address %q in the previous result: %w", ip.Address, err)
+		}
+		podIPs = append(podIPs, addr)
+	}
+	if len(podIPs) == 0 {
+		return nil, fmt.Errorf("no IP address in the previous result")
+	}
+	return podIPs, nil
+}
+
+// newClients returns the Kubernetes and OSM config clients authenticating with the given kubeconfig file
+func newClients(kubeconfig string) (kubernetes.Interface, configClientset.Interface, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	config.Timeout = apiTimeout
+
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	configClient, err := configClientset.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return kubeClient, configClient, nil
+}
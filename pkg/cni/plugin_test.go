@@ -0,0 +1,459 @@
+package cni
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	fakeKube "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+	fakeConfig "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+)
+
+const (
+	testNamespace     = "test-ns"
+	testPodName       = "test-pod"
+	testOSMNamespace  = "osm-system"
+	testNetNS         = "/var/run/netns/test"
+	testNetConfFormat = `{
+  "cniVersion": "1.0.0",
+  "name": "k8s-pod-network",
+  "type": "osm-cni",
+  "kubeconfig": "/etc/cni/net.d/osm-cni.kubeconfig",
+  "osmNamespace": "osm-system",
+  "meshConfigName": "osm-mesh-config",
+  "prevResult": %s
+}`
+	testPrevResult = `{"cniVersion":"1.0.0","interfaces":[{"name":"eth0"}],"ips":[{"address":"fd00::5/64"},{"address":"10.0.0.5/24","interface":0}]}`
+)
+
+func TestParseCmdArgs(t *testing.T) {
+	testCases := []struct {
+		name         string
+		env          map[string]string
+		expectedArgs cmdArgs
+		expectErr    bool
+	}{
+		{
+			name: "ADD command",
+			env: map[string]string{
+				"CNI_COMMAND":     "ADD",
+				"CNI_CONTAINERID": "abc",
+				"CNI_NETNS":       testNetNS,
+				"CNI_ARGS":        "IgnoreUnknown=1;K8S_POD_NAMESPACE=test-ns;K8S_POD_NAME=test-pod;K8S_POD_INFRA_CONTAINER_ID=abc",
+			},
+			expectedArgs: cmdArgs{
+				command:      cmdAdd,
+				containerID:  "abc",
+				netns:        testNetNS,
+				podNamespace: testNamespace,
+				podName:      testPodName,
+			},
+		},
+		{
+			name: "VERSION command",
+			env: map[string]string{
+				"CNI_COMMAND": "VERSION",
+			},
+			expectedArgs: cmdArgs{
+				command: cmdVersion,
+			},
+		},
+		{
+			name:      "command not set",
+			env:       map[string]string{},
+			expectErr: true,
+		},
+		{
+			name: "ADD command without network namespace",
+			env: map[string]string{
+				"CNI_COMMAND": "ADD",
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid CNI_ARGS",
+			env: map[string]string{
+				"CNI_COMMAND": "DEL",
+				"CNI_ARGS":    "K8S_POD_NAME",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			args, err := parseCmdArgs(func(key string) string { return tc.env[key] })
+			if tc.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestGetPodIPs(t *testing.T) {
+	assert := tassert.New(t)
+
+	ips, err := getPodIPs(json.RawMessage(testPrevResult))
+	assert.NoError(err)
+	assert.Equal([]net.IP{net.ParseIP("fd00::5"), net.ParseIP("10.0.0.5")}, ips)
+
+	ips, err = getPodIPs(json.RawMessage(`{"ips":[{"address":"fd00::5/64"}]}`))
+	assert.NoError(err)
+	assert.Equal([]net.IP{net.ParseIP("fd00::5")}, ips)
+
+	_, err = getPodIPs(json.RawMessage(`{"ips":[]}`))
+	assert.Error(err)
+
+	_, err = getPodIPs(nil)
+	assert.Error(err)
+}
+
+func TestRun(t *testing.T) {
+	meshedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testPodName,
+			Namespace: testNamespace,
+			Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: "proxy-uuid"},
+			Annotations: map[string]string{
+				"openservicemesh.io/outbound-port-exclusion-list": "6379",
+			},
+		},
+	}
+	meshConfig := &v1alpha2.MeshConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "osm-mesh-config",
+			Namespace: testOSMNamespace,
+		},
+		Spec: v1alpha2.MeshConfigSpec{
+			Sidecar: v1alpha2.SidecarSpec{
+				LocalProxyMode: v1alpha2.LocalProxyModePodIP,
+			},
+			Traffic: v1alpha2.TrafficSpec{
+				InboundPortExclusionList: []int{8081},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		command           string
+		stdin             string
+		pod               *corev1.Pod
+		meshConfig        *v1alpha2.MeshConfig
+		programErr        error
+		expectCommands    [][]string
+		expectRules       []string
+		expectNoRules     bool
+		expectNoClients   bool
+		expectedResult    string
+		expectedErrorCode uint
+	}{
+		{
+			name:           "rules programmed for pod injected without the init container",
+			command:        cmdAdd,
+			stdin:          fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod:            meshedPod,
+			meshConfig:     meshConfig,
+			expectCommands: [][]string{{"iptables-restore", "--noflush"}, {"ip6tables-restore", "--noflush"}},
+			expectRules: []string{
+				"-A OSM_PROXY_OUTBOUND -p tcp --match multiport --dports 6379 -j RETURN",
+				"-I OSM_PROXY_INBOUND -p tcp --match multiport --dports 8081 -j RETURN",
+				"-j DNAT --to-destination 10.0.0.5",
+				"-j DNAT --to-destination fd00::5",
+			},
+			expectedResult: testPrevResult,
+		},
+		{
+			name:    "nftables rules programmed",
+			command: cmdAdd,
+			stdin:   fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod:     meshedPod,
+			meshConfig: &v1alpha2.MeshConfig{
+				ObjectMeta: meshConfig.ObjectMeta,
+				Spec: v1alpha2.MeshConfigSpec{
+					Sidecar: v1alpha2.SidecarSpec{
+						LocalProxyMode:     v1alpha2.LocalProxyModePodIP,
+						RedirectionBackend: v1alpha2.RedirectionBackendNftables,
+					},
+				},
+			},
+			expectCommands: [][]string{{"nft", "-f", "-"}, {"nft", "-f", "-"}},
+			expectRules: []string{
+				"tcp dport { 6379 } return",
+				"table ip osm_proxy {",
+				"dnat to 10.0.0.5",
+				"table ip6 osm_proxy {",
+				"dnat to fd00::5",
+			},
+			expectedResult: testPrevResult,
+		},
+		{
+			name:    "rules programmed for IPv6-only pod",
+			command: cmdAdd,
+			stdin:   fmt.Sprintf(testNetConfFormat, `{"cniVersion":"1.0.0","ips":[{"address":"fd00::5/64"}]}`),
+			pod:     meshedPod,
+			meshConfig: &v1alpha2.MeshConfig{
+				ObjectMeta: meshConfig.ObjectMeta,
+				Spec: v1alpha2.MeshConfigSpec{
+					Traffic: v1alpha2.TrafficSpec{
+						OutboundIPRangeExclusionList: []string{"10.0.0.0/8", "fd00:10::/64"},
+					},
+				},
+			},
+			expectCommands: [][]string{{"ip6tables-restore", "--noflush"}},
+			expectRules: []string{
+				"-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN",
+				"-A OSM_PROXY_OUTBOUND -d fd00:10::/64 -j RETURN",
+			},
+			expectedResult: `{"cniVersion":"1.0.0","ips":[{"address":"fd00::5/64"}]}`,
+		},
+		{
+			name:              "pod without IP address",
+			command:           cmdAdd,
+			stdin:             fmt.Sprintf(testNetConfFormat, `{"cniVersion":"1.0.0","ips":[]}`),
+			pod:               meshedPod,
+			meshConfig:        meshConfig,
+			expectedErrorCode: errCodeInternal,
+		},
+		{
+			name:    "rules not programmed for pod not in the mesh",
+			command: cmdAdd,
+			stdin:   fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: testPodName, Namespace: testNamespace},
+			},
+			meshConfig:     meshConfig,
+			expectNoRules:  true,
+			expectedResult: testPrevResult,
+		},
+		{
+			name:    "rules not programmed for pod injected with the init container",
+			command: cmdAdd,
+			stdin:   fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod: &corev1.Pod{
+				ObjectMeta: meshedPod.ObjectMeta,
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: constants.InitContainerName}},
+				},
+			},
+			meshConfig:     meshConfig,
+			expectNoRules:  true,
+			expectedResult: testPrevResult,
+		},
+		{
+			name:            "rules not programmed for pod in an excluded namespace",
+			command:         cmdAdd,
+			stdin:           fmt.Sprintf(strings.Replace(testNetConfFormat, `"meshConfigName"`, `"excludeNamespaces": ["kube-system", "test-ns"], "meshConfigName"`, 1), testPrevResult),
+			pod:             meshedPod,
+			meshConfig:      meshConfig,
+			expectNoRules:   true,
+			expectNoClients: true,
+			expectedResult:  testPrevResult,
+		},
+		{
+			name:           "rules not programmed for deleted pod",
+			command:        cmdAdd,
+			stdin:          fmt.Sprintf(testNetConfFormat, testPrevResult),
+			meshConfig:     meshConfig,
+			expectNoRules:  true,
+			expectedResult: testPrevResult,
+		},
+		{
+			name:              "MeshConfig not found",
+			command:           cmdAdd,
+			stdin:             fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod:               meshedPod,
+			expectedErrorCode: errCodeInternal,
+		},
+		{
+			name:              "error programming the rules",
+			command:           cmdAdd,
+			stdin:             fmt.Sprintf(testNetConfFormat, testPrevResult),
+			pod:               meshedPod,
+			meshConfig:        meshConfig,
+			programErr:        fmt.Errorf("iptables-restore failed"),
+			expectedErrorCode: errCodeInternal,
+		},
+		{
+			name:              "unsupported CNI version",
+			command:           cmdAdd,
+			stdin:             `{"cniVersion":"0.2.0","type":"osm-cni"}`,
+			expectedErrorCode: errCodeIncompatibleVersion,
+		},
+		{
+			name:              "invalid network configuration",
+			command:           cmdAdd,
+			stdin:             `{"cniVersion":"1.0.0","type":"osm-cni"}`,
+			expectedErrorCode: errCodeInvalidNetworkConfig,
+		},
+		{
+			name:           "DEL command",
+			command:        cmdDel,
+			stdin:          fmt.Sprintf(testNetConfFormat, testPrevResult),
+			expectNoRules:  true,
+			expectedResult: "",
+		},
+		{
+			name:           "VERSION command",
+			command:        cmdVersion,
+			expectNoRules:  true,
+			expectedResult: `{"cniVersion":"1.0.0","supportedVersions":["0.3.0","0.3.1","0.4.0","1.0.0"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			kubeClient := fakeKube.NewSimpleClientset()
+			if tc.pod != nil {
+				kubeClient = fakeKube.NewSimpleClientset(tc.pod)
+			}
+			configClient := fakeConfig.NewSimpleClientset()
+			if tc.meshConfig != nil {
+				configClient = fakeConfig.NewSimpleClientset(tc.meshConfig)
+			}
+
+			var programmedNetNS, programmedRules string
+			var programmedCommands [][]string
+			clientsCreated := false
+			p := &plugin{
+				newClients: func(string) (kubernetes.Interface, configClientset.Interface, error) {
+					clientsCreated = true
+					return kubeClient, configClient, nil
+				},
+				programRules: func(netns string, command []string, rules string) error {
+					programmedNetNS = netns
+					programmedCommands = append(programmedCommands, command)
+					programmedRules += rules
+					return tc.programErr
+				},
+			}
+			env := map[string]string{
+				"CNI_COMMAND":     tc.command,
+				"CNI_CONTAINERID": "abc",
+				"CNI_NETNS":       testNetNS,
+				"CNI_ARGS":        "K8S_POD_NAMESPACE=test-ns;K8S_POD_NAME=test-pod",
+			}
+
+			result, err := p.run(func(key string) string { return env[key] }, strings.NewReader(tc.stdin))
+			if tc.expectedErrorCode != 0 {
+				assert.Error(err)
+				e, ok := err.(*cniError)
+				assert.True(ok)
+				assert.Equal(tc.expectedErrorCode, e.Code)
+				return
+			}
+			assert.NoError(err)
+
+			if tc.expectedResult == "" {
+				assert.Empty(result)
+			} else {
+				assert.JSONEq(tc.expectedResult, string(result))
+			}
+			if tc.expectNoClients {
+				assert.False(clientsCreated)
+			}
+			if tc.expectNoRules {
+				assert.Empty(programmedRules)
+				return
+			}
+			assert.Equal(testNetNS, programmedNetNS)
+			assert.Equal(tc.expectCommands, programmedCommands)
+			for _, rule := range tc.expectRules {
+				assert.Contains(programmedRules, rule)
+			}
+		})
+	}
+}
+
+func TestIsNamespaceSkipped(t *testing.T) {
+	testCases := []struct {
+		name              string
+		excludeNamespaces []string
+		monitored         string
+		age               time.Duration
+		expected          bool
+	}{
+		{
+			name:     "no exclusion nor monitored namespaces file",
+			expected: false,
+		},
+		{
+			name:              "excluded namespace",
+			excludeNamespaces: []string{"kube-system", testNamespace},
+			expected:          true,
+		},
+		{
+			name:      "monitored namespace",
+			monitored: `["other-ns","test-ns"]`,
+			expected:  false,
+		},
+		{
+			name:      "unmonitored namespace",
+			monitored: `["other-ns"]`,
+			expected:  true,
+		},
+		{
+			name:      "outdated monitored namespaces file",
+			monitored: `["other-ns"]`,
+			age:       2 * monitoredNamespacesMaxAge,
+			expected:  false,
+		},
+		{
+			name:      "invalid monitored namespaces file",
+			monitored: `other-ns`,
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			conf := &NetConf{
+				ExcludeNamespaces:       tc.excludeNamespaces,
+				MonitoredNamespacesFile: filepath.Join(t.TempDir(), monitoredNamespacesFileName),
+			}
+			if tc.monitored != "" {
+				assert.NoError(os.WriteFile(conf.MonitoredNamespacesFile, []byte(tc.monitored), 0600))
+				modTime := time.Now().Add(-tc.age)
+				assert.NoError(os.Chtimes(conf.MonitoredNamespacesFile, modTime, modTime))
+			}
+
+			assert.Equal(tc.expected, isNamespaceSkipped(testNamespace, conf))
+		})
+	}
+}
+
+func TestRunWritesError(t *testing.T) {
+	assert := tassert.New(t)
+
+	var stdout bytes.Buffer
+	err := Run(func(string) string { return "" }, strings.NewReader(""), &stdout)
+	assert.Error(err)
+
+	var e cniError
+	assert.NoError(json.Unmarshal(stdout.Bytes(), &e))
+	assert.Equal(errCodeInvalidEnvVars, e.Code)
+	assert.Equal(defaultCNIVersion, e.CNIVersion)
+}
@@ -0,0 +1,103 @@
+// Package cni implements the osm-cni plugin, programming the traffic interception and redirection of meshed pods in
+// their network namespace when their sandbox is created, and its installer deployed on each node by a DaemonSet.
+package cni
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var log = logger.New("osm-cni")
+
+const (
+	// PluginName is the name and type of the osm-cni plugin in the CNI network configuration of the nodes
+	PluginName = "osm-cni"
+
+	// defaultCNIVersion is the CNI specification version of the results of the VERSION command and of errors
+	// happening before the network configuration is decoded
+	defaultCNIVersion = "1.0.0"
+)
+
+// supportedVersions is the list of CNI specification versions supported by the osm-cni plugin, all of which support
+// chained plugins receiving the result of the previous plugin
+var supportedVersions = []string{"0.3.0", "0.3.1", "0.4.0", "1.0.0"}
+
+// CNI commands, given by the CNI_COMMAND environment variable
+const (
+	cmdAdd     = "ADD"
+	cmdDel     = "DEL"
+	cmdCheck   = "CHECK"
+	cmdVersion = "VERSION"
+)
+
+// CNI error codes, as defined by the CNI specification
+const (
+	errCodeIncompatibleVersion  uint = 1
+	errCodeInvalidEnvVars       uint = 4
+	errCodeDecodingFailure      uint = 6
+	errCodeInvalidNetworkConfig uint = 7
+	errCodeInternal             uint = 999
+)
+
+// NetConf is the type used to represent the configuration of the osm-cni plugin, chained to the plugins of the
+// network configuration list of the nodes
+type NetConf struct {
+	CNIVersion string `json:"cniVersion"`
+	Name       string `json:"name,omitempty"`
+	Type       string `json:"type"`
+
+	// PrevResult is the result of the previous plugin of the chain, passed through by the osm-cni plugin
+	PrevResult json.RawMessage `json:"prevResult,omitempty"`
+
+	// Kubeconfig is the path of the kubeconfig file used to get the pods and the MeshConfig
+	Kubeconfig string `json:"kubeconfig"`
+
+	// OSMNamespace is the namespace of the MeshConfig
+	OSMNamespace string `json:"osmNamespace"`
+
+	// MeshConfigName is the name of the MeshConfig
+	MeshConfigName string `json:"meshConfigName"`
+
+	// LogLevel is the log verbosity of the plugin, whose logs are written to the container runtime's logs
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// ExcludeNamespaces is the list of namespaces whose pods are skipped
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// MonitoredNamespacesFile is the path of the file the installer writes the namespaces monitored by the mesh to,
+	// the pods of the other namespaces being skipped
+	MonitoredNamespacesFile string `json:"monitoredNamespacesFile,omitempty"`
+}
+
+// cmdArgs is the type used to represent the arguments of a CNI command, given by environment variables
+type cmdArgs struct {
+	command      string
+	containerID  string
+	netns        string
+	podNamespace string
+	podName      string
+}
+
+// prevResult is the type used to decode the IP addresses of the result of the previous plugin of the chain
+type prevResult struct {
+	IPs []struct {
+		Address string `json:"address"`
+	} `json:"ips,omitempty"`
+}
+
+// cniError is the type used to represent an error returned by the osm-cni plugin, as defined by the CNI specification
+type cniError struct {
+	CNIVersion string `json:"cniVersion"`
+	Code       uint   `json:"code"`
+	Msg        string `json:"msg"`
+	Details    string `json:"details,omitempty"`
+}
+
+func (e *cniError) Error() string {
+	if e.Details == "" {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Msg, e.Details)
+}
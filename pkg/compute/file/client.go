@@ -0,0 +1,667 @@
+package file
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewClient returns a compute provider that reads the resources defined in the given directory, and watches the
+// directory for changes until the stop channel is closed. Changes are announced on the message broker as they would
+// be by the Kubernetes informers.
+func NewClient(dir, osmNamespace string, msgBroker *messaging.Broker, stop <-chan struct{}) (*client, error) { //nolint: revive // unexported-return
+	c := &client{
+		dir:          dir,
+		osmNamespace: osmNamespace,
+		msgBroker:    msgBroker,
+		state:        &state{},
+	}
+
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating watcher for directory %s: %w", dir, err)
+	}
+	if err := c.watchDirs(watcher); err != nil {
+		//nolint: errcheck
+		//#nosec G104
+		watcher.Close()
+		return nil, fmt.Errorf("error watching directory %s: %w", dir, err)
+	}
+
+	go c.watch(watcher, stop)
+
+	return c, nil
+}
+
+// watchDirs adds the directory and its subdirectories to the given watcher, as directories are not watched
+// recursively
+func (c *client) watchDirs(watcher *fsnotify.Watcher) error {
+	return filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
+
+// watch reloads the directory when its files change, until the stop channel is closed. The events observed within
+// reloadDelay of each other are handled with a single reload, since writing a file usually generates several events.
+func (c *client) watch(watcher *fsnotify.Watcher, stop <-chan struct{}) {
+	//nolint: errcheck
+	//#nosec G307
+	defer watcher.Close()
+
+	var reload <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			log.Trace().Msgf("Observed %s, reloading compute resources from %s", event, c.dir)
+			if reload == nil {
+				reload = time.After(reloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrLoadingComputeFiles)).
+				Msgf("Error watching %s for changes", c.dir)
+		case <-reload:
+			reload = nil
+			// Subdirectories created since the last reload must be watched as well
+			if err := c.watchDirs(watcher); err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrLoadingComputeFiles)).
+					Msgf("Error watching the subdirectories of %s for changes", c.dir)
+			}
+			if err := c.reload(); err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrLoadingComputeFiles)).
+					Msgf("Error reloading compute resources from %s, keeping the previous resources", c.dir)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// reload reads the directory, and if its contents changed, replaces the resources of the client and announces
+// the changes on the message broker
+func (c *client) reload() error {
+	files, checksum, err := readDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("error reading directory %s: %w", c.dir, err)
+	}
+
+	c.mu.RLock()
+	unchanged := checksum == c.checksum
+	c.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	newState, err := parseFiles(files)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	oldState := c.state
+	c.state = newState
+	c.checksum = checksum
+	c.mu.Unlock()
+
+	msgs := diff(oldState, newState)
+	log.Info().Msgf("Loaded compute resources from %s, %d change(s)", c.dir, len(msgs))
+	for _, msg := range msgs {
+		c.msgBroker.GetQueue().AddRateLimited(msg)
+	}
+	return nil
+}
+
+// getState returns the current resources of the client. The returned state must not be modified.
+func (c *client) getState() *state {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+// GetMeshConfig returns the MeshConfig defined in the directory, or an empty MeshConfig if none is defined
+func (c *client) GetMeshConfig() configv1alpha2.MeshConfig {
+	if meshConfig := c.getState().meshConfig; meshConfig != nil {
+		return *meshConfig
+	}
+	return configv1alpha2.MeshConfig{}
+}
+
+// GetOSMNamespace returns the namespace of the control plane
+func (c *client) GetOSMNamespace() string {
+	return c.osmNamespace
+}
+
+// UpdateIngressBackendStatus returns the given IngressBackend as is, as status is not persisted to files
+func (c *client) UpdateIngressBackendStatus(obj *policyv1alpha1.IngressBackend) (*policyv1alpha1.IngressBackend, error) {
+	return obj, nil
+}
+
+// UpdateUpstreamTrafficSettingStatus returns the given UpstreamTrafficSetting as is, as status is not persisted to files
+func (c *client) UpdateUpstreamTrafficSettingStatus(obj *policyv1alpha1.UpstreamTrafficSetting) (*policyv1alpha1.UpstreamTrafficSetting, error) {
+	return obj, nil
+}
+
+// ListEgressPolicies lists the all Egress policies
+func (c *client) ListEgressPolicies() []*policyv1alpha1.Egress {
+	return c.getState().egresses
+}
+
+// ListIngressBackendPolicies lists the all IngressBackend policies
+func (c *client) ListIngressBackendPolicies() []*policyv1alpha1.IngressBackend {
+	return c.getState().ingressBackends
+}
+
+// ListRetryPolicies returns the all retry policies
+func (c *client) ListRetryPolicies() []*policyv1alpha1.Retry {
+	return c.getState().retries
+}
+
+// ListUpstreamTrafficSettings returns all UpstreamTrafficSetting resources
+func (c *client) ListUpstreamTrafficSettings() []*policyv1alpha1.UpstreamTrafficSetting {
+	return c.getState().upstreamTrafficSettings
+}
+
+// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resources with namespaced name
+func (c *client) GetUpstreamTrafficSetting(namespace *types.NamespacedName) *policyv1alpha1.UpstreamTrafficSetting {
+	for _, setting := range c.getState().upstreamTrafficSettings {
+		if setting.Namespace == namespace.Namespace && setting.Name == namespace.Name {
+			return setting
+		}
+	}
+	return nil
+}
+
+// ListHTTPFilterExtensions returns all HTTPFilterExtension resources
+func (c *client) ListHTTPFilterExtensions() []*policyv1alpha1.HTTPFilterExtension {
+	return c.getState().httpFilterExtensions
+}
+
+// GetMeshService returns the service.MeshService corresponding to the Port used by clients
+// to communicate with it.
+func (c *client) GetMeshService(name, namespace string, port uint16) (service.MeshService, error) {
+	svc := c.getState().getService(name, namespace)
+	if svc == nil {
+		return service.MeshService{}, errServiceNotFound
+	}
+	for _, meshSvc := range toMeshServices(svc) {
+		if meshSvc.Port == port {
+			return meshSvc, nil
+		}
+	}
+	return service.MeshService{}, fmt.Errorf("service %s/%s does not have a port %d", namespace, name, port)
+}
+
+// GetServicesForServiceIdentity retrieves a list of services for the given service identity.
+func (c *client) GetServicesForServiceIdentity(svcIdentity identity.ServiceIdentity) []service.MeshService {
+	s := c.getState()
+	var meshServices []service.MeshService
+	svcSet := mapset.NewSet()
+	for _, workload := range s.workloads {
+		if workloadIdentity(workload) != svcIdentity {
+			continue
+		}
+		for _, svc := range s.servicesForWorkload(workload) {
+			for _, meshSvc := range toMeshServices(svc) {
+				if added := svcSet.Add(meshSvc); added {
+					meshServices = append(meshServices, meshSvc)
+				}
+			}
+		}
+	}
+	return meshServices
+}
+
+// ListServices returns a list of all the services defined in the directory
+func (c *client) ListServices() []service.MeshService {
+	var meshServices []service.MeshService
+	for _, svc := range c.getState().services {
+		meshServices = append(meshServices, toMeshServices(svc)...)
+	}
+	return meshServices
+}
+
+// ListServiceIdentitiesForService lists the service identities of the workloads selected by the given service
+func (c *client) ListServiceIdentitiesForService(name, namespace string) ([]identity.ServiceIdentity, error) {
+	s := c.getState()
+	svc := s.getService(name, namespace)
+	if svc == nil {
+		return nil, fmt.Errorf("Error fetching service %s/%s: %s", namespace, name, errServiceNotFound)
+	}
+
+	var identities []identity.ServiceIdentity
+	identitySet := mapset.NewSet()
+	for _, workload := range s.workloadsForService(svc) {
+		if added := identitySet.Add(workloadIdentity(workload)); added {
+			identities = append(identities, workloadIdentity(workload))
+		}
+	}
+	return identities, nil
+}
+
+// ListEndpointsForService retrieves the addresses of the instances backing the given service
+func (c *client) ListEndpointsForService(meshSvc service.MeshService) []endpoint.Endpoint {
+	s := c.getState()
+	svc := s.getService(meshSvc.Name, meshSvc.Namespace)
+	if svc == nil {
+		log.Info().Msgf("No service found for MeshService %s", meshSvc)
+		return nil
+	}
+
+	var endpoints []endpoint.Endpoint
+	for _, port := range svc.Spec.Ports {
+		targetPort := getTargetPort(port)
+		// If a TargetPort is specified for the MeshService, filter the endpoints by this port.
+		if meshSvc.TargetPort != 0 && targetPort != meshSvc.TargetPort {
+			continue
+		}
+		for _, workload := range s.workloadsForService(svc) {
+			for _, instance := range workload.Spec.Instances {
+				ip := net.ParseIP(instance.IP)
+				if ip == nil {
+					log.Error().Msgf("Error parsing IP address %s of instance %s of workload %s/%s", instance.IP, instance.Name, workload.Namespace, workload.Name)
+					continue
+				}
+				endpoints = append(endpoints, endpoint.Endpoint{IP: ip, Port: endpoint.Port(targetPort)})
+			}
+		}
+	}
+	return endpoints
+}
+
+// ListEndpointsForIdentity retrieves the addresses of the instances running with the given service identity
+func (c *client) ListEndpointsForIdentity(svcIdentity identity.ServiceIdentity) []endpoint.Endpoint {
+	var endpoints []endpoint.Endpoint
+	for _, workload := range c.getState().workloads {
+		if workloadIdentity(workload) != svcIdentity {
+			continue
+		}
+		for _, instance := range workload.Spec.Instances {
+			ip := net.ParseIP(instance.IP)
+			if ip == nil {
+				log.Error().Msgf("Error parsing IP address %s of instance %s of workload %s/%s", instance.IP, instance.Name, workload.Namespace, workload.Name)
+				continue
+			}
+			endpoints = append(endpoints, endpoint.Endpoint{IP: ip})
+		}
+	}
+	return endpoints
+}
+
+// GetResolvableEndpointsForService returns the expected endpoints that are to be reached when the service
+// FQDN is resolved
+func (c *client) GetResolvableEndpointsForService(meshSvc service.MeshService) []endpoint.Endpoint {
+	svc := c.getState().getService(meshSvc.Name, meshSvc.Namespace)
+	if svc == nil {
+		log.Info().Msgf("No service found for MeshService %s", meshSvc)
+		return nil
+	}
+
+	if svc.Spec.IP == "" {
+		return c.ListEndpointsForService(meshSvc)
+	}
+
+	ip := net.ParseIP(svc.Spec.IP)
+	if ip == nil {
+		log.Error().Msgf("Could not parse IP %s of service %s/%s", svc.Spec.IP, svc.Namespace, svc.Name)
+		return nil
+	}
+
+	var endpoints []endpoint.Endpoint
+	for _, port := range svc.Spec.Ports {
+		endpoints = append(endpoints, endpoint.Endpoint{IP: ip, Port: endpoint.Port(port.Port)})
+	}
+	return endpoints
+}
+
+// IsMetricsEnabled returns whether metrics scraping is enabled for the workload of the given proxy
+func (c *client) IsMetricsEnabled(proxy *envoy.Proxy) (bool, error) {
+	workload, _, err := c.getState().getInstanceForProxy(proxy)
+	if err != nil {
+		return false, err
+	}
+	return workload.Spec.MetricsEnabled, nil
+}
+
+// IsWorkloadKeyGenerationEnabled returns false, the private keys of file based workloads being generated by
+// osm-controller
+func (c *client) IsWorkloadKeyGenerationEnabled(_ *envoy.Proxy) (bool, error) {
+	return false, nil
+}
+
+// GetHostnamesForService returns the hostnames over which the service is accessible
+func (c *client) GetHostnamesForService(svc service.MeshService, localNamespace bool) []string {
+	var hostnames []string
+
+	if localNamespace {
+		hostnames = append(hostnames,
+			svc.Name,                                 // service
+			fmt.Sprintf("%s:%d", svc.Name, svc.Port), // service:port
+		)
+	}
+
+	hostnames = append(hostnames,
+		fmt.Sprintf("%s.%s", svc.Name, svc.Namespace),              // service.namespace
+		fmt.Sprintf("%s.%s:%d", svc.Name, svc.Namespace, svc.Port), // service.namespace:port
+		svc.FQDN(), // fqdn
+		fmt.Sprintf("%s:%d", svc.FQDN(), svc.Port), // fqdn:port
+	)
+
+	return hostnames
+}
+
+// ListServicesForProxy returns the services backed by the workload of the given proxy
+func (c *client) ListServicesForProxy(proxy *envoy.Proxy) ([]service.MeshService, error) {
+	s := c.getState()
+	workload, _, err := s.getInstanceForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	var meshServices []service.MeshService
+	for _, svc := range s.servicesForWorkload(workload) {
+		meshServices = append(meshServices, toMeshServices(svc)...)
+	}
+	return meshServices, nil
+}
+
+// ListEgressPoliciesForServiceAccount lists the Egress policies for the given source identity based on service accounts
+func (c *client) ListEgressPoliciesForServiceAccount(source identity.K8sServiceAccount) []*policyv1alpha1.Egress {
+	var policies []*policyv1alpha1.Egress
+	for _, egress := range c.ListEgressPolicies() {
+		for _, sourceSpec := range egress.Spec.Sources {
+			if sourceSpec.Kind == kindSvcAccount && sourceSpec.Name == source.Name && sourceSpec.Namespace == source.Namespace {
+				policies = append(policies, egress)
+			}
+		}
+	}
+	return policies
+}
+
+// GetIngressBackendPolicyForService returns the IngressBackend policy for the given backend MeshService
+func (c *client) GetIngressBackendPolicyForService(svc service.MeshService) *policyv1alpha1.IngressBackend {
+	for _, ingressBackend := range c.ListIngressBackendPolicies() {
+		if ingressBackend.Namespace != svc.Namespace {
+			continue
+		}
+		for _, backend := range ingressBackend.Spec.Backends {
+			if backend.Name == svc.Name && backend.Port.Number == int(svc.TargetPort) {
+				return ingressBackend
+			}
+		}
+	}
+	return nil
+}
+
+// ListRetryPoliciesForServiceAccount returns the retry policies for the given source identity based on service accounts.
+func (c *client) ListRetryPoliciesForServiceAccount(source identity.K8sServiceAccount) []*policyv1alpha1.Retry {
+	var retries []*policyv1alpha1.Retry
+	for _, retry := range c.ListRetryPolicies() {
+		if retry.Spec.Source.Kind == kindSvcAccount && retry.Spec.Source.Name == source.Name && retry.Spec.Source.Namespace == source.Namespace {
+			retries = append(retries, retry)
+		}
+	}
+	return retries
+}
+
+// GetUpstreamTrafficSettingByNamespace returns the UpstreamTrafficSetting resource that matches the namespace
+func (c *client) GetUpstreamTrafficSettingByNamespace(namespace *types.NamespacedName) *policyv1alpha1.UpstreamTrafficSetting {
+	if namespace == nil {
+		log.Error().Msgf("No option specified to get UpstreamTrafficSetting resource")
+		return nil
+	}
+	return c.GetUpstreamTrafficSetting(namespace)
+}
+
+// GetUpstreamTrafficSettingByService returns the UpstreamTrafficSetting resource that matches the given service
+func (c *client) GetUpstreamTrafficSettingByService(meshService *service.MeshService) *policyv1alpha1.UpstreamTrafficSetting {
+	if meshService == nil {
+		log.Error().Msgf("No option specified to get UpstreamTrafficSetting resource")
+		return nil
+	}
+	for _, setting := range c.ListUpstreamTrafficSettings() {
+		if setting.Namespace == meshService.Namespace && setting.Spec.Host == meshService.FQDN() {
+			return setting
+		}
+	}
+	return nil
+}
+
+// GetUpstreamTrafficSettingByHost returns the UpstreamTrafficSetting resource that matches the host
+func (c *client) GetUpstreamTrafficSettingByHost(host string) *policyv1alpha1.UpstreamTrafficSetting {
+	if host == "" {
+		log.Error().Msgf("No option specified to get UpstreamTrafficSetting resource")
+		return nil
+	}
+	for _, setting := range c.ListUpstreamTrafficSettings() {
+		if setting.Spec.Host == host {
+			return setting
+		}
+	}
+	return nil
+}
+
+// ListIPsForProxy returns the IP address of the instance of the given proxy
+func (c *client) ListIPsForProxy(proxy *envoy.Proxy) ([]net.IP, error) {
+	workload, instance, err := c.getState().getInstanceForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(instance.IP)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %s of instance %s of workload %s/%s", instance.IP, instance.Name, workload.Namespace, workload.Name)
+	}
+	return []net.IP{ip}, nil
+}
+
+// GetProxyStatsHeaders returns the headers Envoy adds to the stats it emits, identifying the instance and workload
+// of the given proxy
+func (c *client) GetProxyStatsHeaders(proxy *envoy.Proxy) (map[string]string, error) {
+	workload, instance, err := c.getState().getInstanceForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"osm-stats-pod":       instance.Name,
+		"osm-stats-namespace": workload.Namespace,
+		"osm-stats-kind":      WorkloadKind,
+		"osm-stats-name":      workload.Name,
+	}, nil
+}
+
+// VerifyProxy checks that an instance with the UUID of the given proxy is defined, and that its workload runs with
+// the proxy's service identity.
+func (c *client) VerifyProxy(proxy *envoy.Proxy) error {
+	_, _, err := c.getState().getInstanceForProxy(proxy)
+	return err
+}
+
+// ListNamespaces returns the namespaces of the services and workloads defined in the directory
+func (c *client) ListNamespaces() ([]string, error) {
+	s := c.getState()
+	nsSet := mapset.NewSet()
+	var namespaces []string
+	for _, svc := range s.services {
+		if nsSet.Add(svc.Namespace) {
+			namespaces = append(namespaces, svc.Namespace)
+		}
+	}
+	for _, workload := range s.workloads {
+		if nsSet.Add(workload.Namespace) {
+			namespaces = append(namespaces, workload.Namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// ListHTTPFilterExtensionsForProxy returns the HTTPFilterExtension resources selecting the workload of the given proxy
+func (c *client) ListHTTPFilterExtensionsForProxy(proxy *envoy.Proxy) ([]*policyv1alpha1.HTTPFilterExtension, error) {
+	s := c.getState()
+	workload, _, err := s.getInstanceForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	var extensions []*policyv1alpha1.HTTPFilterExtension
+	for _, extension := range s.httpFilterExtensions {
+		// An empty selector selects all the workloads in the namespace
+		if extension.Namespace == workload.Namespace &&
+			labels.SelectorFromSet(extension.Spec.Selector).Matches(labels.Set(workload.Labels)) {
+			extensions = append(extensions, extension)
+		}
+	}
+	return extensions, nil
+}
+
+// GetHTTPFilterModule returns the WASM module held by the referenced ConfigMap defined in the directory
+func (c *client) GetHTTPFilterModule(namespace string, ref policyv1alpha1.ConfigMapKeyRef) ([]byte, error) {
+	for _, configMap := range c.getState().configMaps {
+		if configMap.Name != ref.Name || configMap.Namespace != namespace {
+			continue
+		}
+		if module, ok := configMap.BinaryData[ref.Key]; ok {
+			return module, nil
+		}
+		if module, ok := configMap.Data[ref.Key]; ok {
+			return []byte(module), nil
+		}
+		return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, namespace, ref.Name)
+	}
+	return nil, fmt.Errorf("ConfigMap %s/%s not found", namespace, ref.Name)
+}
+
+// getService returns the service with the given name and namespace, or nil if not found
+func (s *state) getService(name, namespace string) *Service {
+	for _, svc := range s.services {
+		if svc.Name == name && svc.Namespace == namespace {
+			return svc
+		}
+	}
+	return nil
+}
+
+// servicesForWorkload returns the services selecting the given workload
+func (s *state) servicesForWorkload(workload *Workload) []*Service {
+	var services []*Service
+	for _, svc := range s.services {
+		if selects(svc, workload) {
+			services = append(services, svc)
+		}
+	}
+	return services
+}
+
+// workloadsForService returns the workloads selected by the given service
+func (s *state) workloadsForService(svc *Service) []*Workload {
+	var workloads []*Workload
+	for _, workload := range s.workloads {
+		if selects(svc, workload) {
+			workloads = append(workloads, workload)
+		}
+	}
+	return workloads
+}
+
+// getInstanceForProxy returns the workload instance the given proxy runs on
+func (s *state) getInstanceForProxy(proxy *envoy.Proxy) (*Workload, *Instance, error) {
+	proxyUUID := proxy.UUID.String()
+	for _, workload := range s.workloads {
+		for i := range workload.Spec.Instances {
+			if workload.Spec.Instances[i].ProxyUUID != proxyUUID {
+				continue
+			}
+			if workloadIdentity(workload) != proxy.Identity {
+				return nil, nil, fmt.Errorf("%w: proxy %s has identity %s, workload %s/%s has identity %s", errIdentityMismatch,
+					proxyUUID, proxy.Identity, workload.Namespace, workload.Name, workloadIdentity(workload))
+			}
+			return workload, &workload.Spec.Instances[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w %s", errProxyNotFound, proxyUUID)
+}
+
+// selects returns whether the given service selects the given workload
+func selects(svc *Service, workload *Workload) bool {
+	if svc.Namespace != workload.Namespace || len(svc.Spec.Selector) == 0 {
+		return false
+	}
+	return labels.Set(svc.Spec.Selector).AsSelector().Matches(labels.Set(workload.Labels))
+}
+
+// workloadIdentity returns the service identity of the given workload
+func workloadIdentity(workload *Workload) identity.ServiceIdentity {
+	return identity.K8sServiceAccount{Name: workload.Spec.ServiceAccount, Namespace: workload.Namespace}.ToServiceIdentity()
+}
+
+// getTargetPort returns the port the instances backing the given service port listen on
+func getTargetPort(port ServicePort) uint16 {
+	if port.TargetPort != 0 {
+		return port.TargetPort
+	}
+	return port.Port
+}
+
+// toMeshServices returns a MeshService for each port of the given service
+func toMeshServices(svc *Service) []service.MeshService {
+	var meshServices []service.MeshService
+	for _, port := range svc.Spec.Ports {
+		// Order of Preference is:
+		// 1. port.appProtocol field
+		// 2. protocol prefixed to port name (e.g. tcp-my-port)
+		// 3. default to http
+		protocol := port.AppProtocol
+		if protocol == "" {
+			protocol = constants.ProtocolHTTP
+			for _, p := range constants.SupportedProtocolsInMesh {
+				if strings.HasPrefix(port.Name, p+"-") {
+					protocol = p
+					break
+				}
+			}
+		}
+		if protocol == constants.ProtocolOpaque {
+			protocol = constants.ProtocolTCPServerFirst
+		}
+
+		meshServices = append(meshServices, service.MeshService{
+			Namespace:  svc.Namespace,
+			Name:       svc.Name,
+			Port:       port.Port,
+			TargetPort: getTargetPort(port),
+			Protocol:   protocol,
+		})
+	}
+	return meshServices
+}
@@ -0,0 +1,323 @@
+package file
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+var (
+	_ compute.Interface = &client{}
+	_ smi.MeshSpec      = &client{}
+)
+
+var (
+	bookstoreIdentity = identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"}.ToServiceIdentity()
+	bookbuyerIdentity = identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer"}.ToServiceIdentity()
+
+	bookstoreHTTP = service.MeshService{Namespace: "bookstore", Name: "bookstore", Port: 14001, TargetPort: 8080, Protocol: "http"}
+	bookstoreTCP  = service.MeshService{Namespace: "bookstore", Name: "bookstore", Port: 9000, TargetPort: 9000, Protocol: "tcp"}
+)
+
+func newTestClient(t *testing.T, dir string) *client {
+	t.Helper()
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+
+	c, err := NewClient(dir, "osm-system", messaging.NewBroker(stop), stop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func newTestProxy(proxyUUID string, svcIdentity identity.ServiceIdentity) *envoy.Proxy {
+	return envoy.NewProxy(envoy.KindSidecar, uuid.MustParse(proxyUUID), svcIdentity, nil, 1)
+}
+
+func TestNewClient(t *testing.T) {
+	assert := tassert.New(t)
+
+	c := newTestClient(t, "testdata")
+	assert.Equal("osm-system", c.GetOSMNamespace())
+	assert.Empty(c.GetMeshConfig().Name)
+	assert.Len(c.getState().services, 1)
+	assert.Len(c.getState().workloads, 2)
+	assert.Len(c.ListRetryPolicies(), 1)
+	assert.Equal("bookstore-0", c.getState().workloads[1].Spec.Instances[0].Name)
+
+	_, err := NewClient("does-not-exist", "osm-system", nil, nil)
+	assert.Error(err)
+}
+
+func TestServices(t *testing.T) {
+	assert := tassert.New(t)
+	c := newTestClient(t, "testdata")
+
+	assert.ElementsMatch([]service.MeshService{bookstoreHTTP, bookstoreTCP}, c.ListServices())
+	assert.ElementsMatch([]service.MeshService{bookstoreHTTP, bookstoreTCP}, c.GetServicesForServiceIdentity(bookstoreIdentity))
+	assert.Empty(c.GetServicesForServiceIdentity(bookbuyerIdentity))
+
+	meshSvc, err := c.GetMeshService("bookstore", "bookstore", 9000)
+	assert.NoError(err)
+	assert.Equal(bookstoreTCP, meshSvc)
+	_, err = c.GetMeshService("bookstore", "bookstore", 80)
+	assert.Error(err)
+	_, err = c.GetMeshService("bookstore", "default", 9000)
+	assert.ErrorIs(err, errServiceNotFound)
+
+	identities, err := c.ListServiceIdentitiesForService("bookstore", "bookstore")
+	assert.NoError(err)
+	assert.Equal([]identity.ServiceIdentity{bookstoreIdentity}, identities)
+
+	namespaces, err := c.ListNamespaces()
+	assert.NoError(err)
+	assert.Equal([]string{"bookstore", "bookbuyer"}, namespaces)
+}
+
+func TestEndpoints(t *testing.T) {
+	assert := tassert.New(t)
+	c := newTestClient(t, "testdata")
+
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("192.168.0.10"), Port: 8080},
+		{IP: net.ParseIP("192.168.0.11"), Port: 8080},
+	}, c.ListEndpointsForService(bookstoreHTTP))
+
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("10.0.0.10"), Port: 14001},
+		{IP: net.ParseIP("10.0.0.10"), Port: 9000},
+	}, c.GetResolvableEndpointsForService(bookstoreHTTP))
+
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("192.168.1.10")},
+	}, c.ListEndpointsForIdentity(bookbuyerIdentity))
+}
+
+func TestProxies(t *testing.T) {
+	testCases := []struct {
+		name                string
+		proxy               *envoy.Proxy
+		expectedErr         error
+		expectedServices    []service.MeshService
+		expectedStatsHeader map[string]string
+		expectedMetrics     bool
+		expectedIPs         []net.IP
+	}{
+		{
+			name:             "known proxy",
+			proxy:            newTestProxy("6a3bb75c-3e07-4a2f-8f5c-4c0a5e5c1a02", bookstoreIdentity),
+			expectedServices: []service.MeshService{bookstoreHTTP, bookstoreTCP},
+			expectedStatsHeader: map[string]string{
+				"osm-stats-pod":       "bookstore-vm2",
+				"osm-stats-namespace": "bookstore",
+				"osm-stats-kind":      "Workload",
+				"osm-stats-name":      "bookstore",
+			},
+			expectedMetrics: true,
+			expectedIPs:     []net.IP{net.ParseIP("192.168.0.11")},
+		},
+		{
+			name:        "unknown proxy",
+			proxy:       newTestProxy("8d2c5a53-4b7a-4f63-9d5e-3b4e8f1c9a00", bookstoreIdentity),
+			expectedErr: errProxyNotFound,
+		},
+		{
+			name:        "proxy with the identity of another workload",
+			proxy:       newTestProxy("0c8cc4b6-7d18-4d7e-9a3b-0f2d8b0e2b01", bookstoreIdentity),
+			expectedErr: errIdentityMismatch,
+		},
+	}
+
+	c := newTestClient(t, "testdata")
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			err := c.VerifyProxy(tc.proxy)
+			assert.ErrorIs(err, tc.expectedErr)
+
+			services, err := c.ListServicesForProxy(tc.proxy)
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.ElementsMatch(tc.expectedServices, services)
+
+			headers, err := c.GetProxyStatsHeaders(tc.proxy)
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Equal(tc.expectedStatsHeader, headers)
+
+			metrics, err := c.IsMetricsEnabled(tc.proxy)
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Equal(tc.expectedMetrics, metrics)
+
+			ips, err := c.ListIPsForProxy(tc.proxy)
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Equal(tc.expectedIPs, ips)
+		})
+	}
+}
+
+func TestPolicies(t *testing.T) {
+	assert := tassert.New(t)
+	c := newTestClient(t, "testdata")
+
+	assert.Len(c.ListRetryPoliciesForServiceAccount(identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer"}), 1)
+	assert.Empty(c.ListRetryPoliciesForServiceAccount(identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"}))
+	assert.Nil(c.GetUpstreamTrafficSetting(&types.NamespacedName{Namespace: "bookstore", Name: "bookstore"}))
+
+	extensions, err := c.ListHTTPFilterExtensionsForProxy(newTestProxy("6a3bb75c-3e07-4a2f-8f5c-4c0a5e5c1a01", bookstoreIdentity))
+	assert.NoError(err)
+	assert.Len(extensions, 1)
+	extensions, err = c.ListHTTPFilterExtensionsForProxy(newTestProxy("0c8cc4b6-7d18-4d7e-9a3b-0f2d8b0e2b01", bookbuyerIdentity))
+	assert.NoError(err)
+	assert.Empty(extensions)
+
+	module, err := c.GetHTTPFilterModule("bookstore", policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "auth.wasm"})
+	assert.NoError(err)
+	assert.Equal([]byte("\x00asm\x01\x00\x00\x00"), module)
+	_, err = c.GetHTTPFilterModule("bookbuyer", policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "auth.wasm"})
+	assert.Error(err)
+}
+
+func TestMeshSpec(t *testing.T) {
+	assert := tassert.New(t)
+	c := newTestClient(t, "testdata")
+
+	// The TrafficTarget outside of the namespace of its destination is ignored
+	trafficTargets := c.ListTrafficTargets()
+	assert.Len(trafficTargets, 1)
+	assert.Equal("bookbuyer-access-bookstore", trafficTargets[0].Name)
+	assert.Len(c.ListTrafficTargets(smi.WithTrafficTargetDestination(identity.K8sServiceAccount{Name: "bookstore", Namespace: "bookstore"})), 1)
+	assert.Empty(c.ListTrafficTargets(smi.WithTrafficTargetDestination(identity.K8sServiceAccount{Name: "bookbuyer", Namespace: "bookbuyer"})))
+
+	// The SPIFFE ID of the federated trust domain is not a service account
+	assert.ElementsMatch([]identity.K8sServiceAccount{
+		{Name: "bookbuyer", Namespace: "bookbuyer"},
+		{Name: "bookstore", Namespace: "bookstore"},
+	}, c.ListServiceAccounts())
+
+	assert.Len(c.ListHTTPTrafficSpecs(), 1)
+	assert.NotNil(c.GetHTTPRouteGroup("bookstore/bookstore-routes"))
+	assert.Nil(c.GetHTTPRouteGroup("bookbuyer/bookstore-routes"))
+	assert.Len(c.ListTCPTrafficSpecs(), 1)
+	assert.NotNil(c.GetTCPRoute("bookstore/bookstore-admin"))
+	assert.Nil(c.GetTCPRoute("bookstore/bookstore-routes"))
+
+	assert.Len(c.ListTrafficSplits(), 1)
+	assert.Len(c.ListTrafficSplits(smi.WithTrafficSplitApexService(service.MeshService{Name: "bookstore", Namespace: "bookstore"})), 1)
+	assert.Empty(c.ListTrafficSplits(smi.WithTrafficSplitApexService(service.MeshService{Name: "bookbuyer", Namespace: "bookbuyer"})))
+}
+
+func TestWatch(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir := t.TempDir()
+	c := newTestClient(t, dir)
+	assert.Empty(c.getState().services)
+
+	servicesCh, unsub := c.msgBroker.SubscribeKubeEvents(events.Service.Added())
+	defer unsub()
+
+	// Files written to new subdirectories are read as well
+	content, err := os.ReadFile("testdata/bookstore.yaml")
+	assert.NoError(err)
+	assert.NoError(os.Mkdir(filepath.Join(dir, "bookstore"), 0700))
+	time.Sleep(2 * reloadDelay)
+	assert.NoError(os.WriteFile(filepath.Join(dir, "bookstore", "bookstore.yaml"), content, 0600))
+
+	select {
+	case msg := <-servicesCh:
+		assert.Equal("bookstore", msg.(events.PubSubMessage).NewObj.(*Service).Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the change to the directory to be observed")
+	}
+	assert.Len(c.getState().workloads, 2)
+}
+
+func TestReload(t *testing.T) {
+	assert := tassert.New(t)
+
+	dir := t.TempDir()
+	content, err := os.ReadFile("testdata/bookstore.yaml")
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(filepath.Join(dir, "bookstore.yaml"), content, 0600))
+
+	c := newTestClient(t, dir)
+	assert.Len(c.getState().workloads, 2)
+
+	endpointsCh, unsub := c.msgBroker.SubscribeKubeEvents(events.Endpoint.Deleted())
+	defer unsub()
+
+	// Unchanged files are not parsed again
+	oldState := c.getState()
+	assert.NoError(c.reload())
+	assert.Same(oldState, c.getState())
+
+	// Invalid files keep the previous resources
+	assert.NoError(os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("kind: [\n"), 0600))
+	assert.Error(c.reload())
+	assert.Same(oldState, c.getState())
+
+	// Removing a workload announces the change
+	docs := strings.Split(string(content), "---\n")
+	assert.NoError(os.WriteFile(filepath.Join(dir, "bookstore.yaml"), []byte(strings.Join(docs[:len(docs)-1], "---\n")), 0600))
+	assert.NoError(os.Remove(filepath.Join(dir, "invalid.yaml")))
+	assert.NoError(c.reload())
+	assert.Len(c.getState().workloads, 1)
+
+	select {
+	case msg := <-endpointsCh:
+		assert.Equal("bookbuyer", msg.(events.PubSubMessage).OldObj.(*Workload).Name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the workload deletion to be announced")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	assert := tassert.New(t)
+
+	files, _, err := readDir("testdata")
+	assert.NoError(err)
+	assert.Len(files, 4)
+	s, err := parseFiles(files)
+	assert.NoError(err)
+
+	msgs := diff(&state{}, s)
+	var topics []string
+	for _, msg := range msgs {
+		topics = append(topics, msg.Topic())
+	}
+	assert.Equal([]string{
+		events.ConfigMap.Added(),
+		events.HTTPFilterExtension.Added(),
+		events.RouteGroup.Added(),
+		events.RetryPolicy.Added(),
+		events.Service.Added(),
+		events.Endpoint.Added(),
+		events.TCPRoute.Added(),
+		events.TrafficSplit.Added(),
+		events.TrafficTarget.Added(),
+		events.TrafficTarget.Added(),
+		events.Endpoint.Added(),
+		events.Endpoint.Added(),
+	}, topics)
+
+	assert.Empty(diff(s, s))
+	assert.Len(diff(s, &state{}), len(msgs))
+}
@@ -0,0 +1,316 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+)
+
+// readDir returns the contents of the YAML and JSON files in the given directory and its subdirectories, keyed by
+// path, along with a checksum of the contents used to detect changes.
+func readDir(dir string) (map[string][]byte, string, error) {
+	files := make(map[string][]byte)
+	hash := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		files[path] = content
+		// WalkDir visits files in lexical order, so the checksum is stable
+		_, _ = hash.Write([]byte(path))
+		_, _ = hash.Write(content)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return files, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// parseFiles parses the resources defined in the given files
+func parseFiles(files map[string][]byte) (*state, error) {
+	s := &state{}
+	for path, content := range files {
+		if err := s.parse(content); err != nil {
+			return nil, fmt.Errorf("error parsing file %s: %w", path, err)
+		}
+	}
+	s.sort()
+	return s, nil
+}
+
+// parse adds the resources in the given multi-document YAML or JSON content to the state
+func (s *state) parse(content []byte) error {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			return err
+		}
+
+		var obj metav1.Object
+		switch typeMeta.GroupVersionKind() {
+		case schema.FromAPIVersionAndKind(APIVersion, ServiceKind):
+			svc := &Service{}
+			s.services = append(s.services, svc)
+			obj = svc
+		case schema.FromAPIVersionAndKind(APIVersion, WorkloadKind):
+			workload := &Workload{}
+			s.workloads = append(s.workloads, workload)
+			obj = workload
+		case configv1alpha2.SchemeGroupVersion.WithKind("MeshConfig"):
+			if s.meshConfig != nil {
+				return fmt.Errorf("only one MeshConfig may be defined")
+			}
+			s.meshConfig = &configv1alpha2.MeshConfig{}
+			obj = s.meshConfig
+		case policyv1alpha1.SchemeGroupVersion.WithKind("Egress"):
+			egress := &policyv1alpha1.Egress{}
+			s.egresses = append(s.egresses, egress)
+			obj = egress
+		case policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend"):
+			ingressBackend := &policyv1alpha1.IngressBackend{}
+			s.ingressBackends = append(s.ingressBackends, ingressBackend)
+			obj = ingressBackend
+		case policyv1alpha1.SchemeGroupVersion.WithKind("Retry"):
+			retry := &policyv1alpha1.Retry{}
+			s.retries = append(s.retries, retry)
+			obj = retry
+		case policyv1alpha1.SchemeGroupVersion.WithKind("UpstreamTrafficSetting"):
+			setting := &policyv1alpha1.UpstreamTrafficSetting{}
+			s.upstreamTrafficSettings = append(s.upstreamTrafficSettings, setting)
+			obj = setting
+		case policyv1alpha1.SchemeGroupVersion.WithKind("HTTPFilterExtension"):
+			extension := &policyv1alpha1.HTTPFilterExtension{}
+			s.httpFilterExtensions = append(s.httpFilterExtensions, extension)
+			obj = extension
+		case smiAccess.SchemeGroupVersion.WithKind("TrafficTarget"):
+			trafficTarget := &smiAccess.TrafficTarget{}
+			s.trafficTargets = append(s.trafficTargets, trafficTarget)
+			obj = trafficTarget
+		case smiSpecs.SchemeGroupVersion.WithKind("HTTPRouteGroup"):
+			routeGroup := &smiSpecs.HTTPRouteGroup{}
+			s.httpRouteGroups = append(s.httpRouteGroups, routeGroup)
+			obj = routeGroup
+		case smiSpecs.SchemeGroupVersion.WithKind("TCPRoute"):
+			tcpRoute := &smiSpecs.TCPRoute{}
+			s.tcpRoutes = append(s.tcpRoutes, tcpRoute)
+			obj = tcpRoute
+		case smiSplit.SchemeGroupVersion.WithKind("TrafficSplit"):
+			trafficSplit := &smiSplit.TrafficSplit{}
+			s.trafficSplits = append(s.trafficSplits, trafficSplit)
+			obj = trafficSplit
+		case corev1.SchemeGroupVersion.WithKind("ConfigMap"):
+			// ConfigMaps hold the WASM modules of HTTPFilterExtension resources
+			configMap := &corev1.ConfigMap{}
+			s.configMaps = append(s.configMaps, configMap)
+			obj = configMap
+		default:
+			log.Warn().Msgf("Ignoring resource of unsupported kind %s", typeMeta.GroupVersionKind())
+			continue
+		}
+
+		if err := yaml.Unmarshal(doc, obj); err != nil {
+			return err
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(metav1.NamespaceDefault)
+		}
+	}
+}
+
+// sort orders the resources of each kind by namespace and name, so that the order files are parsed in does not
+// matter
+func (s *state) sort() {
+	sort.Slice(s.services, func(i, j int) bool { return less(s.services[i], s.services[j]) })
+	sort.Slice(s.workloads, func(i, j int) bool { return less(s.workloads[i], s.workloads[j]) })
+	sort.Slice(s.egresses, func(i, j int) bool { return less(s.egresses[i], s.egresses[j]) })
+	sort.Slice(s.ingressBackends, func(i, j int) bool { return less(s.ingressBackends[i], s.ingressBackends[j]) })
+	sort.Slice(s.retries, func(i, j int) bool { return less(s.retries[i], s.retries[j]) })
+	sort.Slice(s.upstreamTrafficSettings, func(i, j int) bool {
+		return less(s.upstreamTrafficSettings[i], s.upstreamTrafficSettings[j])
+	})
+	sort.Slice(s.httpFilterExtensions, func(i, j int) bool {
+		return less(s.httpFilterExtensions[i], s.httpFilterExtensions[j])
+	})
+	sort.Slice(s.trafficTargets, func(i, j int) bool { return less(s.trafficTargets[i], s.trafficTargets[j]) })
+	sort.Slice(s.httpRouteGroups, func(i, j int) bool { return less(s.httpRouteGroups[i], s.httpRouteGroups[j]) })
+	sort.Slice(s.tcpRoutes, func(i, j int) bool { return less(s.tcpRoutes[i], s.tcpRoutes[j]) })
+	sort.Slice(s.trafficSplits, func(i, j int) bool { return less(s.trafficSplits[i], s.trafficSplits[j]) })
+	sort.Slice(s.configMaps, func(i, j int) bool { return less(s.configMaps[i], s.configMaps[j]) })
+
+	for _, workload := range s.workloads {
+		for i := range workload.Spec.Instances {
+			if workload.Spec.Instances[i].Name == "" {
+				workload.Spec.Instances[i].Name = fmt.Sprintf("%s-%d", workload.Name, i)
+			}
+		}
+	}
+}
+
+// less orders objects by namespace and name
+func less(a, b metav1.Object) bool {
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// objects returns the resources in the state keyed by kind, namespace and name
+func (s *state) objects() map[string]interface{} {
+	objects := make(map[string]interface{})
+	add := func(kind string, obj metav1.Object) {
+		objects[fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())] = obj
+	}
+	if s.meshConfig != nil {
+		add("MeshConfig", s.meshConfig)
+	}
+	for _, obj := range s.services {
+		add(ServiceKind, obj)
+	}
+	for _, obj := range s.workloads {
+		add(WorkloadKind, obj)
+	}
+	for _, obj := range s.egresses {
+		add("Egress", obj)
+	}
+	for _, obj := range s.ingressBackends {
+		add("IngressBackend", obj)
+	}
+	for _, obj := range s.retries {
+		add("Retry", obj)
+	}
+	for _, obj := range s.upstreamTrafficSettings {
+		add("UpstreamTrafficSetting", obj)
+	}
+	for _, obj := range s.httpFilterExtensions {
+		add("HTTPFilterExtension", obj)
+	}
+	for _, obj := range s.trafficTargets {
+		add("TrafficTarget", obj)
+	}
+	for _, obj := range s.httpRouteGroups {
+		add("HTTPRouteGroup", obj)
+	}
+	for _, obj := range s.tcpRoutes {
+		add("TCPRoute", obj)
+	}
+	for _, obj := range s.trafficSplits {
+		add("TrafficSplit", obj)
+	}
+	for _, obj := range s.configMaps {
+		add("ConfigMap", obj)
+	}
+	return objects
+}
+
+// diff returns the events announcing the changes between the old and new states, ordered by kind, namespace and
+// name
+func diff(oldState, newState *state) []events.PubSubMessage {
+	oldObjects, newObjects := oldState.objects(), newState.objects()
+
+	keys := make([]string, 0, len(oldObjects)+len(newObjects))
+	for key := range newObjects {
+		keys = append(keys, key)
+	}
+	for key := range oldObjects {
+		if _, ok := newObjects[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var msgs []events.PubSubMessage
+	for _, key := range keys {
+		oldObj, oldOk := oldObjects[key]
+		newObj, newOk := newObjects[key]
+		switch {
+		case !oldOk:
+			msgs = append(msgs, newMessages(events.Added, nil, newObj)...)
+		case !newOk:
+			msgs = append(msgs, newMessages(events.Deleted, oldObj, nil)...)
+		case !reflect.DeepEqual(oldObj, newObj):
+			msgs = append(msgs, newMessages(events.Updated, oldObj, newObj)...)
+		}
+	}
+	return msgs
+}
+
+// newMessages returns the events announcing the given change to an object. Changes to workloads are announced as
+// endpoint changes, and changes to services as both service and endpoint changes, since either changes the
+// endpoints of the services in the mesh.
+func newMessages(eventType events.EventType, oldObj, newObj interface{}) []events.PubSubMessage {
+	obj := newObj
+	if obj == nil {
+		obj = oldObj
+	}
+
+	var kinds []events.Kind
+	switch obj.(type) {
+	case *Service:
+		kinds = []events.Kind{events.Service, events.Endpoint}
+	case *Workload:
+		kinds = []events.Kind{events.Endpoint}
+	default:
+		kinds = []events.Kind{events.GetKind(obj)}
+	}
+
+	msgs := make([]events.PubSubMessage, 0, len(kinds))
+	for _, kind := range kinds {
+		msgs = append(msgs, events.PubSubMessage{
+			Kind:   kind,
+			Type:   eventType,
+			OldObj: oldObj,
+			NewObj: newObj,
+		})
+	}
+	return msgs
+}
@@ -0,0 +1,91 @@
+package file
+
+import (
+	"fmt"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/smi"
+)
+
+// ListTrafficSplits lists the SMI TrafficSplit resources defined in the directory
+func (c *client) ListTrafficSplits(options ...smi.TrafficSplitListOption) []*smiSplit.TrafficSplit {
+	var trafficSplits []*smiSplit.TrafficSplit
+	for _, trafficSplit := range c.getState().trafficSplits {
+		if filteredSplit := smi.FilterTrafficSplit(trafficSplit, options...); filteredSplit != nil {
+			trafficSplits = append(trafficSplits, filteredSplit)
+		}
+	}
+	return trafficSplits
+}
+
+// ListServiceAccounts lists the service accounts specified in the SMI TrafficTarget resources defined in the
+// directory
+func (c *client) ListServiceAccounts() []identity.K8sServiceAccount {
+	var serviceAccounts []identity.K8sServiceAccount
+	for _, trafficTarget := range c.getState().trafficTargets {
+		if !smi.IsValidTrafficTarget(trafficTarget) {
+			continue
+		}
+		for _, source := range trafficTarget.Spec.Sources {
+			// Service accounts of federated trust domains are not defined in the directory
+			if source.Kind == smi.SPIFFEIDKind {
+				continue
+			}
+			serviceAccounts = append(serviceAccounts, identity.K8sServiceAccount{Namespace: source.Namespace, Name: source.Name})
+		}
+		serviceAccounts = append(serviceAccounts, identity.K8sServiceAccount{
+			Namespace: trafficTarget.Spec.Destination.Namespace,
+			Name:      trafficTarget.Spec.Destination.Name,
+		})
+	}
+	return serviceAccounts
+}
+
+// ListHTTPTrafficSpecs lists the SMI HTTPRouteGroup resources defined in the directory
+func (c *client) ListHTTPTrafficSpecs() []*smiSpecs.HTTPRouteGroup {
+	return c.getState().httpRouteGroups
+}
+
+// GetHTTPRouteGroup returns the SMI HTTPRouteGroup resource given its name of the form <namespace>/<name>
+func (c *client) GetHTTPRouteGroup(namespacedName string) *smiSpecs.HTTPRouteGroup {
+	for _, routeGroup := range c.getState().httpRouteGroups {
+		if fmt.Sprintf("%s/%s", routeGroup.Namespace, routeGroup.Name) == namespacedName {
+			return routeGroup
+		}
+	}
+	return nil
+}
+
+// ListTCPTrafficSpecs lists the SMI TCPRoute resources defined in the directory
+func (c *client) ListTCPTrafficSpecs() []*smiSpecs.TCPRoute {
+	return c.getState().tcpRoutes
+}
+
+// GetTCPRoute returns the SMI TCPRoute resource given its name of the form <namespace>/<name>
+func (c *client) GetTCPRoute(namespacedName string) *smiSpecs.TCPRoute {
+	for _, tcpRoute := range c.getState().tcpRoutes {
+		if fmt.Sprintf("%s/%s", tcpRoute.Namespace, tcpRoute.Name) == namespacedName {
+			return tcpRoute
+		}
+	}
+	return nil
+}
+
+// ListTrafficTargets lists the valid SMI TrafficTarget resources defined in the directory, filtered by the given
+// options
+func (c *client) ListTrafficTargets(options ...smi.TrafficTargetListOption) []*smiAccess.TrafficTarget {
+	var trafficTargets []*smiAccess.TrafficTarget
+	for _, trafficTarget := range c.getState().trafficTargets {
+		if !smi.IsValidTrafficTarget(trafficTarget) {
+			continue
+		}
+		if filteredTrafficTarget := smi.FilterTrafficTarget(trafficTarget, options...); filteredTrafficTarget != nil {
+			trafficTargets = append(trafficTargets, filteredTrafficTarget)
+		}
+	}
+	return trafficTargets
+}
@@ -0,0 +1,152 @@
+// Package file implements a compute provider that discovers services, workloads and policies, including SMI
+// policies, from a directory of YAML and JSON files watched for changes, for running the control plane without
+// Kubernetes against workloads that are not managed by Kubernetes.
+package file
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	smiAccess "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/access/v1alpha3"
+	smiSpecs "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/specs/v1alpha4"
+	smiSplit "github.com/servicemeshinterface/smi-sdk-go/pkg/apis/split/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+const (
+	// APIVersion is the apiVersion of the Service and Workload resources read by the file provider
+	APIVersion = "compute.openservicemesh.io/v1alpha1"
+
+	// ServiceKind is the kind of the Service resource read by the file provider
+	ServiceKind = "Service"
+
+	// WorkloadKind is the kind of the Workload resource read by the file provider
+	WorkloadKind = "Workload"
+
+	// kindSvcAccount is the ServiceAccount kind referenced by policies
+	kindSvcAccount = "ServiceAccount"
+
+	// reloadDelay is the delay after a change to the directory is observed before it is reloaded, so that the
+	// changes made together are applied together
+	reloadDelay = 100 * time.Millisecond
+)
+
+var (
+	log = logger.New("file-provider")
+
+	errServiceNotFound  = errors.New("service not found")
+	errProxyNotFound    = errors.New("no workload instance found for proxy")
+	errIdentityMismatch = errors.New("proxy identity does not match the service account of its workload")
+)
+
+// Service is a set of ports exposed by the instances of the workloads it selects.
+type Service struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceSpec `json:"spec"`
+}
+
+// ServiceSpec is the specification of a Service.
+type ServiceSpec struct {
+	// IP is the virtual IP the service is reachable at. If unset, clients resolve the service to the IPs of
+	// its endpoints.
+	// +optional
+	IP string `json:"ip,omitempty"`
+
+	// Selector selects the workloads backing the service by their labels.
+	Selector map[string]string `json:"selector"`
+
+	// Ports are the ports exposed by the service.
+	Ports []ServicePort `json:"ports"`
+}
+
+// ServicePort is a port exposed by a Service.
+type ServicePort struct {
+	// Name is the name of the port.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Port is the port clients connect to.
+	Port uint16 `json:"port"`
+
+	// TargetPort is the port the workload instances listen on. Defaults to Port.
+	// +optional
+	TargetPort uint16 `json:"targetPort,omitempty"`
+
+	// AppProtocol is the application protocol of the port. Defaults to the protocol prefixed to the port's name,
+	// or http.
+	// +optional
+	AppProtocol string `json:"appProtocol,omitempty"`
+}
+
+// Workload is a set of instances running with the same service identity, each with an Envoy sidecar.
+type Workload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkloadSpec `json:"spec"`
+}
+
+// WorkloadSpec is the specification of a Workload.
+type WorkloadSpec struct {
+	// ServiceAccount is the name of the service account, in the workload's namespace, that the workload's
+	// service identity is derived from.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// MetricsEnabled indicates whether Prometheus should scrape the sidecars of the workload.
+	// +optional
+	MetricsEnabled bool `json:"metricsEnabled,omitempty"`
+
+	// Instances are the running instances of the workload.
+	Instances []Instance `json:"instances"`
+}
+
+// Instance is a single running instance of a Workload.
+type Instance struct {
+	// Name is the name of the instance. Defaults to <workload>-<index>.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// IP is the IP address of the instance.
+	IP string `json:"ip"`
+
+	// ProxyUUID is the UUID of the instance's sidecar, as encoded in the certificate it connects to the
+	// control plane with.
+	ProxyUUID string `json:"proxyUUID"`
+}
+
+// state is the set of resources read from the directory at a point in time
+type state struct {
+	meshConfig              *configv1alpha2.MeshConfig
+	services                []*Service
+	workloads               []*Workload
+	egresses                []*policyv1alpha1.Egress
+	ingressBackends         []*policyv1alpha1.IngressBackend
+	retries                 []*policyv1alpha1.Retry
+	upstreamTrafficSettings []*policyv1alpha1.UpstreamTrafficSetting
+	httpFilterExtensions    []*policyv1alpha1.HTTPFilterExtension
+	trafficTargets          []*smiAccess.TrafficTarget
+	httpRouteGroups         []*smiSpecs.HTTPRouteGroup
+	tcpRoutes               []*smiSpecs.TCPRoute
+	trafficSplits           []*smiSplit.TrafficSplit
+	configMaps              []*corev1.ConfigMap
+}
+
+// client is the compute provider backed by a directory of files
+type client struct {
+	dir          string
+	osmNamespace string
+	msgBroker    *messaging.Broker
+
+	mu       sync.RWMutex
+	state    *state
+	checksum string
+}
@@ -11,10 +11,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
-	"k8s.io/utils/pointer"
-
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
 
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 
@@ -36,13 +35,15 @@ func NewClient(kubeController k8s.Controller) *client { //nolint: revive // unex
 func (c *client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
 	log.Trace().Msgf("Getting Endpoints for MeshService %s on Kubernetes", svc)
 
+	// WorkloadEntry resources are not part of the k8s endpoints, which only track pods
+	endpoints := c.listWorkloadEntryEndpointsForService(svc)
+
 	kubernetesEndpoints, err := c.kubeController.GetEndpoints(svc.Name, svc.Namespace)
 	if err != nil || kubernetesEndpoints == nil {
 		log.Info().Msgf("No k8s endpoints found for MeshService %s", svc)
-		return nil
+		return endpoints
 	}
 
-	var endpoints []endpoint.Endpoint
 	for _, kubernetesEndpoint := range kubernetesEndpoints.Subsets {
 		for _, port := range kubernetesEndpoint.Ports {
 			// If a TargetPort is specified for the service, filter the endpoint by this port.
@@ -102,6 +103,18 @@ func (c *client) ListEndpointsForIdentity(serviceIdentity identity.ServiceIdenti
 		}
 	}
 
+	for _, entry := range c.kubeController.ListWorkloadEntries() {
+		if getWorkloadEntryIdentity(entry) != serviceIdentity {
+			continue
+		}
+		ip := net.ParseIP(entry.Spec.Address)
+		if ip == nil {
+			log.Error().Msgf("Error parsing address %s of WorkloadEntry %s/%s", entry.Spec.Address, entry.Namespace, entry.Name)
+			continue
+		}
+		endpoints = append(endpoints, endpoint.Endpoint{IP: ip})
+	}
+
 	log.Trace().Msgf("[ListEndpointsForIdentity] Endpoints for service identity (serviceAccount=%s) %s: %+v", serviceIdentity, sa, endpoints)
 
 	return endpoints
@@ -129,12 +142,32 @@ func (c *client) GetServicesForServiceIdentity(svcIdentity identity.ServiceIdent
 		}
 	}
 
+	for _, entry := range c.kubeController.ListWorkloadEntries() {
+		if getWorkloadEntryIdentity(entry) != svcIdentity {
+			continue
+		}
+
+		for _, svc := range c.getServicesByLabels(entry.Labels, entry.Namespace) {
+			if added := svcSet.Add(svc); added {
+				meshServices = append(meshServices, svc)
+			}
+		}
+	}
+
 	log.Trace().Msgf("Services for service account %s: %v", svcAccount, meshServices)
 	return meshServices
 }
 
 // ListServicesForProxy maps an Envoy instance to a number of Kubernetes services.
 func (c *client) ListServicesForProxy(p *envoy.Proxy) ([]service.MeshService, error) {
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(p)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		return c.getServicesByLabels(entry.Labels, entry.Namespace), nil
+	}
+
 	pod, err := c.kubeController.GetPodForProxy(p)
 	if err != nil {
 		return nil, err
@@ -200,18 +233,24 @@ func (c *client) GetResolvableEndpointsForService(svc service.MeshService) []end
 		return c.ListEndpointsForService(svc)
 	}
 
-	// Cluster IP is present
-	ip := net.ParseIP(kubeService.Spec.ClusterIP)
-	if ip == nil {
-		log.Error().Msgf("Could not parse Cluster IP %s", kubeService.Spec.ClusterIP)
-		return nil
+	// Cluster IP is present, dual-stack services have a cluster IP of each IP family
+	clusterIPs := kubeService.Spec.ClusterIPs
+	if len(clusterIPs) == 0 {
+		clusterIPs = []string{kubeService.Spec.ClusterIP}
 	}
+	for _, clusterIP := range clusterIPs {
+		ip := net.ParseIP(clusterIP)
+		if ip == nil {
+			log.Error().Msgf("Could not parse Cluster IP %s", clusterIP)
+			return nil
+		}
 
-	for _, svcPort := range kubeService.Spec.Ports {
-		endpoints = append(endpoints, endpoint.Endpoint{
-			IP:   ip,
-			Port: endpoint.Port(svcPort.Port),
-		})
+		for _, svcPort := range kubeService.Spec.Ports {
+			endpoints = append(endpoints, endpoint.Endpoint{
+				IP:   ip,
+				Port: endpoint.Port(svcPort.Port),
+			})
+		}
 	}
 
 	return endpoints
@@ -228,11 +267,11 @@ func (c *client) ListServices() []service.MeshService {
 
 // IsMetricsEnabled checks if prometheus metrics scraping are enabled on this pod.
 func (c *client) IsMetricsEnabled(proxy *envoy.Proxy) (bool, error) {
-	pod, err := c.kubeController.GetPodForProxy(proxy)
+	annotations, err := c.getAnnotationsForProxy(proxy)
 	if err != nil {
 		return false, err
 	}
-	val, ok := pod.Annotations[constants.PrometheusScrapeAnnotation]
+	val, ok := annotations[constants.PrometheusScrapeAnnotation]
 	if !ok {
 		return false, nil
 	}
@@ -240,6 +279,76 @@ func (c *client) IsMetricsEnabled(proxy *envoy.Proxy) (bool, error) {
 	return strconv.ParseBool(val)
 }
 
+// IsWorkloadKeyGenerationEnabled returns whether the pod of the given proxy runs the osm-cert-agent container,
+// generating the private key of its Envoy sidecar. The private keys of WorkloadEntry proxies are always generated by
+// osm-controller.
+func (c *client) IsWorkloadKeyGenerationEnabled(proxy *envoy.Proxy) (bool, error) {
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(proxy)
+	if err != nil {
+		return false, err
+	}
+	if entry != nil {
+		return false, nil
+	}
+
+	pod, err := c.kubeController.GetPodForProxy(proxy)
+	if err != nil {
+		return false, err
+	}
+	return csr.HasCertAgent(pod), nil
+}
+
+// ListIPsForProxy returns the IP addresses of the WorkloadEntry or pod the given proxy runs on, a dual-stack pod having
+// an IP address of each IP family
+func (c *client) ListIPsForProxy(proxy *envoy.Proxy) ([]net.IP, error) {
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		ip := net.ParseIP(entry.Spec.Address)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %q of WorkloadEntry %s/%s", entry.Spec.Address, entry.Namespace, entry.Name)
+		}
+		return []net.IP{ip}, nil
+	}
+
+	pod, err := c.kubeController.GetPodForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	podIPs := pod.Status.PodIPs
+	if len(podIPs) == 0 && pod.Status.PodIP != "" {
+		podIPs = []corev1.PodIP{{IP: pod.Status.PodIP}}
+	}
+	var ips []net.IP
+	for _, podIP := range podIPs {
+		ip := net.ParseIP(podIP.IP)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q of pod %s/%s", podIP.IP, pod.Namespace, pod.Name)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// getAnnotationsForProxy returns the annotations of the WorkloadEntry or pod the given proxy runs on
+func (c *client) getAnnotationsForProxy(proxy *envoy.Proxy) (map[string]string, error) {
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		return entry.Annotations, nil
+	}
+
+	pod, err := c.kubeController.GetPodForProxy(proxy)
+	if err != nil {
+		return nil, err
+	}
+	return pod.Annotations, nil
+}
+
 // GetHostnamesForService returns the hostnames over which the service is accessible
 func (c *client) GetHostnamesForService(svc service.MeshService, localNamespace bool) []string {
 	var hostnames []string
@@ -390,6 +499,19 @@ func DetectIngressBackendConflicts(x policyv1alpha1.IngressBackend, y policyv1al
 
 // GetProxyStatsHeaders returns stats headers for the given proxy.
 func (c *client) GetProxyStatsHeaders(p *envoy.Proxy) (map[string]string, error) {
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(p)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		return map[string]string{
+			"osm-stats-pod":       entry.Name,
+			"osm-stats-namespace": entry.Namespace,
+			"osm-stats-kind":      workloadEntryKind,
+			"osm-stats-name":      entry.Name,
+		}, nil
+	}
+
 	pod, err := c.kubeController.GetPodForProxy(p)
 	if err != nil {
 		log.Warn().Str("proxy", p.String()).Msg("Could not find pod for connecting proxy. No metadata was recorded.")
@@ -422,9 +544,15 @@ func (c *client) GetProxyStatsHeaders(p *envoy.Proxy) (map[string]string, error)
 	}, nil
 }
 
-// VerifyProxy attempts to lookup a pod that matches the given proxy instance by service identity, namespace, and UUID.
+// VerifyProxy attempts to lookup a WorkloadEntry or pod that matches the given proxy instance by service identity,
+// namespace, and UUID.
 func (c *client) VerifyProxy(proxy *envoy.Proxy) error {
-	_, err := c.kubeController.GetPodForProxy(proxy)
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(proxy)
+	if entry != nil || err != nil {
+		return err
+	}
+
+	_, err = c.kubeController.GetPodForProxy(proxy)
 	return err
 }
 
@@ -455,6 +583,10 @@ func (c *client) ListServiceIdentitiesForService(name, namespace string) ([]iden
 		}
 	}
 
+	for _, entry := range c.listWorkloadEntriesForService(k8sSvc) {
+		svcAccountsSet.Add(getWorkloadEntryIdentity(entry).ToK8sServiceAccount())
+	}
+
 	for svcAcc := range svcAccountsSet.Iter() {
 		identities = append(identities, svcAcc.(identity.K8sServiceAccount).ToServiceIdentity())
 	}
@@ -488,21 +620,7 @@ func (c *client) serviceToMeshServices(svc corev1.Service) []service.MeshService
 			Port:      uint16(portSpec.Port),
 		}
 
-		// attempt to parse protocol from port name
-		// Order of Preference is:
-		// 1. port.appProtocol field
-		// 2. protocol prefixed to port name (e.g. tcp-my-port)
-		// 3. default to http
-		protocol := constants.ProtocolHTTP
-		for _, p := range constants.SupportedProtocolsInMesh {
-			if strings.HasPrefix(portSpec.Name, p+"-") {
-				protocol = p
-				break
-			}
-		}
-
-		// use port.appProtocol if specified, else use port protocol
-		meshSvc.Protocol = pointer.StringDeref(portSpec.AppProtocol, protocol)
+		meshSvc.Protocol = GetServicePortProtocol(svc, portSpec)
 
 		// The endpoints for the kubernetes service carry information that allows
 		// us to retrieve the TargetPort for the MeshService.
@@ -512,6 +630,15 @@ func (c *client) serviceToMeshServices(svc corev1.Service) []service.MeshService
 		} else {
 			log.Warn().Msgf("k8s service %s/%s does not have endpoints but is being represented as a MeshService", svc.Namespace, svc.Name)
 		}
+		if meshSvc.TargetPort == 0 {
+			// The service may only select WorkloadEntry resources, which are not part of its endpoints
+			for _, entry := range c.listWorkloadEntriesForService(&svc) {
+				if targetPort, ok := getWorkloadEntryTargetPort(entry, portSpec); ok {
+					meshSvc.TargetPort = targetPort
+					break
+				}
+			}
+		}
 		if !IsHeadlessService(svc) || endpoints == nil {
 			meshServices = append(meshServices, meshSvc)
 			continue
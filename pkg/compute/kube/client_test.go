@@ -51,6 +51,7 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 	mockKubeController = k8s.NewMockController(mockCtrl)
 
 	mockKubeController.EXPECT().IsMonitoredNamespace(tests.BookbuyerService.Namespace).Return(true).AnyTimes()
+	mockKubeController.EXPECT().ListWorkloadEntries().Return(nil).AnyTimes()
 
 	BeforeEach(func() {
 		c = NewClient(mockKubeController)
@@ -209,6 +210,35 @@ var _ = Describe("Test Kube client Provider (w/o kubecontroller)", func() {
 		}))
 	})
 
+	It("GetResolvableEndpoints should return the endpoints of each cluster IP of a dual-stack service", func() {
+		mockKubeController.EXPECT().GetService(tests.BookbuyerService.Name, tests.BookbuyerService.Namespace).Return(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tests.BookbuyerService.Name,
+				Namespace: tests.BookbuyerService.Namespace,
+			},
+			Spec: corev1.ServiceSpec{
+				ClusterIP:  "192.168.0.1",
+				ClusterIPs: []string{"192.168.0.1", "fd00:10::1"},
+				Ports: []corev1.ServicePort{{
+					Name:     "servicePort",
+					Protocol: corev1.ProtocolTCP,
+					Port:     tests.ServicePort,
+				}},
+			},
+		})
+
+		Expect(c.GetResolvableEndpointsForService(tests.BookbuyerService)).To(Equal([]endpoint.Endpoint{
+			{
+				IP:   net.IPv4(192, 168, 0, 1),
+				Port: tests.ServicePort,
+			},
+			{
+				IP:   net.ParseIP("fd00:10::1"),
+				Port: tests.ServicePort,
+			},
+		}))
+	})
+
 	It("GetResolvableEndpoints should properly return actual endpoints without ClusterIP when ClusterIP is not set", func() {
 		// Expect the individual pod endpoints, when no cluster IP is assigned to the service
 		mockKubeController.EXPECT().GetService(meshSvc.Name, meshSvc.Namespace).Return(&corev1.Service{
@@ -382,6 +412,7 @@ func TestListEndpointsForIdentity(t *testing.T) {
 				pods = append(pods, pod)
 			}
 			mockKubeController.EXPECT().ListPods().Return(pods).AnyTimes()
+			mockKubeController.EXPECT().ListWorkloadEntries().Return(nil).AnyTimes()
 
 			actual := provider.ListEndpointsForIdentity(tc.serviceAccount)
 			assert.NotNil(actual)
@@ -608,6 +639,7 @@ func TestIsMetricsEnabled(t *testing.T) {
 			assert := tassert.New(t)
 			mockCtrl := gomock.NewController(t)
 			k := k8s.NewMockController(mockCtrl)
+			k.EXPECT().GetWorkloadEntryForProxy(gomock.Any()).Return(nil, nil)
 			if tc.pod != nil {
 				k.EXPECT().GetPodForProxy(gomock.Any()).Return(tc.pod, nil)
 			} else {
@@ -626,6 +658,156 @@ func TestIsMetricsEnabled(t *testing.T) {
 	}
 }
 
+func TestIsWorkloadKeyGenerationEnabled(t *testing.T) {
+	testCases := []struct {
+		name          string
+		entry         *policyv1alpha1.WorkloadEntry
+		pod           *corev1.Pod
+		expectEnabled bool
+		expectErr     bool
+	}{
+		{
+			name: "pod without the cert agent",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: constants.EnvoyContainerName}},
+				},
+			},
+			expectEnabled: false,
+		},
+		{
+			name: "pod running the cert agent",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: constants.EnvoyContainerName}, {Name: constants.CertAgentContainerName}},
+				},
+			},
+			expectEnabled: true,
+		},
+		{
+			name: "pod running the cert agent as a native sidecar",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Name: constants.CertAgentContainerName}, {Name: constants.EnvoyContainerName}},
+				},
+			},
+			expectEnabled: true,
+		},
+		{
+			name:          "WorkloadEntry",
+			entry:         &policyv1alpha1.WorkloadEntry{},
+			expectEnabled: false,
+		},
+		{
+			name:      "pod not found",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			k := k8s.NewMockController(mockCtrl)
+			k.EXPECT().GetWorkloadEntryForProxy(gomock.Any()).Return(tc.entry, nil)
+			if tc.entry == nil {
+				if tc.pod != nil {
+					k.EXPECT().GetPodForProxy(gomock.Any()).Return(tc.pod, nil)
+				} else {
+					k.EXPECT().GetPodForProxy(gomock.Any()).Return(nil, errors.New("not found"))
+				}
+			}
+			c := NewClient(k)
+
+			actual, err := c.IsWorkloadKeyGenerationEnabled(&envoy.Proxy{})
+			assert.Equal(tc.expectEnabled, actual)
+			if tc.expectErr {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
+
+func TestListIPsForProxy(t *testing.T) {
+	testCases := []struct {
+		name        string
+		entry       *policyv1alpha1.WorkloadEntry
+		pod         *corev1.Pod
+		expectedIPs []net.IP
+		expectErr   bool
+	}{
+		{
+			name: "dual-stack pod",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					PodIP:  "10.0.0.5",
+					PodIPs: []corev1.PodIP{{IP: "10.0.0.5"}, {IP: "fd00::5"}},
+				},
+			},
+			expectedIPs: []net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("fd00::5")},
+		},
+		{
+			name: "pod without pod IPs",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					PodIP: "fd00::5",
+				},
+			},
+			expectedIPs: []net.IP{net.ParseIP("fd00::5")},
+		},
+		{
+			name: "pod with an invalid IP",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{
+					PodIPs: []corev1.PodIP{{IP: "invalid"}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "WorkloadEntry",
+			entry: &policyv1alpha1.WorkloadEntry{
+				Spec: policyv1alpha1.WorkloadEntrySpec{
+					Address: "fd00::10",
+				},
+			},
+			expectedIPs: []net.IP{net.ParseIP("fd00::10")},
+		},
+		{
+			name: "WorkloadEntry with an invalid address",
+			entry: &policyv1alpha1.WorkloadEntry{
+				Spec: policyv1alpha1.WorkloadEntrySpec{
+					Address: "invalid",
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			k := k8s.NewMockController(mockCtrl)
+			k.EXPECT().GetWorkloadEntryForProxy(gomock.Any()).Return(tc.entry, nil)
+			if tc.entry == nil {
+				k.EXPECT().GetPodForProxy(gomock.Any()).Return(tc.pod, nil)
+			}
+			c := NewClient(k)
+
+			actual, err := c.ListIPsForProxy(&envoy.Proxy{})
+			if tc.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedIPs, actual)
+		})
+	}
+}
+
 func TestListServicesForProxy(t *testing.T) {
 	goodUUID := uuid.New()
 	badUUID := uuid.New()
@@ -1961,6 +2143,7 @@ func TestListServiceIdentitiesForService(t *testing.T) {
 			mockCtrl := gomock.NewController(t)
 			controller := k8s.NewMockController(mockCtrl)
 			controller.EXPECT().ListPods().Return(tc.pods).AnyTimes()
+			controller.EXPECT().ListWorkloadEntries().Return(nil).AnyTimes()
 			if tc.svc.Name == tc.service.Name && tc.svc.Namespace == tc.service.Namespace {
 				controller.EXPECT().GetService(tc.svc.Name, tc.svc.Namespace).Return(tc.service).AnyTimes()
 			} else {
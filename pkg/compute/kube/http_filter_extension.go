@@ -0,0 +1,63 @@
+package kube
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// ListHTTPFilterExtensionsForProxy returns the HTTPFilterExtension resources selecting the pod or WorkloadEntry of
+// the given proxy
+func (c *client) ListHTTPFilterExtensionsForProxy(p *envoy.Proxy) ([]*policyv1alpha1.HTTPFilterExtension, error) {
+	extensions := c.kubeController.ListHTTPFilterExtensions()
+	if len(extensions) == 0 {
+		return nil, nil
+	}
+
+	var namespace string
+	var workloadLabels map[string]string
+	entry, err := c.kubeController.GetWorkloadEntryForProxy(p)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		namespace, workloadLabels = entry.Namespace, entry.Labels
+	} else {
+		pod, err := c.kubeController.GetPodForProxy(p)
+		if err != nil {
+			return nil, err
+		}
+		namespace, workloadLabels = pod.Namespace, pod.Labels
+	}
+
+	var selected []*policyv1alpha1.HTTPFilterExtension
+	for _, extension := range extensions {
+		if extension.Namespace != namespace {
+			continue
+		}
+		// An empty selector selects all the workloads in the namespace
+		if labels.SelectorFromSet(extension.Spec.Selector).Matches(labels.Set(workloadLabels)) {
+			selected = append(selected, extension)
+		}
+	}
+	return selected, nil
+}
+
+// GetHTTPFilterModule returns the WASM module held by the referenced ConfigMap in the given namespace
+func (c *client) GetHTTPFilterModule(namespace string, ref policyv1alpha1.ConfigMapKeyRef) ([]byte, error) {
+	configMap := c.kubeController.GetHTTPFilterModule(ref.Name, namespace)
+	if configMap == nil {
+		return nil, fmt.Errorf("ConfigMap %s/%s holding HTTP filter modules not found", namespace, ref.Name)
+	}
+	if module, ok := configMap.BinaryData[ref.Key]; ok {
+		return module, nil
+	}
+	if module, ok := configMap.Data[ref.Key]; ok {
+		return []byte(module), nil
+	}
+	return nil, fmt.Errorf("key %s not found in ConfigMap %s/%s", ref.Key, namespace, ref.Name)
+}
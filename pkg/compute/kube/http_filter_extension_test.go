@@ -0,0 +1,79 @@
+package kube
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+func TestListHTTPFilterExtensionsForProxy(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	c := NewClient(mockKubeController)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bookstore-1",
+			Namespace: "bookstore",
+			Labels:    map[string]string{"app": "bookstore", "version": "v1"},
+		},
+	}
+	allWorkloads := &policyv1alpha1.HTTPFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "bookstore"},
+	}
+	selected := &policyv1alpha1.HTTPFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "v1", Namespace: "bookstore"},
+		Spec:       policyv1alpha1.HTTPFilterExtensionSpec{Selector: map[string]string{"version": "v1"}},
+	}
+	notSelected := &policyv1alpha1.HTTPFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "v2", Namespace: "bookstore"},
+		Spec:       policyv1alpha1.HTTPFilterExtensionSpec{Selector: map[string]string{"version": "v2"}},
+	}
+	otherNamespace := &policyv1alpha1.HTTPFilterExtension{
+		ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "bookbuyer"},
+	}
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("bookstore", "bookstore"), nil, 1)
+
+	mockKubeController.EXPECT().ListHTTPFilterExtensions().Return([]*policyv1alpha1.HTTPFilterExtension{
+		allWorkloads, selected, notSelected, otherNamespace,
+	})
+	mockKubeController.EXPECT().GetWorkloadEntryForProxy(proxy).Return(nil, nil)
+	mockKubeController.EXPECT().GetPodForProxy(proxy).Return(pod, nil)
+
+	extensions, err := c.ListHTTPFilterExtensionsForProxy(proxy)
+	assert.NoError(err)
+	assert.Equal([]*policyv1alpha1.HTTPFilterExtension{allWorkloads, selected}, extensions)
+}
+
+func TestGetHTTPFilterModule(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	c := NewClient(mockKubeController)
+
+	mockKubeController.EXPECT().GetHTTPFilterModule("modules", "bookstore").Return(&corev1.ConfigMap{
+		BinaryData: map[string][]byte{"auth.wasm": []byte("module")},
+	}).Times(2)
+	mockKubeController.EXPECT().GetHTTPFilterModule("missing", "bookstore").Return(nil)
+
+	module, err := c.GetHTTPFilterModule("bookstore", policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "auth.wasm"})
+	assert.NoError(err)
+	assert.Equal([]byte("module"), module)
+
+	_, err = c.GetHTTPFilterModule("bookstore", policyv1alpha1.ConfigMapKeyRef{Name: "modules", Key: "other.wasm"})
+	assert.Error(err)
+
+	_, err = c.GetHTTPFilterModule("bookstore", policyv1alpha1.ConfigMapKeyRef{Name: "missing", Key: "auth.wasm"})
+	assert.Error(err)
+}
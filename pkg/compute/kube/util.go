@@ -1,7 +1,13 @@
 package kube
 
 import (
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 // GetTargetPortFromEndpoints returns the endpoint port corresponding to the given endpoint name and endpoints
@@ -41,3 +47,68 @@ func GetTargetPortFromEndpoints(endpointName string, endpoints corev1.Endpoints)
 func IsHeadlessService(svc corev1.Service) bool {
 	return len(svc.Spec.ClusterIP) == 0 || svc.Spec.ClusterIP == corev1.ClusterIPNone
 }
+
+// GetServicePortProtocol returns the protocol served by the given port of the given service
+func GetServicePortProtocol(svc corev1.Service, portSpec corev1.ServicePort) string {
+	// attempt to parse protocol from port name
+	// Order of Preference is:
+	// 1. port listed in the service's opaque ports annotation
+	// 2. port.appProtocol field
+	// 3. protocol prefixed to port name (e.g. tcp-my-port)
+	// 4. default to http
+	if isOpaqueServicePort(svc, portSpec) {
+		return constants.ProtocolTCPServerFirst
+	}
+
+	protocol := constants.ProtocolHTTP
+	if p := getServicePortNameProtocol(portSpec); p != "" {
+		protocol = p
+	}
+
+	// use port.appProtocol if specified, else use port protocol
+	protocol = pointer.StringDeref(portSpec.AppProtocol, protocol)
+	if protocol == constants.ProtocolOpaque {
+		return constants.ProtocolTCPServerFirst
+	}
+	return protocol
+}
+
+// IsServicePortProtocolAmbiguous returns true if the protocol served by the given port of the given service is
+// neither specified by the port's appProtocol field, a protocol prefixed to the port's name, nor the service's
+// opaque ports annotation, in which case the protocol defaults to http.
+func IsServicePortProtocolAmbiguous(svc corev1.Service, portSpec corev1.ServicePort) bool {
+	return portSpec.AppProtocol == nil && getServicePortNameProtocol(portSpec) == "" && !isOpaqueServicePort(svc, portSpec)
+}
+
+// isOpaqueServicePort returns true if the given port is listed in the opaque ports annotation of the given service
+func isOpaqueServicePort(svc corev1.Service, portSpec corev1.ServicePort) bool {
+	opaquePorts, ok := svc.Annotations[constants.OpaquePortsAnnotation]
+	if !ok {
+		return false
+	}
+
+	for _, portStr := range strings.Split(opaquePorts, ",") {
+		portStr = strings.TrimSpace(portStr)
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid port value '%s' specified for annotation '%s' on service %s/%s, ignoring it",
+				portStr, constants.OpaquePortsAnnotation, svc.Namespace, svc.Name)
+			continue
+		}
+		if int32(port) == portSpec.Port {
+			return true
+		}
+	}
+	return false
+}
+
+// getServicePortNameProtocol returns the protocol prefixed to the name of the given service port,
+// or an empty string if the name isn't prefixed with a supported protocol.
+func getServicePortNameProtocol(portSpec corev1.ServicePort) string {
+	for _, p := range constants.SupportedProtocolsInMesh {
+		if strings.HasPrefix(portSpec.Name, p+"-") {
+			return p
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package kube
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestGetServicePortProtocol(t *testing.T) {
+	testCases := []struct {
+		name              string
+		annotations       map[string]string
+		portSpec          corev1.ServicePort
+		expectedProtocol  string
+		expectedAmbiguous bool
+	}{
+		{
+			name:              "no appProtocol and no protocol in port name",
+			portSpec:          corev1.ServicePort{Name: "web", Port: 80},
+			expectedProtocol:  constants.ProtocolHTTP,
+			expectedAmbiguous: true,
+		},
+		{
+			name:              "unnamed port",
+			portSpec:          corev1.ServicePort{Port: 80},
+			expectedProtocol:  constants.ProtocolHTTP,
+			expectedAmbiguous: true,
+		},
+		{
+			name:              "protocol in port name",
+			portSpec:          corev1.ServicePort{Name: "tcp-db", Port: 5432},
+			expectedProtocol:  constants.ProtocolTCP,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "server-first protocol in port name",
+			portSpec:          corev1.ServicePort{Name: "tcp-server-first-db", Port: 3306},
+			expectedProtocol:  constants.ProtocolTCPServerFirst,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "auto protocol in port name",
+			portSpec:          corev1.ServicePort{Name: "auto-web", Port: 8080},
+			expectedProtocol:  constants.ProtocolAuto,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "appProtocol takes precedence over the port name",
+			portSpec:          corev1.ServicePort{Name: "tcp-web", Port: 8080, AppProtocol: pointer.StringPtr(constants.ProtocolAuto)},
+			expectedProtocol:  constants.ProtocolAuto,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "opaque appProtocol",
+			portSpec:          corev1.ServicePort{Name: "db", Port: 3306, AppProtocol: pointer.StringPtr(constants.ProtocolOpaque)},
+			expectedProtocol:  constants.ProtocolTCPServerFirst,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "port listed in the opaque ports annotation",
+			annotations:       map[string]string{constants.OpaquePortsAnnotation: "25, 3306"},
+			portSpec:          corev1.ServicePort{Name: "http-db", Port: 3306, AppProtocol: pointer.StringPtr(constants.ProtocolHTTP)},
+			expectedProtocol:  constants.ProtocolTCPServerFirst,
+			expectedAmbiguous: false,
+		},
+		{
+			name:              "port not listed in the opaque ports annotation",
+			annotations:       map[string]string{constants.OpaquePortsAnnotation: "25,invalid"},
+			portSpec:          corev1.ServicePort{Name: "web", Port: 80},
+			expectedProtocol:  constants.ProtocolHTTP,
+			expectedAmbiguous: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			svc := corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "s1",
+					Namespace:   "ns1",
+					Annotations: tc.annotations,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{tc.portSpec},
+				},
+			}
+
+			assert.Equal(tc.expectedProtocol, GetServicePortProtocol(svc, tc.portSpec))
+			assert.Equal(tc.expectedAmbiguous, IsServicePortProtocolAmbiguous(svc, tc.portSpec))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package kube
+
+import (
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// workloadEntryKind is the kind reported in the stats headers of proxies running on a WorkloadEntry
+	workloadEntryKind = "WorkloadEntry"
+)
+
+// listWorkloadEntriesForService returns the WorkloadEntry resources selected by the given service
+func (c *client) listWorkloadEntriesForService(svc *corev1.Service) []*policyv1alpha1.WorkloadEntry {
+	// Services without selectors do not select any WorkloadEntry
+	if len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := labels.Set(svc.Spec.Selector).AsSelector()
+
+	var entries []*policyv1alpha1.WorkloadEntry
+	for _, entry := range c.kubeController.ListWorkloadEntries() {
+		if entry.Namespace == svc.Namespace && selector.Matches(labels.Set(entry.Labels)) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// listWorkloadEntryEndpointsForService returns the endpoints of the WorkloadEntry resources selected by the
+// service corresponding to the given MeshService
+func (c *client) listWorkloadEntryEndpointsForService(meshSvc service.MeshService) []endpoint.Endpoint {
+	if len(c.kubeController.ListWorkloadEntries()) == 0 {
+		return nil
+	}
+
+	svc := c.kubeController.GetService(meshSvc.Name, meshSvc.Namespace)
+	if svc == nil {
+		return nil
+	}
+
+	var endpoints []endpoint.Endpoint
+	for _, entry := range c.listWorkloadEntriesForService(svc) {
+		ip := net.ParseIP(entry.Spec.Address)
+		if ip == nil {
+			log.Error().Msgf("Error parsing address %s of WorkloadEntry %s/%s", entry.Spec.Address, entry.Namespace, entry.Name)
+			continue
+		}
+		for _, portSpec := range svc.Spec.Ports {
+			targetPort, ok := getWorkloadEntryTargetPort(entry, portSpec)
+			if !ok {
+				continue
+			}
+			// If a TargetPort is specified for the MeshService, filter the endpoints by this port.
+			if meshSvc.TargetPort != 0 && targetPort != meshSvc.TargetPort {
+				continue
+			}
+			endpoints = append(endpoints, endpoint.Endpoint{IP: ip, Port: endpoint.Port(targetPort)})
+		}
+	}
+	return endpoints
+}
+
+// getWorkloadEntryTargetPort returns the port of the given WorkloadEntry that the given service port targets, and
+// whether the WorkloadEntry has such a port
+func getWorkloadEntryTargetPort(entry *policyv1alpha1.WorkloadEntry, portSpec corev1.ServicePort) (uint16, bool) {
+	if portSpec.TargetPort.Type == intstr.String {
+		for _, port := range entry.Spec.Ports {
+			if port.Name == portSpec.TargetPort.StrVal {
+				return uint16(port.Number), true
+			}
+		}
+		return 0, false
+	}
+
+	// The target port defaults to the service port when unset
+	if portSpec.TargetPort.IntVal == 0 {
+		return uint16(portSpec.Port), true
+	}
+	return uint16(portSpec.TargetPort.IntVal), true
+}
+
+// getWorkloadEntryIdentity returns the service identity of the given WorkloadEntry
+func getWorkloadEntryIdentity(entry *policyv1alpha1.WorkloadEntry) identity.ServiceIdentity {
+	return identity.K8sServiceAccount{
+		Name:      entry.Spec.ServiceAccount,
+		Namespace: entry.Namespace, // ServiceAccount must belong to the same namespace as the WorkloadEntry
+	}.ToServiceIdentity()
+}
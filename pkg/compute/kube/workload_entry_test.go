@@ -0,0 +1,188 @@
+package kube
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+func TestWorkloadEntries(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	const namespace = "legacy"
+	entryUUID := uuid.New()
+	entry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-1",
+			Namespace: namespace,
+			UID:       types.UID(entryUUID.String()),
+			Labels:    map[string]string{"app": "billing"},
+			Annotations: map[string]string{
+				constants.PrometheusScrapeAnnotation: "true",
+			},
+		},
+		Spec: policyv1alpha1.WorkloadEntrySpec{
+			Address:        "10.10.0.5",
+			ServiceAccount: "billing",
+			Ports: []policyv1alpha1.WorkloadEntryPortSpec{
+				{Name: "http", Number: 8080},
+			},
+		},
+	}
+	otherEntry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-2",
+			Namespace: namespace,
+			Labels:    map[string]string{"app": "other"},
+		},
+		Spec: policyv1alpha1.WorkloadEntrySpec{
+			Address:        "10.10.0.6",
+			ServiceAccount: "other",
+		},
+	}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "billing",
+			Namespace: namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:  map[string]string{"app": "billing"},
+			ClusterIP: "10.96.0.20",
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+				{Name: "tcp-admin", Port: 9000},
+				{Name: "grpc", Port: 9090, TargetPort: intstr.FromString("grpc")},
+			},
+		},
+	}
+	billingIdentity := identity.K8sServiceAccount{Name: "billing", Namespace: namespace}.ToServiceIdentity()
+	billingHTTP := service.MeshService{Name: "billing", Namespace: namespace, Port: 80, TargetPort: 8080, Protocol: "http"}
+	billingTCP := service.MeshService{Name: "billing", Namespace: namespace, Port: 9000, TargetPort: 9000, Protocol: "tcp"}
+	billingGRPC := service.MeshService{Name: "billing", Namespace: namespace, Port: 9090, Protocol: "http"}
+
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListWorkloadEntries().Return([]*policyv1alpha1.WorkloadEntry{entry, otherEntry}).AnyTimes()
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{svc}).AnyTimes()
+	mockKubeController.EXPECT().GetService(svc.Name, svc.Namespace).Return(svc).AnyTimes()
+	mockKubeController.EXPECT().GetEndpoints(svc.Name, svc.Namespace).Return(nil, nil).AnyTimes()
+	mockKubeController.EXPECT().ListPods().Return(nil).AnyTimes()
+	c := NewClient(mockKubeController)
+
+	// Services resolve their target ports using the ports of the WorkloadEntry resources they select
+	assert.ElementsMatch([]service.MeshService{billingHTTP, billingTCP, billingGRPC}, c.ListServices())
+
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("10.10.0.5"), Port: 8080},
+	}, c.ListEndpointsForService(billingHTTP))
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("10.10.0.5"), Port: 8080},
+		{IP: net.ParseIP("10.10.0.5"), Port: 9000},
+	}, c.ListEndpointsForService(service.MeshService{Name: "billing", Namespace: namespace}))
+
+	assert.ElementsMatch([]endpoint.Endpoint{
+		{IP: net.ParseIP("10.10.0.5")},
+	}, c.ListEndpointsForIdentity(billingIdentity))
+
+	assert.ElementsMatch([]service.MeshService{billingHTTP, billingTCP, billingGRPC}, c.GetServicesForServiceIdentity(billingIdentity))
+
+	identities, err := c.ListServiceIdentitiesForService(svc.Name, svc.Namespace)
+	assert.NoError(err)
+	assert.Equal([]identity.ServiceIdentity{billingIdentity}, identities)
+
+	// Proxies running on a WorkloadEntry are identified by the UID of the WorkloadEntry
+	proxy := envoy.NewProxy(envoy.KindSidecar, entryUUID, billingIdentity, nil, 1)
+	mockKubeController.EXPECT().GetWorkloadEntryForProxy(proxy).Return(entry, nil).AnyTimes()
+
+	assert.NoError(c.VerifyProxy(proxy))
+
+	services, err := c.ListServicesForProxy(proxy)
+	assert.NoError(err)
+	assert.ElementsMatch([]service.MeshService{billingHTTP, billingTCP, billingGRPC}, services)
+
+	enabled, err := c.IsMetricsEnabled(proxy)
+	assert.NoError(err)
+	assert.True(enabled)
+
+	headers, err := c.GetProxyStatsHeaders(proxy)
+	assert.NoError(err)
+	assert.Equal(map[string]string{
+		"osm-stats-pod":       "vm-1",
+		"osm-stats-namespace": namespace,
+		"osm-stats-kind":      "WorkloadEntry",
+		"osm-stats-name":      "vm-1",
+	}, headers)
+
+	// Errors looking up the WorkloadEntry of a proxy are not masked by looking up a pod
+	badProxy := envoy.NewProxy(envoy.KindSidecar, entryUUID, identity.K8sServiceAccount{Name: "other", Namespace: namespace}.ToServiceIdentity(), nil, 1)
+	mockKubeController.EXPECT().GetWorkloadEntryForProxy(badProxy).Return(nil, errors.New("service account mismatch")).AnyTimes()
+	assert.Error(c.VerifyProxy(badProxy))
+}
+
+func TestGetWorkloadEntryTargetPort(t *testing.T) {
+	entry := &policyv1alpha1.WorkloadEntry{
+		Spec: policyv1alpha1.WorkloadEntrySpec{
+			Ports: []policyv1alpha1.WorkloadEntryPortSpec{
+				{Name: "http", Number: 8080},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		portSpec     corev1.ServicePort
+		expectedPort uint16
+		expectedOk   bool
+	}{
+		{
+			name:         "target port defaults to the service port",
+			portSpec:     corev1.ServicePort{Port: 80},
+			expectedPort: 80,
+			expectedOk:   true,
+		},
+		{
+			name:         "numeric target port",
+			portSpec:     corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(9080)},
+			expectedPort: 9080,
+			expectedOk:   true,
+		},
+		{
+			name:         "named target port",
+			portSpec:     corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")},
+			expectedPort: 8080,
+			expectedOk:   true,
+		},
+		{
+			name:       "unknown named target port",
+			portSpec:   corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("grpc")},
+			expectedOk: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			port, ok := getWorkloadEntryTargetPort(entry, tc.portSpec)
+			assert.Equal(tc.expectedPort, port)
+			assert.Equal(tc.expectedOk, ok)
+		})
+	}
+}
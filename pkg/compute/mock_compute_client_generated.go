@@ -5,6 +5,7 @@
 package compute
 
 import (
+	net "net"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -40,6 +41,21 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// GetHTTPFilterModule mocks base method.
+func (m *MockInterface) GetHTTPFilterModule(arg0 string, arg1 v1alpha1.ConfigMapKeyRef) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPFilterModule", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHTTPFilterModule indicates an expected call of GetHTTPFilterModule.
+func (mr *MockInterfaceMockRecorder) GetHTTPFilterModule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPFilterModule", reflect.TypeOf((*MockInterface)(nil).GetHTTPFilterModule), arg0, arg1)
+}
+
 // GetHostnamesForService mocks base method.
 func (m *MockInterface) GetHostnamesForService(arg0 service.MeshService, arg1 bool) []string {
 	m.ctrl.T.Helper()
@@ -225,6 +241,21 @@ func (mr *MockInterfaceMockRecorder) IsMetricsEnabled(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMetricsEnabled", reflect.TypeOf((*MockInterface)(nil).IsMetricsEnabled), arg0)
 }
 
+// IsWorkloadKeyGenerationEnabled mocks base method.
+func (m *MockInterface) IsWorkloadKeyGenerationEnabled(arg0 *envoy.Proxy) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsWorkloadKeyGenerationEnabled", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsWorkloadKeyGenerationEnabled indicates an expected call of IsWorkloadKeyGenerationEnabled.
+func (mr *MockInterfaceMockRecorder) IsWorkloadKeyGenerationEnabled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsWorkloadKeyGenerationEnabled", reflect.TypeOf((*MockInterface)(nil).IsWorkloadKeyGenerationEnabled), arg0)
+}
+
 // ListEgressPolicies mocks base method.
 func (m *MockInterface) ListEgressPolicies() []*v1alpha1.Egress {
 	m.ctrl.T.Helper()
@@ -281,6 +312,50 @@ func (mr *MockInterfaceMockRecorder) ListEndpointsForService(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEndpointsForService", reflect.TypeOf((*MockInterface)(nil).ListEndpointsForService), arg0)
 }
 
+// ListHTTPFilterExtensions mocks base method.
+func (m *MockInterface) ListHTTPFilterExtensions() []*v1alpha1.HTTPFilterExtension {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTTPFilterExtensions")
+	ret0, _ := ret[0].([]*v1alpha1.HTTPFilterExtension)
+	return ret0
+}
+
+// ListHTTPFilterExtensions indicates an expected call of ListHTTPFilterExtensions.
+func (mr *MockInterfaceMockRecorder) ListHTTPFilterExtensions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTTPFilterExtensions", reflect.TypeOf((*MockInterface)(nil).ListHTTPFilterExtensions))
+}
+
+// ListHTTPFilterExtensionsForProxy mocks base method.
+func (m *MockInterface) ListHTTPFilterExtensionsForProxy(arg0 *envoy.Proxy) ([]*v1alpha1.HTTPFilterExtension, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTTPFilterExtensionsForProxy", arg0)
+	ret0, _ := ret[0].([]*v1alpha1.HTTPFilterExtension)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHTTPFilterExtensionsForProxy indicates an expected call of ListHTTPFilterExtensionsForProxy.
+func (mr *MockInterfaceMockRecorder) ListHTTPFilterExtensionsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTTPFilterExtensionsForProxy", reflect.TypeOf((*MockInterface)(nil).ListHTTPFilterExtensionsForProxy), arg0)
+}
+
+// ListIPsForProxy mocks base method.
+func (m *MockInterface) ListIPsForProxy(arg0 *envoy.Proxy) ([]net.IP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIPsForProxy", arg0)
+	ret0, _ := ret[0].([]net.IP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIPsForProxy indicates an expected call of ListIPsForProxy.
+func (mr *MockInterfaceMockRecorder) ListIPsForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIPsForProxy", reflect.TypeOf((*MockInterface)(nil).ListIPsForProxy), arg0)
+}
+
 // ListIngressBackendPolicies mocks base method.
 func (m *MockInterface) ListIngressBackendPolicies() []*v1alpha1.IngressBackend {
 	m.ctrl.T.Helper()
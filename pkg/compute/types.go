@@ -1,6 +1,8 @@
 package compute
 
 import (
+	"net"
+
 	"k8s.io/apimachinery/pkg/types"
 
 	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
@@ -39,6 +41,13 @@ type Interface interface {
 
 	IsMetricsEnabled(*envoy.Proxy) (bool, error)
 
+	// IsWorkloadKeyGenerationEnabled returns whether the private key of the given proxy is generated by the workload
+	// it runs on, rather than by osm-controller
+	IsWorkloadKeyGenerationEnabled(*envoy.Proxy) (bool, error)
+
+	// ListIPsForProxy returns the IP addresses of the workload the given proxy runs on
+	ListIPsForProxy(*envoy.Proxy) ([]net.IP, error)
+
 	GetHostnamesForService(svc service.MeshService, localNamespace bool) []string
 
 	// ListServicesForProxy gets the services that map to the given proxy.
@@ -69,4 +78,10 @@ type Interface interface {
 
 	// ListNamespaces returns the namespaces monitored by the mesh
 	ListNamespaces() ([]string, error)
+
+	// ListHTTPFilterExtensionsForProxy returns the HTTPFilterExtension resources selecting the workload of the given proxy
+	ListHTTPFilterExtensionsForProxy(p *envoy.Proxy) ([]*policyv1alpha1.HTTPFilterExtension, error)
+
+	// GetHTTPFilterModule returns the WASM module referenced by an HTTPFilterExtension in the given namespace
+	GetHTTPFilterModule(namespace string, ref policyv1alpha1.ConfigMapKeyRef) ([]byte, error)
 }
@@ -7,6 +7,9 @@ const (
 	// WildcardIPAddr is a string constant.
 	WildcardIPAddr = "0.0.0.0"
 
+	// WildcardIPv6Addr is the IPv6 wildcard address.
+	WildcardIPv6Addr = "::"
+
 	// EnvoyAdminPort is Envoy's admin port
 	EnvoyAdminPort = 15000
 
@@ -37,6 +40,9 @@ const (
 	// LocalhostIPAddress is the local host address.
 	LocalhostIPAddress = "127.0.0.1"
 
+	// LocalhostIPv6Address is the IPv6 local host address.
+	LocalhostIPv6Address = "::1"
+
 	// EnvoyMetricsCluster is the cluster name of the Prometheus metrics cluster
 	EnvoyMetricsCluster = "envoy-metrics-cluster"
 
@@ -85,6 +91,17 @@ const (
 	// ADSServerPort is the port on which the Aggregated Discovery Service (ADS) listens for new gRPC connections from Envoy proxies
 	ADSServerPort = 15128
 
+	// ADSServerCertificateCommonNamePrefix is the prefix of the common name of the certificate of the ADS server,
+	// suffixed with the trust domain
+	ADSServerCertificateCommonNamePrefix = "ads"
+
+	// CertificateRequestPort is the port on which osm-controller signs the certificate signing requests of the pods
+	// generating their private keys
+	CertificateRequestPort = 15129
+
+	// PodTokenAudience is the audience of the service account tokens projected in the pods to authenticate to osm-controller
+	PodTokenAudience = "osm-controller"
+
 	// PrometheusScrapePath is the path for prometheus to scrap envoy metrics from
 	PrometheusScrapePath = "/stats/prometheus"
 
@@ -118,6 +135,14 @@ const (
 	// EnvoyUniqueIDLabelName is the label applied to pods with the unique ID of the Envoy sidecar.
 	EnvoyUniqueIDLabelName = "osm-proxy-uuid"
 
+	// EnvoyBootstrapConfigSecretPrefix is the prefix of the names of the Secrets holding the bootstrap config of the
+	// Envoy sidecars, suffixed with the unique ID of the sidecar
+	EnvoyBootstrapConfigSecretPrefix = "envoy-bootstrap-config-"
+
+	// EnvoyBootstrapConfigVolume is the name of the pod volume mounting the Secret holding the bootstrap config of the
+	// Envoy sidecar
+	EnvoyBootstrapConfigVolume = "envoy-bootstrap-config-volume"
+
 	// ----- Environment Variables
 
 	// EnvVarLogKubernetesEvents is the name of the env var instructing the event handlers whether to log at all (true/false)
@@ -176,6 +201,31 @@ const (
 	HealthcheckPath = "/osm-healthcheck"
 )
 
+// Constants of the workloads generating the private keys of their Envoy sidecars
+const (
+	// CertAgentContainerName is the name of the osm-cert-agent container, generating the private key of the Envoy
+	// sidecar and requesting its certificate from osm-controller
+	CertAgentContainerName = "osm-cert-agent"
+
+	// CertAgentInitContainerName is the name of the init container requesting the first certificate of the Envoy sidecar
+	CertAgentInitContainerName = "osm-cert-agent-init"
+
+	// CertAgentCertDir is the directory the certificate and private key of the Envoy sidecar are written to
+	CertAgentCertDir = "/etc/osm/certs"
+
+	// CertAgentCertFile is the name of the file holding the certificate chain of the Envoy sidecar in CertAgentCertDir
+	CertAgentCertFile = "cert.pem"
+
+	// CertAgentKeyFile is the name of the file holding the private key of the Envoy sidecar in CertAgentCertDir
+	CertAgentKeyFile = "key.pem"
+
+	// PodTokenDir is the directory the service account token authenticating the pod to osm-controller is projected in
+	PodTokenDir = "/var/run/secrets/osm"
+
+	// PodTokenFile is the name of the file holding the service account token in PodTokenDir
+	PodTokenFile = "token"
+)
+
 // Annotations used by the control plane
 const (
 	// SidecarInjectionAnnotation is the annotation used for sidecar injection
@@ -183,6 +233,16 @@ const (
 
 	// MetricsAnnotation is the annotation used for enabling/disabling metrics
 	MetricsAnnotation = "openservicemesh.io/metrics"
+
+	// SidecarDrainDurationAnnotation is the annotation used to override the drain duration of a pod's sidecar
+	SidecarDrainDurationAnnotation = "openservicemesh.io/sidecar-drain-duration"
+
+	// HoldApplicationUntilProxyStartsAnnotation is the annotation used to hold the start of a pod's application containers until its sidecar is ready
+	HoldApplicationUntilProxyStartsAnnotation = "openservicemesh.io/hold-application-until-proxy-starts"
+
+	// OpaquePortsAnnotation is the annotation used to list the ports of a service whose traffic is proxied without
+	// inspecting it, such as ports serving server-first protocols
+	OpaquePortsAnnotation = "openservicemesh.io/opaque-ports"
 )
 
 // Annotations and labels used by the MeshRootCertificate
@@ -252,6 +312,17 @@ const (
 
 	// AppLabel is the label used to identify the app
 	AppLabel = "app"
+
+	// MultiClusterExportLabel is the label used to export a service to the peer clusters of the mesh
+	MultiClusterExportLabel = "openservicemesh.io/multicluster-export"
+
+	// HTTPFilterModuleLabel is the label used to mark the ConfigMaps holding the WASM modules of HTTPFilterExtension
+	// resources
+	HTTPFilterModuleLabel = "openservicemesh.io/http-filter-module"
+
+	// CNIInstalledNodeLabel is the label of the nodes the osm-cni plugin is installed on, selected by the pods whose
+	// traffic redirection is programmed by the plugin
+	CNIInstalledNodeLabel = "openservicemesh.io/cni-installed"
 )
 
 // Annotations used for Metrics
@@ -292,6 +363,14 @@ const (
 	// Ex. MySQL, SMTP, PostgreSQL etc. where the server initiates the first
 	// byte in a TCP connection.
 	ProtocolTCPServerFirst = "tcp-server-first"
+
+	// ProtocolOpaque implies the traffic is proxied without inspecting it, and is
+	// equivalent to ProtocolTCPServerFirst.
+	ProtocolOpaque = "opaque"
+
+	// ProtocolAuto implies the protocol is detected per connection, such that
+	// HTTP/1.1 and h2c traffic is proxied as HTTP and the rest as TCP.
+	ProtocolAuto = "auto"
 )
 
 // HTTPProtocolVersion defines the HTTP protocol version to use
@@ -332,6 +411,9 @@ const (
 	// OSMControllerSMIVersionPath is the path at which OSM controller servers SMI version info
 	OSMControllerSMIVersionPath = "/smi/version"
 
+	// SPIFFETrustBundlePath is the path at which OSM controller serves the SPIFFE trust bundle of the mesh trust domain
+	SPIFFETrustBundlePath = "/spiffe/bundle"
+
 	// MetricsPath is the path at which OSM controller serves metrics
 	MetricsPath = "/metrics"
 
@@ -353,5 +435,5 @@ const (
 
 var (
 	// SupportedProtocolsInMesh is a list of the protocols OSM supports for in-mesh traffic
-	SupportedProtocolsInMesh = []string{ProtocolTCPServerFirst, ProtocolHTTP, ProtocolTCP, ProtocolGRPC}
+	SupportedProtocolsInMesh = []string{ProtocolTCPServerFirst, ProtocolHTTP, ProtocolTCP, ProtocolGRPC, ProtocolAuto}
 )
@@ -2,6 +2,8 @@ package debugger
 
 import (
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -10,6 +12,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 )
 
+// certNameQueryKey is the query parameter naming a certificate by key, common name or serial number
+const certNameQueryKey = "name"
+
 func (ds DebugConfig) getCertHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		certs := ds.certDebugger.ListIssuedCertificates()
@@ -58,3 +63,89 @@ func (ds DebugConfig) getCertHandler() http.Handler {
 		}
 	})
 }
+
+func (ds DebugConfig) getIssuedCertsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		certs := ds.certDebugger.ListIssuedCertificates()
+
+		infos := make([]certificate.CertificateInfo, 0, len(certs))
+		for _, cert := range certs {
+			infos = append(infos, cert.GetInfo())
+		}
+		sort.Slice(infos, func(i, j int) bool {
+			if infos[i].CommonName != infos[j].CommonName {
+				return infos[i].CommonName < infos[j].CommonName
+			}
+			return infos[i].Key < infos[j].Key
+		})
+
+		writeCertInfo(w, infos)
+	})
+}
+
+func (ds DebugConfig) getInspectCertHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get(certNameQueryKey)
+		if name == "" {
+			http.Error(w, fmt.Sprintf("missing query parameter %q", certNameQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		cert := ds.certDebugger.GetIssuedCertificate(name)
+		if cert == nil {
+			http.Error(w, fmt.Sprintf("%s: %s", certificate.ErrCertificateNotFound, name), http.StatusNotFound)
+			return
+		}
+
+		info := cert.GetInfo()
+		info.CertChain = string(cert.GetCertificateChain())
+		writeCertInfo(w, info)
+	})
+}
+
+func (ds DebugConfig) getRotateCertHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := r.URL.Query().Get(certNameQueryKey)
+		if name == "" {
+			http.Error(w, fmt.Sprintf("missing query parameter %q", certNameQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		cert, err := ds.certDebugger.RotateCertificate(name)
+		if errors.Is(err, certificate.ErrCertificateNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error rotating certificate %s: %s", name, err), http.StatusInternalServerError)
+			return
+		}
+
+		writeCertInfo(w, cert.GetInfo())
+	})
+}
+
+func (ds DebugConfig) getRootsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeCertInfo(w, ds.certDebugger.ListRoots())
+	})
+}
+
+// writeCertInfo writes the given certificate descriptions as JSON
+func writeCertInfo(w http.ResponseWriter, info interface{}) {
+	jsonInfo, err := json.Marshal(info)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling certificate info")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprint(w, string(jsonInfo))
+}
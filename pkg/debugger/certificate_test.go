@@ -1,6 +1,8 @@
 package debugger
 
 import (
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -37,3 +39,110 @@ func TestGetCertHandler(t *testing.T) {
 	assert.Contains(actualResponseBody, "x509.PublicKeyAlgorithm")
 	assert.Contains(actualResponseBody, "x509.SerialNumber")
 }
+
+func TestCertInfoHandlers(t *testing.T) {
+	assert := tassert.New(t)
+
+	ds := DebugConfig{
+		certDebugger: tresorFake.NewFake(time.Hour),
+	}
+
+	cert, err := ds.certDebugger.IssueCertificate(certificate.ForServiceIdentity("sa.ns"))
+	assert.Nil(err)
+
+	// list
+	responseRecorder := httptest.NewRecorder()
+	ds.getIssuedCertsHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/certs/issued", nil))
+	assert.Equal(http.StatusOK, responseRecorder.Code)
+
+	var infos []certificate.CertificateInfo
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &infos))
+	assert.Len(infos, 1)
+	assert.Equal("sa.ns", infos[0].Key)
+	assert.Equal(cert.GetCommonName(), infos[0].CommonName)
+	assert.Equal(cert.GetSerialNumber(), infos[0].SerialNumber)
+	assert.Equal("service", infos[0].CertType)
+	assert.Empty(infos[0].CertChain)
+
+	// inspect
+	testCases := []struct {
+		name         string
+		query        string
+		expectedCode int
+	}{
+		{
+			name:         "by key",
+			query:        "?name=sa.ns",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "by serial number",
+			query:        "?name=" + cert.GetSerialNumber().String(),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unknown certificate",
+			query:        "?name=other.ns",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "missing name",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			responseRecorder := httptest.NewRecorder()
+			ds.getInspectCertHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/certs/inspect"+tc.query, nil))
+			assert.Equal(tc.expectedCode, responseRecorder.Code)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			var info certificate.CertificateInfo
+			assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &info))
+			assert.Equal(cert.GetSerialNumber(), info.SerialNumber)
+			assert.Equal(string(cert.GetCertificateChain()), info.CertChain)
+		})
+	}
+
+	// rotate
+	responseRecorder = httptest.NewRecorder()
+	ds.getRotateCertHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/certs/rotate?name=sa.ns", nil))
+	assert.Equal(http.StatusMethodNotAllowed, responseRecorder.Code)
+
+	responseRecorder = httptest.NewRecorder()
+	ds.getRotateCertHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodPost, "/debug/certs/rotate?name=other.ns", nil))
+	assert.Equal(http.StatusNotFound, responseRecorder.Code)
+
+	responseRecorder = httptest.NewRecorder()
+	ds.getRotateCertHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodPost, "/debug/certs/rotate?name=sa.ns", nil))
+	assert.Equal(http.StatusOK, responseRecorder.Code)
+
+	var info certificate.CertificateInfo
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &info))
+	assert.NotEqual(cert.GetSerialNumber(), info.SerialNumber)
+	assert.Equal(info.SerialNumber, ds.certDebugger.GetIssuedCertificate("sa.ns").GetSerialNumber())
+}
+
+func TestGetRootsHandler(t *testing.T) {
+	assert := tassert.New(t)
+
+	ds := DebugConfig{
+		certDebugger: tresorFake.NewFake(time.Hour),
+	}
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getRootsHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, "/debug/certs/roots", nil))
+	assert.Equal(http.StatusOK, responseRecorder.Code)
+
+	var roots []certificate.RootInfo
+	assert.Nil(json.Unmarshal(responseRecorder.Body.Bytes(), &roots))
+	assert.Len(roots, 1)
+	assert.True(roots[0].Signing)
+	assert.True(roots[0].Validating)
+	assert.Contains(roots[0].RootCertificate, "BEGIN CERTIFICATE")
+}
@@ -35,6 +35,35 @@ func (m *MockXDSDebugger) EXPECT() *MockXDSDebuggerMockRecorder {
 	return m.recorder
 }
 
+// GetSnapshotHistory mocks base method.
+func (m *MockXDSDebugger) GetSnapshotHistory(arg0 string) []envoy.SnapshotRecord {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshotHistory", arg0)
+	ret0, _ := ret[0].([]envoy.SnapshotRecord)
+	return ret0
+}
+
+// GetSnapshotHistory indicates an expected call of GetSnapshotHistory.
+func (mr *MockXDSDebuggerMockRecorder) GetSnapshotHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshotHistory", reflect.TypeOf((*MockXDSDebugger)(nil).GetSnapshotHistory), arg0)
+}
+
+// GetXDSDiff mocks base method.
+func (m *MockXDSDebugger) GetXDSDiff(arg0 string, arg1, arg2 uint64) (*envoy.XDSDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSDiff", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*envoy.XDSDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetXDSDiff indicates an expected call of GetXDSDiff.
+func (mr *MockXDSDebuggerMockRecorder) GetXDSDiff(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSDiff", reflect.TypeOf((*MockXDSDebugger)(nil).GetXDSDiff), arg0, arg1, arg2)
+}
+
 // GetXDSLog mocks base method.
 func (m *MockXDSDebugger) GetXDSLog() map[string]map[envoy.TypeURI][]time.Time {
 	m.ctrl.T.Helper()
@@ -48,3 +77,17 @@ func (mr *MockXDSDebuggerMockRecorder) GetXDSLog() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSLog", reflect.TypeOf((*MockXDSDebugger)(nil).GetXDSLog))
 }
+
+// GetXDSSyncStatus mocks base method.
+func (m *MockXDSDebugger) GetXDSSyncStatus() map[string]envoy.ProxySyncStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSSyncStatus")
+	ret0, _ := ret[0].(map[string]envoy.ProxySyncStatus)
+	return ret0
+}
+
+// GetXDSSyncStatus indicates an expected call of GetXDSSyncStatus.
+func (mr *MockXDSDebuggerMockRecorder) GetXDSSyncStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSSyncStatus", reflect.TypeOf((*MockXDSDebugger)(nil).GetXDSSyncStatus))
+}
@@ -18,7 +18,14 @@ import (
 func (ds DebugConfig) GetHandlers() map[string]http.Handler {
 	handlers := map[string]http.Handler{
 		"/debug/certs":         ds.getCertHandler(),
+		"/debug/certs/issued":  ds.getIssuedCertsHandler(),
+		"/debug/certs/inspect": ds.getInspectCertHandler(),
+		"/debug/certs/rotate":  ds.getRotateCertHandler(),
+		"/debug/certs/roots":   ds.getRootsHandler(),
 		"/debug/xds":           ds.getXDSHandler(),
+		"/debug/xds/status":    ds.getXDSSyncStatusHandler(),
+		"/debug/xds/history":   ds.getSnapshotHistoryHandler(),
+		"/debug/xds/diff":      ds.getXDSDiffHandler(),
 		"/debug/proxy":         ds.getProxies(),
 		"/debug/policies":      ds.getSMIPoliciesHandler(),
 		"/debug/config":        ds.getOSMConfigHandler(),
@@ -39,7 +39,14 @@ func TestGetHandlers(t *testing.T) {
 
 	debugEndpoints := []string{
 		"/debug/certs",
+		"/debug/certs/issued",
+		"/debug/certs/inspect",
+		"/debug/certs/rotate",
+		"/debug/certs/roots",
 		"/debug/xds",
+		"/debug/xds/status",
+		"/debug/xds/history",
+		"/debug/xds/diff",
 		"/debug/proxy",
 		"/debug/policies",
 		"/debug/config",
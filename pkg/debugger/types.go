@@ -35,4 +35,16 @@ type XDSDebugger interface {
 	// GetXDSLog returns a log of the XDS responses sent to Envoy proxies. It is keyed by proxy.GetName(), which is
 	// of the form <identity>:<uuid>.
 	GetXDSLog() map[string]map[envoy.TypeURI][]time.Time
+
+	// GetXDSSyncStatus returns the ACK/NACK status of the configuration sent to connected Envoy proxies. It is
+	// keyed by proxy UUID.
+	GetXDSSyncStatus() map[string]envoy.ProxySyncStatus
+
+	// GetSnapshotHistory returns the snapshots recently sent to the proxy with the given UUID, oldest first.
+	GetSnapshotHistory(proxyUUID string) []envoy.SnapshotRecord
+
+	// GetXDSDiff returns the difference in the xDS configuration of the proxy with the given UUID between two
+	// snapshot versions. A 'to' version of 0 refers to the latest snapshot, and a 'from' version of 0 refers to
+	// the snapshot preceding the 'to' snapshot.
+	GetXDSDiff(proxyUUID string, from, to uint64) (*envoy.XDSDiff, error)
 }
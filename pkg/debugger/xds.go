@@ -1,9 +1,11 @@
 package debugger
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -48,3 +50,97 @@ func (ds DebugConfig) getXDSHandler() http.Handler {
 		}
 	})
 }
+
+func (ds DebugConfig) getXDSSyncStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		syncStatus := ds.xdsDebugger.GetXDSSyncStatus()
+
+		proxies := make([]envoy.ProxySyncStatus, 0, len(syncStatus))
+		for _, proxyStatus := range syncStatus {
+			proxies = append(proxies, proxyStatus)
+		}
+		sort.Slice(proxies, func(i, j int) bool {
+			if proxies[i].Identity != proxies[j].Identity {
+				return proxies[i].Identity < proxies[j].Identity
+			}
+			return proxies[i].UUID < proxies[j].UUID
+		})
+
+		jsonSyncStatus, err := json.Marshal(proxies)
+		if err != nil {
+			log.Error().Err(err).Msg("Error marshalling xDS sync status")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonSyncStatus))
+	})
+}
+
+const (
+	proxyUUIDQueryKey   = "proxy"
+	fromVersionQueryKey = "from"
+	toVersionQueryKey   = "to"
+)
+
+func (ds DebugConfig) getSnapshotHistoryHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyUUID := r.URL.Query().Get(proxyUUIDQueryKey)
+		if proxyUUID == "" {
+			http.Error(w, fmt.Sprintf("missing query parameter %q", proxyUUIDQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		jsonHistory, err := json.Marshal(ds.xdsDebugger.GetSnapshotHistory(proxyUUID))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling snapshot history for proxy %s", proxyUUID)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonHistory))
+	})
+}
+
+func (ds DebugConfig) getXDSDiffHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		proxyUUID := query.Get(proxyUUIDQueryKey)
+		if proxyUUID == "" {
+			http.Error(w, fmt.Sprintf("missing query parameter %q", proxyUUIDQueryKey), http.StatusBadRequest)
+			return
+		}
+
+		var versions [2]uint64
+		for i, key := range []string{fromVersionQueryKey, toVersionQueryKey} {
+			value := query.Get(key)
+			if value == "" {
+				continue
+			}
+			version, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("couldn't parse %s version %s", key, value), http.StatusBadRequest)
+				return
+			}
+			versions[i] = version
+		}
+
+		diff, err := ds.xdsDebugger.GetXDSDiff(proxyUUID, versions[0], versions[1])
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error computing xDS diff for proxy %s: %s", proxyUUID, err), http.StatusNotFound)
+			return
+		}
+
+		jsonDiff, err := json.Marshal(diff)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling xDS diff for proxy %s", proxyUUID)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, string(jsonDiff))
+	})
+}
@@ -0,0 +1,95 @@
+package debugger
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestGetXDSSyncStatusHandler(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockXdsDebugger := NewMockXDSDebugger(mockCtrl)
+	ds := DebugConfig{xdsDebugger: mockXdsDebugger}
+
+	mockXdsDebugger.EXPECT().GetXDSSyncStatus().Return(map[string]envoy.ProxySyncStatus{
+		"uuid-2": {UUID: "uuid-2", Identity: "b.ns"},
+		"uuid-1": {UUID: "uuid-1", Identity: "a.ns"},
+	})
+
+	responseRecorder := httptest.NewRecorder()
+	ds.getXDSSyncStatusHandler().ServeHTTP(responseRecorder, httptest.NewRequest("GET", "/debug/xds/status", nil))
+
+	assert.Equal(http.StatusOK, responseRecorder.Code)
+	assert.Equal(`[{"uuid":"uuid-1","identity":"a.ns","kind":"","connectedAt":"0001-01-01T00:00:00Z","types":null},`+
+		`{"uuid":"uuid-2","identity":"b.ns","kind":"","connectedAt":"0001-01-01T00:00:00Z","types":null}]`, responseRecorder.Body.String())
+}
+
+func TestGetXDSDiffHandler(t *testing.T) {
+	testCases := []struct {
+		name         string
+		url          string
+		mockCalls    func(*MockXDSDebugger)
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "missing proxy",
+			url:          "/debug/xds/diff",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid version",
+			url:          "/debug/xds/diff?proxy=uuid&from=abc",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "diff not found",
+			url:  "/debug/xds/diff?proxy=uuid&to=3",
+			mockCalls: func(m *MockXDSDebugger) {
+				m.EXPECT().GetXDSDiff("uuid", uint64(0), uint64(3)).Return(nil, errors.New("not found"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "diff found",
+			url:  "/debug/xds/diff?proxy=uuid&from=1&to=3",
+			mockCalls: func(m *MockXDSDebugger) {
+				m.EXPECT().GetXDSDiff("uuid", uint64(1), uint64(3)).Return(&envoy.XDSDiff{
+					From:  envoy.SnapshotRecord{Version: 1},
+					To:    envoy.SnapshotRecord{Version: 3},
+					Types: map[string]envoy.XDSTypeDiff{"CDS": {Added: []string{"c"}}},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `{"from":{"version":1,"createdAt":"0001-01-01T00:00:00Z","triggers":null},` +
+				`"to":{"version":3,"createdAt":"0001-01-01T00:00:00Z","triggers":null},"types":{"CDS":{"added":["c"]}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			mockXdsDebugger := NewMockXDSDebugger(mockCtrl)
+			if tc.mockCalls != nil {
+				tc.mockCalls(mockXdsDebugger)
+			}
+			ds := DebugConfig{xdsDebugger: mockXdsDebugger}
+
+			responseRecorder := httptest.NewRecorder()
+			ds.getXDSDiffHandler().ServeHTTP(responseRecorder, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(tc.expectedCode, responseRecorder.Code)
+			if tc.expectedBody != "" {
+				assert.Equal(tc.expectedBody, responseRecorder.Body.String())
+			}
+		})
+	}
+}
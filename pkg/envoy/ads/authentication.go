@@ -0,0 +1,85 @@
+package ads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+// authorizationHeader is the gRPC metadata key of the bearer token authenticating a proxy
+const authorizationHeader = "authorization"
+
+// authenticateProxy returns the proxy connected on the given stream. The proxy is authenticated with the service
+// account token projected in its pod if it sent one, otherwise with its client certificate.
+func (s *Server) authenticateProxy(ctx context.Context, streamID int64) (*envoy.Proxy, error) {
+	if token := getBearerToken(ctx); token != "" {
+		return s.authenticateProxyToken(ctx, token, streamID)
+	}
+
+	// ValidateClient ensures that the proxy has a valid certificate, whose common name identifies the proxy.
+	certCommonName, certSerialNumber, err := utils.ValidateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Trace().Msgf("Envoy with certificate SerialNumber=%s connected", certSerialNumber)
+
+	kind, uuid, si, err := getCertificateCommonNameMeta(certCommonName)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing certificate common name %s: %w", certCommonName, err)
+	}
+	return envoy.NewProxy(kind, uuid, si, utils.GetIPFromContext(ctx), streamID), nil
+}
+
+// authenticateProxyToken returns the sidecar of the pod the given service account token is bound to. The pod must
+// still exist with the UID the token was issued for, and the proxy is identified by the UUID of the pod's label.
+func (s *Server) authenticateProxyToken(ctx context.Context, token string, streamID int64) (*envoy.Proxy, error) {
+	if !s.tokenAuthenticationEnabled() {
+		return nil, errTokenAuthenticationDisabled
+	}
+	id, err := s.podAuthenticator.Authenticate(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("error authenticating service account token: %w", err)
+	}
+
+	pod := s.kubecontroller.GetPod(id.PodName, id.ServiceAccount.Namespace)
+	if err := id.VerifyPod(pod); err != nil {
+		return nil, err
+	}
+	proxyUUID, err := uuid.Parse(pod.Labels[constants.EnvoyUniqueIDLabelName])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing label %s of pod %s/%s: %w", constants.EnvoyUniqueIDLabelName, pod.Namespace, pod.Name, err)
+	}
+	log.Trace().Msgf("Envoy of pod %s/%s connected with its service account token", pod.Namespace, pod.Name)
+
+	return envoy.NewProxy(envoy.KindSidecar, proxyUUID, id.ServiceAccount.ToServiceIdentity(), utils.GetIPFromContext(ctx), streamID), nil
+}
+
+// tokenAuthenticationEnabled returns whether the proxies may authenticate with the service account tokens of their pods,
+// which the mesh allows in the ServiceAccountToken xDS authentication mode only.
+func (s *Server) tokenAuthenticationEnabled() bool {
+	return s.podAuthenticator != nil &&
+		s.catalog.GetMeshConfig().Spec.Sidecar.XDSAuthentication == v1alpha2.XDSAuthenticationModeServiceAccountToken
+}
+
+// getBearerToken returns the bearer token of the gRPC metadata of the given stream context, or an empty string if
+// there's none
+func getBearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get(authorizationHeader) {
+		if token := strings.TrimPrefix(value, "Bearer "); token != value {
+			return token
+		}
+	}
+	return ""
+}
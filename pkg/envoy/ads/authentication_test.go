@@ -0,0 +1,184 @@
+package ads
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/podidentity"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+// fakeAuthenticator authenticates the pods of the tokens it was given
+type fakeAuthenticator map[string]*podidentity.Identity
+
+func (a fakeAuthenticator) Authenticate(_ context.Context, token string) (*podidentity.Identity, error) {
+	id, ok := a[token]
+	if !ok {
+		return nil, podidentity.ErrUnauthenticated
+	}
+	return id, nil
+}
+
+func TestAuthenticateProxy(t *testing.T) {
+	proxyUUID := uuid.New()
+	sa := identity.K8sServiceAccount{Namespace: "ns", Name: "sa"}
+	newPod := func(name, label string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				UID:       "uid",
+				Labels:    map[string]string{constants.EnvoyUniqueIDLabelName: label},
+			},
+			Spec: corev1.PodSpec{ServiceAccountName: "sa"},
+		}
+	}
+
+	certManager := tresorFake.NewFake(time.Hour)
+	cnPrefix := envoy.NewXDSCertCNPrefix(proxyUUID, envoy.KindSidecar, sa.ToServiceIdentity())
+	certPEM, err := certManager.IssueCertificate(certificate.ForCommonNamePrefix(cnPrefix))
+	trequire.NoError(t, err)
+	cert, err := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
+	trequire.NoError(t, err)
+
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1")}
+	withToken := func(token string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: credentials.TLSInfo{}})
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationHeader, "Bearer "+token))
+	}
+
+	testCases := []struct {
+		name              string
+		ctx               context.Context
+		noAuthenticator   bool
+		xdsAuth           v1alpha2.XDSAuthenticationMode
+		expectedProxyUUID uuid.UUID
+		expectedErr       bool
+	}{
+		{
+			name:              "proxy with a client certificate",
+			ctx:               peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: tests.NewMockAuthInfo(cert)}),
+			expectedProxyUUID: proxyUUID,
+		},
+		{
+			name:              "proxy with the token of its pod",
+			ctx:               withToken("pod"),
+			expectedProxyUUID: proxyUUID,
+		},
+		{
+			name:        "proxy without credentials",
+			ctx:         peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: credentials.TLSInfo{}}),
+			expectedErr: true,
+		},
+		{
+			name:        "invalid token",
+			ctx:         withToken("invalid"),
+			expectedErr: true,
+		},
+		{
+			name:            "token authentication disabled",
+			ctx:             withToken("pod"),
+			noAuthenticator: true,
+			expectedErr:     true,
+		},
+		{
+			name:        "token in the Certificate xDS authentication mode",
+			ctx:         withToken("pod"),
+			xdsAuth:     v1alpha2.XDSAuthenticationModeCertificate,
+			expectedErr: true,
+		},
+		{
+			name:              "proxy with a client certificate in the Certificate xDS authentication mode",
+			ctx:               peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: tests.NewMockAuthInfo(cert)}),
+			xdsAuth:           v1alpha2.XDSAuthenticationModeCertificate,
+			expectedProxyUUID: proxyUUID,
+		},
+		{
+			name:        "token of a deleted pod",
+			ctx:         withToken("deleted"),
+			expectedErr: true,
+		},
+		{
+			name:        "token of a pod recreated with the same name",
+			ctx:         withToken("recreated"),
+			expectedErr: true,
+		},
+		{
+			name:        "token of a pod without Envoy sidecar",
+			ctx:         withToken("no-sidecar"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockCtrl := gomock.NewController(t)
+			kubeController := k8s.NewMockController(mockCtrl)
+			kubeController.EXPECT().GetPod("pod", "ns").Return(newPod("pod", proxyUUID.String())).AnyTimes()
+			kubeController.EXPECT().GetPod("deleted", "ns").Return(nil).AnyTimes()
+			recreated := newPod("recreated", proxyUUID.String())
+			recreated.UID = "other"
+			kubeController.EXPECT().GetPod("recreated", "ns").Return(recreated).AnyTimes()
+			kubeController.EXPECT().GetPod("no-sidecar", "ns").Return(newPod("no-sidecar", "")).AnyTimes()
+
+			authenticator := fakeAuthenticator{}
+			for _, pod := range []string{"pod", "deleted", "recreated", "no-sidecar"} {
+				authenticator[pod] = &podidentity.Identity{ServiceAccount: sa, PodName: pod, PodUID: "uid"}
+			}
+			xdsAuth := tc.xdsAuth
+			if xdsAuth == "" {
+				xdsAuth = v1alpha2.XDSAuthenticationModeServiceAccountToken
+			}
+			meshCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+			meshCatalog.EXPECT().GetMeshConfig().Return(v1alpha2.MeshConfig{
+				Spec: v1alpha2.MeshConfigSpec{Sidecar: v1alpha2.SidecarSpec{XDSAuthentication: xdsAuth}},
+			}).AnyTimes()
+
+			s := &Server{catalog: meshCatalog, kubecontroller: kubeController, podAuthenticator: authenticator}
+			if tc.noAuthenticator {
+				s.podAuthenticator = nil
+			}
+
+			proxy, err := s.authenticateProxy(tc.ctx, 1)
+			if tc.expectedErr {
+				assert.Error(err)
+				return
+			}
+			trequire.NoError(t, err)
+			assert.Equal(tc.expectedProxyUUID, proxy.UUID)
+			assert.Equal(envoy.KindSidecar, proxy.Kind())
+			assert.Equal(sa.ToServiceIdentity(), proxy.Identity)
+			assert.Equal(int64(1), proxy.GetConnectionID())
+			assert.Equal(addr, proxy.GetIP())
+		})
+	}
+}
+
+func TestGetBearerToken(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Empty(getBearerToken(context.Background()))
+	assert.Empty(getBearerToken(metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Basic abc"))))
+	assert.Equal("token", getBearerToken(metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, "Bearer token"))))
+}
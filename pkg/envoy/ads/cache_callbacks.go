@@ -9,17 +9,16 @@ import (
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
 	"github.com/openservicemesh/osm/pkg/messaging"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
-	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // OnStreamOpen is called on stream open
 func (s *Server) OnStreamOpen(ctx context.Context, streamID int64, typ string) error {
 	log.Debug().Msgf("OnStreamOpen id: %d typ: %s", streamID, typ)
-	// When a new Envoy proxy connects, ValidateClient would ensure that it has a valid certificate,
-	// and the Subject CN is in the allowedCommonNames set.
-	certCommonName, certSerialNumber, err := utils.ValidateClient(ctx)
+	// When a new Envoy proxy connects, it is authenticated with its client certificate or its service account token
+	proxy, err := s.authenticateProxy(ctx, streamID)
 	if err != nil {
 		return fmt.Errorf("Could not start Aggregated Discovery Service gRPC stream for newly connected Envoy proxy: %w", err)
 	}
@@ -30,16 +29,8 @@ func (s *Server) OnStreamOpen(ctx context.Context, streamID int64, typ string) e
 		return errTooManyConnections
 	}
 
-	log.Trace().Msgf("Envoy with certificate SerialNumber=%s connected", certSerialNumber)
 	metricsstore.DefaultMetricsStore.ProxyConnectCount.Inc()
 
-	kind, uuid, si, err := getCertificateCommonNameMeta(certCommonName)
-	if err != nil {
-		return fmt.Errorf("error parsing certificate common name %s: %w", certCommonName, err)
-	}
-
-	proxy := envoy.NewProxy(kind, uuid, si, utils.GetIPFromContext(ctx), streamID)
-
 	if err := s.catalog.VerifyProxy(proxy); err != nil {
 		return err
 	}
@@ -55,15 +46,15 @@ func (s *Server) OnStreamOpen(ctx context.Context, streamID int64, typ string) e
 		defer unsubRotations()
 
 		// schedule one update for this proxy initially.
-		s.scheduleUpdate(proxy)
+		s.scheduleUpdate(proxy, proxyConnectedTrigger)
 		for {
 			select {
-			case <-proxyUpdateChan:
+			case msg := <-proxyUpdateChan:
 				log.Debug().Str("proxy", proxy.String()).Msg("Broadcast update received")
-				s.scheduleUpdate(proxy)
+				s.scheduleUpdate(proxy, getProxyUpdateTriggers(msg)...)
 			case <-certRotations:
 				log.Debug().Str("proxy", proxy.String()).Msg("Certificate has been updated for proxy")
-				s.scheduleUpdate(proxy)
+				s.scheduleUpdate(proxy, certRotatedTrigger)
 			case <-ctx.Done():
 				return
 			}
@@ -72,7 +63,19 @@ func (s *Server) OnStreamOpen(ctx context.Context, streamID int64, typ string) e
 	return nil
 }
 
-func (s *Server) scheduleUpdate(proxy *envoy.Proxy) {
+// getProxyUpdateTriggers returns the event kinds that triggered the given proxy update message
+func getProxyUpdateTriggers(msg interface{}) []string {
+	switch m := msg.(type) {
+	case messaging.ProxyUpdateEvent:
+		return m.Topics
+	case events.PubSubMessage:
+		return []string{m.Topic()}
+	default:
+		return nil
+	}
+}
+
+func (s *Server) scheduleUpdate(proxy *envoy.Proxy, triggers ...string) {
 	var wg sync.WaitGroup
 	wg.Add(1)
 	s.workqueues.AddJob(
@@ -80,7 +83,7 @@ func (s *Server) scheduleUpdate(proxy *envoy.Proxy) {
 			t := time.Now()
 			log.Debug().Msgf("Starting update for proxy %s", proxy.String())
 
-			if err := s.update(proxy); err != nil {
+			if err := s.update(proxy, triggers...); err != nil {
 				log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error generating resources for proxy")
 			}
 			log.Debug().Msgf("Update for proxy %s took took %v", proxy.String(), time.Since(t))
@@ -89,7 +92,7 @@ func (s *Server) scheduleUpdate(proxy *envoy.Proxy) {
 	wg.Wait()
 }
 
-func (s *Server) update(proxy *envoy.Proxy) error {
+func (s *Server) update(proxy *envoy.Proxy, triggers ...string) error {
 	resources, err := s.GenerateResources(proxy)
 	if err != nil {
 		return err
@@ -97,6 +100,12 @@ func (s *Server) update(proxy *envoy.Proxy) error {
 	if err := s.ServeResources(proxy, resources); err != nil {
 		return err
 	}
+	if s.catalog.GetMeshConfig().Spec.Observability.EnableDebugServer {
+		s.configVerMutex.Lock()
+		configVersion := s.configVersion[proxy.UUID.String()]
+		s.configVerMutex.Unlock()
+		s.recordSnapshot(proxy.UUID.String(), configVersion, resources, triggers)
+	}
 	log.Debug().Msgf("successfully updated resources for proxy %s", proxy.String())
 	return nil
 }
@@ -104,8 +113,18 @@ func (s *Server) update(proxy *envoy.Proxy) error {
 // OnStreamClosed is called on stream closed
 func (s *Server) OnStreamClosed(streamID int64) {
 	log.Debug().Msgf("OnStreamClosed id: %d", streamID)
+	proxy := s.proxyRegistry.GetConnectedProxy(streamID)
 	s.proxyRegistry.UnregisterProxy(streamID)
 
+	if proxy != nil {
+		s.removeSyncStatus(proxy)
+
+		// Keep the snapshot history if the proxy has already reconnected on another stream
+		if _, connected := s.proxyRegistry.ListConnectedProxies()[proxy.UUID.String()]; !connected {
+			s.removeSnapshotHistory(proxy.UUID.String())
+		}
+	}
+
 	metricsstore.DefaultMetricsStore.ProxyConnectCount.Dec()
 }
 
@@ -115,7 +134,12 @@ func (s *Server) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest
 
 	proxy := s.proxyRegistry.GetConnectedProxy(streamID)
 	if proxy != nil {
+		// The snapshots are served to the node ID of the requests, which must be the authenticated proxy
+		if nodeID := req.GetNode().GetId(); nodeID != proxy.UUID.String() {
+			return fmt.Errorf("%w: node %s on the stream of proxy %s", errNodeIDMismatch, nodeID, proxy.String())
+		}
 		metricsstore.DefaultMetricsStore.ProxyXDSRequestCount.WithLabelValues(proxy.UUID.String(), proxy.Identity.String(), req.TypeUrl).Inc()
+		s.recordXDSRequest(proxy, req)
 	}
 
 	return nil
@@ -124,6 +148,10 @@ func (s *Server) OnStreamRequest(streamID int64, req *discovery.DiscoveryRequest
 // OnStreamResponse is called when a response is being sent to a request
 func (s *Server) OnStreamResponse(_ context.Context, streamID int64, req *discovery.DiscoveryRequest, resp *discovery.DiscoveryResponse) {
 	log.Debug().Msgf("OnStreamResponse RESP: %d type: %s, v: %s, nonce: %s, NumResources: %d", streamID, resp.TypeUrl, resp.VersionInfo, resp.Nonce, len(resp.Resources))
+
+	if proxy := s.proxyRegistry.GetConnectedProxy(streamID); proxy != nil {
+		s.recordXDSResponse(proxy, resp)
+	}
 }
 
 // --- Fetch request types. Callback interfaces still requires these to be defined
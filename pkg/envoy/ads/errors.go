@@ -6,3 +6,5 @@ var errUnknownTypeURL = fmt.Errorf("unknown TypeUrl")
 var errTooManyConnections = fmt.Errorf("too many connections")
 var errUnsuportedXDSRequest = fmt.Errorf("Unsupported XDS server connection type")
 var errInvalidCertificateCN = fmt.Errorf("invalid cn")
+var errTokenAuthenticationDisabled = fmt.Errorf("service account token authentication is not enabled")
+var errNodeIDMismatch = fmt.Errorf("node ID mismatch")
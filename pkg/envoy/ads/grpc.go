@@ -32,10 +32,15 @@ type GRPCServer struct {
 
 	mu     sync.Mutex
 	config tls.Config
+
+	// tokenAuthenticationEnabled returns whether the clients may authenticate with bearer tokens instead of client
+	// certificates. The client certificates are required when it is nil.
+	tokenAuthenticationEnabled func() bool
 }
 
-// NewGrpc creates a new gRPC server
-func NewGrpc(serverName string, port int, certCommonName string, cm *certificate.Manager) (*GRPCServer, net.Listener, error) {
+// NewGrpc creates a new gRPC server. The client certificates are optional while tokenAuthenticationEnabled returns true,
+// the clients without certificates then authenticating their gRPC streams with bearer tokens.
+func NewGrpc(serverName string, port int, certCommonName string, cm *certificate.Manager, tokenAuthenticationEnabled func() bool) (*GRPCServer, net.Listener, error) {
 	log.Info().Msgf("Setting up %s gRPC server...", serverName)
 	addr := fmt.Sprintf(":%d", port)
 	lis, err := net.Listen("tcp", addr)
@@ -47,9 +52,10 @@ func NewGrpc(serverName string, port int, certCommonName string, cm *certificate
 	log.Debug().Msgf("Parameters for %s gRPC server: MaxConcurrentStreams=%d;  KeepAlive=%+v", serverName, maxStreams, streamKeepAliveDuration)
 
 	s := &GRPCServer{
-		name:           serverName,
-		cm:             cm,
-		certCommonName: certCommonName,
+		name:                       serverName,
+		cm:                         cm,
+		certCommonName:             certCommonName,
+		tokenAuthenticationEnabled: tokenAuthenticationEnabled,
 	}
 
 	grpcOptions := []grpc.ServerOption{
@@ -69,7 +75,10 @@ func NewGrpc(serverName string, port int, certCommonName string, cm *certificate
 			// use lock to prevent concurrent updates and reads to the tls config
 			s.mu.Lock()
 			defer s.mu.Unlock()
-			return &s.config, nil
+			// the client authentication follows the configuration changes between the certificate rotations
+			config := s.config.Clone()
+			config.ClientAuth = s.clientAuth()
+			return config, nil
 		},
 	}
 	mutualTLS := grpc.Creds(credentials.NewTLS(&tlsConfig))
@@ -108,7 +117,7 @@ func (s *GRPCServer) updateConfig() error {
 	s.config = tls.Config{
 		InsecureSkipVerify: false,
 		ServerName:         s.name,
-		ClientAuth:         tls.RequireAndVerifyClientCert,
+		ClientAuth:         s.clientAuth(),
 		Certificates:       []tls.Certificate{certif},
 		ClientCAs:          certPool,
 		MinVersion:         constants.MinTLSVersion,
@@ -118,6 +127,16 @@ func (s *GRPCServer) updateConfig() error {
 	return nil
 }
 
+// clientAuth returns the client authentication policy of the server. The clients without certificates authenticate
+// the gRPC streams with bearer tokens, checked by the streams' callbacks, which reject the streams of unauthenticated
+// clients.
+func (s *GRPCServer) clientAuth() tls.ClientAuthType {
+	if s.tokenAuthenticationEnabled != nil && s.tokenAuthenticationEnabled() {
+		return tls.VerifyClientCertIfGiven
+	}
+	return tls.RequireAndVerifyClientCert
+}
+
 func (s *GRPCServer) watchCertRotations(ctx context.Context) error {
 	// listen for certificate rotation first, so we don't miss any events
 	certRotationChan, unsubscribeRotation := s.cm.SubscribeRotations(s.certCommonName)
@@ -2,6 +2,7 @@ package ads
 
 import (
 	"context"
+	"crypto/tls"
 	"testing"
 	"time"
 
@@ -28,7 +29,7 @@ func TestNewGrpc(t *testing.T) {
 	}
 
 	for _, gt := range newGrpcTests {
-		resServer, resListener, err := NewGrpc(gt.serverType, gt.port, "fake-ads", certManager)
+		resServer, resListener, err := NewGrpc(gt.serverType, gt.port, "fake-ads", certManager, nil)
 		if err != nil {
 			assert.Nil(resServer)
 			assert.Nil(resListener)
@@ -41,6 +42,20 @@ func TestNewGrpc(t *testing.T) {
 	}
 }
 
+func TestClientAuth(t *testing.T) {
+	assert := tassert.New(t)
+
+	s := &GRPCServer{}
+	assert.Equal(tls.RequireAndVerifyClientCert, s.clientAuth())
+
+	tokenAuthenticationEnabled := false
+	s.tokenAuthenticationEnabled = func() bool { return tokenAuthenticationEnabled }
+	assert.Equal(tls.RequireAndVerifyClientCert, s.clientAuth())
+
+	tokenAuthenticationEnabled = true
+	assert.Equal(tls.VerifyClientCertIfGiven, s.clientAuth())
+}
+
 func TestGrpcServe(t *testing.T) {
 	assert := tassert.New(t)
 
@@ -49,7 +64,7 @@ func TestGrpcServe(t *testing.T) {
 	serverType := "ADS"
 	certName := "fake-ads"
 	port := 9999
-	grpcServer, lis, err := NewGrpc(serverType, port, certName, certManager)
+	grpcServer, lis, err := NewGrpc(serverType, port, certName, certManager, nil)
 	assert.Nil(err)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -138,7 +138,7 @@ func setupTestServer(b *testing.B) {
 
 	proxy = envoy.NewProxy(envoy.KindSidecar, proxyUUID, proxySvcAccount.ToServiceIdentity(), nil, 1)
 
-	adsServer = NewADSServer(mc, proxyRegistry, true, tests.Namespace, certManager, kubeController, nil)
+	adsServer = NewADSServer(mc, proxyRegistry, true, tests.Namespace, certManager, kubeController, nil, nil)
 }
 
 func BenchmarkSendXDSResponse(b *testing.B) {
@@ -1,6 +1,7 @@
 package ads
 
 import (
+	"net"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -38,6 +39,7 @@ var _ = Describe("Test ADS response functions", func() {
 
 	provider := compute.NewMockInterface(mockCtrl)
 	provider.EXPECT().IsMetricsEnabled(gomock.Any()).Return(true, nil).AnyTimes()
+	provider.EXPECT().ListIPsForProxy(gomock.Any()).Return([]net.IP{net.ParseIP("10.0.0.5")}, nil).AnyTimes()
 	provider.EXPECT().ListEgressPoliciesForServiceAccount(gomock.Any()).Return(nil).AnyTimes()
 	provider.EXPECT().GetIngressBackendPolicyForService(gomock.Any()).Return(nil).AnyTimes()
 	provider.EXPECT().GetUpstreamTrafficSettingByService(gomock.Any()).Return(nil).AnyTimes()
@@ -59,11 +61,12 @@ var _ = Describe("Test ADS response functions", func() {
 		}).AnyTimes()
 		provider.EXPECT().ListServiceIdentitiesForService(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		provider.EXPECT().ListServicesForProxy(proxy).Return(nil, nil).AnyTimes()
+		provider.EXPECT().ListHTTPFilterExtensionsForProxy(proxy).Return(nil, nil).AnyTimes()
 
 		metricsstore.DefaultMetricsStore.Start(metricsstore.DefaultMetricsStore.ProxyResponseSendSuccessCount)
 
 		It("returns Aggregated Discovery Service response", func() {
-			s := NewADSServer(mc, proxyRegistry, true, tests.Namespace, certManager, kubectrlMock, nil)
+			s := NewADSServer(mc, proxyRegistry, true, tests.Namespace, certManager, kubectrlMock, nil, nil)
 
 			Expect(s).ToNot(BeNil())
 			snapshot, err := s.snapshotCache.GetSnapshot(proxy.UUID.String())
@@ -12,8 +12,10 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/cds"
+	"github.com/openservicemesh/osm/pkg/envoy/ecds"
 	"github.com/openservicemesh/osm/pkg/envoy/eds"
 	"github.com/openservicemesh/osm/pkg/envoy/lds"
 	"github.com/openservicemesh/osm/pkg/envoy/rds"
@@ -22,6 +24,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/podidentity"
 	"github.com/openservicemesh/osm/pkg/workerpool"
 )
 
@@ -31,34 +34,37 @@ const (
 
 	// workerPoolSize is the default number of workerpool workers (0 is GOMAXPROCS)
 	workerPoolSize = 0
-
-	// xdsServerCertificateCommonName is the common name of the certificate for the ADS server
-	xdsServerCertificateCommonName = "ads"
 )
 
-// NewADSServer creates a new Aggregated Discovery Service server
+// NewADSServer creates a new Aggregated Discovery Service server. The proxies connect with client certificates, or
+// with the service account tokens projected in their pods when a pod authenticator is given and the MeshConfig
+// selects the ServiceAccountToken xDS authentication mode.
 func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.ProxyRegistry, enableDebug bool, osmNamespace string,
-	certManager *certificate.Manager, kubecontroller k8s.Controller, msgBroker *messaging.Broker) *Server {
+	certManager *certificate.Manager, kubecontroller k8s.Controller, msgBroker *messaging.Broker, podAuthenticator podidentity.Authenticator) *Server {
 	server := Server{
 		catalog:       meshCatalog,
 		proxyRegistry: proxyRegistry,
 		xdsHandlers: map[envoy.TypeURI]func(catalog.MeshCataloger, *envoy.Proxy, *certificate.Manager, *registry.ProxyRegistry) ([]types.Resource, error){
-			envoy.TypeEDS: eds.NewResponse,
-			envoy.TypeCDS: cds.NewResponse,
-			envoy.TypeRDS: rds.NewResponse,
-			envoy.TypeLDS: lds.NewResponse,
-			envoy.TypeSDS: sds.NewResponse,
+			envoy.TypeEDS:  eds.NewResponse,
+			envoy.TypeCDS:  cds.NewResponse,
+			envoy.TypeRDS:  rds.NewResponse,
+			envoy.TypeLDS:  lds.NewResponse,
+			envoy.TypeSDS:  sds.NewResponse,
+			envoy.TypeECDS: ecds.NewResponse,
 		},
 		osmNamespace: osmNamespace,
 		certManager:  certManager,
 		snapshotCache: cachev3.NewSnapshotCache(false, cachev3.IDHash{}, &scLogger{
 			log: logger.New("envoy/snapshot-cache"),
 		}),
-		xdsLog:         make(map[string]map[envoy.TypeURI][]time.Time),
-		workqueues:     workerpool.NewWorkerPool(workerPoolSize),
-		kubecontroller: kubecontroller,
-		configVersion:  make(map[string]uint64),
-		msgBroker:      msgBroker,
+		xdsLog:           make(map[string]map[envoy.TypeURI][]time.Time),
+		workqueues:       workerpool.NewWorkerPool(workerPoolSize),
+		kubecontroller:   kubecontroller,
+		configVersion:    make(map[string]uint64),
+		syncStatus:       make(map[string]*envoy.ProxySyncStatus),
+		snapshotHistory:  make(map[string][]*snapshotHistoryEntry),
+		msgBroker:        msgBroker,
+		podAuthenticator: podAuthenticator,
 	}
 
 	return &server
@@ -66,7 +72,7 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, proxyRegistry *registry.Pro
 
 // Start starts the ADS server
 func (s *Server) Start(ctx context.Context, cancel context.CancelFunc, port int) error {
-	grpcServer, lis, err := NewGrpc(ServerType, port, xdsServerCertificateCommonName, s.certManager)
+	grpcServer, lis, err := NewGrpc(ServerType, port, constants.ADSServerCertificateCommonNamePrefix, s.certManager, s.tokenAuthenticationEnabled)
 	if err != nil {
 		return fmt.Errorf("error starting ADS server: %w", err)
 	}
@@ -0,0 +1,178 @@
+package ads
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+const (
+	// MaxSnapshotHistoryPerProxy keeps a higher bound of how many snapshots do we keep per proxy
+	MaxSnapshotHistoryPerProxy = 10
+
+	// proxyConnectedTrigger is the trigger recorded for the snapshot generated when a proxy connects
+	proxyConnectedTrigger = "proxy-connected"
+
+	// certRotatedTrigger is the trigger recorded for snapshots generated when a proxy's certificate is rotated
+	certRotatedTrigger = "certificate-rotated"
+)
+
+// diffTypes are the xDS types compared by GetXDSDiff. SDS is deliberately left out as its resources
+// contain private keys.
+var diffTypes = []envoy.TypeURI{envoy.TypeLDS, envoy.TypeRDS, envoy.TypeCDS, envoy.TypeEDS}
+
+var (
+	errSnapshotHistoryNotFound = fmt.Errorf("no snapshot history found for proxy")
+	errSnapshotNotFound        = fmt.Errorf("snapshot not found in history")
+)
+
+// snapshotHistoryEntry is a snapshot of the resources sent to a proxy.
+type snapshotHistoryEntry struct {
+	envoy.SnapshotRecord
+	resources map[string][]types.Resource
+}
+
+// GetSnapshotHistory implements XDSDebugger interface and returns the snapshots recorded for the given proxy,
+// oldest first.
+func (s *Server) GetSnapshotHistory(proxyUUID string) []envoy.SnapshotRecord {
+	s.snapshotHistoryMutex.Lock()
+	defer s.snapshotHistoryMutex.Unlock()
+
+	history := s.snapshotHistory[proxyUUID]
+	records := make([]envoy.SnapshotRecord, 0, len(history))
+	for _, entry := range history {
+		records = append(records, entry.SnapshotRecord)
+	}
+	return records
+}
+
+// GetXDSDiff implements XDSDebugger interface and returns the difference in the listeners, routes, clusters and
+// endpoints of the given proxy between the two snapshot versions. A 'to' version of 0 refers to the latest
+// snapshot, and a 'from' version of 0 refers to the snapshot preceding the 'to' snapshot.
+func (s *Server) GetXDSDiff(proxyUUID string, from, to uint64) (*envoy.XDSDiff, error) {
+	s.snapshotHistoryMutex.Lock()
+	history := s.snapshotHistory[proxyUUID]
+	s.snapshotHistoryMutex.Unlock()
+
+	if len(history) == 0 {
+		return nil, errSnapshotHistoryNotFound
+	}
+
+	toIdx := len(history) - 1
+	if to != 0 {
+		toIdx = findSnapshot(history, to)
+		if toIdx < 0 {
+			return nil, fmt.Errorf("%w: version %d", errSnapshotNotFound, to)
+		}
+	}
+
+	fromIdx := toIdx - 1
+	if from != 0 {
+		fromIdx = findSnapshot(history, from)
+		if fromIdx < 0 {
+			return nil, fmt.Errorf("%w: version %d", errSnapshotNotFound, from)
+		}
+	}
+
+	// When there is no snapshot preceding 'to', everything in it was added
+	fromEntry := &snapshotHistoryEntry{}
+	if fromIdx >= 0 {
+		fromEntry = history[fromIdx]
+	}
+	toEntry := history[toIdx]
+
+	diff := &envoy.XDSDiff{
+		From:  fromEntry.SnapshotRecord,
+		To:    toEntry.SnapshotRecord,
+		Types: make(map[string]envoy.XDSTypeDiff, len(diffTypes)),
+	}
+	for _, typeURI := range diffTypes {
+		diff.Types[typeURI.Short()] = diffResources(fromEntry.resources[typeURI.String()], toEntry.resources[typeURI.String()])
+	}
+	return diff, nil
+}
+
+// findSnapshot returns the index of the snapshot with the given version in the history, or -1 if not found.
+func findSnapshot(history []*snapshotHistoryEntry, version uint64) int {
+	for i, entry := range history {
+		if entry.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// diffResources returns the difference between two sets of resources of the same xDS type, matched by name.
+func diffResources(from, to []types.Resource) envoy.XDSTypeDiff {
+	fromByName := make(map[string]types.Resource, len(from))
+	for _, res := range from {
+		fromByName[cache.GetResourceName(res)] = res
+	}
+
+	var diff envoy.XDSTypeDiff
+	toNames := make(map[string]struct{}, len(to))
+	for _, res := range to {
+		name := cache.GetResourceName(res)
+		toNames[name] = struct{}{}
+
+		fromRes, ok := fromByName[name]
+		if !ok {
+			diff.Added = append(diff.Added, name)
+			continue
+		}
+		if !proto.Equal(fromRes, res) {
+			diff.Modified = append(diff.Modified, envoy.XDSResourceDiff{
+				Name: name,
+				Diff: cmp.Diff(fromRes, res, protocmp.Transform()),
+			})
+		}
+	}
+	for name := range fromByName {
+		if _, ok := toNames[name]; !ok {
+			diff.Removed = append(diff.Removed, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Modified, func(i, j int) bool {
+		return diff.Modified[i].Name < diff.Modified[j].Name
+	})
+	return diff
+}
+
+// recordSnapshot records the resources of the snapshot with the given version in the history of the proxy,
+// evicting the oldest snapshot when the history is full.
+func (s *Server) recordSnapshot(proxyUUID string, version uint64, resources map[string][]types.Resource, triggers []string) {
+	s.snapshotHistoryMutex.Lock()
+	defer s.snapshotHistoryMutex.Unlock()
+
+	history := append(s.snapshotHistory[proxyUUID], &snapshotHistoryEntry{
+		SnapshotRecord: envoy.SnapshotRecord{
+			Version:   version,
+			CreatedAt: time.Now(),
+			Triggers:  triggers,
+		},
+		resources: resources,
+	})
+	if len(history) > MaxSnapshotHistoryPerProxy {
+		history = history[len(history)-MaxSnapshotHistoryPerProxy:]
+	}
+	s.snapshotHistory[proxyUUID] = history
+}
+
+// removeSnapshotHistory removes the snapshot history recorded for the given proxy.
+func (s *Server) removeSnapshotHistory(proxyUUID string) {
+	s.snapshotHistoryMutex.Lock()
+	defer s.snapshotHistoryMutex.Unlock()
+
+	delete(s.snapshotHistory, proxyUUID)
+}
@@ -0,0 +1,128 @@
+package ads
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+func TestRecordSnapshot(t *testing.T) {
+	assert := tassert.New(t)
+	s := &Server{snapshotHistory: make(map[string][]*snapshotHistoryEntry)}
+
+	for version := uint64(1); version <= MaxSnapshotHistoryPerProxy+2; version++ {
+		s.recordSnapshot("proxy", version, nil, []string{proxyConnectedTrigger})
+	}
+
+	history := s.GetSnapshotHistory("proxy")
+	assert.Len(history, MaxSnapshotHistoryPerProxy)
+	assert.Equal(uint64(3), history[0].Version)
+	assert.Equal(uint64(MaxSnapshotHistoryPerProxy+2), history[len(history)-1].Version)
+	assert.Equal([]string{proxyConnectedTrigger}, history[0].Triggers)
+
+	s.removeSnapshotHistory("proxy")
+	assert.Empty(s.GetSnapshotHistory("proxy"))
+}
+
+func TestGetXDSDiff(t *testing.T) {
+	cluster := func(name string, timeout time.Duration) types.Resource {
+		return &xds_cluster.Cluster{Name: name, ConnectTimeout: durationpb.New(timeout)}
+	}
+	listener := func(name string) types.Resource {
+		return &xds_listener.Listener{Name: name}
+	}
+
+	s := &Server{snapshotHistory: make(map[string][]*snapshotHistoryEntry)}
+	s.recordSnapshot("proxy", 1, map[string][]types.Resource{
+		envoy.TypeCDS.String(): {cluster("a", time.Second), cluster("b", time.Second)},
+		envoy.TypeLDS.String(): {listener("inbound")},
+	}, []string{proxyConnectedTrigger})
+	s.recordSnapshot("proxy", 2, map[string][]types.Resource{
+		envoy.TypeCDS.String(): {cluster("a", 2*time.Second), cluster("c", time.Second)},
+		envoy.TypeLDS.String(): {listener("inbound")},
+	}, []string{"service-added"})
+	s.recordSnapshot("proxy", 3, map[string][]types.Resource{
+		envoy.TypeCDS.String(): {cluster("a", 2*time.Second), cluster("c", time.Second)},
+		envoy.TypeLDS.String(): {listener("inbound"), listener("outbound")},
+	}, []string{certRotatedTrigger})
+
+	testCases := []struct {
+		name            string
+		from, to        uint64
+		expectedFrom    uint64
+		expectedTo      uint64
+		expectedCDS     envoy.XDSTypeDiff
+		expectedLDS     envoy.XDSTypeDiff
+		expectedErr     error
+		expectModifiedA bool
+	}{
+		{
+			name:         "defaults to latest version and its predecessor",
+			expectedFrom: 2,
+			expectedTo:   3,
+			expectedLDS:  envoy.XDSTypeDiff{Added: []string{"outbound"}},
+		},
+		{
+			name:            "explicit versions",
+			from:            1,
+			to:              3,
+			expectedFrom:    1,
+			expectedTo:      3,
+			expectedCDS:     envoy.XDSTypeDiff{Added: []string{"c"}, Removed: []string{"b"}},
+			expectedLDS:     envoy.XDSTypeDiff{Added: []string{"outbound"}},
+			expectModifiedA: true,
+		},
+		{
+			name:         "oldest version is diffed against nothing",
+			to:           1,
+			expectedFrom: 0,
+			expectedTo:   1,
+			expectedCDS:  envoy.XDSTypeDiff{Added: []string{"a", "b"}},
+			expectedLDS:  envoy.XDSTypeDiff{Added: []string{"inbound"}},
+		},
+		{
+			name:        "unknown version",
+			from:        10,
+			expectedErr: errSnapshotNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			diff, err := s.GetXDSDiff("proxy", tc.from, tc.to)
+			if tc.expectedErr != nil {
+				assert.True(errors.Is(err, tc.expectedErr))
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedFrom, diff.From.Version)
+			assert.Equal(tc.expectedTo, diff.To.Version)
+			assert.Equal(tc.expectedLDS, diff.Types[envoy.TypeLDS.Short()])
+			assert.True(diff.Types[envoy.TypeRDS.Short()].IsEmpty())
+
+			cdsDiff := diff.Types[envoy.TypeCDS.Short()]
+			assert.Equal(tc.expectedCDS.Added, cdsDiff.Added)
+			assert.Equal(tc.expectedCDS.Removed, cdsDiff.Removed)
+			if tc.expectModifiedA {
+				assert.Len(cdsDiff.Modified, 1)
+				assert.Equal("a", cdsDiff.Modified[0].Name)
+				assert.Contains(cdsDiff.Modified[0].Diff, "connect_timeout")
+			} else {
+				assert.Empty(cdsDiff.Modified)
+			}
+		})
+	}
+
+	_, err := s.GetXDSDiff("unknown", 0, 0)
+	tassert.Equal(t, errSnapshotHistoryNotFound, err)
+}
@@ -0,0 +1,143 @@
+package ads
+
+import (
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// GetXDSSyncStatus implements XDSDebugger interface and returns the xDS sync status of the connected proxies,
+// keyed by proxy UUID.
+func (s *Server) GetXDSSyncStatus() map[string]envoy.ProxySyncStatus {
+	s.syncStatusMutex.Lock()
+	defer s.syncStatusMutex.Unlock()
+
+	// Making a copy to avoid debugger potential reads while writes are happening from XDS routines
+	statusCopy := make(map[string]envoy.ProxySyncStatus, len(s.syncStatus))
+	for proxyUUID, status := range s.syncStatus {
+		types := make(map[envoy.TypeURI]*envoy.XDSSyncStatus, len(status.Types))
+		for typeURI, typeStatus := range status.Types {
+			typeStatusCopy := *typeStatus
+			types[typeURI] = &typeStatusCopy
+		}
+		statusCopy[proxyUUID] = envoy.ProxySyncStatus{
+			UUID:        status.UUID,
+			Identity:    status.Identity,
+			Kind:        status.Kind,
+			ConnectedAt: status.ConnectedAt,
+			Types:       types,
+		}
+	}
+	return statusCopy
+}
+
+// getTypeSyncStatus returns the sync status of the given type for the given proxy, initializing it if necessary.
+// It must be called with syncStatusMutex held.
+func (s *Server) getTypeSyncStatus(proxy *envoy.Proxy, typeURI envoy.TypeURI) *envoy.XDSSyncStatus {
+	proxyStatus, ok := s.syncStatus[proxy.UUID.String()]
+	if ok && !proxyStatus.ConnectedAt.Equal(proxy.GetConnectedAt()) {
+		// The proxy reconnected, so the status tracked for its previous stream no longer applies
+		s.removeSyncStatusLocked(proxy.UUID.String())
+		ok = false
+	}
+	if !ok {
+		proxyStatus = &envoy.ProxySyncStatus{
+			UUID:        proxy.UUID.String(),
+			Identity:    proxy.Identity.String(),
+			Kind:        proxy.Kind(),
+			ConnectedAt: proxy.GetConnectedAt(),
+			Types:       make(map[envoy.TypeURI]*envoy.XDSSyncStatus),
+		}
+		s.syncStatus[proxy.UUID.String()] = proxyStatus
+	}
+
+	typeStatus, ok := proxyStatus.Types[typeURI]
+	if !ok {
+		typeStatus = &envoy.XDSSyncStatus{}
+		proxyStatus.Types[typeURI] = typeStatus
+	}
+	return typeStatus
+}
+
+// recordXDSResponse records a DiscoveryResponse being sent to the given proxy.
+func (s *Server) recordXDSResponse(proxy *envoy.Proxy, resp *discovery.DiscoveryResponse) {
+	s.syncStatusMutex.Lock()
+	defer s.syncStatusMutex.Unlock()
+
+	status := s.getTypeSyncStatus(proxy, envoy.TypeURI(resp.TypeUrl))
+	status.LastSentVersion = resp.VersionInfo
+	status.LastSentNonce = resp.Nonce
+	status.LastSentAt = time.Now()
+}
+
+// recordXDSRequest records the ACK or NACK carried by a DiscoveryRequest from the given proxy. Requests that do not
+// respond to a prior DiscoveryResponse, i.e. that do not carry a nonce, are ignored.
+func (s *Server) recordXDSRequest(proxy *envoy.Proxy, req *discovery.DiscoveryRequest) {
+	if req.ResponseNonce == "" {
+		return
+	}
+	typeURI := envoy.TypeURI(req.TypeUrl)
+
+	s.syncStatusMutex.Lock()
+	defer s.syncStatusMutex.Unlock()
+
+	status := s.getTypeSyncStatus(proxy, typeURI)
+	wasNacked := status.Nacked
+
+	if req.ErrorDetail != nil {
+		metricsstore.DefaultMetricsStore.ProxyXDSNackCount.WithLabelValues(req.TypeUrl).Inc()
+		log.Error().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrXDSConfigRejected)).Str("proxy", proxy.String()).
+			Msgf("Proxy rejected %s response with nonce %s: %s", typeURI.Short(), req.ResponseNonce, req.ErrorDetail.Message)
+
+		status.LastNackedNonce = req.ResponseNonce
+		status.LastNackedAt = time.Now()
+		status.LastNackError = req.ErrorDetail.Message
+		status.Nacked = true
+		if !wasNacked {
+			metricsstore.DefaultMetricsStore.ProxyXDSNackedCount.WithLabelValues(req.TypeUrl).Inc()
+		}
+		return
+	}
+
+	metricsstore.DefaultMetricsStore.ProxyXDSAckCount.WithLabelValues(req.TypeUrl).Inc()
+	status.LastAckedVersion = req.VersionInfo
+	status.LastAckedAt = time.Now()
+
+	// An ACK for an older response does not clear a NACK for a newer one
+	if wasNacked && req.ResponseNonce == status.LastSentNonce {
+		status.Nacked = false
+		metricsstore.DefaultMetricsStore.ProxyXDSNackedCount.WithLabelValues(req.TypeUrl).Dec()
+	}
+}
+
+// removeSyncStatus removes the sync status tracked for the given proxy, unless it was recorded for a newer
+// stream of the same proxy.
+func (s *Server) removeSyncStatus(proxy *envoy.Proxy) {
+	s.syncStatusMutex.Lock()
+	defer s.syncStatusMutex.Unlock()
+
+	proxyStatus, ok := s.syncStatus[proxy.UUID.String()]
+	if !ok || proxyStatus.ConnectedAt.After(proxy.GetConnectedAt()) {
+		return
+	}
+	s.removeSyncStatusLocked(proxy.UUID.String())
+}
+
+// removeSyncStatusLocked removes the sync status tracked for the given proxy UUID. It must be called with
+// syncStatusMutex held.
+func (s *Server) removeSyncStatusLocked(proxyUUID string) {
+	proxyStatus, ok := s.syncStatus[proxyUUID]
+	if !ok {
+		return
+	}
+	for typeURI, status := range proxyStatus.Types {
+		if status.Nacked {
+			metricsstore.DefaultMetricsStore.ProxyXDSNackedCount.WithLabelValues(typeURI.String()).Dec()
+		}
+	}
+	delete(s.syncStatus, proxyUUID)
+}
@@ -0,0 +1,76 @@
+package ads
+
+import (
+	"testing"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/status"
+
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/tests"
+)
+
+func TestXDSSyncStatus(t *testing.T) {
+	assert := tassert.New(t)
+
+	s := &Server{syncStatus: make(map[string]*envoy.ProxySyncStatus)}
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), tests.BookstoreServiceIdentity, nil, 1)
+	typeURL := envoy.TypeLDS.String()
+
+	getStatus := func() envoy.XDSSyncStatus {
+		proxyStatus, ok := s.GetXDSSyncStatus()[proxy.UUID.String()]
+		assert.True(ok)
+		return *proxyStatus.Types[envoy.TypeLDS]
+	}
+
+	// Initial request carries no nonce and is not an ACK
+	s.recordXDSRequest(proxy, &discovery.DiscoveryRequest{TypeUrl: typeURL})
+	assert.Empty(s.GetXDSSyncStatus())
+
+	s.recordXDSResponse(proxy, &discovery.DiscoveryResponse{TypeUrl: typeURL, VersionInfo: "1", Nonce: "a"})
+	assert.Equal(envoy.SyncStateStale, getStatus().State())
+
+	s.recordXDSRequest(proxy, &discovery.DiscoveryRequest{TypeUrl: typeURL, VersionInfo: "1", ResponseNonce: "a"})
+	assert.Equal(envoy.SyncStateSynced, getStatus().State())
+	assert.Equal("1", getStatus().LastAckedVersion)
+
+	s.recordXDSResponse(proxy, &discovery.DiscoveryResponse{TypeUrl: typeURL, VersionInfo: "2", Nonce: "b"})
+	assert.Equal(envoy.SyncStateStale, getStatus().State())
+
+	s.recordXDSRequest(proxy, &discovery.DiscoveryRequest{
+		TypeUrl:       typeURL,
+		VersionInfo:   "1",
+		ResponseNonce: "b",
+		ErrorDetail:   &status.Status{Message: "invalid listener"},
+	})
+	nacked := getStatus()
+	assert.Equal(envoy.SyncStateNacked, nacked.State())
+	assert.Equal("invalid listener", nacked.LastNackError)
+	assert.Equal("b", nacked.LastNackedNonce)
+	assert.Equal("1", nacked.LastAckedVersion)
+
+	// A late ACK for an older response does not clear the NACK
+	s.recordXDSResponse(proxy, &discovery.DiscoveryResponse{TypeUrl: typeURL, VersionInfo: "3", Nonce: "c"})
+	s.recordXDSRequest(proxy, &discovery.DiscoveryRequest{TypeUrl: typeURL, VersionInfo: "1", ResponseNonce: "a"})
+	assert.Equal(envoy.SyncStateNacked, getStatus().State())
+
+	s.recordXDSRequest(proxy, &discovery.DiscoveryRequest{TypeUrl: typeURL, VersionInfo: "3", ResponseNonce: "c"})
+	synced := getStatus()
+	assert.Equal(envoy.SyncStateSynced, synced.State())
+	assert.Equal("invalid listener", synced.LastNackError)
+
+	// Status from a previous stream is discarded when the proxy reconnects
+	reconnected := envoy.NewProxy(envoy.KindSidecar, proxy.UUID, tests.BookstoreServiceIdentity, nil, 2)
+	s.recordXDSResponse(reconnected, &discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String(), VersionInfo: "1", Nonce: "d"})
+	_, ok := s.GetXDSSyncStatus()[proxy.UUID.String()].Types[envoy.TypeLDS]
+	assert.False(ok)
+
+	// Closing the previous stream does not remove the status of the new one
+	s.removeSyncStatus(proxy)
+	assert.Len(s.GetXDSSyncStatus(), 1)
+
+	s.removeSyncStatus(reconnected)
+	assert.Empty(s.GetXDSSyncStatus())
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/podidentity"
 	"github.com/openservicemesh/osm/pkg/workerpool"
 )
 
@@ -45,5 +46,17 @@ type Server struct {
 	configVerMutex sync.Mutex
 	configVersion  map[string]uint64
 
+	// syncStatus tracks the ACK/NACK state of the configuration sent to each connected proxy, keyed by proxy UUID
+	syncStatusMutex sync.Mutex
+	syncStatus      map[string]*envoy.ProxySyncStatus
+
+	// snapshotHistory keeps the most recent snapshots sent to each proxy when the debug server is enabled,
+	// keyed by proxy UUID
+	snapshotHistoryMutex sync.Mutex
+	snapshotHistory      map[string][]*snapshotHistoryEntry
+
 	msgBroker *messaging.Broker
+
+	// podAuthenticator authenticates the proxies connecting with the service account tokens projected in their pods
+	podAuthenticator podidentity.Authenticator
 }
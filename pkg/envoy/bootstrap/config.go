@@ -1,6 +1,7 @@
 package bootstrap
 
 import (
+	"fmt"
 	"path/filepath"
 
 	xds_accesslog_config "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
@@ -46,6 +47,12 @@ const (
 
 	// EnvoyXDSKeyFile is the name of the Envoy XDS private key file
 	EnvoyXDSKeyFile = "sds_key.pem"
+
+	// jwtTokenType is the type of the service account tokens exchanged, as defined in RFC 8693
+	jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+	// sslTargetNameOverrideArg is the gRPC channel argument overriding the name the server certificate is verified against
+	sslTargetNameOverrideArg = "grpc.ssl_target_name_override"
 )
 
 var (
@@ -282,6 +289,13 @@ func (b *Builder) Build() (*xds_bootstrap.Bootstrap, error) {
 		},
 	}
 
+	if b.TokenExchangeURI != "" {
+		// The proxy connects to the xDS server with the gRPC client authenticating with the service account token,
+		// rather than through the cluster authenticating with the client certificate
+		bootstrap.DynamicResources.AdsConfig.GrpcServices = []*xds_core.GrpcService{b.getTokenAuthenticatedGrpcService()}
+		bootstrap.StaticResources.Clusters = nil
+	}
+
 	probeListeners, probeClusters, err := b.getProbeResources()
 	if err != nil {
 		return nil, err
@@ -292,6 +306,51 @@ func (b *Builder) Build() (*xds_bootstrap.Bootstrap, error) {
 	return bootstrap, nil
 }
 
+// getTokenAuthenticatedGrpcService returns the Google gRPC service of the xDS server, whose calls are authenticated
+// with the service account token projected in the pod. gRPC exchanges the token at TokenExchangeURI, reading it again
+// from the file rotated by the kubelet once the token exchanged expires.
+func (b *Builder) getTokenAuthenticatedGrpcService() *xds_core.GrpcService {
+	return &xds_core.GrpcService{
+		TargetSpecifier: &xds_core.GrpcService_GoogleGrpc_{
+			GoogleGrpc: &xds_core.GrpcService_GoogleGrpc{
+				TargetUri:  fmt.Sprintf("%s:%d", b.XDSHost, constants.ADSServerPort),
+				StatPrefix: constants.OSMControllerName,
+				ChannelCredentials: &xds_core.GrpcService_GoogleGrpc_ChannelCredentials{
+					CredentialSpecifier: &xds_core.GrpcService_GoogleGrpc_ChannelCredentials_SslCredentials{
+						SslCredentials: &xds_core.GrpcService_GoogleGrpc_SslCredentials{
+							RootCerts: &xds_core.DataSource{
+								Specifier: &xds_core.DataSource_Filename{
+									Filename: envoyXDSCACertPath,
+								},
+							},
+						},
+					},
+				},
+				CallCredentials: []*xds_core.GrpcService_GoogleGrpc_CallCredentials{
+					{
+						CredentialSpecifier: &xds_core.GrpcService_GoogleGrpc_CallCredentials_StsService_{
+							StsService: &xds_core.GrpcService_GoogleGrpc_CallCredentials_StsService{
+								TokenExchangeServiceUri: b.TokenExchangeURI,
+								SubjectTokenPath:        b.ServiceAccountTokenPath,
+								SubjectTokenType:        jwtTokenType,
+							},
+						},
+					},
+				},
+				ChannelArgs: &xds_core.GrpcService_GoogleGrpc_ChannelArgs{
+					Args: map[string]*xds_core.GrpcService_GoogleGrpc_ChannelArgs_Value{
+						sslTargetNameOverrideArg: {
+							ValueSpecifier: &xds_core.GrpcService_GoogleGrpc_ChannelArgs_Value_StringValue{
+								StringValue: b.XDSServerName,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // GetTLSSDSConfigYAML returns the statically used TLS SDS config YAML.
 func GetTLSSDSConfigYAML() ([]byte, error) {
 	tlsSDSConfig, err := BuildTLSSecret()
@@ -369,5 +428,14 @@ func (b *Builder) getProbeResources() ([]*xds_listener.Listener, []*xds_cluster.
 		clusters = append(clusters, getStartupCluster(b.OriginalHealthProbes.Startup))
 	}
 
+	if b.IPv6HealthProbes {
+		// IPv4 probes of dual-stack pods are accepted as IPv4-mapped addresses
+		for _, listener := range listeners {
+			socketAddress := listener.GetAddress().GetSocketAddress()
+			socketAddress.Address = constants.WildcardIPv6Addr
+			socketAddress.Ipv4Compat = true
+		}
+	}
+
 	return listeners, clusters, nil
 }
@@ -298,3 +298,70 @@ static_resources:
 `
 	assert.Equal(expectedYAML, string(actualYAML))
 }
+
+func TestBuildIPv6HealthProbes(t *testing.T) {
+	assert := tassert.New(t)
+
+	b := &Builder{
+		NodeID:  "foo.bar.co.uk",
+		XDSHost: "osm-controller.osm-system.svc.cluster.local",
+		OriginalHealthProbes: models.HealthProbes{
+			Liveness:  &models.HealthProbe{Path: "/liveness", Port: 81, IsHTTP: true},
+			Readiness: &models.HealthProbe{Path: "/readiness", Port: 82, IsHTTP: true},
+		},
+		IPv6HealthProbes: true,
+	}
+
+	bootstrapConfig, err := b.Build()
+	assert.NoError(err)
+
+	listeners := bootstrapConfig.StaticResources.Listeners
+	assert.Len(listeners, 2)
+	for _, listener := range listeners {
+		assert.Equal("::", listener.Address.GetSocketAddress().Address)
+		assert.True(listener.Address.GetSocketAddress().Ipv4Compat)
+	}
+}
+
+func TestBuildTokenAuthentication(t *testing.T) {
+	assert := tassert.New(t)
+
+	b := &Builder{
+		NodeID:                  "node",
+		XDSHost:                 "osm-controller.osm-system.svc.cluster.local",
+		TokenExchangeURI:        "https://osm-controller.osm-system.svc:15129/v1/token",
+		ServiceAccountTokenPath: "/var/run/secrets/osm/token",
+		XDSServerName:           "ads.cluster.local",
+	}
+
+	bootstrapConfig, err := b.Build()
+	assert.NoError(err)
+
+	// The client certificate cluster of the xDS server is not used
+	assert.Empty(bootstrapConfig.StaticResources.Clusters)
+
+	actualYAML, err := utils.ProtoToYAML(bootstrapConfig.DynamicResources.AdsConfig)
+	assert.NoError(err)
+	expectedYAML := `api_type: GRPC
+grpc_services:
+- google_grpc:
+    call_credentials:
+    - sts_service:
+        subject_token_path: /var/run/secrets/osm/token
+        subject_token_type: urn:ietf:params:oauth:token-type:jwt
+        token_exchange_service_uri: https://osm-controller.osm-system.svc:15129/v1/token
+    channel_args:
+      args:
+        grpc.ssl_target_name_override:
+          string_value: ads.cluster.local
+    channel_credentials:
+      ssl_credentials:
+        root_certs:
+          filename: /etc/envoy/cacert.pem
+    stat_prefix: osm-controller
+    target_uri: osm-controller.osm-system.svc.cluster.local:15128
+set_node_on_first_message_only: true
+transport_api_version: V3
+`
+	assert.Equal(expectedYAML, string(actualYAML))
+}
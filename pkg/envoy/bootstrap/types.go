@@ -29,4 +29,19 @@ type Builder struct {
 	ECDHCurves []string
 
 	OriginalHealthProbes models.HealthProbes
+
+	// IPv6HealthProbes indicates whether the health probe listeners bind to the IPv6 wildcard address, as the kubelet
+	// probes the IPv6 address of the pods of clusters whose primary IP family is IPv6
+	IPv6HealthProbes bool
+
+	// TokenExchangeURI is the URI the gRPC client of the proxy exchanges the service account token projected in its
+	// pod at, to authenticate to the xDS server with the token rather than with a client certificate. The proxy
+	// authenticates with the client certificate of its bootstrap Secret if it's empty.
+	TokenExchangeURI string
+
+	// ServiceAccountTokenPath is the path of the service account token projected in the pod, when TokenExchangeURI is set
+	ServiceAccountTokenPath string
+
+	// XDSServerName is the name the certificate of the xDS server is verified against, when TokenExchangeURI is set
+	XDSServerName string
 }
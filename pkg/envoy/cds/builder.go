@@ -47,6 +47,8 @@ type clusterBuilder struct {
 	metricsEnabled bool
 
 	envoyTracingAddress *xds_core.Address
+
+	ipv6Only bool
 }
 
 func NewClusterBuilder() *clusterBuilder { //nolint: revive // unexported-return
@@ -93,6 +95,12 @@ func (b *clusterBuilder) SetEnvoyTracingAddress(tracingAddress *xds_core.Address
 	return b
 }
 
+// SetIPFamilies sets the IP families of the proxy, the local clusters of IPv6-only proxies being reached over IPv6
+func (b *clusterBuilder) SetIPFamilies(ipv4 bool, ipv6 bool) *clusterBuilder {
+	b.ipv6Only = ipv6 && !ipv4
+	return b
+}
+
 func (b *clusterBuilder) tracingEnabled() bool {
 	return b.envoyTracingAddress != nil
 }
@@ -185,6 +193,35 @@ func getUpstreamServiceCluster(downstreamIdentity identity.ServiceIdentity, conf
 	return upstreamCluster
 }
 
+// getUpstreamServiceHTTPCluster returns the Envoy Cluster HTTP traffic to the given upstream service is routed to
+// when the service's protocol is detected automatically. The cluster shares the endpoints of the upstream service
+// cluster, but advertises a distinct ALPN so that the upstream can apply its HTTP filter chain to such traffic.
+func getUpstreamServiceHTTPCluster(downstreamIdentity identity.ServiceIdentity, config trafficpolicy.MeshClusterConfig, sidecarSpec configv1alpha2.SidecarSpec) *xds_cluster.Cluster {
+	upstreamCluster := getUpstreamServiceCluster(downstreamIdentity, config, sidecarSpec)
+	if upstreamCluster == nil {
+		return nil
+	}
+
+	upstreamTLSContext := envoy.GetUpstreamTLSContext(downstreamIdentity, config.Service, sidecarSpec)
+	upstreamTLSContext.CommonTlsContext.AlpnProtocols = envoy.ALPNInMeshHTTP
+	marshalledUpstreamTLSContext, err := anypb.New(upstreamTLSContext)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling UpstreamTLSContext for upstream HTTP cluster %s", config.Service.EnvoyHTTPClusterName())
+		return nil
+	}
+
+	upstreamCluster.Name = config.Service.EnvoyHTTPClusterName()
+	upstreamCluster.TransportSocket = &xds_core.TransportSocket{
+		Name: upstreamCluster.Name,
+		ConfigType: &xds_core.TransportSocket_TypedConfig{
+			TypedConfig: marshalledUpstreamTLSContext,
+		},
+	}
+	upstreamCluster.EdsClusterConfig.ServiceName = config.Name
+
+	return upstreamCluster
+}
+
 func enableHealthChecksOnCluster(cluster *xds_cluster.Cluster, upstreamSvc service.MeshService) {
 	cluster.HealthChecks = []*xds_core.HealthCheck{
 		{
@@ -210,14 +247,22 @@ func enableHealthChecksOnCluster(cluster *xds_cluster.Cluster, upstreamSvc servi
 	}
 }
 
-// getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
-func getLocalServiceCluster(config trafficpolicy.MeshClusterConfig) *xds_cluster.Cluster {
+// getLocalServiceCluster returns an Envoy Cluster corresponding to the local service, reached over IPv6 if ipv6Only is set
+func getLocalServiceCluster(config trafficpolicy.MeshClusterConfig, ipv6Only bool) *xds_cluster.Cluster {
 	typedHTTPProtocolOptions, err := GetTypedHTTPProtocolOptions(GetHTTPProtocolOptions(config.Protocol))
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting typed HTTP protocol options for local cluster %s", config.Name)
 		return nil
 	}
 
+	address, dnsLookupFamily := config.Address, xds_cluster.Cluster_V4_ONLY
+	if ipv6Only {
+		dnsLookupFamily = xds_cluster.Cluster_V6_ONLY
+		if address == constants.LocalhostIPAddress {
+			address = constants.LocalhostIPv6Address
+		}
+	}
+
 	return &xds_cluster.Cluster{
 		// The name must match the domain being cURLed in the demo
 		Name:          config.Name,
@@ -227,7 +272,7 @@ func getLocalServiceCluster(config trafficpolicy.MeshClusterConfig) *xds_cluster
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
 			Type: xds_cluster.Cluster_STRICT_DNS,
 		},
-		DnsLookupFamily: xds_cluster.Cluster_V4_ONLY,
+		DnsLookupFamily: dnsLookupFamily,
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 			// NOTE: results.MeshService is the top level service that is cURLed.
 			ClusterName: config.Name,
@@ -240,7 +285,7 @@ func getLocalServiceCluster(config trafficpolicy.MeshClusterConfig) *xds_cluster
 					LbEndpoints: []*xds_endpoint.LbEndpoint{{
 						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 							Endpoint: &xds_endpoint.Endpoint{
-								Address: envoy.GetAddress(config.Address, config.Port),
+								Address: envoy.GetAddress(address, config.Port),
 							},
 						},
 						LoadBalancingWeight: &wrappers.UInt32Value{
@@ -463,6 +508,9 @@ func (b *clusterBuilder) buildUpstreamClusters() []*xds_cluster.Cluster {
 
 	for _, c := range b.outboundMeshTrafficClusterConfigs {
 		clusters = append(clusters, getUpstreamServiceCluster(b.proxyIdentity, *c, b.sidecarSpec))
+		if c.Service.Protocol == constants.ProtocolAuto {
+			clusters = append(clusters, getUpstreamServiceHTTPCluster(b.proxyIdentity, *c, b.sidecarSpec))
+		}
 	}
 	return clusters
 }
@@ -471,7 +519,7 @@ func (b *clusterBuilder) buildLocalClusters() []*xds_cluster.Cluster {
 	var clusters []*xds_cluster.Cluster
 
 	for _, c := range b.inboundMeshTrafficClusterConfigs {
-		clusters = append(clusters, getLocalServiceCluster(*c))
+		clusters = append(clusters, getLocalServiceCluster(*c, b.ipv6Only))
 	}
 	return clusters
 }
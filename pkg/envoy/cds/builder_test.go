@@ -8,6 +8,7 @@ import (
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	tassert "github.com/stretchr/testify/assert"
@@ -122,11 +123,68 @@ func TestGetUpstreamServiceCluster(t *testing.T) {
 	}
 }
 
+func TestGetUpstreamServiceHTTPCluster(t *testing.T) {
+	assert := tassert.New(t)
+
+	upstreamSvc := service.MeshService{
+		Namespace:  "default",
+		Name:       "bookstore-v1",
+		Port:       14001,
+		TargetPort: 14001,
+		Protocol:   constants.ProtocolAuto,
+	}
+	clusterConfig := trafficpolicy.MeshClusterConfig{
+		Name:    upstreamSvc.EnvoyClusterName(),
+		Service: upstreamSvc,
+	}
+
+	httpCluster := getUpstreamServiceHTTPCluster(tests.BookbuyerServiceIdentity, clusterConfig, configv1alpha2.SidecarSpec{})
+	assert.NotNil(httpCluster)
+	assert.Equal("default/bookstore-v1|14001|http", httpCluster.Name)
+	assert.Equal(httpCluster.Name, httpCluster.TransportSocket.Name)
+
+	// The endpoints of the upstream service cluster are shared
+	assert.Equal(xds_cluster.Cluster_EDS, httpCluster.GetType())
+	assert.Equal("default/bookstore-v1|14001", httpCluster.EdsClusterConfig.ServiceName)
+
+	// HTTP traffic is advertised to the upstream using a distinct ALPN
+	upstreamTLSContext := &xds_auth.UpstreamTlsContext{}
+	assert.NoError(httpCluster.TransportSocket.GetTypedConfig().UnmarshalTo(upstreamTLSContext))
+	assert.Equal(envoy.ALPNInMeshHTTP, upstreamTLSContext.CommonTlsContext.AlpnProtocols)
+	assert.Equal(upstreamSvc.ServerName(), upstreamTLSContext.Sni)
+
+	// The upstream service cluster is unchanged
+	upstreamCluster := getUpstreamServiceCluster(tests.BookbuyerServiceIdentity, clusterConfig, configv1alpha2.SidecarSpec{})
+	assert.Equal("default/bookstore-v1|14001", upstreamCluster.Name)
+	assert.Empty(upstreamCluster.EdsClusterConfig.ServiceName)
+	assert.NoError(upstreamCluster.TransportSocket.GetTypedConfig().UnmarshalTo(upstreamTLSContext))
+	assert.Equal(envoy.ALPNInMesh, upstreamTLSContext.CommonTlsContext.AlpnProtocols)
+
+	// Only upstream services whose protocol is detected automatically have an HTTP cluster
+	httpSvc := service.MeshService{
+		Namespace:  "default",
+		Name:       "bookstore-v2",
+		Port:       14001,
+		TargetPort: 14001,
+		Protocol:   constants.ProtocolHTTP,
+	}
+	clusters := NewClusterBuilder().
+		SetProxyIdentity(tests.BookbuyerServiceIdentity).
+		SetOutboundMeshTrafficClusterConfigs([]*trafficpolicy.MeshClusterConfig{
+			&clusterConfig,
+			{Name: httpSvc.EnvoyClusterName(), Service: httpSvc},
+		}).
+		buildUpstreamClusters()
+	assert.Len(clusters, 3)
+}
+
 func TestGetLocalServiceCluster(t *testing.T) {
 	testCases := []struct {
 		name                             string
 		clusterConfig                    trafficpolicy.MeshClusterConfig
+		ipv6Only                         bool
 		expectedLocalityLbEndpoints      []*xds_endpoint.LocalityLbEndpoints
+		expectedDNSLookupFamily          xds_cluster.Cluster_DnsLookupFamily
 		expectedLbPolicy                 xds_cluster.Cluster_LbPolicy
 		expectedProtocolSelection        xds_cluster.Cluster_ClusterProtocolSelection
 		expectedPortToProtocolMappingErr bool
@@ -157,7 +215,37 @@ func TestGetLocalServiceCluster(t *testing.T) {
 					}},
 				},
 			},
-			expectedErr: false,
+			expectedDNSLookupFamily: xds_cluster.Cluster_V4_ONLY,
+			expectedErr:             false,
+		},
+		{
+			name: "Local service cluster of an IPv6-only proxy",
+			clusterConfig: trafficpolicy.MeshClusterConfig{
+				Name:    "ns/foo|90|local",
+				Service: service.MeshService{Namespace: "ns", Name: "foo"},
+				Port:    90,
+				Address: "127.0.0.1",
+			},
+			ipv6Only: true,
+			expectedLocalityLbEndpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					Locality: &xds_core.Locality{
+						Zone: "zone",
+					},
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress("::1", uint32(90)),
+							},
+						},
+						LoadBalancingWeight: &wrappers.UInt32Value{
+							Value: constants.ClusterWeightAcceptAll, // Local cluster accepts all traffic
+						},
+					}},
+				},
+			},
+			expectedDNSLookupFamily: xds_cluster.Cluster_V6_ONLY,
+			expectedErr:             false,
 		},
 	}
 
@@ -165,7 +253,7 @@ func TestGetLocalServiceCluster(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			assert := tassert.New(t)
 
-			cluster := getLocalServiceCluster(tc.clusterConfig)
+			cluster := getLocalServiceCluster(tc.clusterConfig, tc.ipv6Only)
 
 			if tc.expectedErr {
 				assert.Nil(cluster)
@@ -175,7 +263,7 @@ func TestGetLocalServiceCluster(t *testing.T) {
 				assert.Equal(xds_cluster.Cluster_ROUND_ROBIN, cluster.LbPolicy)
 				assert.Equal(&xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_STRICT_DNS}, cluster.ClusterDiscoveryType)
 				assert.Equal(true, cluster.RespectDnsTtl)
-				assert.Equal(xds_cluster.Cluster_V4_ONLY, cluster.DnsLookupFamily)
+				assert.Equal(tc.expectedDNSLookupFamily, cluster.DnsLookupFamily)
 				assert.Equal(len(tc.expectedLocalityLbEndpoints), len(cluster.LoadAssignment.Endpoints))
 				assert.ElementsMatch(tc.expectedLocalityLbEndpoints, cluster.LoadAssignment.Endpoints)
 			}
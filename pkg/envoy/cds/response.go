@@ -47,6 +47,12 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *certi
 		cb.SetMetricsEnabled(enabled)
 	}
 
+	if ips, err := meshCatalog.ListIPsForProxy(proxy); err != nil {
+		log.Warn().Err(err).Str("proxy", proxy.String()).Msg("Error looking up the IP addresses of proxy, reaching local clusters over IPv4")
+	} else {
+		cb.SetIPFamilies(utils.GetIPFamilies(ips))
+	}
+
 	if meshConfig.Spec.Observability.Tracing.Enable {
 		tracingAddress := envoy.GetAddress(utils.GetTracingHost(meshConfig), utils.GetTracingPort(meshConfig))
 		cb.SetEnvoyTracingAddress(tracingAddress)
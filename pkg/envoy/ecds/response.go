@@ -0,0 +1,96 @@
+package ecds
+
+import (
+	"fmt"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	xds_wasm_ext "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/registry"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// NewResponse creates a new Extension Config Discovery Response, holding the configs of the user-defined WASM HTTP
+// filters of the proxy. The HTTP filter chains of the listeners reference these configs by the name of the filter, so
+// that a WASM module is sent once to the proxy rather than once per filter chain.
+func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *certificate.Manager, _ *registry.ProxyRegistry) ([]types.Resource, error) {
+	httpFilterExtensions, err := meshCatalog.GetHTTPFilterExtensions(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("error building ECDS response: %w", err)
+	}
+
+	var ecdsResources []types.Resource
+	seen := make(map[string]bool)
+	for _, filters := range [][]trafficpolicy.HTTPFilter{
+		httpFilterExtensions.Inbound.First,
+		httpFilterExtensions.Inbound.Last,
+		httpFilterExtensions.Outbound.First,
+		httpFilterExtensions.Outbound.Last,
+	} {
+		for _, filter := range filters {
+			if filter.Type != policyv1alpha1.HTTPFilterTypeWASM || seen[filter.Name] {
+				continue
+			}
+			seen[filter.Name] = true
+
+			extensionConfig, err := buildWASMExtensionConfig(filter)
+			if err != nil {
+				log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrResolvingHTTPFilterExtension)).
+					Str("proxy", proxy.String()).Msgf("Error building WASM filter %s, skipping it", filter.Name)
+				continue
+			}
+			ecdsResources = append(ecdsResources, extensionConfig)
+		}
+	}
+
+	return ecdsResources, nil
+}
+
+// buildWASMExtensionConfig returns the extension config of the given user-defined WASM filter
+func buildWASMExtensionConfig(filter trafficpolicy.HTTPFilter) (*xds_core.TypedExtensionConfig, error) {
+	pluginConfig := &xds_wasm_ext.PluginConfig{
+		Name:   filter.Name,
+		RootId: filter.WASMRootID,
+		Vm: &xds_wasm_ext.PluginConfig_VmConfig{
+			VmConfig: &xds_wasm_ext.VmConfig{
+				Runtime: "envoy.wasm.runtime.v8",
+				Code: &xds_core.AsyncDataSource{
+					Specifier: &xds_core.AsyncDataSource_Local{
+						Local: &xds_core.DataSource{
+							Specifier: &xds_core.DataSource_InlineBytes{
+								InlineBytes: filter.WASMModule,
+							},
+						},
+					},
+				},
+				AllowPrecompiled: true,
+			},
+		},
+	}
+	if filter.WASMConfiguration != "" {
+		configuration, err := anypb.New(&wrappers.StringValue{Value: filter.WASMConfiguration})
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling configuration of WASM filter %s: %w", filter.Name, err)
+		}
+		pluginConfig.Configuration = configuration
+	}
+
+	typedConfig, err := anypb.New(&xds_wasm.Wasm{Config: pluginConfig})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling WASM filter %s: %w", filter.Name, err)
+	}
+
+	return &xds_core.TypedExtensionConfig{
+		Name:        filter.Name,
+		TypedConfig: typedConfig,
+	}, nil
+}
@@ -0,0 +1,58 @@
+package ecds
+
+import (
+	"testing"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestNewResponse(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+	mockCatalog := catalog.NewMockMeshCataloger(mockCtrl)
+
+	authFilter := trafficpolicy.HTTPFilter{
+		Name:              "ns/ext/auth",
+		Type:              policyv1alpha1.HTTPFilterTypeWASM,
+		WASMModule:        []byte("module"),
+		WASMRootID:        "auth_root",
+		WASMConfiguration: `{"realm": "mesh"}`,
+	}
+	luaFilter := trafficpolicy.HTTPFilter{
+		Name:          "ns/ext/headers",
+		Type:          policyv1alpha1.HTTPFilterTypeLua,
+		LuaInlineCode: "function envoy_on_request(h) end",
+	}
+	// The filters of an extension applying to both directions are sent once
+	mockCatalog.EXPECT().GetHTTPFilterExtensions(gomock.Any()).Return(&trafficpolicy.HTTPFilterExtensions{
+		Inbound:  trafficpolicy.HTTPFilters{First: []trafficpolicy.HTTPFilter{authFilter}, Last: []trafficpolicy.HTTPFilter{luaFilter}},
+		Outbound: trafficpolicy.HTTPFilters{First: []trafficpolicy.HTTPFilter{authFilter}},
+	}, nil)
+
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("sa", "ns"), nil, 1)
+	resources, err := NewResponse(mockCatalog, proxy, nil, nil)
+	assert.NoError(err)
+	assert.Len(resources, 1)
+
+	extensionConfig, ok := resources[0].(*xds_core.TypedExtensionConfig)
+	assert.True(ok)
+	assert.Equal("ns/ext/auth", extensionConfig.Name)
+	wasm := &xds_wasm.Wasm{}
+	assert.NoError(extensionConfig.TypedConfig.UnmarshalTo(wasm))
+	assert.Equal("auth_root", wasm.Config.RootId)
+	assert.Equal([]byte("module"), wasm.Config.GetVmConfig().GetCode().GetLocal().GetInlineBytes())
+	configuration := &wrappers.StringValue{}
+	assert.NoError(wasm.Config.Configuration.UnmarshalTo(configuration))
+	assert.Equal(`{"realm": "mesh"}`, configuration.Value)
+}
@@ -0,0 +1,10 @@
+// Package ecds implements Envoy's Extension Config Discovery Service (ECDS).
+package ecds
+
+import (
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+var (
+	log = logger.New("envoy/ecds")
+)
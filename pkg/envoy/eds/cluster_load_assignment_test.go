@@ -56,6 +56,40 @@ func TestNewClusterLoadAssignment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv4 and IPv6 endpoints for a dual-stack service",
+			svc:  service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
+			endpoints: []endpoint.Endpoint{
+				{IP: net.ParseIP("1.1.1.1"), Port: 80},
+				{IP: net.ParseIP("fd00:10::1"), Port: 80},
+			},
+			expected: &xds_endpoint.ClusterLoadAssignment{
+				ClusterName: "ns1/bookstore-1|80",
+				Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+					{
+						Locality: &xds_core.Locality{
+							Zone: localZone,
+						},
+						LbEndpoints: []*xds_endpoint.LbEndpoint{
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("1.1.1.1", 80),
+									},
+								},
+							},
+							{
+								HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+									Endpoint: &xds_endpoint.Endpoint{
+										Address: envoy.GetAddress("fd00:10::1", 80),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:      "no endpoints for cluster",
 			svc:       service.MeshService{Namespace: "ns1", Name: "bookstore-1", TargetPort: 80},
@@ -47,6 +47,11 @@ func (lb *listenerBuilder) Address(address string, port uint32) *listenerBuilder
 	return lb
 }
 
+func (lb *listenerBuilder) WildcardAddress(ipv4 bool, ipv6 bool, port uint32) *listenerBuilder {
+	lb.address = envoy.GetWildcardAddress(ipv4, ipv6, port)
+	return lb
+}
+
 func (lb *listenerBuilder) TrafficDirection(dir xds_core.TrafficDirection) *listenerBuilder {
 	lb.trafficDirection = dir
 	return lb
@@ -109,22 +114,24 @@ func (lb *listenerBuilder) defaultOutboundListenerFilters() *listenerBuilder {
 func (lb *listenerBuilder) DefaultInboundListenerFilters() *listenerBuilder {
 	lb.listenerFilters = append(lb.listenerFilters,
 		&xds_listener.ListenerFilter{
-			// To inspect TLS metadata, such as the transport protocol and SNI
-			Name: envoy.TLSInspectorFilterName,
+			// The OriginalDestination ListenerFilter is used to restore the original destination address
+			// as opposed to the listener's address (due to iptables redirection).
+			// This enables  filter chain matching on the original destination address (ip, port).
+			// It must precede the TLSInspector ListenerFilter, whose match predicate relies on the original
+			// destination port.
+			Name: envoy.OriginalDstFilterName,
 			ConfigType: &xds_listener.ListenerFilter_TypedConfig{
 				TypedConfig: &any.Any{
-					TypeUrl: envoy.TLSInspectorFilterTypeURL,
+					TypeUrl: envoy.OriginalDstFilterTypeURL,
 				},
 			},
 		},
 		&xds_listener.ListenerFilter{
-			// The OriginalDestination ListenerFilter is used to restore the original destination address
-			// as opposed to the listener's address (due to iptables redirection).
-			// This enables  filter chain matching on the original destination address (ip, port).
-			Name: envoy.OriginalDstFilterName,
+			// To inspect TLS metadata, such as the transport protocol and SNI
+			Name: envoy.TLSInspectorFilterName,
 			ConfigType: &xds_listener.ListenerFilter_TypedConfig{
 				TypedConfig: &any.Any{
-					TypeUrl: envoy.OriginalDstFilterTypeURL,
+					TypeUrl: envoy.TLSInspectorFilterTypeURL,
 				},
 			},
 		},
@@ -147,6 +154,11 @@ func (lb *listenerBuilder) WASMStatsHeaders(headers map[string]string) *listener
 	return lb
 }
 
+func (lb *listenerBuilder) HTTPFilterExtensions(filters trafficpolicy.HTTPFilters) *listenerBuilder {
+	lb.httpFilterExtensions = filters
+	return lb
+}
+
 func (lb *listenerBuilder) ActiveHealthCheck(enable bool) *listenerBuilder {
 	lb.activeHealthCheck = enable
 	return lb
@@ -162,6 +174,12 @@ func (lb *listenerBuilder) TrustDomain(trustDomain string) *listenerBuilder {
 	return lb
 }
 
+// IdentityMatchMode sets how downstream identities are matched by the RBAC filters of the listener
+func (lb *listenerBuilder) IdentityMatchMode(mode configv1alpha2.IdentityMatchMode) *listenerBuilder {
+	lb.spiffePrincipalsOnly = mode == configv1alpha2.IdentityMatchModeSPIFFE
+	return lb
+}
+
 func (lb *listenerBuilder) SidecarSpec(sidecarSpec configv1alpha2.SidecarSpec) *listenerBuilder {
 	lb.sidecarSpec = sidecarSpec
 	return lb
@@ -268,6 +286,18 @@ func (lb *listenerBuilder) buildInboundListener() *xds_listener.Listener {
 		AccessLog:        envoy.GetAccessLog(),
 	}
 
+	// Ports corresponding to server-first protocols must not wait on the client to send the first bytes,
+	// so the TLSInspector ListenerFilter is disabled for such ports. The filter chains for these ports
+	// match on the destination port alone.
+	if lb.inboundMeshTrafficPolicy != nil {
+		filterDisableMatchPredicate := getFilterMatchPredicateForTrafficMatches(lb.inboundMeshTrafficPolicy.TrafficMatches)
+		for _, listenerFilter := range l.ListenerFilters {
+			if listenerFilter.Name == envoy.TLSInspectorFilterName {
+				listenerFilter.FilterDisabled = filterDisableMatchPredicate
+			}
+		}
+	}
+
 	return l
 }
 
@@ -294,6 +324,7 @@ func (lb *listenerBuilder) buildOutboundHTTPFilter(routeConfigName string) (*xds
 			hb.AddFilter(f)
 		}
 	}
+	lb.addHTTPFilterExtensions(hb)
 
 	return hb.Build()
 }
@@ -319,10 +350,11 @@ func (fb *filterBuilder) StatsPrefix(statsPrefix string) *filterBuilder {
 }
 
 // WithRBAC sets the RBAC properties used to build the filter
-func (fb *filterBuilder) WithRBAC(t []trafficpolicy.TrafficTargetWithRoutes, trustDomain string) *filterBuilder {
+func (fb *filterBuilder) WithRBAC(t []trafficpolicy.TrafficTargetWithRoutes, trustDomain string, spiffePrincipalsOnly bool) *filterBuilder {
 	fb.withRBAC = true
 	fb.trafficTargets = t
 	fb.trustDomain = trustDomain
+	fb.spiffePrincipalsOnly = spiffePrincipalsOnly
 	return fb
 }
 
@@ -356,7 +388,7 @@ func (fb *filterBuilder) Build() ([]*xds_listener.Filter, error) {
 
 	// RBAC filter should be the very first filter in the filter chain
 	if fb.withRBAC {
-		rbacFilter, err := buildRBACFilter(fb.trafficTargets, fb.trustDomain)
+		rbacFilter, err := buildRBACFilter(fb.trafficTargets, fb.trustDomain, fb.spiffePrincipalsOnly)
 		if err != nil {
 			return nil, err
 		}
@@ -464,6 +496,14 @@ func (hb *httpConnManagerBuilder) AddFilter(filter *xds_hcm.HttpFilter) *httpCon
 	return hb
 }
 
+// UserFilters sets the user-defined HTTP filters added before and after the filters built by OSM. The filters in
+// the last position are added right before the HTTP router filter.
+func (hb *httpConnManagerBuilder) UserFilters(first, last []*xds_hcm.HttpFilter) *httpConnManagerBuilder {
+	hb.firstFilters = first
+	hb.lastFilters = last
+	return hb
+}
+
 // LocalReplyConfig sets the given LocalReplyConfig on the builder
 func (hb *httpConnManagerBuilder) LocalReplyConfig(config *xds_hcm.LocalReplyConfig) *httpConnManagerBuilder {
 	hb.localReplyConfig = config
@@ -483,8 +523,10 @@ func (hb *httpConnManagerBuilder) HTTPGlobalRateLimit(rl *policyv1alpha1.HTTPGlo
 
 // Build builds the HttpConnectionManager filter from the builder config
 func (hb *httpConnManagerBuilder) Build() (*xds_listener.Filter, error) {
-	httpFilters := hb.defaultFilters()
+	httpFilters := append([]*xds_hcm.HttpFilter{}, hb.firstFilters...)
+	httpFilters = append(httpFilters, hb.defaultFilters()...)
 	httpFilters = append(httpFilters, hb.filters...)
+	httpFilters = append(httpFilters, hb.lastFilters...)
 
 	// NOTE: router filter must always be the last filter in the list
 	if hb.routerFilter == nil {
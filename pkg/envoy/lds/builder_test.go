@@ -3,6 +3,7 @@ package lds
 import (
 	"testing"
 
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
@@ -54,6 +55,20 @@ func TestHTTPConnManagerBuilder(t *testing.T) {
 				a.Equal(websocketUpgradeType, hcm.UpgradeConfigs[0].UpgradeType)
 			},
 		},
+		{
+			name: "user filters are ordered around the built-in filters",
+			buildFunc: func(b *httpConnManagerBuilder) {
+				b.AddFilter(&xds_hcm.HttpFilter{Name: "f1"}).
+					UserFilters([]*xds_hcm.HttpFilter{{Name: "first"}}, []*xds_hcm.HttpFilter{{Name: "last"}})
+			},
+			assertFunc: func(a *assert.Assertions, hcm *xds_hcm.HttpConnectionManager) {
+				var names []string
+				for _, f := range hcm.HttpFilters {
+					names = append(names, f.Name)
+				}
+				a.Equal([]string{"first", envoy.HTTPRBACFilterName, envoy.HTTPLocalRateLimitFilterName, "f1", "last", envoy.HTTPRouterFilterName}, names)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -125,6 +140,13 @@ func TestBuildInboundFilterChains(t *testing.T) {
 						Cluster:             "foo",
 						DestinationIPRanges: []string{"1.1.1.1/32", "2.2.2.2/32"},
 					},
+					{
+						Name:                "5",
+						DestinationPort:     110,
+						DestinationProtocol: "auto",
+						Cluster:             "foo",
+						DestinationIPRanges: []string{"1.1.1.1/32"},
+					},
 				},
 			},
 			ingressTrafficPolicies: []*trafficpolicy.IngressTrafficPolicy{
@@ -147,7 +169,33 @@ func TestBuildInboundFilterChains(t *testing.T) {
 					},
 				},
 			},
-			expectedFilterChains: 6, // 4 in-mesh + 2 ingress
+			expectedFilterChains: 8, // 6 in-mesh (2 for the auto traffic match) + 2 ingress
+		},
+		{
+			name: "server-first traffic matches on the same port result in a single filter chain",
+			inboundMeshTrafficPolicy: &trafficpolicy.InboundMeshTrafficPolicy{
+				TrafficMatches: []*trafficpolicy.TrafficMatch{
+					{
+						Name:                "1",
+						DestinationPort:     3306,
+						DestinationProtocol: "tcp-server-first",
+						Cluster:             "foo",
+					},
+					{
+						Name:                "2",
+						DestinationPort:     3306,
+						DestinationProtocol: "tcp-server-first",
+						Cluster:             "bar",
+					},
+					{
+						Name:                "3",
+						DestinationPort:     25,
+						DestinationProtocol: "tcp-server-first",
+						Cluster:             "baz",
+					},
+				},
+			},
+			expectedFilterChains: 2,
 		},
 		{
 			name:                     "nil InboundMeshTrafficPolicy/IngressTrafficPolicy should result in 0 filter chains",
@@ -178,6 +226,39 @@ func TestBuildInboundFilterChains(t *testing.T) {
 	}
 }
 
+func TestBuildInboundListenerFilterDisabled(t *testing.T) {
+	a := assert.New(t)
+
+	lb := ListenerBuilder().
+		Name(InboundListenerName).
+		ProxyIdentity(tests.BookbuyerServiceIdentity).
+		TrafficDirection(xds_core.TrafficDirection_INBOUND).
+		DefaultInboundListenerFilters().
+		InboundMeshTrafficPolicy(&trafficpolicy.InboundMeshTrafficPolicy{
+			TrafficMatches: []*trafficpolicy.TrafficMatch{
+				{
+					Name:                "1",
+					DestinationPort:     80,
+					DestinationProtocol: "http",
+					Cluster:             "foo",
+				},
+				{
+					Name:                "2",
+					DestinationPort:     3306,
+					DestinationProtocol: "tcp-server-first",
+					Cluster:             "bar",
+				},
+			},
+		})
+
+	l := lb.buildInboundListener()
+	a.Len(l.ListenerFilters, 2)
+	a.Equal(envoy.OriginalDstFilterName, l.ListenerFilters[0].Name)
+	a.Nil(l.ListenerFilters[0].FilterDisabled)
+	a.Equal(envoy.TLSInspectorFilterName, l.ListenerFilters[1].Name)
+	a.Equal(getFilterMatchPredicateForPorts([]int{3306}), l.ListenerFilters[1].FilterDisabled)
+}
+
 func TestFilterBuilder(t *testing.T) {
 	testCases := []struct {
 		name                   string
@@ -257,7 +338,7 @@ func TestFilterBuilder(t *testing.T) {
 						},
 						TCPRouteMatches: nil,
 					},
-				}, "cluster.local").
+				}, "cluster.local", false).
 					httpConnManager()
 			},
 			expectedNetworkFilters: []string{envoy.L4RBACFilterName},
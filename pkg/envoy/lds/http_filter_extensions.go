@@ -0,0 +1,74 @@
+package lds
+
+import (
+	"fmt"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/errcode"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+// wasmFilterTypeURL is the type URL of the WASM HTTP filter configs discovered with ECDS
+const wasmFilterTypeURL = "type.googleapis.com/envoy.extensions.filters.http.wasm.v3.Wasm"
+
+// addHTTPFilterExtensions adds the user-defined HTTP filters of the listener to the given HTTP connection manager.
+// The filters are validated by the catalog, a filter that can't be built is skipped so that it does not prevent the
+// listener from being built.
+func (lb *listenerBuilder) addHTTPFilterExtensions(hb *httpConnManagerBuilder) {
+	hb.UserFilters(buildUserHTTPFilters(lb.httpFilterExtensions.First), buildUserHTTPFilters(lb.httpFilterExtensions.Last))
+}
+
+// buildUserHTTPFilters returns the HTTP filters for the given user-defined filters
+func buildUserHTTPFilters(filters []trafficpolicy.HTTPFilter) []*xds_hcm.HttpFilter {
+	var httpFilters []*xds_hcm.HttpFilter
+	for _, filter := range filters {
+		httpFilter, err := buildUserHTTPFilter(filter)
+		if err != nil {
+			log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrResolvingHTTPFilterExtension)).
+				Msgf("Error building HTTP filter %s, skipping it", filter.Name)
+			continue
+		}
+		httpFilters = append(httpFilters, httpFilter)
+	}
+	return httpFilters
+}
+
+// buildUserHTTPFilter returns the HTTP filter for the given user-defined filter. The configs of WASM filters are
+// discovered with ECDS, so that their modules are not copied into every HTTP connection manager.
+func buildUserHTTPFilter(filter trafficpolicy.HTTPFilter) (*xds_hcm.HttpFilter, error) {
+	switch filter.Type {
+	case policyv1alpha1.HTTPFilterTypeLua:
+		typedConfig, err := anypb.New(&xds_lua.Lua{
+			InlineCode: filter.LuaInlineCode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling filter %s: %w", filter.Name, err)
+		}
+		return &xds_hcm.HttpFilter{
+			Name: filter.Name,
+			ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+				TypedConfig: typedConfig,
+			},
+		}, nil
+
+	case policyv1alpha1.HTTPFilterTypeWASM:
+		return &xds_hcm.HttpFilter{
+			Name: filter.Name,
+			ConfigType: &xds_hcm.HttpFilter_ConfigDiscovery{
+				ConfigDiscovery: &xds_core.ExtensionConfigSource{
+					ConfigSource: envoy.GetADSConfigSource(),
+					TypeUrls:     []string{wasmFilterTypeURL},
+				},
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("filter %s has unsupported type %q", filter.Name, filter.Type)
+	}
+}
@@ -0,0 +1,77 @@
+package lds
+
+import (
+	"testing"
+
+	xds_lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/rds"
+	"github.com/openservicemesh/osm/pkg/trafficpolicy"
+)
+
+func TestBuildUserHTTPFilter(t *testing.T) {
+	a := assert.New(t)
+
+	luaFilter, err := buildUserHTTPFilter(trafficpolicy.HTTPFilter{
+		Name:          "ns/ext/headers",
+		Type:          policyv1alpha1.HTTPFilterTypeLua,
+		LuaInlineCode: "function envoy_on_request(h) end",
+	})
+	a.NoError(err)
+	a.Equal("ns/ext/headers", luaFilter.Name)
+	lua := &xds_lua.Lua{}
+	a.NoError(luaFilter.GetTypedConfig().UnmarshalTo(lua))
+	a.Equal("function envoy_on_request(h) end", lua.InlineCode)
+
+	wasmFilter, err := buildUserHTTPFilter(trafficpolicy.HTTPFilter{
+		Name:              "ns/ext/auth",
+		Type:              policyv1alpha1.HTTPFilterTypeWASM,
+		WASMModule:        []byte("module"),
+		WASMRootID:        "auth_root",
+		WASMConfiguration: `{"realm": "mesh"}`,
+	})
+	a.NoError(err)
+	// The config of the WASM filter is discovered with ECDS
+	a.Nil(wasmFilter.GetTypedConfig())
+	a.Equal("ns/ext/auth", wasmFilter.Name)
+	a.NotNil(wasmFilter.GetConfigDiscovery().GetConfigSource().GetAds())
+	a.Equal([]string{"type.googleapis.com/" + string(proto.MessageName(&xds_wasm.Wasm{}))}, wasmFilter.GetConfigDiscovery().GetTypeUrls())
+
+	_, err = buildUserHTTPFilter(trafficpolicy.HTTPFilter{Name: "ns/ext/unknown", Type: "golang"})
+	a.EqualError(err, `filter ns/ext/unknown has unsupported type "golang"`)
+}
+
+func TestBuildOutboundHTTPFilterWithExtensions(t *testing.T) {
+	a := assert.New(t)
+
+	lb := &listenerBuilder{
+		httpFilterExtensions: trafficpolicy.HTTPFilters{
+			First: []trafficpolicy.HTTPFilter{{Name: "first", Type: policyv1alpha1.HTTPFilterTypeLua, LuaInlineCode: "-- first"}},
+			Last:  []trafficpolicy.HTTPFilter{{Name: "last", Type: policyv1alpha1.HTTPFilterTypeLua, LuaInlineCode: "-- last"}},
+		},
+	}
+
+	filter, err := lb.buildOutboundHTTPFilter(rds.OutboundRouteConfigName)
+	a.NoError(err)
+	hcm := &xds_hcm.HttpConnectionManager{}
+	a.NoError(filter.GetTypedConfig().UnmarshalTo(hcm))
+
+	var names []string
+	for _, f := range hcm.HttpFilters {
+		names = append(names, f.Name)
+	}
+	a.Equal([]string{"first", envoy.HTTPRBACFilterName, envoy.HTTPLocalRateLimitFilterName, "last", envoy.HTTPRouterFilterName}, names)
+
+	// A filter that can't be built is skipped rather than failing the listener
+	lb.httpFilterExtensions.Last = append(lb.httpFilterExtensions.Last, trafficpolicy.HTTPFilter{Name: "unknown", Type: "golang"})
+	filter, err = lb.buildOutboundHTTPFilter(rds.OutboundRouteConfigName)
+	a.NoError(err)
+	a.NoError(filter.GetTypedConfig().UnmarshalTo(hcm))
+	a.Len(hcm.HttpFilters, len(names))
+}
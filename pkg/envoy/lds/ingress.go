@@ -56,6 +56,7 @@ func (lb *listenerBuilder) buildIngressFilterChainFromTrafficMatch(trafficMatch
 	if lb.extAuthzConfig != nil && lb.extAuthzConfig.Enable {
 		hcmBuilder.AddFilter(getExtAuthzHTTPFilter(lb.extAuthzConfig))
 	}
+	lb.addHTTPFilterExtensions(hcmBuilder)
 
 	// Build the HTTP Connection Manager filter
 	hcmFilter, err := hcmBuilder.Build()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	mapset "github.com/deckarep/golang-set"
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -22,6 +23,9 @@ func (lb *listenerBuilder) buildInboundMeshFilterChains() []*xds_listener.Filter
 	}
 
 	var filterChains []*xds_listener.FilterChain
+	// Server-first filter chains match on the destination port alone, so only a
+	// single such filter chain can be programmed per port.
+	serverFirstPorts := mapset.NewSet()
 
 	for _, match := range lb.inboundMeshTrafficPolicy.TrafficMatches {
 		// Create protocol specific inbound filter chains for MeshService's TargetPort
@@ -36,6 +40,11 @@ func (lb *listenerBuilder) buildInboundMeshFilterChains() []*xds_listener.Filter
 			}
 
 		case constants.ProtocolTCP, constants.ProtocolTCPServerFirst:
+			if match.DestinationProtocol == constants.ProtocolTCPServerFirst && !serverFirstPorts.Add(match.DestinationPort) {
+				log.Error().Msgf("Skipping inbound filter chain for traffic match %s, server-first port %d is already used by another service",
+					match.Name, match.DestinationPort)
+				continue
+			}
 			filterChainForPort, err := lb.buildInboundTCPFilterChain(match)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error building inbound TCP filter chain for traffic match %s", match.Name)
@@ -43,6 +52,14 @@ func (lb *listenerBuilder) buildInboundMeshFilterChains() []*xds_listener.Filter
 				filterChains = append(filterChains, filterChainForPort)
 			}
 
+		case constants.ProtocolAuto:
+			filterChainsForPort, err := lb.buildInboundAutoFilterChains(match)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error building inbound filter chains for traffic match %s", match.Name)
+			} else {
+				filterChains = append(filterChains, filterChainsForPort...)
+			}
+
 		default:
 			log.Error().Msgf("Cannot build inbound filter chain, unsupported protocol %s for traffic match %s", match.DestinationProtocol, match.Name)
 		}
@@ -62,7 +79,7 @@ func (lb *listenerBuilder) buildInboundHTTPFilterChain(trafficMatch *trafficpoli
 
 	// Network RBAC
 	if !lb.permissiveMesh {
-		fb.WithRBAC(lb.trafficTargets, lb.trustDomain)
+		fb.WithRBAC(lb.trafficTargets, lb.trustDomain, lb.spiffePrincipalsOnly)
 	}
 
 	// TCP local rate limit
@@ -110,6 +127,7 @@ func (lb *listenerBuilder) buildInboundHTTPFilterChain(trafficMatch *trafficpoli
 		}
 		fb.httpConnManager().AddFilter(healthCheckFilter)
 	}
+	lb.addHTTPFilterExtensions(fb.httpConnManager())
 
 	// Build the inbound filters
 	filters, err := fb.Build()
@@ -173,7 +191,7 @@ func (lb *listenerBuilder) buildInboundTCPFilterChain(trafficMatch *trafficpolic
 
 	// Network RBAC
 	if !lb.permissiveMesh && len(lb.trafficTargets) > 0 {
-		fb.WithRBAC(lb.trafficTargets, lb.trustDomain)
+		fb.WithRBAC(lb.trafficTargets, lb.trustDomain, lb.spiffePrincipalsOnly)
 	}
 
 	// TCP local rate limit
@@ -200,25 +218,36 @@ func (lb *listenerBuilder) buildInboundTCPFilterChain(trafficMatch *trafficpolic
 		return nil, err
 	}
 
-	return &xds_listener.FilterChain{
-		Name: trafficMatch.Name,
-		FilterChainMatch: &xds_listener.FilterChainMatch{
-			// The DestinationPort is the service port the downstream directs traffic to
+	filterChainMatch := &xds_listener.FilterChainMatch{
+		// The DestinationPort is the service port the downstream directs traffic to
+		DestinationPort: &wrapperspb.UInt32Value{
+			Value: uint32(trafficMatch.DestinationPort),
+		},
+
+		// The ServerName is the SNI set by the downstream in the UptreamTlsContext by GetUpstreamTLSContext()
+		// This is not a field obtained from the mTLS Certificate.
+		ServerNames: trafficMatch.ServerNames,
+
+		// Only match when transport protocol is TLS
+		TransportProtocol: envoy.TransportProtocolTLS,
+
+		// In-mesh proxies will advertise this, set in the UpstreamTlsContext by GetUpstreamTLSContext()
+		ApplicationProtocols: envoy.ALPNInMesh,
+	}
+	if trafficMatch.DestinationProtocol == constants.ProtocolTCPServerFirst {
+		// The TLSInspector ListenerFilter is disabled for server-first ports, so the TLS metadata
+		// is not available to match on. mTLS is still enforced by the transport socket.
+		filterChainMatch = &xds_listener.FilterChainMatch{
 			DestinationPort: &wrapperspb.UInt32Value{
 				Value: uint32(trafficMatch.DestinationPort),
 			},
+		}
+	}
 
-			// The ServerName is the SNI set by the downstream in the UptreamTlsContext by GetUpstreamTLSContext()
-			// This is not a field obtained from the mTLS Certificate.
-			ServerNames: trafficMatch.ServerNames,
-
-			// Only match when transport protocol is TLS
-			TransportProtocol: envoy.TransportProtocolTLS,
-
-			// In-mesh proxies will advertise this, set in the UpstreamTlsContext by GetUpstreamTLSContext()
-			ApplicationProtocols: envoy.ALPNInMesh,
-		},
-		Filters: filters,
+	return &xds_listener.FilterChain{
+		Name:             trafficMatch.Name,
+		FilterChainMatch: filterChainMatch,
+		Filters:          filters,
 		TransportSocket: &xds_core.TransportSocket{
 			Name: trafficMatch.Name,
 			ConfigType: &xds_core.TransportSocket_TypedConfig{
@@ -228,6 +257,26 @@ func (lb *listenerBuilder) buildInboundTCPFilterChain(trafficMatch *trafficpolic
 	}, nil
 }
 
+// buildInboundAutoFilterChains returns the filter chains for a port whose protocol is detected automatically.
+// Inbound traffic is encrypted by the downstream, so the protocol can't be inspected on the inbound listener.
+// Instead, the downstream detects the protocol and advertises HTTP traffic using the ALPNInMeshHTTP ALPN, which
+// selects the HTTP filter chain. The remaining traffic is proxied by the TCP filter chain.
+func (lb *listenerBuilder) buildInboundAutoFilterChains(trafficMatch *trafficpolicy.TrafficMatch) ([]*xds_listener.FilterChain, error) {
+	httpFilterChain, err := lb.buildInboundHTTPFilterChain(trafficMatch)
+	if err != nil {
+		return nil, err
+	}
+	httpFilterChain.Name = getAutoDetectedHTTPFilterChainName(trafficMatch.Name)
+	httpFilterChain.FilterChainMatch.ApplicationProtocols = envoy.ALPNInMeshHTTP
+
+	tcpFilterChain, err := lb.buildInboundTCPFilterChain(trafficMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*xds_listener.FilterChain{httpFilterChain, tcpFilterChain}, nil
+}
+
 // buildOutboundFilterChainMatch builds a filter chain to match the HTTP or TCP based destination traffic.
 // Filter Chain currently matches on the following:
 // 1. Destination IP of service endpoints
@@ -304,6 +353,31 @@ func (lb *listenerBuilder) buildOutboundTCPFilterChain(trafficMatch trafficpolic
 	}, nil
 }
 
+// buildOutboundAutoFilterChains returns the filter chains for a port whose protocol is detected automatically.
+// Traffic detected as HTTP by the HttpInspector ListenerFilter is matched by the HTTP filter chain, while the
+// remaining traffic is matched by the TCP filter chain.
+func (lb *listenerBuilder) buildOutboundAutoFilterChains(trafficMatch trafficpolicy.TrafficMatch) ([]*xds_listener.FilterChain, error) {
+	httpFilterChain, err := lb.buildOutboundHTTPFilterChain(trafficMatch)
+	if err != nil {
+		return nil, err
+	}
+	httpFilterChain.Name = getAutoDetectedHTTPFilterChainName(trafficMatch.Name)
+	httpFilterChain.FilterChainMatch.ApplicationProtocols = httpProtocols
+
+	tcpFilterChain, err := lb.buildOutboundTCPFilterChain(trafficMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*xds_listener.FilterChain{httpFilterChain, tcpFilterChain}, nil
+}
+
+// getAutoDetectedHTTPFilterChainName returns the name of the filter chain for HTTP traffic detected on a port
+// whose protocol is detected automatically, given the name of the traffic match for the port.
+func getAutoDetectedHTTPFilterChainName(trafficMatchName string) string {
+	return fmt.Sprintf("%s_%s", trafficMatchName, constants.ProtocolHTTP)
+}
+
 // NEWCODE
 // getOutboundFilterChainPerUpstream returns a list of filter chains corresponding to upstream services
 func (lb *listenerBuilder) buildOutboundFilterChains() []*xds_listener.FilterChain {
@@ -333,6 +407,14 @@ func (lb *listenerBuilder) buildOutboundFilterChains() []*xds_listener.FilterCha
 				filterChains = append(filterChains, tcpFilterChain)
 			}
 
+		case constants.ProtocolAuto:
+			// Construct HTTP and TCP filter chains, selected based on the protocol detected by the HttpInspector
+			if autoFilterChains, err := lb.buildOutboundAutoFilterChains(*trafficMatch); err != nil {
+				log.Error().Err(err).Msgf("Error constructing outbound filter chains for traffic match %s on proxy with identity %s", trafficMatch.Name, lb.proxyIdentity)
+			} else {
+				filterChains = append(filterChains, autoFilterChains...)
+			}
+
 		default:
 			log.Error().Msgf("Cannot build outbound filter chain, unsupported protocol %s for traffic match %s", trafficMatch.DestinationProtocol, trafficMatch.Name)
 		}
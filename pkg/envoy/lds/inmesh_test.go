@@ -515,6 +515,22 @@ func TestBuildInboundTCPFilterChain(t *testing.T) {
 			expectedFilterNames: []string{envoy.L4GlobalRateLimitFilterName, envoy.TCPProxyFilterName},
 			expectError:         false,
 		},
+		{
+			name:           "inbound TCP server-first filter chain matches on the destination port only",
+			permissiveMode: true,
+			trafficMatch: &trafficpolicy.TrafficMatch{
+				Name:                "inbound_ns1/svc1_3306_tcp-server-first",
+				Cluster:             "ns1/svc1_3306_tcp-server-first",
+				DestinationPort:     3306,
+				DestinationProtocol: "tcp-server-first",
+				ServerNames:         []string{"svc1.ns1.svc.cluster.local"},
+			},
+			expectedFilterChainMatch: &xds_listener.FilterChainMatch{
+				DestinationPort: &wrapperspb.UInt32Value{Value: 3306},
+			},
+			expectedFilterNames: []string{envoy.TCPProxyFilterName},
+			expectError:         false,
+		},
 	}
 
 	trafficTargets := []trafficpolicy.TrafficTargetWithRoutes{
@@ -550,6 +566,92 @@ func TestBuildInboundTCPFilterChain(t *testing.T) {
 	}
 }
 
+func TestBuildInboundAutoFilterChains(t *testing.T) {
+	assert := tassert.New(t)
+	lb := &listenerBuilder{
+		proxyIdentity:  tests.BookbuyerServiceIdentity,
+		permissiveMesh: true,
+	}
+
+	trafficMatch := &trafficpolicy.TrafficMatch{
+		Name:                "inbound_ns1/svc1_80_auto",
+		Cluster:             "ns1/svc1|80|local",
+		DestinationPort:     80,
+		DestinationProtocol: "auto",
+		ServerNames:         []string{"svc1.ns1.svc.cluster.local"},
+	}
+
+	filterChains, err := lb.buildInboundAutoFilterChains(trafficMatch)
+	assert.NoError(err)
+	assert.Len(filterChains, 2)
+
+	// HTTP traffic is advertised by the downstream using a distinct ALPN
+	httpFilterChain := filterChains[0]
+	assert.Equal("inbound_ns1/svc1_80_auto_http", httpFilterChain.Name)
+	assert.Equal(&xds_listener.FilterChainMatch{
+		DestinationPort:      &wrapperspb.UInt32Value{Value: 80},
+		ServerNames:          []string{"svc1.ns1.svc.cluster.local"},
+		TransportProtocol:    "tls",
+		ApplicationProtocols: []string{"osm-http"},
+	}, httpFilterChain.FilterChainMatch)
+	assert.Len(httpFilterChain.Filters, 1)
+	assert.Equal(envoy.HTTPConnectionManagerFilterName, httpFilterChain.Filters[0].Name)
+
+	// The remaining traffic is proxied over TCP
+	tcpFilterChain := filterChains[1]
+	assert.Equal("inbound_ns1/svc1_80_auto", tcpFilterChain.Name)
+	assert.Equal(&xds_listener.FilterChainMatch{
+		DestinationPort:      &wrapperspb.UInt32Value{Value: 80},
+		ServerNames:          []string{"svc1.ns1.svc.cluster.local"},
+		TransportProtocol:    "tls",
+		ApplicationProtocols: []string{"osm"},
+	}, tcpFilterChain.FilterChainMatch)
+	assert.Len(tcpFilterChain.Filters, 1)
+	assert.Equal(envoy.TCPProxyFilterName, tcpFilterChain.Filters[0].Name)
+}
+
+func TestBuildOutboundAutoFilterChains(t *testing.T) {
+	assert := tassert.New(t)
+	lb := &listenerBuilder{}
+
+	trafficMatch := trafficpolicy.TrafficMatch{
+		Name:                "outbound_ns1/svc1_80_auto",
+		DestinationPort:     80,
+		DestinationProtocol: "auto",
+		DestinationIPRanges: []string{"1.1.1.1/32", "fd00:10::1/128"},
+		WeightedClusters: []service.WeightedCluster{
+			{ClusterName: "ns1/svc1|80", Weight: 100},
+		},
+	}
+
+	filterChains, err := lb.buildOutboundAutoFilterChains(trafficMatch)
+	assert.NoError(err)
+	assert.Len(filterChains, 2)
+
+	// HTTP traffic is detected by the HttpInspector ListenerFilter
+	httpFilterChain := filterChains[0]
+	assert.Equal("outbound_ns1/svc1_80_auto_http", httpFilterChain.Name)
+	assert.Equal(uint32(80), httpFilterChain.FilterChainMatch.DestinationPort.GetValue())
+	assert.Len(httpFilterChain.FilterChainMatch.PrefixRanges, 2)
+	assert.ElementsMatch([]string{"http/1.0", "http/1.1", "h2c"}, httpFilterChain.FilterChainMatch.ApplicationProtocols)
+	assert.Len(httpFilterChain.Filters, 1)
+	assert.Equal(envoy.HTTPConnectionManagerFilterName, httpFilterChain.Filters[0].Name)
+
+	// The remaining traffic is proxied over TCP
+	tcpFilterChain := filterChains[1]
+	assert.Equal("outbound_ns1/svc1_80_auto", tcpFilterChain.Name)
+	assert.Equal(uint32(80), tcpFilterChain.FilterChainMatch.DestinationPort.GetValue())
+	assert.Len(tcpFilterChain.FilterChainMatch.PrefixRanges, 2)
+	assert.Empty(tcpFilterChain.FilterChainMatch.ApplicationProtocols)
+	assert.Len(tcpFilterChain.Filters, 1)
+	assert.Equal(envoy.TCPProxyFilterName, tcpFilterChain.Filters[0].Name)
+
+	// A traffic match without destination IP ranges results in an error
+	trafficMatch.DestinationIPRanges = nil
+	_, err = lb.buildOutboundAutoFilterChains(trafficMatch)
+	assert.Error(err)
+}
+
 // Tests buildOutboundFilterChainMatch and ensures the filter chain match returned is as expected
 func TestBuildOutboundFilterChainMatch(t *testing.T) {
 	testCases := []struct {
@@ -706,9 +808,21 @@ func TestBuildOutboundFilterChains(t *testing.T) {
 						},
 						DestinationIPRanges: []string{"1.1.1.1/32", "2.2.2.2/32"},
 					},
+					{
+						Name:                "5",
+						DestinationPort:     110,
+						DestinationProtocol: "auto",
+						WeightedClusters: []service.WeightedCluster{
+							{
+								ClusterName: "baz",
+								Weight:      100,
+							},
+						},
+						DestinationIPRanges: []string{"1.1.1.1/32"},
+					},
 				},
 			},
-			expectedFilterChains: 4,
+			expectedFilterChains: 6, // 2 filter chains for the auto traffic match
 		},
 		{
 			name:                 "nil OutboundMeshTrafficPolicy should result in 0 filter chains",
@@ -30,7 +30,7 @@ const (
 	egressTCPProxyStatPrefix      = "egress-tcp-proxy"
 )
 
-func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_listener.Listener, error) {
+func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager, ipv4 bool, ipv6 bool) (*xds_listener.Listener, error) {
 	marshalledConnManager, err := anypb.New(connManager)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingXDSResource)).
@@ -41,7 +41,7 @@ func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_l
 	return &xds_listener.Listener{
 		Name:             prometheusListenerName,
 		TrafficDirection: xds_core.TrafficDirection_INBOUND,
-		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort),
+		Address:          envoy.GetWildcardAddress(ipv4, ipv6, constants.EnvoyPrometheusInboundListenerPort),
 		FilterChains: []*xds_listener.FilterChain{
 			{
 				Filters: []*xds_listener.Filter{
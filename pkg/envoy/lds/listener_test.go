@@ -11,12 +11,44 @@ import (
 )
 
 func TestBuildPrometheusListener(t *testing.T) {
-	a := assert.New(t)
+	testCases := []struct {
+		name               string
+		ipv4               bool
+		ipv6               bool
+		expectedAddress    string
+		expectedIpv4Compat bool
+	}{
+		{
+			name:            "IPv4",
+			ipv4:            true,
+			expectedAddress: "0.0.0.0",
+		},
+		{
+			name:            "IPv6",
+			ipv6:            true,
+			expectedAddress: "::",
+		},
+		{
+			name:               "dual-stack",
+			ipv4:               true,
+			ipv6:               true,
+			expectedAddress:    "::",
+			expectedIpv4Compat: true,
+		},
+	}
 
-	connManager := getPrometheusConnectionManager()
-	listener, err := buildPrometheusListener(connManager)
-	a.NotNil(listener)
-	a.Nil(err)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			connManager := getPrometheusConnectionManager()
+			listener, err := buildPrometheusListener(connManager, tc.ipv4, tc.ipv6)
+			a.NotNil(listener)
+			a.Nil(err)
+			a.Equal(tc.expectedAddress, listener.Address.GetSocketAddress().Address)
+			a.Equal(tc.expectedIpv4Compat, listener.Address.GetSocketAddress().Ipv4Compat)
+		})
+	}
 }
 
 func TestGetFilterMatchPredicateForPorts(t *testing.T) {
@@ -13,8 +13,9 @@ import (
 
 // buildRBACFilter builds an RBAC filter based on SMI TrafficTarget policies.
 // The returned RBAC filter has policies that gives downstream principals full access to the local service.
-func buildRBACFilter(trafficTargets []trafficpolicy.TrafficTargetWithRoutes, trustDomain string) (*xds_listener.Filter, error) {
-	networkRBACPolicy, err := buildInboundRBACPolicies(trafficTargets, trustDomain)
+// Downstream identities are matched on their SPIFFE ID, and also on their legacy principal unless spiffePrincipalsOnly is set.
+func buildRBACFilter(trafficTargets []trafficpolicy.TrafficTargetWithRoutes, trustDomain string, spiffePrincipalsOnly bool) (*xds_listener.Filter, error) {
+	networkRBACPolicy, err := buildInboundRBACPolicies(trafficTargets, trustDomain, spiffePrincipalsOnly)
 	if err != nil {
 		return nil, err
 	}
@@ -33,11 +34,11 @@ func buildRBACFilter(trafficTargets []trafficpolicy.TrafficTargetWithRoutes, tru
 }
 
 // buildInboundRBACPolicies builds the RBAC policies based on allowed principals
-func buildInboundRBACPolicies(trafficTargets []trafficpolicy.TrafficTargetWithRoutes, trustDomain string) (*xds_network_rbac.RBAC, error) {
+func buildInboundRBACPolicies(trafficTargets []trafficpolicy.TrafficTargetWithRoutes, trustDomain string, spiffePrincipalsOnly bool) (*xds_network_rbac.RBAC, error) {
 	rbacPolicies := make(map[string]*xds_rbac.Policy)
 	// Build an RBAC policies based on SMI TrafficTarget policies
 	for _, targetPolicy := range trafficTargets {
-		rbacPolicies[targetPolicy.Name] = buildRBACPolicyFromTrafficTarget(targetPolicy, trustDomain)
+		rbacPolicies[targetPolicy.Name] = buildRBACPolicyFromTrafficTarget(targetPolicy, trustDomain, spiffePrincipalsOnly)
 	}
 
 	// Create an inbound RBAC policy that denies a request by default, unless a policy explicitly allows it
@@ -53,12 +54,14 @@ func buildInboundRBACPolicies(trafficTargets []trafficpolicy.TrafficTargetWithRo
 }
 
 // buildRBACPolicyFromTrafficTarget creates an XDS RBAC policy from the given traffic target policy
-func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes, trustDomain string) *xds_rbac.Policy {
+func buildRBACPolicyFromTrafficTarget(trafficTarget trafficpolicy.TrafficTargetWithRoutes, trustDomain string, spiffePrincipalsOnly bool) *xds_rbac.Policy {
 	pb := &rbac.PolicyBuilder{}
 
 	// Create the list of identities for this policy
 	for _, downstreamIdentity := range trafficTarget.Sources {
-		pb.AddPrincipal(downstreamIdentity.AsPrincipal(trustDomain))
+		for _, principal := range downstreamIdentity.AsPrincipals(trustDomain, spiffePrincipalsOnly) {
+			pb.AddPrincipal(principal)
+		}
 	}
 	// Create the list of permissions for this policy
 	for _, tcpRouteMatch := range trafficTarget.TCPRouteMatches {
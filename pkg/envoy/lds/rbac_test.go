@@ -16,8 +16,9 @@ import (
 
 func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 	testCases := []struct {
-		name          string
-		trafficTarget trafficpolicy.TrafficTargetWithRoutes
+		name                 string
+		trafficTarget        trafficpolicy.TrafficTargetWithRoutes
+		spiffePrincipalsOnly bool
 
 		expectedPolicy *xds_rbac.Policy
 	}{
@@ -41,7 +42,9 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 					},
 				},
 				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
 					rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-3/sa/sa-3"),
 					rbac.GetAuthenticatedPrincipal("sa-3.ns-3.cluster.local"),
 				},
 			},
@@ -74,11 +77,38 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 					rbac.GetDestinationPortPermission(3000),
 				},
 				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
 					rbac.GetAuthenticatedPrincipal("sa-2.ns-2.cluster.local"),
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-3/sa/sa-3"),
 					rbac.GetAuthenticatedPrincipal("sa-3.ns-3.cluster.local"),
 				},
 			},
 		},
+
+		{
+			// Test 3
+			name: "traffic target matching SPIFFE principals only",
+			trafficTarget: trafficpolicy.TrafficTargetWithRoutes{
+				Name:        "ns-1/test-1",
+				Destination: identity.ServiceIdentity("sa-1.ns-1"),
+				Sources: []identity.ServiceIdentity{
+					identity.ServiceIdentity("sa-2.ns-2"),
+				},
+				TCPRouteMatches: nil,
+			},
+			spiffePrincipalsOnly: true,
+
+			expectedPolicy: &xds_rbac.Policy{
+				Permissions: []*xds_rbac.Permission{
+					{
+						Rule: &xds_rbac.Permission_Any{Any: true},
+					},
+				},
+				Principals: []*xds_rbac.Principal{
+					rbac.GetAuthenticatedPrincipal("spiffe://cluster.local/ns/ns-2/sa/sa-2"),
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
@@ -86,7 +116,7 @@ func TestBuildRBACPolicyFromTrafficTarget(t *testing.T) {
 			assert := tassert.New(t)
 
 			// Test the RBAC policies
-			policy := buildRBACPolicyFromTrafficTarget(tc.trafficTarget, "cluster.local")
+			policy := buildRBACPolicyFromTrafficTarget(tc.trafficTarget, "cluster.local", tc.spiffePrincipalsOnly)
 
 			assert.Equal(tc.expectedPolicy, policy)
 		})
@@ -152,7 +182,7 @@ func TestBuildInboundRBACPolicies(t *testing.T) {
 			assert := tassert.New(t)
 
 			// Test the RBAC policies
-			policy, err := buildInboundRBACPolicies(tc.trafficTargets, "")
+			policy, err := buildInboundRBACPolicies(tc.trafficTargets, "", false)
 
 			assert.Equal(tc.expectErr, err != nil)
 			assert.Equal(xds_rbac.RBAC_ALLOW, policy.Rules.Action)
@@ -40,15 +40,32 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, cm *cert
 		}
 	}
 
+	httpFilterExtensions, err := meshCatalog.GetHTTPFilterExtensions(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("error building LDS response: %w", err)
+	}
+
+	// The listeners bind to the wildcard address of the IP families of the proxy. They are not built until these are
+	// known, as listeners of the wrong IP family would be rejected or drop the traffic of the other family.
+	ips, err := meshCatalog.ListIPsForProxy(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up the IP addresses of proxy %s: %w", proxy, err)
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("proxy %s has no IP address yet", proxy)
+	}
+	ipv4, ipv6 := utils.GetIPFamilies(ips)
+
 	// --- OUTBOUND -------------------
 	outboundLis := ListenerBuilder().
 		Name(OutboundListenerName).
 		ProxyIdentity(proxy.Identity).
-		Address(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort).
+		WildcardAddress(ipv4, ipv6, constants.EnvoyOutboundListenerPort).
 		TrafficDirection(xds_core.TrafficDirection_OUTBOUND).
 		PermissiveMesh(meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode).
 		OutboundMeshTrafficPolicy(meshCatalog.GetOutboundMeshTrafficPolicy(proxy.Identity)).
-		ActiveHealthCheck(meshConfig.Spec.FeatureFlags.EnableEnvoyActiveHealthChecks)
+		ActiveHealthCheck(meshConfig.Spec.FeatureFlags.EnableEnvoyActiveHealthChecks).
+		HTTPFilterExtensions(httpFilterExtensions.Outbound)
 
 	if meshConfig.Spec.Traffic.EnableEgress {
 		outboundLis.PermissiveEgress(true)
@@ -83,14 +100,16 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, cm *cert
 		Name(InboundListenerName).
 		ProxyIdentity(proxy.Identity).
 		TrustDomain(cm.GetTrustDomain()).
-		Address(constants.WildcardIPAddr, constants.EnvoyInboundListenerPort).
+		IdentityMatchMode(utils.GetIdentityMatchMode(meshConfig)).
+		WildcardAddress(ipv4, ipv6, constants.EnvoyInboundListenerPort).
 		TrafficDirection(xds_core.TrafficDirection_INBOUND).
 		DefaultInboundListenerFilters().
 		PermissiveMesh(meshConfig.Spec.Traffic.EnablePermissiveTrafficPolicyMode).
 		InboundMeshTrafficPolicy(meshCatalog.GetInboundMeshTrafficPolicy(proxy.Identity, svcList)).
 		IngressTrafficPolicies(meshCatalog.GetIngressTrafficPolicies(svcList)).
 		ActiveHealthCheck(meshConfig.Spec.FeatureFlags.EnableEnvoyActiveHealthChecks).
-		SidecarSpec(meshConfig.Spec.Sidecar)
+		SidecarSpec(meshConfig.Spec.Sidecar).
+		HTTPFilterExtensions(httpFilterExtensions.Inbound)
 
 	trafficTargets, err := meshCatalog.ListInboundTrafficTargetsWithRoutes(proxy.Identity)
 	if err != nil {
@@ -121,7 +140,7 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, cm *cert
 	} else if enabled {
 		// Build Prometheus listener config
 		prometheusConnManager := getPrometheusConnectionManager()
-		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
+		if prometheusListener, err := buildPrometheusListener(prometheusConnManager, ipv4, ipv6); err != nil {
 			log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error building Prometheus listener")
 		} else {
 			ldsResources = append(ldsResources, prometheusListener)
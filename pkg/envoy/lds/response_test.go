@@ -1,6 +1,8 @@
 package lds
 
 import (
+	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -82,6 +84,8 @@ func TestNewResponse(t *testing.T) {
 		},
 	}).AnyTimes()
 	provider.EXPECT().ListServicesForProxy(proxy).Return([]service.MeshService{tests.BookbuyerService}, nil).AnyTimes()
+	provider.EXPECT().ListHTTPFilterExtensionsForProxy(proxy).Return(nil, nil).AnyTimes()
+	provider.EXPECT().ListIPsForProxy(proxy).Return([]net.IP{net.ParseIP("10.0.0.5"), net.ParseIP("fd00::5")}, nil).AnyTimes()
 
 	meshCatalog := catalog.NewMeshCatalog(
 		mockMeshSpec,
@@ -101,6 +105,14 @@ func TestNewResponse(t *testing.T) {
 	// 3. Prometheus listener (inbound-prometheus-listener)
 	assert.Len(resources, 3)
 
+	// The listeners of the dual-stack proxy accept IPv4 connections as IPv4-mapped addresses
+	for _, resource := range resources {
+		listener, ok := resource.(*xds_listener.Listener)
+		assert.True(ok)
+		assert.Equal("::", listener.Address.GetSocketAddress().Address)
+		assert.True(listener.Address.GetSocketAddress().Ipv4Compat)
+	}
+
 	// validating outbound listener
 	listener, ok := resources[0].(*xds_listener.Listener)
 	assert.True(ok)
@@ -130,8 +142,8 @@ func TestNewResponse(t *testing.T) {
 	assert.Equal(listener.Name, InboundListenerName)
 	assert.Equal(listener.TrafficDirection, xds_core.TrafficDirection_INBOUND)
 	assert.Len(listener.ListenerFilters, 2)
-	assert.Equal(listener.ListenerFilters[0].Name, envoy.TLSInspectorFilterName)
-	assert.Equal(listener.ListenerFilters[1].Name, envoy.OriginalDstFilterName)
+	assert.Equal(listener.ListenerFilters[0].Name, envoy.OriginalDstFilterName)
+	assert.Equal(listener.ListenerFilters[1].Name, envoy.TLSInspectorFilterName)
 	assert.NotNil(listener.FilterChains)
 	// There is 1 filter chains configured on the inbound-listner based on the configuration:
 	// 1. Filter chanin for bookbuyer
@@ -144,4 +156,16 @@ func TestNewResponse(t *testing.T) {
 	assert.Equal(listener.TrafficDirection, xds_core.TrafficDirection_INBOUND)
 	assert.NotNil(listener.FilterChains)
 	assert.Len(listener.FilterChains, 1)
+
+	// The listeners are not built until the IP families of the proxy are known
+	for _, ipsErr := range []error{fmt.Errorf("pod not found"), nil} {
+		unknownProxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New(tests.BookbuyerServiceAccountName, tests.Namespace), nil, 1)
+		provider.EXPECT().ListServicesForProxy(unknownProxy).Return([]service.MeshService{tests.BookbuyerService}, nil).AnyTimes()
+		provider.EXPECT().ListHTTPFilterExtensionsForProxy(unknownProxy).Return(nil, nil).AnyTimes()
+		provider.EXPECT().ListIPsForProxy(unknownProxy).Return(nil, ipsErr).AnyTimes()
+
+		resources, err = NewResponse(meshCatalog, unknownProxy, cm, nil)
+		assert.Error(err)
+		assert.Nil(resources)
+	}
 }
@@ -26,6 +26,7 @@ type listenerBuilder struct {
 	address                   *xds_core.Address
 	trafficDirection          xds_core.TrafficDirection
 	trustDomain               string
+	spiffePrincipalsOnly      bool
 	permissiveMesh            bool
 	permissiveEgress          bool
 	outboundMeshTrafficPolicy *trafficpolicy.OutboundMeshTrafficPolicy
@@ -34,6 +35,7 @@ type listenerBuilder struct {
 	ingressTrafficPolicies    []*trafficpolicy.IngressTrafficPolicy
 	trafficTargets            []trafficpolicy.TrafficTargetWithRoutes
 	wasmStatsHeaders          map[string]string
+	httpFilterExtensions      trafficpolicy.HTTPFilters
 	httpTracingEndpoint       string
 	extAuthzConfig            *auth.ExtAuthConfig
 	activeHealthCheck         bool
@@ -47,6 +49,8 @@ type httpConnManagerBuilder struct {
 	statsPrefix         string
 	routeConfigName     string
 	filters             []*xds_hcm.HttpFilter
+	firstFilters        []*xds_hcm.HttpFilter
+	lastFilters         []*xds_hcm.HttpFilter
 	tracing             *xds_hcm.HttpConnectionManager_Tracing
 	localReplyConfig    *xds_hcm.LocalReplyConfig
 	routerFilter        *xds_hcm.HttpFilter
@@ -60,12 +64,13 @@ type tcpProxyBuilder struct {
 }
 
 type filterBuilder struct {
-	statsPrefix        string
-	withRBAC           bool
-	trustDomain        string
-	trafficTargets     []trafficpolicy.TrafficTargetWithRoutes
-	tcpLocalRateLimit  *policyv1alpha1.TCPLocalRateLimitSpec
-	tcpGlobalRateLimit *policyv1alpha1.TCPGlobalRateLimitSpec
-	hcmBuilder         *httpConnManagerBuilder
-	tcpProxyBuilder    *tcpProxyBuilder
+	statsPrefix          string
+	withRBAC             bool
+	trustDomain          string
+	spiffePrincipalsOnly bool
+	trafficTargets       []trafficpolicy.TrafficTargetWithRoutes
+	tcpLocalRateLimit    *policyv1alpha1.TCPLocalRateLimitSpec
+	tcpGlobalRateLimit   *policyv1alpha1.TCPGlobalRateLimitSpec
+	hcmBuilder           *httpConnManagerBuilder
+	tcpProxyBuilder      *tcpProxyBuilder
 }
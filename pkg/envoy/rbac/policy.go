@@ -88,7 +88,9 @@ func (p *PolicyBuilder) AddAllowedDestinationPort(port uint16) {
 	p.allowedPorts = append(p.allowedPorts, uint32(port))
 }
 
-// GetAuthenticatedPrincipal returns an authenticated RBAC principal object for the given principal
+// GetAuthenticatedPrincipal returns an authenticated RBAC principal object for the given principal.
+// Envoy matches the principal name against the URI SAN of the peer certificate if present, so SPIFFE IDs
+// match X.509-SVIDs, and otherwise against the DNS SAN and then the subject of the certificate.
 func GetAuthenticatedPrincipal(principalName string) *xds_rbac.Principal {
 	return &xds_rbac.Principal{
 		Identifier: &xds_rbac.Principal_Authenticated_{
@@ -1,11 +1,19 @@
 package sds
 
 import (
+	"fmt"
+	"path"
+	"sort"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/types/known/anypb"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -21,6 +29,13 @@ type SecretsBuilder struct {
 
 	trustDomain string
 
+	// spiffeSANsOnly restricts SAN validation to the SPIFFE ID of upstream identities
+	spiffeSANsOnly bool
+
+	// workloadKeyGeneration references the certificate and private key written by the osm-cert-agent container of the
+	// proxy's pod, rather than inlining the service certificate
+	workloadKeyGeneration bool
+
 	// identities, used for SAN matches, mapped to the name of the secret. Currently only used for outbound secrets.
 	identitiesForSecrets map[string][]identity.ServiceIdentity
 }
@@ -48,6 +63,19 @@ func (b *SecretsBuilder) SetTrustDomain(trustDomain string) *SecretsBuilder {
 	return b
 }
 
+// SetIdentityMatchMode sets how upstream identities are matched in the SANs of their certificates.
+func (b *SecretsBuilder) SetIdentityMatchMode(mode configv1alpha2.IdentityMatchMode) *SecretsBuilder {
+	b.spiffeSANsOnly = mode == configv1alpha2.IdentityMatchModeSPIFFE
+	return b
+}
+
+// SetWorkloadKeyGeneration sets whether the private key of the proxy is generated by the osm-cert-agent container
+// of its pod.
+func (b *SecretsBuilder) SetWorkloadKeyGeneration(enabled bool) *SecretsBuilder {
+	b.workloadKeyGeneration = enabled
+	return b
+}
+
 // SetServiceIdentitiesForService setes the list of identities for each service, to be used for SAN validation.
 func (b *SecretsBuilder) SetServiceIdentitiesForService(serviceIdentitiesForServices map[service.MeshService][]identity.ServiceIdentity) *SecretsBuilder {
 	b.identitiesForSecrets = make(map[string][]identity.ServiceIdentity)
@@ -58,9 +86,19 @@ func (b *SecretsBuilder) SetServiceIdentitiesForService(serviceIdentitiesForServ
 }
 
 // Build generates SDS Secret Resources based on requested certs in the DiscoveryRequest
-func (b *SecretsBuilder) Build() []*xds_auth.Secret {
+func (b *SecretsBuilder) Build() ([]*xds_auth.Secret, error) {
 	var sdsResources = make([]*xds_auth.Secret, 0, len(b.identitiesForSecrets))
 
+	// Peer certificates are validated by the SPIFFE validator when trust domains are federated, each root only
+	// validating the SPIFFE IDs of its trust domain
+	var spiffeValidator *xds_core.TypedExtensionConfig
+	if bundles := b.serviceCert.GetTrustDomainBundles(); len(bundles) > 0 {
+		var err error
+		if spiffeValidator, err = getSPIFFEValidatorConfig(bundles); err != nil {
+			return nil, err
+		}
+	}
+
 	sdsResources = append(sdsResources, b.buildServiceSecret())
 	// SAN validation should not be performed by the root validation certificate used by the upstream server
 	// to validate a downstream client. This is because of the following:
@@ -69,17 +107,20 @@ func (b *SecretsBuilder) Build() []*xds_auth.Secret {
 	// 2. The same root validation certificate is used to validate both in-mesh and ingress downstreams.
 	// For these reasons, we only perform SAN validation of peer certificates on downstream clients (ie. outbound SAN
 	// validation).
-	sdsResources = append(sdsResources, b.buildSecret(secrets.NameForMTLSInbound, nil))
+	sdsResources = append(sdsResources, b.buildSecret(secrets.NameForMTLSInbound, nil, spiffeValidator))
 
 	for name, identites := range b.identitiesForSecrets {
-		sdsResources = append(sdsResources, b.buildSecret(name, identites))
+		sdsResources = append(sdsResources, b.buildSecret(name, identites, spiffeValidator))
 	}
-	return sdsResources
+	return sdsResources, nil
 }
 
 // buildServiceCertSecret creates the struct with certificates for the service, which the
 // connected Envoy proxy belongs to.
 func (b *SecretsBuilder) buildServiceSecret() *xds_auth.Secret {
+	if b.workloadKeyGeneration {
+		return b.buildWorkloadServiceSecret()
+	}
 	return &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name in the Envoy yaml config
 		Name: secrets.NameForIdentity(b.proxy.Identity),
@@ -100,29 +141,117 @@ func (b *SecretsBuilder) buildServiceSecret() *xds_auth.Secret {
 	}
 }
 
-func (b *SecretsBuilder) buildSecret(name string, allowedIdentities []identity.ServiceIdentity) *xds_auth.Secret {
+// buildWorkloadServiceSecret creates the secret referencing the certificate and private key written by the
+// osm-cert-agent container to the volume shared with the Envoy sidecar. Envoy reloads them when the agent renews them,
+// the private key never leaving the pod.
+func (b *SecretsBuilder) buildWorkloadServiceSecret() *xds_auth.Secret {
+	return &xds_auth.Secret{
+		Name: secrets.NameForIdentity(b.proxy.Identity),
+		Type: &xds_auth.Secret_TlsCertificate{
+			TlsCertificate: &xds_auth.TlsCertificate{
+				CertificateChain: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_Filename{
+						Filename: path.Join(constants.CertAgentCertDir, constants.CertAgentCertFile),
+					},
+				},
+				PrivateKey: &xds_core.DataSource{
+					Specifier: &xds_core.DataSource_Filename{
+						Filename: path.Join(constants.CertAgentCertDir, constants.CertAgentKeyFile),
+					},
+				},
+				WatchedDirectory: &xds_core.WatchedDirectory{
+					Path: constants.CertAgentCertDir,
+				},
+			},
+		},
+	}
+}
+
+// buildSecret creates the validation context secret with the given name, validating peer certificates with the given
+// SPIFFE validator if any, or with the trusted CAs of the service certificate otherwise
+func (b *SecretsBuilder) buildSecret(name string, allowedIdentities []identity.ServiceIdentity, spiffeValidator *xds_core.TypedExtensionConfig) *xds_auth.Secret {
 	secret := &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
 		Name: name,
 		Type: &xds_auth.Secret_ValidationContext{
 			ValidationContext: &xds_auth.CertificateValidationContext{
-				TrustedCa: &xds_core.DataSource{
-					Specifier: &xds_core.DataSource_InlineBytes{
-						InlineBytes: b.serviceCert.GetTrustedCAs(),
-					},
-				},
+				MatchTypedSubjectAltNames: getSubjectAltNamesFromSvcIdentities(allowedIdentities, b.trustDomain, b.spiffeSANsOnly),
 			},
 		},
 	}
-	secret.GetValidationContext().MatchTypedSubjectAltNames = getSubjectAltNamesFromSvcIdentities(allowedIdentities, b.trustDomain)
+	if spiffeValidator != nil {
+		secret.GetValidationContext().CustomValidatorConfig = spiffeValidator
+		return secret
+	}
+
+	secret.GetValidationContext().TrustedCa = &xds_core.DataSource{
+		Specifier: &xds_core.DataSource_InlineBytes{
+			InlineBytes: b.serviceCert.GetTrustedCAs(),
+		},
+	}
+
+	// Peer certificates are checked against the revocation lists of their issuers when certificates are revoked.
+	// Only the peer's own certificate is checked, since the revocation lists are issued by the issuers of the
+	// certificates, and not by the roots of intermediate issuers.
+	if revocationLists := b.serviceCert.GetRevocationLists(); len(revocationLists) > 0 {
+		secret.GetValidationContext().Crl = &xds_core.DataSource{
+			Specifier: &xds_core.DataSource_InlineBytes{
+				InlineBytes: revocationLists,
+			},
+		}
+		secret.GetValidationContext().OnlyVerifyLeafCertCrl = true
+	}
 	return secret
 }
 
+// getSPIFFEValidatorConfig returns the configuration of the SPIFFE certificate validator validating peer certificates
+// with the roots of the trust domain of their SPIFFE ID
+func getSPIFFEValidatorConfig(bundles map[string]pem.RootCertificate) (*xds_core.TypedExtensionConfig, error) {
+	trustDomains := make([]string, 0, len(bundles))
+	for trustDomain := range bundles {
+		trustDomains = append(trustDomains, trustDomain)
+	}
+	sort.Strings(trustDomains)
+
+	config := &xds_auth.SPIFFECertValidatorConfig{}
+	for _, trustDomain := range trustDomains {
+		config.TrustDomains = append(config.TrustDomains, &xds_auth.SPIFFECertValidatorConfig_TrustDomain{
+			Name: trustDomain,
+			TrustBundle: &xds_core.DataSource{
+				Specifier: &xds_core.DataSource_InlineBytes{
+					InlineBytes: bundles[trustDomain],
+				},
+			},
+		})
+	}
+
+	typedConfig, err := anypb.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling the SPIFFE certificate validator config: %w", err)
+	}
+	return &xds_core.TypedExtensionConfig{
+		Name:        spiffeCertValidatorName,
+		TypedConfig: typedConfig,
+	}, nil
+}
+
 // Note: ServiceIdentity must be in the format "name.namespace" [https://github.com/openservicemesh/osm/issues/3188]
-func getSubjectAltNamesFromSvcIdentities(serviceIdentities []identity.ServiceIdentity, trustDomain string) []*xds_auth.SubjectAltNameMatcher {
+// Identities are matched on the SPIFFE ID in the URI SAN, and also on the legacy name in the DNS SAN unless spiffeOnly is set.
+func getSubjectAltNamesFromSvcIdentities(serviceIdentities []identity.ServiceIdentity, trustDomain string, spiffeOnly bool) []*xds_auth.SubjectAltNameMatcher {
 	var matchSANs []*xds_auth.SubjectAltNameMatcher
 
 	for _, si := range serviceIdentities {
+		matchSANs = append(matchSANs, &xds_auth.SubjectAltNameMatcher{
+			SanType: xds_auth.SubjectAltNameMatcher_URI,
+			Matcher: &xds_matcher.StringMatcher{
+				MatchPattern: &xds_matcher.StringMatcher_Exact{
+					Exact: si.AsSPIFFEID(trustDomain),
+				},
+			},
+		})
+		if spiffeOnly {
+			continue
+		}
 		match := xds_auth.SubjectAltNameMatcher{
 			SanType: xds_auth.SubjectAltNameMatcher_DNS,
 			Matcher: &xds_matcher.StringMatcher{
@@ -2,6 +2,7 @@ package sds
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
@@ -10,6 +11,8 @@ import (
 	tassert "github.com/stretchr/testify/assert"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -57,8 +60,11 @@ func TestSecretsBuilder(t *testing.T) {
 			},
 			// expectations
 			expectedSANs: map[string][]string{
-				secrets.NameForUpstreamService("service-2", "ns-2"): {"sa-2.ns-2.cluster.local", "sa-3.ns-2.cluster.local"},
-				secrets.NameForUpstreamService("service-3", "ns-4"): {"sa-3.ns-3.cluster.local"},
+				secrets.NameForUpstreamService("service-2", "ns-2"): {
+					"spiffe://cluster.local/ns/ns-2/sa/sa-2", "sa-2.ns-2.cluster.local",
+					"spiffe://cluster.local/ns/ns-2/sa/sa-3", "sa-3.ns-2.cluster.local",
+				},
+				secrets.NameForUpstreamService("service-3", "ns-4"): {"spiffe://cluster.local/ns/ns-3/sa/sa-3", "sa-3.ns-3.cluster.local"},
 			},
 		},
 		// Test case 2 end -------------------------------
@@ -79,7 +85,8 @@ func TestSecretsBuilder(t *testing.T) {
 
 			builder.SetServiceIdentitiesForService(tc.serviceIdentitiesForService)
 
-			sdsSecrets := builder.Build()
+			sdsSecrets, err := builder.Build()
+			assert.NoError(err)
 			assert.Len(sdsSecrets, 2+len(tc.serviceIdentitiesForService))
 
 			serviceSecret := sdsSecrets[0]
@@ -99,19 +106,134 @@ func TestSecretsBuilder(t *testing.T) {
 	}
 }
 
+func TestSecretsBuilderRevocationLists(t *testing.T) {
+	testCases := []struct {
+		name            string
+		revocationLists []byte
+	}{
+		{
+			name: "no revoked certificates",
+		},
+		{
+			name:            "revoked certificates",
+			revocationLists: []byte("crl"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			cert := &certificate.Certificate{
+				CertChain:       []byte("foo"),
+				PrivateKey:      []byte("foo"),
+				IssuingCA:       []byte("foo"),
+				TrustedCAs:      []byte("foo"),
+				RevocationLists: tc.revocationLists,
+			}
+			proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("sa-1", "ns-1"), nil, 1)
+			builder := NewBuilder().SetProxy(proxy).SetProxyCert(cert).SetTrustDomain("cluster.local")
+			builder.SetServiceIdentitiesForService(map[service.MeshService][]identity.ServiceIdentity{
+				{Name: "service-2", Namespace: "ns-2"}: {identity.New("sa-2", "ns-2")},
+			})
+
+			sdsSecrets, err := builder.Build()
+			assert.NoError(err)
+			assert.Len(sdsSecrets, 3)
+			for _, secret := range sdsSecrets[1:] {
+				validationContext := secret.GetValidationContext()
+				assert.Equal(tc.revocationLists, validationContext.GetCrl().GetInlineBytes())
+				assert.Equal(len(tc.revocationLists) > 0, validationContext.GetOnlyVerifyLeafCertCrl())
+			}
+		})
+	}
+}
+
+func TestSecretsBuilderFederatedTrustDomains(t *testing.T) {
+	assert := tassert.New(t)
+	cert := &certificate.Certificate{
+		CertChain:  []byte("foo"),
+		PrivateKey: []byte("foo"),
+		IssuingCA:  []byte("local"),
+		TrustedCAs: []byte("local"),
+		TrustDomainBundles: map[string]pem.RootCertificate{
+			"cluster.local": []byte("local"),
+			"west.local":    []byte("west"),
+		},
+	}
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("sa-1", "ns-1"), nil, 1)
+	builder := NewBuilder().SetProxy(proxy).SetProxyCert(cert).SetTrustDomain("cluster.local")
+	builder.SetServiceIdentitiesForService(map[service.MeshService][]identity.ServiceIdentity{
+		{Name: "service-2", Namespace: "ns-2"}: {identity.New("sa-2", "ns-2")},
+	})
+
+	sdsSecrets, err := builder.Build()
+	assert.NoError(err)
+	assert.Len(sdsSecrets, 3)
+
+	// Peer certificates are only validated by the roots of the trust domain of their SPIFFE ID
+	for _, secret := range sdsSecrets[1:] {
+		validationContext := secret.GetValidationContext()
+		assert.Nil(validationContext.GetTrustedCa())
+		assert.Equal(spiffeCertValidatorName, validationContext.GetCustomValidatorConfig().GetName())
+
+		config := &xds_auth.SPIFFECertValidatorConfig{}
+		assert.NoError(validationContext.GetCustomValidatorConfig().GetTypedConfig().UnmarshalTo(config))
+		assert.Len(config.TrustDomains, 2)
+		assert.Equal("cluster.local", config.TrustDomains[0].Name)
+		assert.Equal([]byte("local"), config.TrustDomains[0].TrustBundle.GetInlineBytes())
+		assert.Equal("west.local", config.TrustDomains[1].Name)
+		assert.Equal([]byte("west"), config.TrustDomains[1].TrustBundle.GetInlineBytes())
+	}
+}
+
+func TestSecretsBuilderWorkloadKeyGeneration(t *testing.T) {
+	assert := tassert.New(t)
+	cert := &certificate.Certificate{
+		IssuingCA:  []byte("foo"),
+		TrustedCAs: []byte("foo"),
+	}
+	proxy := envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("sa-1", "ns-1"), nil, 1)
+	builder := NewBuilder().SetProxy(proxy).SetProxyCert(cert).SetTrustDomain("cluster.local").SetWorkloadKeyGeneration(true)
+
+	sdsSecrets, err := builder.Build()
+	assert.NoError(err)
+	assert.Len(sdsSecrets, 2)
+
+	// The certificate and private key are read from the volume the osm-cert-agent container writes them to
+	tlsCert := sdsSecrets[0].GetTlsCertificate()
+	assert.Equal(secrets.NameForIdentity(proxy.Identity), sdsSecrets[0].GetName())
+	assert.Equal(filepath.Join(constants.CertAgentCertDir, constants.CertAgentCertFile), tlsCert.GetCertificateChain().GetFilename())
+	assert.Equal(filepath.Join(constants.CertAgentCertDir, constants.CertAgentKeyFile), tlsCert.GetPrivateKey().GetFilename())
+	assert.Equal(constants.CertAgentCertDir, tlsCert.GetWatchedDirectory().GetPath())
+
+	// The trusted CAs are still sent by osm-controller
+	assert.Equal([]byte("foo"), sdsSecrets[1].GetValidationContext().GetTrustedCa().GetInlineBytes())
+}
+
 func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 	type testCase struct {
 		serviceIdentities   []identity.ServiceIdentity
+		spiffeOnly          bool
 		expectedSANMatchers []*xds_auth.SubjectAltNameMatcher
 	}
 
+	serviceIdentities := []identity.ServiceIdentity{
+		identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
+		identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(),
+	}
+
 	testCases := []testCase{
 		{
-			serviceIdentities: []identity.ServiceIdentity{
-				identity.K8sServiceAccount{Name: "sa-1", Namespace: "ns-1"}.ToServiceIdentity(),
-				identity.K8sServiceAccount{Name: "sa-2", Namespace: "ns-2"}.ToServiceIdentity(),
-			},
+			serviceIdentities: serviceIdentities,
 			expectedSANMatchers: []*xds_auth.SubjectAltNameMatcher{
+				{
+					SanType: xds_auth.SubjectAltNameMatcher_URI,
+					Matcher: &xds_matcher.StringMatcher{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: "spiffe://cluster.local/ns/ns-1/sa/sa-1",
+						},
+					},
+				},
 				{
 					SanType: xds_auth.SubjectAltNameMatcher_DNS,
 					Matcher: &xds_matcher.StringMatcher{
@@ -120,6 +242,14 @@ func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 						},
 					},
 				},
+				{
+					SanType: xds_auth.SubjectAltNameMatcher_URI,
+					Matcher: &xds_matcher.StringMatcher{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: "spiffe://cluster.local/ns/ns-2/sa/sa-2",
+						},
+					},
+				},
 				{
 					SanType: xds_auth.SubjectAltNameMatcher_DNS,
 					Matcher: &xds_matcher.StringMatcher{
@@ -130,13 +260,35 @@ func TestGetSubjectAltNamesFromSvcAccount(t *testing.T) {
 				},
 			},
 		},
+		{
+			serviceIdentities: serviceIdentities,
+			spiffeOnly:        true,
+			expectedSANMatchers: []*xds_auth.SubjectAltNameMatcher{
+				{
+					SanType: xds_auth.SubjectAltNameMatcher_URI,
+					Matcher: &xds_matcher.StringMatcher{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: "spiffe://cluster.local/ns/ns-1/sa/sa-1",
+						},
+					},
+				},
+				{
+					SanType: xds_auth.SubjectAltNameMatcher_URI,
+					Matcher: &xds_matcher.StringMatcher{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: "spiffe://cluster.local/ns/ns-2/sa/sa-2",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Testing test case %d", i), func(t *testing.T) {
 			assert := tassert.New(t)
 
-			actual := getSubjectAltNamesFromSvcIdentities(tc.serviceIdentities, "cluster.local")
+			actual := getSubjectAltNamesFromSvcIdentities(tc.serviceIdentities, "cluster.local", tc.spiffeOnly)
 			assert.ElementsMatch(actual, tc.expectedSANMatchers)
 		})
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy/registry"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
 // NewResponse creates a new Secrets Discovery Response.
@@ -16,10 +17,23 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, certMana
 	log.Info().Str("proxy", proxy.String()).Msg("Composing SDS Discovery Response")
 
 	// sdsBuilder: builds the Secret Discovery Response
-	builder := NewBuilder().SetProxy(proxy).SetTrustDomain(certManager.GetTrustDomain())
+	builder := NewBuilder().SetProxy(proxy).SetTrustDomain(certManager.GetTrustDomain()).
+		SetIdentityMatchMode(utils.GetIdentityMatchMode(meshCatalog.GetMeshConfig()))
 
-	// 1. Issue a service certificate for this proxy
-	cert, err := certManager.IssueCertificate(certificate.ForServiceIdentity(proxy.Identity))
+	// 1. Issue a service certificate for this proxy. When the private key of the proxy is generated by its pod, only
+	// the trusted CAs of its identity are issued, its certificate being signed for the pod's certificate request.
+	workloadKeyGeneration, err := meshCatalog.IsWorkloadKeyGenerationEnabled(proxy)
+	if err != nil {
+		log.Error().Err(err).Str("proxy", proxy.String()).Msg("Error checking whether the private key of the proxy is generated by its pod")
+		return nil, err
+	}
+	issueOption := certificate.ForServiceIdentity
+	if workloadKeyGeneration {
+		issueOption = certificate.ForServiceIdentityTrust
+	}
+	builder.SetWorkloadKeyGeneration(workloadKeyGeneration)
+
+	cert, err := certManager.IssueCertificate(issueOption(proxy.Identity))
 	if err != nil {
 		log.Error().Err(err).Str("proxy", proxy.String()).Msgf("Error issuing a certificate for proxy")
 		return nil, err
@@ -41,7 +55,11 @@ func NewResponse(meshCatalog catalog.MeshCataloger, proxy *envoy.Proxy, certMana
 
 	// Get SDS Secret Resources based on requested certs in the DiscoveryRequest
 	var sdsResources = make([]types.Resource, 0, len(serviceIdentitiesForOutboundServices)+2)
-	for _, envoyProto := range builder.Build() {
+	sdsSecrets, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	for _, envoyProto := range sdsSecrets {
 		sdsResources = append(sdsResources, envoyProto)
 	}
 	return sdsResources, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	tassert "github.com/stretchr/testify/assert"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/catalog"
 	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -34,6 +35,7 @@ func TestNewResponse(t *testing.T) {
 		name                        string
 		serviceIdentitiesForService map[service.MeshService][]identity.ServiceIdentity
 		trustDomain                 string
+		workloadKeyGeneration       bool
 		expectedCertToSAN           map[string][]string
 	}{
 		{
@@ -56,16 +58,27 @@ func TestNewResponse(t *testing.T) {
 			},
 			expectedCertToSAN: map[string][]string{
 				secrets.NameForUpstreamService("svc-1", "ns-1"): {
+					"spiffe://cluster.local/ns/ns-1/sa/sa-1",
 					"sa-1.ns-1.cluster.local",
+					"spiffe://cluster.local/ns/ns-1/sa/sa-2",
 					"sa-2.ns-1.cluster.local",
 				},
 				secrets.NameForUpstreamService("svc-A", "ns-A"): {
+					"spiffe://cluster.local/ns/ns-A/sa/sa-A",
 					"sa-A.ns-A.cluster.local",
 				},
 				secrets.NameForIdentity(proxySvcID): nil,
 				secrets.NameForMTLSInbound:          nil,
 			},
 		},
+		{
+			name:                  "private key generated by the pod",
+			workloadKeyGeneration: true,
+			expectedCertToSAN: map[string][]string{
+				secrets.NameForIdentity(proxySvcID): nil,
+				secrets.NameForMTLSInbound:          nil,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -82,6 +95,8 @@ func TestNewResponse(t *testing.T) {
 				meshCatalog.EXPECT().ListServiceIdentitiesForService(svc.Name, svc.Namespace).Return(identities, nil)
 			}
 			meshCatalog.EXPECT().ListOutboundServicesForIdentity(proxy.Identity).Return(services)
+			meshCatalog.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{}).AnyTimes()
+			meshCatalog.EXPECT().IsWorkloadKeyGenerationEnabled(proxy).Return(tc.workloadKeyGeneration, nil)
 
 			// ----- Test with an properly configured proxy
 			resources, err := NewResponse(meshCatalog, proxy, certManager, nil)
@@ -101,6 +116,11 @@ func TestNewResponse(t *testing.T) {
 				certNames = append(certNames, secret.Name)
 
 				assert.Contains(tc.expectedCertToSAN, secret.Name)
+				if tlsCert := secret.GetTlsCertificate(); tlsCert != nil {
+					// The private key generated by the pod is read from its volume, and never sent by osm-controller
+					assert.Equal(tc.workloadKeyGeneration, tlsCert.GetPrivateKey().GetFilename() != "")
+					assert.Equal(!tc.workloadKeyGeneration, len(tlsCert.GetPrivateKey().GetInlineBytes()) > 0)
+				}
 				if len(tc.expectedCertToSAN[secret.Name]) == 0 {
 					continue // nothing more to do.
 				}
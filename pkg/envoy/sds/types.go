@@ -8,3 +8,8 @@ import (
 var (
 	log = logger.New("envoy/sds")
 )
+
+const (
+	// spiffeCertValidatorName is the name of Envoy's SPIFFE certificate validator extension
+	spiffeCertValidatorName = "envoy.tls.cert_validator.spiffe"
+)
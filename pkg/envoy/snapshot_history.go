@@ -0,0 +1,56 @@
+package envoy
+
+import (
+	"time"
+)
+
+// SnapshotRecord describes a snapshot of the xDS configuration sent to a proxy.
+type SnapshotRecord struct {
+	// Version is the version of the snapshot, as tracked by the ADS server per proxy.
+	Version uint64 `json:"version"`
+
+	// CreatedAt is the time the snapshot was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Triggers are the event kinds that triggered the snapshot to be generated.
+	Triggers []string `json:"triggers"`
+}
+
+// XDSDiff is the difference in the xDS configuration of a proxy between two snapshots.
+type XDSDiff struct {
+	// From is the snapshot the diff is computed from.
+	From SnapshotRecord `json:"from"`
+
+	// To is the snapshot the diff is computed to.
+	To SnapshotRecord `json:"to"`
+
+	// Types is the diff of the resources of each xDS type, keyed by the short name of the type.
+	Types map[string]XDSTypeDiff `json:"types"`
+}
+
+// XDSTypeDiff is the difference in the resources of a single xDS type between two snapshots.
+type XDSTypeDiff struct {
+	// Added are the names of the resources present only in the newer snapshot.
+	Added []string `json:"added,omitempty"`
+
+	// Removed are the names of the resources present only in the older snapshot.
+	Removed []string `json:"removed,omitempty"`
+
+	// Modified are the resources present in both snapshots with a different configuration.
+	Modified []XDSResourceDiff `json:"modified,omitempty"`
+}
+
+// XDSResourceDiff is the difference in the configuration of a single resource between two snapshots.
+type XDSResourceDiff struct {
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Diff is a human readable diff of the resource's configuration, where lines prefixed with '-' are from the
+	// older snapshot and lines prefixed with '+' are from the newer snapshot.
+	Diff string `json:"diff"`
+}
+
+// IsEmpty returns true if there is no difference in the resources of the type.
+func (d XDSTypeDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
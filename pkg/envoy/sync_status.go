@@ -0,0 +1,141 @@
+package envoy
+
+import (
+	"strings"
+	"time"
+)
+
+// SyncState is the state of the configuration of a given xDS type on a proxy, as seen by the control plane.
+type SyncState string
+
+const (
+	// SyncStateSynced indicates the proxy ACKed the latest configuration sent to it.
+	SyncStateSynced SyncState = "SYNCED"
+
+	// SyncStateStale indicates the proxy has not yet ACKed the latest configuration sent to it.
+	SyncStateStale SyncState = "STALE"
+
+	// SyncStateNacked indicates the proxy rejected the latest configuration sent to it.
+	SyncStateNacked SyncState = "NACKED"
+)
+
+// XDSSyncStatus tracks the last configuration sent to a proxy for a single xDS type, and the proxy's response to it.
+type XDSSyncStatus struct {
+	// LastSentVersion is the version of the last DiscoveryResponse sent to the proxy.
+	LastSentVersion string `json:"lastSentVersion,omitempty"`
+
+	// LastSentNonce is the nonce of the last DiscoveryResponse sent to the proxy.
+	LastSentNonce string `json:"lastSentNonce,omitempty"`
+
+	// LastSentAt is the time the last DiscoveryResponse was sent to the proxy.
+	LastSentAt time.Time `json:"lastSentAt,omitempty"`
+
+	// LastAckedVersion is the last version the proxy ACKed.
+	LastAckedVersion string `json:"lastAckedVersion,omitempty"`
+
+	// LastAckedAt is the time the proxy last ACKed a DiscoveryResponse.
+	LastAckedAt time.Time `json:"lastAckedAt,omitempty"`
+
+	// LastNackedNonce is the nonce of the last DiscoveryResponse the proxy rejected.
+	LastNackedNonce string `json:"lastNackedNonce,omitempty"`
+
+	// LastNackedAt is the time the proxy last rejected a DiscoveryResponse.
+	LastNackedAt time.Time `json:"lastNackedAt,omitempty"`
+
+	// LastNackError is the error message the proxy reported when it last rejected a DiscoveryResponse.
+	LastNackError string `json:"lastNackError,omitempty"`
+
+	// Nacked is set when the proxy rejected the last DiscoveryResponse sent to it, and is cleared once
+	// it ACKs a subsequent one.
+	Nacked bool `json:"nacked"`
+}
+
+// State returns the SyncState corresponding to the status.
+func (s XDSSyncStatus) State() SyncState {
+	switch {
+	case s.Nacked:
+		return SyncStateNacked
+	case s.LastSentVersion != "" && s.LastSentVersion == s.LastAckedVersion:
+		return SyncStateSynced
+	default:
+		return SyncStateStale
+	}
+}
+
+// ProxySyncStatus is the sync status of every xDS type for a connected proxy.
+type ProxySyncStatus struct {
+	// UUID is the UUID of the proxy.
+	UUID string `json:"uuid"`
+
+	// Identity is the service identity of the proxy.
+	Identity string `json:"identity"`
+
+	// Kind is the kind of the proxy.
+	Kind ProxyKind `json:"kind"`
+
+	// ConnectedAt is the time the proxy connected to the control plane.
+	ConnectedAt time.Time `json:"connectedAt"`
+
+	// Types is the sync status keyed by the xDS type.
+	Types map[TypeURI]*XDSSyncStatus `json:"types"`
+}
+
+// State returns the aggregate SyncState of the proxy: NACKED if any type is NACKED, otherwise STALE if any
+// type is STALE or has not been sent yet, otherwise SYNCED. ECDS is only requested by the proxies with WASM
+// filters, so it not being sent does not make the proxy STALE.
+func (p ProxySyncStatus) State() SyncState {
+	state := SyncStateSynced
+	for _, typeURI := range XDSResponseOrder {
+		status, ok := p.Types[typeURI]
+		if !ok && typeURI == TypeECDS {
+			continue
+		}
+		if !ok {
+			state = SyncStateStale
+			continue
+		}
+		switch status.State() {
+		case SyncStateNacked:
+			return SyncStateNacked
+		case SyncStateStale:
+			state = SyncStateStale
+		}
+	}
+	return state
+}
+
+// Reason returns a human readable reason for the proxy's state. It is empty when the proxy is SYNCED.
+func (p ProxySyncStatus) Reason() string {
+	var stale []string
+	for _, typeURI := range XDSResponseOrder {
+		status, ok := p.Types[typeURI]
+		if !ok && typeURI == TypeECDS {
+			continue
+		}
+		if !ok {
+			stale = append(stale, typeURI.Short())
+			continue
+		}
+		switch status.State() {
+		case SyncStateNacked:
+			return typeURI.Short() + " rejected: " + status.LastNackError
+		case SyncStateStale:
+			stale = append(stale, typeURI.Short())
+		}
+	}
+	if len(stale) == 0 {
+		return ""
+	}
+	return "awaiting ACK for " + strings.Join(stale, ", ")
+}
+
+// LastSentAt returns the last time any configuration was sent to the proxy.
+func (p ProxySyncStatus) LastSentAt() time.Time {
+	var last time.Time
+	for _, status := range p.Types {
+		if status.LastSentAt.After(last) {
+			last = status.LastSentAt
+		}
+	}
+	return last
+}
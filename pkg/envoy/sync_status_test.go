@@ -0,0 +1,126 @@
+package envoy
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestXDSSyncStatusState(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   XDSSyncStatus
+		expected SyncState
+	}{
+		{
+			name:     "nothing sent",
+			status:   XDSSyncStatus{},
+			expected: SyncStateStale,
+		},
+		{
+			name:     "sent but not acked",
+			status:   XDSSyncStatus{LastSentVersion: "2", LastAckedVersion: "1"},
+			expected: SyncStateStale,
+		},
+		{
+			name:     "latest version acked",
+			status:   XDSSyncStatus{LastSentVersion: "2", LastAckedVersion: "2"},
+			expected: SyncStateSynced,
+		},
+		{
+			name:     "nacked",
+			status:   XDSSyncStatus{LastSentVersion: "2", LastAckedVersion: "1", Nacked: true},
+			expected: SyncStateNacked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			assert.Equal(tc.expected, tc.status.State())
+		})
+	}
+}
+
+func TestProxySyncStatus(t *testing.T) {
+	synced := func() *XDSSyncStatus {
+		return &XDSSyncStatus{LastSentVersion: "1", LastAckedVersion: "1", LastSentAt: time.Unix(100, 0)}
+	}
+	allSynced := func() map[TypeURI]*XDSSyncStatus {
+		types := make(map[TypeURI]*XDSSyncStatus)
+		for _, typeURI := range XDSResponseOrder {
+			types[typeURI] = synced()
+		}
+		return types
+	}
+
+	testCases := []struct {
+		name           string
+		types          func() map[TypeURI]*XDSSyncStatus
+		expectedState  SyncState
+		expectedReason string
+	}{
+		{
+			name:           "no types sent",
+			types:          func() map[TypeURI]*XDSSyncStatus { return nil },
+			expectedState:  SyncStateStale,
+			expectedReason: "awaiting ACK for CDS, EDS, LDS, RDS, SDS",
+		},
+		{
+			name:           "all types synced",
+			types:          allSynced,
+			expectedState:  SyncStateSynced,
+			expectedReason: "",
+		},
+		{
+			name: "ECDS not requested",
+			types: func() map[TypeURI]*XDSSyncStatus {
+				types := allSynced()
+				delete(types, TypeECDS)
+				return types
+			},
+			expectedState:  SyncStateSynced,
+			expectedReason: "",
+		},
+		{
+			name: "one type stale",
+			types: func() map[TypeURI]*XDSSyncStatus {
+				types := allSynced()
+				types[TypeRDS].LastSentVersion = "2"
+				return types
+			},
+			expectedState:  SyncStateStale,
+			expectedReason: "awaiting ACK for RDS",
+		},
+		{
+			name: "one type nacked",
+			types: func() map[TypeURI]*XDSSyncStatus {
+				types := allSynced()
+				types[TypeEDS].LastSentVersion = "2"
+				types[TypeLDS].LastSentVersion = "2"
+				types[TypeLDS].Nacked = true
+				types[TypeLDS].LastNackError = "invalid listener"
+				return types
+			},
+			expectedState:  SyncStateNacked,
+			expectedReason: "LDS rejected: invalid listener",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			status := ProxySyncStatus{Types: tc.types()}
+			assert.Equal(tc.expectedState, status.State())
+			assert.Equal(tc.expectedReason, status.Reason())
+		})
+	}
+
+	t.Run("last sent at", func(t *testing.T) {
+		assert := tassert.New(t)
+		status := ProxySyncStatus{Types: allSynced()}
+		status.Types[TypeCDS].LastSentAt = time.Unix(200, 0)
+		assert.Equal(time.Unix(200, 0), status.LastSentAt())
+	})
+}
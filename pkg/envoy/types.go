@@ -9,9 +9,9 @@ import (
 )
 
 var (
-	// XDSResponseOrder is the order in which we send xDS responses: CDS, EDS, LDS, RDS
+	// XDSResponseOrder is the order in which we send xDS responses: CDS, EDS, ECDS, LDS, RDS
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
-	XDSResponseOrder = []TypeURI{TypeCDS, TypeEDS, TypeLDS, TypeRDS, TypeSDS}
+	XDSResponseOrder = []TypeURI{TypeCDS, TypeEDS, TypeECDS, TypeLDS, TypeRDS, TypeSDS}
 
 	log = logger.New("envoy")
 )
@@ -44,6 +44,7 @@ var ValidURI = map[string]TypeURI{
 	string(TypeLDS):                TypeLDS,
 	string(TypeRDS):                TypeRDS,
 	string(TypeEDS):                TypeEDS,
+	string(TypeECDS):               TypeECDS,
 	string(TypeUpstreamTLSContext): TypeUpstreamTLSContext,
 	string(TypeZipkinConfig):       TypeZipkinConfig,
 }
@@ -56,6 +57,7 @@ var XDSShortURINames = map[TypeURI]string{
 	TypeLDS:      "LDS",
 	TypeRDS:      "RDS",
 	TypeEDS:      "EDS",
+	TypeECDS:     "ECDS",
 }
 
 // Envoy TypeURIs
@@ -78,6 +80,9 @@ const (
 	// TypeEDS is the EDS type URI.
 	TypeEDS TypeURI = "type.googleapis.com/envoy.config.endpoint.v3.ClusterLoadAssignment"
 
+	// TypeECDS is the ECDS type URI.
+	TypeECDS TypeURI = "type.googleapis.com/envoy.config.core.v3.TypedExtensionConfig"
+
 	// TypeUpstreamTLSContext is an Envoy type URI.
 	TypeUpstreamTLSContext TypeURI = "type.googleapis.com/envoy.extensions.transport_sockets.tls.v3.UpstreamTlsContext"
 
@@ -15,6 +15,7 @@ import (
 
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy/secrets"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
@@ -36,6 +37,10 @@ const (
 // It is set as a part of configuring the UpstreamTLSContext.
 var ALPNInMesh = []string{"osm"}
 
+// ALPNInMeshHTTP indicates that the proxy is connecting to an in-mesh destination with HTTP traffic
+// detected on a port whose protocol is detected automatically.
+var ALPNInMeshHTTP = []string{"osm-http"}
+
 // GetAddress creates an Envoy Address struct.
 func GetAddress(address string, port uint32) *xds_core.Address {
 	return &xds_core.Address{
@@ -51,6 +56,17 @@ func GetAddress(address string, port uint32) *xds_core.Address {
 	}
 }
 
+// GetWildcardAddress creates an Envoy Address struct binding to the wildcard address of the given IP families. The
+// IPv6 wildcard address also accepts IPv4 connections as IPv4-mapped addresses when both families are used.
+func GetWildcardAddress(ipv4 bool, ipv6 bool, port uint32) *xds_core.Address {
+	if !ipv6 {
+		return GetAddress(constants.WildcardIPAddr, port)
+	}
+	address := GetAddress(constants.WildcardIPv6Addr, port)
+	address.GetSocketAddress().Ipv4Compat = ipv4
+	return address
+}
+
 // GetTLSParams creates Envoy TlsParameters struct.
 func GetTLSParams(sidecarSpec configv1alpha2.SidecarSpec) *xds_auth.TlsParameters {
 	minVersionInt := xds_auth.TlsParameters_TlsProtocol_value[sidecarSpec.TLSMinProtocolVersion]
@@ -304,6 +304,17 @@ func TestGetCIDRRangeFromStr(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "valid IPv6 CIDR range",
+			cidr: "fd00:10::/64",
+			expectedCIDRRange: &xds_core.CidrRange{
+				AddressPrefix: "fd00:10::",
+				PrefixLen: &wrapperspb.UInt32Value{
+					Value: 64,
+				},
+			},
+			expectErr: false,
+		},
 		{
 			name:              "invalid CIDR range",
 			cidr:              "10.0.0.1",
@@ -322,3 +333,42 @@ func TestGetCIDRRangeFromStr(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWildcardAddress(t *testing.T) {
+	testCases := []struct {
+		name               string
+		ipv4               bool
+		ipv6               bool
+		expectedAddress    string
+		expectedIpv4Compat bool
+	}{
+		{
+			name:            "IPv4",
+			ipv4:            true,
+			expectedAddress: "0.0.0.0",
+		},
+		{
+			name:            "IPv6",
+			ipv6:            true,
+			expectedAddress: "::",
+		},
+		{
+			name:               "dual-stack",
+			ipv4:               true,
+			ipv6:               true,
+			expectedAddress:    "::",
+			expectedIpv4Compat: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual := GetWildcardAddress(tc.ipv4, tc.ipv6, 15001)
+			assert.Equal(tc.expectedAddress, actual.GetSocketAddress().Address)
+			assert.Equal(uint32(15001), actual.GetSocketAddress().GetPortValue())
+			assert.Equal(tc.expectedIpv4Compat, actual.GetSocketAddress().Ipv4Compat)
+		})
+	}
+}
@@ -78,6 +78,9 @@ const (
 
 	// ErrInvalidSourceKind	indicated an applied SMI TrafficTarget policy has an invalid source kind
 	ErrInvalidSourceKind
+
+	// ErrResolvingHTTPFilterExtension indicates a filter of an HTTPFilterExtension could not be resolved
+	ErrResolvingHTTPFilterExtension
 )
 
 // Range 3000-3500 is reserved for errors related to k8s constructs (service accounts, namespaces, etc.)
@@ -90,6 +93,9 @@ const (
 
 	// ErrUnmarshallingKubernetesResource indicates that a Kubernetes resource could not be unmarshalled
 	ErrUnmarshallingKubernetesResource
+
+	// ErrLoadingComputeFiles indicates the resources of the file compute provider could not be loaded
+	ErrLoadingComputeFiles
 )
 
 // Range 4000-4100 reserved for errors related to certificate providers
@@ -144,6 +150,12 @@ const (
 
 	// ErrRotatingCert indicates a certificate could not be rotated
 	ErrRotatingCert
+
+	// ErrIssuingRevocationList indicates a certificate revocation list could not be issued
+	ErrIssuingRevocationList
+
+	// ErrSigningCertificateRequest indicates the certificate signing request of a pod could not be signed
+	ErrSigningCertificateRequest
 )
 
 // Range 4100-4150 reserved for PubSub system
@@ -256,6 +268,9 @@ const (
 
 	// ErrSDSCertMismatch indicates the indentity obtained from the SDSCert request does not match the identity of the proxy
 	ErrSDSCertMismatch
+
+	// ErrXDSConfigRejected indicates a proxy rejected (NACKed) the configuration sent to it
+	ErrXDSConfigRejected
 )
 
 // Range 6000-6500 reserved for errors related to the OSM Injector
@@ -468,6 +483,13 @@ An applied SMI TrafficTarget policy has an invalid destination kind.
 
 	ErrInvalidSourceKind: `
 An applied SMI TrafficTarget policy has an invalid source kind.
+`,
+
+	ErrResolvingHTTPFilterExtension: `
+A filter of an HTTPFilterExtension selecting a proxy could not be resolved, for
+example because the ConfigMap holding its WASM module is missing or is not
+labeled with openservicemesh.io/http-filter-module=true. The filter is not added
+to the proxy's HTTP filter chains.
 `,
 
 	ErrGettingInboundTrafficTargets: `
@@ -488,6 +510,12 @@ A Kubernetes resource could not be marshalled.
 
 	ErrUnmarshallingKubernetesResource: `
 A Kubernetes resource could not be unmarshalled.
+`,
+
+	ErrLoadingComputeFiles: `
+The services, workloads and policies could not be loaded from the directory
+watched by the file compute provider. The previously loaded resources remain in
+use until the files are fixed.
 `,
 
 	//
@@ -564,6 +592,18 @@ The certificate authority privided when issuing a certificate was invalid.
 
 	ErrRotatingCert: `
 The specified certificate could not be rotated.
+`,
+
+	ErrIssuingRevocationList: `
+The certificate revocation list of an issuer could not be issued. Service
+certificates are sent to proxies without revocation lists, so revoked
+certificates are not rejected by proxies.
+`,
+
+	ErrSigningCertificateRequest: `
+The certificate signing request of a pod generating the private key of its
+Envoy sidecar could not be signed. The pod's osm-cert-agent container retries
+the request.
 `,
 
 	//
@@ -729,6 +769,13 @@ The SDS secret does not correspond to a ServiceAccount.
 The identity obtained from the SDS certificate request does not match the
 identity of the proxy.
 The corresponding certificate request was ignored by the system.
+`,
+
+	ErrXDSConfigRejected: `
+The Envoy proxy rejected (NACKed) the configuration sent to it in a DiscoveryResponse.
+The proxy continues to use the last configuration it accepted for the corresponding
+type. The rejection reason reported by the proxy is included in the log message and
+is visible using the 'osm proxy status' command.
 `,
 
 	//
@@ -0,0 +1,62 @@
+// Package federation implements the federation of the mesh with foreign trust domains, such as other OSM meshes
+// with different roots.
+//
+// A foreign trust domain is federated with a TrustDomainFederation resource, which imports the root certificates of
+// the foreign trust domain from a Secret or from a SPIFFE bundle. The imported roots are added to the trust context
+// of the service certificates, so that proxies accept the certificates issued to the workloads of the foreign trust
+// domain. They only validate the certificates whose SPIFFE ID is in the foreign trust domain, and the Secret holding
+// them must be in the namespace of the TrustDomainFederation or in the OSM namespace. The workloads of a foreign trust domain are authorized in TrafficTargets by their SPIFFE ID, with sources
+// of kind SPIFFEID.
+package federation
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// resyncInterval is the interval at which the roots of the federated trust domains are reloaded, so that
+	// changes to the Secrets holding the roots are picked up
+	resyncInterval = time.Minute
+
+	// rootsIDPrefix is the prefix of the IDs of the roots added to the certificate manager
+	rootsIDPrefix = "federation/"
+)
+
+var (
+	log = logger.New("federation")
+
+	errInvalidTrustDomain  = errors.New("trust domain of the federation must be set and differ from the trust domain of the mesh")
+	errInvalidTrustBundle  = errors.New("exactly one of secretKeyRef and spiffeBundle must be set")
+	errMissingTrustBundle  = errors.New("trust bundle key not found in secret")
+	errInvalidRootCertPEMs = errors.New("trust bundle has no valid PEM encoded root certificate")
+	errForbiddenNamespace  = errors.New("trust bundle secret must be in the namespace of the federation or in the OSM namespace")
+)
+
+// RootsManager manages the roots of the federated trust domains trusted by the workloads of the mesh.
+// It is implemented by certificate.Manager.
+type RootsManager interface {
+	// AddFederatedRoot adds or replaces the roots with the given ID, only trusted for the given trust domain
+	AddFederatedRoot(id string, trustDomain string, roots pem.RootCertificate)
+
+	// RemoveFederatedRoot removes the roots with the given ID
+	RemoveFederatedRoot(id string)
+}
+
+// watcher keeps the federated roots of the RootsManager in sync with the TrustDomainFederation resources
+type watcher struct {
+	kubeClient     kubernetes.Interface
+	kubeController k8s.Controller
+	rootsManager   RootsManager
+	trustDomain    string
+	osmNamespace   string
+
+	// the IDs of the roots currently added to the RootsManager
+	rootIDs map[string]struct{}
+}
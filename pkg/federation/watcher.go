@@ -0,0 +1,126 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+// WatchTrustDomainFederations adds the roots of the trust domains federated with TrustDomainFederation resources to
+// the given RootsManager, and keeps them in sync as the resources and the Secrets they reference change.
+// It blocks until the stop channel is closed.
+func WatchTrustDomainFederations(kubeClient kubernetes.Interface, kubeController k8s.Controller, rootsManager RootsManager,
+	trustDomain string, osmNamespace string, msgBroker *messaging.Broker, stop <-chan struct{}) {
+	w := &watcher{
+		kubeClient:     kubeClient,
+		kubeController: kubeController,
+		rootsManager:   rootsManager,
+		trustDomain:    trustDomain,
+		osmNamespace:   osmNamespace,
+		rootIDs:        make(map[string]struct{}),
+	}
+
+	federationChan, unsub := msgBroker.SubscribeKubeEvents(
+		events.TrustDomainFederation.Added(),
+		events.TrustDomainFederation.Updated(),
+		events.TrustDomainFederation.Deleted(),
+	)
+	defer unsub()
+
+	ticker := time.NewTicker(resyncInterval)
+	defer ticker.Stop()
+
+	w.syncTrustDomainFederations()
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting trust domain federation watch routine")
+			return
+
+		case <-federationChan:
+			w.syncTrustDomainFederations()
+
+		case <-ticker.C:
+			w.syncTrustDomainFederations()
+		}
+	}
+}
+
+// syncTrustDomainFederations adds the roots of the current TrustDomainFederation resources to the RootsManager, and
+// removes the roots of the deleted ones. The roots of a resource that can't be loaded are removed, so that a
+// misconfigured federation is not trusted with stale roots.
+func (w *watcher) syncTrustDomainFederations() {
+	current := make(map[string]struct{})
+	for _, tdf := range w.kubeController.ListTrustDomainFederations() {
+		id := rootsIDPrefix + tdf.Namespace + "/" + tdf.Name
+		roots, err := w.getRoots(tdf)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error loading the roots of trust domain federation %s/%s", tdf.Namespace, tdf.Name)
+			continue
+		}
+		w.rootsManager.AddFederatedRoot(id, tdf.Spec.TrustDomain, roots)
+		current[id] = struct{}{}
+	}
+
+	for id := range w.rootIDs {
+		if _, ok := current[id]; !ok {
+			w.rootsManager.RemoveFederatedRoot(id)
+		}
+	}
+	w.rootIDs = current
+}
+
+// getRoots returns the PEM encoded roots of the foreign trust domain of the given TrustDomainFederation
+func (w *watcher) getRoots(tdf *configv1alpha2.TrustDomainFederation) (pem.RootCertificate, error) {
+	if tdf.Spec.TrustDomain == "" || tdf.Spec.TrustDomain == w.trustDomain {
+		return nil, fmt.Errorf("%w: %q", errInvalidTrustDomain, tdf.Spec.TrustDomain)
+	}
+
+	bundle := tdf.Spec.TrustBundle
+	switch {
+	case bundle.SecretKeyRef != nil && bundle.SPIFFEBundle == "":
+		return w.getRootsFromSecret(tdf.Namespace, bundle.SecretKeyRef)
+
+	case bundle.SecretKeyRef == nil && bundle.SPIFFEBundle != "":
+		return certificate.DecodeSPIFFETrustBundle([]byte(bundle.SPIFFEBundle))
+
+	default:
+		return nil, errInvalidTrustBundle
+	}
+}
+
+// getRootsFromSecret returns the PEM encoded roots stored in the given Secret key. The namespace of the Secret
+// defaults to the namespace of the TrustDomainFederation, and may only be the OSM namespace otherwise, so that a
+// TrustDomainFederation can't read the Secrets of other namespaces.
+func (w *watcher) getRootsFromSecret(namespace string, ref *configv1alpha2.SecretKeyReferenceSpec) (pem.RootCertificate, error) {
+	if ref.Namespace != "" && ref.Namespace != namespace {
+		if ref.Namespace != w.osmNamespace {
+			return nil, fmt.Errorf("%w: %s/%s", errForbiddenNamespace, ref.Namespace, ref.Name)
+		}
+		namespace = ref.Namespace
+	}
+	secret, err := w.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching trust bundle secret %s/%s: %w", namespace, ref.Name, err)
+	}
+	roots, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s key %s", errMissingTrustBundle, namespace, ref.Name, ref.Key)
+	}
+	if _, err := certificate.DecodePEMCertificates(roots); err != nil {
+		return nil, fmt.Errorf("%w: %s/%s key %s: %s", errInvalidRootCertPEMs, namespace, ref.Name, ref.Key, err)
+	}
+	return roots, nil
+}
@@ -0,0 +1,175 @@
+package federation
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+const testTrustDomain = "cluster.local"
+
+// fakeRootsManager records the roots added by the watcher and their trust domains
+type fakeRootsManager struct {
+	roots        map[string]pem.RootCertificate
+	trustDomains map[string]string
+}
+
+func (m *fakeRootsManager) AddFederatedRoot(id string, trustDomain string, roots pem.RootCertificate) {
+	m.roots[id] = roots
+	m.trustDomains[id] = trustDomain
+}
+
+func (m *fakeRootsManager) RemoveFederatedRoot(id string) {
+	delete(m.roots, id)
+	delete(m.trustDomains, id)
+}
+
+func newTestRoot(t *testing.T) pem.RootCertificate {
+	ca, err := tresor.NewCA("west.mesh", time.Hour, "US", "CA", "west", configv1alpha2.KeyAlgorithmECDSAP256)
+	tassert.NoError(t, err)
+	return ca.GetIssuingCA()
+}
+
+func newTestSPIFFEBundle(t *testing.T, roots pem.RootCertificate) string {
+	certs, err := certificate.DecodePEMCertificates(roots)
+	tassert.NoError(t, err)
+
+	var keys []jose.JSONWebKey
+	for _, cert := range certs {
+		keys = append(keys, jose.JSONWebKey{Key: cert.PublicKey, Use: "x509-svid", Certificates: []*x509.Certificate{cert}})
+	}
+	bundle, err := json.Marshal(struct {
+		Keys []jose.JSONWebKey `json:"keys"`
+	}{Keys: keys})
+	tassert.NoError(t, err)
+	return string(bundle)
+}
+
+func newTestFederation(name, trustDomain string, bundle configv1alpha2.TrustBundleSourceSpec) *configv1alpha2.TrustDomainFederation {
+	return newTestFederationInNamespace("osm-system", name, trustDomain, bundle)
+}
+
+func newTestFederationInNamespace(namespace, name, trustDomain string, bundle configv1alpha2.TrustBundleSourceSpec) *configv1alpha2.TrustDomainFederation {
+	return &configv1alpha2.TrustDomainFederation{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: configv1alpha2.TrustDomainFederationSpec{
+			TrustDomain: trustDomain,
+			TrustBundle: bundle,
+		},
+	}
+}
+
+func TestSyncTrustDomainFederations(t *testing.T) {
+	root := newTestRoot(t)
+	secretRef := func(key string) configv1alpha2.TrustBundleSourceSpec {
+		return configv1alpha2.TrustBundleSourceSpec{SecretKeyRef: &configv1alpha2.SecretKeyReferenceSpec{Name: "west-roots", Key: key}}
+	}
+	secretRefInNamespace := func(namespace string) configv1alpha2.TrustBundleSourceSpec {
+		return configv1alpha2.TrustBundleSourceSpec{SecretKeyRef: &configv1alpha2.SecretKeyReferenceSpec{Name: "west-roots", Namespace: namespace, Key: "ca.crt"}}
+	}
+
+	testCases := []struct {
+		name          string
+		federation    *configv1alpha2.TrustDomainFederation
+		expectedRoots map[string]pem.RootCertificate
+	}{
+		{
+			name:          "roots from secret",
+			federation:    newTestFederation("west", "west.mesh", secretRef("ca.crt")),
+			expectedRoots: map[string]pem.RootCertificate{"federation/osm-system/west": root},
+		},
+		{
+			name:          "roots from SPIFFE bundle",
+			federation:    newTestFederation("west", "west.mesh", configv1alpha2.TrustBundleSourceSpec{SPIFFEBundle: newTestSPIFFEBundle(t, root)}),
+			expectedRoots: map[string]pem.RootCertificate{"federation/osm-system/west": root},
+		},
+		{
+			name:          "roots from secret in the OSM namespace",
+			federation:    newTestFederationInNamespace("federation", "west", "west.mesh", secretRefInNamespace("osm-system")),
+			expectedRoots: map[string]pem.RootCertificate{"federation/federation/west": root},
+		},
+		{
+			name:          "roots from secret in the namespace of the federation",
+			federation:    newTestFederationInNamespace("kube-system", "west", "west.mesh", secretRefInNamespace("kube-system")),
+			expectedRoots: map[string]pem.RootCertificate{"federation/kube-system/west": root},
+		},
+		{
+			name:          "roots from secret in another namespace",
+			federation:    newTestFederation("west", "west.mesh", secretRefInNamespace("kube-system")),
+			expectedRoots: map[string]pem.RootCertificate{},
+		},
+		{
+			name:          "missing secret key",
+			federation:    newTestFederation("west", "west.mesh", secretRef("missing")),
+			expectedRoots: map[string]pem.RootCertificate{},
+		},
+		{
+			name:          "invalid roots in secret",
+			federation:    newTestFederation("west", "west.mesh", secretRef("invalid")),
+			expectedRoots: map[string]pem.RootCertificate{},
+		},
+		{
+			name:          "trust domain of the mesh",
+			federation:    newTestFederation("west", testTrustDomain, secretRef("ca.crt")),
+			expectedRoots: map[string]pem.RootCertificate{},
+		},
+		{
+			name:          "no trust bundle source",
+			federation:    newTestFederation("west", "west.mesh", configv1alpha2.TrustBundleSourceSpec{}),
+			expectedRoots: map[string]pem.RootCertificate{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockController := k8s.NewMockController(gomock.NewController(t))
+			kubeClient := fake.NewSimpleClientset()
+			for _, namespace := range []string{"osm-system", "kube-system"} {
+				_, err := kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "west-roots", Namespace: namespace},
+					Data:       map[string][]byte{"ca.crt": root, "invalid": []byte("invalid")},
+				}, metav1.CreateOptions{})
+				assert.NoError(err)
+			}
+
+			rootsManager := &fakeRootsManager{roots: make(map[string]pem.RootCertificate), trustDomains: make(map[string]string)}
+			w := &watcher{
+				kubeClient:     kubeClient,
+				kubeController: mockController,
+				rootsManager:   rootsManager,
+				trustDomain:    testTrustDomain,
+				osmNamespace:   "osm-system",
+				rootIDs:        make(map[string]struct{}),
+			}
+
+			mockController.EXPECT().ListTrustDomainFederations().Return([]*configv1alpha2.TrustDomainFederation{tc.federation})
+			w.syncTrustDomainFederations()
+			assert.Equal(tc.expectedRoots, rootsManager.roots)
+			for id := range tc.expectedRoots {
+				assert.Equal(tc.federation.Spec.TrustDomain, rootsManager.trustDomains[id])
+			}
+
+			// the roots are removed with the federation
+			mockController.EXPECT().ListTrustDomainFederations().Return(nil)
+			w.syncTrustDomainFederations()
+			assert.Empty(rootsManager.roots)
+		})
+	}
+}
@@ -25,8 +25,11 @@ import (
 
 type ConfigV1alpha2Interface interface {
 	RESTClient() rest.Interface
+	CertificateRevocationsGetter
 	MeshConfigsGetter
 	MeshRootCertificatesGetter
+	RemoteClustersGetter
+	TrustDomainFederationsGetter
 }
 
 // ConfigV1alpha2Client is used to interact with features provided by the config.openservicemesh.io group.
@@ -34,6 +37,10 @@ type ConfigV1alpha2Client struct {
 	restClient rest.Interface
 }
 
+func (c *ConfigV1alpha2Client) CertificateRevocations(namespace string) CertificateRevocationInterface {
+	return newCertificateRevocations(c, namespace)
+}
+
 func (c *ConfigV1alpha2Client) MeshConfigs(namespace string) MeshConfigInterface {
 	return newMeshConfigs(c, namespace)
 }
@@ -42,6 +49,14 @@ func (c *ConfigV1alpha2Client) MeshRootCertificates(namespace string) MeshRootCe
 	return newMeshRootCertificates(c, namespace)
 }
 
+func (c *ConfigV1alpha2Client) RemoteClusters(namespace string) RemoteClusterInterface {
+	return newRemoteClusters(c, namespace)
+}
+
+func (c *ConfigV1alpha2Client) TrustDomainFederations(namespace string) TrustDomainFederationInterface {
+	return newTrustDomainFederations(c, namespace)
+}
+
 // NewForConfig creates a new ConfigV1alpha2Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
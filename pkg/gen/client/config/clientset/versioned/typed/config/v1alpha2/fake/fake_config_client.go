@@ -25,6 +25,10 @@ type FakeConfigV1alpha2 struct {
 	*testing.Fake
 }
 
+func (c *FakeConfigV1alpha2) CertificateRevocations(namespace string) v1alpha2.CertificateRevocationInterface {
+	return &FakeCertificateRevocations{c, namespace}
+}
+
 func (c *FakeConfigV1alpha2) MeshConfigs(namespace string) v1alpha2.MeshConfigInterface {
 	return &FakeMeshConfigs{c, namespace}
 }
@@ -33,6 +37,14 @@ func (c *FakeConfigV1alpha2) MeshRootCertificates(namespace string) v1alpha2.Mes
 	return &FakeMeshRootCertificates{c, namespace}
 }
 
+func (c *FakeConfigV1alpha2) RemoteClusters(namespace string) v1alpha2.RemoteClusterInterface {
+	return &FakeRemoteClusters{c, namespace}
+}
+
+func (c *FakeConfigV1alpha2) TrustDomainFederations(namespace string) v1alpha2.TrustDomainFederationInterface {
+	return &FakeTrustDomainFederations{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeConfigV1alpha2) RESTClient() rest.Interface {
@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRemoteClusters implements RemoteClusterInterface
+type FakeRemoteClusters struct {
+	Fake *FakeConfigV1alpha2
+	ns   string
+}
+
+var remoteclustersResource = schema.GroupVersionResource{Group: "config.openservicemesh.io", Version: "v1alpha2", Resource: "remoteclusters"}
+
+var remoteclustersKind = schema.GroupVersionKind{Group: "config.openservicemesh.io", Version: "v1alpha2", Kind: "RemoteCluster"}
+
+// Get takes name of the remoteCluster, and returns the corresponding remoteCluster object, and an error if there is any.
+func (c *FakeRemoteClusters) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.RemoteCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(remoteclustersResource, c.ns, name), &v1alpha2.RemoteCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.RemoteCluster), err
+}
+
+// List takes label and field selectors, and returns the list of RemoteClusters that match those selectors.
+func (c *FakeRemoteClusters) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.RemoteClusterList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(remoteclustersResource, remoteclustersKind, c.ns, opts), &v1alpha2.RemoteClusterList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.RemoteClusterList{ListMeta: obj.(*v1alpha2.RemoteClusterList).ListMeta}
+	for _, item := range obj.(*v1alpha2.RemoteClusterList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested remoteClusters.
+func (c *FakeRemoteClusters) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(remoteclustersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a remoteCluster and creates it.  Returns the server's representation of the remoteCluster, and an error, if there is any.
+func (c *FakeRemoteClusters) Create(ctx context.Context, remoteCluster *v1alpha2.RemoteCluster, opts v1.CreateOptions) (result *v1alpha2.RemoteCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(remoteclustersResource, c.ns, remoteCluster), &v1alpha2.RemoteCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.RemoteCluster), err
+}
+
+// Update takes the representation of a remoteCluster and updates it. Returns the server's representation of the remoteCluster, and an error, if there is any.
+func (c *FakeRemoteClusters) Update(ctx context.Context, remoteCluster *v1alpha2.RemoteCluster, opts v1.UpdateOptions) (result *v1alpha2.RemoteCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(remoteclustersResource, c.ns, remoteCluster), &v1alpha2.RemoteCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.RemoteCluster), err
+}
+
+// Delete takes name of the remoteCluster and deletes it. Returns an error if one occurs.
+func (c *FakeRemoteClusters) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(remoteclustersResource, c.ns, name, opts), &v1alpha2.RemoteCluster{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRemoteClusters) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(remoteclustersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.RemoteClusterList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched remoteCluster.
+func (c *FakeRemoteClusters) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.RemoteCluster, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(remoteclustersResource, c.ns, name, pt, data, subresources...), &v1alpha2.RemoteCluster{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.RemoteCluster), err
+}
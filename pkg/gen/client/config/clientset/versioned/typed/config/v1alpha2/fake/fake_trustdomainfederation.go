@@ -0,0 +1,127 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTrustDomainFederations implements TrustDomainFederationInterface
+type FakeTrustDomainFederations struct {
+	Fake *FakeConfigV1alpha2
+	ns   string
+}
+
+var trustdomainfederationsResource = schema.GroupVersionResource{Group: "config.openservicemesh.io", Version: "v1alpha2", Resource: "trustdomainfederations"}
+
+var trustdomainfederationsKind = schema.GroupVersionKind{Group: "config.openservicemesh.io", Version: "v1alpha2", Kind: "TrustDomainFederation"}
+
+// Get takes name of the trustDomainFederation, and returns the corresponding trustDomainFederation object, and an error if there is any.
+func (c *FakeTrustDomainFederations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.TrustDomainFederation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(trustdomainfederationsResource, c.ns, name), &v1alpha2.TrustDomainFederation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.TrustDomainFederation), err
+}
+
+// List takes label and field selectors, and returns the list of TrustDomainFederations that match those selectors.
+func (c *FakeTrustDomainFederations) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.TrustDomainFederationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(trustdomainfederationsResource, trustdomainfederationsKind, c.ns, opts), &v1alpha2.TrustDomainFederationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.TrustDomainFederationList{ListMeta: obj.(*v1alpha2.TrustDomainFederationList).ListMeta}
+	for _, item := range obj.(*v1alpha2.TrustDomainFederationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested trustDomainFederations.
+func (c *FakeTrustDomainFederations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(trustdomainfederationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a trustDomainFederation and creates it.  Returns the server's representation of the trustDomainFederation, and an error, if there is any.
+func (c *FakeTrustDomainFederations) Create(ctx context.Context, trustDomainFederation *v1alpha2.TrustDomainFederation, opts v1.CreateOptions) (result *v1alpha2.TrustDomainFederation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(trustdomainfederationsResource, c.ns, trustDomainFederation), &v1alpha2.TrustDomainFederation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.TrustDomainFederation), err
+}
+
+// Update takes the representation of a trustDomainFederation and updates it. Returns the server's representation of the trustDomainFederation, and an error, if there is any.
+func (c *FakeTrustDomainFederations) Update(ctx context.Context, trustDomainFederation *v1alpha2.TrustDomainFederation, opts v1.UpdateOptions) (result *v1alpha2.TrustDomainFederation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(trustdomainfederationsResource, c.ns, trustDomainFederation), &v1alpha2.TrustDomainFederation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.TrustDomainFederation), err
+}
+
+// Delete takes name of the trustDomainFederation and deletes it. Returns an error if one occurs.
+func (c *FakeTrustDomainFederations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(trustdomainfederationsResource, c.ns, name, opts), &v1alpha2.TrustDomainFederation{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTrustDomainFederations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(trustdomainfederationsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.TrustDomainFederationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched trustDomainFederation.
+func (c *FakeTrustDomainFederations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.TrustDomainFederation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(trustdomainfederationsResource, c.ns, name, pt, data, subresources...), &v1alpha2.TrustDomainFederation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.TrustDomainFederation), err
+}
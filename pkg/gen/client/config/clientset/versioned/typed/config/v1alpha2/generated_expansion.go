@@ -15,6 +15,12 @@ limitations under the License.
 
 package v1alpha2
 
+type CertificateRevocationExpansion interface{}
+
 type MeshConfigExpansion interface{}
 
 type MeshRootCertificateExpansion interface{}
+
+type RemoteClusterExpansion interface{}
+
+type TrustDomainFederationExpansion interface{}
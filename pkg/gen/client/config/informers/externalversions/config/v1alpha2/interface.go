@@ -21,10 +21,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// CertificateRevocations returns a CertificateRevocationInformer.
+	CertificateRevocations() CertificateRevocationInformer
 	// MeshConfigs returns a MeshConfigInformer.
 	MeshConfigs() MeshConfigInformer
 	// MeshRootCertificates returns a MeshRootCertificateInformer.
 	MeshRootCertificates() MeshRootCertificateInformer
+	// RemoteClusters returns a RemoteClusterInformer.
+	RemoteClusters() RemoteClusterInformer
+	// TrustDomainFederations returns a TrustDomainFederationInformer.
+	TrustDomainFederations() TrustDomainFederationInformer
 }
 
 type version struct {
@@ -38,6 +44,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// CertificateRevocations returns a CertificateRevocationInformer.
+func (v *version) CertificateRevocations() CertificateRevocationInformer {
+	return &certificateRevocationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // MeshConfigs returns a MeshConfigInformer.
 func (v *version) MeshConfigs() MeshConfigInformer {
 	return &meshConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -47,3 +58,13 @@ func (v *version) MeshConfigs() MeshConfigInformer {
 func (v *version) MeshRootCertificates() MeshRootCertificateInformer {
 	return &meshRootCertificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// RemoteClusters returns a RemoteClusterInformer.
+func (v *version) RemoteClusters() RemoteClusterInformer {
+	return &remoteClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TrustDomainFederations returns a TrustDomainFederationInformer.
+func (v *version) TrustDomainFederations() TrustDomainFederationInformer {
+	return &trustDomainFederationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
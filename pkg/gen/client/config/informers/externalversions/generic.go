@@ -55,10 +55,16 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha1().MeshConfigs().Informer()}, nil
 
 		// Group=config.openservicemesh.io, Version=v1alpha2
+	case v1alpha2.SchemeGroupVersion.WithResource("certificaterevocations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().CertificateRevocations().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("meshconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().MeshConfigs().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("meshrootcertificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().MeshRootCertificates().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("remoteclusters"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().RemoteClusters().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("trustdomainfederations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha2().TrustDomainFederations().Informer()}, nil
 
 	}
 
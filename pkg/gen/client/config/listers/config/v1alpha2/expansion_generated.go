@@ -15,6 +15,14 @@ limitations under the License.
 
 package v1alpha2
 
+// CertificateRevocationListerExpansion allows custom methods to be added to
+// CertificateRevocationLister.
+type CertificateRevocationListerExpansion interface{}
+
+// CertificateRevocationNamespaceListerExpansion allows custom methods to be added to
+// CertificateRevocationNamespaceLister.
+type CertificateRevocationNamespaceListerExpansion interface{}
+
 // MeshConfigListerExpansion allows custom methods to be added to
 // MeshConfigLister.
 type MeshConfigListerExpansion interface{}
@@ -30,3 +38,19 @@ type MeshRootCertificateListerExpansion interface{}
 // MeshRootCertificateNamespaceListerExpansion allows custom methods to be added to
 // MeshRootCertificateNamespaceLister.
 type MeshRootCertificateNamespaceListerExpansion interface{}
+
+// RemoteClusterListerExpansion allows custom methods to be added to
+// RemoteClusterLister.
+type RemoteClusterListerExpansion interface{}
+
+// RemoteClusterNamespaceListerExpansion allows custom methods to be added to
+// RemoteClusterNamespaceLister.
+type RemoteClusterNamespaceListerExpansion interface{}
+
+// TrustDomainFederationListerExpansion allows custom methods to be added to
+// TrustDomainFederationLister.
+type TrustDomainFederationListerExpansion interface{}
+
+// TrustDomainFederationNamespaceListerExpansion allows custom methods to be added to
+// TrustDomainFederationNamespaceLister.
+type TrustDomainFederationNamespaceListerExpansion interface{}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RemoteClusterLister helps list RemoteClusters.
+// All objects returned here must be treated as read-only.
+type RemoteClusterLister interface {
+	// List lists all RemoteClusters in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.RemoteCluster, err error)
+	// RemoteClusters returns an object that can list and get RemoteClusters.
+	RemoteClusters(namespace string) RemoteClusterNamespaceLister
+	RemoteClusterListerExpansion
+}
+
+// remoteClusterLister implements the RemoteClusterLister interface.
+type remoteClusterLister struct {
+	indexer cache.Indexer
+}
+
+// NewRemoteClusterLister returns a new RemoteClusterLister.
+func NewRemoteClusterLister(indexer cache.Indexer) RemoteClusterLister {
+	return &remoteClusterLister{indexer: indexer}
+}
+
+// List lists all RemoteClusters in the indexer.
+func (s *remoteClusterLister) List(selector labels.Selector) (ret []*v1alpha2.RemoteCluster, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.RemoteCluster))
+	})
+	return ret, err
+}
+
+// RemoteClusters returns an object that can list and get RemoteClusters.
+func (s *remoteClusterLister) RemoteClusters(namespace string) RemoteClusterNamespaceLister {
+	return remoteClusterNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RemoteClusterNamespaceLister helps list and get RemoteClusters.
+// All objects returned here must be treated as read-only.
+type RemoteClusterNamespaceLister interface {
+	// List lists all RemoteClusters in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.RemoteCluster, err error)
+	// Get retrieves the RemoteCluster from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.RemoteCluster, error)
+	RemoteClusterNamespaceListerExpansion
+}
+
+// remoteClusterNamespaceLister implements the RemoteClusterNamespaceLister
+// interface.
+type remoteClusterNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all RemoteClusters in the indexer for a given namespace.
+func (s remoteClusterNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.RemoteCluster, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.RemoteCluster))
+	})
+	return ret, err
+}
+
+// Get retrieves the RemoteCluster from the indexer for a given namespace and name.
+func (s remoteClusterNamespaceLister) Get(name string) (*v1alpha2.RemoteCluster, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("remotecluster"), name)
+	}
+	return obj.(*v1alpha2.RemoteCluster), nil
+}
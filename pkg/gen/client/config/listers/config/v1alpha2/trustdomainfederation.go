@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TrustDomainFederationLister helps list TrustDomainFederations.
+// All objects returned here must be treated as read-only.
+type TrustDomainFederationLister interface {
+	// List lists all TrustDomainFederations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.TrustDomainFederation, err error)
+	// TrustDomainFederations returns an object that can list and get TrustDomainFederations.
+	TrustDomainFederations(namespace string) TrustDomainFederationNamespaceLister
+	TrustDomainFederationListerExpansion
+}
+
+// trustDomainFederationLister implements the TrustDomainFederationLister interface.
+type trustDomainFederationLister struct {
+	indexer cache.Indexer
+}
+
+// NewTrustDomainFederationLister returns a new TrustDomainFederationLister.
+func NewTrustDomainFederationLister(indexer cache.Indexer) TrustDomainFederationLister {
+	return &trustDomainFederationLister{indexer: indexer}
+}
+
+// List lists all TrustDomainFederations in the indexer.
+func (s *trustDomainFederationLister) List(selector labels.Selector) (ret []*v1alpha2.TrustDomainFederation, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.TrustDomainFederation))
+	})
+	return ret, err
+}
+
+// TrustDomainFederations returns an object that can list and get TrustDomainFederations.
+func (s *trustDomainFederationLister) TrustDomainFederations(namespace string) TrustDomainFederationNamespaceLister {
+	return trustDomainFederationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// TrustDomainFederationNamespaceLister helps list and get TrustDomainFederations.
+// All objects returned here must be treated as read-only.
+type TrustDomainFederationNamespaceLister interface {
+	// List lists all TrustDomainFederations in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.TrustDomainFederation, err error)
+	// Get retrieves the TrustDomainFederation from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.TrustDomainFederation, error)
+	TrustDomainFederationNamespaceListerExpansion
+}
+
+// trustDomainFederationNamespaceLister implements the TrustDomainFederationNamespaceLister
+// interface.
+type trustDomainFederationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all TrustDomainFederations in the indexer for a given namespace.
+func (s trustDomainFederationNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.TrustDomainFederation, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.TrustDomainFederation))
+	})
+	return ret, err
+}
+
+// Get retrieves the TrustDomainFederation from the indexer for a given namespace and name.
+func (s trustDomainFederationNamespaceLister) Get(name string) (*v1alpha2.TrustDomainFederation, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("trustdomainfederation"), name)
+	}
+	return obj.(*v1alpha2.TrustDomainFederation), nil
+}
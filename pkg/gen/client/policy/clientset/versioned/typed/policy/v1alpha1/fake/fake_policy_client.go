@@ -29,6 +29,10 @@ func (c *FakePolicyV1alpha1) Egresses(namespace string) v1alpha1.EgressInterface
 	return &FakeEgresses{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) HTTPFilterExtensions(namespace string) v1alpha1.HTTPFilterExtensionInterface {
+	return &FakeHTTPFilterExtensions{c, namespace}
+}
+
 func (c *FakePolicyV1alpha1) IngressBackends(namespace string) v1alpha1.IngressBackendInterface {
 	return &FakeIngressBackends{c, namespace}
 }
@@ -41,6 +45,10 @@ func (c *FakePolicyV1alpha1) UpstreamTrafficSettings(namespace string) v1alpha1.
 	return &FakeUpstreamTrafficSettings{c, namespace}
 }
 
+func (c *FakePolicyV1alpha1) WorkloadEntries(namespace string) v1alpha1.WorkloadEntryInterface {
+	return &FakeWorkloadEntries{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakePolicyV1alpha1) RESTClient() rest.Interface {
@@ -17,8 +17,12 @@ package v1alpha1
 
 type EgressExpansion interface{}
 
+type HTTPFilterExtensionExpansion interface{}
+
 type IngressBackendExpansion interface{}
 
 type RetryExpansion interface{}
 
 type UpstreamTrafficSettingExpansion interface{}
+
+type WorkloadEntryExpansion interface{}
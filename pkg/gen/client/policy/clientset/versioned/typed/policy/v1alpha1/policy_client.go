@@ -26,9 +26,11 @@ import (
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	EgressesGetter
+	HTTPFilterExtensionsGetter
 	IngressBackendsGetter
 	RetriesGetter
 	UpstreamTrafficSettingsGetter
+	WorkloadEntriesGetter
 }
 
 // PolicyV1alpha1Client is used to interact with features provided by the policy.openservicemesh.io group.
@@ -40,6 +42,10 @@ func (c *PolicyV1alpha1Client) Egresses(namespace string) EgressInterface {
 	return newEgresses(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) HTTPFilterExtensions(namespace string) HTTPFilterExtensionInterface {
+	return newHTTPFilterExtensions(c, namespace)
+}
+
 func (c *PolicyV1alpha1Client) IngressBackends(namespace string) IngressBackendInterface {
 	return newIngressBackends(c, namespace)
 }
@@ -52,6 +58,10 @@ func (c *PolicyV1alpha1Client) UpstreamTrafficSettings(namespace string) Upstrea
 	return newUpstreamTrafficSettings(c, namespace)
 }
 
+func (c *PolicyV1alpha1Client) WorkloadEntries(namespace string) WorkloadEntryInterface {
+	return newWorkloadEntries(c, namespace)
+}
+
 // NewForConfig creates a new PolicyV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
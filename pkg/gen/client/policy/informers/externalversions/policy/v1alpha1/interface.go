@@ -23,12 +23,16 @@ import (
 type Interface interface {
 	// Egresses returns a EgressInformer.
 	Egresses() EgressInformer
+	// HTTPFilterExtensions returns a HTTPFilterExtensionInformer.
+	HTTPFilterExtensions() HTTPFilterExtensionInformer
 	// IngressBackends returns a IngressBackendInformer.
 	IngressBackends() IngressBackendInformer
 	// Retries returns a RetryInformer.
 	Retries() RetryInformer
 	// UpstreamTrafficSettings returns a UpstreamTrafficSettingInformer.
 	UpstreamTrafficSettings() UpstreamTrafficSettingInformer
+	// WorkloadEntries returns a WorkloadEntryInformer.
+	WorkloadEntries() WorkloadEntryInformer
 }
 
 type version struct {
@@ -47,6 +51,11 @@ func (v *version) Egresses() EgressInformer {
 	return &egressInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// HTTPFilterExtensions returns a HTTPFilterExtensionInformer.
+func (v *version) HTTPFilterExtensions() HTTPFilterExtensionInformer {
+	return &hTTPFilterExtensionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // IngressBackends returns a IngressBackendInformer.
 func (v *version) IngressBackends() IngressBackendInformer {
 	return &ingressBackendInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -61,3 +70,8 @@ func (v *version) Retries() RetryInformer {
 func (v *version) UpstreamTrafficSettings() UpstreamTrafficSettingInformer {
 	return &upstreamTrafficSettingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// WorkloadEntries returns a WorkloadEntryInformer.
+func (v *version) WorkloadEntries() WorkloadEntryInformer {
+	return &workloadEntryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
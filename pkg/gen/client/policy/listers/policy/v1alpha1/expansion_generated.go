@@ -23,6 +23,14 @@ type EgressListerExpansion interface{}
 // EgressNamespaceLister.
 type EgressNamespaceListerExpansion interface{}
 
+// HTTPFilterExtensionListerExpansion allows custom methods to be added to
+// HTTPFilterExtensionLister.
+type HTTPFilterExtensionListerExpansion interface{}
+
+// HTTPFilterExtensionNamespaceListerExpansion allows custom methods to be added to
+// HTTPFilterExtensionNamespaceLister.
+type HTTPFilterExtensionNamespaceListerExpansion interface{}
+
 // IngressBackendListerExpansion allows custom methods to be added to
 // IngressBackendLister.
 type IngressBackendListerExpansion interface{}
@@ -46,3 +54,11 @@ type UpstreamTrafficSettingListerExpansion interface{}
 // UpstreamTrafficSettingNamespaceListerExpansion allows custom methods to be added to
 // UpstreamTrafficSettingNamespaceLister.
 type UpstreamTrafficSettingNamespaceListerExpansion interface{}
+
+// WorkloadEntryListerExpansion allows custom methods to be added to
+// WorkloadEntryLister.
+type WorkloadEntryListerExpansion interface{}
+
+// WorkloadEntryNamespaceListerExpansion allows custom methods to be added to
+// WorkloadEntryNamespaceLister.
+type WorkloadEntryNamespaceListerExpansion interface{}
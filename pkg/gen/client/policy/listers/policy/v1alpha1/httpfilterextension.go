@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// HTTPFilterExtensionLister helps list HTTPFilterExtensions.
+// All objects returned here must be treated as read-only.
+type HTTPFilterExtensionLister interface {
+	// List lists all HTTPFilterExtensions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.HTTPFilterExtension, err error)
+	// HTTPFilterExtensions returns an object that can list and get HTTPFilterExtensions.
+	HTTPFilterExtensions(namespace string) HTTPFilterExtensionNamespaceLister
+	HTTPFilterExtensionListerExpansion
+}
+
+// hTTPFilterExtensionLister implements the HTTPFilterExtensionLister interface.
+type hTTPFilterExtensionLister struct {
+	indexer cache.Indexer
+}
+
+// NewHTTPFilterExtensionLister returns a new HTTPFilterExtensionLister.
+func NewHTTPFilterExtensionLister(indexer cache.Indexer) HTTPFilterExtensionLister {
+	return &hTTPFilterExtensionLister{indexer: indexer}
+}
+
+// List lists all HTTPFilterExtensions in the indexer.
+func (s *hTTPFilterExtensionLister) List(selector labels.Selector) (ret []*v1alpha1.HTTPFilterExtension, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.HTTPFilterExtension))
+	})
+	return ret, err
+}
+
+// HTTPFilterExtensions returns an object that can list and get HTTPFilterExtensions.
+func (s *hTTPFilterExtensionLister) HTTPFilterExtensions(namespace string) HTTPFilterExtensionNamespaceLister {
+	return hTTPFilterExtensionNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// HTTPFilterExtensionNamespaceLister helps list and get HTTPFilterExtensions.
+// All objects returned here must be treated as read-only.
+type HTTPFilterExtensionNamespaceLister interface {
+	// List lists all HTTPFilterExtensions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.HTTPFilterExtension, err error)
+	// Get retrieves the HTTPFilterExtension from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.HTTPFilterExtension, error)
+	HTTPFilterExtensionNamespaceListerExpansion
+}
+
+// hTTPFilterExtensionNamespaceLister implements the HTTPFilterExtensionNamespaceLister
+// interface.
+type hTTPFilterExtensionNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all HTTPFilterExtensions in the indexer for a given namespace.
+func (s hTTPFilterExtensionNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.HTTPFilterExtension, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.HTTPFilterExtension))
+	})
+	return ret, err
+}
+
+// Get retrieves the HTTPFilterExtension from the indexer for a given namespace and name.
+func (s hTTPFilterExtensionNamespaceLister) Get(name string) (*v1alpha1.HTTPFilterExtension, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("httpfilterextension"), name)
+	}
+	return obj.(*v1alpha1.HTTPFilterExtension), nil
+}
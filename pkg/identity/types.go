@@ -2,6 +2,7 @@
 package identity
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -9,10 +10,18 @@ import (
 const (
 	// namespaceNameSeparator used for marshalling/unmarshalling MeshService to a string or vice versa
 	namespaceNameSeparator = "/"
+
+	// spiffeScheme is the URI scheme of SPIFFE IDs
+	spiffeScheme = "spiffe://"
 )
 
+// ErrInvalidSPIFFEID is the error for a SPIFFE ID that does not identify a service account
+var ErrInvalidSPIFFEID = errors.New("SPIFFE ID must be of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>")
+
 // ServiceIdentity is the type used to represent the identity for a service
 // For Kubernetes services this string will be in the format: <ServiceAccount>.<Namespace>
+// The identity of a service account of a federated trust domain is its SPIFFE ID, in the format:
+// spiffe://<TrustDomain>/ns/<Namespace>/sa/<ServiceAccount>
 type ServiceIdentity string
 
 // New returns a new ServiceIdentity for the given name and namespace.
@@ -20,6 +29,32 @@ func New(name, namespace string) ServiceIdentity {
 	return ServiceIdentity(fmt.Sprintf("%s.%s", name, namespace))
 }
 
+// NewFromSPIFFEID returns a new ServiceIdentity for the service account identified by the given SPIFFE ID.
+// The SPIFFE ID identifies a service account of a federated trust domain.
+func NewFromSPIFFEID(spiffeID string) (ServiceIdentity, error) {
+	if _, _, _, err := parseSPIFFEID(spiffeID); err != nil {
+		return "", err
+	}
+	return ServiceIdentity(spiffeID), nil
+}
+
+// parseSPIFFEID returns the trust domain, namespace and service account of the given SPIFFE ID
+func parseSPIFFEID(spiffeID string) (trustDomain, namespace, name string, err error) {
+	if !strings.HasPrefix(spiffeID, spiffeScheme) {
+		return "", "", "", fmt.Errorf("%w: %s", ErrInvalidSPIFFEID, spiffeID)
+	}
+	chunks := strings.Split(strings.TrimPrefix(spiffeID, spiffeScheme), "/")
+	if len(chunks) != 5 || chunks[1] != "ns" || chunks[3] != "sa" {
+		return "", "", "", fmt.Errorf("%w: %s", ErrInvalidSPIFFEID, spiffeID)
+	}
+	for _, chunk := range chunks {
+		if chunk == "" {
+			return "", "", "", fmt.Errorf("%w: %s", ErrInvalidSPIFFEID, spiffeID)
+		}
+	}
+	return chunks[0], chunks[2], chunks[4], nil
+}
+
 // WildcardServiceIdentity is a wildcard to match all service identities
 const WildcardServiceIdentity ServiceIdentity = "*"
 
@@ -36,16 +71,67 @@ func (si ServiceIdentity) IsWildcard() bool {
 	return si == WildcardServiceIdentity
 }
 
+// IsFederated determines if the ServiceIdentity is a service account of a federated trust domain
+func (si ServiceIdentity) IsFederated() bool {
+	return strings.HasPrefix(si.String(), spiffeScheme)
+}
+
+// FederatedTrustDomain returns the trust domain of a ServiceIdentity of a federated trust domain,
+// and an empty string for the identities of the mesh.
+func (si ServiceIdentity) FederatedTrustDomain() string {
+	if !si.IsFederated() {
+		return ""
+	}
+	trustDomain, _, _, _ := parseSPIFFEID(si.String())
+	return trustDomain
+}
+
 // AsPrincipal converts the ServiceIdentity to a Principal with the given trust domain.
+// The identities of federated trust domains are qualified by their own trust domain.
 func (si ServiceIdentity) AsPrincipal(trustDomain string) string {
 	if si.IsWildcard() {
 		return si.String()
 	}
+	if si.IsFederated() {
+		return si.ToK8sServiceAccount().ToServiceIdentity().AsPrincipal(si.FederatedTrustDomain())
+	}
 	return fmt.Sprintf("%s.%s", si.String(), trustDomain)
 }
 
+// AsSPIFFEID converts the ServiceIdentity to a SPIFFE ID with the given trust domain,
+// of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>.
+// The identities of federated trust domains are already SPIFFE IDs.
+func (si ServiceIdentity) AsSPIFFEID(trustDomain string) string {
+	if si.IsWildcard() || si.IsFederated() {
+		return si.String()
+	}
+	sa := si.ToK8sServiceAccount()
+	return fmt.Sprintf("spiffe://%s/ns/%s/sa/%s", trustDomain, sa.Namespace, sa.Name)
+}
+
+// AsPrincipals returns the principals the ServiceIdentity can be authenticated as with the given trust domain.
+// Unless spiffeOnly is set, the legacy principal is returned in addition to the SPIFFE ID, so that
+// certificates issued without a SPIFFE ID are still matched.
+func (si ServiceIdentity) AsPrincipals(trustDomain string, spiffeOnly bool) []string {
+	if si.IsWildcard() {
+		return []string{WildcardPrincipal}
+	}
+	if spiffeOnly {
+		return []string{si.AsSPIFFEID(trustDomain)}
+	}
+	return []string{si.AsSPIFFEID(trustDomain), si.AsPrincipal(trustDomain)}
+}
+
 // ToK8sServiceAccount converts a ServiceIdentity to a K8sServiceAccount to help with transition from K8sServiceAccount to ServiceIdentity
 func (si ServiceIdentity) ToK8sServiceAccount() K8sServiceAccount {
+	if si.IsFederated() {
+		_, namespace, name, _ := parseSPIFFEID(si.String())
+		return K8sServiceAccount{
+			Namespace: namespace,
+			Name:      name,
+		}
+	}
+
 	// By convention as of release-v0.8 ServiceIdentity is in the format: <ServiceAccount>.<Namespace>.cluster.local
 	// We can split by "." and will have service account in the first position and namespace in the second.
 	chunks := strings.Split(si.String(), ".")
@@ -77,3 +163,8 @@ func (sa K8sServiceAccount) ToServiceIdentity() ServiceIdentity {
 func (sa K8sServiceAccount) AsPrincipal(trustDomain string) string {
 	return sa.ToServiceIdentity().AsPrincipal(trustDomain)
 }
+
+// AsPrincipals returns the principals the K8sServiceAccount can be authenticated as with the given trust domain.
+func (sa K8sServiceAccount) AsPrincipals(trustDomain string, spiffeOnly bool) []string {
+	return sa.ToServiceIdentity().AsPrincipals(trustDomain, spiffeOnly)
+}
@@ -21,6 +21,15 @@ func TestServiceIdentityType(t *testing.T) {
 
 	// Test ToK8sServiceAccount()
 	assert.Equal(K8sServiceAccount{Name: "foo", Namespace: "bar"}, si.ToK8sServiceAccount())
+
+	// Test AsSPIFFEID()
+	assert.Equal("spiffe://cluster.local/ns/bar/sa/foo", si.AsSPIFFEID("cluster.local"))
+	assert.Equal("*", wildcard.AsSPIFFEID("cluster.local"))
+
+	// Test AsPrincipals()
+	assert.Equal([]string{"spiffe://cluster.local/ns/bar/sa/foo", "foo.bar.cluster.local"}, si.AsPrincipals("cluster.local", false))
+	assert.Equal([]string{"spiffe://cluster.local/ns/bar/sa/foo"}, si.AsPrincipals("cluster.local", true))
+	assert.Equal([]string{WildcardPrincipal}, wildcard.AsPrincipals("cluster.local", false))
 }
 
 func TestK8sServiceAccountType(t *testing.T) {
@@ -56,3 +65,59 @@ func TestToServiceIdentity(t *testing.T) {
 		assert.Equal(si, tc.expectedServiceIdentity)
 	}
 }
+
+func TestNewFromSPIFFEID(t *testing.T) {
+	testCases := []struct {
+		spiffeID             string
+		expectErr            bool
+		expectedSvcAccount   K8sServiceAccount
+		expectedTrustDomain  string
+		expectedPrincipalSet []string
+	}{
+		{
+			spiffeID:             "spiffe://west.mesh/ns/bar/sa/foo",
+			expectedSvcAccount:   K8sServiceAccount{Name: "foo", Namespace: "bar"},
+			expectedTrustDomain:  "west.mesh",
+			expectedPrincipalSet: []string{"spiffe://west.mesh/ns/bar/sa/foo", "foo.bar.west.mesh"},
+		},
+		{
+			spiffeID:  "foo.bar",
+			expectErr: true,
+		},
+		{
+			spiffeID:  "spiffe://west.mesh/ns/bar",
+			expectErr: true,
+		},
+		{
+			spiffeID:  "spiffe://west.mesh/namespace/bar/serviceaccount/foo",
+			expectErr: true,
+		},
+		{
+			spiffeID:  "spiffe://west.mesh/ns//sa/foo",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.spiffeID, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			si, err := NewFromSPIFFEID(tc.spiffeID)
+			if tc.expectErr {
+				assert.ErrorIs(err, ErrInvalidSPIFFEID)
+				return
+			}
+			assert.NoError(err)
+			assert.True(si.IsFederated())
+			assert.Equal(tc.expectedTrustDomain, si.FederatedTrustDomain())
+			assert.Equal(tc.expectedSvcAccount, si.ToK8sServiceAccount())
+			// the mesh trust domain does not apply to federated identities
+			assert.Equal(tc.spiffeID, si.AsSPIFFEID("cluster.local"))
+			assert.Equal(tc.expectedPrincipalSet, si.AsPrincipals("cluster.local", false))
+		})
+	}
+
+	assert := tassert.New(t)
+	assert.False(ServiceIdentity("foo.bar").IsFederated())
+	assert.Empty(ServiceIdentity("foo.bar").FederatedTrustDomain())
+}
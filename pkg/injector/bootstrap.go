@@ -3,13 +3,16 @@ package injector
 import (
 	"context"
 	"fmt"
+	"path"
 
 	xds_bootstrap "github.com/envoyproxy/go-control-plane/envoy/config/bootstrap/v3"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/csr"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
 	"github.com/openservicemesh/osm/pkg/errcode"
@@ -18,6 +21,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/version"
 )
 
+// kubernetesServiceName is the name of the Service of the Kubernetes API server in the default namespace
+const kubernetesServiceName = "kubernetes"
+
 // This will read an existing envoy bootstrap config, and create a new copy by changing the NodeID, and certificates.
 func (wh *mutatingWebhook) createEnvoyBootstrapFromExisting(proxyUUID uuid.UUID, oldBootstrapSecretName, namespace string, cert *certificate.Certificate) (*corev1.Secret, error) {
 	existing, err := wh.kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), oldBootstrapSecretName, metav1.GetOptions{})
@@ -36,7 +42,19 @@ func (wh *mutatingWebhook) createEnvoyBootstrapFromExisting(proxyUUID uuid.UUID,
 	return wh.marshalAndSaveBootstrap(bootstrapConfigName(proxyUUID), namespace, config, cert)
 }
 
-func (wh *mutatingWebhook) createEnvoyBootstrapConfig(proxyUUID uuid.UUID, namespace string, cert *certificate.Certificate, originalHealthProbes models.HealthProbes) (*corev1.Secret, error) {
+func (wh *mutatingWebhook) createEnvoyBootstrapConfig(proxyUUID uuid.UUID, namespace string, cert *certificate.Certificate, originalHealthProbes models.HealthProbes, tokenAuthentication bool) (*corev1.Secret, error) {
+	bootstrapConfig, err := wh.buildEnvoyBootstrapConfig(proxyUUID, originalHealthProbes, tokenAuthentication)
+	if err != nil {
+		return nil, err
+	}
+
+	return wh.marshalAndSaveBootstrap(bootstrapConfigName(proxyUUID), namespace, bootstrapConfig, cert)
+}
+
+// buildEnvoyBootstrapConfig builds the Envoy bootstrap config for the proxy with the given UUID. The proxy
+// authenticates to the xDS server with the service account token projected in its pod if tokenAuthentication is
+// set, and with the client certificate of its bootstrap Secret otherwise.
+func (wh *mutatingWebhook) buildEnvoyBootstrapConfig(proxyUUID uuid.UUID, originalHealthProbes models.HealthProbes, tokenAuthentication bool) (*xds_bootstrap.Bootstrap, error) {
 	builder := bootstrap.Builder{
 		NodeID: proxyUUID.String(),
 
@@ -50,32 +68,46 @@ func (wh *mutatingWebhook) createEnvoyBootstrapConfig(proxyUUID uuid.UUID, names
 		TLSMaxProtocolVersion: wh.kubeController.GetMeshConfig().Spec.Sidecar.TLSMaxProtocolVersion,
 		CipherSuites:          wh.kubeController.GetMeshConfig().Spec.Sidecar.CipherSuites,
 		ECDHCurves:            wh.kubeController.GetMeshConfig().Spec.Sidecar.ECDHCurves,
+
+		IPv6HealthProbes: wh.ipv6PrimaryCluster,
 	}
-	bootstrapConfig, err := builder.Build()
-	if err != nil {
-		return nil, err
+	if tokenAuthentication {
+		builder.TokenExchangeURI = fmt.Sprintf("https://%s.%s.svc:%d%s", constants.OSMControllerName, wh.osmNamespace, constants.CertificateRequestPort, csr.TokenExchangePath)
+		builder.ServiceAccountTokenPath = path.Join(constants.PodTokenDir, constants.PodTokenFile)
+		builder.XDSServerName = fmt.Sprintf("%s.%s", constants.ADSServerCertificateCommonNamePrefix, wh.certManager.GetTrustDomain())
 	}
-
-	return wh.marshalAndSaveBootstrap(bootstrapConfigName(proxyUUID), namespace, bootstrapConfig, cert)
+	return builder.Build()
 }
 
-func (wh *mutatingWebhook) marshalAndSaveBootstrap(name, namespace string, config *xds_bootstrap.Bootstrap, cert *certificate.Certificate) (*corev1.Secret, error) {
-	configYAML, err := utils.ProtoToYAML(config)
+// isIPv6PrimaryCluster returns whether the primary IP family of the cluster, given by the kubernetes Service of the
+// default namespace, is IPv6
+func isIPv6PrimaryCluster(kubeClient kubernetes.Interface) bool {
+	svc, err := kubeClient.CoreV1().Services(metav1.NamespaceDefault).Get(context.Background(), kubernetesServiceName, metav1.GetOptions{})
 	if err != nil {
-		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingProtoToYAML)).
-			Msgf("Failed to marshal envoy bootstrap config to yaml")
-		return nil, err
+		log.Error().Err(err).Msgf("Error getting Service %s/%s, the health probes of the Envoy sidecars are served over IPv4", metav1.NamespaceDefault, kubernetesServiceName)
+		return false
 	}
+	return len(svc.Spec.IPFamilies) > 0 && svc.Spec.IPFamilies[0] == corev1.IPv6Protocol
+}
 
-	tlsYamlContent, err := bootstrap.GetTLSSDSConfigYAML()
+func (wh *mutatingWebhook) marshalAndSaveBootstrap(name, namespace string, config *xds_bootstrap.Bootstrap, cert *certificate.Certificate) (*corev1.Secret, error) {
+	secret, err := wh.newBootstrapSecret(name, config, cert)
 	if err != nil {
-		log.Error().Err(err).Msg("Error creating Envoy TLS Certificate SDS Config YAML")
 		return nil, err
 	}
 
-	validationYamlContent, err := bootstrap.GetValidationContextSDSConfigYAML()
+	log.Debug().Msgf("Creating bootstrap config for Envoy: name=%s, namespace=%s", name, namespace)
+	return wh.kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+}
+
+// newBootstrapSecret returns the Secret holding the given Envoy bootstrap config and the certificate
+// used by Envoy to connect to the control plane. The Secret of a proxy authenticating with the service account token
+// of its pod only holds the CA the certificate of the xDS server is verified with.
+func (wh *mutatingWebhook) newBootstrapSecret(name string, config *xds_bootstrap.Bootstrap, cert *certificate.Certificate) (*corev1.Secret, error) {
+	configYAML, err := utils.ProtoToYAML(config)
 	if err != nil {
-		log.Error().Err(err).Msg("Error creating Envoy Validation Context SDS Config YAML")
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingProtoToYAML)).
+			Msgf("Failed to marshal envoy bootstrap config to yaml")
 		return nil, err
 	}
 
@@ -89,15 +121,29 @@ func (wh *mutatingWebhook) marshalAndSaveBootstrap(name, namespace string, confi
 			},
 		},
 		Data: map[string][]byte{
-			bootstrap.EnvoyBootstrapConfigFile:            configYAML,
-			bootstrap.EnvoyTLSCertificateSDSSecretFile:    tlsYamlContent,
-			bootstrap.EnvoyValidationContextSDSSecretFile: validationYamlContent,
-			bootstrap.EnvoyXDSCACertFile:                  cert.GetTrustedCAs(),
-			bootstrap.EnvoyXDSCertFile:                    cert.GetCertificateChain(),
-			bootstrap.EnvoyXDSKeyFile:                     cert.GetPrivateKey(),
+			bootstrap.EnvoyBootstrapConfigFile: configYAML,
+			bootstrap.EnvoyXDSCACertFile:       cert.GetTrustedCAs(),
 		},
 	}
+	if len(cert.GetPrivateKey()) == 0 {
+		return secret, nil
+	}
 
-	log.Debug().Msgf("Creating bootstrap config for Envoy: name=%s, namespace=%s", name, namespace)
-	return wh.kubeClient.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	tlsYamlContent, err := bootstrap.GetTLSSDSConfigYAML()
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating Envoy TLS Certificate SDS Config YAML")
+		return nil, err
+	}
+
+	validationYamlContent, err := bootstrap.GetValidationContextSDSConfigYAML()
+	if err != nil {
+		log.Error().Err(err).Msg("Error creating Envoy Validation Context SDS Config YAML")
+		return nil, err
+	}
+
+	secret.Data[bootstrap.EnvoyTLSCertificateSDSSecretFile] = tlsYamlContent
+	secret.Data[bootstrap.EnvoyValidationContextSDSSecretFile] = validationYamlContent
+	secret.Data[bootstrap.EnvoyXDSCertFile] = cert.GetCertificateChain()
+	secret.Data[bootstrap.EnvoyXDSKeyFile] = cert.GetPrivateKey()
+	return secret, nil
 }
@@ -1,12 +1,16 @@
 package injector
 
 import (
+	"testing"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	tassert "github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
 
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
@@ -87,7 +91,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 
 	Context("test unix getEnvoySidecarContainerSpec()", func() {
 		It("creates Envoy sidecar spec", func() {
-			actual := getEnvoySidecarContainerSpec(pod, meshConfig, originalHealthProbes, constants.OSLinux)
+			actual := getEnvoySidecarContainerSpec(pod, meshConfig, originalHealthProbes, constants.OSLinux, 0)
 
 			expected := corev1.Container{
 				Name:            constants.EnvoyContainerName,
@@ -103,7 +107,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 				Ports: expectedRewrittenContainerPorts,
 				VolumeMounts: []corev1.VolumeMount{
 					{
-						Name:      envoyBootstrapConfigVolume,
+						Name:      constants.EnvoyBootstrapConfigVolume,
 						ReadOnly:  true,
 						MountPath: bootstrap.EnvoyProxyConfigPath,
 					},
@@ -200,7 +204,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 
 	Context("test Windows getEnvoySidecarContainerSpec()", func() {
 		It("creates Envoy sidecar spec", func() {
-			actual := getEnvoySidecarContainerSpec(pod, meshConfig, originalHealthProbes, constants.OSWindows)
+			actual := getEnvoySidecarContainerSpec(pod, meshConfig, originalHealthProbes, constants.OSWindows, 0)
 
 			expected := corev1.Container{
 				Name:            constants.EnvoyContainerName,
@@ -217,7 +221,7 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 				Ports: expectedRewrittenContainerPorts,
 				VolumeMounts: []corev1.VolumeMount{
 					{
-						Name:      envoyBootstrapConfigVolume,
+						Name:      constants.EnvoyBootstrapConfigVolume,
 						ReadOnly:  true,
 						MountPath: bootstrap.EnvoyProxyConfigPath,
 					},
@@ -312,3 +316,57 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 		})
 	})
 })
+
+func TestIsIPv6PrimaryCluster(t *testing.T) {
+	testCases := []struct {
+		name       string
+		ipFamilies []corev1.IPFamily
+		expected   bool
+	}{
+		{
+			name:       "IPv4 cluster",
+			ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+			expected:   false,
+		},
+		{
+			name:       "IPv6 cluster",
+			ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			expected:   true,
+		},
+		{
+			name:       "dual-stack cluster with IPv6 primary",
+			ipFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			expected:   true,
+		},
+		{
+			name:       "dual-stack cluster with IPv4 primary",
+			ipFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			expected:   false,
+		},
+		{
+			name:     "kubernetes Service not found",
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			kubeClient := fake.NewSimpleClientset()
+			if tc.ipFamilies != nil {
+				kubeClient = fake.NewSimpleClientset(&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      kubernetesServiceName,
+						Namespace: metav1.NamespaceDefault,
+					},
+					Spec: corev1.ServiceSpec{
+						IPFamilies: tc.ipFamilies,
+					},
+				})
+			}
+
+			assert.Equal(tc.expected, isIPv6PrimaryCluster(kubeClient))
+		})
+	}
+}
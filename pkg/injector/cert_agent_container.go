@@ -0,0 +1,89 @@
+package injector
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+const (
+	// certAgentCertVolume is the name of the in-memory volume the osm-cert-agent container writes the certificate
+	// and private key of the Envoy sidecar to
+	certAgentCertVolume = "osm-cert-agent-certs"
+)
+
+// getCertAgentVolume returns the in-memory volume the osm-cert-agent container writes the certificate and private key
+// of the Envoy sidecar to. The container also mounts the volume of getPodTokenVolume.
+func getCertAgentVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: certAgentCertVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium: corev1.StorageMediumMemory,
+			},
+		},
+	}
+}
+
+// getCertAgentVolumeMount returns the read-only mount of the certificate and private key of the Envoy sidecar
+func getCertAgentVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      certAgentCertVolume,
+		ReadOnly:  true,
+		MountPath: constants.CertAgentCertDir,
+	}
+}
+
+// getCertAgentContainerSpec returns the osm-cert-agent container generating the private key of the Envoy sidecar,
+// and requesting its certificate from osm-controller. The init container only requests the first certificate, holding
+// the start of the Envoy sidecar until it is written. The agent runs as the Envoy user, so that its requests to
+// osm-controller are not redirected to the Envoy sidecar, and the private key is only readable by the sidecar.
+func getCertAgentContainerSpec(name string, pod *corev1.Pod, namespace string, meshConfig v1alpha2.MeshConfig, osmNamespace string, pullPolicy corev1.PullPolicy, init bool) corev1.Container {
+	args := []string{
+		"--verbosity", log.GetLevel().String(),
+		"--controller-address", fmt.Sprintf("https://%s.%s.svc:%d", constants.OSMControllerName, osmNamespace, constants.CertificateRequestPort),
+		"--service-account", pod.Spec.ServiceAccountName,
+		"--namespace", namespace,
+		"--key-bit-size", strconv.Itoa(utils.GetCertKeyBitSize(meshConfig)),
+	}
+	if init {
+		args = append(args, "--once")
+	}
+
+	return corev1.Container{
+		Name:            name,
+		Image:           os.Getenv("OSM_DEFAULT_CERT_AGENT_CONTAINER_IMAGE"),
+		ImagePullPolicy: pullPolicy,
+		Command:         []string{"/osm-cert-agent"},
+		Args:            args,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      certAgentCertVolume,
+				MountPath: constants.CertAgentCertDir,
+			},
+			getPodTokenVolumeMount(),
+			{
+				Name:      constants.EnvoyBootstrapConfigVolume,
+				ReadOnly:  true,
+				MountPath: bootstrap.EnvoyProxyConfigPath,
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.BoolPtr(false),
+			ReadOnlyRootFilesystem:   pointer.BoolPtr(true),
+			RunAsNonRoot:             pointer.BoolPtr(true),
+			RunAsUser:                pointer.Int64Ptr(constants.EnvoyUID),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package injector
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// healthcheckBinVolume is the name of the volume the osm-healthcheck container installs its binary to
+	healthcheckBinVolume = "osm-healthcheck-bin"
+
+	// healthcheckBinDir is the directory the volume the osm-healthcheck container installs its binary to is mounted at
+	healthcheckBinDir = "/usr/local/osm-healthcheck"
+)
+
+// getSidecarDrainDuration returns the maximum duration the sidecar of the given pod drains its listeners for when the
+// pod terminates, 0 meaning draining is disabled. The pod's openservicemesh.io/sidecar-drain-duration annotation
+// overrides the MeshConfig's sidecar.drainDuration.
+//
+// The drain must complete within the termination grace period of the pod: an annotation exceeding it is rejected,
+// while the mesh-wide duration is capped to it.
+func getSidecarDrainDuration(pod *corev1.Pod, meshConfig v1alpha2.MeshConfig) (time.Duration, error) {
+	gracePeriod := time.Duration(corev1.DefaultTerminationGracePeriodSeconds) * time.Second
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		gracePeriod = time.Duration(*pod.Spec.TerminationGracePeriodSeconds) * time.Second
+	}
+
+	if durationStr, ok := pod.Annotations[constants.SidecarDrainDurationAnnotation]; ok {
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil || duration < 0 {
+			return 0, fmt.Errorf("invalid value %q for annotation %s, expected a non-negative duration", durationStr, constants.SidecarDrainDurationAnnotation)
+		}
+		if duration > gracePeriod {
+			return 0, fmt.Errorf("sidecar drain duration %s specified by annotation %s exceeds the pod's termination grace period of %s",
+				duration, constants.SidecarDrainDurationAnnotation, gracePeriod)
+		}
+		return duration, nil
+	}
+
+	durationStr := meshConfig.Spec.Sidecar.DrainDuration
+	if durationStr == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil || duration < 0 {
+		log.Error().Err(err).Msgf("Invalid MeshConfig sidecar.drainDuration %q, sidecar draining is disabled", durationStr)
+		return 0, nil
+	}
+	if duration > gracePeriod {
+		log.Warn().Msgf("MeshConfig sidecar.drainDuration %s exceeds the termination grace period of %s of pod %s/%s, capping it to the grace period",
+			duration, gracePeriod, pod.Namespace, pod.Name)
+		return gracePeriod, nil
+	}
+	return duration, nil
+}
+
+// getEnvoyDrainArgs returns the Envoy arguments making the drain of its listeners last for the given duration
+func getEnvoyDrainArgs(drainDuration time.Duration) []string {
+	return []string{
+		"--drain-time-s", strconv.Itoa(int(math.Ceil(drainDuration.Seconds()))),
+		"--drain-strategy", "immediate",
+	}
+}
+
+// getEnvoyDrainLifecycle returns the lifecycle of the Envoy sidecar, whose preStop hook drains Envoy with the
+// osm-healthcheck binary installed in the volume shared with the osm-healthcheck container, and waits for the drain to
+// complete before Envoy is sent SIGTERM. The drain is requested on Envoy's admin interface, only reachable from within
+// the pod.
+func getEnvoyDrainLifecycle(drainDuration time.Duration) *corev1.Lifecycle {
+	return &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{
+					filepath.Join(healthcheckBinDir, healthcheckContainerName),
+					"--drain",
+					"--drain-duration", drainDuration.String(),
+				},
+			},
+		},
+	}
+}
+
+// getHealthcheckBinVolume returns the volume the osm-healthcheck container installs its binary to, for the preStop
+// hook of the Envoy sidecar
+func getHealthcheckBinVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: healthcheckBinVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium: corev1.StorageMediumMemory,
+			},
+		},
+	}
+}
+
+// getHealthcheckBinVolumeMount returns the mount of the volume the osm-healthcheck container installs its binary to
+func getHealthcheckBinVolumeMount(readOnly bool) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      healthcheckBinVolume,
+		ReadOnly:  readOnly,
+		MountPath: healthcheckBinDir,
+	}
+}
@@ -0,0 +1,146 @@
+package injector
+
+import (
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/models"
+)
+
+func TestGetSidecarDrainDuration(t *testing.T) {
+	testCases := []struct {
+		name               string
+		annotations        map[string]string
+		gracePeriodSeconds *int64
+		meshDrainDuration  string
+		expectedDuration   time.Duration
+		expectErr          bool
+	}{
+		{
+			name:             "draining disabled by default",
+			expectedDuration: 0,
+		},
+		{
+			name:              "MeshConfig drain duration",
+			meshDrainDuration: "20s",
+			expectedDuration:  20 * time.Second,
+		},
+		{
+			name:              "invalid MeshConfig drain duration disables draining",
+			meshDrainDuration: "invalid",
+			expectedDuration:  0,
+		},
+		{
+			name:               "MeshConfig drain duration capped to the termination grace period",
+			meshDrainDuration:  "1m",
+			gracePeriodSeconds: pointer.Int64(45),
+			expectedDuration:   45 * time.Second,
+		},
+		{
+			name:              "MeshConfig drain duration capped to the default termination grace period",
+			meshDrainDuration: "1m",
+			expectedDuration:  30 * time.Second,
+		},
+		{
+			name:              "annotation overrides MeshConfig drain duration",
+			annotations:       map[string]string{constants.SidecarDrainDurationAnnotation: "10s"},
+			meshDrainDuration: "20s",
+			expectedDuration:  10 * time.Second,
+		},
+		{
+			name:              "annotation disables draining",
+			annotations:       map[string]string{constants.SidecarDrainDurationAnnotation: "0s"},
+			meshDrainDuration: "20s",
+			expectedDuration:  0,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{constants.SidecarDrainDurationAnnotation: "10"},
+			expectErr:   true,
+		},
+		{
+			name:        "negative annotation",
+			annotations: map[string]string{constants.SidecarDrainDurationAnnotation: "-10s"},
+			expectErr:   true,
+		},
+		{
+			name:               "annotation exceeding the termination grace period",
+			annotations:        map[string]string{constants.SidecarDrainDurationAnnotation: "1m"},
+			gracePeriodSeconds: pointer.Int64(30),
+			expectErr:          true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pod",
+					Namespace:   "ns",
+					Annotations: tc.annotations,
+				},
+				Spec: corev1.PodSpec{
+					TerminationGracePeriodSeconds: tc.gracePeriodSeconds,
+				},
+			}
+			meshConfig := v1alpha2.MeshConfig{
+				Spec: v1alpha2.MeshConfigSpec{
+					Sidecar: v1alpha2.SidecarSpec{
+						DrainDuration: tc.meshDrainDuration,
+					},
+				},
+			}
+
+			duration, err := getSidecarDrainDuration(pod, meshConfig)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.Equal(tc.expectedDuration, duration)
+		})
+	}
+}
+
+func TestGetEnvoySidecarContainerSpecWithDrain(t *testing.T) {
+	assert := tassert.New(t)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "ns",
+		},
+	}
+
+	container := getEnvoySidecarContainerSpec(pod, v1alpha2.MeshConfig{}, models.HealthProbes{}, constants.OSLinux, 0)
+	assert.Nil(container.Lifecycle)
+	assert.NotContains(container.Args, "--drain-time-s")
+
+	container = getEnvoySidecarContainerSpec(pod, v1alpha2.MeshConfig{}, models.HealthProbes{}, constants.OSLinux, 1500*time.Millisecond)
+	assert.Equal([]string{"--drain-time-s", "2", "--drain-strategy", "immediate"}, container.Args[len(container.Args)-4:])
+	assert.NotNil(container.Lifecycle)
+	assert.NotNil(container.Lifecycle.PreStop)
+	assert.NotNil(container.Lifecycle.PreStop.Exec)
+	assert.Equal([]string{"/usr/local/osm-healthcheck/osm-healthcheck", "--drain", "--drain-duration", "1.5s"}, container.Lifecycle.PreStop.Exec.Command)
+	assert.Contains(container.VolumeMounts, getHealthcheckBinVolumeMount(true))
+}
+
+func TestGetHealthcheckContainerSpecWithDrain(t *testing.T) {
+	assert := tassert.New(t)
+
+	container := getHealthcheckContainerSpec(corev1.PullIfNotPresent, 0, false)
+	assert.Nil(container.Lifecycle)
+	assert.NotContains(container.Args, "--install-dir")
+	assert.Empty(container.VolumeMounts)
+
+	// The osm-healthcheck container installs the binary run by the Envoy sidecar's preStop hook, it has no hook itself
+	container = getHealthcheckContainerSpec(corev1.PullIfNotPresent, 1500*time.Millisecond, false)
+	assert.Nil(container.Lifecycle)
+	assert.Equal([]string{"--install-dir", healthcheckBinDir}, container.Args[len(container.Args)-2:])
+	assert.Equal([]corev1.VolumeMount{getHealthcheckBinVolumeMount(false)}, container.VolumeMounts)
+}
@@ -3,6 +3,7 @@ package injector
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/pointer"
@@ -38,7 +39,7 @@ func getPlatformSpecificSpecComponents(meshConfig v1alpha2.MeshConfig, podOS str
 	return
 }
 
-func getEnvoySidecarContainerSpec(pod *corev1.Pod, meshConfig v1alpha2.MeshConfig, originalHealthProbes models.HealthProbes, podOS string) corev1.Container {
+func getEnvoySidecarContainerSpec(pod *corev1.Pod, meshConfig v1alpha2.MeshConfig, originalHealthProbes models.HealthProbes, podOS string, drainDuration time.Duration) corev1.Container {
 	// cluster ID will be used as an identifier to the tracing sink
 	clusterID := fmt.Sprintf("%s.%s", pod.Spec.ServiceAccountName, pod.Namespace)
 	securityContext, containerImage := getPlatformSpecificSpecComponents(meshConfig, podOS)
@@ -47,14 +48,14 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, meshConfig v1alpha2.MeshConfi
 	if logLevel == "" {
 		logLevel = constants.DefaultEnvoyLogLevel
 	}
-	return corev1.Container{
+	container := corev1.Container{
 		Name:            constants.EnvoyContainerName,
 		Image:           containerImage,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		SecurityContext: securityContext,
 		Ports:           getEnvoyContainerPorts(originalHealthProbes),
 		VolumeMounts: []corev1.VolumeMount{{
-			Name:      envoyBootstrapConfigVolume,
+			Name:      constants.EnvoyBootstrapConfigVolume,
 			ReadOnly:  true,
 			MountPath: bootstrap.EnvoyProxyConfigPath,
 		}},
@@ -108,6 +109,16 @@ func getEnvoySidecarContainerSpec(pod *corev1.Pod, meshConfig v1alpha2.MeshConfi
 			},
 		},
 	}
+
+	// Drain the listeners of Envoy before it is sent SIGTERM, so that it keeps serving the in-flight requests
+	// while the other containers of the pod terminate
+	if drainDuration > 0 {
+		container.Args = append(container.Args, getEnvoyDrainArgs(drainDuration)...)
+		container.Lifecycle = getEnvoyDrainLifecycle(drainDuration)
+		container.VolumeMounts = append(container.VolumeMounts, getHealthcheckBinVolumeMount(true))
+	}
+
+	return container
 }
 
 func getEnvoyContainerPorts(originalHealthProbes models.HealthProbes) []corev1.ContainerPort {
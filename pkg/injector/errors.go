@@ -5,4 +5,6 @@ import "fmt"
 var (
 	errNamespaceNotFound   = fmt.Errorf("namespace not found")
 	errNilAdmissionRequest = fmt.Errorf("nil admission request")
+
+	errNativeSidecarsNotSupported = fmt.Errorf("MeshConfig sidecar.nativeSidecarMode is Enabled but the Kubernetes API server does not support native sidecar containers")
 )
@@ -143,6 +143,13 @@ func TestGetOutboundIPRangeListForPod(t *testing.T) {
 			expectedError:    nil,
 			expectedIPRanges: []string{"10.0.0.0/8", "2.2.2.2/32"},
 		},
+		{
+			name:             "valid exclusion annotation with IPv6 ranges",
+			podAnnotation:    map[string]string{outboundIPRangeExclusionListAnnotation: "10.0.0.0/8, fd00:10::/64, 2001:db8::1/128"},
+			forAnnotation:    outboundIPRangeExclusionListAnnotation,
+			expectedError:    nil,
+			expectedIPRanges: []string{"10.0.0.0/8", "fd00:10::/64", "2001:db8::1/128"},
+		},
 		{
 			name:             "no exclusion annotation",
 			podAnnotation:    nil,
@@ -0,0 +1,49 @@
+package injector
+
+import (
+	"os"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// healthcheckContainerName is the name of the osm-healthcheck container
+	healthcheckContainerName = "osm-healthcheck"
+)
+
+// getHealthcheckContainerSpec returns the osm-healthcheck container injected alongside the Envoy sidecar. It serves
+// the rewritten TCPSocket health probes, holds the start of the application containers until Envoy is ready when
+// holdApplication is set, and installs the binary draining Envoy on pod termination when drainDuration is positive.
+func getHealthcheckContainerSpec(pullPolicy corev1.PullPolicy, drainDuration time.Duration, holdApplication bool) corev1.Container {
+	container := corev1.Container{
+		Name:            healthcheckContainerName,
+		Image:           os.Getenv("OSM_DEFAULT_HEALTHCHECK_CONTAINER_IMAGE"),
+		ImagePullPolicy: pullPolicy,
+		Args: []string{
+			"--verbosity", log.GetLevel().String(),
+		},
+		Command: []string{
+			"/osm-healthcheck",
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: constants.HealthcheckPort,
+			},
+		},
+	}
+
+	if holdApplication {
+		container.Lifecycle = &corev1.Lifecycle{
+			PostStart: getHealthcheckHoldHandler(),
+		}
+	}
+	if drainDuration > 0 {
+		container.Args = append(container.Args, "--install-dir", healthcheckBinDir)
+		container.VolumeMounts = append(container.VolumeMounts, getHealthcheckBinVolumeMount(false))
+	}
+
+	return container
+}
@@ -0,0 +1,42 @@
+package injector
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// isApplicationHeldUntilProxyStarts returns whether the start of the application containers of the given pod is held
+// until its sidecar is ready. The pod's openservicemesh.io/hold-application-until-proxy-starts annotation overrides
+// the MeshConfig's sidecar.holdApplicationUntilProxyStarts.
+func isApplicationHeldUntilProxyStarts(pod *corev1.Pod, meshConfig v1alpha2.MeshConfig) (bool, error) {
+	holdStr, ok := pod.Annotations[constants.HoldApplicationUntilProxyStartsAnnotation]
+	if !ok {
+		return meshConfig.Spec.Sidecar.HoldApplicationUntilProxyStarts, nil
+	}
+
+	hold, err := strconv.ParseBool(holdStr)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for annotation %s, expected a boolean", holdStr, constants.HoldApplicationUntilProxyStartsAnnotation)
+	}
+	return hold, nil
+}
+
+// getHealthcheckHoldHandler returns the postStart hook of the osm-healthcheck container, started right after the Envoy
+// sidecar. The containers of a pod being started in order, each once the postStart hook of the previous one completes,
+// the hook holds the start of the application containers until Envoy received and initialized its listeners and
+// clusters.
+func getHealthcheckHoldHandler() *corev1.LifecycleHandler {
+	return &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{
+			Command: []string{
+				"/osm-healthcheck",
+				"--wait-for-envoy-ready",
+			},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestIsApplicationHeldUntilProxyStarts(t *testing.T) {
+	testCases := []struct {
+		name         string
+		annotations  map[string]string
+		meshHold     bool
+		expectedHold bool
+		expectErr    bool
+	}{
+		{
+			name:         "not held by default",
+			expectedHold: false,
+		},
+		{
+			name:         "held by MeshConfig",
+			meshHold:     true,
+			expectedHold: true,
+		},
+		{
+			name:         "held by annotation",
+			annotations:  map[string]string{constants.HoldApplicationUntilProxyStartsAnnotation: "true"},
+			expectedHold: true,
+		},
+		{
+			name:         "annotation overrides MeshConfig",
+			annotations:  map[string]string{constants.HoldApplicationUntilProxyStartsAnnotation: "false"},
+			meshHold:     true,
+			expectedHold: false,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{constants.HoldApplicationUntilProxyStartsAnnotation: "maybe"},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+			}
+			meshConfig := v1alpha2.MeshConfig{
+				Spec: v1alpha2.MeshConfigSpec{
+					Sidecar: v1alpha2.SidecarSpec{
+						HoldApplicationUntilProxyStarts: tc.meshHold,
+					},
+				},
+			}
+
+			hold, err := isApplicationHeldUntilProxyStarts(pod, meshConfig)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.Equal(tc.expectedHold, hold)
+		})
+	}
+}
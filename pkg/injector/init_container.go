@@ -12,7 +12,12 @@ func getInitContainerSpec(containerName string, meshConfig v1alpha2.MeshConfig,
 	outboundIPRangeInclusionList []string, outboundPortExclusionList []int,
 	inboundPortExclusionList []int, enablePrivilegedInitContainer bool, pullPolicy corev1.PullPolicy, networkInterfaceExclusionList []string) corev1.Container {
 	proxyMode := meshConfig.Spec.Sidecar.LocalProxyMode
-	iptablesInitCommand := generateIptablesCommands(proxyMode, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	var redirectionCommand string
+	if useNftables(meshConfig) {
+		redirectionCommand = generateNftablesCommands(proxyMode, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	} else {
+		redirectionCommand = generateIptablesCommands(proxyMode, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	}
 
 	return corev1.Container{
 		Name:            containerName,
@@ -32,15 +37,16 @@ func getInitContainerSpec(containerName string, meshConfig v1alpha2.MeshConfig,
 		Command: []string{"/bin/sh"},
 		Args: []string{
 			"-c",
-			iptablesInitCommand,
+			redirectionCommand,
 		},
 		Env: []corev1.EnvVar{
 			{
-				Name: "POD_IP",
+				// The IP addresses of each IP family of the pod, comma separated
+				Name: "POD_IPS",
 				ValueFrom: &corev1.EnvVarSource{
 					FieldRef: &corev1.ObjectFieldSelector{
 						APIVersion: "v1",
-						FieldPath:  "status.podIP",
+						FieldPath:  "status.podIPs",
 					},
 				},
 			},
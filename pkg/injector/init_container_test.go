@@ -38,7 +38,14 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 				Command:         []string{"/bin/sh"},
 				Args: []string{
 					"-c",
-					`iptables-restore --noflush <<EOF
+					`for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+iptables-restore --noflush <<EOF
 # OSM sidecar interception rules
 *nat
 :OSM_PROXY_INBOUND - [0:0]
@@ -63,6 +70,34 @@ var _ = Describe("Test functions creating Envoy bootstrap configuration", func()
 -A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
 COMMIT
 EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+ip6tables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d ::1/128 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN
+-A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
+COMMIT
+EOF
+fi
 `,
 				},
 				WorkingDir: "",
@@ -79,11 +114,11 @@ EOF
 				},
 				Env: []corev1.EnvVar{
 					{
-						Name: "POD_IP",
+						Name: "POD_IPS",
 						ValueFrom: &corev1.EnvVarSource{
 							FieldRef: &corev1.ObjectFieldSelector{
 								APIVersion: "v1",
-								FieldPath:  "status.podIP",
+								FieldPath:  "status.podIPs",
 							},
 						},
 					},
@@ -113,7 +148,14 @@ EOF
 				Command:         []string{"/bin/sh"},
 				Args: []string{
 					"-c",
-					`iptables-restore --noflush <<EOF
+					`for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+iptables-restore --noflush <<EOF
 # OSM sidecar interception rules
 *nat
 :OSM_PROXY_INBOUND - [0:0]
@@ -135,10 +177,39 @@ EOF
 -A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
 -A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
 -A OSM_PROXY_OUTBOUND -d 127.0.0.1/32 -j RETURN
--I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IP
+-I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IPV4
 -A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
 COMMIT
 EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+ip6tables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d ::1/128 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN
+-I OUTPUT -p tcp -o lo -d ::1/128 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IPV6
+-A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
+COMMIT
+EOF
+fi
 `,
 				},
 				WorkingDir: "",
@@ -155,11 +226,11 @@ EOF
 				},
 				Env: []corev1.EnvVar{
 					{
-						Name: "POD_IP",
+						Name: "POD_IPS",
 						ValueFrom: &corev1.EnvVarSource{
 							FieldRef: &corev1.ObjectFieldSelector{
 								APIVersion: "v1",
-								FieldPath:  "status.podIP",
+								FieldPath:  "status.podIPs",
 							},
 						},
 					},
@@ -171,5 +242,23 @@ EOF
 
 			Expect(actual).To(Equal(expected))
 		})
+		It("Programs the rules with nftables if set in meshconfig", func() {
+			mc := v1alpha2.MeshConfig{
+				Spec: v1alpha2.MeshConfigSpec{
+					Sidecar: v1alpha2.SidecarSpec{
+						InitContainerImage: containerImage,
+						LocalProxyMode:     v1alpha2.LocalProxyModeLocalhost,
+						RedirectionBackend: v1alpha2.RedirectionBackendNftables,
+					},
+				},
+			}
+			actual := getInitContainerSpec(containerName, mc, nil, nil, nil, nil, false, corev1.PullAlways, nil)
+
+			Expect(actual.Args).To(Equal([]string{
+				"-c",
+				generateNftablesCommands(v1alpha2.LocalProxyModeLocalhost, nil, nil, nil, nil, nil),
+			}))
+			Expect(actual.Args[1]).To(ContainSubstring("nft -f - <<EOF"))
+		})
 	})
 })
@@ -2,39 +2,46 @@ package injector
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+
 	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/utils"
 )
 
-// iptablesOutboundStaticRules is the list of iptables rules related to outbound traffic interception and redirection
-var iptablesOutboundStaticRules = []string{
-	// Redirects outbound TCP traffic hitting OSM_PROXY_OUT_REDIRECT chain to Envoy's outbound listener port
-	fmt.Sprintf("-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port %d", constants.EnvoyOutboundListenerPort),
+// iptablesOutboundStaticRules returns the list of iptables rules related to outbound traffic interception and redirection,
+// loopbackCIDR being the loopback address of the IP family of the rules
+func iptablesOutboundStaticRules(loopbackCIDR string) []string {
+	return []string{
+		// Redirects outbound TCP traffic hitting OSM_PROXY_OUT_REDIRECT chain to Envoy's outbound listener port
+		fmt.Sprintf("-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port %d", constants.EnvoyOutboundListenerPort),
 
-	// Traffic to the Proxy Admin port flows to the Proxy -- not redirected
-	fmt.Sprintf("-A OSM_PROXY_OUT_REDIRECT -p tcp --dport %d -j ACCEPT", constants.EnvoyAdminPort),
+		// Traffic to the Proxy Admin port flows to the Proxy -- not redirected
+		fmt.Sprintf("-A OSM_PROXY_OUT_REDIRECT -p tcp --dport %d -j ACCEPT", constants.EnvoyAdminPort),
 
-	// For outbound TCP traffic jump from OUTPUT chain to OSM_PROXY_OUTBOUND chain
-	"-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND",
+		// For outbound TCP traffic jump from OUTPUT chain to OSM_PROXY_OUTBOUND chain
+		"-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND",
 
-	// Outbound traffic from Envoy to the local app over the loopback interface should jump to the inbound proxy redirect chain.
-	// So when an app directs traffic to itself via the k8s service, traffic flows as follows:
-	// app -> local envoy's outbound listener -> iptables -> local envoy's inbound listener -> app
-	fmt.Sprintf("-A OSM_PROXY_OUTBOUND -o lo ! -d 127.0.0.1/32 -m owner --uid-owner %d -j OSM_PROXY_IN_REDIRECT", constants.EnvoyUID),
+		// Outbound traffic from Envoy to the local app over the loopback interface should jump to the inbound proxy redirect chain.
+		// So when an app directs traffic to itself via the k8s service, traffic flows as follows:
+		// app -> local envoy's outbound listener -> iptables -> local envoy's inbound listener -> app
+		fmt.Sprintf("-A OSM_PROXY_OUTBOUND -o lo ! -d %s -m owner --uid-owner %d -j OSM_PROXY_IN_REDIRECT", loopbackCIDR, constants.EnvoyUID),
 
-	// Outbound traffic from the app to itself over the loopback interface is not be redirected via the proxy.
-	// E.g. when app sends traffic to itself via the pod IP.
-	fmt.Sprintf("-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner %d -j RETURN", constants.EnvoyUID),
+		// Outbound traffic from the app to itself over the loopback interface is not be redirected via the proxy.
+		// E.g. when app sends traffic to itself via the pod IP.
+		fmt.Sprintf("-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner %d -j RETURN", constants.EnvoyUID),
 
-	// Don't redirect Envoy traffic back to itself, return it to the next chain for processing
-	fmt.Sprintf("-A OSM_PROXY_OUTBOUND -m owner --uid-owner %d -j RETURN", constants.EnvoyUID),
+		// Don't redirect Envoy traffic back to itself, return it to the next chain for processing
+		fmt.Sprintf("-A OSM_PROXY_OUTBOUND -m owner --uid-owner %d -j RETURN", constants.EnvoyUID),
 
-	// Skip localhost traffic, doesn't need to be routed via the proxy
-	"-A OSM_PROXY_OUTBOUND -d 127.0.0.1/32 -j RETURN",
+		// Skip localhost traffic, doesn't need to be routed via the proxy
+		fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j RETURN", loopbackCIDR),
+	}
 }
 
 // iptablesInboundStaticRules is the list of iptables rules related to inbound traffic interception and redirection
@@ -61,8 +68,28 @@ var iptablesInboundStaticRules = []string{
 	"-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT",
 }
 
-// generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection
+// generateIptablesCommands generates a list of iptables commands to set up sidecar interception and redirection, with
+// iptables for the IPv4 address of the pod and ip6tables for its IPv6 address
 func generateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
+	return generateRedirectionScript(func(ipFamily corev1.IPFamily, podIP string) ([]string, string) {
+		return iptablesCommand(ipFamily), generateIptablesRules(ipFamily, proxyMode, podIP, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	})
+}
+
+// iptablesCommand returns the command programming the iptables rules of the given IP family
+func iptablesCommand(ipFamily corev1.IPFamily) []string {
+	if ipFamily == corev1.IPv6Protocol {
+		return []string{"ip6tables-restore", "--noflush"}
+	}
+	return []string{"iptables-restore", "--noflush"}
+}
+
+// generateIptablesRules generates the iptables-restore input setting up sidecar interception and redirection of the
+// traffic of the given IP family, podIP being the destination of the traffic proxied by Envoy to the local application
+// in the PodIP local proxy mode. The IP ranges of the other IP family are ignored.
+func generateIptablesRules(ipFamily corev1.IPFamily, proxyMode configv1alpha2.LocalProxyMode, podIP string, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
+	loopbackCIDR := utils.GetHostCIDR(net.ParseIP(loopbackAddress(ipFamily)))
+
 	var rules strings.Builder
 
 	fmt.Fprintln(&rules, `# OSM sidecar interception rules
@@ -95,13 +122,13 @@ func generateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundI
 	}
 
 	// 3. Create outbound rules
-	cmds = append(cmds, iptablesOutboundStaticRules...)
+	cmds = append(cmds, iptablesOutboundStaticRules(loopbackCIDR)...)
 
 	if proxyMode == configv1alpha2.LocalProxyModePodIP {
 		// For envoy -> local service container proxying, send traffic to pod IP instead of localhost
 		// *Note: it is important to use the insert option '-I' instead of the append option '-A' to ensure the
 		// DNAT to the pod ip for envoy -> localhost traffic happens before the rule that redirects traffic to the proxy
-		cmds = append(cmds, fmt.Sprintf("-I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner %d -j DNAT --to-destination $POD_IP", constants.EnvoyUID))
+		cmds = append(cmds, fmt.Sprintf("-I OUTPUT -p tcp -o lo -d %s -m owner --uid-owner %d -j DNAT --to-destination %s", loopbackCIDR, constants.EnvoyUID, podIP))
 	}
 
 	// Ignore outbound traffic in specified interfaces
@@ -115,7 +142,7 @@ func generateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundI
 	//
 
 	// 4. Create dynamic outbound IP range exclusion rules
-	for _, cidr := range outboundIPRangeExclusionList {
+	for _, cidr := range filterIPRanges(outboundIPRangeExclusionList, ipFamily) {
 		// *Note: it is important to use the insert option '-I' instead of the append option '-A' to ensure the exclusion
 		// rules take precedence over the static redirection rules. Iptables rules are evaluated in order.
 		rule := fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j RETURN", cidr)
@@ -136,11 +163,12 @@ func generateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundI
 	// 6. Create dynamic outbound IP range inclusion rules
 	if len(outboundIPRangeInclusionList) > 0 {
 		// Redirect specified IP ranges to the proxy
-		for _, cidr := range outboundIPRangeInclusionList {
+		for _, cidr := range filterIPRanges(outboundIPRangeInclusionList, ipFamily) {
 			rule := fmt.Sprintf("-A OSM_PROXY_OUTBOUND -d %s -j OSM_PROXY_OUT_REDIRECT", cidr)
 			cmds = append(cmds, rule)
 		}
-		// Remaining traffic not belonging to specified inclusion IP ranges are not redirected
+		// Remaining traffic not belonging to specified inclusion IP ranges are not redirected, including the traffic
+		// of an IP family without inclusion IP ranges
 		cmds = append(cmds, "-A OSM_PROXY_OUTBOUND -j RETURN")
 	} else {
 		// Redirect remaining outbound traffic to the proxy
@@ -153,10 +181,5 @@ func generateIptablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundI
 
 	fmt.Fprint(&rules, "COMMIT")
 
-	cmd := fmt.Sprintf(`iptables-restore --noflush <<EOF
-%s
-EOF
-`, rules.String())
-
-	return cmd
+	return rules.String()
 }
@@ -21,7 +21,14 @@ func TestGenerateIptablesCommands(t *testing.T) {
 	}{
 		{
 			name: "no exclusions or inclusions",
-			expected: `iptables-restore --noflush <<EOF
+			expected: `for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+iptables-restore --noflush <<EOF
 # OSM sidecar interception rules
 *nat
 :OSM_PROXY_INBOUND - [0:0]
@@ -46,16 +53,51 @@ func TestGenerateIptablesCommands(t *testing.T) {
 -A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
 COMMIT
 EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+ip6tables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d ::1/128 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN
+-A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
+COMMIT
+EOF
+fi
 `,
 		},
 		{
 			name:                       "with exclusions and inclusions",
-			outboundIPRangeExclusions:  []string{"1.1.1.1/32", "2.2.2.2/32"},
-			outboundIPRangeInclusions:  []string{"3.3.3.3/32", "4.4.4.4/32"},
+			outboundIPRangeExclusions:  []string{"1.1.1.1/32", "2.2.2.2/32", "fd00:1::/64"},
+			outboundIPRangeInclusions:  []string{"3.3.3.3/32", "4.4.4.4/32", "fd00:3::/64"},
 			outboundPortExclusions:     []int{10, 20},
 			inboundPortExclusions:      []int{30, 40},
 			networkInterfaceExclusions: []string{"eth0", "eth1"},
-			expected: `iptables-restore --noflush <<EOF
+			expected: `for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+iptables-restore --noflush <<EOF
 # OSM sidecar interception rules
 *nat
 :OSM_PROXY_INBOUND - [0:0]
@@ -90,12 +132,55 @@ EOF
 -A OSM_PROXY_OUTBOUND -j RETURN
 COMMIT
 EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+ip6tables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-I OSM_PROXY_INBOUND -i eth0 -j RETURN
+-I OSM_PROXY_INBOUND -i eth1 -j RETURN
+-I OSM_PROXY_INBOUND -p tcp --match multiport --dports 30,40 -j RETURN
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d ::1/128 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN
+-A OSM_PROXY_OUTBOUND -o eth0 -j RETURN
+-A OSM_PROXY_OUTBOUND -o eth1 -j RETURN
+-A OSM_PROXY_OUTBOUND -d fd00:1::/64 -j RETURN
+-A OSM_PROXY_OUTBOUND -p tcp --match multiport --dports 10,20 -j RETURN
+-A OSM_PROXY_OUTBOUND -d fd00:3::/64 -j OSM_PROXY_OUT_REDIRECT
+-A OSM_PROXY_OUTBOUND -j RETURN
+COMMIT
+EOF
+fi
 `,
 		},
 		{
 			name:      "proxy mode pod ip",
 			proxyMode: configv1alpha2.LocalProxyModePodIP,
-			expected: `iptables-restore --noflush <<EOF
+			expected: `for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+iptables-restore --noflush <<EOF
 # OSM sidecar interception rules
 *nat
 :OSM_PROXY_INBOUND - [0:0]
@@ -117,10 +202,39 @@ EOF
 -A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
 -A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
 -A OSM_PROXY_OUTBOUND -d 127.0.0.1/32 -j RETURN
--I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IP
+-I OUTPUT -p tcp -o lo -d 127.0.0.1/32 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IPV4
+-A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
+COMMIT
+EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+ip6tables-restore --noflush <<EOF
+# OSM sidecar interception rules
+*nat
+:OSM_PROXY_INBOUND - [0:0]
+:OSM_PROXY_IN_REDIRECT - [0:0]
+:OSM_PROXY_OUTBOUND - [0:0]
+:OSM_PROXY_OUT_REDIRECT - [0:0]
+-A OSM_PROXY_IN_REDIRECT -p tcp -j REDIRECT --to-port 15003
+-A PREROUTING -p tcp -j OSM_PROXY_INBOUND
+-A OSM_PROXY_INBOUND -p tcp --dport 15010 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15901 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15902 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15903 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp --dport 15904 -j RETURN
+-A OSM_PROXY_INBOUND -p tcp -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUT_REDIRECT -p tcp -j REDIRECT --to-port 15001
+-A OSM_PROXY_OUT_REDIRECT -p tcp --dport 15000 -j ACCEPT
+-A OUTPUT -p tcp -j OSM_PROXY_OUTBOUND
+-A OSM_PROXY_OUTBOUND -o lo ! -d ::1/128 -m owner --uid-owner 1500 -j OSM_PROXY_IN_REDIRECT
+-A OSM_PROXY_OUTBOUND -o lo -m owner ! --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -m owner --uid-owner 1500 -j RETURN
+-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN
+-I OUTPUT -p tcp -o lo -d ::1/128 -m owner --uid-owner 1500 -j DNAT --to-destination $POD_IPV6
 -A OSM_PROXY_OUTBOUND -j OSM_PROXY_OUT_REDIRECT
 COMMIT
 EOF
+fi
 `,
 		},
 	}
@@ -0,0 +1,119 @@
+package injector
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// nativeSidecarSupportRefreshInterval is the interval at which the support of native sidecar containers by the
+	// Kubernetes API server is checked again
+	nativeSidecarSupportRefreshInterval = 5 * time.Minute
+)
+
+// minNativeSidecarVersion is the first Kubernetes version enabling native sidecar containers by default
+var minNativeSidecarVersion = version.MustParseGeneric("v1.29.0")
+
+// supportsNativeSidecars returns whether the Kubernetes API server supports native sidecar containers, i.e. init
+// containers with an Always restart policy running alongside the application containers
+func supportsNativeSidecars(kubeClient kubernetes.Interface) bool {
+	serverVersion, err := kubeClient.Discovery().ServerVersion()
+	if err != nil {
+		log.Error().Err(err).Msg("Error getting the Kubernetes API server version, native sidecar containers are not used")
+		return false
+	}
+	v, err := version.ParseGeneric(serverVersion.GitVersion)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error parsing the Kubernetes API server version %s, native sidecar containers are not used", serverVersion.GitVersion)
+		return false
+	}
+	return v.AtLeast(minNativeSidecarVersion)
+}
+
+// refreshNativeSidecarSupport checks again whether the Kubernetes API server supports native sidecar containers at the
+// given interval until the given context is done, so that an upgrade of the API server is picked up without restarting
+// osm-injector
+func (wh *mutatingWebhook) refreshNativeSidecarSupport(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			supported := supportsNativeSidecars(wh.kubeClient)
+			if wh.nativeSidecarsSupported.Swap(supported) != supported {
+				log.Info().Msgf("Support of native sidecar containers by the Kubernetes API server changed to %t", supported)
+			}
+		}
+	}
+}
+
+// useNativeSidecars returns whether the Envoy sidecar of a pod running on the given OS is injected as a native sidecar
+// container, based on the MeshConfig's sidecar.nativeSidecarMode and the support of the Kubernetes API server.
+// An error is returned when native sidecar containers are enabled but the Kubernetes API server does not support them,
+// as the API server would drop their restart policy and the pod would never start past the Envoy init container.
+func (wh *mutatingWebhook) useNativeSidecars(podOS string) (bool, error) {
+	if strings.EqualFold(podOS, constants.OSWindows) {
+		return false, nil
+	}
+
+	switch mode := wh.kubeController.GetMeshConfig().Spec.Sidecar.NativeSidecarMode; mode {
+	case v1alpha2.NativeSidecarModeEnabled:
+		if !wh.nativeSidecarsSupported.Load() {
+			return false, errNativeSidecarsNotSupported
+		}
+		return true, nil
+	case v1alpha2.NativeSidecarModeAuto, "":
+		return wh.nativeSidecarsSupported.Load(), nil
+	case v1alpha2.NativeSidecarModeDisabled:
+		return false, nil
+	default:
+		log.Error().Msgf("Invalid MeshConfig sidecar.nativeSidecarMode %q, injecting the sidecar as a regular container", mode)
+		return false, nil
+	}
+}
+
+// getNativeSidecarProbePorts returns the ports probed by the health probes of the native sidecars of the given pod.
+// Init containers may only define health probes when running as native sidecars. Unlike the probes of the application
+// containers, these are not rewritten to be served by the Envoy sidecar, which serves a single probe of each type:
+// their ports are instead excluded from inbound traffic interception.
+func getNativeSidecarProbePorts(pod *corev1.Pod) []int {
+	var ports []int
+	for _, container := range pod.Spec.InitContainers {
+		for _, probe := range []*corev1.Probe{container.LivenessProbe, container.ReadinessProbe, container.StartupProbe} {
+			if probe == nil {
+				continue
+			}
+
+			var definedPort intstr.IntOrString
+			switch {
+			case probe.HTTPGet != nil:
+				definedPort = probe.HTTPGet.Port
+			case probe.TCPSocket != nil:
+				definedPort = probe.TCPSocket.Port
+			case probe.GRPC != nil:
+				definedPort = intstr.FromInt(int(probe.GRPC.Port))
+			default:
+				continue
+			}
+
+			port, err := getPort(definedPort, &container.Ports)
+			if err != nil {
+				log.Error().Err(err).Msgf("Error finding the port probed on native sidecar %s, it is not excluded from inbound traffic interception", container.Name)
+				continue
+			}
+			ports = append(ports, int(port))
+		}
+	}
+	return ports
+}
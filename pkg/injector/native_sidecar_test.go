@@ -0,0 +1,226 @@
+package injector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+func TestSupportsNativeSidecars(t *testing.T) {
+	testCases := []struct {
+		gitVersion string
+		expected   bool
+	}{
+		{gitVersion: "v1.24.2", expected: false},
+		{gitVersion: "v1.28.4", expected: false},
+		{gitVersion: "v1.29.0", expected: true},
+		{gitVersion: "v1.30.1-gke.1329003", expected: true},
+		{gitVersion: "invalid", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.gitVersion, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: tc.gitVersion}
+
+			assert.Equal(tc.expected, supportsNativeSidecars(kubeClient))
+		})
+	}
+}
+
+func TestRefreshNativeSidecarSupport(t *testing.T) {
+	assert := tassert.New(t)
+
+	kubeClient := fake.NewSimpleClientset()
+	fakeDiscovery := kubeClient.Discovery().(*fakediscovery.FakeDiscovery)
+	fakeDiscovery.FakedServerVersion = &version.Info{GitVersion: "v1.29.0"}
+	wh := &mutatingWebhook{
+		kubeClient: kubeClient,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		wh.refreshNativeSidecarSupport(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	// The upgrade of the API server is picked up
+	assert.Eventually(wh.nativeSidecarsSupported.Load, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestUseNativeSidecars(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mode      v1alpha2.NativeSidecarMode
+		supported bool
+		podOS     string
+		expected  bool
+		expectErr bool
+	}{
+		{
+			name:      "auto when supported",
+			mode:      v1alpha2.NativeSidecarModeAuto,
+			supported: true,
+			podOS:     constants.OSLinux,
+			expected:  true,
+		},
+		{
+			name:      "auto when unsupported",
+			mode:      v1alpha2.NativeSidecarModeAuto,
+			supported: false,
+			podOS:     constants.OSLinux,
+			expected:  false,
+		},
+		{
+			name:      "auto by default when supported",
+			supported: true,
+			podOS:     constants.OSLinux,
+			expected:  true,
+		},
+		{
+			name:      "auto by default when unsupported",
+			supported: false,
+			podOS:     constants.OSLinux,
+			expected:  false,
+		},
+		{
+			name:      "enabled when supported",
+			mode:      v1alpha2.NativeSidecarModeEnabled,
+			supported: true,
+			podOS:     constants.OSLinux,
+			expected:  true,
+		},
+		{
+			name:      "enabled when unsupported",
+			mode:      v1alpha2.NativeSidecarModeEnabled,
+			supported: false,
+			podOS:     constants.OSLinux,
+			expected:  false,
+			expectErr: true,
+		},
+		{
+			name:      "disabled",
+			mode:      v1alpha2.NativeSidecarModeDisabled,
+			supported: true,
+			podOS:     constants.OSLinux,
+			expected:  false,
+		},
+		{
+			name:      "invalid mode",
+			mode:      "invalid",
+			supported: true,
+			podOS:     constants.OSLinux,
+			expected:  false,
+		},
+		{
+			name:      "windows pods",
+			mode:      v1alpha2.NativeSidecarModeEnabled,
+			supported: true,
+			podOS:     constants.OSWindows,
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			mockCtrl := gomock.NewController(t)
+			mockKubeController := k8s.NewMockController(mockCtrl)
+			mockKubeController.EXPECT().GetMeshConfig().Return(v1alpha2.MeshConfig{
+				Spec: v1alpha2.MeshConfigSpec{
+					Sidecar: v1alpha2.SidecarSpec{
+						NativeSidecarMode: tc.mode,
+					},
+				},
+			}).AnyTimes()
+
+			wh := &mutatingWebhook{
+				kubeController: mockKubeController,
+			}
+			wh.nativeSidecarsSupported.Store(tc.supported)
+
+			actual, err := wh.useNativeSidecars(tc.podOS)
+			assert.Equal(tc.expectErr, err != nil)
+			assert.Equal(tc.expected, actual)
+		})
+	}
+}
+
+func TestGetNativeSidecarProbePorts(t *testing.T) {
+	assert := tassert.New(t)
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: "init",
+				},
+				{
+					Name: "log-shipper",
+					Ports: []corev1.ContainerPort{
+						{Name: "health", ContainerPort: 9000},
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromString("health")},
+						},
+					},
+					StartupProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(9001)},
+						},
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{Command: []string{"true"}},
+						},
+					},
+				},
+				{
+					Name: "cache",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							GRPC: &corev1.GRPCAction{Port: 9002},
+						},
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Port: intstr.FromString("unknown")},
+						},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8080)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.ElementsMatch([]int{9000, 9001, 9002}, getNativeSidecarProbePorts(pod))
+}
@@ -0,0 +1,189 @@
+package injector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// nftablesTable is the nftables table holding the sidecar interception and redirection chains
+const nftablesTable = "osm_proxy"
+
+// nftablesCommand is the command programming the nftables rules
+var nftablesCommand = []string{"nft", "-f", "-"}
+
+// nftablesInboundStaticRules is the list of nftables rules of the proxy_inbound chain related to inbound traffic
+// interception, the equivalent of iptablesInboundStaticRules
+var nftablesInboundStaticRules = []string{
+	// Skip metrics query traffic being directed to Envoy's inbound prometheus listener port
+	fmt.Sprintf("tcp dport %d return", constants.EnvoyPrometheusInboundListenerPort),
+
+	// Skip inbound health probes; These ports will be explicitly handled by listeners configured on the
+	// Envoy proxy IF any health probes have been configured in the Pod Spec.
+	fmt.Sprintf("tcp dport %d return", constants.LivenessProbePort),
+	fmt.Sprintf("tcp dport %d return", constants.ReadinessProbePort),
+	fmt.Sprintf("tcp dport %d return", constants.StartupProbePort),
+	// Skip inbound health probes (originally TCPSocket health probes); requests handled by osm-healthcheck
+	fmt.Sprintf("tcp dport %d return", constants.HealthcheckPort),
+
+	// Redirect remaining inbound traffic to Envoy
+	"meta l4proto tcp jump proxy_in_redirect",
+}
+
+// nftablesOutboundStaticRules returns the list of nftables rules of the proxy_outbound chain related to outbound traffic
+// interception, the equivalent of iptablesOutboundStaticRules, ipMatch being the address match of the IP family of the
+// rules and loopback its loopback address
+func nftablesOutboundStaticRules(ipMatch string, loopback string) []string {
+	return []string{
+		// Outbound traffic from Envoy to the local app over the loopback interface should jump to the inbound proxy redirect chain.
+		// So when an app directs traffic to itself via the k8s service, traffic flows as follows:
+		// app -> local envoy's outbound listener -> nftables -> local envoy's inbound listener -> app
+		fmt.Sprintf(`oifname "lo" %s daddr != %s meta skuid %d jump proxy_in_redirect`, ipMatch, loopback, constants.EnvoyUID),
+
+		// Outbound traffic from the app to itself over the loopback interface is not be redirected via the proxy.
+		// E.g. when app sends traffic to itself via the pod IP.
+		fmt.Sprintf(`oifname "lo" meta skuid != %d return`, constants.EnvoyUID),
+
+		// Don't redirect Envoy traffic back to itself, return it to the next chain for processing
+		fmt.Sprintf("meta skuid %d return", constants.EnvoyUID),
+
+		// Skip localhost traffic, doesn't need to be routed via the proxy
+		fmt.Sprintf("%s daddr %s return", ipMatch, loopback),
+	}
+}
+
+// generateNftablesCommands generates the nft commands setting up sidecar interception and redirection of the traffic of
+// each IP family of the pod
+func generateNftablesCommands(proxyMode configv1alpha2.LocalProxyMode, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
+	return generateRedirectionScript(func(ipFamily corev1.IPFamily, podIP string) ([]string, string) {
+		return nftablesCommand, generateNftablesRules(ipFamily, proxyMode, podIP, outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, networkInterfaceExclusionList)
+	})
+}
+
+// generateNftablesRules generates the nft ruleset setting up sidecar interception and redirection of the traffic of the
+// given IP family with the same semantics as the iptables rules generated by generateIptablesRules, podIP being the
+// destination of the traffic proxied by Envoy to the local application in the PodIP local proxy mode. The IP ranges of
+// the other IP family are ignored.
+func generateNftablesRules(ipFamily corev1.IPFamily, proxyMode configv1alpha2.LocalProxyMode, podIP string, outboundIPRangeExclusionList []string, outboundIPRangeInclusionList []string, outboundPortExclusionList []int, inboundPortExclusionList []int, networkInterfaceExclusionList []string) string {
+	var rules strings.Builder
+
+	// The IPv4 and IPv6 rules are programmed in tables of the ip and ip6 families, whose address matches have the same name
+	tableFamily := "ip"
+	if ipFamily == corev1.IPv6Protocol {
+		tableFamily = "ip6"
+	}
+	loopback := loopbackAddress(ipFamily)
+
+	// Declaring the table before deleting it makes the ruleset idempotent
+	fmt.Fprintf(&rules, `# OSM sidecar interception rules
+table %[1]s %[2]s
+delete table %[1]s %[2]s
+table %[1]s %[2]s {
+`, tableFamily, nftablesTable)
+
+	writeChain := func(name string, hook string, chainRules []string) {
+		fmt.Fprintf(&rules, "\tchain %s {\n", name)
+		if hook != "" {
+			fmt.Fprintf(&rules, "\t\ttype nat hook %s priority dstnat; policy accept;\n", hook)
+		}
+		for _, rule := range chainRules {
+			fmt.Fprintf(&rules, "\t\t%s\n", rule)
+		}
+		fmt.Fprintln(&rules, "\t}")
+	}
+
+	// 1. Create inbound rules
+	// For inbound TCP traffic jump from the prerouting hook to the proxy_inbound chain
+	writeChain("prerouting", "prerouting", []string{"meta l4proto tcp jump proxy_inbound"})
+
+	var inboundRules []string
+	// Create dynamic inbound ports exclusion rules
+	if len(inboundPortExclusionList) > 0 {
+		inboundRules = append(inboundRules, fmt.Sprintf("tcp dport %s return", nftablesPortSet(inboundPortExclusionList)))
+	}
+	// Ignore inbound traffic on specified interfaces
+	for _, iface := range networkInterfaceExclusionList {
+		inboundRules = append(inboundRules, fmt.Sprintf("iifname %q return", iface))
+	}
+	inboundRules = append(inboundRules, nftablesInboundStaticRules...)
+	writeChain("proxy_inbound", "", inboundRules)
+
+	// Redirects inbound TCP traffic hitting the proxy_in_redirect chain to Envoy's inbound listener port
+	writeChain("proxy_in_redirect", "", []string{fmt.Sprintf("meta l4proto tcp redirect to :%d", constants.EnvoyInboundListenerPort)})
+
+	// 2. Create outbound rules
+	var outputRules []string
+	if proxyMode == configv1alpha2.LocalProxyModePodIP {
+		// For envoy -> local service container proxying, send traffic to pod IP instead of localhost
+		// *Note: the DNAT to the pod ip for envoy -> localhost traffic must happen before the rule that redirects
+		// traffic to the proxy
+		outputRules = append(outputRules, fmt.Sprintf(`meta l4proto tcp oifname "lo" %s daddr %s meta skuid %d dnat to %s`, tableFamily, loopback, constants.EnvoyUID, podIP))
+	}
+	// For outbound TCP traffic jump from the output hook to the proxy_outbound chain
+	outputRules = append(outputRules, "meta l4proto tcp jump proxy_outbound")
+	writeChain("output", "output", outputRules)
+
+	outboundRules := nftablesOutboundStaticRules(tableFamily, loopback)
+
+	// Ignore outbound traffic in specified interfaces
+	for _, iface := range networkInterfaceExclusionList {
+		outboundRules = append(outboundRules, fmt.Sprintf("oifname %q return", iface))
+	}
+
+	//
+	// Create outbound exclusion and inclusion rules.
+	// *Note: exclusion rules must be applied before inclusions as order matters
+	//
+
+	// 3. Create dynamic outbound IP range exclusion rules
+	for _, cidr := range filterIPRanges(outboundIPRangeExclusionList, ipFamily) {
+		outboundRules = append(outboundRules, fmt.Sprintf("%s daddr %s return", tableFamily, cidr))
+	}
+
+	// 4. Create dynamic outbound ports exclusion rules
+	if len(outboundPortExclusionList) > 0 {
+		outboundRules = append(outboundRules, fmt.Sprintf("tcp dport %s return", nftablesPortSet(outboundPortExclusionList)))
+	}
+
+	// 5. Create dynamic outbound IP range inclusion rules
+	if len(outboundIPRangeInclusionList) > 0 {
+		// Redirect specified IP ranges to the proxy
+		for _, cidr := range filterIPRanges(outboundIPRangeInclusionList, ipFamily) {
+			outboundRules = append(outboundRules, fmt.Sprintf("%s daddr %s jump proxy_out_redirect", tableFamily, cidr))
+		}
+		// Remaining traffic not belonging to specified inclusion IP ranges are not redirected, including the traffic
+		// of an IP family without inclusion IP ranges
+		outboundRules = append(outboundRules, "return")
+	} else {
+		// Redirect remaining outbound traffic to the proxy
+		outboundRules = append(outboundRules, "jump proxy_out_redirect")
+	}
+	writeChain("proxy_outbound", "", outboundRules)
+
+	writeChain("proxy_out_redirect", "", []string{
+		// Redirects outbound TCP traffic hitting the proxy_out_redirect chain to Envoy's outbound listener port
+		fmt.Sprintf("meta l4proto tcp redirect to :%d", constants.EnvoyOutboundListenerPort),
+
+		// Traffic to the Proxy Admin port flows to the Proxy -- not redirected
+		fmt.Sprintf("tcp dport %d accept", constants.EnvoyAdminPort),
+	})
+
+	fmt.Fprint(&rules, "}")
+
+	return rules.String()
+}
+
+// nftablesPortSet returns the nftables anonymous set of the given ports
+func nftablesPortSet(ports []int) string {
+	portStrs := make([]string, 0, len(ports))
+	for _, port := range ports {
+		portStrs = append(portStrs, strconv.Itoa(port))
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(portStrs, ", "))
+}
@@ -0,0 +1,392 @@
+package injector
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+)
+
+func TestGenerateNftablesCommands(t *testing.T) {
+	assert := tassert.New(t)
+
+	actual := generateNftablesCommands(configv1alpha2.LocalProxyModePodIP, []string{"1.1.1.1/32", "fd00:1::/64"}, []string{"3.3.3.3/32"}, []int{10, 20}, []int{30, 40}, []string{"eth0"})
+	expected := `for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+if [ -n "$POD_IPV4" ]; then
+nft -f - <<EOF
+# OSM sidecar interception rules
+table ip osm_proxy
+delete table ip osm_proxy
+table ip osm_proxy {
+	chain prerouting {
+		type nat hook prerouting priority dstnat; policy accept;
+		meta l4proto tcp jump proxy_inbound
+	}
+	chain proxy_inbound {
+		tcp dport { 30, 40 } return
+		iifname "eth0" return
+		tcp dport 15010 return
+		tcp dport 15901 return
+		tcp dport 15902 return
+		tcp dport 15903 return
+		tcp dport 15904 return
+		meta l4proto tcp jump proxy_in_redirect
+	}
+	chain proxy_in_redirect {
+		meta l4proto tcp redirect to :15003
+	}
+	chain output {
+		type nat hook output priority dstnat; policy accept;
+		meta l4proto tcp oifname "lo" ip daddr 127.0.0.1 meta skuid 1500 dnat to $POD_IPV4
+		meta l4proto tcp jump proxy_outbound
+	}
+	chain proxy_outbound {
+		oifname "lo" ip daddr != 127.0.0.1 meta skuid 1500 jump proxy_in_redirect
+		oifname "lo" meta skuid != 1500 return
+		meta skuid 1500 return
+		ip daddr 127.0.0.1 return
+		oifname "eth0" return
+		ip daddr 1.1.1.1/32 return
+		tcp dport { 10, 20 } return
+		ip daddr 3.3.3.3/32 jump proxy_out_redirect
+		return
+	}
+	chain proxy_out_redirect {
+		meta l4proto tcp redirect to :15001
+		tcp dport 15000 accept
+	}
+}
+EOF
+fi
+if [ -n "$POD_IPV6" ]; then
+nft -f - <<EOF
+# OSM sidecar interception rules
+table ip6 osm_proxy
+delete table ip6 osm_proxy
+table ip6 osm_proxy {
+	chain prerouting {
+		type nat hook prerouting priority dstnat; policy accept;
+		meta l4proto tcp jump proxy_inbound
+	}
+	chain proxy_inbound {
+		tcp dport { 30, 40 } return
+		iifname "eth0" return
+		tcp dport 15010 return
+		tcp dport 15901 return
+		tcp dport 15902 return
+		tcp dport 15903 return
+		tcp dport 15904 return
+		meta l4proto tcp jump proxy_in_redirect
+	}
+	chain proxy_in_redirect {
+		meta l4proto tcp redirect to :15003
+	}
+	chain output {
+		type nat hook output priority dstnat; policy accept;
+		meta l4proto tcp oifname "lo" ip6 daddr ::1 meta skuid 1500 dnat to $POD_IPV6
+		meta l4proto tcp jump proxy_outbound
+	}
+	chain proxy_outbound {
+		oifname "lo" ip6 daddr != ::1 meta skuid 1500 jump proxy_in_redirect
+		oifname "lo" meta skuid != 1500 return
+		meta skuid 1500 return
+		ip6 daddr ::1 return
+		oifname "eth0" return
+		ip6 daddr fd00:1::/64 return
+		tcp dport { 10, 20 } return
+		return
+	}
+	chain proxy_out_redirect {
+		meta l4proto tcp redirect to :15001
+		tcp dport 15000 accept
+	}
+}
+EOF
+fi
+`
+	assert.Equal(expected, actual)
+}
+
+// TestNftablesIptablesParity verifies the nftables rules have the same semantics as the iptables rules generated for
+// the same redirection settings
+func TestNftablesIptablesParity(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		ipFamily                   corev1.IPFamily
+		podIP                      string
+		proxyMode                  configv1alpha2.LocalProxyMode
+		outboundIPRangeExclusions  []string
+		outboundIPRangeInclusions  []string
+		outboundPortExclusions     []int
+		inboundPortExclusions      []int
+		networkInterfaceExclusions []string
+	}{
+		{
+			name: "no exclusions or inclusions",
+		},
+		{
+			name:                       "with exclusions and inclusions",
+			outboundIPRangeExclusions:  []string{"1.1.1.1/32", "2.2.2.2/32"},
+			outboundIPRangeInclusions:  []string{"3.3.3.3/32", "4.4.4.4/32"},
+			outboundPortExclusions:     []int{10, 20},
+			inboundPortExclusions:      []int{30, 40},
+			networkInterfaceExclusions: []string{"eth0", "eth1"},
+		},
+		{
+			name:                      "exclusions without inclusions",
+			outboundIPRangeExclusions: []string{"10.0.0.0/8"},
+			outboundPortExclusions:    []int{6379},
+		},
+		{
+			name:      "proxy mode pod ip",
+			proxyMode: configv1alpha2.LocalProxyModePodIP,
+		},
+		{
+			name:                       "proxy mode pod ip with exclusions",
+			proxyMode:                  configv1alpha2.LocalProxyModePodIP,
+			inboundPortExclusions:      []int{8081},
+			networkInterfaceExclusions: []string{"net1"},
+		},
+		{
+			name:                      "IPv6 with exclusions and inclusions",
+			ipFamily:                  corev1.IPv6Protocol,
+			podIP:                     "fd00::5",
+			outboundIPRangeExclusions: []string{"1.1.1.1/32", "fd00:1::/64", "fd00:2::1/128"},
+			outboundIPRangeInclusions: []string{"3.3.3.3/32", "fd00:3::/64"},
+			outboundPortExclusions:    []int{10, 20},
+			inboundPortExclusions:     []int{30, 40},
+		},
+		{
+			name:      "IPv6 proxy mode pod ip",
+			ipFamily:  corev1.IPv6Protocol,
+			podIP:     "fd00::5",
+			proxyMode: configv1alpha2.LocalProxyModePodIP,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ipFamily, podIP := corev1.IPv4Protocol, "10.1.2.3"
+			if tc.ipFamily != "" {
+				ipFamily, podIP = tc.ipFamily, tc.podIP
+			}
+			iptablesRules := generateIptablesRules(ipFamily, tc.proxyMode, podIP, tc.outboundIPRangeExclusions, tc.outboundIPRangeInclusions, tc.outboundPortExclusions, tc.inboundPortExclusions, tc.networkInterfaceExclusions)
+			nftablesRules := generateNftablesRules(ipFamily, tc.proxyMode, podIP, tc.outboundIPRangeExclusions, tc.outboundIPRangeInclusions, tc.outboundPortExclusions, tc.inboundPortExclusions, tc.networkInterfaceExclusions)
+
+			assert.Equal(parseIptablesRules(t, iptablesRules), parseNftablesRules(t, nftablesRules))
+		})
+	}
+}
+
+// canonicalRules maps a chain to its rules in canonical form. Consecutive rules with the same terminal verdict are
+// grouped and sorted, as their order does not change the semantics of the chain.
+type canonicalRules map[string][][]string
+
+func (c canonicalRules) add(chain string, matches []string, verdict string) {
+	sort.Strings(matches)
+	rule := strings.Join(append(matches, "=> "+verdict), " ")
+
+	groups := c[chain]
+	terminal := verdict == "return" || verdict == "accept"
+	if n := len(groups); terminal && n > 0 && strings.HasSuffix(groups[n-1][0], "=> "+verdict) {
+		groups[n-1] = append(groups[n-1], rule)
+		sort.Strings(groups[n-1])
+		return
+	}
+	c[chain] = append(groups, []string{rule})
+}
+
+// addPorts adds a rule for each of the given destination ports
+func (c canonicalRules) addPorts(chain string, matches []string, ports []string, verdict string) {
+	if len(ports) == 0 {
+		c.add(chain, matches, verdict)
+		return
+	}
+	for _, port := range ports {
+		c.add(chain, append(append([]string{}, matches...), "proto=tcp", "dport="+port), verdict)
+	}
+}
+
+// canonicalAddr returns the given address without its host prefix length, which nftables rules omit
+func canonicalAddr(addr string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(addr, "/32"), "/128")
+}
+
+func parseIptablesRules(t *testing.T, rules string) canonicalRules {
+	t.Helper()
+
+	// Simulate the append and insert operations on each chain
+	type rule struct {
+		matches []string
+		ports   []string
+		verdict string
+	}
+	chains := map[string][]rule{}
+	for _, line := range strings.Split(rules, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || (fields[0] != "-A" && fields[0] != "-I") {
+			continue
+		}
+		chain := strings.ToLower(strings.TrimPrefix(fields[1], "OSM_"))
+
+		var r rule
+		negate := false
+		for i := 2; i < len(fields); i++ {
+			prefix := ""
+			if negate {
+				prefix = "!"
+				negate = false
+			}
+			switch fields[i] {
+			case "!":
+				negate = true
+			case "-p":
+				i++
+				r.matches = append(r.matches, "proto="+fields[i])
+			case "--dport", "--dports":
+				i++
+				r.ports = strings.Split(fields[i], ",")
+			case "--match", "-m":
+				i++
+			case "-i":
+				i++
+				r.matches = append(r.matches, "iif="+fields[i])
+			case "-o":
+				i++
+				r.matches = append(r.matches, "oif="+fields[i])
+			case "-d":
+				i++
+				r.matches = append(r.matches, prefix+"daddr="+canonicalAddr(fields[i]))
+			case "--uid-owner":
+				i++
+				r.matches = append(r.matches, prefix+"uid="+fields[i])
+			case "-j":
+				i++
+				switch target := fields[i]; target {
+				case "RETURN", "ACCEPT":
+					r.verdict = strings.ToLower(target)
+				case "REDIRECT":
+					r.verdict = "redirect :" + fields[i+2]
+					i += 2
+				case "DNAT":
+					r.verdict = "dnat " + fields[i+2]
+					i += 2
+				default:
+					r.verdict = "jump " + strings.ToLower(strings.TrimPrefix(target, "OSM_"))
+				}
+			default:
+				t.Fatalf("unexpected iptables rule %q", line)
+			}
+		}
+
+		if fields[0] == "-I" {
+			chains[chain] = append([]rule{r}, chains[chain]...)
+		} else {
+			chains[chain] = append(chains[chain], r)
+		}
+	}
+
+	parsed := canonicalRules{}
+	for chain, chainRules := range chains {
+		for _, r := range chainRules {
+			matches := r.matches
+			if len(r.ports) > 0 {
+				// The protocol match is implied by the port match
+				matches = nil
+				for _, m := range r.matches {
+					if m != "proto=tcp" {
+						matches = append(matches, m)
+					}
+				}
+			}
+			parsed.addPorts(chain, matches, r.ports, r.verdict)
+		}
+	}
+	return parsed
+}
+
+func parseNftablesRules(t *testing.T, rules string) canonicalRules {
+	t.Helper()
+
+	parsed := canonicalRules{}
+	chain := ""
+	for _, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "chain "):
+			chain = strings.Fields(line)[1]
+			continue
+		case line == "}":
+			chain = ""
+			continue
+		case chain == "" || strings.HasPrefix(line, "type nat hook"):
+			continue
+		}
+
+		fields := strings.Fields(strings.NewReplacer("{ ", "", " }", "", ", ", ",").Replace(line))
+		var matches, ports []string
+		verdict := ""
+		for i := 0; i < len(fields); i++ {
+			prefix := ""
+			if i+2 < len(fields) && fields[i+2] == "!=" {
+				prefix = "!"
+			}
+			switch fields[i] {
+			case "meta":
+				i++
+				switch fields[i] {
+				case "l4proto":
+					i++
+					matches = append(matches, "proto="+fields[i])
+				case "skuid":
+					if prefix != "" {
+						i++
+					}
+					i++
+					matches = append(matches, prefix+"uid="+fields[i])
+				}
+			case "tcp":
+				i += 2
+				ports = strings.Split(fields[i], ",")
+			case "ip", "ip6":
+				if prefix != "" {
+					i++
+				}
+				i += 2
+				matches = append(matches, prefix+"daddr="+canonicalAddr(fields[i]))
+			case "iifname":
+				i++
+				matches = append(matches, "iif="+strings.Trim(fields[i], `"`))
+			case "oifname":
+				i++
+				matches = append(matches, "oif="+strings.Trim(fields[i], `"`))
+			case "return", "accept":
+				verdict = fields[i]
+			case "jump":
+				i++
+				verdict = "jump " + fields[i]
+			case "redirect":
+				i += 2
+				verdict = "redirect " + fields[i]
+			case "dnat":
+				i += 2
+				verdict = "dnat " + fields[i]
+			default:
+				t.Fatalf("unexpected nftables rule %q", line)
+			}
+		}
+		parsed.addPorts(chain, matches, ports, verdict)
+	}
+	return parsed
+}
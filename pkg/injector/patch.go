@@ -3,7 +3,7 @@ package injector
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -15,39 +15,72 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
 	"github.com/openservicemesh/osm/pkg/errcode"
 	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
 	"github.com/openservicemesh/osm/pkg/utils"
 )
 
+// grpcSSLRootsFileEnvVar is the environment variable of the file holding the roots the gRPC client of Envoy verifies
+// the certificates of servers with, such as the token exchange server of osm-controller
+const grpcSSLRootsFileEnvVar = "GRPC_DEFAULT_SSL_ROOTS_FILE_PATH"
+
 func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.AdmissionRequest, proxyUUID uuid.UUID) ([]byte, error) {
 	namespace := req.Namespace
+	meshConfig := wh.kubeController.GetMeshConfig()
+	podOS := pod.Spec.NodeSelector["kubernetes.io/os"]
 
-	// Issue a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
-	cnPrefix := envoy.NewXDSCertCNPrefix(proxyUUID, envoy.KindSidecar, identity.New(pod.Spec.ServiceAccountName, namespace))
-	log.Debug().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN prefix=%s", pod.Spec.ServiceAccountName, namespace, cnPrefix)
-	startTime := time.Now()
-	bootstrapCertificate, err := wh.certManager.IssueCertificate(certificate.ForCommonNamePrefix(cnPrefix))
-	if err != nil {
-		log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN prefix=%s", cnPrefix)
-		return nil, err
+	// This needs to occur before replacing the label below.
+	originalUUID, alreadyInjected := getProxyUUID(pod)
+
+	// The Envoy sidecar authenticates to XDS with the service account token projected in its pod rather than with a
+	// client certificate of its bootstrap Secret. The sidecar of an already injected pod keeps authenticating as it did.
+	tokenAuthentication := !strings.EqualFold(podOS, constants.OSWindows) && utils.GetXDSAuthenticationMode(meshConfig) == configv1alpha2.XDSAuthenticationModeServiceAccountToken
+	if alreadyInjected {
+		tokenAuthentication = false
+		for _, c := range append(pod.Spec.Containers, pod.Spec.InitContainers...) {
+			if c.Name == constants.EnvoyContainerName {
+				tokenAuthentication = hasPodTokenVolumeMount(c)
+			}
+		}
 	}
-	elapsed := time.Since(startTime)
 
-	metricsstore.DefaultMetricsStore.CertIssuedCount.Inc()
-	metricsstore.DefaultMetricsStore.CertIssuedTime.
-		WithLabelValues().Observe(elapsed.Seconds())
+	var bootstrapCertificate *certificate.Certificate
+	var err error
+	cnPrefix := envoy.NewXDSCertCNPrefix(proxyUUID, envoy.KindSidecar, identity.New(pod.Spec.ServiceAccountName, namespace))
+	if tokenAuthentication {
+		// The bootstrap Secret only holds the CA the certificate of XDS is verified with
+		log.Debug().Msgf("Patching POD spec: service-account=%s, namespace=%s authenticating to XDS with its service account token", pod.Spec.ServiceAccountName, namespace)
+		bootstrapCertificate, err = wh.certManager.IssueCertificate(certificate.ForServiceIdentityTrust(identity.New(pod.Spec.ServiceAccountName, namespace)))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting the XDS CA for Envoy with service account %s/%s", namespace, pod.Spec.ServiceAccountName)
+			return nil, err
+		}
+	} else {
+		// Issue a certificate for the proxy sidecar - used for Envoy to connect to XDS (not Envoy-to-Envoy connections)
+		log.Debug().Msgf("Patching POD spec: service-account=%s, namespace=%s with certificate CN prefix=%s", pod.Spec.ServiceAccountName, namespace, cnPrefix)
+		startTime := time.Now()
+		bootstrapCertificate, err = wh.certManager.IssueCertificate(certificate.ForCommonNamePrefix(cnPrefix))
+		if err != nil {
+			log.Error().Err(err).Msgf("Error issuing bootstrap certificate for Envoy with CN prefix=%s", cnPrefix)
+			return nil, err
+		}
+		elapsed := time.Since(startTime)
+
+		metricsstore.DefaultMetricsStore.CertIssuedCount.Inc()
+		metricsstore.DefaultMetricsStore.CertIssuedTime.
+			WithLabelValues().Observe(elapsed.Seconds())
+	}
 	originalHealthProbes := rewriteHealthProbes(pod)
 
 	// Create the bootstrap configuration for the Envoy proxy for the given pod
 	envoyBootstrapConfigName := bootstrapConfigName(proxyUUID)
 
-	// This needs to occur before replacing the label below.
-	originalUUID, alreadyInjected := getProxyUUID(pod)
 	switch {
 	case req.DryRun != nil && *req.DryRun:
 		// The webhook has a side effect (making out-of-band changes) of creating k8s secret
@@ -60,13 +93,13 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 		// has already had injection occur. We could simply do nothing and return early, but that would leave 2 pods
 		// with the same UUID, so instead we change the UUID, and create a new bootstrap config, copied from the original,
 		// with the proxy UUID changed.
-		oldConfigName := bootstrapSecretPrefix + originalUUID
+		oldConfigName := constants.EnvoyBootstrapConfigSecretPrefix + originalUUID
 		if _, err := wh.createEnvoyBootstrapFromExisting(proxyUUID, oldConfigName, namespace, bootstrapCertificate); err != nil {
 			log.Error().Err(err).Msgf("Failed to create Envoy bootstrap config for already-injected pod: service-account=%s, namespace=%s, certificate CN prefix=%s", pod.Spec.ServiceAccountName, namespace, cnPrefix)
 			return nil, err
 		}
 	default:
-		if _, err = wh.createEnvoyBootstrapConfig(proxyUUID, namespace, bootstrapCertificate, originalHealthProbes); err != nil {
+		if _, err = wh.createEnvoyBootstrapConfig(proxyUUID, namespace, bootstrapCertificate, originalHealthProbes, tokenAuthentication); err != nil {
 			log.Error().Err(err).Msgf("Failed to create Envoy bootstrap config for pod: service-account=%s, namespace=%s, certificate CN prefix=%s", pod.Spec.ServiceAccountName, namespace, cnPrefix)
 			return nil, err
 		}
@@ -97,7 +130,7 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 		// replace the volume and we're done.
 		for i, volume := range pod.Spec.Volumes {
 			// It should be the last, but we check all for posterity.
-			if volume.Name == envoyBootstrapConfigVolume {
+			if volume.Name == constants.EnvoyBootstrapConfigVolume {
 				pod.Spec.Volumes[i] = getVolumeSpec(envoyBootstrapConfigName)
 				break
 			}
@@ -111,41 +144,109 @@ func (wh *mutatingWebhook) createPatch(pod *corev1.Pod, req *admissionv1.Admissi
 	// On Windows we cannot use init containers to program HNS because it requires elevated privileges
 	// As a result we assume that the HNS redirection policies are already programmed via a CNI plugin.
 	// Skip adding the init container and only patch the pod spec with sidecar container.
-	podOS := pod.Spec.NodeSelector["kubernetes.io/os"]
 	if err := wh.verifyPrerequisites(podOS); err != nil {
 		return nil, err
 	}
 
-	err = wh.configurePodInit(podOS, pod, namespace)
+	nativeSidecars, err := wh.useNativeSidecars(podOS)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wh.configurePodInit(podOS, pod, namespace, nativeSidecars)
 	if err != nil {
 		return nil, err
 	}
 
-	if originalHealthProbes.UsesTCP() {
-		healthcheckContainer := corev1.Container{
-			Name:            "osm-healthcheck",
-			Image:           os.Getenv("OSM_DEFAULT_HEALTHCHECK_CONTAINER_IMAGE"),
-			ImagePullPolicy: wh.osmContainerPullPolicy,
-			Args: []string{
-				"--verbosity", log.GetLevel().String(),
-			},
-			Command: []string{
-				"/osm-healthcheck",
-			},
-			Ports: []corev1.ContainerPort{
-				{
-					ContainerPort: constants.HealthcheckPort,
-				},
-			},
+	// Windows pods cannot run the osm-healthcheck container holding the application and draining the sidecar.
+	var drainDuration time.Duration
+	var holdApplication bool
+	if !strings.EqualFold(podOS, constants.OSWindows) {
+		drainDuration, err = getSidecarDrainDuration(pod, meshConfig)
+		if err != nil {
+			return nil, err
+		}
+		holdApplication, err = isApplicationHeldUntilProxyStarts(pod, meshConfig)
+		if err != nil {
+			return nil, err
 		}
-		pod.Spec.Containers = append(pod.Spec.Containers, healthcheckContainer)
 	}
 
-	// Add the Envoy sidecar
-	sidecar := getEnvoySidecarContainerSpec(pod, wh.kubeController.GetMeshConfig(), originalHealthProbes, podOS)
-	pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+	sidecar := getEnvoySidecarContainerSpec(pod, meshConfig, originalHealthProbes, podOS, drainDuration)
+	if drainDuration > 0 {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, getHealthcheckBinVolume())
+	}
+	injectHealthcheck := originalHealthProbes.UsesTCP() || drainDuration > 0 || holdApplication
+	healthcheckContainer := getHealthcheckContainerSpec(wh.osmContainerPullPolicy, drainDuration, holdApplication)
 
-	return json.Marshal(makePatches(req, pod))
+	// The private key of the Envoy sidecar is generated in the pod by the osm-cert-agent containers: the init container
+	// requests the first certificate before the sidecar starts, and the agent renews it
+	workloadKeyGeneration := !strings.EqualFold(podOS, constants.OSWindows) && utils.GetKeyGenerationMode(meshConfig) == configv1alpha2.KeyGenerationModeWorkload
+	var certAgentInitContainers []corev1.Container
+	var certAgent corev1.Container
+	if workloadKeyGeneration || tokenAuthentication {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, getPodTokenVolume())
+	}
+	if tokenAuthentication {
+		// gRPC reads the token from the file rotated by the kubelet, and verifies the certificate of the token
+		// exchange server with the XDS CA of the bootstrap Secret
+		sidecar.VolumeMounts = append(sidecar.VolumeMounts, getPodTokenVolumeMount())
+		sidecar.Env = append(sidecar.Env, corev1.EnvVar{
+			Name:  grpcSSLRootsFileEnvVar,
+			Value: filepath.Join(bootstrap.EnvoyProxyConfigPath, bootstrap.EnvoyXDSCACertFile),
+		})
+	}
+	if workloadKeyGeneration {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, getCertAgentVolume())
+		sidecar.VolumeMounts = append(sidecar.VolumeMounts, getCertAgentVolumeMount())
+		certAgentInitContainers = append(certAgentInitContainers, getCertAgentContainerSpec(constants.CertAgentInitContainerName, pod, namespace, meshConfig, wh.osmNamespace, wh.osmContainerPullPolicy, true))
+		certAgent = getCertAgentContainerSpec(constants.CertAgentContainerName, pod, namespace, meshConfig, wh.osmNamespace, wh.osmContainerPullPolicy, false)
+	}
+
+	var restartableInitContainers []string
+	switch {
+	case nativeSidecars:
+		// Run the Envoy sidecar and the osm-healthcheck container as native sidecars, started in order right after
+		// the init container programming the iptables rules if any, before the other init containers of the pod
+		sidecars := []corev1.Container{sidecar}
+		if injectHealthcheck {
+			sidecars = append(sidecars, healthcheckContainer)
+		}
+		if workloadKeyGeneration {
+			sidecars = append(sidecars, certAgent)
+		}
+		i := 0
+		if len(pod.Spec.InitContainers) > 0 && pod.Spec.InitContainers[0].Name == constants.InitContainerName {
+			i = 1
+		}
+		initContainers := append(append([]corev1.Container{}, pod.Spec.InitContainers[:i]...), certAgentInitContainers...)
+		initContainers = append(initContainers, sidecars...)
+		pod.Spec.InitContainers = append(initContainers, pod.Spec.InitContainers[i:]...)
+		for _, c := range sidecars {
+			restartableInitContainers = append(restartableInitContainers, c.Name)
+		}
+
+	case !holdApplication:
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, certAgentInitContainers...)
+		if injectHealthcheck {
+			pod.Spec.Containers = append(pod.Spec.Containers, healthcheckContainer)
+		}
+		pod.Spec.Containers = append(pod.Spec.Containers, sidecar)
+		if workloadKeyGeneration {
+			pod.Spec.Containers = append(pod.Spec.Containers, certAgent)
+		}
+
+	default:
+		// Start the Envoy sidecar and the osm-healthcheck container holding the application before the
+		// application containers
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, certAgentInitContainers...)
+		pod.Spec.Containers = append([]corev1.Container{sidecar, healthcheckContainer}, pod.Spec.Containers...)
+		if workloadKeyGeneration {
+			pod.Spec.Containers = append(pod.Spec.Containers, certAgent)
+		}
+	}
+
+	return json.Marshal(makePatches(req, pod, restartableInitContainers...))
 }
 
 // verifyPrerequisites verifies if the prerequisites to patch the request are met by returning an error if unmet
@@ -162,68 +263,60 @@ func (wh *mutatingWebhook) verifyPrerequisites(podOS string) error {
 		// Windows pods require Envoy Windows image
 		return fmt.Errorf("MeshConfig sidecar.envoyWindowsImage not set")
 	}
-	if image := utils.GetInitContainerImage(mc); !isWindows && image == "" {
-		// Linux pods require init container image
+	if image := utils.GetInitContainerImage(mc); !isWindows && !mc.Spec.Sidecar.EnableCNI && image == "" {
+		// Linux pods require init container image, unless the traffic redirection is programmed by the osm-cni plugin
 		return fmt.Errorf("MeshConfig sidecar.initContainerImage not set")
 	}
 
 	return nil
 }
 
-func (wh *mutatingWebhook) configurePodInit(podOS string, pod *corev1.Pod, namespace string) error {
+func (wh *mutatingWebhook) configurePodInit(podOS string, pod *corev1.Pod, namespace string, nativeSidecars bool) error {
 	if strings.EqualFold(podOS, constants.OSWindows) {
 		// No init container for Windows
 		return nil
 	}
 
-	// Build outbound port exclusion list
-	podOutboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, outboundPortExclusionListAnnotation)
+	meshConfig := wh.kubeController.GetMeshConfig()
+	redirection, err := getTrafficRedirection(pod, namespace, meshConfig, nativeSidecars)
 	if err != nil {
 		return err
 	}
-	globalOutboundPortExclusionList := wh.kubeController.GetMeshConfig().Spec.Traffic.OutboundPortExclusionList
-	outboundPortExclusionList := mergePortExclusionLists(podOutboundPortExclusionList, globalOutboundPortExclusionList)
-
-	// Build inbound port exclusion list
-	podInboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, inboundPortExclusionListAnnotation)
-	if err != nil {
-		return err
-	}
-	globalInboundPortExclusionList := wh.kubeController.GetMeshConfig().Spec.Traffic.InboundPortExclusionList
-	inboundPortExclusionList := mergePortExclusionLists(podInboundPortExclusionList, globalInboundPortExclusionList)
-
-	// Build the outbound IP range exclusion list
-	podOutboundIPRangeExclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeExclusionListAnnotation)
-	if err != nil {
-		return err
-	}
-	globalOutboundIPRangeExclusionList := wh.kubeController.GetMeshConfig().Spec.Traffic.OutboundIPRangeExclusionList
-	outboundIPRangeExclusionList := mergeIPRangeLists(podOutboundIPRangeExclusionList, globalOutboundIPRangeExclusionList)
-
-	// Build the outbound IP range inclusion list
-	podOutboundIPRangeInclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeInclusionListAnnotation)
-	if err != nil {
-		return err
+	if meshConfig.Spec.Sidecar.EnableCNI {
+		// The traffic redirection is programmed by the osm-cni plugin when the pod sandbox is created, the
+		// exclusions of the pod being validated above. The pod is only scheduled on the nodes the plugin is
+		// installed on, its traffic bypassing the sidecar otherwise.
+		if pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = map[string]string{}
+		}
+		pod.Spec.NodeSelector[constants.CNIInstalledNodeLabel] = "true"
+		return nil
 	}
-	globalOutboundIPRangeInclusionList := wh.kubeController.GetMeshConfig().Spec.Traffic.OutboundIPRangeInclusionList
-	outboundIPRangeInclusionList := mergeIPRangeLists(podOutboundIPRangeInclusionList, globalOutboundIPRangeInclusionList)
-
-	networkInterfaceExclusionList := wh.kubeController.GetMeshConfig().Spec.Traffic.NetworkInterfaceExclusionList
 
 	// Add the init container to the pod spec
-	initContainer := getInitContainerSpec(constants.InitContainerName, wh.kubeController.GetMeshConfig(), outboundIPRangeExclusionList, outboundIPRangeInclusionList, outboundPortExclusionList, inboundPortExclusionList, wh.kubeController.GetMeshConfig().Spec.Sidecar.EnablePrivilegedInitContainer, wh.osmContainerPullPolicy, networkInterfaceExclusionList)
-	pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
+	initContainer := getInitContainerSpec(constants.InitContainerName, meshConfig, redirection.outboundIPRangeExclusionList, redirection.outboundIPRangeInclusionList, redirection.outboundPortExclusionList, redirection.inboundPortExclusionList, meshConfig.Spec.Sidecar.EnablePrivilegedInitContainer, wh.osmContainerPullPolicy, redirection.networkInterfaceExclusionList)
+	if nativeSidecars {
+		// Program the iptables rules first, so that the Envoy sidecar injected right after as a native sidecar
+		// intercepts the traffic of the init containers and native sidecars of the pod
+		pod.Spec.InitContainers = append([]corev1.Container{initContainer}, pod.Spec.InitContainers...)
+	} else {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, initContainer)
+	}
 
 	return nil
 }
 
-func makePatches(req *admissionv1.AdmissionRequest, pod *corev1.Pod) []jsonpatch.JsonPatchOperation {
+func makePatches(req *admissionv1.AdmissionRequest, pod *corev1.Pod, restartableInitContainers ...string) []jsonpatch.JsonPatchOperation {
 	original := req.Object.Raw
 	current, err := json.Marshal(pod)
 	if err != nil {
 		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingKubernetesResource)).
 			Msgf("Error marshaling Pod with UID=%s", pod.ObjectMeta.UID)
 	}
+	if current, err = setRestartableInitContainers(original, current, restartableInitContainers...); err != nil {
+		log.Error().Err(err).Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrMarshallingKubernetesResource)).
+			Msgf("Error setting the restart policy of the native sidecar containers of Pod with UID=%s", pod.ObjectMeta.UID)
+	}
 	admissionResponse := admission.PatchResponseFromRaw(original, current)
 	return admissionResponse.Patches
 }
@@ -231,13 +324,13 @@ func makePatches(req *admissionv1.AdmissionRequest, pod *corev1.Pod) []jsonpatch
 func getProxyUUID(pod *corev1.Pod) (string, bool) {
 	// kubectl debug does not recreate the object with the same metadata
 	for _, volume := range pod.Spec.Volumes {
-		if volume.Name == envoyBootstrapConfigVolume {
-			return strings.TrimPrefix(volume.Secret.SecretName, bootstrapSecretPrefix), true
+		if volume.Name == constants.EnvoyBootstrapConfigVolume {
+			return strings.TrimPrefix(volume.Secret.SecretName, constants.EnvoyBootstrapConfigSecretPrefix), true
 		}
 	}
 	return "", false
 }
 
 func bootstrapConfigName(proxyUUID uuid.UUID) string {
-	return bootstrapSecretPrefix + proxyUUID.String()
+	return constants.EnvoyBootstrapConfigSecretPrefix + proxyUUID.String()
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
 	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
 	"github.com/openservicemesh/osm/pkg/k8s"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
@@ -38,7 +40,16 @@ func TestCreatePatch(t *testing.T) {
 		os              string
 		namespace       *corev1.Namespace
 		dryRun          bool
+		drainDuration   string
+		holdApplication bool
+		nativeSidecars  bool
+		nativeMode      v1alpha2.NativeSidecarMode
+		cni             bool
+		keyGeneration   v1alpha2.KeyGenerationMode
+		xdsAuth         v1alpha2.XDSAuthenticationMode
 		expectedPatches []string
+		absentPatches   []string
+		expectErr       bool
 	}{
 		{
 			name: "creates a patch for a unix worker",
@@ -110,6 +121,232 @@ func TestCreatePatch(t *testing.T) {
 				`"command":["envoy"]`,
 			},
 		},
+		{
+			name: "sidecar draining enabled",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			drainDuration: "10s",
+			expectedPatches: []string{
+				// Add the volume shared by the osm-healthcheck and Envoy Containers
+				`{"emptyDir":{"medium":"Memory"},"name":"osm-healthcheck-bin"}`,
+				// Add osm-healthcheck Container installing its binary draining Envoy
+				`"name":"osm-healthcheck"`,
+				`"--install-dir","/usr/local/osm-healthcheck"`,
+				`{"mountPath":"/usr/local/osm-healthcheck","name":"osm-healthcheck-bin"}`,
+				// Add Envoy Container with a preStop hook
+				`"command":["envoy"]`,
+				`"--drain-time-s","10","--drain-strategy","immediate"`,
+				`"preStop":{"exec":{"command":["/usr/local/osm-healthcheck/osm-healthcheck","--drain","--drain-duration","10s"]}}`,
+				`{"mountPath":"/usr/local/osm-healthcheck","name":"osm-healthcheck-bin","readOnly":true}`,
+			},
+		},
+		{
+			name: "application held until the sidecar starts",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			holdApplication: true,
+			expectedPatches: []string{
+				// Add Envoy Container first, followed by the osm-healthcheck Container holding the application
+				`"path":"/spec/containers","value":[{"args":["--log-level"`,
+				`"lifecycle":{"postStart":{"exec":{"command":["/osm-healthcheck","--wait-for-envoy-ready"]}}},"name":"osm-healthcheck"`,
+			},
+		},
+		{
+			name: "application held until the native sidecar starts",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			holdApplication: true,
+			nativeSidecars:  true,
+			expectedPatches: []string{
+				// Add Envoy and osm-healthcheck Containers as restartable init containers after the init container
+				`"path":"/spec/initContainers"`,
+				`"name":"osm-init"`,
+				`"name":"envoy","ports"`,
+				`"restartPolicy":"Always","securityContext":{"allowPrivilegeEscalation":false,"runAsUser":1500}`,
+				`"name":"osm-healthcheck","ports":[{"containerPort":15904}],"resources":{},"restartPolicy":"Always"`,
+			},
+		},
+		{
+			name: "native sidecar mode",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			drainDuration:  "10s",
+			nativeSidecars: true,
+			expectedPatches: []string{
+				// Add the init container first, followed by the Envoy Container as a restartable init container
+				`"path":"/spec/initContainers","value":[{"args":["-c","for ip in $(echo \"$POD_IPS\"`,
+				`"name":"osm-init","resources":{},"securityContext":{"capabilities":{"add":["NET_ADMIN"]},"privileged":false,"runAsNonRoot":false,"runAsUser":0}},{"args":["--log-level","error","--config-path","/etc/envoy/bootstrap.yaml","--service-cluster","bookstore.-namespace-","--drain-time-s","10","--drain-strategy","immediate"],"command":["envoy"]`,
+				`"restartPolicy":"Always","securityContext":{"allowPrivilegeEscalation":false,"runAsUser":1500}`,
+				// Drain the native Envoy sidecar with the osm-healthcheck binary installed by its restartable init container
+				`"preStop":{"exec":{"command":["/usr/local/osm-healthcheck/osm-healthcheck","--drain","--drain-duration","10s"]}}`,
+				`"name":"osm-healthcheck","ports":[{"containerPort":15904}],"resources":{},"restartPolicy":"Always"`,
+			},
+		},
+		{
+			name: "native sidecar mode enabled but unsupported",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			nativeMode: v1alpha2.NativeSidecarModeEnabled,
+			expectErr:  true,
+		},
+		{
+			name: "CNI mode",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			cni: true,
+			expectedPatches: []string{
+				// Add Envoy Container without the init container
+				`"path":"/spec/containers"`,
+				`"command":["envoy"]`,
+				// Schedule the pod on the nodes the osm-cni plugin is installed on
+				`{"op":"add","path":"/spec/nodeSelector/openservicemesh.io~1cni-installed","value":"true"}`,
+			},
+			absentPatches: []string{
+				`"name":"osm-init"`,
+			},
+		},
+		{
+			name: "native sidecar mode with CNI",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			nativeSidecars: true,
+			cni:            true,
+			expectedPatches: []string{
+				// Add the Envoy Container first as a restartable init container
+				`"path":"/spec/initContainers","value":[{"args":["--log-level"`,
+				`"restartPolicy":"Always","securityContext":{"allowPrivilegeEscalation":false,"runAsUser":1500}`,
+			},
+			absentPatches: []string{
+				`"name":"osm-init"`,
+			},
+		},
+		{
+			name: "workload key generation",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			keyGeneration: v1alpha2.KeyGenerationModeWorkload,
+			expectedPatches: []string{
+				// Add the in-memory certificate volume and the projected service account token
+				`{"emptyDir":{"medium":"Memory"},"name":"osm-cert-agent-certs"}`,
+				`"serviceAccountToken":{"audience":"osm-controller","expirationSeconds":3600,"path":"token"}`,
+				// Add the osm-cert-agent init container requesting the first certificate after the init container
+				`"name":"osm-init"`,
+				`"--controller-address","https://osm-controller.osm-system.svc:15129","--service-account","bookstore","--namespace","-namespace-","--key-bit-size","2048","--once"],"command":["/osm-cert-agent"],"name":"osm-cert-agent-init"`,
+				// Mount the certificate volume read-only in the Envoy Container
+				`{"mountPath":"/etc/osm/certs","name":"osm-cert-agent-certs","readOnly":true}`,
+				// Add the osm-cert-agent Container renewing the certificate
+				`"--controller-address","https://osm-controller.osm-system.svc:15129","--service-account","bookstore","--namespace","-namespace-","--key-bit-size","2048"],"command":["/osm-cert-agent"],"name":"osm-cert-agent"`,
+				`"securityContext":{"allowPrivilegeEscalation":false,"capabilities":{"drop":["ALL"]},"readOnlyRootFilesystem":true,"runAsNonRoot":true,"runAsUser":1500}`,
+			},
+		},
+		{
+			name: "workload key generation with native sidecars",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			keyGeneration:  v1alpha2.KeyGenerationModeWorkload,
+			nativeSidecars: true,
+			expectedPatches: []string{
+				// Add the osm-cert-agent init container before the Envoy Container, and the osm-cert-agent Container
+				// as a restartable init container
+				`"name":"osm-cert-agent-init","resources":{}`,
+				`"name":"osm-cert-agent","resources":{},"restartPolicy":"Always"`,
+			},
+		},
+		{
+			name: "workload key generation on a windows worker",
+			os:   constants.OSWindows,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			keyGeneration: v1alpha2.KeyGenerationModeWorkload,
+			absentPatches: []string{
+				`"name":"osm-cert-agent`,
+			},
+		},
+		{
+			name: "service account token authentication",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			xdsAuth: v1alpha2.XDSAuthenticationModeServiceAccountToken,
+			expectedPatches: []string{
+				// Add the projected service account token, mounted read-only in the Envoy Container
+				`"serviceAccountToken":{"audience":"osm-controller","expirationSeconds":3600,"path":"token"}`,
+				`{"mountPath":"/var/run/secrets/osm","name":"osm-pod-token","readOnly":true}`,
+				// Verify the certificate of the token exchange server with the XDS CA
+				`{"name":"GRPC_DEFAULT_SSL_ROOTS_FILE_PATH","value":"/etc/envoy/cacert.pem"}`,
+			},
+		},
+		{
+			name: "service account token authentication with workload key generation",
+			os:   constants.OSLinux,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			keyGeneration: v1alpha2.KeyGenerationModeWorkload,
+			xdsAuth:       v1alpha2.XDSAuthenticationModeServiceAccountToken,
+			expectedPatches: []string{
+				`{"mountPath":"/var/run/secrets/osm","name":"osm-pod-token","readOnly":true}`,
+				`"name":"osm-cert-agent"`,
+			},
+		},
+		{
+			name: "service account token authentication on a windows worker",
+			os:   constants.OSWindows,
+			namespace: &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: namespace,
+				},
+			},
+			xdsAuth: v1alpha2.XDSAuthenticationModeServiceAccountToken,
+			absentPatches: []string{
+				`"name":"osm-pod-token"`,
+				`GRPC_DEFAULT_SSL_ROOTS_FILE_PATH`,
+			},
+		},
 		{
 			name: "unix dry run",
 			os:   constants.OSLinux,
@@ -136,9 +373,11 @@ func TestCreatePatch(t *testing.T) {
 			wh := &mutatingWebhook{
 				kubeClient:          client,
 				kubeController:      mockNsController,
+				osmNamespace:        "osm-system",
 				certManager:         tresorFake.NewFake(1 * time.Hour),
 				nonInjectNamespaces: mapset.NewSet(),
 			}
+			wh.nativeSidecarsSupported.Store(tc.nativeSidecars)
 
 			mockNsController.EXPECT().GetMeshConfig().Return(v1alpha2.MeshConfig{
 				Spec: v1alpha2.MeshConfigSpec{
@@ -147,6 +386,15 @@ func TestCreatePatch(t *testing.T) {
 						EnvoyImage:         "envoy-windows-image",
 						InitContainerImage: "init-container-image",
 						Resources:          corev1.ResourceRequirements{},
+						DrainDuration:      tc.drainDuration,
+						EnableCNI:          tc.cni,
+						NativeSidecarMode:  tc.nativeMode,
+
+						HoldApplicationUntilProxyStarts: tc.holdApplication,
+						XDSAuthentication:               tc.xdsAuth,
+					},
+					Certificate: v1alpha2.CertificateSpec{
+						KeyGeneration: tc.keyGeneration,
 					},
 				},
 			}).AnyTimes()
@@ -162,12 +410,24 @@ func TestCreatePatch(t *testing.T) {
 				DryRun:    &tc.dryRun,
 			}
 			rawPatches, err := wh.createPatch(pod, req, proxyUUID)
+			if tc.expectErr {
+				assert.ErrorIs(err, errNativeSidecarsNotSupported)
+				return
+			}
 			assert.NoError(err)
 			patches := string(rawPatches)
 
 			for _, expectedPatch := range tc.expectedPatches {
 				assert.Contains(patches, expectedPatch)
 			}
+			for _, absentPatch := range tc.absentPatches {
+				assert.NotContains(patches, absentPatch)
+			}
+			// The service account token is projected once
+			assert.LessOrEqual(strings.Count(patches, `"name":"osm-pod-token","projected"`), 1)
+
+			// The bootstrap Secret of a proxy authenticating with its service account token holds no credentials
+			tokenAuthentication := tc.xdsAuth == v1alpha2.XDSAuthenticationModeServiceAccountToken && tc.os != constants.OSWindows
 
 			// Ensure the bootstrap config was created if not in dry run
 			conf, err := client.CoreV1().Secrets(namespace).Get(ctx, "envoy-bootstrap-config-"+proxyUUID.String(), metav1.GetOptions{})
@@ -177,6 +437,9 @@ func TestCreatePatch(t *testing.T) {
 			} else {
 				assert.NoError(err)
 				assert.NotNil(conf)
+				assert.Equal(!tokenAuthentication, conf.Data[bootstrap.EnvoyXDSKeyFile] != nil)
+				assert.NotEmpty(conf.Data[bootstrap.EnvoyXDSCACertFile])
+				assert.Equal(tokenAuthentication, strings.Contains(string(conf.Data[bootstrap.EnvoyBootstrapConfigFile]), "https://osm-controller.osm-system.svc:15129/v1/token"))
 			}
 
 			// Now we try to reinject, and ensure the only patch is the updated UUID. We also verify the config was
@@ -218,6 +481,7 @@ func TestCreatePatch(t *testing.T) {
 			} else {
 				assert.NoError(err)
 				assert.NotNil(conf)
+				assert.Equal(!tokenAuthentication, conf.Data[bootstrap.EnvoyXDSKeyFile] != nil)
 			}
 		})
 	}
@@ -265,6 +529,7 @@ func TestVerifyPrerequisites(t *testing.T) {
 		linuxImage   string
 		windowsImage string
 		initImage    string
+		cni          bool
 		expectErr    bool
 	}{
 		{
@@ -278,6 +543,12 @@ func TestVerifyPrerequisites(t *testing.T) {
 			linuxImage: "envoy",
 			expectErr:  true,
 		},
+		{
+			name:       "prereqs met for linux pod when init container image is missing in CNI mode",
+			linuxImage: "envoy",
+			cni:        true,
+			expectErr:  false,
+		},
 		{
 			name:      "prereqs not met for linux pod when envoy container image is missing",
 			initImage: "init",
@@ -321,6 +592,7 @@ func TestVerifyPrerequisites(t *testing.T) {
 						EnvoyWindowsImage:  tc.windowsImage,
 						EnvoyImage:         tc.linuxImage,
 						InitContainerImage: tc.initImage,
+						EnableCNI:          tc.cni,
 					},
 				},
 			}).AnyTimes()
@@ -0,0 +1,231 @@
+package injector
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// podIPFamiliesScript sets the POD_IPV4 and POD_IPV6 shell variables to the IPv4 and IPv6 addresses of the pod, if any,
+// from the comma separated list of pod IPs of the POD_IPS environment variable
+const podIPFamiliesScript = `for ip in $(echo "$POD_IPS" | tr ',' ' '); do
+  case "$ip" in
+    *:*) POD_IPV6="$ip" ;;
+    *) POD_IPV4="$ip" ;;
+  esac
+done
+`
+
+// ipFamilies is the list of IP families whose traffic is redirected, in the order their rules are programmed
+var ipFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+
+// RedirectionRules is the type used to represent the traffic interception and redirection rules of a pod for an IP family
+type RedirectionRules struct {
+	// IPFamily is the IP family of the traffic redirected by the rules
+	IPFamily corev1.IPFamily
+
+	// Command is the command programming the rules, reading them from its standard input
+	Command []string
+
+	// Rules is the input of the command
+	Rules string
+}
+
+// trafficRedirection is the type used to represent the traffic interception and redirection settings of a pod,
+// merging the exclusions specified by the pod's annotations with the mesh-wide exclusions of the MeshConfig
+type trafficRedirection struct {
+	outboundIPRangeExclusionList  []string
+	outboundIPRangeInclusionList  []string
+	outboundPortExclusionList     []int
+	inboundPortExclusionList      []int
+	networkInterfaceExclusionList []string
+}
+
+// getTrafficRedirection returns the traffic interception and redirection settings of the given pod. The ports probed
+// by its native sidecars are excluded from inbound traffic interception when nativeSidecars is set.
+func getTrafficRedirection(pod *corev1.Pod, namespace string, meshConfig v1alpha2.MeshConfig, nativeSidecars bool) (trafficRedirection, error) {
+	// Build outbound port exclusion list
+	podOutboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, outboundPortExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirection{}, err
+	}
+	outboundPortExclusionList := mergePortExclusionLists(podOutboundPortExclusionList, meshConfig.Spec.Traffic.OutboundPortExclusionList)
+
+	// Build inbound port exclusion list
+	podInboundPortExclusionList, err := getPortExclusionListForPod(pod, namespace, inboundPortExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirection{}, err
+	}
+	inboundPortExclusionList := mergePortExclusionLists(podInboundPortExclusionList, meshConfig.Spec.Traffic.InboundPortExclusionList)
+	if nativeSidecars {
+		inboundPortExclusionList = mergePortExclusionLists(inboundPortExclusionList, getNativeSidecarProbePorts(pod))
+	}
+
+	// Build the outbound IP range exclusion list
+	podOutboundIPRangeExclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeExclusionListAnnotation)
+	if err != nil {
+		return trafficRedirection{}, err
+	}
+	outboundIPRangeExclusionList := mergeIPRangeLists(podOutboundIPRangeExclusionList, meshConfig.Spec.Traffic.OutboundIPRangeExclusionList)
+
+	// Build the outbound IP range inclusion list
+	podOutboundIPRangeInclusionList, err := getOutboundIPRangeListForPod(pod, namespace, outboundIPRangeInclusionListAnnotation)
+	if err != nil {
+		return trafficRedirection{}, err
+	}
+	outboundIPRangeInclusionList := mergeIPRangeLists(podOutboundIPRangeInclusionList, meshConfig.Spec.Traffic.OutboundIPRangeInclusionList)
+
+	return trafficRedirection{
+		outboundIPRangeExclusionList:  outboundIPRangeExclusionList,
+		outboundIPRangeInclusionList:  outboundIPRangeInclusionList,
+		outboundPortExclusionList:     outboundPortExclusionList,
+		inboundPortExclusionList:      inboundPortExclusionList,
+		networkInterfaceExclusionList: meshConfig.Spec.Traffic.NetworkInterfaceExclusionList,
+	}, nil
+}
+
+// RequiresCNIRedirection returns whether the traffic redirection of the given pod must be programmed by the osm-cni
+// plugin, i.e. the pod was injected with the Envoy sidecar but without the init container programming the rules.
+// Such pods select the nodes labeled by the osm-cni installer, so that they only run where the plugin is installed.
+func RequiresCNIRedirection(pod *corev1.Pod) bool {
+	if _, ok := pod.Labels[constants.EnvoyUniqueIDLabelName]; !ok {
+		return false
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == constants.InitContainerName {
+			return false
+		}
+	}
+	return true
+}
+
+// GenerateRedirectionRules returns the traffic interception and redirection rules of the given injected pod with the
+// given IPs, one for each IP family of the pod, identical to the rules programmed by the init container with the
+// redirection backend selected by the MeshConfig. It is used by the osm-cni plugin, programming the rules of pods
+// injected without the init container in their network namespace.
+func GenerateRedirectionRules(pod *corev1.Pod, meshConfig v1alpha2.MeshConfig, podIPs []net.IP) ([]RedirectionRules, error) {
+	redirection, err := getTrafficRedirection(pod, pod.Namespace, meshConfig, hasNativeSidecar(pod))
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []RedirectionRules
+	for _, ipFamily := range ipFamilies {
+		podIP := getIPOfFamily(podIPs, ipFamily)
+		if podIP == nil {
+			continue
+		}
+
+		r := RedirectionRules{IPFamily: ipFamily}
+		if useNftables(meshConfig) {
+			r.Command = nftablesCommand
+			r.Rules = generateNftablesRules(ipFamily, meshConfig.Spec.Sidecar.LocalProxyMode, podIP.String(),
+				redirection.outboundIPRangeExclusionList, redirection.outboundIPRangeInclusionList, redirection.outboundPortExclusionList,
+				redirection.inboundPortExclusionList, redirection.networkInterfaceExclusionList)
+		} else {
+			r.Command = iptablesCommand(ipFamily)
+			r.Rules = generateIptablesRules(ipFamily, meshConfig.Spec.Sidecar.LocalProxyMode, podIP.String(),
+				redirection.outboundIPRangeExclusionList, redirection.outboundIPRangeInclusionList, redirection.outboundPortExclusionList,
+				redirection.inboundPortExclusionList, redirection.networkInterfaceExclusionList)
+		}
+		rules = append(rules, r)
+	}
+
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("pod %s/%s has no IP address", pod.Namespace, pod.Name)
+	}
+	return rules, nil
+}
+
+// generateRedirectionScript returns the shell script programming the traffic interception and redirection rules of each
+// IP family of the pod, generate returning the command programming the rules of an IP family and its input given the
+// shell variable holding the pod IP of this family
+func generateRedirectionScript(generate func(ipFamily corev1.IPFamily, podIP string) ([]string, string)) string {
+	var script strings.Builder
+	script.WriteString(podIPFamiliesScript)
+
+	for _, ipFamily := range ipFamilies {
+		podIP := "$POD_IPV4"
+		if ipFamily == corev1.IPv6Protocol {
+			podIP = "$POD_IPV6"
+		}
+
+		command, rules := generate(ipFamily, podIP)
+		fmt.Fprintf(&script, `if [ -n "%s" ]; then
+%s <<EOF
+%s
+EOF
+fi
+`, podIP, strings.Join(command, " "), rules)
+	}
+
+	return script.String()
+}
+
+// getIPOfFamily returns the first of the given IP addresses of the given IP family, nil if none is
+func getIPOfFamily(ips []net.IP, ipFamily corev1.IPFamily) net.IP {
+	for _, ip := range ips {
+		if isIPOfFamily(ip, ipFamily) {
+			return ip
+		}
+	}
+	return nil
+}
+
+// isIPOfFamily returns whether the given IP address is of the given IP family
+func isIPOfFamily(ip net.IP, ipFamily corev1.IPFamily) bool {
+	return (ip.To4() != nil) == (ipFamily == corev1.IPv4Protocol)
+}
+
+// loopbackAddress returns the loopback address of the given IP family
+func loopbackAddress(ipFamily corev1.IPFamily) string {
+	if ipFamily == corev1.IPv6Protocol {
+		return constants.LocalhostIPv6Address
+	}
+	return constants.LocalhostIPAddress
+}
+
+// filterIPRanges returns the given IP ranges of the given IP family
+func filterIPRanges(ipRanges []string, ipFamily corev1.IPFamily) []string {
+	var filtered []string
+	for _, ipRange := range ipRanges {
+		ip, _, err := net.ParseCIDR(ipRange)
+		if err != nil {
+			// IP ranges are validated by the MeshConfig CRD and the pod annotation parser
+			continue
+		}
+		if isIPOfFamily(ip, ipFamily) {
+			filtered = append(filtered, ipRange)
+		}
+	}
+	return filtered
+}
+
+// useNftables returns whether the traffic interception and redirection rules are programmed with nftables, based on
+// the MeshConfig's sidecar.redirectionBackend
+func useNftables(meshConfig v1alpha2.MeshConfig) bool {
+	switch backend := meshConfig.Spec.Sidecar.RedirectionBackend; backend {
+	case v1alpha2.RedirectionBackendNftables:
+		return true
+	case v1alpha2.RedirectionBackendIptables, "":
+		return false
+	default:
+		log.Error().Msgf("Invalid MeshConfig sidecar.redirectionBackend %q, programming the rules with iptables", backend)
+		return false
+	}
+}
+
+// hasNativeSidecar returns whether the Envoy sidecar of the given pod was injected as a native sidecar container
+func hasNativeSidecar(pod *corev1.Pod) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == constants.EnvoyContainerName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,252 @@
+package injector
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+func TestRequiresCNIRedirection(t *testing.T) {
+	testCases := []struct {
+		name           string
+		labels         map[string]string
+		initContainers []corev1.Container
+		expected       bool
+	}{
+		{
+			name:     "pod not injected",
+			expected: false,
+		},
+		{
+			name:           "pod injected with the init container",
+			labels:         map[string]string{constants.EnvoyUniqueIDLabelName: "uuid"},
+			initContainers: []corev1.Container{{Name: constants.InitContainerName}},
+			expected:       false,
+		},
+		{
+			name:     "pod injected without the init container",
+			labels:   map[string]string{constants.EnvoyUniqueIDLabelName: "uuid"},
+			expected: true,
+		},
+		{
+			name:           "pod injected with native sidecars without the init container",
+			labels:         map[string]string{constants.EnvoyUniqueIDLabelName: "uuid"},
+			initContainers: []corev1.Container{{Name: constants.EnvoyContainerName}},
+			expected:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: tc.labels,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: tc.initContainers,
+				},
+			}
+			assert.Equal(tc.expected, RequiresCNIRedirection(pod))
+		})
+	}
+}
+
+func TestGenerateRedirectionRules(t *testing.T) {
+	meshConfig := v1alpha2.MeshConfig{
+		Spec: v1alpha2.MeshConfigSpec{
+			Sidecar: v1alpha2.SidecarSpec{
+				LocalProxyMode: v1alpha2.LocalProxyModePodIP,
+			},
+			Traffic: v1alpha2.TrafficSpec{
+				OutboundPortExclusionList:     []int{6379},
+				InboundPortExclusionList:      []int{8081},
+				OutboundIPRangeExclusionList:  []string{"10.0.0.0/8"},
+				NetworkInterfaceExclusionList: []string{"net1"},
+			},
+		},
+	}
+
+	nftablesMeshConfig := *meshConfig.DeepCopy()
+	nftablesMeshConfig.Spec.Sidecar.RedirectionBackend = v1alpha2.RedirectionBackendNftables
+
+	testCases := []struct {
+		name             string
+		pod              *corev1.Pod
+		meshConfig       v1alpha2.MeshConfig
+		podIPs           []net.IP
+		expectedCommands [][]string
+		expectedRules    []string
+		absentRules      []string
+		expectErr        bool
+	}{
+		{
+			name: "MeshConfig and annotation exclusions are merged",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						outboundPortExclusionListAnnotation:    "3306",
+						inboundPortExclusionListAnnotation:     "9090",
+						outboundIPRangeExclusionListAnnotation: "1.1.1.1/32",
+					},
+				},
+			},
+			meshConfig:       meshConfig,
+			podIPs:           []net.IP{net.ParseIP("10.1.2.3")},
+			expectedCommands: [][]string{{"iptables-restore", "--noflush"}},
+			expectedRules: []string{
+				"-A OSM_PROXY_OUTBOUND -p tcp --match multiport --dports 6379,3306 -j RETURN",
+				"-I OSM_PROXY_INBOUND -p tcp --match multiport --dports 8081,9090 -j RETURN",
+				"-A OSM_PROXY_OUTBOUND -d 1.1.1.1/32 -j RETURN",
+				"-A OSM_PROXY_OUTBOUND -d 10.0.0.0/8 -j RETURN",
+				"-I OSM_PROXY_INBOUND -i net1 -j RETURN",
+				"-j DNAT --to-destination 10.1.2.3",
+			},
+		},
+		{
+			name: "native sidecar probe ports are excluded",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name: constants.EnvoyContainerName,
+						},
+						{
+							Name: "sidecar",
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(7070)},
+								},
+							},
+						},
+					},
+				},
+			},
+			meshConfig:       meshConfig,
+			podIPs:           []net.IP{net.ParseIP("10.1.2.3")},
+			expectedCommands: [][]string{{"iptables-restore", "--noflush"}},
+			expectedRules: []string{
+				"-I OSM_PROXY_INBOUND -p tcp --match multiport --dports 7070,8081 -j RETURN",
+			},
+		},
+		{
+			name: "dual-stack pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						outboundIPRangeExclusionListAnnotation: "fd00:1::/64",
+					},
+				},
+			},
+			meshConfig:       meshConfig,
+			podIPs:           []net.IP{net.ParseIP("fd00::5"), net.ParseIP("10.1.2.3")},
+			expectedCommands: [][]string{{"iptables-restore", "--noflush"}, {"ip6tables-restore", "--noflush"}},
+			expectedRules: []string{
+				"-A OSM_PROXY_OUTBOUND -d 10.0.0.0/8 -j RETURN",
+				"-A OSM_PROXY_OUTBOUND -d 127.0.0.1/32 -j RETURN",
+				"-j DNAT --to-destination 10.1.2.3",
+				"-A OSM_PROXY_OUTBOUND -d fd00:1::/64 -j RETURN",
+				"-A OSM_PROXY_OUTBOUND -d ::1/128 -j RETURN",
+				"-j DNAT --to-destination fd00::5",
+			},
+		},
+		{
+			name: "IPv6-only pod",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						outboundIPRangeExclusionListAnnotation: "fd00:1::/64",
+					},
+				},
+			},
+			meshConfig:       meshConfig,
+			podIPs:           []net.IP{net.ParseIP("fd00::5")},
+			expectedCommands: [][]string{{"ip6tables-restore", "--noflush"}},
+			expectedRules: []string{
+				"-A OSM_PROXY_OUTBOUND -d fd00:1::/64 -j RETURN",
+				"-I OSM_PROXY_INBOUND -i net1 -j RETURN",
+				"-I OUTPUT -p tcp -o lo -d ::1/128 -m owner --uid-owner 1500 -j DNAT --to-destination fd00::5",
+			},
+			absentRules: []string{
+				"10.0.0.0/8",
+				"127.0.0.1",
+			},
+		},
+		{
+			name: "nftables backend",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						outboundPortExclusionListAnnotation: "3306",
+					},
+				},
+			},
+			meshConfig:       nftablesMeshConfig,
+			podIPs:           []net.IP{net.ParseIP("10.1.2.3")},
+			expectedCommands: [][]string{{"nft", "-f", "-"}},
+			expectedRules: []string{
+				"tcp dport { 6379, 3306 } return",
+				"tcp dport { 8081 } return",
+				"ip daddr 10.0.0.0/8 return",
+				`iifname "net1" return`,
+				"dnat to 10.1.2.3",
+			},
+		},
+		{
+			name: "invalid annotation",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						outboundPortExclusionListAnnotation: "not-a-port",
+					},
+				},
+			},
+			meshConfig: meshConfig,
+			podIPs:     []net.IP{net.ParseIP("10.1.2.3")},
+			expectErr:  true,
+		},
+		{
+			name:       "pod without IP address",
+			pod:        &corev1.Pod{},
+			meshConfig: meshConfig,
+			expectErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			redirectionRules, err := GenerateRedirectionRules(tc.pod, tc.meshConfig, tc.podIPs)
+			if tc.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+
+			var commands [][]string
+			var rules string
+			for _, r := range redirectionRules {
+				assert.True(strings.HasPrefix(r.Rules, "# OSM sidecar interception rules"))
+				commands = append(commands, r.Command)
+				rules += r.Rules
+			}
+			assert.Equal(tc.expectedCommands, commands)
+			for _, rule := range tc.expectedRules {
+				assert.Contains(rules, rule)
+			}
+			for _, rule := range tc.absentRules {
+				assert.NotContains(rules, rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package injector
+
+import (
+	"encoding/json"
+)
+
+// The vendored Kubernetes API types, k8s.io/api v0.24, predate the restartPolicy field of containers added in v1.28
+// for native sidecar containers. The restart policy is lost when decoding a pod, so it is set on the JSON of the
+// mutated pod before the patch is computed. This file can be removed once k8s.io/api is bumped to v0.28 or later,
+// setting corev1.Container.RestartPolicy instead.
+
+const (
+	// containerRestartPolicyAlways is the restart policy of the init containers running as native sidecar containers
+	containerRestartPolicyAlways = "Always"
+)
+
+// podInitContainers is the type used to decode the init containers of a pod along with their restart policy, which
+// the vendored Kubernetes API types predate
+type podInitContainers struct {
+	Spec struct {
+		InitContainers []struct {
+			Name          string `json:"name"`
+			RestartPolicy string `json:"restartPolicy,omitempty"`
+		} `json:"initContainers,omitempty"`
+	} `json:"spec"`
+}
+
+// setRestartableInitContainers returns the current pod JSON with the Always restart policy set on the given init
+// containers, as well as on the init containers of the original pod JSON running as native sidecars, whose restart
+// policy is lost when decoding the pod. The current pod JSON is returned unchanged on error.
+func setRestartableInitContainers(original, current []byte, names ...string) ([]byte, error) {
+	restartable := make(map[string]bool, len(names))
+	for _, name := range names {
+		restartable[name] = true
+	}
+
+	var originalPod podInitContainers
+	if err := json.Unmarshal(original, &originalPod); err != nil {
+		return current, err
+	}
+	for _, container := range originalPod.Spec.InitContainers {
+		if container.RestartPolicy == containerRestartPolicyAlways {
+			restartable[container.Name] = true
+		}
+	}
+	if len(restartable) == 0 {
+		return current, nil
+	}
+
+	var pod map[string]interface{}
+	if err := json.Unmarshal(current, &pod); err != nil {
+		return current, err
+	}
+	spec, _ := pod["spec"].(map[string]interface{})
+	initContainers, _ := spec["initContainers"].([]interface{})
+	for _, c := range initContainers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _ := container["name"].(string); restartable[name] {
+			container["restartPolicy"] = containerRestartPolicyAlways
+		}
+	}
+
+	patched, err := json.Marshal(pod)
+	if err != nil {
+		return current, err
+	}
+	return patched, nil
+}
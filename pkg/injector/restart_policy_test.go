@@ -0,0 +1,81 @@
+package injector
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestSetRestartableInitContainers(t *testing.T) {
+	testCases := []struct {
+		name      string
+		original  string
+		current   string
+		names     []string
+		expected  string
+		expectErr bool
+	}{
+		{
+			name:     "no restartable init containers",
+			original: `{"spec":{"initContainers":[{"name":"app-init"}]}}`,
+			current:  `{"spec":{"initContainers":[{"name":"app-init"},{"name":"osm-init"}]}}`,
+			expected: `{"spec":{"initContainers":[{"name":"app-init"},{"name":"osm-init"}]}}`,
+		},
+		{
+			name:     "injected native sidecars",
+			original: `{"spec":{}}`,
+			current:  `{"spec":{"initContainers":[{"name":"osm-init"},{"name":"envoy"}]}}`,
+			names:    []string{"envoy"},
+			expected: `{"spec":{"initContainers":[{"name":"osm-init"},{"name":"envoy","restartPolicy":"Always"}]}}`,
+		},
+		{
+			name:     "native sidecars of the original pod are kept",
+			original: `{"spec":{"initContainers":[{"name":"log-shipper","restartPolicy":"Always"}]}}`,
+			current:  `{"spec":{"initContainers":[{"name":"log-shipper"},{"name":"osm-init"}]}}`,
+			expected: `{"spec":{"initContainers":[{"name":"log-shipper","restartPolicy":"Always"},{"name":"osm-init"}]}}`,
+		},
+		{
+			name:     "other fields of the init containers are kept",
+			original: `{"spec":{}}`,
+			current:  `{"metadata":{"name":"pod"},"spec":{"initContainers":[{"name":"envoy","image":"envoy","args":["-c","bootstrap.yaml"]}]}}`,
+			names:    []string{"envoy"},
+			expected: `{"metadata":{"name":"pod"},"spec":{"initContainers":[{"name":"envoy","image":"envoy","args":["-c","bootstrap.yaml"],"restartPolicy":"Always"}]}}`,
+		},
+		{
+			name:     "unknown init containers are ignored",
+			original: `{"spec":{}}`,
+			current:  `{"spec":{"initContainers":[{"name":"osm-init"}]}}`,
+			names:    []string{"envoy"},
+			expected: `{"spec":{"initContainers":[{"name":"osm-init"}]}}`,
+		},
+		{
+			name:      "invalid original pod",
+			original:  `{"spec":`,
+			current:   `{"spec":{"initContainers":[{"name":"envoy"}]}}`,
+			names:     []string{"envoy"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid current pod",
+			original:  `{"spec":{}}`,
+			current:   `{"spec":`,
+			names:     []string{"envoy"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			actual, err := setRestartableInitContainers([]byte(tc.original), []byte(tc.current), tc.names...)
+			assert.Equal(tc.expectErr, err != nil)
+			if tc.expectErr {
+				// The current pod is returned unchanged
+				assert.Equal(tc.current, string(actual))
+				return
+			}
+			assert.JSONEq(tc.expected, string(actual))
+		})
+	}
+}
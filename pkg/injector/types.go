@@ -3,6 +3,8 @@
 package injector
 
 import (
+	"sync/atomic"
+
 	mapset "github.com/deckarep/golang-set"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -12,8 +14,6 @@ import (
 	"github.com/openservicemesh/osm/pkg/logger"
 )
 
-const envoyBootstrapConfigVolume = "envoy-bootstrap-config-volume"
-
 var log = logger.New("sidecar-injector")
 
 // mutatingWebhook is the type used to represent the webhook for sidecar injection
@@ -25,6 +25,13 @@ type mutatingWebhook struct {
 	meshName               string
 	osmContainerPullPolicy corev1.PullPolicy
 
+	// nativeSidecarsSupported indicates whether the Kubernetes API server supports native sidecar containers,
+	// refreshed periodically as the API server may be upgraded while osm-injector runs
+	nativeSidecarsSupported atomic.Bool
+
+	// ipv6PrimaryCluster indicates whether the primary IP family of the cluster is IPv6
+	ipv6PrimaryCluster bool
+
 	nonInjectNamespaces mapset.Set
 }
 
@@ -2,12 +2,24 @@ package injector
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+const (
+	// podTokenVolume is the name of the volume holding the service account token authenticating the pod to
+	// osm-controller
+	podTokenVolume = "osm-pod-token"
+
+	// podTokenExpirationSeconds is the validity of the projected service account token, rotated by the kubelet
+	podTokenExpirationSeconds = 3600
 )
 
 // getVolumeSpec returns a volume to add to the POD
 func getVolumeSpec(envoyBootstrapConfigName string) corev1.Volume {
 	return corev1.Volume{
-		Name: envoyBootstrapConfigVolume,
+		Name: constants.EnvoyBootstrapConfigVolume,
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
 				SecretName: envoyBootstrapConfigName,
@@ -15,3 +27,41 @@ func getVolumeSpec(envoyBootstrapConfigName string) corev1.Volume {
 		},
 	}
 }
+
+// getPodTokenVolume returns the volume of the service account token bound to the pod, whose audience is
+// osm-controller. It authenticates the osm-cert-agent container and the Envoy sidecar to osm-controller.
+func getPodTokenVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: podTokenVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          constants.PodTokenAudience,
+						ExpirationSeconds: pointer.Int64Ptr(podTokenExpirationSeconds),
+						Path:              constants.PodTokenFile,
+					},
+				}},
+			},
+		},
+	}
+}
+
+// getPodTokenVolumeMount returns the read-only mount of the volume of getPodTokenVolume
+func getPodTokenVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      podTokenVolume,
+		ReadOnly:  true,
+		MountPath: constants.PodTokenDir,
+	}
+}
+
+// hasPodTokenVolumeMount returns whether the given container mounts the volume of getPodTokenVolume
+func hasPodTokenVolumeMount(container corev1.Container) bool {
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == podTokenVolume {
+			return true
+		}
+	}
+	return false
+}
@@ -30,13 +30,11 @@ const (
 
 	// webhookCreatePod is the HTTP path at which the webhook expects to receive pod creation events
 	webhookCreatePod = "/mutate-pod-creation"
-
-	bootstrapSecretPrefix = "envoy-bootstrap-config-"
 )
 
 // NewMutatingWebhook starts a new web server handling requests from the injector MutatingWebhookConfiguration
 func NewMutatingWebhook(ctx context.Context, kubeClient kubernetes.Interface, certManager *certificate.Manager, kubeController k8s.Controller, meshName, osmNamespace, webhookConfigName, osmVersion string, webhookTimeout int32, enableReconciler bool, osmContainerPullPolicy corev1.PullPolicy) error {
-	wh := mutatingWebhook{
+	wh := &mutatingWebhook{
 		kubeClient:             kubeClient,
 		certManager:            certManager,
 		kubeController:         kubeController,
@@ -44,6 +42,8 @@ func NewMutatingWebhook(ctx context.Context, kubeClient kubernetes.Interface, ce
 		meshName:               meshName,
 		osmContainerPullPolicy: osmContainerPullPolicy,
 
+		ipv6PrimaryCluster: isIPv6PrimaryCluster(kubeClient),
+
 		// Envoy sidecars should never be injected in these namespaces
 		nonInjectNamespaces: mapset.NewSet(
 			metav1.NamespaceSystem,
@@ -52,6 +52,9 @@ func NewMutatingWebhook(ctx context.Context, kubeClient kubernetes.Interface, ce
 		),
 	}
 
+	wh.nativeSidecarsSupported.Store(supportsNativeSidecars(kubeClient))
+	go wh.refreshNativeSidecarSupport(ctx, nativeSidecarSupportRefreshInterval)
+
 	// We know that the events arriving at this handler are CREATE POD only
 	// because of the specifics of MutatingWebhookConfiguration template in this repository.
 
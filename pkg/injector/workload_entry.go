@@ -0,0 +1,189 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/certificate/pem"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/models"
+)
+
+const (
+	// workloadEntryIptablesScriptFile is the key of the bootstrap config Secret of a WorkloadEntry holding the
+	// script that sets up the interception of the workload's traffic by its sidecar
+	workloadEntryIptablesScriptFile = "iptables.sh"
+
+	// workloadEntryRenewalInterval is the interval at which the certificates of the bootstrap config Secrets of the
+	// WorkloadEntry resources are checked for renewal
+	workloadEntryRenewalInterval = 10 * time.Minute
+)
+
+// WatchAndCreateWorkloadEntryBootstrap watches for WorkloadEntry resources being added to the mesh and
+// creates the bootstrap config Secret for the Envoy sidecar running on the host of each workload.
+// The Secret is owned by the WorkloadEntry, and is retrieved by the `osm vm bootstrap` command.
+// The certificate of the Secret is renewed when it is about to expire, is revoked or is not signed by the signing
+// issuer anymore, such as after a root certificate rotation. The hosts fetch it again with `osm vm bootstrap`.
+func WatchAndCreateWorkloadEntryBootstrap(kubeClient kubernetes.Interface, certManager *certificate.Manager, kubeController k8s.Controller, msgBroker *messaging.Broker, meshName, osmNamespace string, stop <-chan struct{}) {
+	wh := &mutatingWebhook{
+		kubeClient:     kubeClient,
+		certManager:    certManager,
+		kubeController: kubeController,
+		osmNamespace:   osmNamespace,
+		meshName:       meshName,
+	}
+
+	entryAddChan, unsub := msgBroker.SubscribeKubeEvents(events.WorkloadEntry.Added())
+	defer unsub()
+
+	renewalTicker := time.NewTicker(workloadEntryRenewalInterval)
+	defer renewalTicker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting WorkloadEntry bootstrap config routine")
+			return
+
+		case <-renewalTicker.C:
+			for _, entry := range kubeController.ListWorkloadEntries() {
+				if err := wh.createWorkloadEntryBootstrap(entry); err != nil {
+					log.Error().Err(err).Msgf("Failed to renew Envoy bootstrap config for WorkloadEntry %s/%s", entry.Namespace, entry.Name)
+				}
+			}
+
+		case entryAddedMsg := <-entryAddChan:
+			psubMessage, castOk := entryAddedMsg.(events.PubSubMessage)
+			if !castOk {
+				log.Error().Msgf("Error casting to events.PubSubMessage, got type %T", psubMessage)
+				continue
+			}
+
+			// guaranteed can only be a WorkloadEntryAdded event
+			entry, castOk := psubMessage.NewObj.(*policyv1alpha1.WorkloadEntry)
+			if !castOk {
+				log.Error().Msgf("Error casting to *WorkloadEntry: got type %T", entry)
+				continue
+			}
+
+			if err := wh.createWorkloadEntryBootstrap(entry); err != nil {
+				log.Error().Err(err).Msgf("Failed to create Envoy bootstrap config for WorkloadEntry %s/%s", entry.Namespace, entry.Name)
+			}
+		}
+	}
+}
+
+// createWorkloadEntryBootstrap creates the bootstrap config Secret for the Envoy sidecar of the given WorkloadEntry,
+// or renews the certificate of the existing Secret if needed. The sidecar connects to the control plane with the UID
+// of the WorkloadEntry as its proxy UUID.
+func (wh *mutatingWebhook) createWorkloadEntryBootstrap(entry *policyv1alpha1.WorkloadEntry) error {
+	proxyUUID, err := uuid.Parse(string(entry.UID))
+	if err != nil {
+		return fmt.Errorf("error parsing UID %s of WorkloadEntry %s/%s: %w", entry.UID, entry.Namespace, entry.Name, err)
+	}
+	secretName := bootstrapConfigName(proxyUUID)
+	cnPrefix := envoy.NewXDSCertCNPrefix(proxyUUID, envoy.KindSidecar, identity.New(entry.Spec.ServiceAccount, entry.Namespace))
+
+	// The Secret already exists when the WorkloadEntry is added again on a resync or a restart
+	existing, err := wh.kubeClient.CoreV1().Secrets(entry.Namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err == nil {
+		return wh.renewWorkloadEntryBootstrap(entry, existing, cnPrefix)
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	cert, err := wh.certManager.IssueCertificate(certificate.ForCommonNamePrefix(cnPrefix))
+	if err != nil {
+		return fmt.Errorf("error issuing bootstrap certificate for Envoy with CN prefix=%s: %w", cnPrefix, err)
+	}
+
+	// The proxies of the workloads outside the cluster have no service account token to authenticate with
+	bootstrapConfig, err := wh.buildEnvoyBootstrapConfig(proxyUUID, models.HealthProbes{}, false)
+	if err != nil {
+		return err
+	}
+
+	secret, err := wh.newBootstrapSecret(secretName, bootstrapConfig, cert)
+	if err != nil {
+		return err
+	}
+	secret.Data[workloadEntryIptablesScriptFile] = []byte(wh.getWorkloadEntryIptablesScript(entry))
+	secret.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: policyv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "WorkloadEntry",
+			Name:       entry.Name,
+			UID:        entry.UID,
+		},
+	}
+
+	log.Debug().Msgf("Creating bootstrap config for Envoy of WorkloadEntry %s/%s: name=%s", entry.Namespace, entry.Name, secretName)
+	_, err = wh.kubeClient.CoreV1().Secrets(entry.Namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// renewWorkloadEntryBootstrap renews the certificate of the given bootstrap config Secret of a WorkloadEntry when it
+// is about to expire, is revoked or is not signed by the signing issuer anymore
+func (wh *mutatingWebhook) renewWorkloadEntryBootstrap(entry *policyv1alpha1.WorkloadEntry, secret *corev1.Secret, cnPrefix string) error {
+	renew, err := wh.certManager.ShouldRenewCertificate(pem.Certificate(secret.Data[bootstrap.EnvoyXDSCertFile]))
+	if err != nil {
+		log.Warn().Err(err).Msgf("Error checking the bootstrap certificate of WorkloadEntry %s/%s, renewing it", entry.Namespace, entry.Name)
+		renew = true
+	}
+	if !renew {
+		return nil
+	}
+
+	cert, err := wh.certManager.IssueCertificate(certificate.ForCommonNamePrefix(cnPrefix))
+	if err != nil {
+		return fmt.Errorf("error issuing bootstrap certificate for Envoy with CN prefix=%s: %w", cnPrefix, err)
+	}
+	secret.Data[bootstrap.EnvoyXDSCACertFile] = cert.GetTrustedCAs()
+	secret.Data[bootstrap.EnvoyXDSCertFile] = cert.GetCertificateChain()
+	secret.Data[bootstrap.EnvoyXDSKeyFile] = cert.GetPrivateKey()
+
+	log.Info().Msgf("Renewing the bootstrap certificate of the Envoy of WorkloadEntry %s/%s, expiring on %s: name=%s", entry.Namespace, entry.Name, cert.GetExpiration(), secret.Name)
+	_, err = wh.kubeClient.CoreV1().Secrets(entry.Namespace).Update(context.Background(), secret, metav1.UpdateOptions{})
+	return err
+}
+
+// getWorkloadEntryIptablesScript returns the script that sets up the interception of the traffic of the given
+// WorkloadEntry by its sidecar on the workload's host, using the global exclusion lists of the MeshConfig
+func (wh *mutatingWebhook) getWorkloadEntryIptablesScript(entry *policyv1alpha1.WorkloadEntry) string {
+	meshConfig := wh.kubeController.GetMeshConfig()
+	iptablesCommands := generateIptablesCommands(
+		meshConfig.Spec.Sidecar.LocalProxyMode,
+		meshConfig.Spec.Traffic.OutboundIPRangeExclusionList,
+		meshConfig.Spec.Traffic.OutboundIPRangeInclusionList,
+		meshConfig.Spec.Traffic.OutboundPortExclusionList,
+		meshConfig.Spec.Traffic.InboundPortExclusionList,
+		meshConfig.Spec.Traffic.NetworkInterfaceExclusionList,
+	)
+
+	return fmt.Sprintf(`#!/bin/sh
+# Redirects the traffic of WorkloadEntry %s/%s to its Envoy sidecar.
+# Must be run as root, with Envoy running as the user with UID %d.
+set -e
+POD_IPS=%s
+%s`, entry.Namespace, entry.Name, constants.EnvoyUID, entry.Spec.Address, iptablesCommands)
+}
@@ -0,0 +1,98 @@
+package injector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	tassert "github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	policyv1alpha1 "github.com/openservicemesh/osm/pkg/apis/policy/v1alpha1"
+
+	tresorFake "github.com/openservicemesh/osm/pkg/certificate/providers/tresor/fake"
+	"github.com/openservicemesh/osm/pkg/envoy/bootstrap"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+func TestCreateWorkloadEntryBootstrap(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	kubeClient := fake.NewSimpleClientset()
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().GetMeshConfig().Return(configv1alpha2.MeshConfig{
+		Spec: configv1alpha2.MeshConfigSpec{
+			Sidecar: configv1alpha2.SidecarSpec{
+				LocalProxyMode: configv1alpha2.LocalProxyModePodIP,
+			},
+			Traffic: configv1alpha2.TrafficSpec{
+				OutboundPortExclusionList: []int{6379},
+			},
+		},
+	}).AnyTimes()
+
+	wh := &mutatingWebhook{
+		kubeClient:     kubeClient,
+		kubeController: mockKubeController,
+		certManager:    tresorFake.NewFake(1 * time.Hour),
+		osmNamespace:   "osm-system",
+		meshName:       "osm",
+	}
+
+	entryUUID := uuid.New()
+	entry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-1",
+			Namespace: "legacy",
+			UID:       types.UID(entryUUID.String()),
+		},
+		Spec: policyv1alpha1.WorkloadEntrySpec{
+			Address:        "10.10.0.5",
+			ServiceAccount: "billing",
+		},
+	}
+
+	assert.NoError(wh.createWorkloadEntryBootstrap(entry))
+
+	secret, err := kubeClient.CoreV1().Secrets("legacy").Get(context.Background(), bootstrapConfigName(entryUUID), metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Len(secret.OwnerReferences, 1)
+	assert.Equal("WorkloadEntry", secret.OwnerReferences[0].Kind)
+	assert.Equal(entry.UID, secret.OwnerReferences[0].UID)
+	assert.Contains(string(secret.Data[bootstrap.EnvoyBootstrapConfigFile]), entryUUID.String())
+	assert.Contains(string(secret.Data[bootstrap.EnvoyBootstrapConfigFile]), "osm-controller.osm-system.svc.cluster.local")
+	assert.NotEmpty(secret.Data[bootstrap.EnvoyXDSCertFile])
+	assert.NotEmpty(secret.Data[bootstrap.EnvoyXDSKeyFile])
+
+	script := string(secret.Data[workloadEntryIptablesScriptFile])
+	assert.Contains(script, "POD_IPS=10.10.0.5\n")
+	assert.Contains(script, "--to-destination $POD_IPV4")
+	assert.Contains(script, "--dports 6379 -j RETURN")
+
+	// The Secret is not recreated when the WorkloadEntry is added again
+	secret.Data[bootstrap.EnvoyXDSKeyFile] = []byte("unchanged")
+	_, err = kubeClient.CoreV1().Secrets("legacy").Update(context.Background(), secret, metav1.UpdateOptions{})
+	assert.NoError(err)
+	assert.NoError(wh.createWorkloadEntryBootstrap(entry))
+	secret, err = kubeClient.CoreV1().Secrets("legacy").Get(context.Background(), bootstrapConfigName(entryUUID), metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal("unchanged", string(secret.Data[bootstrap.EnvoyXDSKeyFile]))
+
+	// The certificate is renewed when it is about to expire
+	wh.certManager = tresorFake.NewFakeWithValidityDuration(func() time.Duration { return time.Second }, time.Hour)
+	assert.NoError(wh.createWorkloadEntryBootstrap(entry))
+	secret, err = kubeClient.CoreV1().Secrets("legacy").Get(context.Background(), bootstrapConfigName(entryUUID), metav1.GetOptions{})
+	assert.NoError(err)
+	assert.NotEqual("unchanged", string(secret.Data[bootstrap.EnvoyXDSKeyFile]))
+	assert.Contains(string(secret.Data[workloadEntryIptablesScriptFile]), "POD_IPS=10.10.0.5\n")
+
+	// WorkloadEntry resources are expected to have a UUID as their UID
+	entry.UID = "not-a-uuid"
+	assert.Error(wh.createWorkloadEntryBootstrap(entry))
+}
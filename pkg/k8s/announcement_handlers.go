@@ -2,7 +2,6 @@ package k8s
 
 import (
 	"context"
-	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -48,7 +47,7 @@ func WatchAndUpdateProxyBootstrapSecret(kubeClient kubernetes.Interface, msgBrok
 			podUUID := addedPodObj.GetLabels()[constants.EnvoyUniqueIDLabelName]
 			podName := addedPodObj.GetName()
 			namespace := addedPodObj.GetNamespace()
-			secretName := fmt.Sprintf("envoy-bootstrap-config-%s", podUUID)
+			secretName := constants.EnvoyBootstrapConfigSecretPrefix + podUUID
 
 			secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
 			if err != nil {
@@ -40,17 +40,23 @@ func NewClient(osmNamespace, meshConfigName string, informerCollection *osminfor
 		Endpoints:              c.initEndpointMonitor,
 		MeshConfig:             c.initMeshConfigMonitor,
 		MeshRootCertificate:    c.initMRCMonitor,
+		RemoteCluster:          c.initRemoteClusterMonitor,
+		TrustDomainFederation:  c.initTrustDomainFederationMonitor,
+		CertificateRevocation:  c.initCertificateRevocationMonitor,
 		Egress:                 c.initEgressMonitor,
 		IngressBackend:         c.initIngressBackendMonitor,
 		Retry:                  c.initRetryMonitor,
 		UpstreamTrafficSetting: c.initUpstreamTrafficSettingMonitor,
+		WorkloadEntry:          c.initWorkloadEntryMonitor,
+		HTTPFilterExtension:    c.initHTTPFilterExtensionMonitor,
+		HTTPFilterModule:       c.initHTTPFilterModuleMonitor,
 	}
 
 	// If specific informers are not selected to be initialized, initialize all informers
 	if len(selectInformers) == 0 {
 		selectInformers = []InformerKey{
-			Namespaces, Services, ServiceAccounts, Pods, Endpoints, MeshConfig, MeshRootCertificate,
-			Egress, IngressBackend, Retry, UpstreamTrafficSetting}
+			Namespaces, Services, ServiceAccounts, Pods, Endpoints, MeshConfig, MeshRootCertificate, RemoteCluster, TrustDomainFederation,
+			CertificateRevocation, Egress, IngressBackend, Retry, UpstreamTrafficSetting, WorkloadEntry, HTTPFilterExtension, HTTPFilterModule}
 	}
 
 	for _, informer := range selectInformers {
@@ -75,6 +81,18 @@ func (c *Client) initMRCMonitor() {
 	c.informers.AddEventHandler(osminformers.InformerKeyMeshRootCertificate, GetEventHandlerFuncs(nil, c.msgBroker))
 }
 
+func (c *Client) initRemoteClusterMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyRemoteCluster, GetEventHandlerFuncs(nil, c.msgBroker))
+}
+
+func (c *Client) initTrustDomainFederationMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyTrustDomainFederation, GetEventHandlerFuncs(nil, c.msgBroker))
+}
+
+func (c *Client) initCertificateRevocationMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyCertificateRevocation, GetEventHandlerFuncs(nil, c.msgBroker))
+}
+
 func (c *Client) initEgressMonitor() {
 	c.informers.AddEventHandler(osminformers.InformerKeyEgress, GetEventHandlerFuncs(c.shouldObserve, c.msgBroker))
 }
@@ -91,6 +109,18 @@ func (c *Client) initUpstreamTrafficSettingMonitor() {
 	c.informers.AddEventHandler(osminformers.InformerKeyUpstreamTrafficSetting, GetEventHandlerFuncs(c.shouldObserve, c.msgBroker))
 }
 
+func (c *Client) initWorkloadEntryMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyWorkloadEntry, GetEventHandlerFuncs(c.shouldObserve, c.msgBroker))
+}
+
+func (c *Client) initHTTPFilterExtensionMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyHTTPFilterExtension, GetEventHandlerFuncs(c.shouldObserve, c.msgBroker))
+}
+
+func (c *Client) initHTTPFilterModuleMonitor() {
+	c.informers.AddEventHandler(osminformers.InformerKeyHTTPFilterModule, GetEventHandlerFuncs(c.shouldObserve, c.msgBroker))
+}
+
 // Function to filter K8s meta Objects by OSM's isMonitoredNamespace
 func (c *Client) shouldObserve(obj interface{}) bool {
 	object, ok := obj.(metav1.Object)
@@ -209,6 +239,18 @@ func (c *Client) ListPods() []*corev1.Pod {
 	return pods
 }
 
+// GetPod returns the pod with the given name and namespace if it exists in cache and is part of the mesh, otherwise nil
+func (c *Client) GetPod(name, namespace string) *corev1.Pod {
+	if !c.IsMonitoredNamespace(namespace) {
+		return nil
+	}
+	podIf, exists, err := c.informers.GetByKey(osminformers.InformerKeyPod, key(name, namespace))
+	if exists && err == nil {
+		return podIf.(*corev1.Pod)
+	}
+	return nil
+}
+
 // GetEndpoints returns the endpoint for a given service, otherwise returns nil if not found
 // or error if the API errored out.
 func (c *Client) GetEndpoints(name, namespace string) (*corev1.Endpoints, error) {
@@ -309,6 +351,112 @@ func (c *Client) GetPodForProxy(proxy *envoy.Proxy) (*v1.Pod, error) {
 	return &pod, nil
 }
 
+// ListWorkloadEntries returns the WorkloadEntry resources in the monitored namespaces
+func (c *Client) ListWorkloadEntries() []*policyv1alpha1.WorkloadEntry {
+	var entries []*policyv1alpha1.WorkloadEntry
+
+	for _, entryIface := range c.informers.List(osminformers.InformerKeyWorkloadEntry) {
+		entry := entryIface.(*policyv1alpha1.WorkloadEntry)
+		if !c.IsMonitoredNamespace(entry.Namespace) {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// ListHTTPFilterExtensions returns the HTTPFilterExtension resources in the monitored namespaces
+func (c *Client) ListHTTPFilterExtensions() []*policyv1alpha1.HTTPFilterExtension {
+	var extensions []*policyv1alpha1.HTTPFilterExtension
+
+	for _, extensionIface := range c.informers.List(osminformers.InformerKeyHTTPFilterExtension) {
+		extension := extensionIface.(*policyv1alpha1.HTTPFilterExtension)
+		if !c.IsMonitoredNamespace(extension.Namespace) {
+			continue
+		}
+
+		extensions = append(extensions, extension)
+	}
+
+	return extensions
+}
+
+// GetHTTPFilterModule returns the ConfigMap, labeled as holding HTTP filter modules, with the given name and
+// namespace, or nil if it does not exist
+func (c *Client) GetHTTPFilterModule(name, namespace string) *corev1.ConfigMap {
+	configMap, exists, err := c.informers.GetByKey(osminformers.InformerKeyHTTPFilterModule, key(name, namespace))
+	if exists && err == nil {
+		return configMap.(*corev1.ConfigMap)
+	}
+	return nil
+}
+
+// ListRemoteClusters returns the RemoteCluster resources registering the peer clusters of the mesh
+func (c *Client) ListRemoteClusters() []*configv1alpha2.RemoteCluster {
+	var remoteClusters []*configv1alpha2.RemoteCluster
+
+	for _, remoteClusterIface := range c.informers.List(osminformers.InformerKeyRemoteCluster) {
+		remoteClusters = append(remoteClusters, remoteClusterIface.(*configv1alpha2.RemoteCluster))
+	}
+
+	return remoteClusters
+}
+
+// ListTrustDomainFederations returns the TrustDomainFederation resources importing the root certificates of foreign
+// trust domains
+func (c *Client) ListTrustDomainFederations() []*configv1alpha2.TrustDomainFederation {
+	var federations []*configv1alpha2.TrustDomainFederation
+
+	for _, federationIface := range c.informers.List(osminformers.InformerKeyTrustDomainFederation) {
+		federations = append(federations, federationIface.(*configv1alpha2.TrustDomainFederation))
+	}
+
+	return federations
+}
+
+// ListCertificateRevocations returns the CertificateRevocation resources revoking the certificates issued to the
+// workloads of the mesh
+func (c *Client) ListCertificateRevocations() []*configv1alpha2.CertificateRevocation {
+	var revocations []*configv1alpha2.CertificateRevocation
+
+	for _, revocationIface := range c.informers.List(osminformers.InformerKeyCertificateRevocation) {
+		revocations = append(revocations, revocationIface.(*configv1alpha2.CertificateRevocation))
+	}
+
+	return revocations
+}
+
+// GetWorkloadEntryForProxy returns the WorkloadEntry the given proxy runs on, or nil if the proxy does not run
+// on a WorkloadEntry. The UUID of a proxy running on a WorkloadEntry is the UID of the WorkloadEntry.
+func (c *Client) GetWorkloadEntryForProxy(proxy *envoy.Proxy) (*policyv1alpha1.WorkloadEntry, error) {
+	proxyUUID, svcAccount := proxy.UUID.String(), proxy.Identity.ToK8sServiceAccount()
+
+	for _, entry := range c.ListWorkloadEntries() {
+		if string(entry.UID) != proxyUUID {
+			continue
+		}
+
+		if entry.Namespace != svcAccount.Namespace {
+			log.Warn().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingPodFromCert)).
+				Msgf("WorkloadEntry %s/%s belongs to Namespace %s. The proxy's xDS certificate was issued for Namespace %s",
+					entry.Namespace, entry.Name, entry.Namespace, svcAccount.Namespace)
+			return nil, errNamespaceDoesNotMatchProxy
+		}
+		if entry.Spec.ServiceAccount != svcAccount.Name {
+			log.Warn().Str(errcode.Kind, errcode.GetErrCodeWithMetric(errcode.ErrFetchingPodFromCert)).
+				Msgf("WorkloadEntry %s/%s belongs to ServiceAccount=%s. The proxy's xDS certificate was issued for ServiceAccount=%s",
+					entry.Namespace, entry.Name, entry.Spec.ServiceAccount, svcAccount)
+			return nil, errServiceAccountDoesNotMatchProxy
+		}
+
+		return entry, nil
+	}
+
+	return nil, nil
+}
+
 // GetOSMNamespace returns the namespace in which the OSM controller pod resides.
 func (c *Client) GetOSMNamespace() string {
 	return c.osmNamespace
@@ -13,6 +13,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -411,6 +412,23 @@ func TestListPods(t *testing.T) {
 	}
 }
 
+func TestGetPod(t *testing.T) {
+	a := tassert.New(t)
+	ic, err := informers.NewInformerCollection(testMeshName, nil, informers.WithKubeClient(testclient.NewSimpleClientset()))
+	a.Nil(err)
+	c := NewClient("osm", tests.OsmMeshConfigName, ic, nil, nil)
+	_ = ic.Add(informers.InformerKeyNamespace, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}}, t)
+
+	monitored := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "p1"}}
+	unmonitored := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "p2"}}
+	_ = ic.Add(informers.InformerKeyPod, monitored, t)
+	_ = ic.Add(informers.InformerKeyPod, unmonitored, t)
+
+	a.Equal(monitored, c.GetPod("p1", "ns1"))
+	a.Nil(c.GetPod("p2", "ns2"))
+	a.Nil(c.GetPod("p3", "ns1"))
+}
+
 func TestGetEndpoints(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -1034,3 +1052,76 @@ func TestListUpstreamTrafficSetting(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWorkloadEntryForProxy(t *testing.T) {
+	entryUUID := uuid.New()
+	entry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-1",
+			Namespace: testNs,
+			UID:       types.UID(entryUUID.String()),
+		},
+		Spec: policyv1alpha1.WorkloadEntrySpec{
+			Address:        "10.10.0.5",
+			ServiceAccount: "sa1",
+		},
+	}
+	outMeshEntry := &policyv1alpha1.WorkloadEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vm-2",
+			Namespace: "wrong-ns",
+			UID:       types.UID(uuid.NewString()),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		proxy         *envoy.Proxy
+		expectedEntry *policyv1alpha1.WorkloadEntry
+		expectedErr   error
+	}{
+		{
+			name:          "proxy running on a WorkloadEntry",
+			proxy:         envoy.NewProxy(envoy.KindSidecar, entryUUID, identity.New("sa1", testNs), nil, 1),
+			expectedEntry: entry,
+		},
+		{
+			name:  "proxy not running on a WorkloadEntry",
+			proxy: envoy.NewProxy(envoy.KindSidecar, uuid.New(), identity.New("sa1", testNs), nil, 1),
+		},
+		{
+			name:        "proxy with a different service account",
+			proxy:       envoy.NewProxy(envoy.KindSidecar, entryUUID, identity.New("sa2", testNs), nil, 1),
+			expectedErr: errServiceAccountDoesNotMatchProxy,
+		},
+		{
+			name:        "proxy with a different namespace",
+			proxy:       envoy.NewProxy(envoy.KindSidecar, entryUUID, identity.New("sa1", "other-ns"), nil, 1),
+			expectedErr: errNamespaceDoesNotMatchProxy,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			fakeClient := fakePolicyClient.NewSimpleClientset()
+			informerCollection, err := informers.NewInformerCollection("osm", nil,
+				informers.WithPolicyClient(fakeClient),
+				informers.WithKubeClient(testclient.NewSimpleClientset()),
+			)
+			a.Nil(err)
+			c := NewClient("osm", tests.OsmMeshConfigName, informerCollection, fakeClient, nil)
+
+			a.Nil(c.informers.Add(informers.InformerKeyNamespace, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNs}}, t))
+			a.Nil(c.informers.Add(informers.InformerKeyWorkloadEntry, entry, t))
+			a.Nil(c.informers.Add(informers.InformerKeyWorkloadEntry, outMeshEntry, t))
+
+			a.Equal([]*policyv1alpha1.WorkloadEntry{entry}, c.ListWorkloadEntries())
+
+			actual, err := c.GetWorkloadEntryForProxy(tc.proxy)
+			a.Equal(tc.expectedEntry, actual)
+			a.Equal(tc.expectedErr, err)
+		})
+	}
+}
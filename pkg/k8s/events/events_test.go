@@ -109,6 +109,18 @@ func TestEventKinds(t *testing.T) {
 			obj:          &configv1alpha2.MeshRootCertificate{},
 			expectedKind: MeshRootCertificate,
 		},
+		{
+			obj:          &configv1alpha2.RemoteCluster{},
+			expectedKind: RemoteCluster,
+		},
+		{
+			obj:          &configv1alpha2.TrustDomainFederation{},
+			expectedKind: TrustDomainFederation,
+		},
+		{
+			obj:          &configv1alpha2.CertificateRevocation{},
+			expectedKind: CertificateRevocation,
+		},
 		{
 			obj:          &policyv1alpha1.Egress{},
 			expectedKind: Egress,
@@ -125,6 +137,18 @@ func TestEventKinds(t *testing.T) {
 			obj:          &policyv1alpha1.Retry{},
 			expectedKind: RetryPolicy,
 		},
+		{
+			obj:          &policyv1alpha1.WorkloadEntry{},
+			expectedKind: WorkloadEntry,
+		},
+		{
+			obj:          &policyv1alpha1.HTTPFilterExtension{},
+			expectedKind: HTTPFilterExtension,
+		},
+		{
+			obj:          &corev1.ConfigMap{},
+			expectedKind: ConfigMap,
+		},
 		{
 			obj:          &corev1.Pod{},
 			expectedKind: Pod,
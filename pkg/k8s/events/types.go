@@ -134,6 +134,15 @@ const (
 	// MeshRootCertificate is the Kind for Kubernetes mrc events.
 	MeshRootCertificate Kind = "meshrootcertificate"
 
+	// RemoteCluster is the Kind for Kubernetes remote cluster events.
+	RemoteCluster Kind = "remotecluster"
+
+	// TrustDomainFederation is the Kind for Kubernetes trust domain federation events.
+	TrustDomainFederation Kind = "trustdomainfederation"
+
+	// CertificateRevocation is the Kind for Kubernetes certificate revocation events.
+	CertificateRevocation Kind = "certificaterevocation"
+
 	// Egress is the Kind for Kubernetes egress events.
 	Egress Kind = "egress"
 
@@ -145,6 +154,15 @@ const (
 
 	// UpstreamTrafficSetting is the Kind for Kubernetes updstream traffic settings events.
 	UpstreamTrafficSetting Kind = "upstreamtrafficsetting"
+
+	// WorkloadEntry is the Kind for Kubernetes workload entry events.
+	WorkloadEntry Kind = "workloadentry"
+
+	// HTTPFilterExtension is the Kind for Kubernetes HTTP filter extension events.
+	HTTPFilterExtension Kind = "httpfilterextension"
+
+	// ConfigMap is the Kind for Kubernetes config map events.
+	ConfigMap Kind = "configmap"
 )
 
 // GetKind returns the Kind for the given k8s object.
@@ -160,6 +178,8 @@ func GetKind(obj interface{}) Kind {
 		return Service
 	case *corev1.ServiceAccount:
 		return ServiceAccount
+	case *corev1.ConfigMap:
+		return ConfigMap
 	case *networkingv1.Ingress:
 		return Ingress
 	case *smiSplit.TrafficSplit:
@@ -174,6 +194,12 @@ func GetKind(obj interface{}) Kind {
 		return MeshConfig
 	case *configv1alpha2.MeshRootCertificate:
 		return MeshRootCertificate
+	case *configv1alpha2.RemoteCluster:
+		return RemoteCluster
+	case *configv1alpha2.TrustDomainFederation:
+		return TrustDomainFederation
+	case *configv1alpha2.CertificateRevocation:
+		return CertificateRevocation
 	case *policyv1alpha1.Egress:
 		return Egress
 	case *policyv1alpha1.IngressBackend:
@@ -182,6 +208,10 @@ func GetKind(obj interface{}) Kind {
 		return RetryPolicy
 	case *policyv1alpha1.UpstreamTrafficSetting:
 		return UpstreamTrafficSetting
+	case *policyv1alpha1.WorkloadEntry:
+		return WorkloadEntry
+	case *policyv1alpha1.HTTPFilterExtension:
+		return HTTPFilterExtension
 	default:
 		log.Error().Msgf("Unknown kind: %v", obj)
 		return ""
@@ -72,6 +72,13 @@ func WithKubeClient(kubeClient kubernetes.Interface) InformerCollectionOption {
 		ic.informers[InformerKeyServiceAccount] = v1api.ServiceAccounts().Informer()
 		ic.informers[InformerKeyPod] = v1api.Pods().Informer()
 		ic.informers[InformerKeyEndpoints] = v1api.Endpoints().Informer()
+
+		// Only the ConfigMaps holding the WASM modules of HTTPFilterExtension resources are cached
+		moduleInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, DefaultKubeEventResyncInterval,
+			informers.WithTweakListOptions(func(opt *metav1.ListOptions) {
+				opt.LabelSelector = fields.OneTermEqualSelector(constants.HTTPFilterModuleLabel, "true").String()
+			}))
+		ic.informers[InformerKeyHTTPFilterModule] = moduleInformerFactory.Core().V1().ConfigMaps().Informer()
 	}
 }
 
@@ -100,6 +107,9 @@ func WithConfigClient(configClient configClientset.Interface, meshConfigName, os
 
 		ic.informers[InformerKeyMeshConfig] = meshConfiginformerFactory.Config().V1alpha2().MeshConfigs().Informer()
 		ic.informers[InformerKeyMeshRootCertificate] = mrcInformerFactory.Config().V1alpha2().MeshRootCertificates().Informer()
+		ic.informers[InformerKeyRemoteCluster] = mrcInformerFactory.Config().V1alpha2().RemoteClusters().Informer()
+		ic.informers[InformerKeyTrustDomainFederation] = mrcInformerFactory.Config().V1alpha2().TrustDomainFederations().Informer()
+		ic.informers[InformerKeyCertificateRevocation] = mrcInformerFactory.Config().V1alpha2().CertificateRevocations().Informer()
 	}
 }
 
@@ -112,6 +122,8 @@ func WithPolicyClient(policyClient policyClientset.Interface) InformerCollection
 		ic.informers[InformerKeyIngressBackend] = informerFactory.Policy().V1alpha1().IngressBackends().Informer()
 		ic.informers[InformerKeyUpstreamTrafficSetting] = informerFactory.Policy().V1alpha1().UpstreamTrafficSettings().Informer()
 		ic.informers[InformerKeyRetry] = informerFactory.Policy().V1alpha1().Retries().Informer()
+		ic.informers[InformerKeyWorkloadEntry] = informerFactory.Policy().V1alpha1().WorkloadEntries().Informer()
+		ic.informers[InformerKeyHTTPFilterExtension] = informerFactory.Policy().V1alpha1().HTTPFilterExtensions().Informer()
 	}
 }
 
@@ -35,6 +35,12 @@ const (
 	InformerKeyMeshConfig InformerKey = "MeshConfig"
 	// InformerKeyMeshRootCertificate is the InformerKey for a MeshRootCertificate informer
 	InformerKeyMeshRootCertificate InformerKey = "MeshRootCertificate"
+	// InformerKeyRemoteCluster is the InformerKey for a RemoteCluster informer
+	InformerKeyRemoteCluster InformerKey = "RemoteCluster"
+	// InformerKeyTrustDomainFederation is the InformerKey for a TrustDomainFederation informer
+	InformerKeyTrustDomainFederation InformerKey = "TrustDomainFederation"
+	// InformerKeyCertificateRevocation is the InformerKey for a CertificateRevocation informer
+	InformerKeyCertificateRevocation InformerKey = "CertificateRevocation"
 
 	// InformerKeyEgress is the InformerKey for a Egress informer
 	InformerKeyEgress InformerKey = "Egress"
@@ -44,6 +50,12 @@ const (
 	InformerKeyUpstreamTrafficSetting InformerKey = "UpstreamTrafficSetting"
 	// InformerKeyRetry is the InformerKey for a Retry informer
 	InformerKeyRetry InformerKey = "Retry"
+	// InformerKeyWorkloadEntry is the InformerKey for a WorkloadEntry informer
+	InformerKeyWorkloadEntry InformerKey = "WorkloadEntry"
+	// InformerKeyHTTPFilterExtension is the InformerKey for a HTTPFilterExtension informer
+	InformerKeyHTTPFilterExtension InformerKey = "HTTPFilterExtension"
+	// InformerKeyHTTPFilterModule is the InformerKey for the informer of the ConfigMaps holding HTTP filter modules
+	InformerKeyHTTPFilterModule InformerKey = "HTTPFilterModule"
 )
 
 const (
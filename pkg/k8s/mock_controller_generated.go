@@ -53,6 +53,20 @@ func (mr *MockControllerMockRecorder) GetEndpoints(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockController)(nil).GetEndpoints), arg0, arg1)
 }
 
+// GetHTTPFilterModule mocks base method.
+func (m *MockController) GetHTTPFilterModule(arg0, arg1 string) *v1.ConfigMap {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHTTPFilterModule", arg0, arg1)
+	ret0, _ := ret[0].(*v1.ConfigMap)
+	return ret0
+}
+
+// GetHTTPFilterModule indicates an expected call of GetHTTPFilterModule.
+func (mr *MockControllerMockRecorder) GetHTTPFilterModule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHTTPFilterModule", reflect.TypeOf((*MockController)(nil).GetHTTPFilterModule), arg0, arg1)
+}
+
 // GetMeshConfig mocks base method.
 func (m *MockController) GetMeshConfig() v1alpha2.MeshConfig {
 	m.ctrl.T.Helper()
@@ -95,6 +109,20 @@ func (mr *MockControllerMockRecorder) GetOSMNamespace() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMNamespace", reflect.TypeOf((*MockController)(nil).GetOSMNamespace))
 }
 
+// GetPod mocks base method.
+func (m *MockController) GetPod(arg0, arg1 string) *v1.Pod {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPod", arg0, arg1)
+	ret0, _ := ret[0].(*v1.Pod)
+	return ret0
+}
+
+// GetPod indicates an expected call of GetPod.
+func (mr *MockControllerMockRecorder) GetPod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPod", reflect.TypeOf((*MockController)(nil).GetPod), arg0, arg1)
+}
+
 // GetPodForProxy mocks base method.
 func (m *MockController) GetPodForProxy(arg0 *envoy.Proxy) (*v1.Pod, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +166,21 @@ func (mr *MockControllerMockRecorder) GetUpstreamTrafficSetting(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpstreamTrafficSetting", reflect.TypeOf((*MockController)(nil).GetUpstreamTrafficSetting), arg0)
 }
 
+// GetWorkloadEntryForProxy mocks base method.
+func (m *MockController) GetWorkloadEntryForProxy(arg0 *envoy.Proxy) (*v1alpha1.WorkloadEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkloadEntryForProxy", arg0)
+	ret0, _ := ret[0].(*v1alpha1.WorkloadEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkloadEntryForProxy indicates an expected call of GetWorkloadEntryForProxy.
+func (mr *MockControllerMockRecorder) GetWorkloadEntryForProxy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkloadEntryForProxy", reflect.TypeOf((*MockController)(nil).GetWorkloadEntryForProxy), arg0)
+}
+
 // IsMonitoredNamespace mocks base method.
 func (m *MockController) IsMonitoredNamespace(arg0 string) bool {
 	m.ctrl.T.Helper()
@@ -166,6 +209,20 @@ func (mr *MockControllerMockRecorder) ListEgressPolicies() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEgressPolicies", reflect.TypeOf((*MockController)(nil).ListEgressPolicies))
 }
 
+// ListHTTPFilterExtensions mocks base method.
+func (m *MockController) ListHTTPFilterExtensions() []*v1alpha1.HTTPFilterExtension {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHTTPFilterExtensions")
+	ret0, _ := ret[0].([]*v1alpha1.HTTPFilterExtension)
+	return ret0
+}
+
+// ListHTTPFilterExtensions indicates an expected call of ListHTTPFilterExtensions.
+func (mr *MockControllerMockRecorder) ListHTTPFilterExtensions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHTTPFilterExtensions", reflect.TypeOf((*MockController)(nil).ListHTTPFilterExtensions))
+}
+
 // ListIngressBackendPolicies mocks base method.
 func (m *MockController) ListIngressBackendPolicies() []*v1alpha1.IngressBackend {
 	m.ctrl.T.Helper()
@@ -209,6 +266,48 @@ func (mr *MockControllerMockRecorder) ListPods() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPods", reflect.TypeOf((*MockController)(nil).ListPods))
 }
 
+// ListRemoteClusters mocks base method.
+func (m *MockController) ListRemoteClusters() []*v1alpha2.RemoteCluster {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRemoteClusters")
+	ret0, _ := ret[0].([]*v1alpha2.RemoteCluster)
+	return ret0
+}
+
+// ListRemoteClusters indicates an expected call of ListRemoteClusters.
+func (mr *MockControllerMockRecorder) ListRemoteClusters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRemoteClusters", reflect.TypeOf((*MockController)(nil).ListRemoteClusters))
+}
+
+// ListCertificateRevocations mocks base method.
+func (m *MockController) ListCertificateRevocations() []*v1alpha2.CertificateRevocation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCertificateRevocations")
+	ret0, _ := ret[0].([]*v1alpha2.CertificateRevocation)
+	return ret0
+}
+
+// ListCertificateRevocations indicates an expected call of ListCertificateRevocations.
+func (mr *MockControllerMockRecorder) ListCertificateRevocations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificateRevocations", reflect.TypeOf((*MockController)(nil).ListCertificateRevocations))
+}
+
+// ListTrustDomainFederations mocks base method.
+func (m *MockController) ListTrustDomainFederations() []*v1alpha2.TrustDomainFederation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrustDomainFederations")
+	ret0, _ := ret[0].([]*v1alpha2.TrustDomainFederation)
+	return ret0
+}
+
+// ListTrustDomainFederations indicates an expected call of ListTrustDomainFederations.
+func (mr *MockControllerMockRecorder) ListTrustDomainFederations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrustDomainFederations", reflect.TypeOf((*MockController)(nil).ListTrustDomainFederations))
+}
+
 // ListRetryPolicies mocks base method.
 func (m *MockController) ListRetryPolicies() []*v1alpha1.Retry {
 	m.ctrl.T.Helper()
@@ -265,6 +364,20 @@ func (mr *MockControllerMockRecorder) ListUpstreamTrafficSettings() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpstreamTrafficSettings", reflect.TypeOf((*MockController)(nil).ListUpstreamTrafficSettings))
 }
 
+// ListWorkloadEntries mocks base method.
+func (m *MockController) ListWorkloadEntries() []*v1alpha1.WorkloadEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkloadEntries")
+	ret0, _ := ret[0].([]*v1alpha1.WorkloadEntry)
+	return ret0
+}
+
+// ListWorkloadEntries indicates an expected call of ListWorkloadEntries.
+func (mr *MockControllerMockRecorder) ListWorkloadEntries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkloadEntries", reflect.TypeOf((*MockController)(nil).ListWorkloadEntries))
+}
+
 // UpdateIngressBackendStatus mocks base method.
 func (m *MockController) UpdateIngressBackendStatus(arg0 *v1alpha1.IngressBackend) (*v1alpha1.IngressBackend, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +65,12 @@ const (
 	MeshConfig InformerKey = "MeshConfig"
 	// MeshRootCertificate lookup identifier
 	MeshRootCertificate InformerKey = "MeshRootCertificate"
+	// RemoteCluster lookup identifier
+	RemoteCluster InformerKey = "RemoteCluster"
+	// TrustDomainFederation lookup identifier
+	TrustDomainFederation InformerKey = "TrustDomainFederation"
+	// CertificateRevocation lookup identifier
+	CertificateRevocation InformerKey = "CertificateRevocation"
 	// Egress lookup identifier
 	Egress InformerKey = "Egress"
 	// IngressBackend lookup identifier
@@ -73,6 +79,12 @@ const (
 	Retry InformerKey = "Retry"
 	// UpstreamTrafficSetting lookup identifier
 	UpstreamTrafficSetting InformerKey = "UpstreamTrafficSetting"
+	// WorkloadEntry lookup identifier
+	WorkloadEntry InformerKey = "WorkloadEntry"
+	// HTTPFilterExtension lookup identifier
+	HTTPFilterExtension InformerKey = "HTTPFilterExtension"
+	// HTTPFilterModule lookup identifier
+	HTTPFilterModule InformerKey = "HTTPFilterModule"
 )
 
 // Client is the type used to represent the k8s client for the native k8s resources
@@ -109,10 +121,35 @@ type Controller interface {
 	// ListPods returns a list of pods part of the mesh
 	ListPods() []*corev1.Pod
 
+	// GetPod returns the pod with the given name and namespace if it exists in cache and is part of the mesh, otherwise nil
+	GetPod(name, namespace string) *corev1.Pod
+
 	// GetEndpoints returns the endpoints for a given service, if found
 	GetEndpoints(name, namespace string) (*corev1.Endpoints, error)
 
 	GetPodForProxy(proxy *envoy.Proxy) (*corev1.Pod, error)
+
+	// ListWorkloadEntries returns the WorkloadEntry resources in the monitored namespaces
+	ListWorkloadEntries() []*policyv1alpha1.WorkloadEntry
+
+	// GetWorkloadEntryForProxy returns the WorkloadEntry the given proxy runs on, or nil if the proxy does not
+	// run on a WorkloadEntry
+	GetWorkloadEntryForProxy(proxy *envoy.Proxy) (*policyv1alpha1.WorkloadEntry, error)
+
+	// ListRemoteClusters returns the RemoteCluster resources registering the peer clusters of the mesh
+	ListRemoteClusters() []*configv1alpha2.RemoteCluster
+
+	// ListTrustDomainFederations returns the TrustDomainFederation resources importing the root certificates of
+	// foreign trust domains
+	ListTrustDomainFederations() []*configv1alpha2.TrustDomainFederation
+
+	// ListCertificateRevocations returns the CertificateRevocation resources revoking the certificates issued to
+	// the workloads of the mesh
+	ListCertificateRevocations() []*configv1alpha2.CertificateRevocation
+
+	// GetHTTPFilterModule returns the ConfigMap, labeled as holding HTTP filter modules, with the given name and
+	// namespace, or nil if it does not exist
+	GetHTTPFilterModule(name, namespace string) *corev1.ConfigMap
 }
 
 // PassthroughInterface is the interface for methods that are implemented by the k8s.Client, but are not considered
@@ -143,4 +180,7 @@ type PassthroughInterface interface {
 
 	// GetUpstreamTrafficSetting returns the UpstreamTrafficSetting resources with namespaced name
 	GetUpstreamTrafficSetting(*types.NamespacedName) *policyv1alpha1.UpstreamTrafficSetting
+
+	// ListHTTPFilterExtensions returns all HTTPFilterExtension resources
+	ListHTTPFilterExtensions() []*policyv1alpha1.HTTPFilterExtension
 }
@@ -2,6 +2,7 @@ package messaging
 
 import (
 	"fmt"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -136,6 +137,9 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 	dispatchPending := false
 	batchCount := 0 // number of proxy update events batched per dispatch
 
+	// pendingTopics is the set of event topics coalesced into the pending dispatch
+	pendingTopics := make(map[string]struct{})
+
 	var msgName string
 	for {
 		select {
@@ -145,6 +149,7 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				return
 			}
 			msgName = e
+			pendingTopics[e] = struct{}{}
 
 			if !dispatchPending {
 				// No proxy update events are pending send on the pub-sub.
@@ -179,12 +184,13 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				<-maxTimer.C
 			}
 			maxTimer.Reset(noTimeout)
-			b.proxyUpdatePubSub.Pub(msgName, ProxyUpdateTopic)
+			b.proxyUpdatePubSub.Pub(newProxyUpdateEvent(pendingTopics), ProxyUpdateTopic)
 			atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
 			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
 			log.Trace().Msgf("Sliding window expired, msg kind %s, batch size %d", msgName, batchCount)
 			dispatchPending = false
 			batchCount = 0
+			pendingTopics = make(map[string]struct{})
 
 		case <-maxTimer.C:
 			maxTimer.Reset(noTimeout) // 'maxTimer' drained in this case statement
@@ -194,12 +200,13 @@ func (b *Broker) runProxyUpdateDispatcher(stopCh <-chan struct{}) {
 				<-slidingTimer.C
 			}
 			slidingTimer.Reset(noTimeout)
-			b.proxyUpdatePubSub.Pub(msgName, ProxyUpdateTopic)
+			b.proxyUpdatePubSub.Pub(newProxyUpdateEvent(pendingTopics), ProxyUpdateTopic)
 			atomic.AddUint64(&b.totalDispatchedProxyEventCount, 1)
 			metricsstore.DefaultMetricsStore.ProxyBroadcastEventCount.Inc()
 			log.Trace().Msgf("Max window expired, msg kind %s, batch size %d", msgName, batchCount)
 			dispatchPending = false
 			batchCount = 0
+			pendingTopics = make(map[string]struct{})
 
 		case <-stopCh:
 			log.Info().Msg("Proxy update dispatcher received stop signal, exiting")
@@ -272,7 +279,8 @@ func shouldPublish(msg events.PubSubMessage) (bool, string) {
 	switch msg.Kind {
 	case
 		events.Endpoint, events.Ingress,
-		events.Egress, events.IngressBackend, events.RetryPolicy, events.UpstreamTrafficSetting,
+		events.Egress, events.IngressBackend, events.RetryPolicy, events.UpstreamTrafficSetting, events.WorkloadEntry,
+		events.HTTPFilterExtension, events.ConfigMap,
 		events.RouteGroup, events.TCPRoute, events.TrafficSplit, events.TrafficTarget,
 		events.ProxyUpdate:
 		return true, ""
@@ -327,6 +335,16 @@ func shouldPublish(msg events.PubSubMessage) (bool, string) {
 	}
 }
 
+// newProxyUpdateEvent returns the ProxyUpdateEvent for the given set of coalesced event topics
+func newProxyUpdateEvent(topics map[string]struct{}) ProxyUpdateEvent {
+	event := ProxyUpdateEvent{Topics: make([]string, 0, len(topics))}
+	for topic := range topics {
+		event.Topics = append(event.Topics, topic)
+	}
+	sort.Strings(event.Topics)
+	return event
+}
+
 // GetPubSubTopicForProxyUUID returns the topic on which PubSubMessages specific to a proxy UUID are published
 func GetPubSubTopicForProxyUUID(uuid string) string {
 	return fmt.Sprintf("proxy:%s", uuid)
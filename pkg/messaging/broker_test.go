@@ -349,8 +349,12 @@ func TestRunProxyUpdateDispatcher(t *testing.T) {
 	// Verify sliding window expiry
 	b.proxyUpdateCh <- ProxyUpdateTopic
 
+	b.proxyUpdateCh <- events.Pod.Added()
+	b.proxyUpdateCh <- ProxyUpdateTopic
+
 	time.Sleep(proxyUpdateSlidingWindow + 10*time.Millisecond)
-	<-proxyUpdateChan
+	msg := <-proxyUpdateChan
+	a.Equal(ProxyUpdateEvent{Topics: []string{events.Pod.Added(), ProxyUpdateTopic}}, msg)
 	a.EqualValues(b.GetTotalDispatchedProxyEventCount(), 1)
 
 	// Verify max window expiry
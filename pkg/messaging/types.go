@@ -30,3 +30,9 @@ const (
 	// ProxyUpdateTopic is the topic used to send proxy updates
 	ProxyUpdateTopic = "proxy-update"
 )
+
+// ProxyUpdateEvent is the message published on ProxyUpdateTopic to broadcast an update to all proxies.
+type ProxyUpdateEvent struct {
+	// Topics are the topics of the events coalesced into this update, sorted alphabetically.
+	Topics []string
+}
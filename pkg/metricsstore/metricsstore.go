@@ -58,6 +58,16 @@ type MetricsStore struct {
 	// rejected due to the max connections limit being reached
 	ProxyMaxConnectionsRejected prometheus.Counter
 
+	// ProxyXDSAckCount counts the xDS responses ACKed by proxies
+	ProxyXDSAckCount *prometheus.CounterVec
+
+	// ProxyXDSNackCount counts the xDS responses rejected (NACKed) by proxies
+	ProxyXDSNackCount *prometheus.CounterVec
+
+	// ProxyXDSNackedCount is the number of connected proxies whose latest
+	// xDS response was rejected (NACKed)
+	ProxyXDSNackedCount *prometheus.GaugeVec
+
 	// AdmissionWebhookResponseTotal counts the number of webhook responses
 	// generated for both validating and mutating webhooks
 	AdmissionWebhookResponseTotal *prometheus.CounterVec
@@ -71,6 +81,16 @@ type MetricsStore struct {
 	// CertXdsIssuedCounter the histogram to track the time to issue a certificates
 	CertIssuedTime *prometheus.HistogramVec
 
+	// CertRotationErrorCount is the metric counter for the number of certificates that failed to be rotated
+	CertRotationErrorCount *prometheus.CounterVec
+
+	// CertEarliestExpiration is the earliest expiration of the issued certificates of each type, as a Unix timestamp
+	CertEarliestExpiration *prometheus.GaugeVec
+
+	// CertMRCExpiration is the expiration of the root certificate of each MeshRootCertificate in use, as a Unix
+	// timestamp
+	CertMRCExpiration *prometheus.GaugeVec
+
 	/*
 	 * ErrCode metrics
 	 */
@@ -199,6 +219,27 @@ func init() {
 		Help:      "Represents the number of proxy connections rejected due to the configured max connections limit",
 	})
 
+	defaultMetricsStore.ProxyXDSAckCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "xds_ack_count",
+		Help:      "Represents the number of XDS responses ACKed by proxies",
+	}, []string{"type"})
+
+	defaultMetricsStore.ProxyXDSNackCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "xds_nack_count",
+		Help:      "Represents the number of XDS responses rejected (NACKed) by proxies",
+	}, []string{"type"})
+
+	defaultMetricsStore.ProxyXDSNackedCount = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "proxy",
+		Name:      "xds_nacked_count",
+		Help:      "Represents the number of connected proxies whose latest XDS response was rejected (NACKed)",
+	}, []string{"type"})
+
 	defaultMetricsStore.AdmissionWebhookResponseTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: metricsRootNamespace,
 		Name:      "admission_webhook_response_total",
@@ -225,6 +266,27 @@ func init() {
 		},
 		[]string{})
 
+	defaultMetricsStore.CertRotationErrorCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "cert",
+		Name:      "rotation_error_count",
+		Help:      "Represents the number of certificates that failed to be rotated",
+	}, []string{"cert_type"})
+
+	defaultMetricsStore.CertEarliestExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "cert",
+		Name:      "earliest_expiration_timestamp_seconds",
+		Help:      "Represents the earliest expiration of the issued certificates of each type, as a Unix timestamp",
+	}, []string{"cert_type"})
+
+	defaultMetricsStore.CertMRCExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsRootNamespace,
+		Subsystem: "cert",
+		Name:      "mrc_expiration_timestamp_seconds",
+		Help:      "Represents the expiration of the root certificate of each MeshRootCertificate in use, as a Unix timestamp",
+	}, []string{"mrc"})
+
 	/*
 	 * ErrCode metrics
 	 */
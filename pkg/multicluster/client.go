@@ -0,0 +1,184 @@
+package multicluster
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewClient returns a compute.Interface adding the services exported by the peer clusters registered with
+// RemoteCluster resources to the services of the given local compute provider. The endpoints of the peer clusters
+// are their gateways, which are assigned a lower priority than the local endpoints so that clients only fail over
+// to a peer cluster when the local endpoints of a service are unavailable.
+func NewClient(local compute.Interface, kubeClient kubernetes.Interface, kubeController k8s.Controller, trustDomain string,
+	clientFactory ClientFactory, msgBroker *messaging.Broker, stop <-chan struct{}) compute.Interface {
+	c := &client{
+		Interface:      local,
+		kubeClient:     kubeClient,
+		kubeController: kubeController,
+		msgBroker:      msgBroker,
+		trustDomain:    trustDomain,
+		clientFactory:  clientFactory,
+		clusters:       make(map[types.NamespacedName]*remoteCluster),
+	}
+
+	go c.watchRemoteClusters(stop)
+
+	return c
+}
+
+// ListServices returns the services of the local cluster, and the services only exported by peer clusters
+func (c *client) ListServices() []service.MeshService {
+	services := c.Interface.ListServices()
+
+	known := make(map[types.NamespacedName]struct{})
+	for _, svc := range services {
+		known[types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}] = struct{}{}
+	}
+
+	for _, cluster := range c.getClusters() {
+		for _, svc := range cluster.listServices() {
+			key := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+			if _, ok := known[key]; ok {
+				continue
+			}
+			known[key] = struct{}{}
+			services = append(services, cluster.toMeshServices(svc)...)
+		}
+	}
+
+	return services
+}
+
+// GetMeshService returns the MeshService of the local cluster, or of the first peer cluster exporting the service
+func (c *client) GetMeshService(name, namespace string, port uint16) (service.MeshService, error) {
+	meshSvc, err := c.Interface.GetMeshService(name, namespace, port)
+	if err == nil {
+		return meshSvc, nil
+	}
+
+	for _, cluster := range c.getClusters() {
+		svc := cluster.getService(name, namespace)
+		if svc == nil {
+			continue
+		}
+		for _, remoteMeshSvc := range cluster.toMeshServices(svc) {
+			if remoteMeshSvc.Port == port {
+				return remoteMeshSvc, nil
+			}
+		}
+	}
+
+	return meshSvc, err
+}
+
+// GetServicesForServiceIdentity returns the services of the local cluster and of the peer clusters backed by
+// workloads with the given identity
+func (c *client) GetServicesForServiceIdentity(svcIdentity identity.ServiceIdentity) []service.MeshService {
+	services := c.Interface.GetServicesForServiceIdentity(svcIdentity)
+
+	known := make(map[types.NamespacedName]struct{})
+	for _, svc := range services {
+		known[types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}] = struct{}{}
+	}
+
+	for _, cluster := range c.getClusters() {
+		for _, svc := range cluster.listServicesForIdentity(svcIdentity) {
+			key := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+			if _, ok := known[key]; ok {
+				continue
+			}
+			known[key] = struct{}{}
+			services = append(services, cluster.toMeshServices(svc)...)
+		}
+	}
+
+	return services
+}
+
+// ListServiceIdentitiesForService returns the identities backing the service in the local cluster and in the peer
+// clusters exporting it
+func (c *client) ListServiceIdentitiesForService(name, namespace string) ([]identity.ServiceIdentity, error) {
+	identities, err := c.Interface.ListServiceIdentitiesForService(name, namespace)
+
+	known := make(map[identity.ServiceIdentity]struct{})
+	for _, id := range identities {
+		known[id] = struct{}{}
+	}
+
+	exported := false
+	for _, cluster := range c.getClusters() {
+		svc := cluster.getService(name, namespace)
+		if svc == nil {
+			continue
+		}
+		exported = true
+		for _, id := range cluster.listServiceIdentitiesForService(svc) {
+			if _, ok := known[id]; ok {
+				continue
+			}
+			known[id] = struct{}{}
+			identities = append(identities, id)
+		}
+	}
+
+	if err != nil && !exported {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// ListEndpointsForService returns the endpoints of the service in the local cluster, followed by the gateways of the
+// peer clusters exporting the service with ready endpoints
+func (c *client) ListEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	endpoints := c.Interface.ListEndpointsForService(svc)
+
+	// The gateways route connections to services, and can't reach a given endpoint of a headless service
+	if svc.Subdomain != "" {
+		return endpoints
+	}
+
+	for _, cluster := range c.getClusters() {
+		if cluster.hasEndpoints(svc.Name, svc.Namespace, svc.Port) {
+			endpoints = append(endpoints, cluster.gatewayEndpoint(svc.Port))
+		}
+	}
+
+	return endpoints
+}
+
+// ListEndpointsForIdentity returns the endpoints of the identity in the local cluster, and the gateways of the peer
+// clusters with workloads of the mesh running with the identity
+func (c *client) ListEndpointsForIdentity(svcIdentity identity.ServiceIdentity) []endpoint.Endpoint {
+	endpoints := c.Interface.ListEndpointsForIdentity(svcIdentity)
+
+	for _, cluster := range c.getClusters() {
+		if len(cluster.listServicesForIdentity(svcIdentity)) > 0 {
+			endpoints = append(endpoints, endpoint.Endpoint{IP: cluster.gatewayIP})
+		}
+	}
+
+	return endpoints
+}
+
+// GetResolvableEndpointsForService returns the endpoints the service resolves to in the local cluster. A service only
+// exported by peer clusters resolves to their gateways.
+func (c *client) GetResolvableEndpointsForService(svc service.MeshService) []endpoint.Endpoint {
+	if _, err := c.Interface.GetMeshService(svc.Name, svc.Namespace, svc.Port); err == nil {
+		return c.Interface.GetResolvableEndpointsForService(svc)
+	}
+
+	var endpoints []endpoint.Endpoint
+	for _, cluster := range c.getClusters() {
+		if cluster.getService(svc.Name, svc.Namespace) != nil {
+			endpoints = append(endpoints, endpoint.Endpoint{IP: cluster.gatewayIP, Port: endpoint.Port(svc.Port)})
+		}
+	}
+	return endpoints
+}
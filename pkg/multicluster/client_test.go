@@ -0,0 +1,332 @@
+package multicluster
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/envoy/eds"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	testTrustDomain  = "cluster.local"
+	testOSMNamespace = "osm-system"
+)
+
+var errNotFound = errors.New("not found")
+
+// testCluster is a peer cluster of the test harness, each with its own fake API server
+type testCluster struct {
+	name        string
+	gatewayIP   string
+	priority    int
+	weight      int
+	trustDomain string
+	objects     []interface{}
+}
+
+// newTestHarness returns a multicluster client watching the given peer clusters, each backed by its own fake
+// clientset, on top of the given local compute provider
+func newTestHarness(t *testing.T, local compute.Interface, clusters ...testCluster) (*client, *messaging.Broker) {
+	t.Helper()
+
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+
+	localKubeClient := fake.NewSimpleClientset()
+	remoteKubeClients := make(map[string]kubernetes.Interface)
+
+	var remoteClusters []*configv1alpha2.RemoteCluster
+	for _, cluster := range clusters {
+		remoteKubeClient := fake.NewSimpleClientset()
+		for _, obj := range cluster.objects {
+			var err error
+			switch o := obj.(type) {
+			case *corev1.Service:
+				_, err = remoteKubeClient.CoreV1().Services(o.Namespace).Create(context.Background(), o, metav1.CreateOptions{})
+			case *corev1.Endpoints:
+				_, err = remoteKubeClient.CoreV1().Endpoints(o.Namespace).Create(context.Background(), o, metav1.CreateOptions{})
+			case *corev1.Pod:
+				_, err = remoteKubeClient.CoreV1().Pods(o.Namespace).Create(context.Background(), o, metav1.CreateOptions{})
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		remoteKubeClients[cluster.name] = remoteKubeClient
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.name + "-kubeconfig", Namespace: testOSMNamespace},
+			// The kubeconfig of each cluster is its name, which the client factory maps to its fake clientset
+			Data: map[string][]byte{KubeconfigSecretKey: []byte(cluster.name)},
+		}
+		if _, err := localKubeClient.CoreV1().Secrets(testOSMNamespace).Create(context.Background(), secret, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+
+		remoteClusters = append(remoteClusters, &configv1alpha2.RemoteCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster.name, Namespace: testOSMNamespace, Generation: 1},
+			Spec: configv1alpha2.RemoteClusterSpec{
+				KubeconfigSecretRef: corev1.SecretReference{Name: secret.Name},
+				GatewayAddress:      cluster.gatewayIP,
+				TrustDomain:         cluster.trustDomain,
+				Priority:            cluster.priority,
+				Weight:              cluster.weight,
+			},
+		})
+	}
+
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListRemoteClusters().Return(remoteClusters).AnyTimes()
+
+	msgBroker := messaging.NewBroker(stop)
+	c := &client{
+		Interface:      local,
+		kubeClient:     localKubeClient,
+		kubeController: mockKubeController,
+		msgBroker:      msgBroker,
+		trustDomain:    testTrustDomain,
+		clientFactory: func(kubeconfig []byte) (kubernetes.Interface, error) {
+			remoteKubeClient, ok := remoteKubeClients[string(kubeconfig)]
+			if !ok {
+				return nil, errNotFound
+			}
+			return remoteKubeClient, nil
+		},
+		clusters: make(map[types.NamespacedName]*remoteCluster),
+	}
+	c.syncRemoteClusters()
+
+	for _, cluster := range c.getClusters() {
+		tassert.Eventually(t, func() bool {
+			return cluster.services.HasSynced() && cluster.endpoints.HasSynced() && cluster.pods.HasSynced()
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	return c, msgBroker
+}
+
+func newExportedService(name, namespace string, port int32, ready bool) (*corev1.Service, *corev1.Endpoints, *corev1.Pod) {
+	labels := map[string]string{constants.MultiClusterExportLabel: "true"}
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Ports:    []corev1.ServicePort{{Name: "http", Port: port}},
+			Selector: map[string]string{"app": name},
+		},
+	}
+	subset := corev1.EndpointSubset{
+		Ports: []corev1.EndpointPort{{Name: "http", Port: port + 1000}},
+	}
+	if ready {
+		subset.Addresses = []corev1.EndpointAddress{{IP: "10.10.0.1"}}
+	} else {
+		subset.NotReadyAddresses = []corev1.EndpointAddress{{IP: "10.10.0.1"}}
+	}
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Subsets:    []corev1.EndpointSubset{subset},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-pod",
+			Namespace: namespace,
+			Labels:    map[string]string{"app": name, constants.EnvoyUniqueIDLabelName: "proxy-uuid"},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: name},
+	}
+	return svc, endpoints, pod
+}
+
+func TestListEndpointsForService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	bookstore := service.MeshService{Name: "bookstore", Namespace: "bookstore", Port: 80, TargetPort: 8080, Protocol: "http"}
+	localEndpoint := endpoint.Endpoint{IP: net.ParseIP("10.0.0.1"), Port: 8080}
+
+	local := compute.NewMockInterface(mockCtrl)
+	local.EXPECT().ListEndpointsForService(bookstore).Return([]endpoint.Endpoint{localEndpoint}).AnyTimes()
+
+	svcA, epA, podA := newExportedService("bookstore", "bookstore", 80, true)
+	svcB, epB, podB := newExportedService("bookstore", "bookstore", 80, true)
+	svcC, epC, podC := newExportedService("bookstore", "bookstore", 80, false)
+
+	c, _ := newTestHarness(t, local,
+		testCluster{name: "cluster-a", gatewayIP: "192.168.0.1", objects: []interface{}{svcA, epA, podA}},
+		testCluster{name: "cluster-b", gatewayIP: "192.168.0.2", priority: 2, weight: 3, objects: []interface{}{svcB, epB, podB}},
+		// cluster-c has no ready endpoints for the service
+		testCluster{name: "cluster-c", gatewayIP: "192.168.0.3", objects: []interface{}{svcC, epC, podC}},
+	)
+
+	endpoints := c.ListEndpointsForService(bookstore)
+	assert.Equal([]endpoint.Endpoint{
+		localEndpoint,
+		{IP: net.ParseIP("192.168.0.1"), Port: 80, Weight: 1, Priority: 1, Zone: "cluster-a"},
+		{IP: net.ParseIP("192.168.0.2"), Port: 80, Weight: 3, Priority: 2, Zone: "cluster-b"},
+	}, endpoints)
+
+	// Envoy prefers the local endpoints, and fails over to the peer clusters by priority
+	edsBuilder := eds.NewEndpointsBuilder()
+	edsBuilder.AddEndpoints(bookstore, endpoints)
+	resources := edsBuilder.Build()
+	assert.Len(resources, 1)
+	cla := resources[0].(*xds_endpoint.ClusterLoadAssignment)
+	assert.Len(cla.Endpoints, 3)
+	priorities := make(map[string]uint32)
+	for _, localityEndpoints := range cla.Endpoints {
+		priorities[localityEndpoints.Locality.Zone] = localityEndpoints.Priority
+	}
+	assert.Equal(map[string]uint32{"local": 0, "cluster-a": 1, "cluster-b": 2}, priorities)
+
+	// Endpoints of a headless service are not reachable through the gateways
+	headless := bookstore
+	headless.Subdomain = "bookstore-0"
+	local.EXPECT().ListEndpointsForService(headless).Return(nil)
+	assert.Empty(c.ListEndpointsForService(headless))
+}
+
+func TestRemoteOnlyService(t *testing.T) {
+	assert := tassert.New(t)
+	mockCtrl := gomock.NewController(t)
+
+	bookstore := service.MeshService{Name: "bookstore", Namespace: "bookstore", Port: 80, TargetPort: 8080, Protocol: "http"}
+	bookwarehouse := service.MeshService{Name: "bookwarehouse", Namespace: "bookwarehouse", Port: 90, TargetPort: 1090, Protocol: "http"}
+	warehouseIdentity := identity.K8sServiceAccount{Name: "bookwarehouse", Namespace: "bookwarehouse"}.ToServiceIdentity()
+
+	local := compute.NewMockInterface(mockCtrl)
+	local.EXPECT().ListServices().Return([]service.MeshService{bookstore}).AnyTimes()
+	local.EXPECT().GetMeshService("bookwarehouse", "bookwarehouse", uint16(90)).Return(service.MeshService{}, errNotFound).AnyTimes()
+	local.EXPECT().ListServiceIdentitiesForService("bookwarehouse", "bookwarehouse").Return(nil, errNotFound)
+	local.EXPECT().ListServiceIdentitiesForService("unknown", "bookwarehouse").Return(nil, errNotFound)
+	local.EXPECT().ListEndpointsForIdentity(warehouseIdentity).Return(nil)
+	local.EXPECT().GetServicesForServiceIdentity(warehouseIdentity).Return(nil)
+
+	svcA, epA, podA := newExportedService("bookwarehouse", "bookwarehouse", 90, true)
+	svcB, epB, podB := newExportedService("bookwarehouse", "bookwarehouse", 90, true)
+
+	c, _ := newTestHarness(t, local,
+		testCluster{name: "cluster-a", gatewayIP: "192.168.0.1", objects: []interface{}{svcA, epA, podA}},
+		testCluster{name: "cluster-b", gatewayIP: "192.168.0.2", objects: []interface{}{svcB, epB, podB}},
+	)
+
+	// The service is listed once even though both peer clusters export it
+	assert.ElementsMatch([]service.MeshService{bookstore, bookwarehouse}, c.ListServices())
+
+	meshSvc, err := c.GetMeshService("bookwarehouse", "bookwarehouse", 90)
+	assert.NoError(err)
+	assert.Equal(bookwarehouse, meshSvc)
+
+	identities, err := c.ListServiceIdentitiesForService("bookwarehouse", "bookwarehouse")
+	assert.NoError(err)
+	assert.Equal([]identity.ServiceIdentity{warehouseIdentity}, identities)
+
+	_, err = c.ListServiceIdentitiesForService("unknown", "bookwarehouse")
+	assert.Error(err)
+
+	assert.Equal([]endpoint.Endpoint{
+		{IP: net.ParseIP("192.168.0.1")},
+		{IP: net.ParseIP("192.168.0.2")},
+	}, c.ListEndpointsForIdentity(warehouseIdentity))
+
+	assert.Equal([]service.MeshService{bookwarehouse}, c.GetServicesForServiceIdentity(warehouseIdentity))
+
+	assert.Equal([]endpoint.Endpoint{
+		{IP: net.ParseIP("192.168.0.1"), Port: 90},
+		{IP: net.ParseIP("192.168.0.2"), Port: 90},
+	}, c.GetResolvableEndpointsForService(bookwarehouse))
+}
+
+func TestSyncRemoteClusters(t *testing.T) {
+	testCases := []struct {
+		name            string
+		cluster         testCluster
+		expectedWatched bool
+	}{
+		{
+			name:            "valid cluster",
+			cluster:         testCluster{name: "cluster-a", gatewayIP: "192.168.0.1"},
+			expectedWatched: true,
+		},
+		{
+			name:            "same trust domain",
+			cluster:         testCluster{name: "cluster-a", gatewayIP: "192.168.0.1", trustDomain: testTrustDomain},
+			expectedWatched: true,
+		},
+		{
+			name:            "trust domain mismatch",
+			cluster:         testCluster{name: "cluster-a", gatewayIP: "192.168.0.1", trustDomain: "other.domain"},
+			expectedWatched: false,
+		},
+		{
+			name:            "invalid gateway address",
+			cluster:         testCluster{name: "cluster-a", gatewayIP: "gateway.example.com"},
+			expectedWatched: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			c, _ := newTestHarness(t, compute.NewMockInterface(gomock.NewController(t)), tc.cluster)
+			assert.Equal(tc.expectedWatched, len(c.getClusters()) == 1)
+		})
+	}
+}
+
+func TestSyncRemoteClustersOnChange(t *testing.T) {
+	assert := tassert.New(t)
+
+	c, _ := newTestHarness(t, compute.NewMockInterface(gomock.NewController(t)),
+		testCluster{name: "cluster-a", gatewayIP: "192.168.0.1"})
+	clusters := c.getClusters()
+	assert.Len(clusters, 1)
+
+	// The cluster is not restarted when its RemoteCluster is unchanged
+	c.syncRemoteClusters()
+	assert.Equal(clusters, c.getClusters())
+
+	// The cluster is restarted when its RemoteCluster is updated
+	rc := c.kubeController.ListRemoteClusters()[0]
+	rc.Generation++
+	rc.Spec.GatewayAddress = "192.168.0.10"
+	c.syncRemoteClusters()
+	updated := c.getClusters()
+	assert.Len(updated, 1)
+	assert.Equal("192.168.0.10", updated[0].gatewayIP.String())
+	assert.NotEqual(clusters[0], updated[0])
+	select {
+	case <-clusters[0].stop:
+	default:
+		assert.Fail("watch of the previous cluster was not stopped")
+	}
+
+	// The cluster is stopped when its RemoteCluster is deleted
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListRemoteClusters().Return(nil)
+	c.kubeController = mockKubeController
+	c.syncRemoteClusters()
+	assert.Empty(c.getClusters())
+}
@@ -0,0 +1,273 @@
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+const gatewayConnectTimeout = 5 * time.Second
+
+// WatchAndUpdateGateway keeps the listeners and clusters of the east-west gateway, and the ports of its Service, in
+// sync with the services exported by the local cluster
+func WatchAndUpdateGateway(kubeClient kubernetes.Interface, kubeController k8s.Controller, msgBroker *messaging.Broker, osmNamespace string, stop <-chan struct{}) {
+	svcChan, unsub := msgBroker.SubscribeKubeEvents(
+		events.Service.Added(),
+		events.Service.Updated(),
+		events.Service.Deleted(),
+	)
+	defer unsub()
+
+	if err := updateGateway(kubeClient, kubeController, osmNamespace); err != nil {
+		log.Error().Err(err).Msg("Error updating the east-west gateway")
+	}
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting east-west gateway update routine")
+			return
+
+		case <-svcChan:
+			if err := updateGateway(kubeClient, kubeController, osmNamespace); err != nil {
+				log.Error().Err(err).Msg("Error updating the east-west gateway")
+			}
+		}
+	}
+}
+
+// updateGateway updates the gateway ConfigMap and Service for the services currently exported by the local cluster
+func updateGateway(kubeClient kubernetes.Interface, kubeController k8s.Controller, osmNamespace string) error {
+	var exported []*corev1.Service
+	for _, svc := range kubeController.ListServices() {
+		if svc.Labels[constants.MultiClusterExportLabel] == "true" {
+			exported = append(exported, svc)
+		}
+	}
+
+	listeners, clusters, ports, err := buildGatewayConfig(exported)
+	if err != nil {
+		return err
+	}
+
+	if err := updateGatewayConfigMap(kubeClient, osmNamespace, map[string]string{
+		GatewayListenersFile: string(listeners),
+		GatewayClustersFile:  string(clusters),
+	}); err != nil {
+		return err
+	}
+
+	return updateGatewayService(kubeClient, osmNamespace, ports)
+}
+
+// buildGatewayConfig returns the listeners and clusters of the gateway, as xDS responses read from the filesystem by
+// Envoy, and the ports the gateway listens on. The gateway listens on each port of the exported services, and routes
+// connections to the service matching their SNI.
+func buildGatewayConfig(exported []*corev1.Service) ([]byte, []byte, []uint16, error) {
+	sort.Slice(exported, func(i, j int) bool {
+		if exported[i].Namespace != exported[j].Namespace {
+			return exported[i].Namespace < exported[j].Namespace
+		}
+		return exported[i].Name < exported[j].Name
+	})
+
+	filterChainsPerPort := make(map[uint16][]*xds_listener.FilterChain)
+	clustersResponse := &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeCDS.String()}
+
+	for _, svc := range exported {
+		for _, portSpec := range svc.Spec.Ports {
+			if portSpec.Protocol != "" && portSpec.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+			meshSvc := service.MeshService{
+				Name:      svc.Name,
+				Namespace: svc.Namespace,
+				Port:      uint16(portSpec.Port),
+			}
+			clusterName := fmt.Sprintf("%s|%d", meshSvc, meshSvc.Port)
+
+			cluster, err := anypb.New(&xds_cluster.Cluster{
+				Name:           clusterName,
+				ConnectTimeout: durationpb.New(gatewayConnectTimeout),
+				ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+					Type: xds_cluster.Cluster_STRICT_DNS,
+				},
+				LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+					ClusterName: clusterName,
+					Endpoints: []*xds_endpoint.LocalityLbEndpoints{{
+						LbEndpoints: []*xds_endpoint.LbEndpoint{{
+							HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+								Endpoint: &xds_endpoint.Endpoint{
+									Address: envoy.GetAddress(meshSvc.FQDN(), uint32(meshSvc.Port)),
+								},
+							},
+						}},
+					}},
+				},
+			})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			clustersResponse.Resources = append(clustersResponse.Resources, cluster)
+
+			tcpProxy, err := anypb.New(&xds_tcp_proxy.TcpProxy{
+				StatPrefix:       clusterName,
+				ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: clusterName},
+			})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			filterChainsPerPort[meshSvc.Port] = append(filterChainsPerPort[meshSvc.Port], &xds_listener.FilterChain{
+				Name: clusterName,
+				FilterChainMatch: &xds_listener.FilterChainMatch{
+					ServerNames:       []string{meshSvc.ServerName()},
+					TransportProtocol: envoy.TransportProtocolTLS,
+				},
+				Filters: []*xds_listener.Filter{{
+					Name:       envoy.TCPProxyFilterName,
+					ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: tcpProxy},
+				}},
+			})
+		}
+	}
+
+	ports := make([]uint16, 0, len(filterChainsPerPort))
+	for port := range filterChainsPerPort {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	listenersResponse := &xds_discovery.DiscoveryResponse{TypeUrl: envoy.TypeLDS.String()}
+	for _, port := range ports {
+		listener, err := anypb.New(&xds_listener.Listener{
+			Name:    fmt.Sprintf("gateway_%d", port),
+			Address: envoy.GetAddress(constants.WildcardIPAddr, uint32(port)),
+			ListenerFilters: []*xds_listener.ListenerFilter{{
+				// To inspect the SNI of the connections
+				Name: envoy.TLSInspectorFilterName,
+				ConfigType: &xds_listener.ListenerFilter_TypedConfig{
+					TypedConfig: &any.Any{
+						TypeUrl: envoy.TLSInspectorFilterTypeURL,
+					},
+				},
+			}},
+			FilterChains: filterChainsPerPort[port],
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		listenersResponse.Resources = append(listenersResponse.Resources, listener)
+	}
+
+	listeners, err := utils.ProtoToYAML(listenersResponse)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	clusters, err := utils.ProtoToYAML(clustersResponse)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return listeners, clusters, ports, nil
+}
+
+// updateGatewayConfigMap creates or updates the gateway ConfigMap with the given data
+func updateGatewayConfigMap(kubeClient kubernetes.Interface, osmNamespace string, data map[string]string) error {
+	configMap, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.Background(), GatewayConfigMapName, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GatewayConfigMapName,
+				Namespace: osmNamespace,
+				Labels: map[string]string{
+					constants.OSMAppNameLabelKey: constants.OSMAppNameLabelValue,
+				},
+			},
+			Data: data,
+		}
+		_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.Background(), configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if reflect.DeepEqual(configMap.Data, data) {
+		return nil
+	}
+	configMap.Data = data
+	_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.Background(), configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// updateGatewayService sets the ports of the gateway Service managed by the controller to the given ports. The other
+// ports of the Service, such as its health port, are left unchanged.
+func updateGatewayService(kubeClient kubernetes.Interface, osmNamespace string, ports []uint16) error {
+	svc, err := kubeClient.CoreV1().Services(osmNamespace).Get(context.Background(), GatewayServiceName, metav1.GetOptions{})
+	if k8sErrors.IsNotFound(err) {
+		log.Warn().Msgf("East-west gateway Service %s/%s not found, skipping update of its ports", osmNamespace, GatewayServiceName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var svcPorts []corev1.ServicePort
+	for _, svcPort := range svc.Spec.Ports {
+		if !strings.HasPrefix(svcPort.Name, gatewayPortNamePrefix) {
+			svcPorts = append(svcPorts, svcPort)
+		}
+	}
+	for _, port := range ports {
+		svcPorts = append(svcPorts, corev1.ServicePort{
+			Name:       gatewayPortNamePrefix + strconv.Itoa(int(port)),
+			Protocol:   corev1.ProtocolTCP,
+			Port:       int32(port),
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+
+	if reflect.DeepEqual(managedPorts(svc.Spec.Ports), managedPorts(svcPorts)) {
+		return nil
+	}
+	svc.Spec.Ports = svcPorts
+	_, err = kubeClient.CoreV1().Services(osmNamespace).Update(context.Background(), svc, metav1.UpdateOptions{})
+	return err
+}
+
+// managedPorts returns the ports of the gateway Service managed by the controller
+func managedPorts(svcPorts []corev1.ServicePort) map[string]int32 {
+	ports := make(map[string]int32)
+	for _, svcPort := range svcPorts {
+		if strings.HasPrefix(svcPort.Name, gatewayPortNamePrefix) {
+			ports[svcPort.Name] = svcPort.Port
+		}
+	}
+	return ports
+}
@@ -0,0 +1,96 @@
+package multicluster
+
+import (
+	"context"
+	"testing"
+
+	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/utils"
+)
+
+func TestBuildGatewayConfig(t *testing.T) {
+	assert := tassert.New(t)
+
+	bookstore, _, _ := newExportedService("bookstore", "bookstore", 80, true)
+	bookwarehouse, _, _ := newExportedService("bookwarehouse", "bookwarehouse", 80, true)
+	bookwarehouse.Spec.Ports = append(bookwarehouse.Spec.Ports,
+		corev1.ServicePort{Name: "tcp-db", Port: 5432},
+		corev1.ServicePort{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+	)
+
+	listenersYAML, clustersYAML, ports, err := buildGatewayConfig([]*corev1.Service{bookwarehouse, bookstore})
+	assert.NoError(err)
+	assert.Equal([]uint16{80, 5432}, ports)
+
+	listeners := &xds_discovery.DiscoveryResponse{}
+	assert.NoError(utils.YAMLToProto(listenersYAML, listeners))
+	assert.Len(listeners.Resources, 2)
+
+	listener := &xds_listener.Listener{}
+	assert.NoError(listeners.Resources[0].UnmarshalTo(listener))
+	assert.Equal("gateway_80", listener.Name)
+	assert.Equal(uint32(80), listener.Address.GetSocketAddress().GetPortValue())
+	assert.Len(listener.FilterChains, 2)
+	assert.Equal([]string{"bookstore.bookstore.svc.cluster.local"}, listener.FilterChains[0].FilterChainMatch.ServerNames)
+	assert.Equal([]string{"bookwarehouse.bookwarehouse.svc.cluster.local"}, listener.FilterChains[1].FilterChainMatch.ServerNames)
+
+	clusters := &xds_discovery.DiscoveryResponse{}
+	assert.NoError(utils.YAMLToProto(clustersYAML, clusters))
+	assert.Len(clusters.Resources, 3)
+	assert.Contains(string(clustersYAML), "bookwarehouse.bookwarehouse.svc.cluster.local")
+	assert.Contains(string(clustersYAML), "bookwarehouse/bookwarehouse|5432")
+}
+
+func TestUpdateGateway(t *testing.T) {
+	assert := tassert.New(t)
+
+	gatewaySvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: GatewayServiceName, Namespace: testOSMNamespace},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http-health", Port: 15021},
+				{Name: "tls-8080", Port: 8080},
+			},
+		},
+	}
+	kubeClient := fake.NewSimpleClientset(gatewaySvc)
+
+	exported, _, _ := newExportedService("bookstore", "bookstore", 80, true)
+	notExported := exported.DeepCopy()
+	notExported.Name = "bookbuyer"
+	notExported.Labels = nil
+
+	mockCtrl := gomock.NewController(t)
+	mockKubeController := k8s.NewMockController(mockCtrl)
+	mockKubeController.EXPECT().ListServices().Return([]*corev1.Service{exported, notExported}).Times(2)
+
+	assert.NoError(updateGateway(kubeClient, mockKubeController, testOSMNamespace))
+
+	configMap, err := kubeClient.CoreV1().ConfigMaps(testOSMNamespace).Get(context.Background(), GatewayConfigMapName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Contains(configMap.Data[GatewayListenersFile], "bookstore.bookstore.svc.cluster.local")
+	assert.NotContains(configMap.Data[GatewayListenersFile], "bookbuyer")
+	assert.Contains(configMap.Data[GatewayClustersFile], "bookstore/bookstore|80")
+
+	svc, err := kubeClient.CoreV1().Services(testOSMNamespace).Get(context.Background(), GatewayServiceName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal([]corev1.ServicePort{
+		{Name: "http-health", Port: 15021},
+		{Name: "tls-80", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(80)},
+	}, svc.Spec.Ports)
+
+	// Updating again with the same exported services is a no-op
+	assert.NoError(updateGateway(kubeClient, mockKubeController, testOSMNamespace))
+	updatedConfigMap, err := kubeClient.CoreV1().ConfigMaps(testOSMNamespace).Get(context.Background(), GatewayConfigMapName, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal(configMap, updatedConfigMap)
+}
@@ -0,0 +1,321 @@
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+
+	"github.com/openservicemesh/osm/pkg/compute/kube"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	osminformers "github.com/openservicemesh/osm/pkg/k8s/informers"
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+// NewClientFromKubeconfig returns a Kubernetes client for the cluster referenced by the given kubeconfig
+func NewClientFromKubeconfig(kubeconfig []byte) (kubernetes.Interface, error) {
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// watchRemoteClusters starts and stops watching the peer clusters as RemoteCluster resources are added, updated and
+// deleted
+func (c *client) watchRemoteClusters(stop <-chan struct{}) {
+	remoteClusterChan, unsub := c.msgBroker.SubscribeKubeEvents(
+		events.RemoteCluster.Added(),
+		events.RemoteCluster.Updated(),
+		events.RemoteCluster.Deleted(),
+	)
+	defer unsub()
+
+	c.syncRemoteClusters()
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting remote cluster watch routine")
+			c.mu.Lock()
+			for key, cluster := range c.clusters {
+				close(cluster.stop)
+				delete(c.clusters, key)
+			}
+			c.mu.Unlock()
+			return
+
+		case <-remoteClusterChan:
+			c.syncRemoteClusters()
+		}
+	}
+}
+
+// syncRemoteClusters watches the peer clusters registered with the current RemoteCluster resources, restarting the
+// watch of the clusters whose RemoteCluster has changed
+func (c *client) syncRemoteClusters() {
+	remoteClusters := make(map[types.NamespacedName]*configv1alpha2.RemoteCluster)
+	for _, rc := range c.kubeController.ListRemoteClusters() {
+		remoteClusters[types.NamespacedName{Namespace: rc.Namespace, Name: rc.Name}] = rc
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	changed := false
+	for key, cluster := range c.clusters {
+		if rc, ok := remoteClusters[key]; ok && rc.Generation == cluster.generation {
+			continue
+		}
+		log.Info().Msgf("Stopping watch of remote cluster %s", key)
+		close(cluster.stop)
+		delete(c.clusters, key)
+		changed = true
+	}
+
+	for key, rc := range remoteClusters {
+		if _, ok := c.clusters[key]; ok {
+			continue
+		}
+		cluster, err := c.newRemoteCluster(rc)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error watching remote cluster %s", key)
+			continue
+		}
+		log.Info().Msgf("Started watch of remote cluster %s", key)
+		c.clusters[key] = cluster
+		changed = true
+	}
+
+	if changed {
+		c.msgBroker.BroadcastProxyUpdate()
+	}
+}
+
+// newRemoteCluster starts watching the services exported by the peer cluster registered with the given RemoteCluster
+func (c *client) newRemoteCluster(rc *configv1alpha2.RemoteCluster) (*remoteCluster, error) {
+	gatewayIP := net.ParseIP(rc.Spec.GatewayAddress)
+	if gatewayIP == nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidGatewayAddress, rc.Spec.GatewayAddress)
+	}
+
+	if rc.Spec.TrustDomain != "" && rc.Spec.TrustDomain != c.trustDomain {
+		return nil, fmt.Errorf("%w: %s != %s", errTrustDomainMismatch, rc.Spec.TrustDomain, c.trustDomain)
+	}
+
+	secretNamespace := rc.Spec.KubeconfigSecretRef.Namespace
+	if secretNamespace == "" {
+		secretNamespace = rc.Namespace
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(secretNamespace).Get(context.Background(), rc.Spec.KubeconfigSecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching kubeconfig secret %s/%s: %w", secretNamespace, rc.Spec.KubeconfigSecretRef.Name, err)
+	}
+	kubeconfig, ok := secret.Data[KubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("%w %s/%s", errMissingKubeconfig, secretNamespace, rc.Spec.KubeconfigSecretRef.Name)
+	}
+
+	remoteKubeClient, err := c.clientFactory(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client from kubeconfig secret %s/%s: %w", secretNamespace, rc.Spec.KubeconfigSecretRef.Name, err)
+	}
+
+	cluster := &remoteCluster{
+		name:       rc.Name,
+		generation: rc.Generation,
+		gatewayIP:  gatewayIP,
+		priority:   endpoint.Priority(rc.Spec.Priority),
+		weight:     endpoint.Weight(rc.Spec.Weight),
+		stop:       make(chan struct{}),
+	}
+	if cluster.priority == 0 {
+		cluster.priority = defaultPriority
+	}
+	if cluster.weight == 0 {
+		cluster.weight = defaultWeight
+	}
+
+	// Only the exported services and their endpoints, and the pods of the mesh are watched
+	exportedInformerFactory := informers.NewSharedInformerFactoryWithOptions(remoteKubeClient, osminformers.DefaultKubeEventResyncInterval,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = fmt.Sprintf("%s=true", constants.MultiClusterExportLabel)
+		}))
+	podInformerFactory := informers.NewSharedInformerFactoryWithOptions(remoteKubeClient, osminformers.DefaultKubeEventResyncInterval,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = constants.EnvoyUniqueIDLabelName
+		}))
+	cluster.services = exportedInformerFactory.Core().V1().Services().Informer()
+	cluster.endpoints = exportedInformerFactory.Core().V1().Endpoints().Informer()
+	cluster.pods = podInformerFactory.Core().V1().Pods().Informer()
+
+	// Changes to the peer cluster are not published as events of the local cluster, the proxies are updated instead
+	handler := cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.msgBroker.BroadcastProxyUpdate() },
+		UpdateFunc: func(interface{}, interface{}) { c.msgBroker.BroadcastProxyUpdate() },
+		DeleteFunc: func(interface{}) { c.msgBroker.BroadcastProxyUpdate() },
+	}
+	for _, informer := range []cache.SharedIndexInformer{cluster.services, cluster.endpoints, cluster.pods} {
+		informer.AddEventHandler(handler)
+		go informer.Run(cluster.stop)
+	}
+
+	return cluster, nil
+}
+
+// getService returns the exported service with the given name and namespace
+func (rc *remoteCluster) getService(name, namespace string) *corev1.Service {
+	svcIface, exists, err := rc.services.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil
+	}
+	return svcIface.(*corev1.Service)
+}
+
+// getEndpoints returns the endpoints of the exported service with the given name and namespace
+func (rc *remoteCluster) getEndpoints(name, namespace string) *corev1.Endpoints {
+	epIface, exists, err := rc.endpoints.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil
+	}
+	return epIface.(*corev1.Endpoints)
+}
+
+// listServices returns the exported services
+func (rc *remoteCluster) listServices() []*corev1.Service {
+	var services []*corev1.Service
+	for _, svcIface := range rc.services.GetStore().List() {
+		services = append(services, svcIface.(*corev1.Service))
+	}
+	return services
+}
+
+// toMeshServices returns a MeshService for each port of the given exported service
+func (rc *remoteCluster) toMeshServices(svc *corev1.Service) []service.MeshService {
+	var meshServices []service.MeshService
+	endpoints := rc.getEndpoints(svc.Name, svc.Namespace)
+	for _, portSpec := range svc.Spec.Ports {
+		meshSvc := service.MeshService{
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+			Port:      uint16(portSpec.Port),
+			Protocol:  kube.GetServicePortProtocol(*svc, portSpec),
+		}
+		if endpoints != nil {
+			meshSvc.TargetPort = kube.GetTargetPortFromEndpoints(portSpec.Name, *endpoints)
+		}
+		meshServices = append(meshServices, meshSvc)
+	}
+	return meshServices
+}
+
+// hasEndpoints returns whether the exported service with the given name and namespace has ready endpoints for the
+// given service port
+func (rc *remoteCluster) hasEndpoints(name, namespace string, port uint16) bool {
+	svc := rc.getService(name, namespace)
+	endpoints := rc.getEndpoints(name, namespace)
+	if svc == nil || endpoints == nil {
+		return false
+	}
+
+	for _, portSpec := range svc.Spec.Ports {
+		if uint16(portSpec.Port) != port {
+			continue
+		}
+		for _, subset := range endpoints.Subsets {
+			if len(subset.Addresses) == 0 {
+				continue
+			}
+			for _, epPort := range subset.Ports {
+				if portSpec.Name == "" || epPort.Name == portSpec.Name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// gatewayEndpoint returns the endpoint of the peer cluster's gateway for the given service port
+func (rc *remoteCluster) gatewayEndpoint(port uint16) endpoint.Endpoint {
+	return endpoint.Endpoint{
+		IP:       rc.gatewayIP,
+		Port:     endpoint.Port(port),
+		Weight:   rc.weight,
+		Priority: rc.priority,
+		Zone:     rc.name,
+	}
+}
+
+// listPodsForService returns the pods of the mesh selected by the given exported service
+func (rc *remoteCluster) listPodsForService(svc *corev1.Service) []*corev1.Pod {
+	if len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := labels.Set(svc.Spec.Selector).AsSelector()
+
+	var pods []*corev1.Pod
+	for _, podIface := range rc.pods.GetStore().List() {
+		pod := podIface.(*corev1.Pod)
+		if pod.Namespace == svc.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+	return pods
+}
+
+// listServiceIdentitiesForService returns the identities of the pods of the mesh selected by the given exported
+// service
+func (rc *remoteCluster) listServiceIdentitiesForService(svc *corev1.Service) []identity.ServiceIdentity {
+	var identities []identity.ServiceIdentity
+	for _, pod := range rc.listPodsForService(svc) {
+		identities = append(identities, identity.K8sServiceAccount{
+			Name:      pod.Spec.ServiceAccountName,
+			Namespace: pod.Namespace,
+		}.ToServiceIdentity())
+	}
+	return identities
+}
+
+// listServicesForIdentity returns the exported services selecting the pods with the given identity
+func (rc *remoteCluster) listServicesForIdentity(svcIdentity identity.ServiceIdentity) []*corev1.Service {
+	var services []*corev1.Service
+	for _, svc := range rc.listServices() {
+		for _, id := range rc.listServiceIdentitiesForService(svc) {
+			if id == svcIdentity {
+				services = append(services, svc)
+				break
+			}
+		}
+	}
+	return services
+}
+
+// getClusters returns the watched peer clusters sorted by name
+func (c *client) getClusters() []*remoteCluster {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	clusters := make([]*remoteCluster, 0, len(c.clusters))
+	for _, cluster := range c.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].name < clusters[j].name
+	})
+	return clusters
+}
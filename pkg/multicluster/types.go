@@ -0,0 +1,91 @@
+// Package multicluster implements the discovery of the services exported by the peer clusters of the mesh, and the
+// configuration of the east-west gateway exposing the services exported by the local cluster to its peers.
+//
+// Peer clusters are registered with RemoteCluster resources. A service is exported by labeling it with
+// openservicemesh.io/multicluster-export=true. Clients reach the endpoints of a peer cluster through its east-west
+// gateway, which routes the mTLS connections of the clients to the exported services based on their SNI, without
+// terminating TLS. The clusters of the mesh must share the same trust domain.
+package multicluster
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/compute"
+	"github.com/openservicemesh/osm/pkg/endpoint"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+const (
+	// KubeconfigSecretKey is the key of the kubeconfig in the Secret referenced by a RemoteCluster
+	KubeconfigSecretKey = "kubeconfig"
+
+	// GatewayConfigMapName is the name of the ConfigMap holding the listeners and clusters of the east-west gateway
+	GatewayConfigMapName = "osm-multicluster-gateway-config"
+
+	// GatewayServiceName is the name of the Service of the east-west gateway
+	GatewayServiceName = "osm-multicluster-gateway"
+
+	// GatewayListenersFile is the key of the gateway listeners in the gateway ConfigMap
+	GatewayListenersFile = "lds.yaml"
+
+	// GatewayClustersFile is the key of the gateway clusters in the gateway ConfigMap
+	GatewayClustersFile = "cds.yaml"
+
+	// gatewayPortNamePrefix is the prefix of the names of the gateway Service ports managed by the controller
+	gatewayPortNamePrefix = "tls-"
+
+	// defaultPriority is the priority of the endpoints of a peer cluster when unset on the RemoteCluster
+	defaultPriority = 1
+
+	// defaultWeight is the weight of the endpoints of a peer cluster when unset on the RemoteCluster
+	defaultWeight = 1
+)
+
+var (
+	log = logger.New("multicluster")
+
+	errInvalidGatewayAddress = errors.New("invalid gateway address")
+	errTrustDomainMismatch   = errors.New("trust domain of the peer cluster does not match the trust domain of the mesh")
+	errMissingKubeconfig     = errors.New("kubeconfig not found in secret")
+)
+
+// ClientFactory returns the client used to watch a peer cluster from the given kubeconfig
+type ClientFactory func(kubeconfig []byte) (kubernetes.Interface, error)
+
+// client is a compute.Interface decorating the compute provider of the local cluster with the services exported
+// by the peer clusters of the mesh
+type client struct {
+	compute.Interface
+
+	kubeClient     kubernetes.Interface
+	kubeController k8s.Controller
+	msgBroker      *messaging.Broker
+	trustDomain    string
+	clientFactory  ClientFactory
+
+	mu       sync.RWMutex
+	clusters map[types.NamespacedName]*remoteCluster
+}
+
+// remoteCluster is the view of the services exported by a peer cluster
+type remoteCluster struct {
+	name       string
+	generation int64
+	gatewayIP  net.IP
+	priority   endpoint.Priority
+	weight     endpoint.Weight
+
+	services  cache.SharedIndexInformer
+	endpoints cache.SharedIndexInformer
+	pods      cache.SharedIndexInformer
+
+	stop chan struct{}
+}
@@ -0,0 +1,187 @@
+package podidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// openIDConfigurationPath is the path of the OpenID provider configuration of the service account issuer, served
+	// by the Kubernetes API server
+	openIDConfigurationPath = "/.well-known/openid-configuration"
+
+	// jwksPath is the path of the JSON Web Key Set of the service account issuer, served by the Kubernetes API server
+	jwksPath = "/openid/v1/jwks"
+
+	// minKeySetRefreshInterval is the minimum interval between two fetches of the key set, refetched when a token is
+	// signed with an unknown key after the rotation of the keys of the service account issuer
+	minKeySetRefreshInterval = time.Minute
+)
+
+// KeySetFunc returns the JSON Web Key Set the service account tokens are signed with.
+type KeySetFunc func(ctx context.Context) (*jose.JSONWebKeySet, error)
+
+// serviceAccountClaims are the private claims of the service account tokens bound to pods
+type serviceAccountClaims struct {
+	Kubernetes struct {
+		Namespace string `json:"namespace"`
+		Pod       struct {
+			Name string `json:"name"`
+			UID  string `json:"uid"`
+		} `json:"pod"`
+		ServiceAccount struct {
+			Name string `json:"name"`
+		} `json:"serviceaccount"`
+	} `json:"kubernetes.io"`
+}
+
+// jwksAuthenticator authenticates pods by verifying the signatures and claims of their tokens locally, with the keys
+// of the service account issuer.
+type jwksAuthenticator struct {
+	issuer    string
+	audience  string
+	getKeySet KeySetFunc
+
+	mu        sync.Mutex
+	keySet    *jose.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+// NewJWKSAuthenticator returns an Authenticator verifying the tokens locally with the keys returned by the given
+// function, which requires the tokens to be issued by the given issuer for the given audience. The keys are fetched
+// on the first authentication, and refetched when a token is signed with an unknown key.
+func NewJWKSAuthenticator(issuer, audience string, getKeySet KeySetFunc) Authenticator {
+	return &jwksAuthenticator{
+		issuer:    issuer,
+		audience:  audience,
+		getKeySet: getKeySet,
+	}
+}
+
+// NewAPIServerJWKSAuthenticator returns an Authenticator verifying the tokens locally with the keys of the service
+// account issuer, discovered from the Kubernetes API server. It doesn't review each token with the API server.
+func NewAPIServerJWKSAuthenticator(ctx context.Context, kubeClient kubernetes.Interface, audience string) (Authenticator, error) {
+	raw, err := kubeClient.Discovery().RESTClient().Get().AbsPath(openIDConfigurationPath).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the service account issuer configuration: %w", err)
+	}
+	var config struct {
+		Issuer string `json:"issuer"`
+	}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("error decoding the service account issuer configuration: %w", err)
+	}
+	if config.Issuer == "" {
+		return nil, fmt.Errorf("service account issuer configuration without issuer")
+	}
+
+	return NewJWKSAuthenticator(config.Issuer, audience, func(ctx context.Context) (*jose.JSONWebKeySet, error) {
+		raw, err := kubeClient.Discovery().RESTClient().Get().AbsPath(jwksPath).DoRaw(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the keys of the service account issuer: %w", err)
+		}
+		keySet := &jose.JSONWebKeySet{}
+		if err := json.Unmarshal(raw, keySet); err != nil {
+			return nil, fmt.Errorf("error decoding the keys of the service account issuer: %w", err)
+		}
+		return keySet, nil
+	}), nil
+}
+
+// Authenticate returns the identity of the pod the given token is bound to.
+func (a *jwksAuthenticator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: empty token", ErrUnauthenticated)
+	}
+
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	if len(parsed.Headers) != 1 {
+		return nil, fmt.Errorf("%w: token with %d signatures", ErrUnauthenticated, len(parsed.Headers))
+	}
+	header := parsed.Headers[0]
+
+	key, err := a.getKey(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+		return nil, fmt.Errorf("%w: token signed with algorithm %s, key %s is for %s", ErrUnauthenticated, header.Algorithm, header.KeyID, key.Algorithm)
+	}
+
+	var claims jwt.Claims
+	var saClaims serviceAccountClaims
+	if err := parsed.Claims(key.Key, &claims, &saClaims); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	if err := claims.ValidateWithLeeway(jwt.Expected{
+		Issuer:   a.issuer,
+		Audience: jwt.Audience{a.audience},
+		Time:     time.Now(),
+	}, jwt.DefaultLeeway); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, err)
+	}
+	// Tokens bound to pods always expire
+	if claims.Expiry == nil {
+		return nil, fmt.Errorf("%w: token without expiration", ErrUnauthenticated)
+	}
+
+	pod := saClaims.Kubernetes.Pod
+	id, err := identityFromUser(claims.Subject, []string{pod.Name}, []string{pod.UID})
+	if err != nil {
+		return nil, err
+	}
+	if saClaims.Kubernetes.Namespace != id.ServiceAccount.Namespace || saClaims.Kubernetes.ServiceAccount.Name != id.ServiceAccount.Name {
+		return nil, fmt.Errorf("%w: token of %s bound to service account %s/%s", ErrUnauthenticated, claims.Subject,
+			saClaims.Kubernetes.Namespace, saClaims.Kubernetes.ServiceAccount.Name)
+	}
+	id.Expiration = claims.Expiry.Time()
+	return id, nil
+}
+
+// getKey returns the key with the given ID, refetching the key set if the key is unknown
+func (a *jwksAuthenticator) getKey(ctx context.Context, keyID string) (*jose.JSONWebKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if key := a.findKey(keyID); key != nil {
+		return key, nil
+	}
+	if a.keySet != nil && time.Since(a.fetchedAt) < minKeySetRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrUnauthenticated, keyID)
+	}
+
+	keySet, err := a.getKeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.keySet = keySet
+	a.fetchedAt = time.Now()
+
+	if key := a.findKey(keyID); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrUnauthenticated, keyID)
+}
+
+// findKey returns the public key with the given ID in the cached key set, or nil if there's none
+func (a *jwksAuthenticator) findKey(keyID string) *jose.JSONWebKey {
+	if a.keySet == nil {
+		return nil
+	}
+	for _, key := range a.keySet.Key(keyID) {
+		if key.Valid() && key.IsPublic() {
+			return &key
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package podidentity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	trequire "github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const testIssuer = "https://kubernetes.default.svc.cluster.local"
+
+// testSigner signs service account tokens like the service account issuer of the API server
+type testSigner struct {
+	key   *ecdsa.PrivateKey
+	keyID string
+}
+
+func newTestSigner(t *testing.T, keyID string) *testSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	trequire.NoError(t, err)
+	return &testSigner{key: key, keyID: keyID}
+}
+
+func (s *testSigner) publicKey() jose.JSONWebKey {
+	return jose.JSONWebKey{Key: s.key.Public(), KeyID: s.keyID, Algorithm: string(jose.ES256), Use: "sig"}
+}
+
+// sign returns a token with the given claims, bound to the pod ns/pod with UID uid running as service account ns/sa
+func (s *testSigner) sign(t *testing.T, claims jwt.Claims, mutate func(*serviceAccountClaims)) string {
+	t.Helper()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: s.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", s.keyID))
+	trequire.NoError(t, err)
+
+	saClaims := serviceAccountClaims{}
+	saClaims.Kubernetes.Namespace = "ns"
+	saClaims.Kubernetes.Pod.Name = "pod"
+	saClaims.Kubernetes.Pod.UID = "uid"
+	saClaims.Kubernetes.ServiceAccount.Name = "sa"
+	if mutate != nil {
+		mutate(&saClaims)
+	}
+
+	token, err := jwt.Signed(signer).Claims(claims).Claims(saClaims).CompactSerialize()
+	trequire.NoError(t, err)
+	return token
+}
+
+func validClaims(expiry time.Time) jwt.Claims {
+	return jwt.Claims{
+		Issuer:    testIssuer,
+		Subject:   "system:serviceaccount:ns:sa",
+		Audience:  jwt.Audience{"osm-controller"},
+		IssuedAt:  jwt.NewNumericDate(expiry.Add(-time.Hour)),
+		NotBefore: jwt.NewNumericDate(expiry.Add(-time.Hour)),
+		Expiry:    jwt.NewNumericDate(expiry),
+	}
+}
+
+func TestJWKSAuthenticate(t *testing.T) {
+	signer := newTestSigner(t, "key1")
+	otherSigner := newTestSigner(t, "key1")
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	withClaims := func(mutate func(*jwt.Claims)) jwt.Claims {
+		claims := validClaims(expiry)
+		mutate(&claims)
+		return claims
+	}
+
+	testCases := []struct {
+		name             string
+		token            string
+		expectedIdentity *Identity
+	}{
+		{
+			name:  "token bound to a pod",
+			token: signer.sign(t, validClaims(expiry), nil),
+			expectedIdentity: &Identity{
+				ServiceAccount: identity.K8sServiceAccount{Namespace: "ns", Name: "sa"},
+				PodName:        "pod",
+				PodUID:         "uid",
+				Expiration:     expiry,
+			},
+		},
+		{
+			name: "empty token",
+		},
+		{
+			name:  "malformed token",
+			token: "token",
+		},
+		{
+			name:  "token signed with another key",
+			token: otherSigner.sign(t, validClaims(expiry), nil),
+		},
+		{
+			name:  "expired token",
+			token: signer.sign(t, validClaims(time.Now().Add(-time.Hour)), nil),
+		},
+		{
+			name:  "token of another audience",
+			token: signer.sign(t, withClaims(func(c *jwt.Claims) { c.Audience = jwt.Audience{"api"} }), nil),
+		},
+		{
+			name:  "token of another issuer",
+			token: signer.sign(t, withClaims(func(c *jwt.Claims) { c.Issuer = "https://other" }), nil),
+		},
+		{
+			name:  "token without expiration",
+			token: signer.sign(t, withClaims(func(c *jwt.Claims) { c.Expiry = nil }), nil),
+		},
+		{
+			name:  "token of a user",
+			token: signer.sign(t, withClaims(func(c *jwt.Claims) { c.Subject = "alice" }), nil),
+		},
+		{
+			name:  "token not bound to a pod",
+			token: signer.sign(t, validClaims(expiry), func(c *serviceAccountClaims) { c.Kubernetes.Pod.UID = "" }),
+		},
+		{
+			name:  "token bound to another service account",
+			token: signer.sign(t, validClaims(expiry), func(c *serviceAccountClaims) { c.Kubernetes.ServiceAccount.Name = "other" }),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			authenticator := NewJWKSAuthenticator(testIssuer, "osm-controller", func(context.Context) (*jose.JSONWebKeySet, error) {
+				return &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{signer.publicKey()}}, nil
+			})
+
+			id, err := authenticator.Authenticate(context.Background(), tc.token)
+			if tc.expectedIdentity == nil {
+				assert.ErrorIs(err, ErrUnauthenticated)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedIdentity, id)
+		})
+	}
+}
+
+func TestJWKSKeyRotation(t *testing.T) {
+	assert := tassert.New(t)
+	oldSigner := newTestSigner(t, "old")
+	newSigner := newTestSigner(t, "new")
+	expiry := time.Now().Add(time.Hour)
+
+	fetches := 0
+	keySet := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{oldSigner.publicKey()}}
+	var fetchErr error
+	a := NewJWKSAuthenticator(testIssuer, "osm-controller", func(context.Context) (*jose.JSONWebKeySet, error) {
+		fetches++
+		return keySet, fetchErr
+	}).(*jwksAuthenticator)
+
+	// The keys are fetched on the first authentication
+	_, err := a.Authenticate(context.Background(), oldSigner.sign(t, validClaims(expiry), nil))
+	assert.NoError(err)
+	_, err = a.Authenticate(context.Background(), oldSigner.sign(t, validClaims(expiry), nil))
+	assert.NoError(err)
+	assert.Equal(1, fetches)
+
+	// The keys were just fetched, the unknown key is not refetched
+	keySet = &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{oldSigner.publicKey(), newSigner.publicKey()}}
+	_, err = a.Authenticate(context.Background(), newSigner.sign(t, validClaims(expiry), nil))
+	assert.ErrorIs(err, ErrUnauthenticated)
+	assert.Equal(1, fetches)
+
+	// The unknown key is refetched once the refresh interval elapsed
+	a.fetchedAt = time.Now().Add(-minKeySetRefreshInterval)
+	_, err = a.Authenticate(context.Background(), newSigner.sign(t, validClaims(expiry), nil))
+	assert.NoError(err)
+	assert.Equal(2, fetches)
+
+	// Errors fetching the keys are not authentication errors
+	a.fetchedAt = time.Now().Add(-minKeySetRefreshInterval)
+	fetchErr = errors.New("forbidden")
+	_, err = a.Authenticate(context.Background(), newTestSigner(t, "unknown").sign(t, validClaims(expiry), nil))
+	assert.Error(err)
+	assert.NotErrorIs(err, ErrUnauthenticated)
+}
@@ -0,0 +1,108 @@
+package podidentity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/square/go-jose.v2/jwt"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+// tokenReviewAuthenticator authenticates pods by reviewing their tokens with the Kubernetes API server.
+type tokenReviewAuthenticator struct {
+	kubeClient kubernetes.Interface
+	audience   string
+}
+
+// NewTokenReviewAuthenticator returns an Authenticator reviewing the tokens with the TokenReview API of the
+// Kubernetes API server, which requires the tokens to be issued for the given audience.
+func NewTokenReviewAuthenticator(kubeClient kubernetes.Interface, audience string) Authenticator {
+	return &tokenReviewAuthenticator{
+		kubeClient: kubeClient,
+		audience:   audience,
+	}
+}
+
+// Authenticate returns the identity of the pod the given token is bound to.
+func (a *tokenReviewAuthenticator) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, fmt.Errorf("%w: empty token", ErrUnauthenticated)
+	}
+
+	review, err := a.kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     token,
+			Audiences: []string{a.audience},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reviewing token: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		return nil, fmt.Errorf("%w: %s", ErrUnauthenticated, review.Status.Error)
+	}
+	if !contains(review.Status.Audiences, a.audience) {
+		return nil, fmt.Errorf("%w: token audiences %v don't include %s", ErrUnauthenticated, review.Status.Audiences, a.audience)
+	}
+
+	id, err := identityFromUser(review.Status.User.Username, review.Status.User.Extra[podNameExtraKey], review.Status.User.Extra[podUIDExtraKey])
+	if err != nil {
+		return nil, err
+	}
+	id.Expiration = tokenExpiration(token)
+	return id, nil
+}
+
+// tokenExpiration returns the expiration time of the given token, reviewed by the API server, or the zero time if it
+// can't be read from the token
+func tokenExpiration(token string) time.Time {
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return time.Time{}
+	}
+	var claims jwt.Claims
+	if err := parsed.UnsafeClaimsWithoutVerification(&claims); err != nil || claims.Expiry == nil {
+		return time.Time{}
+	}
+	return claims.Expiry.Time()
+}
+
+// identityFromUser returns the identity of the pod of the given service account user
+func identityFromUser(username string, podNames, podUIDs []string) (*Identity, error) {
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) {
+		return nil, fmt.Errorf("%w: %s is not a service account", ErrUnauthenticated, username)
+	}
+	chunks := strings.Split(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if len(chunks) != 2 || chunks[0] == "" || chunks[1] == "" {
+		return nil, fmt.Errorf("%w: %s is not a service account", ErrUnauthenticated, username)
+	}
+	if len(podNames) != 1 || len(podUIDs) != 1 || podNames[0] == "" || podUIDs[0] == "" {
+		return nil, fmt.Errorf("%w: token of %s is not bound to a pod", ErrUnauthenticated, username)
+	}
+
+	return &Identity{
+		ServiceAccount: identity.K8sServiceAccount{
+			Namespace: chunks[0],
+			Name:      chunks[1],
+		},
+		PodName: podNames[0],
+		PodUID:  types.UID(podUIDs[0]),
+	}, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package podidentity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tassert "github.com/stretchr/testify/assert"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestTokenReviewAuthenticate(t *testing.T) {
+	testCases := []struct {
+		name             string
+		token            string
+		status           authenticationv1.TokenReviewStatus
+		reviewErr        error
+		expectedIdentity *Identity
+		expectedErr      error
+	}{
+		{
+			name:  "token bound to a pod",
+			token: "token",
+			status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				Audiences:     []string{"osm-controller"},
+				User: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:ns:sa",
+					Extra: map[string]authenticationv1.ExtraValue{
+						podNameExtraKey: {"pod"},
+						podUIDExtraKey:  {"uid"},
+					},
+				},
+			},
+			expectedIdentity: &Identity{
+				ServiceAccount: identity.K8sServiceAccount{Namespace: "ns", Name: "sa"},
+				PodName:        "pod",
+				PodUID:         "uid",
+			},
+		},
+		{
+			name:        "empty token",
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:  "invalid token",
+			token: "token",
+			status: authenticationv1.TokenReviewStatus{
+				Authenticated: false,
+				Error:         "invalid bearer token",
+			},
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:  "token of another audience",
+			token: "token",
+			status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				Audiences:     []string{"api"},
+				User: authenticationv1.UserInfo{
+					Username: "system:serviceaccount:ns:sa",
+					Extra: map[string]authenticationv1.ExtraValue{
+						podNameExtraKey: {"pod"},
+						podUIDExtraKey:  {"uid"},
+					},
+				},
+			},
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:  "token of a user",
+			token: "token",
+			status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				Audiences:     []string{"osm-controller"},
+				User:          authenticationv1.UserInfo{Username: "alice"},
+			},
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:  "token not bound to a pod",
+			token: "token",
+			status: authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				Audiences:     []string{"osm-controller"},
+				User:          authenticationv1.UserInfo{Username: "system:serviceaccount:ns:sa"},
+			},
+			expectedErr: ErrUnauthenticated,
+		},
+		{
+			name:      "token review error",
+			token:     "token",
+			reviewErr: errors.New("forbidden"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				assert.Equal(tc.token, review.Spec.Token)
+				assert.Equal([]string{"osm-controller"}, review.Spec.Audiences)
+				if tc.reviewErr != nil {
+					return true, nil, tc.reviewErr
+				}
+				review.Status = tc.status
+				return true, review, nil
+			})
+
+			id, err := NewTokenReviewAuthenticator(kubeClient, "osm-controller").Authenticate(context.Background(), tc.token)
+			if tc.expectedIdentity == nil {
+				assert.Error(err)
+				if tc.expectedErr != nil {
+					assert.ErrorIs(err, tc.expectedErr)
+				}
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tc.expectedIdentity, id)
+		})
+	}
+}
+
+func TestTokenExpiration(t *testing.T) {
+	assert := tassert.New(t)
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	assert.Equal(expiry, tokenExpiration(newTestSigner(t, "key").sign(t, validClaims(expiry), nil)))
+	assert.True(tokenExpiration("token").IsZero())
+}
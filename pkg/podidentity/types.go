@@ -0,0 +1,67 @@
+// Package podidentity implements the authentication of pods to the control plane with the service account tokens
+// projected in them. The tokens are bound to the audience of the control plane, and to the pods they are projected in,
+// so that a pod is identified by its service account, name and UID.
+package podidentity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+const (
+	// serviceAccountUsernamePrefix is the prefix of the usernames of service accounts, of the form
+	// system:serviceaccount:<namespace>:<name>
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+	// podNameExtraKey is the key of the extra of the authenticated user holding the name of the pod the token is bound to
+	podNameExtraKey = "authentication.kubernetes.io/pod-name"
+
+	// podUIDExtraKey is the key of the extra of the authenticated user holding the UID of the pod the token is bound to
+	podUIDExtraKey = "authentication.kubernetes.io/pod-uid"
+)
+
+// ErrUnauthenticated is the error for a token that doesn't authenticate a pod
+var ErrUnauthenticated = errors.New("token does not authenticate a pod")
+
+// Identity is the identity of an authenticated pod.
+type Identity struct {
+	// ServiceAccount is the service account of the pod
+	ServiceAccount identity.K8sServiceAccount
+
+	// PodName is the name of the pod, in the namespace of its service account
+	PodName string
+
+	// PodUID is the UID of the pod
+	PodUID types.UID
+
+	// Expiration is the expiration time of the token, zero if it's unknown
+	Expiration time.Time
+}
+
+// VerifyPod checks that the given pod, found by the name and namespace of the identity, is the pod the token was bound
+// to. A pod recreated with the same name has another UID, which rejects the tokens of the deleted pod.
+func (id *Identity) VerifyPod(pod *corev1.Pod) error {
+	switch {
+	case pod == nil:
+		return fmt.Errorf("pod %s/%s not found in the monitored namespaces", id.ServiceAccount.Namespace, id.PodName)
+	case pod.UID != id.PodUID:
+		return fmt.Errorf("pod %s/%s has UID %s, the token was issued for UID %s", pod.Namespace, pod.Name, pod.UID, id.PodUID)
+	case pod.Spec.ServiceAccountName != id.ServiceAccount.Name:
+		return fmt.Errorf("pod %s/%s runs as service account %s, the token was issued for %s", pod.Namespace, pod.Name, pod.Spec.ServiceAccountName, id.ServiceAccount)
+	}
+	return nil
+}
+
+// Authenticator authenticates pods with the service account tokens projected in them.
+type Authenticator interface {
+	// Authenticate returns the identity of the pod the given token is bound to. ErrUnauthenticated is returned if the
+	// token is invalid, has another audience, or isn't bound to a pod.
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+}
@@ -0,0 +1,62 @@
+package podidentity
+
+import (
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openservicemesh/osm/pkg/identity"
+)
+
+func TestVerifyPod(t *testing.T) {
+	id := &Identity{
+		ServiceAccount: identity.K8sServiceAccount{Namespace: "ns", Name: "sa"},
+		PodName:        "pod",
+		PodUID:         "uid",
+	}
+	newPod := func(uid, serviceAccount string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", UID: types.UID(uid)},
+			Spec:       corev1.PodSpec{ServiceAccountName: serviceAccount},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		pod         *corev1.Pod
+		expectedErr bool
+	}{
+		{
+			name: "pod the token was bound to",
+			pod:  newPod("uid", "sa"),
+		},
+		{
+			name:        "pod not found",
+			expectedErr: true,
+		},
+		{
+			name:        "pod recreated with the same name",
+			pod:         newPod("other", "sa"),
+			expectedErr: true,
+		},
+		{
+			name:        "pod running as another service account",
+			pod:         newPod("uid", "other"),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := id.VerifyPod(tc.pod)
+			if tc.expectedErr {
+				tassert.Error(t, err)
+			} else {
+				tassert.NoError(t, err)
+			}
+		})
+	}
+}
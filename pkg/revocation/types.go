@@ -0,0 +1,55 @@
+// Package revocation implements the revocation of the certificates issued to the workloads of the mesh, such as
+// certificates whose private keys are compromised.
+//
+// Certificates are revoked with CertificateRevocation resources in the control plane namespace, listing the serial
+// numbers of the revoked certificates, or the service identities whose certificates issued before the creation of
+// the resource are revoked. The revoked certificates are reissued, and the proxies are sent the revocation lists of
+// the issuers to reject them. The serial numbers of the certificates of the revoked identities are recorded in the
+// status of the resource, so that the certificates remain revoked once reissued, and across restarts of the
+// control plane. A revocation the proxies can't enforce is not applied, and reported with the Accepted condition of
+// the resource.
+package revocation
+
+import (
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/logger"
+)
+
+const (
+	// revocationIDPrefix is the prefix of the IDs of the revocations added to the certificate manager
+	revocationIDPrefix = "revocation/"
+
+	// reasonAccepted is the reason of the Accepted condition of an enforced revocation
+	reasonAccepted = "Accepted"
+
+	// reasonNotSupported is the reason of the Accepted condition of a revocation that can't be enforced
+	reasonNotSupported = "RevocationListsNotSupported"
+)
+
+var (
+	log = logger.New("revocation")
+)
+
+// RevocationManager manages the certificates revoked in the mesh.
+// It is implemented by certificate.Manager.
+type RevocationManager interface {
+	// AddRevocation adds or replaces the revocation with the given ID, and returns the serial numbers of the
+	// certificates of the revoked identities. An error is returned if the revocation can't be enforced.
+	AddRevocation(id string, r certificate.Revocation) ([]certificate.SerialNumber, error)
+
+	// RemoveRevocation removes the revocation with the given ID
+	RemoveRevocation(id string)
+}
+
+// watcher keeps the revocations of the RevocationManager in sync with the CertificateRevocation resources
+type watcher struct {
+	kubeController    k8s.Controller
+	configClient      configClientset.Interface
+	revocationManager RevocationManager
+
+	// the IDs of the revocations currently added to the RevocationManager
+	revocationIDs map[string]struct{}
+}
@@ -0,0 +1,131 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	configClientset "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+	"github.com/openservicemesh/osm/pkg/k8s/events"
+	"github.com/openservicemesh/osm/pkg/messaging"
+)
+
+// WatchCertificateRevocations adds the revocations of the CertificateRevocation resources to the given
+// RevocationManager, and keeps them in sync as the resources change.
+// It blocks until the stop channel is closed.
+func WatchCertificateRevocations(kubeController k8s.Controller, configClient configClientset.Interface,
+	revocationManager RevocationManager, msgBroker *messaging.Broker, stop <-chan struct{}) {
+	w := &watcher{
+		kubeController:    kubeController,
+		configClient:      configClient,
+		revocationManager: revocationManager,
+		revocationIDs:     make(map[string]struct{}),
+	}
+
+	revocationChan, unsub := msgBroker.SubscribeKubeEvents(
+		events.CertificateRevocation.Added(),
+		events.CertificateRevocation.Updated(),
+		events.CertificateRevocation.Deleted(),
+	)
+	defer unsub()
+
+	w.syncCertificateRevocations()
+
+	for {
+		select {
+		case <-stop:
+			log.Info().Msg("Received stop signal, exiting certificate revocation watch routine")
+			return
+
+		case <-revocationChan:
+			w.syncCertificateRevocations()
+		}
+	}
+}
+
+// syncCertificateRevocations adds the revocations of the current CertificateRevocation resources to the
+// RevocationManager, and removes the revocations of the deleted ones.
+func (w *watcher) syncCertificateRevocations() {
+	current := make(map[string]struct{})
+	for _, cr := range w.kubeController.ListCertificateRevocations() {
+		id := revocationIDPrefix + cr.Namespace + "/" + cr.Name
+		identitySerialNumbers, err := w.revocationManager.AddRevocation(id, getRevocation(cr))
+		if err != nil {
+			log.Error().Err(err).Msgf("Certificate revocation %s/%s is not applied", cr.Namespace, cr.Name)
+		} else {
+			current[id] = struct{}{}
+		}
+		w.updateStatus(cr, identitySerialNumbers, err)
+	}
+
+	for id := range w.revocationIDs {
+		if _, ok := current[id]; !ok {
+			w.revocationManager.RemoveRevocation(id)
+		}
+	}
+	w.revocationIDs = current
+}
+
+// getRevocation returns the certificates revoked by the given CertificateRevocation, including the certificates
+// of the revoked identities recorded in its status
+func getRevocation(cr *configv1alpha2.CertificateRevocation) certificate.Revocation {
+	r := certificate.Revocation{
+		RevokedAt: cr.CreationTimestamp.Time,
+	}
+	for _, serialNumber := range cr.Spec.SerialNumbers {
+		r.SerialNumbers = append(r.SerialNumbers, certificate.SerialNumber(serialNumber))
+	}
+	for _, serialNumber := range cr.Status.RevokedSerialNumbers {
+		r.SerialNumbers = append(r.SerialNumbers, certificate.SerialNumber(serialNumber))
+	}
+	for _, si := range cr.Spec.Identities {
+		r.Identities = append(r.Identities, identity.ServiceIdentity(si))
+	}
+	return r
+}
+
+// updateStatus records the serial numbers of the certificates of the revoked identities missing from the status of
+// the given CertificateRevocation, and whether the revocation was accepted given the error adding it
+func (w *watcher) updateStatus(cr *configv1alpha2.CertificateRevocation, identitySerialNumbers []certificate.SerialNumber, err error) {
+	recorded := make(map[string]struct{}, len(cr.Status.RevokedSerialNumbers))
+	for _, serialNumber := range cr.Status.RevokedSerialNumbers {
+		recorded[serialNumber] = struct{}{}
+	}
+
+	updated := cr.DeepCopy()
+	for _, serialNumber := range identitySerialNumbers {
+		if _, ok := recorded[serialNumber.String()]; !ok {
+			updated.Status.RevokedSerialNumbers = append(updated.Status.RevokedSerialNumbers, serialNumber.String())
+		}
+	}
+
+	accepted := metav1.Condition{
+		Type:               configv1alpha2.CertificateRevocationConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cr.Generation,
+		Reason:             reasonAccepted,
+		Message:            "The revoked certificates are reissued and rejected by the proxies",
+	}
+	if err != nil {
+		accepted.Status = metav1.ConditionFalse
+		accepted.Reason = reasonNotSupported
+		accepted.Message = fmt.Sprintf("The revoked certificates can't be rejected by the proxies: %s", err)
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, accepted)
+
+	if equality.Semantic.DeepEqual(updated.Status, cr.Status) {
+		return
+	}
+
+	if _, err := w.configClient.ConfigV1alpha2().CertificateRevocations(cr.Namespace).UpdateStatus(context.Background(), updated, metav1.UpdateOptions{}); err != nil {
+		log.Error().Err(err).Msgf("Error updating the status of certificate revocation %s/%s", cr.Namespace, cr.Name)
+	}
+}
@@ -0,0 +1,148 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	tassert "github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha2 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha2"
+	configFake "github.com/openservicemesh/osm/pkg/gen/client/config/clientset/versioned/fake"
+
+	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/identity"
+	"github.com/openservicemesh/osm/pkg/k8s"
+)
+
+// fakeRevocationManager records the revocations added by the watcher, and finds the given serial numbers for the
+// revoked identities. Revocations are rejected with the given error.
+type fakeRevocationManager struct {
+	revocations           map[string]certificate.Revocation
+	identitySerialNumbers []certificate.SerialNumber
+	err                   error
+}
+
+func (m *fakeRevocationManager) AddRevocation(id string, r certificate.Revocation) ([]certificate.SerialNumber, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.revocations[id] = r
+	if len(r.Identities) == 0 {
+		return nil, nil
+	}
+	return m.identitySerialNumbers, nil
+}
+
+func (m *fakeRevocationManager) RemoveRevocation(id string) {
+	delete(m.revocations, id)
+}
+
+func TestSyncCertificateRevocations(t *testing.T) {
+	createdAt := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	testCases := []struct {
+		name                  string
+		revocation            *configv1alpha2.CertificateRevocation
+		identitySerialNumbers []certificate.SerialNumber
+		err                   error
+		expectedRevocations   map[string]certificate.Revocation
+		expectedStatus        []string
+		expectedAccepted      metav1.ConditionStatus
+	}{
+		{
+			name: "serial numbers",
+			revocation: &configv1alpha2.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "compromised", Namespace: "osm-system", CreationTimestamp: createdAt},
+				Spec:       configv1alpha2.CertificateRevocationSpec{SerialNumbers: []string{"1", "2"}},
+			},
+			expectedRevocations: map[string]certificate.Revocation{"revocation/osm-system/compromised": {
+				SerialNumbers: []certificate.SerialNumber{"1", "2"},
+				RevokedAt:     createdAt.Time,
+			}},
+			expectedAccepted: metav1.ConditionTrue,
+		},
+		{
+			name: "identities",
+			revocation: &configv1alpha2.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "compromised", Namespace: "osm-system", CreationTimestamp: createdAt},
+				Spec:       configv1alpha2.CertificateRevocationSpec{Identities: []string{"bookbuyer.bookbuyer"}},
+			},
+			identitySerialNumbers: []certificate.SerialNumber{"3", "4"},
+			expectedRevocations: map[string]certificate.Revocation{"revocation/osm-system/compromised": {
+				Identities: []identity.ServiceIdentity{"bookbuyer.bookbuyer"},
+				RevokedAt:  createdAt.Time,
+			}},
+			expectedStatus:   []string{"3", "4"},
+			expectedAccepted: metav1.ConditionTrue,
+		},
+		{
+			name: "identities with serial numbers recorded in the status",
+			revocation: &configv1alpha2.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "compromised", Namespace: "osm-system", CreationTimestamp: createdAt},
+				Spec:       configv1alpha2.CertificateRevocationSpec{Identities: []string{"bookbuyer.bookbuyer"}},
+				Status:     configv1alpha2.CertificateRevocationStatus{RevokedSerialNumbers: []string{"3"}},
+			},
+			identitySerialNumbers: []certificate.SerialNumber{"3", "4"},
+			expectedRevocations: map[string]certificate.Revocation{"revocation/osm-system/compromised": {
+				SerialNumbers: []certificate.SerialNumber{"3"},
+				Identities:    []identity.ServiceIdentity{"bookbuyer.bookbuyer"},
+				RevokedAt:     createdAt.Time,
+			}},
+			expectedStatus:   []string{"3", "4"},
+			expectedAccepted: metav1.ConditionTrue,
+		},
+		{
+			name: "revocation lists not supported",
+			revocation: &configv1alpha2.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Name: "compromised", Namespace: "osm-system", CreationTimestamp: createdAt},
+				Spec:       configv1alpha2.CertificateRevocationSpec{SerialNumbers: []string{"1"}},
+			},
+			err:                 certificate.ErrRevocationListsNotSupported,
+			expectedRevocations: map[string]certificate.Revocation{},
+			expectedAccepted:    metav1.ConditionFalse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+			mockController := k8s.NewMockController(gomock.NewController(t))
+			configClient := configFake.NewSimpleClientset(tc.revocation)
+
+			revocationManager := &fakeRevocationManager{
+				revocations:           make(map[string]certificate.Revocation),
+				identitySerialNumbers: tc.identitySerialNumbers,
+				err:                   tc.err,
+			}
+			w := &watcher{
+				kubeController:    mockController,
+				configClient:      configClient,
+				revocationManager: revocationManager,
+				revocationIDs:     make(map[string]struct{}),
+			}
+
+			mockController.EXPECT().ListCertificateRevocations().Return([]*configv1alpha2.CertificateRevocation{tc.revocation})
+			w.syncCertificateRevocations()
+			assert.Equal(tc.expectedRevocations, revocationManager.revocations)
+
+			// the serial numbers of the certificates of the revoked identities and whether the revocation is
+			// accepted are recorded in the status
+			cr, err := configClient.ConfigV1alpha2().CertificateRevocations("osm-system").Get(context.Background(), "compromised", metav1.GetOptions{})
+			assert.NoError(err)
+			assert.Equal(tc.expectedStatus, cr.Status.RevokedSerialNumbers)
+			accepted := meta.FindStatusCondition(cr.Status.Conditions, configv1alpha2.CertificateRevocationConditionAccepted)
+			if assert.NotNil(accepted) {
+				assert.Equal(tc.expectedAccepted, accepted.Status)
+			}
+
+			// the revocation is removed with the resource
+			mockController.EXPECT().ListCertificateRevocations().Return(nil)
+			w.syncCertificateRevocations()
+			assert.Empty(revocationManager.revocations)
+		})
+	}
+}
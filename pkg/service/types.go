@@ -67,6 +67,12 @@ func (ms MeshService) EnvoyLocalClusterName() string {
 	return fmt.Sprintf("%s|local", ms.EnvoyClusterName())
 }
 
+// EnvoyHTTPClusterName is the name of the cluster HTTP traffic is routed to when the MeshService's
+// protocol is detected automatically. It shares the endpoints of the cluster named by EnvoyClusterName().
+func (ms MeshService) EnvoyHTTPClusterName() string {
+	return fmt.Sprintf("%s|http", ms.EnvoyClusterName())
+}
+
 // FQDN is similar to String(), but uses a dot separator and is in a different order.
 func (ms MeshService) FQDN() string {
 	if ms.Subdomain != "" {
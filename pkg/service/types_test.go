@@ -44,6 +44,7 @@ func TestMeshServiceCluster(t *testing.T) {
 		meshSvc                  MeshService
 		expectedClusterName      string
 		expectedLocalClusterName string
+		expectedHTTPClusterName  string
 	}{
 		{
 			name: "envoy cluster and local cluster name",
@@ -55,6 +56,7 @@ func TestMeshServiceCluster(t *testing.T) {
 			},
 			expectedClusterName:      "ns1/s1|90",
 			expectedLocalClusterName: "ns1/s1|90|local",
+			expectedHTTPClusterName:  "ns1/s1|90|http",
 		},
 		{
 			name: "envoy cluster and local cluster name with subdomain",
@@ -67,6 +69,7 @@ func TestMeshServiceCluster(t *testing.T) {
 			},
 			expectedClusterName:      "ns1/pod-0.s1|90",
 			expectedLocalClusterName: "ns1/pod-0.s1|90|local",
+			expectedHTTPClusterName:  "ns1/pod-0.s1|90|http",
 		},
 	}
 
@@ -75,6 +78,7 @@ func TestMeshServiceCluster(t *testing.T) {
 			assert := tassert.New(t)
 			assert.Equal(tc.expectedClusterName, tc.meshSvc.EnvoyClusterName())
 			assert.Equal(tc.expectedLocalClusterName, tc.meshSvc.EnvoyLocalClusterName())
+			assert.Equal(tc.expectedHTTPClusterName, tc.meshSvc.EnvoyHTTPClusterName())
 		})
 	}
 }
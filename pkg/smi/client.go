@@ -20,6 +20,11 @@ const (
 	// ServiceAccountKind is the kind specified for the destination and sources in an SMI TrafficTarget policy
 	ServiceAccountKind = "ServiceAccount"
 
+	// SPIFFEIDKind is the kind specified for the sources in an SMI TrafficTarget policy that identify a
+	// service account of a federated trust domain. The name of the source is the SPIFFE ID of the service
+	// account, of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>.
+	SPIFFEIDKind = "SPIFFEID"
+
 	// TCPRouteKind is the kind specified for the TCP route rules in an SMI Traffictarget policy
 	TCPRouteKind = "TCPRoute"
 
@@ -176,7 +181,7 @@ func (c *Client) ListTrafficTargets(options ...TrafficTargetListOption) []*smiAc
 			continue
 		}
 
-		if !isValidTrafficTarget(trafficTarget) {
+		if !IsValidTrafficTarget(trafficTarget) {
 			continue
 		}
 
@@ -188,7 +193,9 @@ func (c *Client) ListTrafficTargets(options ...TrafficTargetListOption) []*smiAc
 	return trafficTargets
 }
 
-func isValidTrafficTarget(trafficTarget *smiAccess.TrafficTarget) bool {
+// IsValidTrafficTarget returns whether the given TrafficTarget is in the namespace of its destination, and has valid
+// rules
+func IsValidTrafficTarget(trafficTarget *smiAccess.TrafficTarget) bool {
 	// destination namespace must be same as traffic target namespace
 	if trafficTarget.Namespace != trafficTarget.Spec.Destination.Namespace {
 		return false
@@ -248,11 +255,15 @@ func (c *Client) ListServiceAccounts() []identity.K8sServiceAccount {
 			continue
 		}
 
-		if !isValidTrafficTarget(trafficTarget) {
+		if !IsValidTrafficTarget(trafficTarget) {
 			continue
 		}
 
 		for _, sources := range trafficTarget.Spec.Sources {
+			// Service accounts of federated trust domains do not belong to the cluster
+			if sources.Kind == SPIFFEIDKind {
+				continue
+			}
 			// Only monitor sources in namespaces OSM is observing
 			if !c.kubeController.IsMonitoredNamespace(sources.Namespace) {
 				// Doesn't belong to namespaces we are observing
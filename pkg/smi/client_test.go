@@ -576,7 +576,7 @@ func TestIsValidTrafficTarget(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			a := assert.New(t)
-			result := isValidTrafficTarget(tc.trafficTarget)
+			result := IsValidTrafficTarget(tc.trafficTarget)
 			a.Equal(tc.expectedResult, result)
 		})
 	}
@@ -201,3 +201,47 @@ type TrafficMatch struct {
 	// +optional
 	RateLimit *policyv1alpha1.RateLimitSpec
 }
+
+// HTTPFilter is the type used to represent a user-defined HTTP filter resolved from an HTTPFilterExtension
+type HTTPFilter struct {
+	// Name defines the name of the filter, qualified by the namespace and name of its HTTPFilterExtension
+	Name string
+
+	// Type defines the type of the filter
+	Type policyv1alpha1.HTTPFilterType
+
+	// WASMModule defines the WASM module of a WASM filter
+	// +optional
+	WASMModule []byte
+
+	// WASMRootID defines the root ID of a WASM filter
+	// +optional
+	WASMRootID string
+
+	// WASMConfiguration defines the configuration passed to a WASM filter
+	// +optional
+	WASMConfiguration string
+
+	// LuaInlineCode defines the script of a Lua filter
+	// +optional
+	LuaInlineCode string
+}
+
+// HTTPFilters is the type used to represent the user-defined HTTP filters of a traffic direction, ordered relative
+// to the HTTP filters built by OSM
+type HTTPFilters struct {
+	// First defines the filters added before the HTTP filters built by OSM
+	First []HTTPFilter
+
+	// Last defines the filters added after the HTTP filters built by OSM, before the router filter
+	Last []HTTPFilter
+}
+
+// HTTPFilterExtensions is the type used to represent the user-defined HTTP filters of a proxy
+type HTTPFilterExtensions struct {
+	// Inbound defines the filters added to the inbound HTTP filter chains
+	Inbound HTTPFilters
+
+	// Outbound defines the filters added to the outbound HTTP filter chains
+	Outbound HTTPFilters
+}
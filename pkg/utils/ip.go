@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+)
+
+const (
+	// ipv4HostPrefixLen is the prefix length of a CIDR range matching a single IPv4 address
+	ipv4HostPrefixLen = 32
+
+	// ipv6HostPrefixLen is the prefix length of a CIDR range matching a single IPv6 address
+	ipv6HostPrefixLen = 128
+)
+
+// GetHostCIDR returns the CIDR range matching only the given IP address, with a /32 prefix for an IPv4 address and a
+// /128 prefix for an IPv6 address
+func GetHostCIDR(ip net.IP) string {
+	if ip.To4() != nil {
+		return fmt.Sprintf("%s/%d", ip, ipv4HostPrefixLen)
+	}
+	return fmt.Sprintf("%s/%d", ip, ipv6HostPrefixLen)
+}
+
+// IsHostPrefixLen returns whether the given prefix length of a CIDR range of the given IP address matches a single
+// IP address
+func IsHostPrefixLen(ip net.IP, prefixLen uint32) bool {
+	if ip.To4() != nil {
+		return prefixLen == ipv4HostPrefixLen
+	}
+	return prefixLen == ipv6HostPrefixLen
+}
+
+// GetIPFamilies returns whether the given IP addresses include an IPv4 address and an IPv6 address. IPv4 is assumed
+// when no IP address is given.
+func GetIPFamilies(ips []net.IP) (ipv4 bool, ipv6 bool) {
+	if len(ips) == 0 {
+		return true, false
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			ipv4 = true
+		} else if ip.To16() != nil {
+			ipv6 = true
+		}
+	}
+	return ipv4, ipv6
+}
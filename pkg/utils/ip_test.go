@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"net"
+	"testing"
+
+	tassert "github.com/stretchr/testify/assert"
+)
+
+func TestGetHostCIDR(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.Equal("10.0.0.1/32", GetHostCIDR(net.ParseIP("10.0.0.1")))
+	assert.Equal("fd00::1/128", GetHostCIDR(net.ParseIP("fd00::1")))
+	assert.Equal("::1/128", GetHostCIDR(net.ParseIP("::1")))
+}
+
+func TestIsHostPrefixLen(t *testing.T) {
+	assert := tassert.New(t)
+
+	assert.True(IsHostPrefixLen(net.ParseIP("10.0.0.1"), 32))
+	assert.False(IsHostPrefixLen(net.ParseIP("10.0.0.0"), 24))
+	assert.True(IsHostPrefixLen(net.ParseIP("fd00::1"), 128))
+	assert.False(IsHostPrefixLen(net.ParseIP("fd00::1"), 32))
+}
+
+func TestGetIPFamilies(t *testing.T) {
+	testCases := []struct {
+		name         string
+		ips          []net.IP
+		expectedIPv4 bool
+		expectedIPv6 bool
+	}{
+		{
+			name:         "no IP defaults to IPv4",
+			expectedIPv4: true,
+		},
+		{
+			name:         "IPv4",
+			ips:          []net.IP{net.ParseIP("10.0.0.1")},
+			expectedIPv4: true,
+		},
+		{
+			name:         "IPv6",
+			ips:          []net.IP{net.ParseIP("fd00::1")},
+			expectedIPv6: true,
+		},
+		{
+			name:         "dual-stack",
+			ips:          []net.IP{net.ParseIP("fd00::1"), net.ParseIP("10.0.0.1")},
+			expectedIPv4: true,
+			expectedIPv6: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			ipv4, ipv6 := GetIPFamilies(tc.ips)
+			assert.Equal(tc.expectedIPv4, ipv4)
+			assert.Equal(tc.expectedIPv6, ipv6)
+		})
+	}
+}
@@ -130,6 +130,58 @@ func GetCertKeyBitSize(mc v1alpha2.MeshConfig) int {
 	return bitSize
 }
 
+// GetCertKeyAlgorithm returns the algorithm to be used to generate the private keys of certificates
+func GetCertKeyAlgorithm(mc v1alpha2.MeshConfig) v1alpha2.KeyAlgorithm {
+	switch algorithm := mc.Spec.Certificate.KeyAlgorithm; algorithm {
+	case "":
+		return v1alpha2.KeyAlgorithmRSA
+	case v1alpha2.KeyAlgorithmRSA, v1alpha2.KeyAlgorithmECDSAP256, v1alpha2.KeyAlgorithmECDSAP384:
+		return algorithm
+	default:
+		log.Error().Msgf("Invalid key algorithm: %s", algorithm)
+		return v1alpha2.KeyAlgorithmRSA
+	}
+}
+
+// GetIdentityMatchMode returns how the identities of workloads are matched in their certificates
+func GetIdentityMatchMode(mc v1alpha2.MeshConfig) v1alpha2.IdentityMatchMode {
+	switch mode := mc.Spec.Certificate.IdentityMatchMode; mode {
+	case "":
+		return v1alpha2.IdentityMatchModeCompat
+	case v1alpha2.IdentityMatchModeCompat, v1alpha2.IdentityMatchModeSPIFFE:
+		return mode
+	default:
+		log.Error().Msgf("Invalid identity match mode: %s", mode)
+		return v1alpha2.IdentityMatchModeCompat
+	}
+}
+
+// GetKeyGenerationMode returns where the private keys of the Envoy sidecars injected are generated
+func GetKeyGenerationMode(mc v1alpha2.MeshConfig) v1alpha2.KeyGenerationMode {
+	switch mode := mc.Spec.Certificate.KeyGeneration; mode {
+	case "":
+		return v1alpha2.KeyGenerationModeController
+	case v1alpha2.KeyGenerationModeController, v1alpha2.KeyGenerationModeWorkload:
+		return mode
+	default:
+		log.Error().Msgf("Invalid key generation mode: %s", mode)
+		return v1alpha2.KeyGenerationModeController
+	}
+}
+
+// GetXDSAuthenticationMode returns how the Envoy sidecars injected authenticate to the xDS server
+func GetXDSAuthenticationMode(mc v1alpha2.MeshConfig) v1alpha2.XDSAuthenticationMode {
+	switch mode := mc.Spec.Sidecar.XDSAuthentication; mode {
+	case "":
+		return v1alpha2.XDSAuthenticationModeCertificate
+	case v1alpha2.XDSAuthenticationModeCertificate, v1alpha2.XDSAuthenticationModeServiceAccountToken:
+		return mode
+	default:
+		log.Error().Msgf("Invalid xDS authentication mode: %s", mode)
+		return v1alpha2.XDSAuthenticationModeCertificate
+	}
+}
+
 // ExternalAuthConfigFromMeshConfig returns the External Authentication configuration for incoming traffic, if any
 func ExternalAuthConfigFromMeshConfig(mc v1alpha2.MeshConfig) auth.ExtAuthConfig {
 	extAuthConfig := auth.ExtAuthConfig{}
@@ -34,7 +34,7 @@ func createOrUpdateValidatingWebhook(clientSet kubernetes.Interface, cert *certi
 			Rule: admissionregv1.Rule{
 				APIGroups:   []string{"policy.openservicemesh.io"},
 				APIVersions: []string{"v1alpha1"},
-				Resources:   []string{"ingressbackends", "egresses"},
+				Resources:   []string{"ingressbackends", "egresses", "httpfilterextensions"},
 			},
 		},
 	}
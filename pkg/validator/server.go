@@ -42,6 +42,7 @@ func NewValidatingWebhook(ctx context.Context, webhookConfigName, osmNamespace,
 			policyv1alpha1.SchemeGroupVersion.WithKind("IngressBackend").String():         kv.ingressBackendValidator,
 			policyv1alpha1.SchemeGroupVersion.WithKind("Egress").String():                 egressValidator,
 			policyv1alpha1.SchemeGroupVersion.WithKind("UpstreamTrafficSetting").String(): kv.upstreamTrafficSettingValidator,
+			policyv1alpha1.SchemeGroupVersion.WithKind("HTTPFilterExtension").String():    httpFilterExtensionValidator,
 			smiAccess.SchemeGroupVersion.WithKind("TrafficTarget").String():               trafficTargetValidator,
 		},
 	}
@@ -19,8 +19,10 @@ import (
 	"github.com/openservicemesh/osm/pkg/compute"
 
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/identity"
 	"github.com/openservicemesh/osm/pkg/policy"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
 )
 
 // validateFunc is a function type that accepts an AdmissionRequest and returns an AdmissionResponse.
@@ -82,6 +84,15 @@ func trafficTargetValidator(req *admissionv1.AdmissionRequest) (*admissionv1.Adm
 			trafficTarget.Namespace, trafficTarget.Spec.Destination.Namespace)
 	}
 
+	for _, source := range trafficTarget.Spec.Sources {
+		if source.Kind != smi.SPIFFEIDKind {
+			continue
+		}
+		if _, err := identity.NewFromSPIFFEID(source.Name); err != nil {
+			return nil, fmt.Errorf("Invalid source %s of kind %s: %w", source.Name, source.Kind, err)
+		}
+	}
+
 	return nil, nil
 }
 
@@ -266,3 +277,67 @@ func (kc *policyValidator) upstreamTrafficSettingValidator(req *admissionv1.Admi
 
 	return nil, nil
 }
+
+// httpFilterExtensionValidator validates the HTTPFilterExtension custom resource
+func httpFilterExtensionValidator(req *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	extension := &policyv1alpha1.HTTPFilterExtension{}
+	if err := json.NewDecoder(bytes.NewBuffer(req.Object.Raw)).Decode(extension); err != nil {
+		return nil, err
+	}
+
+	specPath := field.NewPath("spec")
+	switch extension.Spec.Direction {
+	case "", policyv1alpha1.HTTPFilterDirectionInbound, policyv1alpha1.HTTPFilterDirectionOutbound, policyv1alpha1.HTTPFilterDirectionBoth:
+	default:
+		return nil, field.NotSupported(specPath.Child("direction"), extension.Spec.Direction,
+			[]string{string(policyv1alpha1.HTTPFilterDirectionInbound), string(policyv1alpha1.HTTPFilterDirectionOutbound), string(policyv1alpha1.HTTPFilterDirectionBoth)})
+	}
+	switch extension.Spec.Position {
+	case "", policyv1alpha1.HTTPFilterPositionFirst, policyv1alpha1.HTTPFilterPositionLast:
+	default:
+		return nil, field.NotSupported(specPath.Child("position"), extension.Spec.Position,
+			[]string{string(policyv1alpha1.HTTPFilterPositionFirst), string(policyv1alpha1.HTTPFilterPositionLast)})
+	}
+
+	if len(extension.Spec.Filters) == 0 {
+		return nil, field.Required(specPath.Child("filters"), "at least one filter must be specified")
+	}
+
+	names := mapset.NewSet()
+	for i, filter := range extension.Spec.Filters {
+		filterPath := specPath.Child("filters").Index(i)
+		if filter.Name == "" {
+			return nil, field.Required(filterPath.Child("name"), "filter name must be specified")
+		}
+		if !names.Add(filter.Name) {
+			return nil, field.Duplicate(filterPath.Child("name"), filter.Name)
+		}
+
+		switch filter.Type {
+		case policyv1alpha1.HTTPFilterTypeWASM:
+			if filter.WASM == nil {
+				return nil, field.Required(filterPath.Child("wasm"), "wasm config must be specified for filters of type wasm")
+			}
+			if filter.WASM.ConfigMapRef.Name == "" || filter.WASM.ConfigMapRef.Key == "" {
+				return nil, field.Required(filterPath.Child("wasm", "configMapRef"), "name and key of the ConfigMap holding the WASM module must be specified")
+			}
+			if filter.Lua != nil {
+				return nil, field.Forbidden(filterPath.Child("lua"), "lua config may not be specified for filters of type wasm")
+			}
+
+		case policyv1alpha1.HTTPFilterTypeLua:
+			if filter.Lua == nil || filter.Lua.InlineCode == "" {
+				return nil, field.Required(filterPath.Child("lua", "inlineCode"), "inline code must be specified for filters of type lua")
+			}
+			if filter.WASM != nil {
+				return nil, field.Forbidden(filterPath.Child("wasm"), "wasm config may not be specified for filters of type lua")
+			}
+
+		default:
+			return nil, field.NotSupported(filterPath.Child("type"), filter.Type,
+				[]string{string(policyv1alpha1.HTTPFilterTypeWASM), string(policyv1alpha1.HTTPFilterTypeLua)})
+		}
+	}
+
+	return nil, nil
+}
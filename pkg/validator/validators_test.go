@@ -1028,6 +1028,46 @@ func TestTrafficTargetValidator(t *testing.T) {
 			expResp:   nil,
 			expErrStr: "The traffic target namespace (another-namespace) must match spec.Destination.Namespace (destination-namespace)",
 		},
+		{
+			name: "TrafficTarget source of a federated trust domain has an invalid SPIFFE ID",
+			input: &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha3",
+					Version: "access.smi-spec.io",
+					Kind:    "TrafficTarget",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`
+					{
+						"apiVersion": "v1alpha3",
+						"kind": "TrafficTarget",
+						"metadata": {
+							"namespace": "destination-namespace"
+						},
+						"spec": {
+							"destination": {
+								"kind": "ServiceAccount",
+								"name": "destination-name",
+								"namespace": "destination-namespace"
+							},
+							"sources": [
+								{
+									"kind": "SPIFFEID",
+									"name": "spiffe://west.mesh/ns/source-namespace/sa/source-name"
+								},
+								{
+									"kind": "SPIFFEID",
+									"name": "source-name.source-namespace"
+								}
+							]
+						}
+					}
+					`),
+				},
+			},
+			expResp:   nil,
+			expErrStr: "Invalid source source-name.source-namespace of kind SPIFFEID: SPIFFE ID must be of the form spiffe://<trust-domain>/ns/<namespace>/sa/<service-account>: source-name.source-namespace",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1350,3 +1390,86 @@ func TestUpstreamTrafficSettingValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPFilterExtensionValidator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      string
+		expErrStr string
+	}{
+		{
+			name: "valid wasm and lua filters",
+			spec: `{
+				"direction": "both",
+				"position": "first",
+				"filters": [
+					{"name": "auth", "type": "wasm", "wasm": {"configMapRef": {"name": "modules", "key": "auth.wasm"}}},
+					{"name": "headers", "type": "lua", "lua": {"inlineCode": "function envoy_on_request(h) end"}}
+				]
+			}`,
+		},
+		{
+			name:      "unknown filter type",
+			spec:      `{"filters": [{"name": "auth", "type": "golang"}]}`,
+			expErrStr: `spec.filters[0].type: Unsupported value: "golang": supported values: "wasm", "lua"`,
+		},
+		{
+			name:      "unknown direction",
+			spec:      `{"direction": "sideways", "filters": [{"name": "headers", "type": "lua", "lua": {"inlineCode": "-- noop"}}]}`,
+			expErrStr: `spec.direction: Unsupported value: "sideways": supported values: "inbound", "outbound", "both"`,
+		},
+		{
+			name:      "unknown position",
+			spec:      `{"position": "middle", "filters": [{"name": "headers", "type": "lua", "lua": {"inlineCode": "-- noop"}}]}`,
+			expErrStr: `spec.position: Unsupported value: "middle": supported values: "first", "last"`,
+		},
+		{
+			name:      "no filters",
+			spec:      `{"filters": []}`,
+			expErrStr: "spec.filters: Required value: at least one filter must be specified",
+		},
+		{
+			name: "duplicate filter names",
+			spec: `{"filters": [
+				{"name": "headers", "type": "lua", "lua": {"inlineCode": "-- noop"}},
+				{"name": "headers", "type": "lua", "lua": {"inlineCode": "-- noop"}}
+			]}`,
+			expErrStr: `spec.filters[1].name: Duplicate value: "headers"`,
+		},
+		{
+			name:      "wasm filter without module",
+			spec:      `{"filters": [{"name": "auth", "type": "wasm"}]}`,
+			expErrStr: "spec.filters[0].wasm: Required value: wasm config must be specified for filters of type wasm",
+		},
+		{
+			name:      "lua filter with wasm config",
+			spec:      `{"filters": [{"name": "auth", "type": "lua", "lua": {"inlineCode": "-- noop"}, "wasm": {"configMapRef": {"name": "modules", "key": "auth.wasm"}}}]}`,
+			expErrStr: "spec.filters[0].wasm: Forbidden: wasm config may not be specified for filters of type lua",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := tassert.New(t)
+
+			input := &admissionv1.AdmissionRequest{
+				Kind: metav1.GroupVersionKind{
+					Group:   "v1alpha1",
+					Version: "policy.openservicemesh.io",
+					Kind:    "HTTPFilterExtension",
+				},
+				Object: runtime.RawExtension{
+					Raw: []byte(`{"apiVersion": "v1alpha1", "kind": "HTTPFilterExtension", "spec": ` + tc.spec + `}`),
+				},
+			}
+
+			resp, err := httpFilterExtensionValidator(input)
+			assert.Nil(resp)
+			if tc.expErrStr == "" {
+				assert.NoError(err)
+			} else {
+				assert.EqualError(err, tc.expErrStr)
+			}
+		})
+	}
+}